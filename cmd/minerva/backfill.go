@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fortuna/minerva/internal/backfill"
+	"github.com/fortuna/minerva/internal/quality"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+func newBackfillCommand() *command {
+	var season, seasons, startDate, endDate, gameID string
+	var dryRun, restart bool
+	var requestsPerMinute int
+	var dateDelay time.Duration
+
+	return &command{
+		name:    "backfill",
+		summary: "Backfill historical games and box scores from ESPN",
+		usage:   "(--season S | --seasons A..B | --start D --end D | --game ID)",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&season, "season", "", "Season to backfill (e.g., 2024-25)")
+			fs.StringVar(&seasons, "seasons", "", "Seasons to bulk import with checkpoints (e.g., 2015-16..2024-25)")
+			fs.BoolVar(&restart, "restart", false, "With --seasons, ignore checkpoints and reimport completed seasons")
+			fs.StringVar(&startDate, "start", "", "Start date (YYYY-MM-DD)")
+			fs.StringVar(&endDate, "end", "", "End date (YYYY-MM-DD)")
+			fs.StringVar(&gameID, "game", "", "Single ESPN game ID to backfill")
+			fs.BoolVar(&dryRun, "dry-run", false, "Report new vs existing games without writing")
+			fs.IntVar(&requestsPerMinute, "rpm", 0, "Max ESPN requests per minute (0 = unlimited)")
+			fs.DurationVar(&dateDelay, "date-delay", 0, "Pause between scoreboard dates (e.g., 2s)")
+		},
+		run: func(ctx context.Context, args []string) error {
+			rateLimit := backfill.RateLimit{RequestsPerMinute: requestsPerMinute, DateDelay: dateDelay}
+			if seasons != "" {
+				if dryRun {
+					return fmt.Errorf("--dry-run is not supported with --seasons")
+				}
+				return runBulkImport(ctx, seasons, backfill.BulkOptions{RateLimit: rateLimit, Restart: restart})
+			}
+
+			if season == "" && startDate == "" && gameID == "" {
+				return fmt.Errorf("specify --season, --seasons, --start/--end, or --game")
+			}
+
+			spec, err := backfill.BuildSpec(season, startDate, endDate, gameID)
+			if err != nil {
+				return fmt.Errorf("build spec: %w", err)
+			}
+			spec.DryRun = dryRun
+			spec.RateLimit = rateLimit
+
+			config := loadConfig()
+			db, err := openDatabase(config)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			runner := newBackfillRunner(config, db)
+			if err := runner.Run(ctx, spec, backfill.NewConsoleReporter(dryRun)); err != nil {
+				return fmt.Errorf("backfill failed: %w", err)
+			}
+
+			log.Println("✓ Backfill completed successfully")
+			return nil
+		},
+	}
+}
+
+// runBulkImport imports a range of seasons, resuming from saved checkpoints
+func runBulkImport(ctx context.Context, seasonRange string, opts backfill.BulkOptions) error {
+	seasons, err := backfill.ParseSeasonRange(seasonRange)
+	if err != nil {
+		return err
+	}
+
+	config := loadConfig()
+	db, err := openDatabase(config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	importer := backfill.NewBulkImporter(db, newBackfillRunner(config, db))
+	reports, err := importer.Run(ctx, seasons, opts)
+	backfill.LogSeasonReports(reports)
+	if err != nil {
+		return fmt.Errorf("bulk import interrupted (rerun to resume): %w", err)
+	}
+
+	for _, report := range reports {
+		if report.Status == backfill.SeasonFailed {
+			return fmt.Errorf("bulk import finished with failed seasons (rerun to resume)")
+		}
+	}
+	log.Println("✓ Bulk import completed successfully")
+	return nil
+}
+
+// newBackfillRunner creates a runner for the configured ESPN API base.
+// The default ESPN_API_BASE is the bare host; only override the client when it was customised.
+func newBackfillRunner(config Config, db *store.Database) *backfill.Runner {
+	var runner *backfill.Runner
+	if config.ESPNAPIBase != "" && config.ESPNAPIBase != "https://site.api.espn.com" {
+		runner = backfill.NewRunnerWithBaseURL(db, config.ESPNAPIBase)
+	} else {
+		runner = backfill.NewRunner(db)
+	}
+	runner.SetValidator(quality.NewValidator(db, config.Quality))
+	return runner
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// errUsage signals that usage has already been printed and the process should exit non-zero
+var errUsage = errors.New("usage")
+
+// command is a node in the CLI tree. Leaf commands set run; group commands set subcommands.
+type command struct {
+	name        string
+	summary     string
+	usage       string // argument synopsis shown after the command path
+	flags       func(fs *flag.FlagSet)
+	run         func(ctx context.Context, args []string) error
+	subcommands []*command
+}
+
+// execute dispatches args to the matching subcommand, parses flags, and runs it
+func (c *command) execute(ctx context.Context, path string, args []string) error {
+	if len(c.subcommands) > 0 && len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		for _, sub := range c.subcommands {
+			if sub.name == args[0] {
+				return sub.execute(ctx, path+" "+sub.name, args[1:])
+			}
+		}
+		if c.run == nil {
+			fmt.Fprintf(os.Stderr, "unknown command %q for %s\n\n", args[0], path)
+			c.printUsage(path, nil)
+			return errUsage
+		}
+	}
+
+	fs := flag.NewFlagSet(path, flag.ContinueOnError)
+	if c.flags != nil {
+		c.flags(fs)
+	}
+	fs.Usage = func() { c.printUsage(path, fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+
+	if c.run == nil {
+		c.printUsage(path, fs)
+		return errUsage
+	}
+
+	return c.run(ctx, fs.Args())
+}
+
+func (c *command) printUsage(path string, fs *flag.FlagSet) {
+	out := os.Stderr
+	if c.summary != "" {
+		fmt.Fprintf(out, "%s\n\n", c.summary)
+	}
+
+	if len(c.subcommands) > 0 {
+		fmt.Fprintf(out, "Usage:\n  %s <command> [flags]\n\nCommands:\n", path)
+		for _, sub := range c.subcommands {
+			fmt.Fprintf(out, "  %-12s %s\n", sub.name, sub.summary)
+		}
+		return
+	}
+
+	fmt.Fprintf(out, "Usage:\n  %s [flags] %s\n", path, c.usage)
+	if fs != nil {
+		fmt.Fprintln(out, "\nFlags:")
+		fs.SetOutput(out)
+		fs.PrintDefaults()
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/ingest/espn"
+)
+
+func newDraftCommand() *command {
+	return &command{
+		name:    "draft",
+		summary: "Draft history maintenance",
+		subcommands: []*command{
+			newDraftSyncCommand(),
+		},
+	}
+}
+
+func newDraftSyncCommand() *command {
+	var years string
+	var delay time.Duration
+
+	return &command{
+		name:    "sync",
+		summary: "Backfill player draft year, round, pick, and team from ESPN",
+		usage:   "--years YYYY[..YYYY]",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&years, "years", "", "Draft year or inclusive range (e.g., 2024 or 2003..2024)")
+			fs.DurationVar(&delay, "delay", time.Second, "Pause between draft years")
+		},
+		run: func(ctx context.Context, args []string) error {
+			first, last, err := parseYearRange(years)
+			if err != nil {
+				return err
+			}
+
+			config := loadConfig()
+			db, err := openDatabase(config)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			// The default ESPN_API_BASE is the bare host; only override the client when it was customised
+			ingester := espn.NewIngester(db)
+			if config.ESPNAPIBase != "" && config.ESPNAPIBase != "https://site.api.espn.com" {
+				ingester = espn.NewIngesterWithBaseURL(db, config.ESPNAPIBase)
+			}
+
+			failed := 0
+			for year := first; year <= last; year++ {
+				if year > first && delay > 0 {
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case <-time.After(delay):
+					}
+				}
+
+				result, err := ingester.SyncDraft(ctx, year)
+				if err != nil {
+					log.Printf("  ⚠️  %d draft: %v", year, err)
+					failed++
+					continue
+				}
+				log.Printf("  ✓ %s", result)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d draft years failed", failed, last-first+1)
+			}
+			return nil
+		},
+	}
+}
+
+// parseYearRange reads "2024" or "2003..2024"
+func parseYearRange(value string) (int, int, error) {
+	if value == "" {
+		return 0, 0, fmt.Errorf("--years is required")
+	}
+	from, to, isRange := strings.Cut(value, "..")
+	if !isRange {
+		to = from
+	}
+	first, err := strconv.Atoi(strings.TrimSpace(from))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --years %q", value)
+	}
+	last, err := strconv.Atoi(strings.TrimSpace(to))
+	if err != nil || last < first {
+		return 0, 0, fmt.Errorf("invalid --years %q", value)
+	}
+	return first, last, nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fortuna/minerva/internal/publisher"
+)
+
+// maxCapturedMessage bounds one line of a capture file
+const maxCapturedMessage = 4 << 20
+
+func newEventsCommand() *command {
+	return &command{
+		name:    "events",
+		summary: "Check stream messages against their published schemas",
+		subcommands: []*command{
+			newEventsValidateCommand(),
+			newEventsSchemaCommand(),
+		},
+	}
+}
+
+func newEventsValidateCommand() *command {
+	var stream string
+
+	return &command{
+		name:    "validate",
+		summary: "Validate captured stream messages (JSON Lines) against their schema",
+		usage:   "[--stream games.live.basketball_nba] [FILE...]",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&stream, "stream", "", `Stream the messages came from; not needed for {"stream", "data"} entries`)
+		},
+		run: func(ctx context.Context, args []string) error {
+			if stream != "" {
+				if _, err := publisher.SchemaDocument(stream); err != nil {
+					return err
+				}
+			}
+			if len(args) == 0 {
+				args = []string{"-"}
+			}
+
+			var checked, invalid int
+			for _, name := range args {
+				c, i, err := validateCapture(name, stream)
+				if err != nil {
+					return err
+				}
+				checked += c
+				invalid += i
+			}
+
+			if invalid > 0 {
+				return fmt.Errorf("%d of %d messages do not match their schema", invalid, checked)
+			}
+			log.Printf("✓ %d messages match their schema", checked)
+			return nil
+		},
+	}
+}
+
+// validateCapture checks each line of a capture file ("-" for stdin). A line is
+// either a message payload or an entry {"stream": ..., "data": "<payload>"}.
+func validateCapture(name, stream string) (checked, invalid int, err error) {
+	var in io.Reader = os.Stdin
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return 0, 0, err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxCapturedMessage)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		msgStream, data := capturedMessage([]byte(text), stream)
+		if msgStream == "" {
+			return checked, invalid, fmt.Errorf("%s:%d: message names no stream; pass --stream", name, line)
+		}
+
+		checked++
+		if err := publisher.ValidateMessage(msgStream, data); err != nil {
+			var invalidErr *publisher.ValidationError
+			if !errors.As(err, &invalidErr) {
+				return checked, invalid, fmt.Errorf("%s:%d: %w", name, line, err)
+			}
+			invalid++
+			log.Printf("✗ %s:%d (%s)", name, line, msgStream)
+			for _, problem := range invalidErr.Problems {
+				log.Printf("    %s", problem)
+			}
+		}
+	}
+	return checked, invalid, scanner.Err()
+}
+
+// capturedMessage unwraps a {"stream", "data"} entry, or returns the line as a
+// payload on the default stream
+func capturedMessage(line []byte, stream string) (string, []byte) {
+	var entry struct {
+		Stream string           `json:"stream"`
+		Data   *json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(line, &entry) != nil || entry.Data == nil {
+		return stream, line
+	}
+	var payload string
+	if json.Unmarshal(*entry.Data, &payload) != nil {
+		return stream, line
+	}
+	if entry.Stream != "" {
+		stream = entry.Stream
+	}
+	return stream, []byte(payload)
+}
+
+func newEventsSchemaCommand() *command {
+	return &command{
+		name:    "schema",
+		summary: "Print a stream's JSON Schema, or list the streams that have one",
+		usage:   "[STREAM]",
+		run: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				for _, family := range publisher.SchemaStreams() {
+					fmt.Printf("%s.{sport}\n", family)
+				}
+				return nil
+			}
+
+			doc, err := publisher.SchemaDocument(args[0])
+			if err != nil {
+				return err
+			}
+			_, err = os.Stdout.Write(doc)
+			return err
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/service"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+func newExportCommand() *command {
+	return &command{
+		name:    "export",
+		summary: "Write bulk datasets to files",
+		subcommands: []*command{
+			newExportMLFeaturesCommand(),
+		},
+	}
+}
+
+func newExportMLFeaturesCommand() *command {
+	var seasons, league, format, out, asOfDate string
+
+	return &command{
+		name:    "ml-features",
+		summary: "Export the player-game feature matrix of one or more seasons for model training",
+		usage:   "--season S[,S...] [--as-of YYYY-MM-DD] [--format csv|ndjson] [--out FILE]",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&seasons, "season", "", "Comma-separated seasons (e.g., 2023-24,2024-25)")
+			fs.StringVar(&league, "league", store.LeagueNBA, "League key")
+			fs.StringVar(&format, "format", "csv", "csv or ndjson")
+			fs.StringVar(&out, "out", "", "Output file (default stdout)")
+			fs.StringVar(&asOfDate, "as-of", "", "Leave out games on or after this date (YYYY-MM-DD)")
+		},
+		run: func(ctx context.Context, args []string) error {
+			if seasons == "" {
+				return fmt.Errorf("--season is required")
+			}
+			if format != "csv" && format != "ndjson" {
+				return fmt.Errorf("invalid --format %q: use csv or ndjson", format)
+			}
+			var asOf time.Time
+			if asOfDate != "" {
+				var err error
+				if asOf, err = time.Parse("2006-01-02", asOfDate); err != nil {
+					return fmt.Errorf("invalid --as-of: %w", err)
+				}
+			}
+
+			db, err := openDatabase(loadConfig())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			var selected []service.FeatureSeason
+			for _, year := range strings.Split(seasons, ",") {
+				year = strings.TrimSpace(year)
+				var seasonID int
+				query := `SELECT season_id FROM seasons WHERE season_year = $1 AND sport = 'basketball_nba' AND league = $2 LIMIT 1`
+				if err := db.DB().QueryRowContext(ctx, query, year, league).Scan(&seasonID); err != nil {
+					return fmt.Errorf("season '%s' not found in database: %w", year, err)
+				}
+				selected = append(selected, service.FeatureSeason{ID: seasonID, Year: year})
+			}
+
+			var w io.Writer = os.Stdout
+			if out != "" {
+				f, err := os.Create(out)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+
+			rows := 0
+			matrix := service.NewFeatureMatrixService(db)
+			if format == "ndjson" {
+				enc := json.NewEncoder(w)
+				err = matrix.Build(ctx, selected, asOf, func(row *service.FeatureRow) error {
+					rows++
+					return enc.Encode(row)
+				})
+			} else {
+				cw := csv.NewWriter(w)
+				cw.Write(service.FeatureMatrixColumns)
+				err = matrix.Build(ctx, selected, asOf, func(row *service.FeatureRow) error {
+					rows++
+					return cw.Write(row.Record())
+				})
+				cw.Flush()
+				if err == nil {
+					err = cw.Error()
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			if out != "" {
+				log.Printf("✓ Wrote %d player-games to %s", rows, out)
+			}
+			return nil
+		},
+	}
+}
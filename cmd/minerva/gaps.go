@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fortuna/minerva/internal/backfill"
+	"github.com/fortuna/minerva/internal/maintenance"
+)
+
+func newGapsCommand() *command {
+	return &command{
+		name:    "gaps",
+		summary: "Find holes in stored game data",
+		subcommands: []*command{
+			newGapsScanCommand(),
+		},
+	}
+}
+
+func newGapsScanCommand() *command {
+	var season, startDate, endDate, sport string
+
+	return &command{
+		name:    "scan",
+		summary: "List final games missing box scores and dates with no games",
+		usage:   "(--season S | --start D --end D)",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&season, "season", "", "Season to scan (e.g., 2024-25)")
+			fs.StringVar(&startDate, "start", "", "Start date (YYYY-MM-DD)")
+			fs.StringVar(&endDate, "end", "", "End date (YYYY-MM-DD)")
+			fs.StringVar(&sport, "sport", "basketball_nba", "Sport key")
+		},
+		run: func(ctx context.Context, args []string) error {
+			var start, end time.Time
+			switch {
+			case season != "":
+				start, end = backfill.SeasonWindow(season)
+			case startDate != "" && endDate != "":
+				var err error
+				if start, err = time.Parse("2006-01-02", startDate); err != nil {
+					return fmt.Errorf("invalid --start: %w", err)
+				}
+				if end, err = time.Parse("2006-01-02", endDate); err != nil {
+					return fmt.Errorf("invalid --end: %w", err)
+				}
+			default:
+				return fmt.Errorf("specify --season or --start/--end")
+			}
+
+			// Never report future dates as gaps
+			if today := time.Now().UTC().Truncate(24 * time.Hour); end.After(today) {
+				end = today
+			}
+
+			db, err := openDatabase(loadConfig())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			report, err := maintenance.NewGapScanner(db).Scan(ctx, sport, start, end)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("Gap scan %s → %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+			log.Printf("Final games missing stats: %d", len(report.MissingStats))
+			for _, m := range report.MissingStats {
+				log.Printf("  %s  game %d (espn %s)  player_stats_missing=%v team_stats_missing=%v",
+					m.GameDate.Format("2006-01-02"), m.GameID, m.ExternalID, m.MissingPlayerStats, m.MissingTeamStats)
+			}
+
+			log.Printf("Dates without games: %d", len(report.DatesWithoutGames))
+			for _, d := range report.DatesWithoutGames {
+				log.Printf("  %s", d.Format("2006-01-02"))
+			}
+
+			if len(report.MissingStats) > 0 {
+				log.Println("⚠️  Re-run `minerva backfill --game <espn id>` for the games above")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/backfill"
+	"github.com/fortuna/minerva/internal/maintenance"
+)
+
+func newMaintenanceCommand() *command {
+	return &command{
+		name:    "maintenance",
+		summary: "Targeted repairs of stored game data",
+		subcommands: []*command{
+			newBackfillTeamStatsCommand(),
+		},
+	}
+}
+
+func newBackfillTeamStatsCommand() *command {
+	var season, startDate, endDate, sport, source string
+	var delay time.Duration
+	var dryRun bool
+
+	return &command{
+		name:    "backfill-team-stats",
+		summary: "Fill team box score rows for final games missing them",
+		usage:   "(--season S | --start D --end D)",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&season, "season", "", "Season to backfill (e.g., 2019-20)")
+			fs.StringVar(&startDate, "start", "", "Start date (YYYY-MM-DD)")
+			fs.StringVar(&endDate, "end", "", "End date (YYYY-MM-DD)")
+			fs.StringVar(&sport, "sport", "basketball_nba", "Sport key")
+			fs.StringVar(&source, "source", maintenance.TeamStatsFromAuto, "espn (refetch box scores), player-stats (sum stored player lines), or auto (espn, then player-stats)")
+			fs.DurationVar(&delay, "delay", 500*time.Millisecond, "Pause between ESPN fetches")
+			fs.BoolVar(&dryRun, "dry-run", false, "Report coverage and missing games without writing")
+		},
+		run: func(ctx context.Context, args []string) error {
+			var start, end time.Time
+			switch {
+			case season != "":
+				start, end = backfill.SeasonWindow(season)
+			case startDate != "" && endDate != "":
+				var err error
+				if start, err = time.Parse("2006-01-02", startDate); err != nil {
+					return fmt.Errorf("invalid --start: %w", err)
+				}
+				if end, err = time.Parse("2006-01-02", endDate); err != nil {
+					return fmt.Errorf("invalid --end: %w", err)
+				}
+			default:
+				return fmt.Errorf("specify --season or --start/--end")
+			}
+
+			db, err := openDatabase(loadConfig())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			report, err := maintenance.NewTeamStatsBackfill(db).Run(ctx, sport, start, end, maintenance.TeamStatsBackfillOptions{
+				Source: strings.ReplaceAll(source, "-", "_"),
+				Delay:  delay,
+				DryRun: dryRun,
+			})
+			if err != nil {
+				return err
+			}
+
+			log.Printf("Team stats backfill %s → %s (source %s)", start.Format("2006-01-02"), end.Format("2006-01-02"), report.Source)
+			log.Printf("Coverage before: %d/%d final games (%.1f%%)", report.Before.Covered, report.Before.FinalGames, report.Before.Pct)
+			if dryRun {
+				log.Printf("Games missing team stats: %d", len(report.Games))
+				for _, g := range report.Games {
+					log.Printf("  %s  game %d (%s)", g.GameDate.Format("2006-01-02"), g.GameID, g.ExternalID)
+				}
+				return nil
+			}
+
+			log.Printf("Filled from ESPN: %d, from player stats: %d", report.FromESPN, report.FromPlayerStats)
+			log.Printf("Coverage after: %d/%d final games (%.1f%%)", report.After.Covered, report.After.FinalGames, report.After.Pct)
+
+			missing := report.StillMissing()
+			if len(missing) == 0 {
+				log.Println("✓ Team stats backfill completed")
+				return nil
+			}
+			log.Printf("Still missing: %d", len(missing))
+			for _, g := range missing {
+				log.Printf("  %s  game %d (%s): %s", g.GameDate.Format("2006-01-02"), g.GameID, g.ExternalID, g.Error)
+			}
+			return fmt.Errorf("%d games still missing team stats", len(missing))
+		},
+	}
+}
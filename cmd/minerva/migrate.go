@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+func newMigrateCommand() *command {
+	return &command{
+		name:    "migrate",
+		summary: "Inspect and apply Atlas schema migrations",
+		subcommands: []*command{
+			{
+				name:    "up",
+				summary: "Apply all pending migrations",
+				run: func(ctx context.Context, args []string) error {
+					db, err := openDatabase(loadConfig())
+					if err != nil {
+						return err
+					}
+					defer db.Close()
+
+					return db.RunMigrations()
+				},
+			},
+			{
+				name:    "status",
+				summary: "Show applied vs pending migrations",
+				run:     runMigrateStatus,
+			},
+			newMigrateDownCommand(),
+			{
+				name:    "plans",
+				summary: "EXPLAIN hot stats queries and fail if any needs a sequential scan",
+				run:     runMigratePlans,
+			},
+			{
+				name:    "queries",
+				summary: "Check repository column lists against the live schema",
+				run:     runMigrateQueries,
+			},
+		},
+	}
+}
+
+func runMigrateStatus(ctx context.Context, args []string) error {
+	config := loadConfig()
+	db, err := openDatabase(config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	states, err := db.MigrationStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	source := "embedded"
+	if config.MigrationsDir != "" {
+		source = config.MigrationsDir
+	}
+	fmt.Printf("Migrations source: %s\n\n", source)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tSTATUS\tAPPLIED AT\tDOWN")
+
+	pending := 0
+	for _, s := range states {
+		status, appliedAt := "pending", "-"
+		if s.Applied {
+			status = "applied"
+			appliedAt = s.AppliedAt.Time.Format("2006-01-02 15:04:05")
+		} else {
+			pending++
+		}
+		down := "no"
+		if s.Reversible {
+			down = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.Version, status, appliedAt, down)
+	}
+	tw.Flush()
+
+	fmt.Printf("\n%d applied, %d pending\n", len(states)-pending, pending)
+	return nil
+}
+
+// runMigratePlans guards the indexes hot queries depend on; run it in CI after `migrate up`
+func runMigratePlans(ctx context.Context, args []string) error {
+	db, err := openDatabase(loadConfig())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	failed := 0
+	for _, check := range repository.HotQueryPlans() {
+		scans, err := db.SeqScans(ctx, check)
+		if err != nil {
+			return err
+		}
+		if len(scans) > 0 {
+			failed++
+			log.Printf("❌ %s: sequential scan on %s", check.Name, strings.Join(scans, ", "))
+			continue
+		}
+		log.Printf("✓ %s: served by indexes", check.Name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d hot queries fall back to sequential scans; add or restore their indexes", failed)
+	}
+	return nil
+}
+
+// runMigrateQueries catches repository column sets left behind by a schema
+// change; run it in CI after `migrate up`
+func runMigrateQueries(ctx context.Context, args []string) error {
+	db, err := openDatabase(loadConfig())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	failed := 0
+	for _, check := range repository.ColumnSetChecks() {
+		if err := db.Explain(ctx, check); err != nil {
+			failed++
+			log.Printf("❌ %v", err)
+			continue
+		}
+		log.Printf("✓ %s: matches schema", check.Name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d repository column sets don't match the schema", failed)
+	}
+	return nil
+}
+
+func newMigrateDownCommand() *command {
+	var target, backup string
+	var backupMaxAge time.Duration
+	var yes bool
+
+	return &command{
+		name:    "down",
+		summary: "Roll back applied migrations newer than a version",
+		usage:   "--to VERSION --backup FILE [--yes]",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&target, "to", "", "Version to roll back to (kept applied), e.g. 021")
+			fs.StringVar(&backup, "backup", "", "Path to a recent database dump (pg_dump) taken before rolling back")
+			fs.DurationVar(&backupMaxAge, "backup-max-age", 24*time.Hour, "Reject backups older than this")
+			fs.BoolVar(&yes, "yes", false, "Skip the interactive confirmation")
+		},
+		run: func(ctx context.Context, args []string) error {
+			if target == "" {
+				return fmt.Errorf("--to is required")
+			}
+			if err := checkBackup(backup, backupMaxAge); err != nil {
+				return err
+			}
+
+			db, err := openDatabase(loadConfig())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			plan, err := db.PlanDown(ctx, target)
+			if err != nil {
+				return err
+			}
+			if len(plan) == 0 {
+				log.Printf("Nothing to roll back; no applied migrations after %s", target)
+				return nil
+			}
+
+			fmt.Println("The following migrations will be rolled back (data in dropped tables/columns is lost):")
+			for _, m := range plan {
+				fmt.Printf("  %s\n", m.Version)
+			}
+			fmt.Printf("Backup: %s\n", backup)
+
+			if !yes && !confirm(fmt.Sprintf("Type %q to continue: ", target), target) {
+				return fmt.Errorf("rollback aborted")
+			}
+
+			if err := db.MigrateDown(ctx, plan); err != nil {
+				return err
+			}
+
+			log.Printf("✓ Rolled back %d migrations; schema is at %s", len(plan), target)
+			return nil
+		},
+	}
+}
+
+// checkBackup refuses destructive operations unless a fresh, non-empty dump exists
+func checkBackup(path string, maxAge time.Duration) error {
+	if path == "" {
+		return fmt.Errorf("--backup is required; take a dump first (pg_dump \"$ATLAS_DSN\" > atlas.sql)")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("backup check failed: %w", err)
+	}
+	if info.IsDir() || info.Size() == 0 {
+		return fmt.Errorf("backup check failed: %s is empty or not a file", path)
+	}
+	if age := time.Since(info.ModTime()); age > maxAge {
+		return fmt.Errorf("backup check failed: %s is %v old (max %v)", path, age.Round(time.Minute), maxAge)
+	}
+	return nil
+}
+
+// confirm reads a line from stdin and reports whether it matches the expected answer
+func confirm(prompt, expected string) bool {
+	fmt.Print(prompt)
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(line) == expected
+}
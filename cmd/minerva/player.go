@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+func newPlayerCommand() *command {
+	return &command{
+		name:    "player",
+		summary: "Player record maintenance",
+		subcommands: []*command{
+			newPlayerMergeCommand(),
+			newPlayerAliasCommand(),
+		},
+	}
+}
+
+func newPlayerMergeCommand() *command {
+	var fromID, intoID int
+
+	return &command{
+		name:    "merge",
+		summary: "Merge a duplicate player into another and delete the duplicate",
+		usage:   "--from ID --into ID",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&fromID, "from", 0, "Duplicate player_id to remove")
+			fs.IntVar(&intoID, "into", 0, "player_id that keeps the merged records")
+		},
+		run: func(ctx context.Context, args []string) error {
+			if fromID <= 0 || intoID <= 0 {
+				return fmt.Errorf("both --from and --into are required")
+			}
+
+			db, err := openDatabase(loadConfig())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			result, err := repository.NewPlayerRepository(db).Merge(ctx, fromID, intoID)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("✓ Merged player %d into %d", fromID, intoID)
+			log.Printf("  game stats moved: %d (dropped %d duplicates)", result.GameStatsMoved, result.GameStatsDropped)
+			log.Printf("  team history moved: %d", result.TeamHistoryMoved)
+			log.Printf("  odds mappings moved: %d", result.OddsMappingsMoved)
+			log.Printf("  aliases moved: %d, added from the duplicate's names: %d", result.AliasesMoved, result.AliasesAdded)
+			return nil
+		},
+	}
+}
+
+func newPlayerAliasCommand() *command {
+	return &command{
+		name:    "alias",
+		summary: "Manage alternate player names used by search and ingestion",
+		subcommands: []*command{
+			newPlayerAliasListCommand(),
+			newPlayerAliasAddCommand(),
+			newPlayerAliasRemoveCommand(),
+		},
+	}
+}
+
+func newPlayerAliasListCommand() *command {
+	var playerID int
+
+	return &command{
+		name:    "list",
+		summary: "List a player's aliases",
+		usage:   "--player ID",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&playerID, "player", 0, "player_id")
+		},
+		run: func(ctx context.Context, args []string) error {
+			if playerID <= 0 {
+				return fmt.Errorf("--player is required")
+			}
+
+			db, err := openDatabase(loadConfig())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			aliases, err := repository.NewPlayerRepository(db).GetAliases(ctx, playerID)
+			if err != nil {
+				return err
+			}
+			if len(aliases) == 0 {
+				log.Printf("Player %d has no aliases", playerID)
+			}
+			for _, a := range aliases {
+				log.Printf("  %-5d %-30s %-16s %s", a.AliasID, a.Alias, a.AliasType, a.Source)
+			}
+			return nil
+		},
+	}
+}
+
+func newPlayerAliasAddCommand() *command {
+	var playerID int
+	var alias, aliasType string
+
+	return &command{
+		name:    "add",
+		summary: "Add an alias to a player",
+		usage:   "--player ID --alias NAME [--type nickname|spelling|transliteration]",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&playerID, "player", 0, "player_id")
+			fs.StringVar(&alias, "alias", "", "Alternate name")
+			fs.StringVar(&aliasType, "type", store.PlayerAliasNickname, "nickname, spelling, or transliteration")
+		},
+		run: func(ctx context.Context, args []string) error {
+			if playerID <= 0 || alias == "" {
+				return fmt.Errorf("both --player and --alias are required")
+			}
+			switch aliasType {
+			case store.PlayerAliasNickname, store.PlayerAliasSpelling, store.PlayerAliasTransliteration:
+			default:
+				return fmt.Errorf("invalid --type %q", aliasType)
+			}
+
+			db, err := openDatabase(loadConfig())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			a := &store.PlayerAlias{PlayerID: playerID, Alias: alias, AliasType: aliasType}
+			if err := repository.NewPlayerRepository(db).AddAlias(ctx, a); err != nil {
+				return err
+			}
+			log.Printf("✓ Added alias %d %q to player %d", a.AliasID, a.Alias, playerID)
+			return nil
+		},
+	}
+}
+
+func newPlayerAliasRemoveCommand() *command {
+	var playerID, aliasID int
+
+	return &command{
+		name:    "remove",
+		summary: "Remove one of a player's aliases",
+		usage:   "--player ID --id ALIAS_ID",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&playerID, "player", 0, "player_id")
+			fs.IntVar(&aliasID, "id", 0, "alias_id, from player alias list")
+		},
+		run: func(ctx context.Context, args []string) error {
+			if playerID <= 0 || aliasID <= 0 {
+				return fmt.Errorf("both --player and --id are required")
+			}
+
+			db, err := openDatabase(loadConfig())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			if err := repository.NewPlayerRepository(db).DeleteAlias(ctx, playerID, aliasID); err != nil {
+				return err
+			}
+			log.Printf("✓ Removed alias %d from player %d", aliasID, playerID)
+			return nil
+		},
+	}
+}
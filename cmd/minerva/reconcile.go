@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fortuna/minerva/internal/ingest/google"
+	"github.com/fortuna/minerva/internal/reconciliation"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+func newReconcileCommand() *command {
+	var demo bool
+
+	return &command{
+		name:    "reconcile",
+		summary: "Exercise the ESPN/Google reconciliation engine",
+		usage:   "--demo",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&demo, "demo", false, "Run every strategy against built-in sample games")
+		},
+		run: func(ctx context.Context, args []string) error {
+			if !demo {
+				return fmt.Errorf("only --demo mode is supported; live reconciliation runs inside `minerva serve`")
+			}
+			return runReconcileDemo()
+		},
+	}
+}
+
+func runReconcileDemo() error {
+	strategies := []reconciliation.ReconciliationStrategy{
+		reconciliation.SmartMerge,
+		reconciliation.PreferLatest,
+		reconciliation.PreferAuthoritative,
+	}
+
+	for _, strategy := range strategies {
+		log.Printf("--- Strategy: %s ---", strategy)
+		engine := reconciliation.NewEngine(strategy)
+
+		cases := []struct {
+			label  string
+			espn   *store.Game
+			google *google.LiveGame
+		}{
+			{"both sources (live game)", demoESPNGame(), demoGoogleGame()},
+			{"ESPN only", demoESPNGame(), nil},
+			{"Google only", nil, demoGoogleGame()},
+		}
+
+		for _, c := range cases {
+			merged, err := engine.ReconcileGame(c.espn, c.google)
+			if err != nil {
+				return fmt.Errorf("%s/%s: %w", strategy, c.label, err)
+			}
+			log.Printf("  %-26s %s", c.label, describeGame(merged))
+		}
+
+		metrics := engine.GetMetrics()
+		log.Printf("  metrics: total=%d conflicts=%d google_preferred=%d espn_preferred=%d",
+			metrics.TotalReconciliations, metrics.Conflicts, metrics.GooglePreferred, metrics.ESPNPreferred)
+	}
+
+	log.Println("--- Conflict detection ---")
+	engine := reconciliation.NewEngine(reconciliation.SmartMerge)
+
+	googleGame := demoGoogleGame()
+	googleGame.HomeScore = 130 // 25 point gap
+	merged, err := engine.ReconcileGame(demoESPNGame(), googleGame)
+	if err != nil {
+		return fmt.Errorf("score conflict: %w", err)
+	}
+	log.Printf("  score gap:       %s (conflicts=%d)", describeGame(merged), engine.GetMetrics().Conflicts)
+
+	engine.ResetMetrics()
+	espnGame := demoESPNGame()
+	espnGame.Status = "final"
+	merged, err = engine.ReconcileGame(espnGame, demoGoogleGame())
+	if err != nil {
+		return fmt.Errorf("status conflict: %w", err)
+	}
+	log.Printf("  status mismatch: %s (conflicts=%d)", describeGame(merged), engine.GetMetrics().Conflicts)
+
+	log.Println("--- Team matching ---")
+	matcher := reconciliation.NewMatcher(store.NewTeamResolver([]*store.Team{
+		{TeamID: 13, Abbreviation: "LAL", FullName: "Los Angeles Lakers", ShortName: "Lakers"},
+		{TeamID: 2, Abbreviation: "BOS", FullName: "Boston Celtics", ShortName: "Celtics"},
+		{TeamID: 9, Abbreviation: "GSW", FullName: "Golden State Warriors", ShortName: "Warriors"},
+	}, []store.TeamAlias{{Abbreviation: "LAL", Alias: "LA Lakers"}}))
+	candidates := []google.LiveGame{
+		{HomeTeam: "Lakers", AwayTeam: "Celtics"},
+		{HomeTeam: "Los Angeles Lakers", AwayTeam: "Boston Celtics"},
+		{HomeTeam: "LA Lakers", AwayTeam: "Celtics"},
+		{HomeTeam: "Warriors", AwayTeam: "Nets"},
+	}
+	for _, candidate := range candidates {
+		result := "✗ no match"
+		if matcher.FindMatchingGoogleGame(demoESPNGame(), []google.LiveGame{candidate}) != nil {
+			result = "✓ match"
+		}
+		log.Printf("  %-34s %s", candidate.AwayTeam+" @ "+candidate.HomeTeam, result)
+	}
+
+	log.Println("✓ Reconciliation demo complete")
+	return nil
+}
+
+// demoESPNGame is Celtics @ Lakers late in the fourth quarter
+func demoESPNGame() *store.Game {
+	return &store.Game{
+		GameID:     401584894,
+		Sport:      "basketball_nba",
+		SeasonID:   1,
+		ExternalID: "401584894",
+		GameDate:   time.Now().UTC().Truncate(24 * time.Hour),
+		HomeTeamID: 13,
+		AwayTeamID: 2,
+		HomeScore:  store.NullInt32{Int32: 105, Valid: true},
+		AwayScore:  store.NullInt32{Int32: 98, Valid: true},
+		Status:     "in_progress",
+		Period:     store.NullInt32{Int32: 4, Valid: true},
+		Clock:      store.NullString{String: "2:30", Valid: true},
+	}
+}
+
+// demoGoogleGame is the same game scraped slightly later
+func demoGoogleGame() *google.LiveGame {
+	return &google.LiveGame{
+		HomeTeam:      "Lakers",
+		AwayTeam:      "Celtics",
+		HomeScore:     107,
+		AwayScore:     100,
+		GameStatus:    "Q4 2:15",
+		Period:        4,
+		TimeRemaining: "2:15",
+		IsLive:        true,
+	}
+}
+
+func describeGame(game *store.Game) string {
+	score := "-"
+	if game.HomeScore.Valid && game.AwayScore.Valid {
+		score = fmt.Sprintf("%d-%d", game.AwayScore.Int32, game.HomeScore.Int32)
+	}
+	return fmt.Sprintf("score=%s status=%s period=%d clock=%s", score, game.Status, game.Period.Int32, game.Clock.String)
+}
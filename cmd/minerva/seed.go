@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+)
+
+func newSeedCommand() *command {
+	var updateTeams bool
+
+	return &command{
+		name:    "seed",
+		summary: "Apply the bundled team and season reference data",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&updateTeams, "update-teams", false, "Overwrite logos, venues, divisions, and colors on existing teams")
+		},
+		run: func(ctx context.Context, args []string) error {
+			db, err := openDatabase(loadConfig())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			if err := db.SeedData(); err != nil {
+				return err
+			}
+
+			if updateTeams {
+				written, err := db.UpdateTeams(ctx)
+				if err != nil {
+					return err
+				}
+				log.Printf("✓ Refreshed %d teams from the bundled dataset", written)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fortuna/minerva/internal/alert"
+	"github.com/fortuna/minerva/internal/api/cors"
+	"github.com/fortuna/minerva/internal/api/rest"
+	"github.com/fortuna/minerva/internal/api/websocket"
+	"github.com/fortuna/minerva/internal/backfill"
+	"github.com/fortuna/minerva/internal/cache"
+	"github.com/fortuna/minerva/internal/ingest"
+	"github.com/fortuna/minerva/internal/ingest/espn"
+	"github.com/fortuna/minerva/internal/leader"
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/quality"
+	"github.com/fortuna/minerva/internal/scheduler"
+	"github.com/fortuna/minerva/internal/service"
+	"github.com/fortuna/minerva/internal/startup"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+func newServeCommand() *command {
+	return &command{
+		name:    "serve",
+		summary: "Run the REST/WebSocket API, scheduler, and backfill worker (default)",
+		run:     runServe,
+	}
+}
+
+func runServe(ctx context.Context, args []string) error {
+	log.Printf("Starting %s v%s - Sports Analytics Service", serviceName, serviceVersion)
+
+	// Load configuration from environment
+	config := loadConfig()
+	corsPolicies, corsErr := corsRoutes(config)
+	if corsErr != nil {
+		log.Fatalf("Invalid CORS configuration: %v", corsErr)
+	}
+	adminTokens, tokensErr := parseAdminTokens(config.AdminTokens)
+	if tokensErr != nil {
+		log.Fatalf("Invalid ADMIN_API_TOKENS: %v", tokensErr)
+	}
+	if len(adminTokens) == 0 {
+		log.Println("⚠️  ADMIN_API_TOKENS is empty; /api/v1/admin will refuse every request")
+	}
+
+	// Wait for Postgres and Redis, retrying both with backoff
+	var db *store.Database
+	var redisCache *cache.RedisCache
+	var redisPublisher *publisher.RedisPublisher
+	dependencies := startup.NewManager(config.Startup)
+	err := waitForDependencies(ctx, dependencies, config,
+		startup.Dependency{Name: "postgres", Connect: func(ctx context.Context) error {
+			var err error
+			db, err = store.NewDatabaseWithPool(config.AtlasDSN, config.DBPool)
+			return err
+		}},
+		startup.Dependency{Name: "redis", Connect: func(ctx context.Context) error {
+			var err error
+			if redisCache, err = cache.NewRedisCache(config.RedisURL); err != nil {
+				return err
+			}
+			if redisPublisher, err = publisher.NewRedisPublisher(config.RedisURL); err != nil {
+				redisCache.Close()
+				return err
+			}
+			return nil
+		}},
+	)
+	if err != nil && ctx.Err() != nil {
+		log.Println("Shutdown requested before dependencies were available")
+		return nil
+	}
+	if err != nil {
+		log.Fatalf("Failed to start: %v", err)
+	}
+	defer db.Close()
+	defer redisCache.Close()
+	defer redisPublisher.Close()
+
+	db.SetQueryTimeouts(config.QueryTimeouts)
+	db.SetSlowQueryThreshold(config.SlowQueryThreshold)
+	db.SetMigrationsDir(config.MigrationsDir)
+
+	log.Printf("✓ Connected to Atlas database (pool: max_open=%d max_idle=%d lifetime=%v idle_time=%v)",
+		config.DBPool.MaxOpenConns, config.DBPool.MaxIdleConns, config.DBPool.ConnMaxLifetime, config.DBPool.ConnMaxIdleTime)
+
+	// Attach read replica for query endpoints (optional)
+	if config.AtlasReadDSN != "" {
+		if err := db.AttachReplica(config.AtlasReadDSN); err != nil {
+			log.Printf("⚠️  Read replica unavailable: %v (reads will use the primary)", err)
+		} else {
+			log.Println("✓ Read replica attached")
+		}
+	}
+
+	// Run migrations
+	if err := db.RunMigrations(); err != nil {
+		log.Fatalf("Failed to run database migrations: %v", err)
+	}
+	log.Println("✓ Database migrations applied")
+
+	// Seed initial data (non-fatal - may already exist)
+	if err := db.SeedData(); err != nil {
+		log.Printf("⚠️  Seed data warning: %v (continuing anyway)", err)
+	} else {
+		log.Println("✓ Seed data applied")
+	}
+
+	log.Println("✓ Connected to Redis")
+	service.ConfigureSeasonAverageCache(redisCache.Client(), config.SeasonAverageCache)
+
+	redisPublisher.SetMaxLen(config.Retention.MaxLen)
+	redisPublisher.SetValidation(config.ValidateEvents)
+
+	// Live updates, final box scores, and game and ingestion events go through
+	// the Postgres outbox, which the leader relays to Redis, unless the outbox
+	// is disabled
+	var streamPublisher publisher.Publisher = redisPublisher
+	if config.OutboxEnabled {
+		outboxPublisher := publisher.NewOutboxPublisher(db)
+		outboxPublisher.SetValidation(config.ValidateEvents)
+		streamPublisher = outboxPublisher
+		log.Println("✓ Stream publishes routed through the event outbox")
+	}
+
+	// Alert notifiers (Slack, PagerDuty, generic webhook) are optional
+	alerts := alert.NewDispatcher(config.Alerts)
+	if names := alerts.Notifiers(); len(names) > 0 {
+		log.Printf("✓ Alerting enabled (%s)", strings.Join(names, ", "))
+	} else {
+		log.Println("⚠️  No alert notifiers configured; failures will only be logged")
+	}
+
+	// Initialize scheduler/orchestrator with configuration
+	schedulerConfig := &scheduler.Config{
+		LivePollInterval:  10 * time.Second,
+		LivePollMax:       getEnvDuration("LIVE_POLL_MAX_INTERVAL", 60*time.Second),
+		LiveHeartbeat:     getEnvDuration("LIVE_HEARTBEAT_INTERVAL", 60*time.Second),
+		CurrentSeasonID:   getEnv("CURRENT_SEASON", "2024-25"),
+		EnableLivePolling: getEnv("ENABLE_LIVE_POLLING", "true") == "true" || getEnv("MINERVA_SIMULATE", "false") == "true",
+		MaxRetries:        3,
+		RetryDelay:        5 * time.Second,
+		Jobs:              loadJobConfigs(),
+		Google:            config.Google,
+		Retention:         config.Retention,
+		Leagues:           splitList(getEnv("INGEST_LEAGUES", "")),
+		Enrichment: espn.EnrichmentConfig{
+			BatchSize:         getEnvInt("ENRICHMENT_BATCH_SIZE", espn.DefaultEnrichmentConfig().BatchSize),
+			RequestsPerMinute: getEnvInt("ENRICHMENT_RPM", espn.DefaultEnrichmentConfig().RequestsPerMinute),
+			RetryAfter:        getEnvDuration("ENRICHMENT_RETRY_AFTER", espn.DefaultEnrichmentConfig().RetryAfter),
+		},
+		News: espn.NewsConfig{
+			ArticlesPerFeed:   getEnvInt("NEWS_ARTICLES_PER_FEED", espn.DefaultNewsConfig().ArticlesPerFeed),
+			RequestsPerMinute: getEnvInt("NEWS_RPM", espn.DefaultNewsConfig().RequestsPerMinute),
+		},
+	}
+
+	sched, err := scheduler.NewOrchestrator(db, redisCache, streamPublisher, schedulerConfig)
+	if err != nil {
+		log.Fatalf("Failed to create scheduler: %v", err)
+	}
+	sched.SetAlerts(alerts)
+	gameEvents := publisher.NewGameEventPublisher(streamPublisher, config.GameEventsWebhookURL)
+	gameEvents.SetValidation(config.ValidateEvents)
+	sched.SetGameEvents(gameEvents)
+	ingestEvents := publisher.NewIngestionEventPublisher(streamPublisher, config.IngestionWebhookURL)
+	sched.SetIngestionEvents(ingestEvents)
+	validator := quality.NewValidator(db, config.Quality)
+	sched.SetValidator(validator)
+	if getEnv("MINERVA_SIMULATE", "false") == "true" {
+		replayConfig, err := loadReplayConfig()
+		if err != nil {
+			log.Fatalf("Failed to configure simulation: %v", err)
+		}
+		replay, err := ingest.NewReplay(ctx, db, replayConfig)
+		if err != nil {
+			log.Fatalf("Failed to load simulated game: %v", err)
+		}
+		sched.SetReplay(replay)
+		log.Printf("✓ Simulation mode: live polling replays game %s at %gx", replay.Game().ExternalID, replayConfig.Speed)
+	}
+	if config.GameEventsWebhookURL != "" {
+		log.Println("✓ Game events webhook enabled")
+	}
+	if config.IngestionWebhookURL != "" {
+		log.Println("✓ Ingestion events webhook enabled")
+	}
+
+	// Background work runs on its own context so shutdown can stop it after the
+	// APIs rather than all at once when the signal cancels ctx
+	signalCtx := ctx
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	// Initialize backfill service
+	backfillService := backfill.NewService(db, config.ESPNAPIBase, log.Default())
+	backfillService.SetAlerts(alerts)
+	backfillService.SetValidator(validator)
+	backfillService.SetLimits(config.BackfillLimits)
+
+	// Every replica serves the API; only the elected leader polls, runs scheduled
+	// jobs, works the backfill queue, and relays the outbox
+	elector := leader.NewElector(redisCache.Client(), config.Leader)
+	outboxRelay := publisher.NewOutboxRelay(db, redisPublisher, config.Outbox)
+	lead := func(leaderCtx context.Context) {
+		var workers sync.WaitGroup
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			backfillService.Run(leaderCtx)
+		}()
+		if config.OutboxEnabled {
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				outboxRelay.Run(leaderCtx)
+			}()
+		}
+		sched.Start(leaderCtx)
+		workers.Wait()
+	}
+	leaderDone := make(chan struct{})
+	go func() {
+		defer close(leaderDone)
+		elector.Run(ctx, lead)
+	}()
+
+	log.Printf("✓ Scheduler and backfill worker waiting for leadership (%s)", elector.ID())
+
+	// The WebSocket server is created first so the admin overview can count its clients
+	config.WebSocket.CORS = config.CORS
+	wsServer, err := websocket.NewServerWithConfig(db, redisCache, redisPublisher, config.WebSocket)
+	if err != nil {
+		log.Fatalf("Failed to create WebSocket server: %v", err)
+	}
+
+	// Initialize REST API server
+	service.ConfigureLookupCache(config.LookupCache)
+	restServer := rest.NewServer(config.RESTPort, db, backfillService, sched)
+	restServer.SetLegacyResponses(config.LegacyResponses)
+	restServer.SetCompression(config.Compression)
+	restServer.SetCORS(corsPolicies)
+	restServer.SetAdminTokens(adminTokens)
+	restServer.SetOverviewSources(rest.OverviewSources{
+		Redis:     redisCache,
+		WebSocket: wsServer,
+		Leader:    elector,
+	})
+	go func() {
+		log.Printf("Starting REST API server on port %s", config.RESTPort)
+		if err := restServer.Start(); err != nil {
+			log.Printf("REST server error: %v", err)
+		}
+	}()
+
+	log.Printf("✓ REST API server listening on :%s", config.RESTPort)
+
+	// Start WebSocket server
+	go func() {
+		log.Printf("Starting WebSocket server on port %s", config.WSPort)
+		if err := wsServer.ListenAndServe(config.WSPort); err != nil {
+			log.Printf("WebSocket server error: %v", err)
+		}
+	}()
+
+	log.Printf("✓ WebSocket server listening on :%s", config.WSPort)
+	log.Printf("✓ Minerva v%s started successfully", serviceVersion)
+	log.Printf("  REST API: http://0.0.0.0:%s", config.RESTPort)
+	log.Printf("  WebSocket: ws://0.0.0.0:%s", config.WSPort)
+
+	// Wait for interrupt signal
+	<-signalCtx.Done()
+
+	log.Printf("Shutting down Minerva gracefully (timeout %v)...", config.ShutdownTimeout)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer shutdownCancel()
+
+	// Stop taking WebSocket connections and tell connected clients to reconnect elsewhere
+	if err := wsServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("WebSocket server shutdown error: %v", err)
+	}
+
+	if err := restServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("REST API server shutdown error: %v", err)
+	}
+
+	// Stop leader work: the backfill worker checkpoints its job for the next
+	// leader, and the lease is released once everything has returned
+	cancel()
+	sched.Stop()
+	select {
+	case <-leaderDone:
+		log.Println("✓ Background workers stopped")
+	case <-shutdownCtx.Done():
+		log.Println("⚠️  Background workers still running at the shutdown timeout")
+	}
+
+	// Relay what the outbox still holds so queued updates aren't left for the next leader
+	if config.OutboxEnabled {
+		pending, err := outboxRelay.Flush(shutdownCtx)
+		switch {
+		case err != nil:
+			log.Printf("⚠️  Outbox flush failed: %v", err)
+		case pending > 0:
+			log.Printf("⚠️  %d outbox entries left for the next leader", pending)
+		default:
+			log.Println("✓ Outbox flushed")
+		}
+	}
+
+	// Redis and the database close as runServe returns
+	log.Println("Minerva stopped")
+	return nil
+}
+
+// waitForDependencies connects deps within STARTUP_MAX_WAIT. In degraded mode a
+// dependency still down after that doesn't stop the process: the REST port
+// answers 503 with each dependency's status until all connect, then is freed
+// for the real server.
+func waitForDependencies(ctx context.Context, manager *startup.Manager, config Config, deps ...startup.Dependency) error {
+	err := manager.Wait(ctx, config.Startup.MaxWait, deps...)
+	if err == nil || !config.Startup.Degraded || ctx.Err() != nil {
+		return err
+	}
+
+	log.Printf("⚠️  %v", err)
+	log.Printf("⚠️  Starting in degraded mode: :%s reports dependency status until they recover", config.RESTPort)
+	degraded := &http.Server{Addr: ":" + config.RESTPort, Handler: manager.DegradedHandler()}
+	go func() {
+		if err := degraded.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("Degraded server error: %v", err)
+		}
+	}()
+
+	err = manager.Wait(ctx, 0, deps...)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	degraded.Shutdown(shutdownCtx)
+	if err == nil {
+		log.Println("✓ Dependencies recovered; leaving degraded mode")
+	}
+	return err
+}
+
+// loadJobConfigs applies JOB_<NAME>_SCHEDULE and JOB_<NAME>_ENABLED overrides to
+// the default cron jobs. ENABLE_DAILY_INGESTION is still honoured for daily_ingestion.
+// corsRoutes applies the shared CORS policy to the API, narrowing the origins
+// allowed on operator routes when CORS_ADMIN_ALLOWED_ORIGINS is set. It fails
+// when CORS_ALLOW_CREDENTIALS is combined with an origin list of "*".
+func corsRoutes(config Config) (*cors.Routes, error) {
+	routes, err := cors.NewRoutes(config.CORS)
+	if err != nil {
+		return nil, err
+	}
+	if routes.Default().AllowsAny() {
+		log.Println("⚠️  CORS allows every origin; set CORS_ALLOWED_ORIGINS to restrict browser access")
+	}
+
+	if len(config.AdminCORSOrigins) > 0 {
+		admin := config.CORS
+		admin.AllowedOrigins = config.AdminCORSOrigins
+		for _, prefix := range []string{"/api/v1/admin", "/api/v1/backfill"} {
+			if err := routes.Add(prefix, admin); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return routes, nil
+}
+
+// parseAdminTokens reads ADMIN_API_TOKENS entries of the form name:token
+func parseAdminTokens(entries []string) (map[string]string, error) {
+	tokens := make(map[string]string, len(entries))
+	for i, entry := range entries {
+		name, token, found := strings.Cut(entry, ":")
+		name, token = strings.TrimSpace(name), strings.TrimSpace(token)
+		if !found || name == "" || token == "" {
+			// The entry is a secret, so only its position is reported
+			return nil, fmt.Errorf("entry %d is not name:token", i+1)
+		}
+		if _, dup := tokens[name]; dup {
+			return nil, fmt.Errorf("%s is listed twice", name)
+		}
+		tokens[name] = token
+	}
+	return tokens, nil
+}
+
+func loadJobConfigs() map[string]scheduler.JobConfig {
+	jobs := scheduler.DefaultJobConfigs()
+
+	daily := jobs[scheduler.JobDailyIngestion]
+	daily.Enabled = getEnv("ENABLE_DAILY_INGESTION", "true") == "true"
+	jobs[scheduler.JobDailyIngestion] = daily
+
+	for name, cfg := range jobs {
+		key := "JOB_" + strings.ToUpper(name)
+		cfg.Schedule = getEnv(key+"_SCHEDULE", cfg.Schedule)
+		cfg.Enabled = getEnv(key+"_ENABLED", strconv.FormatBool(cfg.Enabled)) == "true"
+		jobs[name] = cfg
+	}
+	return jobs
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/cache"
+	"github.com/fortuna/minerva/internal/ingest"
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/scheduler"
+)
+
+func newSimulateCommand() *command {
+	var gameID, speed string
+	var interval time.Duration
+
+	return &command{
+		name:    "simulate",
+		summary: "Replay an archived game through live polling, reconciliation, and publishing",
+		usage:   "--game ID [--speed 10x]",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&gameID, "game", "", "ESPN or database ID of a stored game to replay")
+			fs.StringVar(&speed, "speed", "10x", "Replay speed (e.g., 1x real time, 10x, 60x)")
+			fs.DurationVar(&interval, "interval", 2*time.Second, "Live poll interval")
+		},
+		run: func(ctx context.Context, args []string) error {
+			if gameID == "" {
+				return fmt.Errorf("--game is required")
+			}
+			factor, err := parseReplaySpeed(speed)
+			if err != nil {
+				return err
+			}
+			return runSimulate(ctx, ingest.ReplayConfig{GameID: gameID, Speed: factor}, interval)
+		},
+	}
+}
+
+// runSimulate polls the replay with only live polling enabled and publishes
+// straight to Redis, so a running `minerva serve` relays it to WebSocket clients
+func runSimulate(ctx context.Context, replayConfig ingest.ReplayConfig, interval time.Duration) error {
+	config := loadConfig()
+	db, err := openDatabase(config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	redisCache, err := cache.NewRedisCache(config.RedisURL)
+	if err != nil {
+		return fmt.Errorf("connect Redis: %w", err)
+	}
+	defer redisCache.Close()
+
+	redisPublisher, err := publisher.NewRedisPublisher(config.RedisURL)
+	if err != nil {
+		return fmt.Errorf("connect Redis publisher: %w", err)
+	}
+	defer redisPublisher.Close()
+	redisPublisher.SetMaxLen(config.Retention.MaxLen)
+	redisPublisher.SetValidation(true)
+
+	replay, err := ingest.NewReplay(ctx, db, replayConfig)
+	if err != nil {
+		return err
+	}
+
+	jobs := scheduler.DefaultJobConfigs()
+	for name, job := range jobs {
+		job.Enabled = false
+		jobs[name] = job
+	}
+	sched, err := scheduler.NewOrchestrator(db, redisCache, redisPublisher, &scheduler.Config{
+		LivePollInterval:  interval,
+		LiveHeartbeat:     getEnvDuration("LIVE_HEARTBEAT_INTERVAL", 60*time.Second),
+		CurrentSeasonID:   getEnv("CURRENT_SEASON", "2024-25"),
+		EnableLivePolling: true,
+		MaxRetries:        1,
+		RetryDelay:        time.Second,
+		Jobs:              jobs,
+		Google:            config.Google,
+		Retention:         config.Retention,
+	})
+	if err != nil {
+		return err
+	}
+	sched.SetReplay(replay)
+
+	game := replay.Game()
+	log.Printf("Replaying game %d (%s) at %gx, about %v", game.GameID, game.ExternalID, replayConfig.Speed,
+		replay.Duration().Round(time.Second))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-replay.Done():
+			log.Println("✓ Replay finished")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	sched.Start(ctx)
+	return nil
+}
+
+// loadReplayConfig reads the game `minerva serve` replays when MINERVA_SIMULATE=true
+func loadReplayConfig() (ingest.ReplayConfig, error) {
+	gameID := getEnv("MINERVA_SIMULATE_GAME", "")
+	if gameID == "" {
+		return ingest.ReplayConfig{}, fmt.Errorf("MINERVA_SIMULATE_GAME is required when MINERVA_SIMULATE=true")
+	}
+	speed, err := parseReplaySpeed(getEnv("MINERVA_SIMULATE_SPEED", "10x"))
+	if err != nil {
+		return ingest.ReplayConfig{}, err
+	}
+	return ingest.ReplayConfig{GameID: gameID, Speed: speed}, nil
+}
+
+// parseReplaySpeed accepts "10x" or "10"
+func parseReplaySpeed(value string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("invalid replay speed %q (use e.g. 10x)", value)
+	}
+	return speed, nil
+}
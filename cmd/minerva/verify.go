@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/fortuna/minerva/internal/ingest/bref"
+	"github.com/fortuna/minerva/internal/ingest/espn"
+	"github.com/fortuna/minerva/internal/ingest/google"
+	"github.com/fortuna/minerva/internal/maintenance"
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+func newVerifyCommand() *command {
+	return &command{
+		name:    "verify",
+		summary: "Check connectivity and parsing against upstream data sources",
+		subcommands: []*command{
+			newVerifyESPNCommand(),
+			newVerifyGoogleCommand(),
+			newVerifyBrefCommand(),
+		},
+	}
+}
+
+func newVerifyESPNCommand() *command {
+	var date string
+
+	return &command{
+		name:    "espn",
+		summary: "Fetch an ESPN scoreboard and the first game's summary",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&date, "date", "", "Scoreboard date (YYYY-MM-DD, default today)")
+		},
+		run: func(ctx context.Context, args []string) error {
+			var day time.Time
+			if date != "" {
+				parsed, err := time.Parse("2006-01-02", date)
+				if err != nil {
+					return fmt.Errorf("invalid --date: %w", err)
+				}
+				day = parsed
+			}
+
+			client := espn.NewClient()
+
+			log.Printf("Fetching scoreboard for %s...", valueOrToday(date))
+			data, err := client.FetchScoreboard(ctx, espn.BasketballNBA, day)
+			if err != nil {
+				return fmt.Errorf("fetch scoreboard: %w", err)
+			}
+
+			events, _ := data["events"].([]interface{})
+			log.Printf("✓ Retrieved %d events", len(events))
+			if len(events) == 0 {
+				log.Println("No events on this date; skipping summary check")
+				return nil
+			}
+
+			event, _ := events[0].(map[string]interface{})
+			gameID, _ := event["id"].(string)
+			log.Printf("  First event: %v", event["name"])
+			if gameID == "" {
+				return fmt.Errorf("first event has no id")
+			}
+
+			log.Printf("Fetching game summary for %s...", gameID)
+			summary, err := client.FetchGameSummary(ctx, espn.BasketballNBA, gameID)
+			if err != nil {
+				return fmt.Errorf("fetch summary: %w", err)
+			}
+
+			if _, ok := summary["boxscore"].(map[string]interface{}); !ok {
+				return fmt.Errorf("summary for %s has no boxscore", gameID)
+			}
+
+			log.Println("✓ ESPN client OK")
+			return nil
+		},
+	}
+}
+
+func newVerifyGoogleCommand() *command {
+	var timeout time.Duration
+	var home, away, mode string
+
+	return &command{
+		name:    "google",
+		summary: "Scrape Google live scores and print parsed games",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&mode, "mode", google.FetchAuto, "Fetch mode: auto, http, or browser (headless Chrome)")
+			fs.DurationVar(&timeout, "timeout", 60*time.Second, "Overall scrape timeout")
+			fs.StringVar(&home, "home", "", "Optional home team for a single-game details fetch")
+			fs.StringVar(&away, "away", "", "Optional away team for a single-game details fetch")
+		},
+		run: func(ctx context.Context, args []string) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			config := google.DefaultConfig()
+			config.Mode = mode
+			client, err := google.NewClientWithConfig(config)
+			if err != nil {
+				return fmt.Errorf("create scraper: %w", err)
+			}
+			defer client.Close()
+
+			log.Println("Fetching live NBA games...")
+			htmlContent, err := client.FetchLiveGames(ctx)
+			if err != nil {
+				return fmt.Errorf("fetch live games: %w", err)
+			}
+			log.Printf("✓ Retrieved HTML content (%d bytes)", len(htmlContent))
+
+			doc, err := google.ParseHTML(htmlContent)
+			if err != nil {
+				return fmt.Errorf("parse HTML: %w", err)
+			}
+
+			games, err := google.ParseLiveGames(doc)
+			if err != nil {
+				return fmt.Errorf("parse games: %w", err)
+			}
+
+			if len(games) == 0 {
+				log.Println("No live games currently available (expected when no NBA games are scheduled)")
+			}
+			for i, game := range games {
+				log.Printf("Game %d: %s @ %s  %d-%d  %s (live=%v)",
+					i+1, game.AwayTeam, game.HomeTeam, game.AwayScore, game.HomeScore, game.GameStatus, game.IsLive)
+			}
+
+			if home != "" && away != "" {
+				log.Printf("Fetching game details for %s vs %s...", home, away)
+				if _, err := client.FetchGameDetails(ctx, home, away); err != nil {
+					return fmt.Errorf("fetch game details: %w", err)
+				}
+				log.Println("✓ Fetched game details")
+			}
+
+			log.Println("✓ Google scraper OK")
+			return nil
+		},
+	}
+}
+
+func newVerifyBrefCommand() *command {
+	var season, game, output string
+	var sample, rpm int
+	var seed int64
+
+	return &command{
+		name:    "bref",
+		summary: "Compare stored final box scores with Basketball-Reference for a sample of games",
+		usage:   "(--season S [--sample N] | --game ESPNID)",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&season, "season", "", "Season to sample final games from (e.g., 2024-25)")
+			fs.IntVar(&sample, "sample", 20, "Number of games to check (0 for every final game)")
+			fs.Int64Var(&seed, "seed", 0, "Sampling seed, to repeat a run (default: random)")
+			fs.StringVar(&game, "game", "", "Check one game by ESPN ID instead of sampling")
+			fs.IntVar(&rpm, "rpm", bref.DefaultRequestsPerMinute, "Basketball-Reference requests per minute")
+			fs.StringVar(&output, "json", "", "Also write the full report as JSON to this file")
+		},
+		run: func(ctx context.Context, args []string) error {
+			if season == "" && game == "" {
+				return fmt.Errorf("specify --season or --game")
+			}
+
+			db, err := openDatabase(loadConfig())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			games, err := crosscheckGames(ctx, db, season, game, sample, &seed)
+			if err != nil {
+				return err
+			}
+			if len(games) == 0 {
+				log.Println("No final games to check")
+				return nil
+			}
+			log.Printf("Checking %d games against Basketball-Reference at %d requests/minute (seed %d)...", len(games), rpm, seed)
+
+			checker := maintenance.NewCrosschecker(db, bref.New("", rpm))
+			report, runErr := checker.Run(ctx, games, func(g *maintenance.GameCrosscheck) {
+				switch {
+				case g.Error != "":
+					log.Printf("✗ %s  game %d (espn %s)  %s", g.GameDate.Format("2006-01-02"), g.GameID, g.ExternalID, g.Error)
+				case g.Clean():
+					log.Printf("✓ %s  game %d (espn %s)", g.GameDate.Format("2006-01-02"), g.GameID, g.ExternalID)
+				default:
+					log.Printf("⚠ %s  game %d (espn %s)  %d discrepancies, %d missing and %d extra players",
+						g.GameDate.Format("2006-01-02"), g.GameID, g.ExternalID,
+						len(g.Discrepancies), len(g.MissingPlayers), len(g.ExtraPlayers))
+					for _, d := range g.Discrepancies {
+						who := d.Team
+						if d.Player != "" {
+							who += " " + d.Player
+						}
+						log.Printf("    %-28s %-26s stored=%d bref=%d", who, d.Field, d.Stored, d.Reference)
+					}
+					for _, p := range g.MissingPlayers {
+						log.Printf("    missing %s", p)
+					}
+					for _, p := range g.ExtraPlayers {
+						log.Printf("    extra   %s", p)
+					}
+				}
+			})
+
+			log.Printf("Checked %d games: %d clean, %d with discrepancies, %d failed",
+				report.Checked, report.Clean, report.Checked-report.Clean, report.Failed)
+			for _, field := range report.Fields() {
+				log.Printf("  %-26s %d games (%d values)", field, report.GamesByField[field], report.ByField[field])
+			}
+			if report.MissingPlayers > 0 || report.ExtraPlayers > 0 {
+				log.Printf("  players missing: %d, extra: %d", report.MissingPlayers, report.ExtraPlayers)
+			}
+
+			if output != "" {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(output, data, 0o644); err != nil {
+					return fmt.Errorf("write report: %w", err)
+				}
+				log.Printf("Report written to %s", output)
+			}
+			return runErr
+		},
+	}
+}
+
+// crosscheckGames returns the one game asked for, or a sample of a season's
+// final games. A zero seed is replaced with a random one so it can be logged.
+func crosscheckGames(ctx context.Context, db *store.Database, season, externalID string, sample int, seed *int64) ([]*store.Game, error) {
+	games := repository.NewGameRepository(db)
+	if externalID != "" {
+		game, err := games.GetByExternalID(ctx, externalID)
+		if err != nil {
+			return nil, err
+		}
+		return maintenance.SampleGames([]*store.Game{game}, 0, 0), nil
+	}
+
+	var seasonID int
+	query := `SELECT season_id FROM seasons WHERE season_year = $1 AND sport = 'basketball_nba' AND league = 'nba' LIMIT 1`
+	if err := db.DB().QueryRowContext(ctx, query, season).Scan(&seasonID); err != nil {
+		return nil, fmt.Errorf("season '%s' not found in database: %w", season, err)
+	}
+	all, err := games.GetBySeason(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	return maintenance.SampleGames(all, sample, *seed), nil
+}
+
+func valueOrToday(date string) string {
+	if date == "" {
+		return "today"
+	}
+	return date
+}
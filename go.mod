@@ -4,6 +4,7 @@ go 1.24.0
 
 require (
 	github.com/PuerkitoBio/goquery v1.10.3
+	github.com/andybalholm/brotli v1.2.0
 	github.com/chromedp/chromedp v0.10.0
 	github.com/gorilla/mux v1.8.1
 	github.com/gorilla/websocket v1.5.3
@@ -0,0 +1,10 @@
+// Package atlas bundles the Atlas database schema so the service binary does not
+// depend on the working directory to find its migrations.
+package atlas
+
+import "embed"
+
+// Migrations holds every SQL file under migrations/
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,2 @@
+-- Revert 011_create_seasons_v2.sql
+DROP TABLE IF EXISTS seasons;
@@ -0,0 +1,2 @@
+-- Revert 012_create_teams_v2.sql
+DROP TABLE IF EXISTS teams;
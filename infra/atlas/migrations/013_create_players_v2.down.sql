@@ -0,0 +1,2 @@
+-- Revert 013_create_players_v2.sql
+DROP TABLE IF EXISTS players;
@@ -0,0 +1,3 @@
+-- Revert 014_create_player_team_history.sql
+DROP FUNCTION IF EXISTS get_player_team_at_date(INTEGER, DATE);
+DROP TABLE IF EXISTS player_team_history;
@@ -0,0 +1,2 @@
+-- Revert 015_create_games_v2.sql
+DROP TABLE IF EXISTS games;
@@ -0,0 +1,2 @@
+-- Revert 016_create_player_game_stats_v2.sql
+DROP TABLE IF EXISTS player_game_stats;
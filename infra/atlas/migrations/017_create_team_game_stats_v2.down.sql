@@ -0,0 +1,2 @@
+-- Revert 017_create_team_game_stats_v2.sql
+DROP TABLE IF EXISTS team_game_stats;
@@ -0,0 +1,2 @@
+-- Revert 018_create_odds_mappings_v2.sql
+DROP TABLE IF EXISTS odds_mappings;
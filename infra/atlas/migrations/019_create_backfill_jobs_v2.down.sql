@@ -0,0 +1,3 @@
+-- Revert 019_create_backfill_jobs_v2.sql
+DROP TABLE IF EXISTS backfill_job_events;
+DROP TABLE IF EXISTS backfill_jobs;
@@ -0,0 +1,16 @@
+-- Revert 020_create_triggers.sql
+DROP TRIGGER IF EXISTS calculate_team_stats ON team_game_stats;
+DROP TRIGGER IF EXISTS calculate_player_stats ON player_game_stats;
+DROP FUNCTION IF EXISTS calculate_team_advanced_stats();
+DROP FUNCTION IF EXISTS calculate_player_advanced_stats();
+
+DROP TRIGGER IF EXISTS update_backfill_jobs_updated_at ON backfill_jobs;
+DROP TRIGGER IF EXISTS update_odds_mappings_updated_at ON odds_mappings;
+DROP TRIGGER IF EXISTS update_team_game_stats_updated_at ON team_game_stats;
+DROP TRIGGER IF EXISTS update_player_game_stats_updated_at ON player_game_stats;
+DROP TRIGGER IF EXISTS update_games_updated_at ON games;
+DROP TRIGGER IF EXISTS update_player_team_history_updated_at ON player_team_history;
+DROP TRIGGER IF EXISTS update_players_updated_at ON players;
+DROP TRIGGER IF EXISTS update_teams_updated_at ON teams;
+DROP TRIGGER IF EXISTS update_seasons_updated_at ON seasons;
+DROP FUNCTION IF EXISTS update_updated_at_column();
@@ -0,0 +1,2 @@
+-- Revert 021_create_materialized_views.sql
+DROP MATERIALIZED VIEW IF EXISTS player_season_averages;
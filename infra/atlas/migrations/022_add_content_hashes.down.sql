@@ -0,0 +1,4 @@
+-- Revert 022_add_content_hashes.sql
+ALTER TABLE team_game_stats DROP COLUMN IF EXISTS content_hash;
+ALTER TABLE player_game_stats DROP COLUMN IF EXISTS content_hash;
+ALTER TABLE games DROP COLUMN IF EXISTS content_hash;
@@ -0,0 +1,10 @@
+-- Add content hashes for idempotent ingestion
+-- Upserts compare the hash of the canonical payload and skip the write when nothing changed
+
+ALTER TABLE games ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+ALTER TABLE player_game_stats ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+ALTER TABLE team_game_stats ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+
+COMMENT ON COLUMN games.content_hash IS 'SHA-256 of the ingested game payload; unchanged hashes skip the update';
+COMMENT ON COLUMN player_game_stats.content_hash IS 'SHA-256 of the ingested stat line; unchanged hashes skip the update';
+COMMENT ON COLUMN team_game_stats.content_hash IS 'SHA-256 of the ingested team totals; unchanged hashes skip the update';
@@ -0,0 +1,5 @@
+-- Revert 023_add_backfill_dry_run.sql
+ALTER TABLE backfill_job_events DROP COLUMN IF EXISTS progress_total;
+ALTER TABLE backfill_job_events DROP COLUMN IF EXISTS progress_current;
+ALTER TABLE backfill_jobs DROP COLUMN IF EXISTS result;
+ALTER TABLE backfill_jobs DROP COLUMN IF EXISTS dry_run;
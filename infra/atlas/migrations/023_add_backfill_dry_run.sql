@@ -0,0 +1,12 @@
+-- Backfill dry-run support
+-- Dry-run jobs enumerate work without writing game data; the plan is stored on the job
+
+ALTER TABLE backfill_jobs ADD COLUMN IF NOT EXISTS dry_run BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE backfill_jobs ADD COLUMN IF NOT EXISTS result JSONB;
+
+-- The job event writer records progress counters alongside each event
+ALTER TABLE backfill_job_events ADD COLUMN IF NOT EXISTS progress_current INTEGER;
+ALTER TABLE backfill_job_events ADD COLUMN IF NOT EXISTS progress_total INTEGER;
+
+COMMENT ON COLUMN backfill_jobs.dry_run IS 'When true the job only enumerates games (new vs. existing) and writes nothing';
+COMMENT ON COLUMN backfill_jobs.result IS 'JSONB: dry-run plan (per-date new/existing game IDs)';
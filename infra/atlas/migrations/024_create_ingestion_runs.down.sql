@@ -0,0 +1,2 @@
+-- Revert 024_create_ingestion_runs.sql
+DROP TABLE IF EXISTS ingestion_runs;
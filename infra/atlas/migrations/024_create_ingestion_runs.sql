@@ -0,0 +1,28 @@
+-- Create ingestion_runs table
+-- One row per scheduler or backfill run so operators can see when data was last refreshed
+
+CREATE TABLE ingestion_runs (
+  run_id SERIAL PRIMARY KEY,
+  sport VARCHAR(50) NOT NULL,
+  source VARCHAR(50) NOT NULL,             -- 'scheduler_daily', 'scheduler_manual', 'backfill'
+  start_date DATE,
+  end_date DATE,
+  status VARCHAR(20) NOT NULL DEFAULT 'running',
+  games_processed INTEGER NOT NULL DEFAULT 0,
+  rows_changed INTEGER NOT NULL DEFAULT 0,
+  rows_unchanged INTEGER NOT NULL DEFAULT 0,
+  error_count INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT,
+  started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  completed_at TIMESTAMPTZ,
+  duration_ms BIGINT,
+  metadata JSONB DEFAULT '{}',             -- Source-specific details (e.g. backfill job_id)
+
+  CONSTRAINT ingestion_runs_valid_status CHECK (status IN ('running', 'succeeded', 'failed'))
+);
+
+CREATE INDEX idx_ingestion_runs_started ON ingestion_runs(started_at DESC);
+CREATE INDEX idx_ingestion_runs_source ON ingestion_runs(sport, source, started_at DESC);
+CREATE INDEX idx_ingestion_runs_succeeded ON ingestion_runs(sport, source, completed_at DESC) WHERE status = 'succeeded';
+
+COMMENT ON TABLE ingestion_runs IS 'History of scheduler and backfill ingestion runs';
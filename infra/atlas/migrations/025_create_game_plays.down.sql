@@ -0,0 +1,3 @@
+-- Revert 025_create_game_plays.sql
+DROP TABLE IF EXISTS game_plays;
+COMMENT ON COLUMN games.game_data IS 'JSONB: quarter_scores[], largest_lead, lead_changes';
@@ -0,0 +1,31 @@
+-- Create game_plays table
+-- Play-by-play from the ESPN game summary, one row per play in sequence order
+
+CREATE TABLE game_plays (
+  play_id BIGSERIAL PRIMARY KEY,
+  game_id INTEGER NOT NULL REFERENCES games(game_id) ON DELETE CASCADE,
+  sequence INTEGER NOT NULL,               -- ESPN sequenceNumber, ordering within the game
+  external_id VARCHAR(50),                 -- ESPN play ID
+  period INTEGER NOT NULL,
+  clock VARCHAR(20),                       -- '5:23' remaining in the period
+  clock_seconds INTEGER,                   -- Seconds remaining in the period
+  team_id INTEGER REFERENCES teams(team_id),
+  player_id INTEGER REFERENCES players(player_id),
+  play_type VARCHAR(100),                  -- 'Jump Shot', 'Defensive Rebound', 'End Period'
+  description TEXT,
+  scoring_play BOOLEAN NOT NULL DEFAULT false,
+  score_value INTEGER NOT NULL DEFAULT 0,
+  home_score INTEGER NOT NULL DEFAULT 0,   -- Score after the play
+  away_score INTEGER NOT NULL DEFAULT 0,
+  wallclock TIMESTAMPTZ,
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  CONSTRAINT game_plays_unique UNIQUE(game_id, sequence)
+);
+
+CREATE INDEX idx_game_plays_scoring ON game_plays(game_id, sequence) WHERE scoring_play;
+CREATE INDEX idx_game_plays_player ON game_plays(player_id) WHERE player_id IS NOT NULL;
+
+COMMENT ON TABLE game_plays IS 'Play-by-play events per game';
+COMMENT ON COLUMN games.game_data IS 'JSONB: quarter_scores{home[], away[]}, largest_lead, lead_changes';
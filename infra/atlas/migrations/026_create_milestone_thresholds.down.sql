@@ -0,0 +1,2 @@
+-- Revert 026_create_milestone_thresholds.sql
+DROP TABLE IF EXISTS milestone_thresholds;
@@ -0,0 +1,37 @@
+-- Create milestone_thresholds table
+-- Career totals that are worth flagging as a player approaches them (e.g. 20,000 points)
+
+CREATE TABLE milestone_thresholds (
+  threshold_id SERIAL PRIMARY KEY,
+  sport VARCHAR(50) NOT NULL,
+  stat VARCHAR(50) NOT NULL,               -- Career total: 'points', 'rebounds', 'assists', ...
+  threshold INTEGER NOT NULL,
+  proximity INTEGER NOT NULL,              -- Flag as upcoming within this many of the threshold
+  label VARCHAR(100),                      -- Optional display text, e.g. '20,000 career points'
+  is_active BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  CONSTRAINT milestone_thresholds_unique UNIQUE(sport, stat, threshold),
+  CONSTRAINT milestone_thresholds_valid_stat CHECK (stat IN (
+    'games_played', 'points', 'rebounds', 'assists', 'steals', 'blocks', 'three_pointers_made'
+  )),
+  CONSTRAINT milestone_thresholds_positive CHECK (threshold > 0 AND proximity > 0)
+);
+
+CREATE INDEX idx_milestone_thresholds_active ON milestone_thresholds(sport, stat, threshold) WHERE is_active;
+
+INSERT INTO milestone_thresholds (sport, stat, threshold, proximity)
+SELECT 'basketball_nba', m.stat, t.threshold, m.proximity
+FROM (VALUES
+  ('points',              ARRAY[1000, 5000, 10000, 15000, 20000, 25000, 30000, 35000, 40000], 500),
+  ('rebounds',            ARRAY[1000, 2500, 5000, 7500, 10000, 12500, 15000], 250),
+  ('assists',             ARRAY[1000, 2500, 5000, 7500, 10000, 12500, 15000], 250),
+  ('steals',              ARRAY[500, 1000, 1500, 2000, 2500], 75),
+  ('blocks',              ARRAY[500, 1000, 1500, 2000, 2500, 3000], 75),
+  ('three_pointers_made', ARRAY[500, 1000, 1500, 2000, 2500, 3000, 3500, 4000], 100),
+  ('games_played',        ARRAY[500, 750, 1000, 1250, 1500], 25)
+) AS m(stat, thresholds, proximity)
+CROSS JOIN LATERAL unnest(m.thresholds) AS t(threshold);
+
+COMMENT ON TABLE milestone_thresholds IS 'Career milestone thresholds flagged by GET /players/{id}/career';
@@ -0,0 +1,3 @@
+-- Revert 027_create_playoff_series.sql
+DROP TABLE IF EXISTS playoff_series_games;
+DROP TABLE IF EXISTS playoff_series;
@@ -0,0 +1,39 @@
+-- Create playoff_series and playoff_series_games tables
+-- One row per postseason matchup; series scores are recomputed from the linked final games
+
+CREATE TABLE playoff_series (
+  series_id SERIAL PRIMARY KEY,
+  sport VARCHAR(50) NOT NULL,
+  season_id INTEGER NOT NULL REFERENCES seasons(season_id),
+  round INTEGER,                           -- 1 = first round ... 4 = finals; NULL when ESPN gave no round
+  round_name VARCHAR(100),                 -- 'East 1st Round', 'NBA Finals'
+  conference VARCHAR(20),                  -- 'East', 'West'; NULL for the finals
+  team_a_id INTEGER NOT NULL REFERENCES teams(team_id),  -- Lower team_id of the pair
+  team_b_id INTEGER NOT NULL REFERENCES teams(team_id),
+  team_a_wins INTEGER NOT NULL DEFAULT 0,
+  team_b_wins INTEGER NOT NULL DEFAULT 0,
+  best_of INTEGER NOT NULL DEFAULT 7,
+  winner_team_id INTEGER REFERENCES teams(team_id),
+  status VARCHAR(20) NOT NULL DEFAULT 'scheduled', -- 'scheduled', 'in_progress', 'completed'
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  CONSTRAINT playoff_series_unique UNIQUE(season_id, team_a_id, team_b_id),
+  CONSTRAINT playoff_series_ordered_teams CHECK (team_a_id < team_b_id),
+  CONSTRAINT playoff_series_valid_status CHECK (status IN ('scheduled', 'in_progress', 'completed')),
+  CONSTRAINT playoff_series_valid_round CHECK (round IS NULL OR round BETWEEN 1 AND 4)
+);
+
+CREATE INDEX idx_playoff_series_season ON playoff_series(season_id, round);
+
+CREATE TABLE playoff_series_games (
+  game_id INTEGER PRIMARY KEY REFERENCES games(game_id) ON DELETE CASCADE,
+  series_id INTEGER NOT NULL REFERENCES playoff_series(series_id) ON DELETE CASCADE,
+  game_number INTEGER NOT NULL,            -- 1-based, in game date order
+  created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_playoff_series_games_series ON playoff_series_games(series_id, game_number);
+
+COMMENT ON TABLE playoff_series IS 'Postseason matchups populated from ingested playoff games';
+COMMENT ON TABLE playoff_series_games IS 'Links playoff games to their series with the game number';
@@ -0,0 +1,34 @@
+-- Revert 028_add_game_type.sql
+DROP MATERIALIZED VIEW IF EXISTS player_season_averages;
+
+CREATE MATERIALIZED VIEW player_season_averages AS
+SELECT 
+  pgs.player_id,
+  g.season_id,
+  g.sport,
+  COUNT(*) as games_played,
+  AVG(pgs.minutes_played) as avg_minutes,
+  AVG(pgs.points) as ppg,
+  AVG(pgs.rebounds) as rpg,
+  AVG(pgs.assists) as apg,
+  AVG(pgs.steals) as spg,
+  AVG(pgs.blocks) as bpg,
+  AVG(pgs.field_goal_pct) as fg_pct,
+  AVG(pgs.three_point_pct) as three_pt_pct,
+  AVG(pgs.free_throw_pct) as ft_pct,
+  AVG(pgs.true_shooting_pct) as ts_pct,
+  SUM(pgs.points) as total_points,
+  SUM(pgs.rebounds) as total_rebounds,
+  SUM(pgs.assists) as total_assists
+FROM player_game_stats pgs
+JOIN games g ON pgs.game_id = g.game_id
+WHERE pgs.active = true
+GROUP BY pgs.player_id, g.season_id, g.sport;
+
+CREATE UNIQUE INDEX idx_player_season_averages ON player_season_averages(player_id, season_id);
+CREATE INDEX idx_player_season_averages_ppg ON player_season_averages(season_id, ppg DESC);
+CREATE INDEX idx_player_season_averages_games ON player_season_averages(season_id, games_played DESC);
+
+DROP INDEX IF EXISTS idx_games_game_type;
+ALTER TABLE games DROP CONSTRAINT IF EXISTS games_valid_game_type;
+ALTER TABLE games DROP COLUMN IF EXISTS game_type;
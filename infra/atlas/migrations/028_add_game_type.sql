@@ -0,0 +1,56 @@
+-- Add games.game_type to separate special event games from regular competition
+-- All-Star, Rising Stars, and the NBA Cup championship are excluded from season averages
+
+ALTER TABLE games ADD COLUMN game_type VARCHAR(20) NOT NULL DEFAULT 'regular';
+
+ALTER TABLE games ADD CONSTRAINT games_valid_game_type CHECK (game_type IN (
+  'regular', 'preseason', 'playoffs', 'play_in',
+  'tournament', 'tournament_final', 'all_star', 'rising_stars'
+));
+
+-- Existing rows inherit the type of the season they were filed under
+UPDATE games g
+SET game_type = s.season_type
+FROM seasons s
+WHERE g.season_id = s.season_id AND s.season_type IN ('preseason', 'playoffs');
+
+UPDATE games SET game_type = 'playoffs'
+WHERE game_id IN (SELECT game_id FROM playoff_series_games);
+
+CREATE INDEX idx_games_game_type ON games(season_id, game_type);
+
+COMMENT ON COLUMN games.game_type IS 'regular, preseason, playoffs, play_in, tournament (NBA Cup, counts toward the season), tournament_final, all_star, rising_stars';
+
+-- Rebuild season averages without special event games
+DROP MATERIALIZED VIEW IF EXISTS player_season_averages;
+
+CREATE MATERIALIZED VIEW player_season_averages AS
+SELECT 
+  pgs.player_id,
+  g.season_id,
+  g.sport,
+  COUNT(*) as games_played,
+  AVG(pgs.minutes_played) as avg_minutes,
+  AVG(pgs.points) as ppg,
+  AVG(pgs.rebounds) as rpg,
+  AVG(pgs.assists) as apg,
+  AVG(pgs.steals) as spg,
+  AVG(pgs.blocks) as bpg,
+  AVG(pgs.field_goal_pct) as fg_pct,
+  AVG(pgs.three_point_pct) as three_pt_pct,
+  AVG(pgs.free_throw_pct) as ft_pct,
+  AVG(pgs.true_shooting_pct) as ts_pct,
+  SUM(pgs.points) as total_points,
+  SUM(pgs.rebounds) as total_rebounds,
+  SUM(pgs.assists) as total_assists
+FROM player_game_stats pgs
+JOIN games g ON pgs.game_id = g.game_id
+WHERE pgs.active = true
+  AND g.game_type NOT IN ('all_star', 'rising_stars', 'tournament_final')
+GROUP BY pgs.player_id, g.season_id, g.sport;
+
+CREATE UNIQUE INDEX idx_player_season_averages ON player_season_averages(player_id, season_id);
+CREATE INDEX idx_player_season_averages_ppg ON player_season_averages(season_id, ppg DESC);
+CREATE INDEX idx_player_season_averages_games ON player_season_averages(season_id, games_played DESC);
+
+COMMENT ON MATERIALIZED VIEW player_season_averages IS 'Pre-calculated player season averages for fast queries, excluding special event games. Refresh nightly or after each game day.';
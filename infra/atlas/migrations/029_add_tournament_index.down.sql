@@ -0,0 +1,4 @@
+-- Revert 029_add_tournament_index.sql
+DROP INDEX IF EXISTS idx_games_tournament;
+
+COMMENT ON COLUMN games.metadata IS 'JSONB: playoff_round, series_game_number, rivalry_game';
@@ -0,0 +1,7 @@
+-- Index NBA Cup games by season and round
+-- Tournament group and knockout round live in games.metadata (tournament_group, tournament_round)
+
+CREATE INDEX idx_games_tournament ON games(season_id, (metadata->>'tournament_round'))
+  WHERE game_type IN ('tournament', 'tournament_final');
+
+COMMENT ON COLUMN games.metadata IS 'JSONB: tournament_group, tournament_round, playoff_round, rivalry_game';
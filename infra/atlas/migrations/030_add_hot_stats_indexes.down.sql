@@ -0,0 +1,4 @@
+-- Revert 030_add_hot_stats_indexes.sql
+DROP INDEX IF EXISTS idx_player_game_stats_player_covering;
+DROP INDEX IF EXISTS idx_games_final_season;
+DROP INDEX IF EXISTS idx_games_final_date;
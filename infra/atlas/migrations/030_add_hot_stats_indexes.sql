@@ -0,0 +1,13 @@
+-- Indexes for the hot stats read paths
+-- (player recent stats, season averages, season exports, team game logs).
+-- `minerva migrate plans` EXPLAINs those queries and fails if any falls back to a sequential scan.
+
+-- Final games are the only ones the stats endpoints read
+CREATE INDEX idx_games_final_date ON games(game_date DESC) WHERE status = 'final';
+CREATE INDEX idx_games_final_season ON games(season_id, game_date) WHERE status = 'final';
+
+-- Covers per-player aggregates so season averages can be answered from the index alone
+CREATE INDEX idx_player_game_stats_player_covering ON player_game_stats(player_id, game_id)
+  INCLUDE (points, rebounds, assists, steals, blocks, turnovers, minutes_played,
+           field_goals_made, field_goals_attempted, three_pointers_made, three_pointers_attempted,
+           free_throws_made, free_throws_attempted);
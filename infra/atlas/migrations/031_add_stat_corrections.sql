@@ -0,0 +1,60 @@
+-- Manual correction workflow for player stat lines
+-- Operators amend or soft-delete rows through PATCH /api/v1/admin/player-stats/{statID}.
+-- Corrected rows are skipped by ingestion upserts so a later ESPN refresh cannot undo them.
+
+ALTER TABLE player_game_stats
+  ADD COLUMN corrected BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN deleted_at TIMESTAMP;
+
+COMMENT ON COLUMN player_game_stats.corrected IS 'Amended by an operator; automated upserts leave the row alone';
+COMMENT ON COLUMN player_game_stats.deleted_at IS 'Soft delete; excluded from every read path';
+
+CREATE TABLE stat_corrections (
+  correction_id SERIAL PRIMARY KEY,
+  stat_id INTEGER NOT NULL REFERENCES player_game_stats(stat_id) ON DELETE CASCADE,
+  action VARCHAR(10) NOT NULL CHECK (action IN ('correct', 'delete', 'restore')),
+  changes JSONB NOT NULL DEFAULT '{}'::jsonb, -- field -> {"old": ..., "new": ...}
+  reason TEXT NOT NULL,
+  corrected_by VARCHAR(100) NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_stat_corrections_stat ON stat_corrections(stat_id, created_at DESC);
+CREATE INDEX idx_stat_corrections_created ON stat_corrections(created_at DESC);
+
+COMMENT ON TABLE stat_corrections IS 'Audit log of manual player stat corrections';
+
+-- Rebuild season averages without soft-deleted rows
+DROP MATERIALIZED VIEW IF EXISTS player_season_averages;
+
+CREATE MATERIALIZED VIEW player_season_averages AS
+SELECT 
+  pgs.player_id,
+  g.season_id,
+  g.sport,
+  COUNT(*) as games_played,
+  AVG(pgs.minutes_played) as avg_minutes,
+  AVG(pgs.points) as ppg,
+  AVG(pgs.rebounds) as rpg,
+  AVG(pgs.assists) as apg,
+  AVG(pgs.steals) as spg,
+  AVG(pgs.blocks) as bpg,
+  AVG(pgs.field_goal_pct) as fg_pct,
+  AVG(pgs.three_point_pct) as three_pt_pct,
+  AVG(pgs.free_throw_pct) as ft_pct,
+  AVG(pgs.true_shooting_pct) as ts_pct,
+  SUM(pgs.points) as total_points,
+  SUM(pgs.rebounds) as total_rebounds,
+  SUM(pgs.assists) as total_assists
+FROM player_game_stats pgs
+JOIN games g ON pgs.game_id = g.game_id
+WHERE pgs.active = true
+  AND pgs.deleted_at IS NULL
+  AND g.game_type NOT IN ('all_star', 'rising_stars', 'tournament_final')
+GROUP BY pgs.player_id, g.season_id, g.sport;
+
+CREATE UNIQUE INDEX idx_player_season_averages ON player_season_averages(player_id, season_id);
+CREATE INDEX idx_player_season_averages_ppg ON player_season_averages(season_id, ppg DESC);
+CREATE INDEX idx_player_season_averages_games ON player_season_averages(season_id, games_played DESC);
+
+COMMENT ON MATERIALIZED VIEW player_season_averages IS 'Pre-calculated player season averages for fast queries, excluding special event games and deleted stat lines. Refresh nightly or after each game day.';
@@ -0,0 +1,3 @@
+-- Revert 032_create_closing_lines.sql
+DROP INDEX IF EXISTS idx_odds_mappings_game_type;
+DROP TABLE IF EXISTS closing_lines;
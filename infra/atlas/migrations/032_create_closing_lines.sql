@@ -0,0 +1,21 @@
+-- Closing lines for mapped games
+-- Alexandria writes one row per game mapping once a market closes; Minerva joins
+-- them against final scores for GET /api/v1/analytics/closing-line-performance.
+
+CREATE TABLE closing_lines (
+  closing_line_id SERIAL PRIMARY KEY,
+  mapping_id INTEGER NOT NULL UNIQUE REFERENCES odds_mappings(mapping_id) ON DELETE CASCADE,
+  home_spread NUMERIC(5,1),                -- Home team line; negative when home is favored
+  total NUMERIC(5,1),                      -- Closing over/under
+  bookmaker VARCHAR(100),
+  closed_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  CONSTRAINT closing_lines_has_line CHECK (home_spread IS NOT NULL OR total IS NOT NULL)
+);
+
+CREATE INDEX idx_odds_mappings_game_type ON odds_mappings(minerva_game_id) WHERE mapping_type = 'game';
+
+COMMENT ON TABLE closing_lines IS 'Closing spread and total per mapped Alexandria event';
+COMMENT ON COLUMN closing_lines.home_spread IS 'Spread from the home team perspective (-5.5 = home favored by 5.5)';
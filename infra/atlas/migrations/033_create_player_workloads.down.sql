@@ -0,0 +1,2 @@
+-- Revert 033_create_player_workloads.sql
+DROP TABLE IF EXISTS player_workloads;
@@ -0,0 +1,23 @@
+-- Nightly player workload snapshots
+-- Written after daily ingestion for every player who appeared in the trailing
+-- 14 days; read by GET /api/v1/players/{id}/workload and the ML feature set.
+
+CREATE TABLE player_workloads (
+  player_id INTEGER NOT NULL REFERENCES players(player_id) ON DELETE CASCADE,
+  as_of_date DATE NOT NULL,
+  minutes_3d NUMERIC(6,1) NOT NULL DEFAULT 0,   -- Minutes in the 3 days ending as_of_date
+  minutes_7d NUMERIC(6,1) NOT NULL DEFAULT 0,
+  minutes_14d NUMERIC(6,1) NOT NULL DEFAULT 0,
+  games_7d INTEGER NOT NULL DEFAULT 0,
+  games_14d INTEGER NOT NULL DEFAULT 0,
+  back_to_backs_14d INTEGER NOT NULL DEFAULT 0, -- Second legs of back-to-backs played
+  back_to_back_minutes_14d NUMERIC(6,1) NOT NULL DEFAULT 0, -- Minutes across both legs
+  fatigue_index NUMERIC(4,1) NOT NULL DEFAULT 0,  -- 0 (rested) to 100 (heaviest load)
+  computed_at TIMESTAMP DEFAULT NOW(),
+
+  PRIMARY KEY (player_id, as_of_date)
+);
+
+CREATE INDEX idx_player_workloads_date ON player_workloads(as_of_date);
+
+COMMENT ON TABLE player_workloads IS 'Rolling minute loads and fatigue index per player per night';
@@ -0,0 +1,3 @@
+-- Revert 034_add_play_shooting_flag.sql
+DROP INDEX IF EXISTS idx_game_plays_late;
+ALTER TABLE game_plays DROP COLUMN IF EXISTS shooting_play;
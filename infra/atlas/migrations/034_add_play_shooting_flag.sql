@@ -0,0 +1,13 @@
+-- Flag shot attempts in play-by-play
+-- ESPN marks field goal and free throw attempts (made or missed) with shootingPlay;
+-- clutch FG% needs misses, which scoring_play alone cannot identify.
+
+ALTER TABLE game_plays ADD COLUMN shooting_play BOOLEAN NOT NULL DEFAULT false;
+
+-- Backfill stored plays from ESPN's "makes"/"misses" wording
+UPDATE game_plays SET shooting_play = true
+WHERE description ~* '\m(makes|misses)\M';
+
+CREATE INDEX idx_game_plays_late ON game_plays(game_id, sequence) WHERE period >= 4 AND clock_seconds <= 300;
+
+COMMENT ON COLUMN game_plays.shooting_play IS 'Field goal or free throw attempt, made or missed';
@@ -0,0 +1,3 @@
+-- Revert 035_add_backfill_rate_limits.sql
+ALTER TABLE backfill_jobs DROP COLUMN IF EXISTS date_delay_ms;
+ALTER TABLE backfill_jobs DROP COLUMN IF EXISTS requests_per_minute;
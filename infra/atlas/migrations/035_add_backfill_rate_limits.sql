@@ -0,0 +1,8 @@
+-- Per-job backfill throughput controls
+-- Season-sized backfills can be slowed down to stay clear of ESPN throttling; 0 means unlimited
+
+ALTER TABLE backfill_jobs ADD COLUMN IF NOT EXISTS requests_per_minute INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE backfill_jobs ADD COLUMN IF NOT EXISTS date_delay_ms INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN backfill_jobs.requests_per_minute IS 'ESPN requests per minute allowed for the job; 0 = unlimited';
+COMMENT ON COLUMN backfill_jobs.date_delay_ms IS 'Pause between scoreboard dates in milliseconds';
@@ -0,0 +1,2 @@
+-- Revert 036_create_backfill_season_checkpoints.sql
+DROP TABLE IF EXISTS backfill_season_checkpoints;
@@ -0,0 +1,25 @@
+-- Multi-season bulk import checkpoints
+-- One row per season imported by `minerva backfill --seasons`; the last completed
+-- date lets an interrupted import resume where it stopped.
+
+CREATE TABLE backfill_season_checkpoints (
+  sport VARCHAR(50) NOT NULL,
+  season_year VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'running',  -- 'running', 'completed', 'failed'
+  last_completed_date DATE,                       -- Resume from the day after
+  dates_completed INTEGER NOT NULL DEFAULT 0,
+  dates_total INTEGER NOT NULL DEFAULT 0,
+  games_changed INTEGER NOT NULL DEFAULT 0,
+  games_unchanged INTEGER NOT NULL DEFAULT 0,
+  rows_changed INTEGER NOT NULL DEFAULT 0,
+  rows_unchanged INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT,
+  started_at TIMESTAMP DEFAULT NOW(),
+  completed_at TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  PRIMARY KEY (sport, season_year),
+  CONSTRAINT backfill_season_checkpoints_valid_status CHECK (status IN ('running', 'completed', 'failed'))
+);
+
+COMMENT ON TABLE backfill_season_checkpoints IS 'Progress of multi-season bulk imports, for resuming after restarts';
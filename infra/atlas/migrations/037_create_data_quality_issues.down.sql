@@ -0,0 +1,2 @@
+-- Revert 037_create_data_quality_issues.sql
+DROP TABLE IF EXISTS data_quality_issues;
@@ -0,0 +1,23 @@
+-- Post-ingest data quality violations
+-- The validator replaces a game's rows each time the game is ingested, so a
+-- corrected box score clears its issues on the next run.
+
+CREATE TABLE data_quality_issues (
+  issue_id BIGSERIAL PRIMARY KEY,
+  game_id INTEGER NOT NULL REFERENCES games(game_id) ON DELETE CASCADE,
+  team_id INTEGER REFERENCES teams(team_id),
+  player_id INTEGER REFERENCES players(player_id) ON DELETE CASCADE,
+  rule VARCHAR(50) NOT NULL,             -- e.g. 'team_points_match', 'shots_made_le_attempted'
+  severity VARCHAR(10) NOT NULL,         -- 'error' or 'warning'
+  message TEXT NOT NULL,
+  expected NUMERIC(10,2),
+  actual NUMERIC(10,2),
+  detected_at TIMESTAMP DEFAULT NOW(),
+
+  CONSTRAINT data_quality_issues_valid_severity CHECK (severity IN ('error', 'warning'))
+);
+
+CREATE INDEX idx_data_quality_issues_game ON data_quality_issues(game_id);
+CREATE INDEX idx_data_quality_issues_rule ON data_quality_issues(rule, detected_at DESC);
+
+COMMENT ON TABLE data_quality_issues IS 'Box score consistency violations found after ingestion';
@@ -0,0 +1,2 @@
+-- Revert 038_create_game_update_log.sql
+DROP TABLE IF EXISTS game_update_log;
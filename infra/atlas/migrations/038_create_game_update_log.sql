@@ -0,0 +1,16 @@
+-- Append-only log of ingested game changes
+-- The ingester writes one row per game write that changed the score, status,
+-- period, or clock, and one per box score refresh that moved any stat line.
+-- GET /games/{id}/changes?since= folds the rows after a timestamp into one diff.
+
+CREATE TABLE game_update_log (
+  update_id BIGSERIAL PRIMARY KEY,
+  game_id INTEGER NOT NULL REFERENCES games(game_id) ON DELETE CASCADE,
+  fields JSONB NOT NULL DEFAULT '{}',      -- {home_score: {from: 98, to: 101}, clock: {from: '2:14', to: '1:52'}}
+  stats JSONB NOT NULL DEFAULT '[]',       -- [{player_id, team_id, deltas: {points: 3, field_goals_made: 1}}]
+  changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_game_update_log_game_changed ON game_update_log(game_id, changed_at);
+
+COMMENT ON TABLE game_update_log IS 'Per-write game and box score diffs for pull-based sync';
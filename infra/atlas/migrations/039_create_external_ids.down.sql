@@ -0,0 +1,2 @@
+-- Revert 039_create_external_ids.sql
+DROP TABLE IF EXISTS external_ids;
@@ -0,0 +1,39 @@
+-- Cross-source ID mappings
+-- Players, teams, and games keep their ESPN ID in external_id; this table holds
+-- every ID an entity is known by, so one entity can carry alternate ESPN IDs and
+-- IDs from NBA Stats, Basketball-Reference, and Alexandria at the same time.
+
+CREATE TABLE external_ids (
+  entity_type VARCHAR(10) NOT NULL,       -- 'player', 'team', 'game'
+  source VARCHAR(30) NOT NULL,            -- 'espn', 'nba_stats', 'basketball_reference', 'alexandria'
+  source_id VARCHAR(100) NOT NULL,        -- the source's own ID, e.g. 'jamesle01'
+  internal_id INTEGER NOT NULL,           -- players.player_id, teams.team_id, or games.game_id
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  PRIMARY KEY (entity_type, source, source_id),
+  CONSTRAINT external_ids_valid_entity CHECK (entity_type IN ('player', 'team', 'game'))
+);
+
+CREATE INDEX idx_external_ids_internal ON external_ids(entity_type, internal_id);
+
+COMMENT ON TABLE external_ids IS 'Maps source-specific player, team, and game IDs to Minerva IDs';
+
+-- Seed from the IDs already on record
+INSERT INTO external_ids (entity_type, source, source_id, internal_id)
+SELECT 'team', 'espn', external_id, team_id FROM teams WHERE external_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO external_ids (entity_type, source, source_id, internal_id)
+SELECT 'player', 'espn', external_id, player_id FROM players WHERE external_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO external_ids (entity_type, source, source_id, internal_id)
+SELECT 'game', 'espn', external_id, game_id FROM games WHERE external_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO external_ids (entity_type, source, source_id, internal_id)
+SELECT 'game', 'alexandria', alexandria_event_id, minerva_game_id
+FROM odds_mappings
+WHERE mapping_type = 'game' AND minerva_game_id IS NOT NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+-- Revert 040_create_event_outbox.sql
+DROP TABLE IF EXISTS event_outbox;
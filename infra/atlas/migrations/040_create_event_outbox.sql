@@ -0,0 +1,21 @@
+-- Transactional outbox for Redis stream publishes
+-- Publishers insert the encoded payload here (inside the writer's transaction
+-- when it has one); the leader's outbox relay XADDs pending rows to their
+-- stream and stamps published_at, so a Redis outage delays delivery instead of
+-- dropping it. Delivery is at-least-once: a relay that dies between XADD and
+-- the update republishes the row.
+
+CREATE TABLE event_outbox (
+  outbox_id BIGSERIAL PRIMARY KEY,
+  stream TEXT NOT NULL,                    -- e.g. games.live.basketball_nba
+  payload JSONB NOT NULL,                  -- becomes the entry's data field
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  published_at TIMESTAMPTZ,                -- NULL until the relay has published it
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT
+);
+
+CREATE INDEX idx_event_outbox_pending ON event_outbox(outbox_id) WHERE published_at IS NULL;
+CREATE INDEX idx_event_outbox_published ON event_outbox(published_at) WHERE published_at IS NOT NULL;
+
+COMMENT ON TABLE event_outbox IS 'Pending and recently published Redis stream entries (at-least-once delivery)';
@@ -0,0 +1,4 @@
+-- Revert 041_add_games_stats_complete.sql
+DROP INDEX IF EXISTS idx_games_stats_pending;
+ALTER TABLE games DROP COLUMN IF EXISTS stats_complete;
+ALTER TABLE games DROP COLUMN IF EXISTS finalized_at;
@@ -0,0 +1,17 @@
+-- Track when a final game's box score has settled
+-- ESPN can mark a game final before its stat totals stop moving. The
+-- stats_verification job refetches each final game's box score between two and
+-- four hours after it went final and sets stats_complete once a refetch changes
+-- nothing (or the four hour window closes).
+
+ALTER TABLE games ADD COLUMN IF NOT EXISTS finalized_at TIMESTAMPTZ;
+ALTER TABLE games ADD COLUMN IF NOT EXISTS stats_complete BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Games already final when this ships are long settled
+UPDATE games SET stats_complete = TRUE, finalized_at = updated_at WHERE status = 'final';
+
+CREATE INDEX IF NOT EXISTS idx_games_stats_pending ON games(finalized_at)
+  WHERE status = 'final' AND NOT stats_complete;
+
+COMMENT ON COLUMN games.finalized_at IS 'When ingestion first saw the game as final';
+COMMENT ON COLUMN games.stats_complete IS 'Box score verified settled after the post-final refetch window';
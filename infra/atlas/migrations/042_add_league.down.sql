@@ -0,0 +1,21 @@
+-- Revert 042_add_league.sql
+-- Development league rows have nowhere to go once the column is gone
+DELETE FROM player_game_stats WHERE game_id IN (SELECT game_id FROM games WHERE league <> 'nba');
+DELETE FROM team_game_stats WHERE game_id IN (SELECT game_id FROM games WHERE league <> 'nba');
+DELETE FROM odds_mappings WHERE minerva_game_id IN (SELECT game_id FROM games WHERE league <> 'nba');
+DELETE FROM games WHERE league <> 'nba';
+DELETE FROM seasons WHERE league <> 'nba';
+DELETE FROM teams WHERE league <> 'nba';
+
+DROP INDEX IF EXISTS idx_games_league_date;
+
+ALTER TABLE teams DROP CONSTRAINT teams_unique_sport_abbr;
+ALTER TABLE teams ADD CONSTRAINT teams_unique_sport_abbr UNIQUE(sport, abbreviation);
+ALTER TABLE teams DROP CONSTRAINT teams_unique_sport_external;
+ALTER TABLE teams ADD CONSTRAINT teams_unique_sport_external UNIQUE(sport, external_id);
+ALTER TABLE seasons DROP CONSTRAINT seasons_unique_sport_year;
+ALTER TABLE seasons ADD CONSTRAINT seasons_unique_sport_year UNIQUE(sport, season_year, season_type);
+
+ALTER TABLE teams DROP COLUMN IF EXISTS league;
+ALTER TABLE seasons DROP COLUMN IF EXISTS league;
+ALTER TABLE games DROP COLUMN IF EXISTS league;
@@ -0,0 +1,25 @@
+-- Store Summer League and G League games beside NBA games
+-- Development leagues get their own seasons (and, for the G League, their own
+-- teams), so season_id-keyed aggregates never mix them with the NBA. Queries
+-- that group by season_year filter on games.league = 'nba' instead.
+
+ALTER TABLE games ADD COLUMN IF NOT EXISTS league VARCHAR(20) NOT NULL DEFAULT 'nba'
+  CONSTRAINT games_valid_league CHECK (league IN ('nba', 'summer_league', 'g_league'));
+ALTER TABLE seasons ADD COLUMN IF NOT EXISTS league VARCHAR(20) NOT NULL DEFAULT 'nba'
+  CONSTRAINT seasons_valid_league CHECK (league IN ('nba', 'summer_league', 'g_league'));
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS league VARCHAR(20) NOT NULL DEFAULT 'nba'
+  CONSTRAINT teams_valid_league CHECK (league IN ('nba', 'summer_league', 'g_league'));
+
+-- Each league numbers its own seasons and teams
+ALTER TABLE seasons DROP CONSTRAINT seasons_unique_sport_year;
+ALTER TABLE seasons ADD CONSTRAINT seasons_unique_sport_year UNIQUE(sport, league, season_year, season_type);
+ALTER TABLE teams DROP CONSTRAINT teams_unique_sport_external;
+ALTER TABLE teams ADD CONSTRAINT teams_unique_sport_external UNIQUE(sport, league, external_id);
+ALTER TABLE teams DROP CONSTRAINT teams_unique_sport_abbr;
+ALTER TABLE teams ADD CONSTRAINT teams_unique_sport_abbr UNIQUE(sport, league, abbreviation);
+
+CREATE INDEX IF NOT EXISTS idx_games_league_date ON games(league, game_date) WHERE league <> 'nba';
+
+COMMENT ON COLUMN games.league IS 'nba, summer_league, or g_league';
+COMMENT ON COLUMN seasons.league IS 'League the season belongs to; development seasons share the NBA season_year they lead into';
+COMMENT ON COLUMN teams.league IS 'nba or g_league; Summer League games use NBA franchises';
@@ -0,0 +1,2 @@
+-- Revert 043_create_player_transactions.sql
+DROP TABLE IF EXISTS player_transactions;
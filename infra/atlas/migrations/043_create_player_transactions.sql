@@ -0,0 +1,26 @@
+-- Roster transactions (signings, waivers, trades) from the ESPN transactions feed
+-- The transaction_sync job stores each new entry, moves the player in
+-- player_team_history when the player is known, and publishes the entry on the
+-- transactions stream. Entries are matched by team, date, and description, since
+-- ESPN does not number them.
+
+CREATE TABLE player_transactions (
+  transaction_id SERIAL PRIMARY KEY,
+  sport VARCHAR(50) NOT NULL,
+  transaction_date DATE NOT NULL,
+  transaction_type VARCHAR(20) NOT NULL
+    CHECK (transaction_type IN ('signing', 'waiver', 'release', 'trade', 'claim', 'assignment', 'recall', 'other')),
+  team_id INTEGER NOT NULL REFERENCES teams(team_id),
+  player_id INTEGER REFERENCES players(player_id) ON DELETE SET NULL, -- NULL when the entry names no ESPN athlete
+  description TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW(),
+
+  CONSTRAINT player_transactions_unique UNIQUE(sport, team_id, transaction_date, description)
+);
+
+CREATE INDEX idx_player_transactions_date ON player_transactions(sport, transaction_date DESC);
+CREATE INDEX idx_player_transactions_team ON player_transactions(team_id, transaction_date DESC);
+CREATE INDEX idx_player_transactions_player ON player_transactions(player_id) WHERE player_id IS NOT NULL;
+
+COMMENT ON TABLE player_transactions IS 'Player movement events from the ESPN transactions feed';
+COMMENT ON COLUMN player_transactions.transaction_type IS 'Classified from the description; other when no keyword matches';
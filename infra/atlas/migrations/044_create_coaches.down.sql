@@ -0,0 +1,3 @@
+-- Revert 044_create_coaches.sql
+DROP TABLE IF EXISTS coach_team_history;
+DROP TABLE IF EXISTS coaches;
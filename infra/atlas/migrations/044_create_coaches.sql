@@ -0,0 +1,44 @@
+-- Head coaches and their tenure with each team
+-- roster_sync reads the coach from each ESPN team roster response. When a team's
+-- head coach differs from its open coach_team_history row, the row is closed the
+-- day before and a new one opened, and a coach.changed event is published.
+
+CREATE TABLE coaches (
+  coach_id SERIAL PRIMARY KEY,
+  sport VARCHAR(50) NOT NULL,
+  external_id VARCHAR(50) NOT NULL,       -- ESPN coach ID
+  first_name VARCHAR(100),
+  last_name VARCHAR(100),
+  full_name VARCHAR(200) NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  CONSTRAINT coaches_unique UNIQUE(sport, external_id)
+);
+
+CREATE TABLE coach_team_history (
+  history_id SERIAL PRIMARY KEY,
+  coach_id INTEGER NOT NULL REFERENCES coaches(coach_id) ON DELETE CASCADE,
+  team_id INTEGER NOT NULL REFERENCES teams(team_id),
+  season_id INTEGER REFERENCES seasons(season_id),
+  start_date DATE NOT NULL,
+  end_date DATE,                           -- NULL while the coach leads the team
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  CONSTRAINT coach_team_history_valid_dates CHECK (end_date IS NULL OR end_date >= start_date),
+  CONSTRAINT coach_team_history_unique UNIQUE(coach_id, team_id, start_date)
+);
+
+CREATE INDEX idx_coach_team_history_team ON coach_team_history(team_id, start_date DESC);
+CREATE INDEX idx_coach_team_history_coach ON coach_team_history(coach_id);
+CREATE UNIQUE INDEX idx_coach_team_history_current ON coach_team_history(team_id) WHERE end_date IS NULL;
+
+CREATE TRIGGER update_coaches_updated_at BEFORE UPDATE ON coaches
+  FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_coach_team_history_updated_at BEFORE UPDATE ON coach_team_history
+  FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE coaches IS 'Head coaches seen in ESPN team rosters';
+COMMENT ON TABLE coach_team_history IS 'Head coach tenure per team; at most one open row per team';
+COMMENT ON COLUMN coach_team_history.start_date IS 'First roster sync that saw the coach, not necessarily the hire date';
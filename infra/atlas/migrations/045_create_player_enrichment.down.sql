@@ -0,0 +1,2 @@
+-- Revert 045_create_player_enrichment.sql
+DROP TABLE IF EXISTS player_enrichment;
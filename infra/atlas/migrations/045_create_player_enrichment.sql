@@ -0,0 +1,20 @@
+-- Progress of the player_enrichment job, which fills missing biographical
+-- fields (birth date and place, height, college, nationality) from ESPN athlete
+-- profiles. One row per player the job has tried; next_attempt_at spaces out
+-- retries for profiles that had nothing new and for failed fetches.
+
+CREATE TABLE player_enrichment (
+  player_id INTEGER PRIMARY KEY REFERENCES players(player_id) ON DELETE CASCADE,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_status VARCHAR(20) NOT NULL
+    CHECK (last_status IN ('enriched', 'no_new_data', 'not_found', 'failed')),
+  fields_filled TEXT[] NOT NULL DEFAULT '{}',  -- columns the last attempt filled
+  last_error TEXT,
+  last_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  next_attempt_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_player_enrichment_next ON player_enrichment(next_attempt_at);
+
+COMMENT ON TABLE player_enrichment IS 'Biographical enrichment attempts per player';
+COMMENT ON COLUMN player_enrichment.next_attempt_at IS 'Earliest time the player is eligible again while fields are still missing';
@@ -0,0 +1,2 @@
+-- Revert 046_add_player_seconds_played.sql
+ALTER TABLE player_game_stats DROP COLUMN IF EXISTS seconds_played;
@@ -0,0 +1,16 @@
+-- Exact playing time for player stat lines. minutes_played is NUMERIC(5,2), so
+-- box score MM:SS values lose their seconds and sums drift; seconds_played keeps
+-- the value as reported. minutes_played stays for existing readers and is written
+-- alongside it.
+
+ALTER TABLE player_game_stats
+  ADD COLUMN seconds_played INTEGER
+    CONSTRAINT player_game_stats_valid_seconds CHECK (seconds_played IS NULL OR seconds_played >= 0);
+
+-- Two decimal places of a minute are finer than a second, so rounding recovers
+-- the original seconds for rows written before this column existed
+UPDATE player_game_stats
+SET seconds_played = ROUND(minutes_played * 60)::integer
+WHERE minutes_played IS NOT NULL;
+
+COMMENT ON COLUMN player_game_stats.seconds_played IS 'Playing time in whole seconds; minutes_played is derived from it';
@@ -0,0 +1,8 @@
+-- Revert 047_add_game_duration.sql
+UPDATE team_game_stats SET possessions = NULL, pace = NULL;
+
+ALTER TABLE games
+  DROP COLUMN IF EXISTS game_minutes,
+  DROP COLUMN IF EXISTS overtime_periods;
+
+ALTER TABLE games ADD COLUMN overtime_periods INTEGER DEFAULT 0;
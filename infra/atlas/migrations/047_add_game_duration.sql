@@ -0,0 +1,38 @@
+-- Game length for overtime-aware normalization. games.overtime_periods has
+-- existed since 015 but was never written; it is replaced by a column derived
+-- from the final period, alongside game_minutes (48 plus 5 per overtime).
+-- team_game_stats.possessions and pace were likewise never filled; ingestion now
+-- sets them once both teams' totals are stored, and existing games are
+-- backfilled here.
+
+ALTER TABLE games DROP COLUMN overtime_periods;
+
+ALTER TABLE games
+  ADD COLUMN overtime_periods SMALLINT GENERATED ALWAYS AS (
+    CASE WHEN status = 'final' AND period > 4 THEN period - 4 ELSE 0 END
+  ) STORED,
+  ADD COLUMN game_minutes SMALLINT GENERATED ALWAYS AS (
+    48 + 5 * CASE WHEN status = 'final' AND period > 4 THEN period - 4 ELSE 0 END
+  ) STORED;
+
+COMMENT ON COLUMN games.overtime_periods IS 'Overtime periods played; 0 until the game is final';
+COMMENT ON COLUMN games.game_minutes IS 'Game length in minutes: regulation plus 5 per overtime';
+
+-- Possessions: FGA + 0.44 * FTA - ORB + TOV. Pace: both teams' average
+-- possessions per 48 minutes, set only for final games with both teams' totals.
+WITH est AS (
+  SELECT game_id, team_id,
+    ROUND(field_goals_attempted + 0.44 * free_throws_attempted - offensive_rebounds + turnovers)::integer AS possessions
+  FROM team_game_stats
+), paced AS (
+  SELECT e.game_id, e.team_id, e.possessions,
+    CASE WHEN g.status = 'final' AND COUNT(e.possessions) OVER (PARTITION BY e.game_id) = 2
+      THEN ROUND(48.0 * AVG(e.possessions) OVER (PARTITION BY e.game_id) / g.game_minutes, 2)
+    END AS pace
+  FROM est e
+  JOIN games g ON g.game_id = e.game_id
+)
+UPDATE team_game_stats t
+SET possessions = p.possessions, pace = p.pace
+FROM paced p
+WHERE t.game_id = p.game_id AND t.team_id = p.team_id;
@@ -0,0 +1,2 @@
+-- Revert 048_create_news_articles.sql
+DROP TABLE IF EXISTS news_articles;
@@ -0,0 +1,28 @@
+-- Article metadata from ESPN news feeds, stored by the news_sync job from the
+-- league feed and each team's feed. An article appears in every feed it is
+-- tagged for, so the related team and player IDs are merged across feeds.
+-- Only metadata is kept; link points at the article on ESPN.
+
+CREATE TABLE news_articles (
+  article_id SERIAL PRIMARY KEY,
+  sport VARCHAR(50) NOT NULL,
+  external_id VARCHAR(50) NOT NULL,           -- ESPN article ID
+  article_type VARCHAR(30),                   -- HeadlineNews, Story, Media, ...
+  headline TEXT NOT NULL,
+  description TEXT,
+  link TEXT,
+  published_at TIMESTAMP NOT NULL,
+  team_ids INTEGER[] NOT NULL DEFAULT '{}',   -- teams the article is tagged with
+  player_ids INTEGER[] NOT NULL DEFAULT '{}', -- stored players the article is tagged with
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  CONSTRAINT news_articles_unique UNIQUE(sport, external_id)
+);
+
+CREATE INDEX idx_news_articles_published ON news_articles(sport, published_at DESC);
+CREATE INDEX idx_news_articles_teams ON news_articles USING GIN (team_ids);
+CREATE INDEX idx_news_articles_players ON news_articles USING GIN (player_ids);
+
+COMMENT ON TABLE news_articles IS 'ESPN news article metadata with related teams and players';
+COMMENT ON COLUMN news_articles.player_ids IS 'Only athletes already stored in players are linked';
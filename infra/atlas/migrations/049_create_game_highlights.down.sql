@@ -0,0 +1,3 @@
+-- Revert 049_create_game_highlights.sql
+DROP INDEX IF EXISTS idx_game_plays_external;
+DROP TABLE IF EXISTS game_highlights;
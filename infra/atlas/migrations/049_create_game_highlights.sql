@@ -0,0 +1,26 @@
+-- Video highlights from the ESPN game summary "videos" list, refreshed with
+-- each box score fetch. ESPN tags some clips with the play they show; that ID
+-- is kept as play_external_id and joined to game_plays when read, so a clip
+-- stored before its play still links once play-by-play arrives.
+
+CREATE TABLE game_highlights (
+  highlight_id SERIAL PRIMARY KEY,
+  game_id INTEGER NOT NULL REFERENCES games(game_id) ON DELETE CASCADE,
+  external_id VARCHAR(50) NOT NULL,        -- ESPN video ID
+  headline TEXT NOT NULL,
+  description TEXT,
+  duration_seconds INTEGER,
+  thumbnail_url TEXT,
+  web_url TEXT,                            -- ESPN video page
+  video_url TEXT,                          -- direct media source
+  play_external_id VARCHAR(50),            -- ESPN play ID (game_plays.external_id), when tagged
+  published_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  CONSTRAINT game_highlights_unique UNIQUE(game_id, external_id)
+);
+
+CREATE INDEX idx_game_plays_external ON game_plays(game_id, external_id) WHERE external_id IS NOT NULL;
+
+COMMENT ON TABLE game_highlights IS 'ESPN video highlight links per game';
@@ -0,0 +1,6 @@
+-- Revert 050_add_player_search_indexes.sql
+DROP INDEX IF EXISTS idx_players_last_name_prefix;
+DROP INDEX IF EXISTS idx_players_full_name_prefix;
+DROP INDEX IF EXISTS idx_players_display_name_trgm;
+DROP INDEX IF EXISTS idx_players_full_name_trgm;
+DROP FUNCTION IF EXISTS player_search_key(TEXT);
@@ -0,0 +1,22 @@
+-- Fuzzy, prefix, and accent-insensitive player search
+-- player_search_key folds case and strips accents ('Jokić' -> 'jokic') so names
+-- compare equal however they are typed. unaccent() is only STABLE (it reads its
+-- dictionary), so it is wrapped with the dictionary named to allow indexing.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+CREATE OR REPLACE FUNCTION player_search_key(name TEXT)
+RETURNS TEXT AS $$
+  SELECT lower(public.unaccent('public.unaccent'::regdictionary, COALESCE(name, '')));
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+-- Trigram indexes serve fuzzy (<%) and substring (LIKE '%x%') matches
+CREATE INDEX idx_players_full_name_trgm ON players USING GIN (player_search_key(full_name) gin_trgm_ops);
+CREATE INDEX idx_players_display_name_trgm ON players USING GIN (player_search_key(display_name) gin_trgm_ops);
+
+-- B-tree indexes serve autocomplete prefix matches (LIKE 'x%')
+CREATE INDEX idx_players_full_name_prefix ON players (player_search_key(full_name) text_pattern_ops);
+CREATE INDEX idx_players_last_name_prefix ON players (player_search_key(last_name) text_pattern_ops);
+
+COMMENT ON FUNCTION player_search_key(TEXT) IS 'Lowercased, unaccented name used by player search';
@@ -0,0 +1,2 @@
+-- Revert 051_create_team_aliases.sql
+DROP TABLE IF EXISTS team_aliases;
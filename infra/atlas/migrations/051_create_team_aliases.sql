@@ -0,0 +1,39 @@
+-- Informal team names used to resolve free-text team references (Google
+-- scoreboards, /teams/search). A team's abbreviation, nickname (short_name),
+-- city, and full name are matched from teams directly; this table holds the
+-- extra spellings. Aliases are keyed by abbreviation rather than team_id so the
+-- seed rows apply whenever the teams themselves are seeded.
+
+CREATE TABLE team_aliases (
+  alias_id SERIAL PRIMARY KEY,
+  league VARCHAR(20) NOT NULL DEFAULT 'nba',
+  abbreviation VARCHAR(10) NOT NULL,       -- teams.abbreviation within the league
+  alias VARCHAR(100) NOT NULL,             -- 'Sixers', 'LA Clippers', 'Dubs'
+  created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_team_aliases_unique ON team_aliases(league, lower(alias));
+
+INSERT INTO team_aliases (abbreviation, alias) VALUES
+  ('BKN', 'BK Nets'),
+  ('CLE', 'Cavs'),
+  ('DAL', 'Mavs'),
+  ('GSW', 'GS Warriors'),
+  ('GSW', 'Dubs'),
+  ('LAC', 'Los Angeles Clippers'),
+  ('LAL', 'LA Lakers'),
+  ('MIN', 'Wolves'),
+  ('MIN', 'T-Wolves'),
+  ('NOP', 'NO Pelicans'),
+  ('NOP', 'Pels'),
+  ('NYK', 'NY Knicks'),
+  ('OKC', 'OKC Thunder'),
+  ('PHI', 'Sixers'),
+  ('PHI', 'Philly'),
+  ('PHX', 'PHO'),
+  ('POR', 'Blazers'),
+  ('SAS', 'SA Spurs'),
+  ('UTA', 'UTAH'),
+  ('WAS', 'WSH');
+
+COMMENT ON TABLE team_aliases IS 'Extra team names for text matching, beyond teams abbreviation/short_name/city/full_name';
@@ -0,0 +1,2 @@
+-- Revert 052_create_player_aliases.sql
+DROP TABLE IF EXISTS player_aliases;
@@ -0,0 +1,23 @@
+-- Alternate names for players: nicknames ('Greek Freak'), earlier or variant
+-- spellings ('Nic Claxton' for 'Nicolas Claxton'), and transliterations
+-- ('Dzanan Musa'). Search matches them like names, ESPN ingestion uses them to
+-- recognise a known player arriving under a new ID, and merging a duplicate
+-- records its name here.
+
+CREATE TABLE player_aliases (
+  alias_id SERIAL PRIMARY KEY,
+  player_id INTEGER NOT NULL REFERENCES players(player_id) ON DELETE CASCADE,
+  alias VARCHAR(150) NOT NULL,
+  alias_type VARCHAR(20) NOT NULL DEFAULT 'nickname',  -- 'nickname', 'spelling', 'transliteration'
+  source VARCHAR(20) NOT NULL DEFAULT 'manual',        -- 'manual', 'merge'
+  created_at TIMESTAMP DEFAULT NOW(),
+  CONSTRAINT player_aliases_type_check CHECK (alias_type IN ('nickname', 'spelling', 'transliteration'))
+);
+
+CREATE UNIQUE INDEX idx_player_aliases_unique ON player_aliases(player_id, player_search_key(alias));
+
+-- Exact lookups during ingestion, prefix matches for autocomplete, trigrams for search
+CREATE INDEX idx_player_aliases_key ON player_aliases (player_search_key(alias) text_pattern_ops);
+CREATE INDEX idx_player_aliases_trgm ON player_aliases USING GIN (player_search_key(alias) gin_trgm_ops);
+
+COMMENT ON TABLE player_aliases IS 'Alternate player names matched by search, ingestion name resolution, and merges';
@@ -0,0 +1,10 @@
+-- Revert 053_add_foul_and_scoring_breakdown_stats.sql
+ALTER TABLE team_game_stats
+  DROP COLUMN IF EXISTS points_in_paint,
+  DROP COLUMN IF EXISTS fast_break_points,
+  DROP COLUMN IF EXISTS flagrant_fouls,
+  DROP COLUMN IF EXISTS technical_fouls;
+
+ALTER TABLE player_game_stats
+  DROP COLUMN IF EXISTS flagrant_fouls,
+  DROP COLUMN IF EXISTS technical_fouls;
@@ -0,0 +1,21 @@
+-- Technical and flagrant fouls per player and team, and the team scoring
+-- breakdowns ESPN reports for some games. ESPN omits these columns from many
+-- box scores, so they are nullable: NULL means not reported, not zero.
+
+ALTER TABLE player_game_stats
+  ADD COLUMN technical_fouls INTEGER
+    CONSTRAINT player_game_stats_valid_technical_fouls CHECK (technical_fouls IS NULL OR technical_fouls >= 0),
+  ADD COLUMN flagrant_fouls INTEGER
+    CONSTRAINT player_game_stats_valid_flagrant_fouls CHECK (flagrant_fouls IS NULL OR flagrant_fouls >= 0);
+
+ALTER TABLE team_game_stats
+  ADD COLUMN technical_fouls INTEGER
+    CONSTRAINT team_game_stats_valid_technical_fouls CHECK (technical_fouls IS NULL OR technical_fouls >= 0),
+  ADD COLUMN flagrant_fouls INTEGER
+    CONSTRAINT team_game_stats_valid_flagrant_fouls CHECK (flagrant_fouls IS NULL OR flagrant_fouls >= 0),
+  ADD COLUMN fast_break_points INTEGER
+    CONSTRAINT team_game_stats_valid_fast_break_points CHECK (fast_break_points IS NULL OR fast_break_points >= 0),
+  ADD COLUMN points_in_paint INTEGER
+    CONSTRAINT team_game_stats_valid_points_in_paint CHECK (points_in_paint IS NULL OR points_in_paint >= 0);
+
+COMMENT ON COLUMN team_game_stats.technical_fouls IS 'Technicals charged to the team, its players, and its bench; NULL when ESPN did not report them';
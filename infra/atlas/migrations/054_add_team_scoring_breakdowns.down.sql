@@ -0,0 +1,6 @@
+-- Revert 054_add_team_scoring_breakdowns.sql
+ALTER TABLE team_game_stats
+  DROP COLUMN IF EXISTS largest_lead,
+  DROP COLUMN IF EXISTS bench_points,
+  DROP COLUMN IF EXISTS second_chance_points,
+  DROP COLUMN IF EXISTS points_off_turnovers;
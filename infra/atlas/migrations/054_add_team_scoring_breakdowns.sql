@@ -0,0 +1,16 @@
+-- More of the team scoring breakdowns in ESPN's team statistics, next to the
+-- fast-break and paint points added in 053. bench_points is summed from the
+-- player lines when ESPN does not report it. NULL means not reported.
+
+ALTER TABLE team_game_stats
+  ADD COLUMN points_off_turnovers INTEGER
+    CONSTRAINT team_game_stats_valid_points_off_turnovers CHECK (points_off_turnovers IS NULL OR points_off_turnovers >= 0),
+  ADD COLUMN second_chance_points INTEGER
+    CONSTRAINT team_game_stats_valid_second_chance_points CHECK (second_chance_points IS NULL OR second_chance_points >= 0),
+  ADD COLUMN bench_points INTEGER
+    CONSTRAINT team_game_stats_valid_bench_points CHECK (bench_points IS NULL OR bench_points >= 0),
+  ADD COLUMN largest_lead INTEGER
+    CONSTRAINT team_game_stats_valid_largest_lead CHECK (largest_lead IS NULL OR largest_lead >= 0);
+
+COMMENT ON COLUMN team_game_stats.points_off_turnovers IS 'Points scored off the opponent''s turnovers (ESPN turnoverPoints)';
+COMMENT ON COLUMN team_game_stats.largest_lead IS 'Largest lead the team held; 0 if it never led';
@@ -0,0 +1,6 @@
+-- Revert 055_add_game_lead_tracking.sql
+ALTER TABLE games
+  DROP COLUMN IF EXISTS times_tied,
+  DROP COLUMN IF EXISTS lead_changes,
+  DROP COLUMN IF EXISTS away_largest_lead,
+  DROP COLUMN IF EXISTS home_largest_lead;
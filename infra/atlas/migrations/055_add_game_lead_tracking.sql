@@ -0,0 +1,55 @@
+-- Competitiveness of a game: each team's largest lead, how often the lead
+-- changed hands, and how often the score was tied after a basket. Ingestion
+-- computes them from the scoring plays, taking largest leads from ESPN's team
+-- statistics where reported. NULL means no plays or team statistics stored.
+
+ALTER TABLE games
+  ADD COLUMN home_largest_lead SMALLINT
+    CONSTRAINT games_valid_home_largest_lead CHECK (home_largest_lead IS NULL OR home_largest_lead >= 0),
+  ADD COLUMN away_largest_lead SMALLINT
+    CONSTRAINT games_valid_away_largest_lead CHECK (away_largest_lead IS NULL OR away_largest_lead >= 0),
+  ADD COLUMN lead_changes SMALLINT
+    CONSTRAINT games_valid_lead_changes CHECK (lead_changes IS NULL OR lead_changes >= 0),
+  ADD COLUMN times_tied SMALLINT
+    CONSTRAINT games_valid_times_tied CHECK (times_tied IS NULL OR times_tied >= 0);
+
+COMMENT ON COLUMN games.home_largest_lead IS 'Largest lead the home team held; 0 if it never led';
+COMMENT ON COLUMN games.away_largest_lead IS 'Largest lead the away team held; 0 if it never led';
+COMMENT ON COLUMN games.lead_changes IS 'Times the lead passed from one team to the other, ties in between not counted';
+COMMENT ON COLUMN games.times_tied IS 'Scoring plays that left the game tied';
+
+-- Backfill games with stored play-by-play
+WITH scores AS (
+  SELECT game_id, sequence, home_score - away_score AS diff
+  FROM game_plays
+  WHERE scoring_play
+), leaders AS (
+  SELECT game_id, SIGN(diff) AS leader,
+    LAG(SIGN(diff)) OVER (PARTITION BY game_id ORDER BY sequence) AS prev_leader
+  FROM scores
+  WHERE diff <> 0
+), tracked AS (
+  SELECT s.game_id,
+    GREATEST(MAX(s.diff), 0) AS home_largest_lead,
+    GREATEST(-MIN(s.diff), 0) AS away_largest_lead,
+    COUNT(*) FILTER (WHERE s.diff = 0) AS times_tied,
+    COALESCE((SELECT COUNT(*) FROM leaders l WHERE l.game_id = s.game_id AND l.leader <> l.prev_leader), 0) AS lead_changes
+  FROM scores s
+  GROUP BY s.game_id
+)
+UPDATE games g
+SET home_largest_lead = t.home_largest_lead,
+  away_largest_lead = t.away_largest_lead,
+  lead_changes = t.lead_changes,
+  times_tied = t.times_tied
+FROM tracked t
+WHERE g.game_id = t.game_id;
+
+-- ESPN's largest leads win over play-derived ones
+UPDATE games g
+SET home_largest_lead = COALESCE(h.largest_lead, g.home_largest_lead),
+  away_largest_lead = COALESCE(a.largest_lead, g.away_largest_lead)
+FROM team_game_stats h, team_game_stats a
+WHERE h.game_id = g.game_id AND h.is_home
+  AND a.game_id = g.game_id AND NOT a.is_home
+  AND (h.largest_lead IS NOT NULL OR a.largest_lead IS NOT NULL);
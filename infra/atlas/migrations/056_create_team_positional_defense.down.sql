@@ -0,0 +1,2 @@
+-- Revert 056_create_team_positional_defense.sql
+DROP TABLE IF EXISTS team_positional_defense;
@@ -0,0 +1,26 @@
+-- Nightly positional defense per team and season: what players at each
+-- position have produced against the team, per game. Written after daily
+-- ingestion; read by GET /api/v1/teams/{id}/positional-defense, the league
+-- table at /analytics/positional-defense, and the ML feature set.
+-- Positions are the players' listed ones, with generic tags folded in: G counts
+-- as SG, F and G-F as SF, F-C as PF, and C-F as C.
+
+CREATE TABLE team_positional_defense (
+  team_id INTEGER NOT NULL REFERENCES teams(team_id) ON DELETE CASCADE,
+  season_id INTEGER NOT NULL REFERENCES seasons(season_id) ON DELETE CASCADE,
+  position VARCHAR(2) NOT NULL CHECK (position IN ('PG', 'SG', 'SF', 'PF', 'C')),
+  games INTEGER NOT NULL DEFAULT 0,                  -- Final games the position appeared against the team
+  points_per_game NUMERIC(5,1) NOT NULL DEFAULT 0,   -- Summed over every player at the position
+  rebounds_per_game NUMERIC(5,1) NOT NULL DEFAULT 0,
+  assists_per_game NUMERIC(5,1) NOT NULL DEFAULT 0,
+  threes_per_game NUMERIC(5,1) NOT NULL DEFAULT 0,
+  fg_pct NUMERIC(4,3),                               -- NULL without attempts
+  points_vs_league NUMERIC(5,1) NOT NULL DEFAULT 0,  -- points_per_game minus the league average for the position
+  computed_at TIMESTAMP DEFAULT NOW(),
+
+  PRIMARY KEY (team_id, season_id, position)
+);
+
+CREATE INDEX idx_team_positional_defense_season ON team_positional_defense(season_id, position);
+
+COMMENT ON TABLE team_positional_defense IS 'Per-game production allowed by each team to each position, per season';
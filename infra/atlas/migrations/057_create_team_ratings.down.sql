@@ -0,0 +1,2 @@
+-- Revert 057_create_team_ratings.sql
+DROP TABLE IF EXISTS team_ratings;
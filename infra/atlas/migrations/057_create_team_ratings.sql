@@ -0,0 +1,25 @@
+-- Elo ratings per team after each final NBA game, independent of betting
+-- markets. Written by the rating engine after daily ingestion; read by
+-- GET /api/v1/teams/{id}/rating-history and the ratings block of game details.
+-- A team's latest row is its current rating.
+
+CREATE TABLE team_ratings (
+  team_id INTEGER NOT NULL REFERENCES teams(team_id) ON DELETE CASCADE,
+  game_id INTEGER NOT NULL REFERENCES games(game_id) ON DELETE CASCADE,
+  season_id INTEGER NOT NULL REFERENCES seasons(season_id) ON DELETE CASCADE,
+  rating_date DATE NOT NULL,
+  opponent_team_id INTEGER NOT NULL REFERENCES teams(team_id) ON DELETE CASCADE,
+  is_home BOOLEAN NOT NULL,
+  elo_before NUMERIC(6,1) NOT NULL,      -- After the offseason carry-over for a team's first game of a season
+  elo NUMERIC(6,1) NOT NULL,
+  win_probability NUMERIC(4,3) NOT NULL, -- Pregame, home court included
+  computed_at TIMESTAMP DEFAULT NOW(),
+
+  PRIMARY KEY (team_id, game_id)
+);
+
+CREATE INDEX idx_team_ratings_team_date ON team_ratings(team_id, rating_date DESC);
+CREATE INDEX idx_team_ratings_game ON team_ratings(game_id);
+CREATE INDEX idx_team_ratings_date ON team_ratings(rating_date);
+
+COMMENT ON TABLE team_ratings IS 'Team Elo rating before and after each final NBA game';
@@ -0,0 +1,2 @@
+-- Revert 058_add_backfill_job_checkpoint.sql
+ALTER TABLE backfill_jobs DROP COLUMN IF EXISTS units_completed;
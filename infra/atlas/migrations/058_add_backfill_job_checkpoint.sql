@@ -0,0 +1,6 @@
+-- Resume point for backfill jobs interrupted by shutdown or lost leadership
+-- A requeued job skips the dates (or game IDs) it already finished instead of starting over
+
+ALTER TABLE backfill_jobs ADD COLUMN IF NOT EXISTS units_completed INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN backfill_jobs.units_completed IS 'Leading dates or game IDs finished before the job was last interrupted';
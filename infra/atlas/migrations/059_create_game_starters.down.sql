@@ -0,0 +1,2 @@
+-- Revert 059_create_game_starters.sql
+DROP TABLE IF EXISTS game_starters;
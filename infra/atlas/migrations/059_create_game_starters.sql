@@ -0,0 +1,18 @@
+-- Starting lineups ESPN lists in the game summary ahead of tip-off. Written by
+-- lineup_sync for games starting within the hour; read by GET
+-- /api/v1/games/{id}. A team's lineup is replaced whenever ESPN's changes.
+
+CREATE TABLE game_starters (
+  game_id INTEGER NOT NULL REFERENCES games(game_id) ON DELETE CASCADE,
+  team_id INTEGER NOT NULL REFERENCES teams(team_id) ON DELETE CASCADE,
+  player_id INTEGER NOT NULL REFERENCES players(player_id) ON DELETE CASCADE,
+  lineup_slot SMALLINT NOT NULL,         -- 1-5 in ESPN's listing order
+  position VARCHAR(10),                  -- As listed for the game, not the roster position
+  captured_at TIMESTAMP DEFAULT NOW(),
+
+  PRIMARY KEY (game_id, team_id, player_id)
+);
+
+CREATE INDEX idx_game_starters_player ON game_starters(player_id);
+
+COMMENT ON TABLE game_starters IS 'Pre-game starting lineups per game and team from ESPN';
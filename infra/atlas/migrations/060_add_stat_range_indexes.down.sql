@@ -0,0 +1,8 @@
+-- Revert 060_add_stat_range_indexes.sql
+DROP INDEX IF EXISTS idx_team_game_stats_team_covering;
+
+DROP INDEX IF EXISTS idx_player_game_stats_player_covering;
+CREATE INDEX idx_player_game_stats_player_covering ON player_game_stats(player_id, game_id)
+  INCLUDE (points, rebounds, assists, steals, blocks, turnovers, minutes_played,
+           field_goals_made, field_goals_attempted, three_pointers_made, three_pointers_attempted,
+           free_throws_made, free_throws_attempted);
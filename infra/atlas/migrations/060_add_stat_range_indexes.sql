@@ -0,0 +1,17 @@
+-- Indexes for date-range aggregation (GET /api/v1/stats/aggregate).
+-- Player and team totals over an arbitrary window read every stat line of the
+-- entity and keep the ones whose game falls in the window, so both are
+-- answered from covering indexes plus a primary key probe on games.
+
+-- Widen the per-player covering index to every column the totals sum
+DROP INDEX IF EXISTS idx_player_game_stats_player_covering;
+CREATE INDEX idx_player_game_stats_player_covering ON player_game_stats(player_id, game_id)
+  INCLUDE (team_id, points, rebounds, offensive_rebounds, defensive_rebounds, assists, steals,
+           blocks, turnovers, minutes_played, seconds_played,
+           field_goals_made, field_goals_attempted, three_pointers_made, three_pointers_attempted,
+           free_throws_made, free_throws_attempted, deleted_at);
+
+CREATE INDEX idx_team_game_stats_team_covering ON team_game_stats(team_id, game_id)
+  INCLUDE (points, rebounds, offensive_rebounds, defensive_rebounds, assists, steals, blocks,
+           turnovers, possessions, field_goals_made, field_goals_attempted,
+           three_pointers_made, three_pointers_attempted, free_throws_made, free_throws_attempted);
@@ -0,0 +1,2 @@
+-- Revert 061_create_name_translations.sql
+DROP TABLE IF EXISTS name_translations;
@@ -0,0 +1,21 @@
+-- Localized display names for teams and players, keyed by lowercase BCP 47
+-- language tag ('es', 'es-mx'). Managed through /api/v1/admin/translations
+-- and applied to JSON responses for requests asking for that locale.
+-- entity_id is a team_id or player_id depending on entity_type, so there is
+-- no foreign key; the admin endpoints check the entity exists.
+
+CREATE TABLE name_translations (
+  entity_type VARCHAR(10) NOT NULL CHECK (entity_type IN ('team', 'player')),
+  entity_id INTEGER NOT NULL,
+  locale VARCHAR(35) NOT NULL,
+  full_name VARCHAR(200) NOT NULL,
+  short_name VARCHAR(100),               -- Team nickname or player display name
+  city VARCHAR(100),                     -- Teams only
+  updated_at TIMESTAMP DEFAULT NOW(),
+
+  PRIMARY KEY (entity_type, entity_id, locale)
+);
+
+CREATE INDEX idx_name_translations_locale ON name_translations(locale);
+
+COMMENT ON TABLE name_translations IS 'Per-locale team and player display names for localized API responses';
@@ -0,0 +1,2 @@
+-- Revert 062_add_game_broadcasts.sql
+ALTER TABLE games DROP COLUMN IF EXISTS broadcasts;
@@ -0,0 +1,7 @@
+-- TV and streaming networks carrying each game, from the broadcasts ESPN
+-- lists on scoreboard events and summary headers, in ESPN's order. Games
+-- ingested before this migration fill in on their next scoreboard sync.
+
+ALTER TABLE games ADD COLUMN IF NOT EXISTS broadcasts TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN games.broadcasts IS 'Network short names carrying the game, e.g. {ESPN,TNT}';
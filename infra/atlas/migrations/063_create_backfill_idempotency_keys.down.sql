@@ -0,0 +1,2 @@
+-- Revert 063_create_backfill_idempotency_keys.sql
+DROP TABLE IF EXISTS backfill_idempotency_keys;
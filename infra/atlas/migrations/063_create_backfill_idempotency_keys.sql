@@ -0,0 +1,15 @@
+-- Idempotency-Key values sent with POST /api/v1/backfill, each mapped to the
+-- job it returned (created, or an already queued job covering the request)
+-- and a digest of the job spec it asked for. A retry with the same key gets
+-- that job back; the same key with a different spec is rejected.
+
+CREATE TABLE backfill_idempotency_keys (
+  idempotency_key VARCHAR(255) PRIMARY KEY,
+  job_id UUID NOT NULL REFERENCES backfill_jobs(job_id) ON DELETE CASCADE,
+  request_hash CHAR(64) NOT NULL,          -- SHA-256 of the normalized job spec
+  created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_backfill_idempotency_keys_job ON backfill_idempotency_keys(job_id);
+
+COMMENT ON TABLE backfill_idempotency_keys IS 'Client idempotency keys for backfill requests and the job each returned';
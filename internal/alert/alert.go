@@ -0,0 +1,171 @@
+package alert
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Severity ranks how urgently an alert needs attention
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Alert is a single operational event delivered to every configured notifier
+type Alert struct {
+	Key      string            `json:"key"`    // dedup key; repeats are suppressed during the cooldown
+	Source   string            `json:"source"` // subsystem raising the alert, e.g. "scheduler" or "backfill"
+	Severity Severity          `json:"severity"`
+	Title    string            `json:"title"`
+	Message  string            `json:"message"`
+	Fields   map[string]string `json:"fields,omitempty"`
+	Resolved bool              `json:"resolved"`
+	Time     time.Time         `json:"time"`
+}
+
+// Notifier delivers alerts to an external channel
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, a Alert) error
+}
+
+// Config selects notifiers and delivery behaviour. Empty URLs/keys disable that notifier.
+type Config struct {
+	SlackWebhookURL     string
+	PagerDutyRoutingKey string
+	WebhookURL          string
+	Cooldown            time.Duration // minimum gap between repeats of the same key
+	Timeout             time.Duration // per-notifier delivery timeout
+}
+
+// DefaultConfig returns a configuration with no notifiers enabled
+func DefaultConfig() Config {
+	return Config{
+		Cooldown: 30 * time.Minute,
+		Timeout:  10 * time.Second,
+	}
+}
+
+// Dispatcher fans alerts out to notifiers in the background, suppressing repeats
+// of the same key within the cooldown. A nil Dispatcher drops every alert, so
+// callers don't need to check whether alerting is configured.
+type Dispatcher struct {
+	notifiers []Notifier
+	cooldown  time.Duration
+	timeout   time.Duration
+
+	mu     sync.Mutex
+	active map[string]time.Time // key -> last time it fired
+}
+
+// NewDispatcher builds a dispatcher with a notifier for every configured channel
+func NewDispatcher(config Config) *Dispatcher {
+	defaults := DefaultConfig()
+	if config.Cooldown <= 0 {
+		config.Cooldown = defaults.Cooldown
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+
+	var notifiers []Notifier
+	if config.SlackWebhookURL != "" {
+		notifiers = append(notifiers, NewSlackNotifier(config.SlackWebhookURL))
+	}
+	if config.PagerDutyRoutingKey != "" {
+		notifiers = append(notifiers, NewPagerDutyNotifier(config.PagerDutyRoutingKey))
+	}
+	if config.WebhookURL != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(config.WebhookURL))
+	}
+
+	return NewDispatcherWithNotifiers(config, notifiers...)
+}
+
+// NewDispatcherWithNotifiers builds a dispatcher around explicit notifiers
+func NewDispatcherWithNotifiers(config Config, notifiers ...Notifier) *Dispatcher {
+	return &Dispatcher{
+		notifiers: notifiers,
+		cooldown:  config.Cooldown,
+		timeout:   config.Timeout,
+		active:    make(map[string]time.Time),
+	}
+}
+
+// Notifiers returns the names of the enabled notifiers
+func (d *Dispatcher) Notifiers() []string {
+	if d == nil {
+		return nil
+	}
+	names := make([]string, 0, len(d.notifiers))
+	for _, n := range d.notifiers {
+		names = append(names, n.Name())
+	}
+	return names
+}
+
+// Fire sends an alert unless the same key fired within the cooldown
+func (d *Dispatcher) Fire(ctx context.Context, a Alert) {
+	if d == nil || len(d.notifiers) == 0 {
+		return
+	}
+	if a.Time.IsZero() {
+		a.Time = time.Now()
+	}
+
+	d.mu.Lock()
+	if last, ok := d.active[a.Key]; ok && a.Time.Sub(last) < d.cooldown {
+		d.mu.Unlock()
+		return
+	}
+	d.active[a.Key] = a.Time
+	d.mu.Unlock()
+
+	d.send(ctx, a)
+}
+
+// Resolve sends a recovery notice for a key that previously fired; it is a no-op otherwise
+func (d *Dispatcher) Resolve(ctx context.Context, a Alert) {
+	if d == nil || len(d.notifiers) == 0 {
+		return
+	}
+
+	d.mu.Lock()
+	_, ok := d.active[a.Key]
+	delete(d.active, a.Key)
+	d.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	a.Resolved = true
+	if a.Severity == "" {
+		a.Severity = SeverityInfo
+	}
+	if a.Time.IsZero() {
+		a.Time = time.Now()
+	}
+	d.send(ctx, a)
+}
+
+// send delivers to every notifier without blocking the caller; delivery outlives ctx cancellation
+func (d *Dispatcher) send(ctx context.Context, a Alert) {
+	ctx = context.WithoutCancel(ctx)
+	for _, n := range d.notifiers {
+		go func(n Notifier) {
+			ctx, cancel := context.WithTimeout(ctx, d.timeout)
+			defer cancel()
+
+			if err := n.Notify(ctx, a); err != nil {
+				log.Printf("[alert] ⚠️  %s delivery failed for %s: %v", n.Name(), a.Key, err)
+				return
+			}
+			log.Printf("[alert] ✓ Sent %s alert %q via %s", a.Severity, a.Title, n.Name())
+		}(n)
+	}
+}
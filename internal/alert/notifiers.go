@@ -0,0 +1,169 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PagerDutyEventsURL is the Events API v2 endpoint
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+var defaultClient = &http.Client{Timeout: 15 * time.Second}
+
+// SlackNotifier posts alerts to a Slack incoming webhook
+type SlackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackNotifier creates a Slack notifier for an incoming webhook URL
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{webhookURL: webhookURL, client: defaultClient}
+}
+
+// Name implements Notifier
+func (s *SlackNotifier) Name() string { return "slack" }
+
+// Notify implements Notifier
+func (s *SlackNotifier) Notify(ctx context.Context, a Alert) error {
+	color := map[Severity]string{
+		SeverityInfo:     "#439FE0",
+		SeverityWarning:  "warning",
+		SeverityCritical: "danger",
+	}[a.Severity]
+	if a.Resolved {
+		color = "good"
+	}
+
+	fields := make([]map[string]interface{}, 0, len(a.Fields))
+	for _, k := range sortedKeys(a.Fields) {
+		fields = append(fields, map[string]interface{}{"title": k, "value": a.Fields[k], "short": true})
+	}
+
+	payload := map[string]interface{}{
+		"text": summary(a),
+		"attachments": []map[string]interface{}{{
+			"color":  color,
+			"text":   a.Message,
+			"fields": fields,
+			"footer": "minerva/" + a.Source,
+			"ts":     a.Time.Unix(),
+		}},
+	}
+	return postJSON(ctx, s.client, s.webhookURL, payload)
+}
+
+// PagerDutyNotifier triggers and resolves PagerDuty incidents keyed by alert key
+type PagerDutyNotifier struct {
+	routingKey string
+	eventsURL  string
+	client     *http.Client
+}
+
+// NewPagerDutyNotifier creates a PagerDuty notifier for an Events API v2 routing key
+func NewPagerDutyNotifier(routingKey string) *PagerDutyNotifier {
+	return &PagerDutyNotifier{routingKey: routingKey, eventsURL: PagerDutyEventsURL, client: defaultClient}
+}
+
+// Name implements Notifier
+func (p *PagerDutyNotifier) Name() string { return "pagerduty" }
+
+// Notify implements Notifier
+func (p *PagerDutyNotifier) Notify(ctx context.Context, a Alert) error {
+	dedupKey := "minerva:" + a.Key
+
+	if a.Resolved {
+		return postJSON(ctx, p.client, p.eventsURL, map[string]interface{}{
+			"routing_key":  p.routingKey,
+			"event_action": "resolve",
+			"dedup_key":    dedupKey,
+		})
+	}
+
+	details := map[string]interface{}{"message": a.Message}
+	for k, v := range a.Fields {
+		details[k] = v
+	}
+
+	return postJSON(ctx, p.client, p.eventsURL, map[string]interface{}{
+		"routing_key":  p.routingKey,
+		"event_action": "trigger",
+		"dedup_key":    dedupKey,
+		"payload": map[string]interface{}{
+			"summary":        a.Title,
+			"source":         "minerva",
+			"component":      a.Source,
+			"severity":       string(a.Severity),
+			"timestamp":      a.Time.Format(time.RFC3339),
+			"custom_details": details,
+		},
+	})
+}
+
+// WebhookNotifier posts the alert as JSON to an arbitrary endpoint
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a generic JSON webhook notifier
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: defaultClient}
+}
+
+// Name implements Notifier
+func (w *WebhookNotifier) Name() string { return "webhook" }
+
+// Notify implements Notifier
+func (w *WebhookNotifier) Notify(ctx context.Context, a Alert) error {
+	return postJSON(ctx, w.client, w.url, a)
+}
+
+// summary renders the one-line headline shared by chat-style notifiers
+func summary(a Alert) string {
+	if a.Resolved {
+		return fmt.Sprintf("[RESOLVED] %s", a.Title)
+	}
+	return fmt.Sprintf("[%s] %s", strings.ToUpper(string(a.Severity)), a.Title)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
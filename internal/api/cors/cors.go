@@ -0,0 +1,256 @@
+// Package cors holds the cross-origin policy shared by the REST API and the
+// WebSocket server.
+package cors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrCredentialsWithAnyOrigin rejects a config allowing every origin to make
+// credentialed requests: the policy would echo any site's origin back with
+// Access-Control-Allow-Credentials, letting it act with a visitor's cookies.
+var ErrCredentialsWithAnyOrigin = errors.New(`cors: AllowCredentials can't be combined with AllowedOrigins "*"; list the origins instead`)
+
+// Config is a cross-origin policy. AllowedOrigins entries are exact origins
+// (https://app.example.com), wildcard subdomains (https://*.example.com, or
+// *.example.com for any scheme), or "*" for every origin.
+type Config struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration // how long browsers may cache a preflight response
+}
+
+// DefaultConfig allows every origin, the methods the API serves, and caches
+// preflights for an hour
+func DefaultConfig() Config {
+	return Config{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type", "Authorization", "X-Response-Format", "Idempotency-Key"},
+		MaxAge:         time.Hour,
+	}
+}
+
+// withDefaults fills unset lists from DefaultConfig. An empty origin list
+// means the default, not "deny all"; list an unused origin to block browsers.
+func (c Config) withDefaults() Config {
+	defaults := DefaultConfig()
+	if len(c.AllowedOrigins) == 0 {
+		c.AllowedOrigins = defaults.AllowedOrigins
+	}
+	if len(c.AllowedMethods) == 0 {
+		c.AllowedMethods = defaults.AllowedMethods
+	}
+	if len(c.AllowedHeaders) == 0 {
+		c.AllowedHeaders = defaults.AllowedHeaders
+	}
+	if c.MaxAge < 0 {
+		c.MaxAge = 0
+	}
+	return c
+}
+
+// wildcard matches origins whose host is a subdomain of suffix
+type wildcard struct {
+	scheme string // empty matches any scheme
+	suffix string // ".example.com"
+}
+
+// Policy answers whether an origin is allowed and writes CORS headers
+type Policy struct {
+	config    Config
+	any       bool
+	exact     map[string]bool
+	wildcards []wildcard
+}
+
+// NewPolicy compiles a config. It fails with ErrCredentialsWithAnyOrigin when
+// credentials are allowed for every origin, including the default "*".
+func NewPolicy(config Config) (*Policy, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config = config.withDefaults()
+	p := &Policy{config: config, exact: make(map[string]bool)}
+	for _, origin := range config.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimRight(strings.TrimSpace(origin), "/"))
+		switch {
+		case origin == "*":
+			p.any = true
+		case strings.Contains(origin, "*."):
+			scheme, host, found := strings.Cut(origin, "://")
+			if !found {
+				scheme, host = "", origin
+			}
+			p.wildcards = append(p.wildcards, wildcard{scheme: scheme, suffix: strings.TrimPrefix(host, "*")})
+		case origin != "":
+			p.exact[origin] = true
+		}
+	}
+	return p, nil
+}
+
+// Validate reports whether NewPolicy accepts the config
+func (c Config) Validate() error {
+	if !c.AllowCredentials {
+		return nil
+	}
+	for _, origin := range c.withDefaults().AllowedOrigins {
+		if strings.TrimSpace(origin) == "*" {
+			return ErrCredentialsWithAnyOrigin
+		}
+	}
+	return nil
+}
+
+// AllowsAny reports whether every origin is allowed
+func (p *Policy) AllowsAny() bool {
+	return p.any
+}
+
+// Allowed reports whether a browser origin may call the API
+func (p *Policy) Allowed(origin string) bool {
+	if p.any {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if p.exact[origin] {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	host := u.Hostname()
+	for _, w := range p.wildcards {
+		if w.scheme != "" && w.scheme != u.Scheme {
+			continue
+		}
+		if strings.HasSuffix(host, w.suffix) && len(host) > len(w.suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckOrigin is a websocket.Upgrader CheckOrigin. Requests without an Origin
+// header come from non-browser clients and are allowed.
+func (p *Policy) CheckOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || p.Allowed(origin)
+}
+
+// serve writes CORS headers for r and answers preflights. It reports whether
+// the request was fully handled.
+func (p *Policy) serve(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	h := w.Header()
+	preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+	echo := !p.any
+	if echo {
+		// Responses differ by origin, so shared caches must key on it
+		h.Add("Vary", "Origin")
+	}
+
+	if origin != "" && p.Allowed(origin) {
+		if echo {
+			h.Set("Access-Control-Allow-Origin", origin)
+		} else {
+			h.Set("Access-Control-Allow-Origin", "*")
+		}
+		if p.config.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+		if len(p.config.ExposedHeaders) > 0 && !preflight {
+			h.Set("Access-Control-Expose-Headers", strings.Join(p.config.ExposedHeaders, ", "))
+		}
+	} else if preflight {
+		w.WriteHeader(http.StatusForbidden)
+		return true
+	}
+
+	if r.Method != http.MethodOptions {
+		return false
+	}
+	if preflight {
+		h.Set("Access-Control-Allow-Methods", strings.Join(p.config.AllowedMethods, ", "))
+		h.Set("Access-Control-Allow-Headers", strings.Join(p.config.AllowedHeaders, ", "))
+		if p.config.MaxAge > 0 {
+			h.Set("Access-Control-Max-Age", strconv.Itoa(int(p.config.MaxAge.Seconds())))
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+type route struct {
+	prefix string
+	policy *Policy
+}
+
+// Routes picks a policy per request path: the longest matching prefix added
+// with Add, otherwise the default
+type Routes struct {
+	fallback *Policy
+	routes   []route
+}
+
+// NewRoutes creates a route table whose unmatched paths use config
+func NewRoutes(config Config) (*Routes, error) {
+	fallback, err := NewPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Routes{fallback: fallback}, nil
+}
+
+// Add applies config to paths starting with prefix, e.g. /api/v1/admin
+func (rs *Routes) Add(prefix string, config Config) error {
+	policy, err := NewPolicy(config)
+	if err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	rs.routes = append(rs.routes, route{prefix: prefix, policy: policy})
+	sort.SliceStable(rs.routes, func(i, j int) bool {
+		return len(rs.routes[i].prefix) > len(rs.routes[j].prefix)
+	})
+	return nil
+}
+
+// Default returns the policy for paths without a route of their own
+func (rs *Routes) Default() *Policy {
+	return rs.fallback
+}
+
+// For returns the policy governing path
+func (rs *Routes) For(path string) *Policy {
+	for _, rt := range rs.routes {
+		if strings.HasPrefix(path, rt.prefix) {
+			return rt.policy
+		}
+	}
+	return rs.fallback
+}
+
+// Handler applies the matching policy before next. Wrap the whole router with
+// it rather than installing it as router middleware, so preflight OPTIONS
+// requests are answered even for routes registered for other methods only.
+func (rs *Routes) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rs.For(r.URL.Path).serve(w, r) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
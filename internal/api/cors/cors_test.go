@@ -0,0 +1,35 @@
+package cors
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewPolicyRejectsCredentialsForAnyOrigin(t *testing.T) {
+	cases := []struct {
+		name    string
+		origins []string
+		wantErr bool
+	}{
+		{"default origins", nil, true},
+		{"wildcard", []string{"*"}, true},
+		{"wildcard among others", []string{"https://app.example.com", " * "}, true},
+		{"listed origins", []string{"https://app.example.com", "https://*.example.com"}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewPolicy(Config{AllowedOrigins: tc.origins, AllowCredentials: true})
+			if got := errors.Is(err, ErrCredentialsWithAnyOrigin); got != tc.wantErr {
+				t.Errorf("got %v, want rejected=%v", err, tc.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewPolicy(DefaultConfig()); err != nil {
+		t.Errorf("default config: %v", err)
+	}
+	if _, err := NewRoutes(Config{AllowCredentials: true}); !errors.Is(err, ErrCredentialsWithAnyOrigin) {
+		t.Errorf("routes: got %v, want ErrCredentialsWithAnyOrigin", err)
+	}
+}
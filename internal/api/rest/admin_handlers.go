@@ -0,0 +1,361 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/backfill"
+	"github.com/fortuna/minerva/internal/scheduler"
+	"github.com/fortuna/minerva/internal/service"
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+	"github.com/gorilla/mux"
+)
+
+// AdminHandler serves operator-facing endpoints under /api/v1/admin
+type AdminHandler struct {
+	db          *store.Database
+	runs        *repository.IngestionRunRepository
+	corrections *repository.StatCorrectionRepository
+	quality     *repository.DataQualityRepository
+	enrichment  *repository.EnrichmentRepository
+	games       *repository.GameRepository
+	players     *repository.PlayerRepository
+	scheduler   *scheduler.Orchestrator
+	backfill    *backfill.Service
+	localizer   *service.Localizer
+	sources     OverviewSources
+}
+
+// NewAdminHandler creates a new admin handler. sched and backfillSvc may be nil
+// when the scheduler or backfill worker is not running in this process. The
+// localizer is the one serving responses, so translation edits apply at once.
+func NewAdminHandler(db *store.Database, sched *scheduler.Orchestrator, backfillSvc *backfill.Service, localizer *service.Localizer) *AdminHandler {
+	return &AdminHandler{
+		db:          db,
+		runs:        repository.NewIngestionRunRepository(db),
+		corrections: repository.NewStatCorrectionRepository(db),
+		quality:     repository.NewDataQualityRepository(db),
+		enrichment:  repository.NewEnrichmentRepository(db),
+		games:       repository.NewGameRepository(db),
+		players:     repository.NewPlayerRepository(db),
+		scheduler:   sched,
+		backfill:    backfillSvc,
+		localizer:   localizer,
+	}
+}
+
+// ListIngestionRuns handles GET /api/v1/admin/ingestion-runs?source=&limit=
+func (h *AdminHandler) ListIngestionRuns(w http.ResponseWriter, r *http.Request) {
+	source := r.URL.Query().Get("source")
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 500 {
+			limit = l
+		}
+	}
+
+	runs, err := h.runs.List(r.Context(), source, limit)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch ingestion runs", err)
+		return
+	}
+
+	respondList(w, r, "runs", runs, len(runs), limit)
+}
+
+// ListJobs handles GET /api/v1/admin/jobs
+func (h *AdminHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	jobs := []scheduler.JobStatus{}
+	if h.scheduler != nil {
+		jobs = h.scheduler.Jobs()
+	}
+
+	respondList(w, r, "jobs", jobs, len(jobs), 0)
+}
+
+// EnrichmentStatus handles GET /api/v1/admin/enrichment/status: biographical
+// field coverage, player_enrichment progress, and the job's schedule and last run
+func (h *AdminHandler) EnrichmentStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.enrichment.Status(r.Context(), sportFrom(r))
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch enrichment status", err)
+		return
+	}
+
+	var job *scheduler.JobStatus
+	if h.scheduler != nil {
+		for _, j := range h.scheduler.Jobs() {
+			if j.Name == scheduler.JobEnrichment {
+				job = &j
+				break
+			}
+		}
+	}
+
+	respondJSON(w, r, http.StatusOK, map[string]interface{}{
+		"progress": status,
+		"job":      job,
+	})
+}
+
+// ListDataQualityIssues handles GET /api/v1/admin/data-quality?date=&rule=&limit=.
+// Without a date, issues from the most recent game dates are returned.
+func (h *AdminHandler) ListDataQualityIssues(w http.ResponseWriter, r *http.Request) {
+	var date time.Time
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid date format (use YYYY-MM-DD)", err)
+			return
+		}
+		date = parsed
+	}
+
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 1000 {
+			limit = l
+		}
+	}
+
+	issues, err := h.quality.List(r.Context(), sportFrom(r), date, r.URL.Query().Get("rule"), limit)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch data quality issues", err)
+		return
+	}
+
+	respondList(w, r, "issues", issues, len(issues), limit)
+}
+
+// CorrectPlayerStats handles PATCH /api/v1/admin/player-stats/{statID}.
+// The body carries the fields to amend plus a required reason; "deleted": true
+// soft-deletes the stat line and "deleted": false restores it. The correction is
+// attributed to the authenticated admin principal.
+func (h *AdminHandler) CorrectPlayerStats(w http.ResponseWriter, r *http.Request) {
+	statID, err := strconv.Atoi(mux.Vars(r)["statID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid stat ID", err)
+		return
+	}
+
+	var correction store.StatCorrection
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&correction); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	correction.CorrectedBy = principalFrom(r)
+
+	stats, entry, err := h.corrections.Apply(r.Context(), statID, &correction)
+	if errors.Is(err, repository.ErrInvalidCorrection) {
+		respondError(w, r, http.StatusBadRequest, "Invalid correction", err)
+		return
+	}
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to apply correction", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, map[string]interface{}{
+		"stats":      stats,
+		"correction": entry,
+		"changed":    entry != nil,
+	})
+}
+
+// ListStatCorrections handles GET /api/v1/admin/player-stats/{statID}/corrections
+// and GET /api/v1/admin/stat-corrections (all stat lines)
+func (h *AdminHandler) ListStatCorrections(w http.ResponseWriter, r *http.Request) {
+	statID := 0
+	if statIDStr, ok := mux.Vars(r)["statID"]; ok {
+		id, err := strconv.Atoi(statIDStr)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid stat ID", err)
+			return
+		}
+		statID = id
+	}
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 500 {
+			limit = l
+		}
+	}
+
+	corrections, err := h.corrections.List(r.Context(), statID, limit)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch stat corrections", err)
+		return
+	}
+
+	respondList(w, r, "corrections", corrections, len(corrections), limit)
+}
+
+// ListPlayerAliases handles GET /api/v1/admin/players/{playerID}/aliases
+func (h *AdminHandler) ListPlayerAliases(w http.ResponseWriter, r *http.Request) {
+	playerID, err := strconv.Atoi(mux.Vars(r)["playerID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
+		return
+	}
+
+	aliases, err := h.players.GetAliases(r.Context(), playerID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch player aliases", err)
+		return
+	}
+
+	respondList(w, r, "aliases", aliases, len(aliases), 0)
+}
+
+// AddPlayerAlias handles POST /api/v1/admin/players/{playerID}/aliases with a
+// body of {"alias": "...", "alias_type": "nickname|spelling|transliteration"}
+func (h *AdminHandler) AddPlayerAlias(w http.ResponseWriter, r *http.Request) {
+	playerID, err := strconv.Atoi(mux.Vars(r)["playerID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
+		return
+	}
+
+	var body struct {
+		Alias     string `json:"alias"`
+		AliasType string `json:"alias_type"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&body); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if strings.TrimSpace(body.Alias) == "" {
+		respondError(w, r, http.StatusBadRequest, "alias is required", nil)
+		return
+	}
+	switch body.AliasType {
+	case "", store.PlayerAliasNickname, store.PlayerAliasSpelling, store.PlayerAliasTransliteration:
+	default:
+		respondError(w, r, http.StatusBadRequest, "alias_type must be nickname, spelling, or transliteration", nil)
+		return
+	}
+
+	alias := &store.PlayerAlias{PlayerID: playerID, Alias: body.Alias, AliasType: body.AliasType}
+	if err := h.players.AddAlias(r.Context(), alias); err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to add player alias", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusCreated, alias)
+}
+
+// DeletePlayerAlias handles DELETE /api/v1/admin/players/{playerID}/aliases/{aliasID}
+func (h *AdminHandler) DeletePlayerAlias(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	playerID, err := strconv.Atoi(vars["playerID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
+		return
+	}
+	aliasID, err := strconv.Atoi(vars["aliasID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid alias ID", err)
+		return
+	}
+
+	if err := h.players.DeleteAlias(r.Context(), playerID, aliasID); err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete player alias", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTranslations handles GET /api/v1/admin/translations?locale=&entity_type=team|player
+func (h *AdminHandler) ListTranslations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	translations, err := h.localizer.ListTranslations(r.Context(), query.Get("locale"), query.Get("entity_type"))
+	if errors.Is(err, service.ErrInvalidLocale) || errors.Is(err, service.ErrInvalidEntity) {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch translations", err)
+		return
+	}
+
+	respondList(w, r, "translations", translations, len(translations), 0)
+}
+
+// PutTranslation handles PUT /api/v1/admin/translations/{entityType}/{entityID}/{locale}
+// with a body of {"full_name": "...", "short_name": "...", "city": "..."}.
+// short_name and city are optional; city applies to teams only.
+func (h *AdminHandler) PutTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entityID, err := strconv.Atoi(vars["entityID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid entity ID", err)
+		return
+	}
+
+	var body struct {
+		FullName  string           `json:"full_name"`
+		ShortName store.NullString `json:"short_name"`
+		City      store.NullString `json:"city"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&body); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	translation := &store.NameTranslation{
+		EntityType: vars["entityType"],
+		EntityID:   entityID,
+		Locale:     vars["locale"],
+		FullName:   body.FullName,
+		ShortName:  body.ShortName,
+		City:       body.City,
+	}
+	err = h.localizer.PutTranslation(r.Context(), translation)
+	switch {
+	case errors.Is(err, service.ErrInvalidLocale) || errors.Is(err, service.ErrInvalidEntity) || errors.Is(err, service.ErrInvalidTranslation):
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	case err != nil:
+		respondError(w, r, http.StatusInternalServerError, "Failed to save translation", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, translation)
+}
+
+// DeleteTranslation handles DELETE /api/v1/admin/translations/{entityType}/{entityID}/{locale}
+func (h *AdminHandler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entityID, err := strconv.Atoi(vars["entityID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid entity ID", err)
+		return
+	}
+
+	err = h.localizer.DeleteTranslation(r.Context(), vars["entityType"], entityID, vars["locale"])
+	switch {
+	case errors.Is(err, service.ErrInvalidLocale) || errors.Is(err, service.ErrInvalidEntity):
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	case err != nil:
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete translation", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,146 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fortuna/minerva/internal/cache"
+)
+
+// overviewRuns is how many recent ingestion runs the overview includes
+const overviewRuns = 10
+
+// overviewRedisTimeout bounds the Redis ping so a hung Redis cannot stall the dashboard
+const overviewRedisTimeout = 2 * time.Second
+
+// ClientCounter reports connected WebSocket clients
+type ClientCounter interface {
+	ClientCount() int
+}
+
+// LeaderStatus reports whether this replica holds the scheduler lease
+type LeaderStatus interface {
+	ID() string
+	IsLeader() bool
+}
+
+// OverviewSources are the in-process components /admin/overview reports on
+// besides the database. Nil fields are reported as not configured.
+type OverviewSources struct {
+	Redis     *cache.RedisCache
+	WebSocket ClientCounter
+	Leader    LeaderStatus
+}
+
+// Overview handles GET /api/v1/admin/overview: scheduler and leader status,
+// recent ingestion runs, backfill queue depth, live games, reconciliation
+// counters, WebSocket clients, dependency health, and data freshness in one
+// response for the ops dashboard. A failing section reports its error in
+// place instead of failing the request.
+func (h *AdminHandler) Overview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	respondJSON(w, r, http.StatusOK, map[string]interface{}{
+		"generated_at":   time.Now().UTC(),
+		"health":         h.overviewHealth(ctx),
+		"scheduler":      h.overviewScheduler(),
+		"ingestion_runs": section(h.runs.List(ctx, "", overviewRuns)),
+		"backfill":       h.overviewBackfill(ctx),
+		"live_games":     h.overviewLiveGames(ctx, sportFrom(r)),
+		"reconciliation": h.overviewReconciliation(),
+		"websocket":      h.overviewWebSocket(),
+		"data_freshness": section(h.runs.Freshness(ctx)),
+	})
+}
+
+// section reports value, or the error in its place
+func section[T any](value T, err error) interface{} {
+	if err != nil {
+		return map[string]string{"error": err.Error()}
+	}
+	return value
+}
+
+func (h *AdminHandler) overviewHealth(ctx context.Context) map[string]string {
+	health := map[string]string{"database": "ok", "redis": "not configured"}
+	if err := h.db.HealthCheck(); err != nil {
+		health["database"] = err.Error()
+	}
+	if h.db.HasReplica() {
+		health["read_replica"] = "ok"
+		if err := h.db.ReplicaHealthCheck(); err != nil {
+			health["read_replica"] = "unavailable (reads served by primary)"
+		}
+	}
+	if h.sources.Redis != nil {
+		ctx, cancel := context.WithTimeout(ctx, overviewRedisTimeout)
+		defer cancel()
+		health["redis"] = "ok"
+		if err := h.sources.Redis.HealthCheck(ctx); err != nil {
+			health["redis"] = err.Error()
+		}
+	}
+	return health
+}
+
+func (h *AdminHandler) overviewScheduler() map[string]interface{} {
+	status := map[string]interface{}{"running_here": h.scheduler != nil}
+	if h.sources.Leader != nil {
+		status["instance_id"] = h.sources.Leader.ID()
+		status["leader"] = h.sources.Leader.IsLeader()
+	}
+	if h.scheduler != nil {
+		for key, value := range h.scheduler.GetStatus() {
+			status[key] = value
+		}
+	}
+	return status
+}
+
+func (h *AdminHandler) overviewBackfill(ctx context.Context) interface{} {
+	if h.backfill == nil {
+		return map[string]string{"error": "backfill service not running"}
+	}
+	queued, running, err := h.backfill.QueueDepth(ctx)
+	if err != nil {
+		return section[interface{}](nil, err)
+	}
+	summary, err := h.backfill.GetStatus(ctx)
+	if err != nil {
+		return section[interface{}](nil, err)
+	}
+	return map[string]interface{}{
+		"queued":     queued,
+		"running":    running,
+		"active_job": jobPayload(summary.ActiveJob),
+	}
+}
+
+func (h *AdminHandler) overviewLiveGames(ctx context.Context, sport string) interface{} {
+	games, err := h.games.GetLiveGames(ctx, sport, nil, nil)
+	if err != nil {
+		return section[interface{}](nil, err)
+	}
+	return len(games)
+}
+
+// overviewReconciliation reports live polling's Google/ESPN counters. They are
+// kept in memory by the leader, so other replicas report null.
+func (h *AdminHandler) overviewReconciliation() interface{} {
+	if h.scheduler == nil {
+		return nil
+	}
+	if metrics := h.scheduler.ReconciliationMetrics(); metrics != nil {
+		return metrics
+	}
+	return nil
+}
+
+// overviewWebSocket reports this replica's WebSocket clients
+func (h *AdminHandler) overviewWebSocket() interface{} {
+	if h.sources.WebSocket == nil {
+		return nil
+	}
+	return map[string]int{"clients": h.sources.WebSocket.ClientCount()}
+}
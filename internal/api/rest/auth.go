@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+type principalKey struct{}
+
+// AdminAuth guards /api/v1/admin. Requests must send "Authorization: Bearer
+// <token>" with one of the configured tokens; the token's name is the principal
+// recorded on audited writes such as stat corrections. Until tokens are set,
+// every admin request is refused.
+type AdminAuth struct {
+	tokens atomic.Pointer[map[string]string] // principal -> token
+}
+
+// NewAdminAuth creates an admin guard with no tokens
+func NewAdminAuth() *AdminAuth {
+	return &AdminAuth{}
+}
+
+// SetTokens replaces the accepted tokens, keyed by principal name
+func (a *AdminAuth) SetTokens(tokens map[string]string) {
+	copied := make(map[string]string, len(tokens))
+	for principal, token := range tokens {
+		if principal != "" && token != "" {
+			copied[principal] = token
+		}
+	}
+	a.tokens.Store(&copied)
+}
+
+// Middleware rejects requests without a valid token and stores the
+// authenticated principal on the request context
+func (a *AdminAuth) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := a.authenticate(r)
+		if principal == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="minerva-admin"`)
+			respondError(w, r, http.StatusUnauthorized, "Admin API requires a valid bearer token", nil)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
+
+// authenticate returns the principal whose token the request carries, or ""
+func (a *AdminAuth) authenticate(r *http.Request) string {
+	presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	tokens := a.tokens.Load()
+	if !ok || presented == "" || tokens == nil {
+		return ""
+	}
+
+	// Every token is compared, in constant time, so timing reveals none of them
+	var principal string
+	for name, token := range *tokens {
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+			principal = name
+		}
+	}
+	return principal
+}
+
+// principalFrom returns the admin principal AdminAuth authenticated for r
+func principalFrom(r *http.Request) string {
+	principal, _ := r.Context().Value(principalKey{}).(string)
+	return principal
+}
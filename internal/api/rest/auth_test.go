@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminAuth(t *testing.T) {
+	var principal string
+	guarded := func(auth *AdminAuth) http.Handler {
+		return auth.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal = principalFrom(r)
+		}))
+	}
+
+	configured := NewAdminAuth()
+	configured.SetTokens(map[string]string{"ops": "s3cret", "alice": "t0ken"})
+
+	cases := []struct {
+		name          string
+		auth          *AdminAuth
+		authorization string
+		wantStatus    int
+		wantPrincipal string
+	}{
+		{"valid token", configured, "Bearer t0ken", http.StatusOK, "alice"},
+		{"no header", configured, "", http.StatusUnauthorized, ""},
+		{"wrong token", configured, "Bearer nope", http.StatusUnauthorized, ""},
+		{"not a bearer token", configured, "Basic t0ken", http.StatusUnauthorized, ""},
+		{"no tokens configured", NewAdminAuth(), "Bearer t0ken", http.StatusUnauthorized, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			principal = ""
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/admin/player-stats/1", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rec := httptest.NewRecorder()
+			guarded(tc.auth).ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if principal != tc.wantPrincipal {
+				t.Errorf("principal %q, want %q", principal, tc.wantPrincipal)
+			}
+		})
+	}
+}
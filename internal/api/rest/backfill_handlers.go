@@ -2,7 +2,9 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/fortuna/minerva/internal/backfill"
@@ -18,6 +20,12 @@ func NewBackfillHandler(service *backfill.Service) *BackfillHandler {
 	return &BackfillHandler{service: service}
 }
 
+// maxBackfillBodyBytes caps the POST body; 500 game IDs fit comfortably
+const maxBackfillBodyBytes = 64 << 10
+
+// maxIdempotencyKeyLength is the longest Idempotency-Key header accepted
+const maxIdempotencyKeyLength = 255
+
 type apiBackfillRequest struct {
 	Sport     string   `json:"sport"`
 	SeasonID  string   `json:"season_id"`
@@ -26,13 +34,31 @@ type apiBackfillRequest struct {
 	GameID    string   `json:"game_id"`
 	GameIDs   []string `json:"game_ids"`
 	DryRun    bool     `json:"dry_run"`
+
+	// Throughput controls; omitted or 0 means unlimited
+	RequestsPerMinute int `json:"requests_per_minute"`
+	DateDelayMs       int `json:"date_delay_ms"`
 }
 
-// HandleBackfillRequest handles POST /api/v1/backfill
+// HandleBackfillRequest handles POST /api/v1/backfill. A new job is answered
+// with 202; a retry carrying an earlier Idempotency-Key, or a request a queued
+// job already covers, gets that job with 200. The job's "outcome" says which.
 func (h *BackfillHandler) HandleBackfillRequest(w http.ResponseWriter, r *http.Request) {
+	idempotencyKey := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		respondError(w, r, http.StatusBadRequest, "Idempotency-Key is longer than 255 characters", nil)
+		return
+	}
+
 	var req apiBackfillRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxBackfillBodyBytes)
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body", err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondError(w, r, http.StatusRequestEntityTooLarge, "Request body too large", err)
+			return
+		}
+		respondError(w, r, http.StatusBadRequest, "Invalid request body", err)
 		return
 	}
 
@@ -40,6 +66,11 @@ func (h *BackfillHandler) HandleBackfillRequest(w http.ResponseWriter, r *http.R
 		Sport:    req.Sport,
 		SeasonID: req.SeasonID,
 		DryRun:   req.DryRun,
+		RateLimit: backfill.RateLimit{
+			RequestsPerMinute: req.RequestsPerMinute,
+			DateDelay:         time.Duration(req.DateDelayMs) * time.Millisecond,
+		},
+		IdempotencyKey: idempotencyKey,
 	}
 
 	if len(req.GameIDs) > 0 {
@@ -52,7 +83,7 @@ func (h *BackfillHandler) HandleBackfillRequest(w http.ResponseWriter, r *http.R
 	if req.StartDate != "" {
 		start, err := time.Parse("2006-01-02", req.StartDate)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "Invalid start_date format (YYYY-MM-DD)", err)
+			respondError(w, r, http.StatusBadRequest, "Invalid start_date format (YYYY-MM-DD)", err)
 			return
 		}
 		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
@@ -62,34 +93,47 @@ func (h *BackfillHandler) HandleBackfillRequest(w http.ResponseWriter, r *http.R
 	if req.EndDate != "" {
 		end, err := time.Parse("2006-01-02", req.EndDate)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "Invalid end_date format (YYYY-MM-DD)", err)
+			respondError(w, r, http.StatusBadRequest, "Invalid end_date format (YYYY-MM-DD)", err)
 			return
 		}
 		end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
 		backfillReq.EndDate = &end
 	}
 
-	job, err := h.service.Enqueue(r.Context(), backfillReq)
+	job, outcome, err := h.service.Enqueue(r.Context(), backfillReq)
+	if backfill.IsValidationError(err) {
+		respondError(w, r, http.StatusUnprocessableEntity, "Backfill request out of bounds", err)
+		return
+	}
+	if errors.Is(err, backfill.ErrIdempotencyKeyReused) {
+		respondError(w, r, http.StatusUnprocessableEntity, "Idempotency-Key reused with a different request", err)
+		return
+	}
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Failed to enqueue backfill job", err)
+		respondError(w, r, http.StatusBadRequest, "Failed to enqueue backfill job", err)
 		return
 	}
 
-	respondJSON(w, http.StatusAccepted, map[string]interface{}{
-		"job": jobPayload(job),
-	})
+	status := http.StatusAccepted
+	if outcome != backfill.EnqueueCreated {
+		status = http.StatusOK
+	}
+
+	payload := jobPayload(job)
+	payload["outcome"] = outcome
+	respondEnvelope(w, r, status, payload, nil, map[string]interface{}{"job": payload})
 }
 
 // HandleBackfillStatus handles GET /api/v1/backfill/status
 func (h *BackfillHandler) HandleBackfillStatus(w http.ResponseWriter, r *http.Request) {
 	summary, err := h.service.GetStatus(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch status", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch status", err)
 		return
 	}
 
 	payload := buildStatusPayload(summary)
-	respondJSON(w, http.StatusOK, payload)
+	respondJSON(w, r, http.StatusOK, payload)
 }
 
 func buildStatusPayload(summary *backfill.StatusSummary) map[string]interface{} {
@@ -127,6 +171,7 @@ func jobPayload(job *backfill.Job) map[string]interface{} {
 		"status":           job.Status,
 		"progress_current": job.ProgressCurrent,
 		"progress_total":   job.ProgressTotal,
+		"dry_run":          job.DryRun,
 		"created_at":       job.CreatedAt,
 		"updated_at":       job.UpdatedAt,
 	}
@@ -155,6 +200,19 @@ func jobPayload(job *backfill.Job) map[string]interface{} {
 	if job.LastError.Valid {
 		payload["last_error"] = job.LastError.String
 	}
+	if job.UnitsCompleted > 0 {
+		payload["units_completed"] = job.UnitsCompleted
+	}
+	if !job.RateLimit.IsZero() {
+		payload["requests_per_minute"] = job.RateLimit.RequestsPerMinute
+		payload["date_delay_ms"] = job.RateLimit.DateDelay.Milliseconds()
+	}
+	if job.Result.Valid {
+		var report backfill.DryRunReport
+		if err := json.Unmarshal([]byte(job.Result.String), &report); err == nil {
+			payload["dry_run_report"] = report
+		}
+	}
 
 	return payload
 }
@@ -0,0 +1,300 @@
+package rest
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Content encodings the compression middleware produces
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+// brotliLevel trades ratio for speed; brotli's default (6) is too slow for
+// dynamic responses
+const brotliLevel = 4
+
+// CompressionConfig controls response compression
+type CompressionConfig struct {
+	Enabled bool
+	MinSize int // bodies smaller than this many bytes are sent uncompressed
+}
+
+// DefaultCompressionConfig compresses bodies of 1 KiB and up
+func DefaultCompressionConfig() CompressionConfig {
+	return CompressionConfig{Enabled: true, MinSize: 1024}
+}
+
+// uncompressedTypes are content types sent as is: formats that are already
+// compressed (parquet, archives, media) and event streams, which must not be
+// held back by buffering
+var uncompressedTypes = []string{
+	"application/vnd.apache.parquet",
+	"application/x-parquet",
+	"application/octet-stream",
+	"application/gzip",
+	"application/x-gzip",
+	"application/zip",
+	"application/zstd",
+	"image/",
+	"video/",
+	"audio/",
+	"font/",
+	"text/event-stream",
+}
+
+var (
+	gzipWriters = sync.Pool{New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	}}
+	brotliWriters = sync.Pool{New: func() interface{} {
+		return brotli.NewWriterLevel(io.Discard, brotliLevel)
+	}}
+)
+
+// Compression brotli- or gzip-encodes responses for clients that accept it,
+// preferring brotli. The body is buffered up to the minimum size before
+// deciding, so small payloads go out unchanged; a flush (streamed endpoints)
+// decides immediately.
+type Compression struct {
+	enabled atomic.Bool
+	minSize atomic.Int64
+}
+
+// NewCompression creates a compression middleware with DefaultCompressionConfig
+func NewCompression() *Compression {
+	c := &Compression{}
+	c.Configure(DefaultCompressionConfig())
+	return c
+}
+
+// Configure replaces the compression settings
+func (c *Compression) Configure(config CompressionConfig) {
+	if config.MinSize < 0 {
+		config.MinSize = 0
+	}
+	c.enabled.Store(config.Enabled)
+	c.minSize.Store(int64(config.MinSize))
+}
+
+// Middleware wraps the response writer when the request accepts br or gzip
+func (c *Compression) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !c.enabled.Load() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: int(c.minSize.Load())}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks br or gzip from an Accept-Encoding header, honouring
+// q-values and "*". It returns "" when neither is acceptable.
+func negotiateEncoding(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		weight := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				weight = f
+			}
+		}
+		weights[name] = weight
+	}
+
+	weight := func(encoding string) float64 {
+		if w, ok := weights[encoding]; ok {
+			return w
+		}
+		return weights["*"]
+	}
+
+	br, gz := weight(encodingBrotli), weight(encodingGzip)
+	switch {
+	case br > 0 && br >= gz:
+		return encodingBrotli
+	case gz > 0:
+		return encodingGzip
+	}
+	return ""
+}
+
+// compressWriter holds the status and the start of the body until it knows
+// whether to compress, then either streams through an encoder or passes
+// everything to the underlying writer
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status  int
+	buf     []byte
+	decided bool
+	enc     io.WriteCloser // nil when passing through
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.decided {
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if status >= 200 && cw.status == 0 {
+		cw.status = status
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		cw.buf = append(cw.buf, p...)
+		if len(cw.buf) < cw.minSize {
+			return len(p), nil
+		}
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	if cw.enc != nil {
+		return cw.enc.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// FlushError sends what has been written so far, compressing it if eligible.
+// http.ResponseController calls it for streamed responses.
+func (cw *compressWriter) FlushError() error {
+	if !cw.decided {
+		if err := cw.decide(true); err != nil {
+			return err
+		}
+	}
+	if f, ok := cw.enc.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return err
+		}
+	}
+	return http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Flush implements http.Flusher
+func (cw *compressWriter) Flush() {
+	cw.FlushError()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Close sends a body that never reached the minimum size uncompressed, or
+// finishes the encoded stream
+func (cw *compressWriter) Close() error {
+	if !cw.decided {
+		return cw.decide(false)
+	}
+	if cw.enc == nil {
+		return nil
+	}
+
+	err := cw.enc.Close()
+	switch enc := cw.enc.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(enc)
+	case *brotli.Writer:
+		brotliWriters.Put(enc)
+	}
+	cw.enc = nil
+	return err
+}
+
+// decide writes the held status and body, through an encoder when compress is
+// set and the response is eligible
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+
+	h := cw.Header()
+	// The server sniffs a missing Content-Type from the first bytes it sees,
+	// which would be the compressed ones
+	if h.Get("Content-Type") == "" && len(cw.buf) > 0 {
+		h.Set("Content-Type", http.DetectContentType(cw.buf))
+	}
+
+	if compress && cw.compressible() {
+		h.Set("Content-Encoding", cw.encoding)
+		h.Del("Content-Length")
+		switch cw.encoding {
+		case encodingBrotli:
+			w := brotliWriters.Get().(*brotli.Writer)
+			w.Reset(cw.ResponseWriter)
+			cw.enc = w
+		default:
+			w := gzipWriters.Get().(*gzip.Writer)
+			w.Reset(cw.ResponseWriter)
+			cw.enc = w
+		}
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if len(cw.buf) == 0 {
+		return nil
+	}
+	buf := cw.buf
+	cw.buf = nil
+	if cw.enc != nil {
+		_, err := cw.enc.Write(buf)
+		return err
+	}
+	_, err := cw.ResponseWriter.Write(buf)
+	return err
+}
+
+// compressible reports whether the held response may be encoded
+func (cw *compressWriter) compressible() bool {
+	if cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+
+	h := cw.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := strings.ToLower(h.Get("Content-Type"))
+	if contentType == "" {
+		return false
+	}
+	for _, t := range uncompressedTypes {
+		if strings.HasPrefix(contentType, t) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,219 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// ErrorCode is a machine-readable error classification returned in error.code
+type ErrorCode string
+
+// Error codes. Clients should branch on these, not on message text.
+const (
+	ErrCodeValidation          ErrorCode = "VALIDATION"
+	ErrCodeNotFound            ErrorCode = "NOT_FOUND"
+	ErrCodeConflict            ErrorCode = "CONFLICT"
+	ErrCodeUpstreamUnavailable ErrorCode = "UPSTREAM_UNAVAILABLE"
+	ErrCodeTimeout             ErrorCode = "TIMEOUT"
+	ErrCodeInternal            ErrorCode = "INTERNAL"
+)
+
+// Response formats selected per request by ResponseFormat
+const (
+	formatEnvelope = "envelope"
+	formatLegacy   = "legacy"
+)
+
+// Envelope is the standard body of every /api/v1 response: data on success,
+// error on failure, and meta for lists
+type Envelope struct {
+	Data  interface{} `json:"data,omitempty"`
+	Meta  *Meta       `json:"meta,omitempty"`
+	Error *APIError   `json:"error,omitempty"`
+}
+
+// Meta describes a list response
+type Meta struct {
+	Count int `json:"count"`
+	Limit int `json:"limit,omitempty"`
+}
+
+// APIError is the error member of an envelope
+type APIError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	Details string    `json:"details,omitempty"`
+}
+
+type formatKey struct{}
+
+// ResponseFormat chooses between the envelope and the legacy bare payloads for
+// each request. ?envelope=true|false or X-Response-Format: envelope|legacy win;
+// otherwise the server default applies.
+type ResponseFormat struct {
+	legacy atomic.Bool
+}
+
+// SetLegacyDefault makes legacy payloads the default for requests that don't ask
+func (f *ResponseFormat) SetLegacyDefault(legacy bool) {
+	f.legacy.Store(legacy)
+}
+
+// Middleware stores the request's response format on its context
+func (f *ResponseFormat) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := formatEnvelope
+		if f.legacy.Load() {
+			format = formatLegacy
+		}
+
+		if v := r.URL.Query().Get("envelope"); v != "" {
+			if enveloped, err := strconv.ParseBool(v); err == nil && !enveloped {
+				format = formatLegacy
+			} else if err == nil {
+				format = formatEnvelope
+			}
+		} else {
+			switch strings.ToLower(r.Header.Get("X-Response-Format")) {
+			case formatLegacy:
+				format = formatLegacy
+			case formatEnvelope:
+				format = formatEnvelope
+			}
+		}
+
+		ctx := context.WithValue(r.Context(), formatKey{}, format)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// respondLookupError reports a failed fetch of one resource: 404 "<what> not found"
+// when the store found nothing, otherwise 500 so database failures aren't masked
+func respondLookupError(w http.ResponseWriter, r *http.Request, what string, err error) {
+	if store.IsNotFound(err) {
+		respondError(w, r, http.StatusNotFound, what+" not found", err)
+		return
+	}
+	respondError(w, r, http.StatusInternalServerError, "Failed to fetch "+strings.ToLower(what), err)
+}
+
+// enveloped reports whether the response should be wrapped. Routes outside the
+// middleware (health, metrics) always answer with bare payloads.
+func enveloped(r *http.Request) bool {
+	format, _ := r.Context().Value(formatKey{}).(string)
+	return format == formatEnvelope
+}
+
+// errorCodeForStatus maps an HTTP status to its error code
+func errorCodeForStatus(status int) ErrorCode {
+	switch {
+	case status == http.StatusNotFound:
+		return ErrCodeNotFound
+	case status == http.StatusConflict:
+		return ErrCodeConflict
+	case status == http.StatusGatewayTimeout:
+		return ErrCodeTimeout
+	case status == http.StatusBadGateway || status == http.StatusServiceUnavailable:
+		return ErrCodeUpstreamUnavailable
+	case status >= 400 && status < 500:
+		return ErrCodeValidation
+	default:
+		return ErrCodeInternal
+	}
+}
+
+// respondJSON writes a response whose payload is the same in both formats
+func respondJSON(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
+	respondEnvelope(w, r, status, data, nil, data)
+}
+
+// respondList writes a list. Enveloped, items become data and the count goes in
+// meta; legacy clients get {key: items, "count": n}, or the bare items when key is empty.
+func respondList(w http.ResponseWriter, r *http.Request, key string, items interface{}, count, limit int) {
+	var legacy interface{} = items
+	if key != "" {
+		legacy = map[string]interface{}{key: items, "count": count}
+	}
+	respondEnvelope(w, r, http.StatusOK, items, &Meta{Count: count, Limit: limit}, legacy)
+}
+
+// statusClientClosedRequest is logged for requests whose client disconnected before
+// the response was written (nginx's 499); nothing is sent
+const statusClientClosedRequest = 499
+
+// clientGone reports whether the client disconnected. The server cancels a
+// request's context when its connection closes; shutdown does not cancel it.
+func clientGone(r *http.Request) bool {
+	return errors.Is(r.Context().Err(), context.Canceled)
+}
+
+// respondEnvelope writes data and meta in an envelope, or legacy as-is for legacy
+// clients, with names localized for requests that selected a locale
+func respondEnvelope(w http.ResponseWriter, r *http.Request, status int, data interface{}, meta *Meta, legacy interface{}) {
+	if clientGone(r) {
+		w.WriteHeader(statusClientClosedRequest)
+		return
+	}
+
+	payload := legacy
+	if enveloped(r) {
+		payload = Envelope{Data: data, Meta: meta}
+	}
+	payload = localized(w, r, payload)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
+
+// respondError writes an error response.
+// Query timeouts are reported as 504 regardless of the status the handler chose,
+// and a 5xx for an error typed store.ErrNotFound or store.ErrConflict becomes 404 or 409.
+// Nothing is written once the client has disconnected.
+func respondError(w http.ResponseWriter, r *http.Request, status int, message string, err error) {
+	if clientGone(r) {
+		w.WriteHeader(statusClientClosedRequest)
+		return
+	}
+
+	switch {
+	case store.IsTimeout(err):
+		status = http.StatusGatewayTimeout
+		message = message + " (query timed out)"
+	case status >= http.StatusInternalServerError && store.IsNotFound(err):
+		status = http.StatusNotFound
+	case status >= http.StatusInternalServerError && errors.Is(err, store.ErrConflict):
+		status = http.StatusConflict
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if enveloped(r) {
+		apiErr := &APIError{Code: errorCodeForStatus(status), Message: message}
+		if err != nil {
+			apiErr.Details = err.Error()
+		}
+		json.NewEncoder(w).Encode(Envelope{Error: apiErr})
+		return
+	}
+
+	response := map[string]interface{}{
+		"error":  message,
+		"status": status,
+	}
+	if status == http.StatusGatewayTimeout {
+		response["code"] = "query_timeout"
+	}
+	if err != nil {
+		response["details"] = err.Error()
+	}
+	json.NewEncoder(w).Encode(response)
+}
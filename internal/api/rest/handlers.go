@@ -3,9 +3,12 @@ package rest
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fortuna/minerva/internal/service"
@@ -16,54 +19,160 @@ import (
 
 // Handler contains dependencies for HTTP handlers
 type Handler struct {
-	db               *store.Database
-	gameService      *service.GameService
-	playerService    *service.PlayerService
-	statsService     *service.StatsService
-	analyticsService *service.AnalyticsService
+	db                *store.Database
+	gameService       *service.GameService
+	playerService     *service.PlayerService
+	statsService      *service.StatsService
+	analyticsService  *service.AnalyticsService
+	featureMatrix     *service.FeatureMatrixService
+	recapService      *service.RecapService
+	liveDerived       *service.LiveDerivedService
+	playoffService    *service.PlayoffService
+	tournamentService *service.TournamentService
+	closingLines      *service.ClosingLineService
+	workloadService   *service.WorkloadService
+	positionalDefense *service.PositionalDefenseService
+	ratings           *service.RatingService
+	clutchService     *service.ClutchService
+	scheduleService   *service.ScheduleService
+	attendance        *service.AttendanceService
+	news              *service.NewsService
+	transactions      *service.TransactionService
+	ingestionRuns     *repository.IngestionRunRepository
 }
 
 // NewHandler creates a new handler
 func NewHandler(db *store.Database) *Handler {
 	return &Handler{
-		db:               db,
-		gameService:      service.NewGameService(db),
-		playerService:    service.NewPlayerService(db),
-		statsService:     service.NewStatsService(db),
-		analyticsService: service.NewAnalyticsService(db),
+		db:                db,
+		gameService:       service.NewGameService(db),
+		playerService:     service.NewPlayerService(db),
+		statsService:      service.NewStatsService(db),
+		analyticsService:  service.NewAnalyticsService(db),
+		featureMatrix:     service.NewFeatureMatrixService(db),
+		recapService:      service.NewRecapService(db),
+		liveDerived:       service.NewLiveDerivedService(db),
+		playoffService:    service.NewPlayoffService(db),
+		tournamentService: service.NewTournamentService(db),
+		closingLines:      service.NewClosingLineService(db),
+		workloadService:   service.NewWorkloadService(db),
+		positionalDefense: service.NewPositionalDefenseService(db),
+		ratings:           service.NewRatingService(db),
+		clutchService:     service.NewClutchService(db),
+		scheduleService:   service.NewScheduleService(db),
+		attendance:        service.NewAttendanceService(db),
+		news:              service.NewNewsService(db),
+		transactions:      service.NewTransactionService(db),
+		ingestionRuns:     repository.NewIngestionRunRepository(db),
 	}
 }
 
-// HealthCheck handles health check requests
+// HealthCheck handles health check requests. It fails with 503 while the
+// primary database is unreachable; a replica outage only shows in read_replica.
 func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
+	status := http.StatusOK
+	health := map[string]string{
+		"status":   "healthy",
+		"service":  "minerva",
+		"version":  "2.0.0",
+		"database": "ok",
+	}
+
+	if err := h.db.HealthCheck(); err != nil {
+		status = http.StatusServiceUnavailable
+		health["status"] = "unhealthy"
+		health["database"] = err.Error()
+	}
+
+	if h.db.HasReplica() {
+		health["read_replica"] = "ok"
+		if err := h.db.ReplicaHealthCheck(); err != nil {
+			// Reads fall back to the primary, so the service stays up
+			health["read_replica"] = "unavailable (reads served by primary)"
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"status":  "healthy",
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(health)
+}
+
+// ReadinessCheck handles GET /health/ready.
+// The service is ready when the database answers and no migrations are pending;
+// data_freshness is informational and never fails the check.
+func (h *Handler) ReadinessCheck(w http.ResponseWriter, r *http.Request) {
+	ready := true
+	checks := map[string]string{"database": "ok", "migrations": "ok"}
+
+	if err := h.db.HealthCheck(); err != nil {
+		ready = false
+		checks["database"] = err.Error()
+	} else if pending, err := h.db.PendingMigrations(r.Context()); err != nil {
+		ready = false
+		checks["migrations"] = err.Error()
+	} else if pending > 0 {
+		ready = false
+		checks["migrations"] = fmt.Sprintf("%d pending", pending)
+	}
+
+	response := map[string]interface{}{
+		"status":  "ready",
 		"service": "minerva",
-		"version": "2.0.0",
-	})
+		"checks":  checks,
+	}
+
+	if ready {
+		if freshness, err := h.ingestionRuns.Freshness(r.Context()); err != nil {
+			response["data_freshness"] = map[string]string{"error": err.Error()}
+		} else {
+			response["data_freshness"] = freshness
+		}
+	}
+
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+		response["status"] = "not_ready"
+	}
+
+	respondJSON(w, r, status, response)
 }
 
 // GetLiveGames returns all currently live games
 func (h *Handler) GetLiveGames(w http.ResponseWriter, r *http.Request) {
-	games, err := h.gameService.GetLiveGames(r.Context())
+	gameTypes, err := parseGameTypes(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	leagues, err := parseLeagues(r)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch live games", err)
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, games)
+	games, err := h.gameService.GetLiveGames(r.Context(), sportFrom(r), leagues, gameTypes)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch live games", err)
+		return
+	}
+
+	projected, ok := selectFields(w, r, games)
+	if !ok {
+		return
+	}
+	respondList(w, r, "", projected, len(games), 0)
 }
 
 // CleanupStaleGames marks old "in_progress" games as "final"
 func (h *Handler) CleanupStaleGames(w http.ResponseWriter, r *http.Request) {
 	count, err := h.gameService.CleanupStaleGames(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to cleanup stale games", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to cleanup stale games", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
+	respondJSON(w, r, http.StatusOK, map[string]interface{}{
 		"message":       "Stale games cleaned up",
 		"games_updated": count,
 	})
@@ -78,17 +187,32 @@ func (h *Handler) GetGamesByDate(w http.ResponseWriter, r *http.Request) {
 
 	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid date format (use YYYY-MM-DD)", err)
+		respondError(w, r, http.StatusBadRequest, "Invalid date format (use YYYY-MM-DD)", err)
+		return
+	}
+
+	gameTypes, err := parseGameTypes(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	leagues, err := parseLeagues(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
 		return
 	}
 
-	games, err := h.gameService.GetGamesByDate(r.Context(), date)
+	games, err := h.gameService.GetGamesByDate(r.Context(), sportFrom(r), date, leagues, gameTypes)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch games", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch games", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, games)
+	projected, ok := selectFields(w, r, games)
+	if !ok {
+		return
+	}
+	respondList(w, r, "", projected, len(games), 0)
 }
 
 // GetUpcomingGames returns upcoming scheduled games
@@ -101,27 +225,54 @@ func (h *Handler) GetUpcomingGames(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	games, err := h.gameService.GetUpcomingGames(r.Context(), limit)
+	gameTypes, err := parseGameTypes(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	leagues, err := parseLeagues(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	games, err := h.gameService.GetUpcomingGames(r.Context(), sportFrom(r), limit, leagues, gameTypes)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch upcoming games", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch upcoming games", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, games)
+	projected, ok := selectFields(w, r, games)
+	if !ok {
+		return
+	}
+	respondList(w, r, "", projected, len(games), limit)
 }
 
 // GetTodaysGames returns all games for today (live, scheduled, final)
 func (h *Handler) GetTodaysGames(w http.ResponseWriter, r *http.Request) {
-	games, err := h.gameService.GetTodaysGames(r.Context())
+	gameTypes, err := parseGameTypes(r)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch today's games", err)
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	leagues, err := parseLeagues(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"games": games,
-		"count": len(games),
-	})
+	games, err := h.gameService.GetTodaysGames(r.Context(), sportFrom(r), leagues, gameTypes)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch today's games", err)
+		return
+	}
+
+	projected, ok := selectFields(w, r, games)
+	if !ok {
+		return
+	}
+	respondList(w, r, "games", projected, len(games), 0)
 }
 
 // GetGame returns a specific game by ID
@@ -131,11 +282,48 @@ func (h *Handler) GetGame(w http.ResponseWriter, r *http.Request) {
 
 	game, err := h.gameService.GetGame(r.Context(), gameID)
 	if err != nil {
-		respondError(w, http.StatusNotFound, "Game not found", err)
+		respondLookupError(w, r, "Game", err)
+		return
+	}
+	if game.Game.Sport != sportFrom(r) {
+		respondError(w, r, http.StatusNotFound, "Game not found", nil)
+		return
+	}
+
+	projected, ok := selectFields(w, r, game)
+	if !ok {
+		return
+	}
+	respondJSON(w, r, http.StatusOK, projected)
+}
+
+// GetGameChanges returns what changed in a game since ?since= (RFC 3339)
+func (h *Handler) GetGameChanges(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	gameID := vars["gameID"]
+
+	sinceStr := r.URL.Query().Get("since")
+	if sinceStr == "" {
+		respondError(w, r, http.StatusBadRequest, "since is required (RFC 3339 timestamp)", nil)
+		return
+	}
+	since, err := time.Parse(time.RFC3339, sinceStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid since (use an RFC 3339 timestamp)", err)
+		return
+	}
+
+	changes, err := h.gameService.GetGameChanges(r.Context(), gameID, since)
+	if err != nil {
+		respondLookupError(w, r, "Game", err)
+		return
+	}
+	if changes.Sport != sportFrom(r) {
+		respondError(w, r, http.StatusNotFound, "Game not found", nil)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, game)
+	respondJSON(w, r, http.StatusOK, changes)
 }
 
 // GetGameBoxScore returns the box score for a game
@@ -143,297 +331,1316 @@ func (h *Handler) GetGameBoxScore(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	gameID := vars["gameID"]
 
+	format := vars["format"]
+	if format == "" {
+		format = "json"
+	}
+	render, ok := boxScoreRenderers[format]
+	if !ok {
+		respondError(w, r, http.StatusNotFound, fmt.Sprintf("Unknown box score format %q", format), nil)
+		return
+	}
+
 	boxScore, err := h.statsService.GetGameBoxScore(r.Context(), gameID)
 	if err != nil {
-		respondError(w, http.StatusNotFound, "Box score not found", err)
+		respondLookupError(w, r, "Box score", err)
+		return
+	}
+	if boxScore.Game.Sport != sportFrom(r) {
+		respondError(w, r, http.StatusNotFound, "Game not found", nil)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, boxScore)
+	render(w, r, boxScore)
 }
 
-// GetPlayer returns a player by ID
-func (h *Handler) GetPlayer(w http.ResponseWriter, r *http.Request) {
+// GetGameHighlights returns a game's ESPN video highlight links
+func (h *Handler) GetGameHighlights(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	playerIDStr := vars["playerID"]
+	gameID := vars["gameID"]
 
-	playerID, err := strconv.Atoi(playerIDStr)
+	highlights, err := h.gameService.GetGameHighlights(r.Context(), gameID)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid player ID", err)
+		respondLookupError(w, r, "Game", err)
 		return
 	}
-
-	player, err := h.playerService.GetPlayer(r.Context(), playerID)
-	if err != nil {
-		respondError(w, http.StatusNotFound, "Player not found", err)
+	if highlights.Sport != sportFrom(r) {
+		respondError(w, r, http.StatusNotFound, "Game not found", nil)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, player)
+	projected, ok := selectFields(w, r, highlights)
+	if !ok {
+		return
+	}
+	respondJSON(w, r, http.StatusOK, projected)
 }
 
-// SearchPlayers searches for players by name
-func (h *Handler) SearchPlayers(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query().Get("q")
-	if query == "" {
-		respondError(w, http.StatusBadRequest, "Missing query parameter 'q'", nil)
+// ResolveGame maps ?source= and ?id= (an ESPN ID, Minerva game_id, google_
+// synthetic ID, or Alexandria event ID) to the canonical game and every
+// identifier known for it
+func (h *Handler) ResolveGame(w http.ResponseWriter, r *http.Request) {
+	source := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("source")))
+	id := strings.TrimSpace(r.URL.Query().Get("id"))
+	if source == "" || id == "" {
+		respondError(w, r, http.StatusBadRequest, "source and id are required", nil)
 		return
 	}
 
-	profiles, err := h.playerService.SearchPlayers(r.Context(), query)
+	resolution, err := h.gameService.ResolveGame(r.Context(), source, id)
+	if errors.Is(err, service.ErrUnknownIDSource) {
+		respondError(w, r, http.StatusBadRequest,
+			"Unknown source (use minerva, espn, google, alexandria, nba_stats, or basketball_reference)", err)
+		return
+	}
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to search players", err)
+		respondLookupError(w, r, "Game", err)
 		return
 	}
-
-	// Extract just the player data for the response
-	players := make([]*store.Player, 0, len(profiles))
-	for _, profile := range profiles {
-		if profile.Player != nil {
-			// Add current_team_id if available
-			if profile.Team != nil {
-				profile.Player.CurrentTeamID = profile.Team.TeamID
-			}
-			players = append(players, profile.Player)
-		}
+	if resolution.Game.Game.Sport != sportFrom(r) {
+		respondError(w, r, http.StatusNotFound, "Game not found", nil)
+		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{"players": players})
+	projected, ok := selectFields(w, r, resolution)
+	if !ok {
+		return
+	}
+	respondJSON(w, r, http.StatusOK, projected)
 }
 
-// GetPlayerStats returns a player's recent game stats
-func (h *Handler) GetPlayerStats(w http.ResponseWriter, r *http.Request) {
+// GetGameRecap returns a structured recap (headline, top performers, key runs,
+// quarter scores, milestones) built from stored box score and play-by-play data
+func (h *Handler) GetGameRecap(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	playerIDStr := vars["playerID"]
+	gameID := vars["gameID"]
 
-	playerID, err := strconv.Atoi(playerIDStr)
+	recap, err := h.recapService.GetGameRecap(r.Context(), gameID)
+	if errors.Is(err, service.ErrRecapUnavailable) {
+		respondError(w, r, http.StatusConflict, "Game has not started", err)
+		return
+	}
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid player ID", err)
+		respondLookupError(w, r, "Game", err)
+		return
+	}
+	if recap.Sport != sportFrom(r) {
+		respondError(w, r, http.StatusNotFound, "Game not found", nil)
 		return
 	}
 
-	limitStr := r.URL.Query().Get("limit")
-	limit := 10 // default
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 50 {
-			limit = l
-		}
+	respondJSON(w, r, http.StatusOK, recap)
+}
+
+// GetDailyRecap summarizes a date's final games league-wide: scores, the top
+// performances, milestones, and standings movement (?date=YYYY-MM-DD, default yesterday)
+func (h *Handler) GetDailyRecap(w http.ResponseWriter, r *http.Request) {
+	dateStr := r.URL.Query().Get("date")
+	if dateStr == "" {
+		dateStr = time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	}
+
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid date format (use YYYY-MM-DD)", err)
+		return
 	}
 
-	stats, err := h.playerService.GetPlayerStats(r.Context(), playerID, limit)
+	recap, err := h.recapService.GetDailyRecap(r.Context(), sportFrom(r), date)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch player stats", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to build daily recap", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, stats)
+	respondJSON(w, r, http.StatusOK, recap)
 }
 
-// GetPlayerSeasonAverages returns a player's season averages
-func (h *Handler) GetPlayerSeasonAverages(w http.ResponseWriter, r *http.Request) {
+// GetGameLiveDerived returns a live game's pace, projected final score, and
+// scoring runs, as published on games.live.derived
+func (h *Handler) GetGameLiveDerived(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	playerIDStr := vars["playerID"]
+	gameID := vars["gameID"]
 
-	playerID, err := strconv.Atoi(playerIDStr)
+	derived, err := h.liveDerived.GetLiveDerived(r.Context(), gameID)
+	if errors.Is(err, service.ErrLiveDerivedUnavailable) {
+		respondError(w, r, http.StatusConflict, "Game has not started", err)
+		return
+	}
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid player ID", err)
+		respondLookupError(w, r, "Game", err)
 		return
 	}
-
-	seasonID := r.URL.Query().Get("season")
-	if seasonID == "" {
-		seasonID = "2024-25" // default to current season
+	if derived.Sport != sportFrom(r) {
+		respondError(w, r, http.StatusNotFound, "Game not found", nil)
+		return
 	}
 
-	averages, err := h.playerService.GetPlayerSeasonAverages(r.Context(), playerID, seasonID)
+	respondJSON(w, r, http.StatusOK, derived)
+}
+
+// GetPlayoffBracket returns the postseason bracket for ?season= (latest when omitted)
+func (h *Handler) GetPlayoffBracket(w http.ResponseWriter, r *http.Request) {
+	season := r.URL.Query().Get("season")
+
+	bracket, err := h.playoffService.GetBracket(r.Context(), sportFrom(r), season)
+	if errors.Is(err, service.ErrNoPlayoffSeries) {
+		respondError(w, r, http.StatusNotFound, "No playoff series found", err)
+		return
+	}
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to calculate season averages", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to build playoff bracket", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, averages)
+	respondJSON(w, r, http.StatusOK, bracket)
 }
 
-// GetTeams returns all teams
-func (h *Handler) GetTeams(w http.ResponseWriter, r *http.Request) {
-	teamRepo := repository.NewTeamRepository(h.db)
-	teams, err := teamRepo.GetAll(r.Context())
+// GetTournamentStandings returns NBA Cup group standings for ?season= (latest when omitted)
+func (h *Handler) GetTournamentStandings(w http.ResponseWriter, r *http.Request) {
+	season := r.URL.Query().Get("season")
+
+	standings, err := h.tournamentService.GetStandings(r.Context(), sportFrom(r), season)
+	if errors.Is(err, service.ErrNoTournamentGames) {
+		respondError(w, r, http.StatusNotFound, "No tournament games found", err)
+		return
+	}
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch teams", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to compute tournament standings", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{"teams": teams})
+	respondJSON(w, r, http.StatusOK, standings)
 }
 
-// GetTeam returns a specific team by ID
-func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
+// GetPlayer returns a player by ID
+func (h *Handler) GetPlayer(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	teamIDStr := vars["teamID"]
+	playerIDStr := vars["playerID"]
 
-	teamID, err := strconv.Atoi(teamIDStr)
+	playerID, err := strconv.Atoi(playerIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid team ID", err)
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
 		return
 	}
 
-	teamRepo := repository.NewTeamRepository(h.db)
-	team, err := teamRepo.GetByID(r.Context(), teamID)
+	player, err := h.playerService.GetPlayer(r.Context(), playerID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch team", err)
+		respondLookupError(w, r, "Player", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{"team": team})
+	projected, ok := selectFields(w, r, player)
+	if !ok {
+		return
+	}
+	respondJSON(w, r, http.StatusOK, projected)
 }
 
-// GetTeamRoster returns a team's current roster
-func (h *Handler) GetTeamRoster(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	teamIDStr := vars["teamID"]
-
-	teamID, err := strconv.Atoi(teamIDStr)
-	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid team ID", err)
+// SearchPlayers searches for players by name. ?mode=autocomplete matches name
+// prefixes and returns only id, name, team, and headshot.
+func (h *Handler) SearchPlayers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		respondError(w, r, http.StatusBadRequest, "Missing query parameter 'q'", nil)
 		return
 	}
 
-	roster, err := h.playerService.GetTeamRoster(r.Context(), teamID)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch team roster", err)
+	mode := r.URL.Query().Get("mode")
+	if mode != "" && mode != "full" && mode != "autocomplete" {
+		respondError(w, r, http.StatusBadRequest, "Invalid mode (use full or autocomplete)", nil)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, roster)
-}
+	limit, maxLimit := 50, 100
+	if mode == "autocomplete" {
+		limit, maxLimit = 10, 25
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= maxLimit {
+			limit = l
+		}
+	}
 
-// GetTeamSchedule returns a team's schedule
-func (h *Handler) GetTeamSchedule(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	teamIDStr := vars["teamID"]
+	if mode == "autocomplete" {
+		suggestions, err := h.playerService.AutocompletePlayers(r.Context(), query, limit)
+		if err != nil {
+			respondError(w, r, http.StatusInternalServerError, "Failed to search players", err)
+			return
+		}
+		projected, ok := selectFields(w, r, suggestions)
+		if !ok {
+			return
+		}
+		respondList(w, r, "players", projected, len(suggestions), limit)
+		return
+	}
 
-	teamID, err := strconv.Atoi(teamIDStr)
+	profiles, err := h.playerService.SearchPlayers(r.Context(), query, limit)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid team ID", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to search players", err)
 		return
 	}
 
-	seasonYear := r.URL.Query().Get("season")
-	if seasonYear == "" {
-		seasonYear = "2025-26" // default to current season
+	// Extract just the player data for the response
+	players := make([]*store.Player, 0, len(profiles))
+	for _, profile := range profiles {
+		if profile.Player != nil {
+			// Add current_team_id if available
+			if profile.Team != nil {
+				profile.Player.CurrentTeamID = profile.Team.TeamID
+			}
+			players = append(players, profile.Player)
+		}
 	}
 
-	// Lookup season_id from season_year
-	seasonID, err := h.lookupSeasonID(r.Context(), seasonYear)
+	projected, ok := selectFields(w, r, players)
+	if !ok {
+		return
+	}
+	respondList(w, r, "players", projected, len(players), limit)
+}
+
+// GetPlayerStats returns a player's recent game stats
+func (h *Handler) GetPlayerStats(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	playerIDStr := vars["playerID"]
+
+	playerID, err := strconv.Atoi(playerIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid season: %s", seasonYear), err)
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
 		return
 	}
 
 	limitStr := r.URL.Query().Get("limit")
-	limit := 20 // default
+	limit := 10 // default
 	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 50 {
 			limit = l
 		}
 	}
 
-	schedule, err := h.gameService.GetTeamSchedule(r.Context(), teamID, seasonID, limit)
+	per, err := service.ParsePer(r.URL.Query().Get("per"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	stats, err := h.playerService.GetPlayerStats(r.Context(), playerID, limit, per)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch team schedule", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch player stats", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, schedule)
+	projected, ok := selectFields(w, r, stats)
+	if !ok {
+		return
+	}
+	respondList(w, r, "", projected, len(stats), limit)
 }
 
-// GetPlayerPerformanceTrend returns performance trends for a player
-func (h *Handler) GetPlayerPerformanceTrend(w http.ResponseWriter, r *http.Request) {
+// GetPlayerWorkload returns a player's current minute load and fatigue index,
+// plus ?days= nightly snapshots (default 14)
+func (h *Handler) GetPlayerWorkload(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	playerIDStr := vars["playerID"]
 
 	playerID, err := strconv.Atoi(playerIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid player ID", err)
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
 		return
 	}
 
-	gamesStr := r.URL.Query().Get("games")
-	games := 10 // default
-	if gamesStr != "" {
-		if g, err := strconv.Atoi(gamesStr); err == nil && g > 0 && g <= 50 {
-			games = g
+	days := 14 // default
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 && d <= 90 {
+			days = d
 		}
 	}
 
-	trend, err := h.analyticsService.GetPlayerPerformanceTrend(r.Context(), playerID, games)
+	workload, err := h.workloadService.GetPlayerWorkload(r.Context(), sportFrom(r), playerID, days)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to calculate performance trend", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch player workload", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, trend)
+	respondJSON(w, r, http.StatusOK, workload)
 }
 
-// GetPlayerMLFeatures returns ML features for a player
-func (h *Handler) GetPlayerMLFeatures(w http.ResponseWriter, r *http.Request) {
+// GetPlayerClutch returns a player's clutch-time scoring and shooting (?season=, all seasons when omitted)
+func (h *Handler) GetPlayerClutch(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	playerIDStr := vars["playerID"]
 
 	playerID, err := strconv.Atoi(playerIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid player ID", err)
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
 		return
 	}
 
-	seasonID := r.URL.Query().Get("season")
-	if seasonID == "" {
-		seasonID = "2024-25" // default to current season
-	}
-
-	features, err := h.analyticsService.GetPlayerMLFeatures(r.Context(), playerID, seasonID)
+	clutch, err := h.clutchService.GetPlayerClutch(r.Context(), playerID, r.URL.Query().Get("season"))
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to generate ML features", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch player clutch stats", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, features)
+	respondJSON(w, r, http.StatusOK, clutch)
 }
 
-// lookupSeasonID queries the database to get season_id (INT) from season_year (STRING)
-func (h *Handler) lookupSeasonID(ctx context.Context, seasonYear string) (int, error) {
-	query := `SELECT season_id FROM seasons WHERE season_year = $1 AND sport = 'basketball_nba' LIMIT 1`
-	
-	var seasonID int
-	err := h.db.DB().QueryRowContext(ctx, query, seasonYear).Scan(&seasonID)
-	if err != nil {
-		return 0, fmt.Errorf("season '%s' not found in database: %w", seasonYear, err)
-	}
-	
-	return seasonID, nil
+// GetPlayerSeasonAverages returns a player's season averages
+func (h *Handler) GetPlayerSeasonAverages(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	playerIDStr := vars["playerID"]
+
+	playerID, err := strconv.Atoi(playerIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
+		return
+	}
+
+	seasonID := r.URL.Query().Get("season")
+	if seasonID == "" {
+		seasonID = "2024-25" // default to current season
+	}
+
+	includeSpecial := r.URL.Query().Get("include_special") == "true"
+
+	per, err := service.ParsePer(r.URL.Query().Get("per"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if per != service.PerGame {
+		rates, err := h.playerService.GetPlayerSeasonRates(r.Context(), playerID, seasonID, includeSpecial, per)
+		if err != nil {
+			respondError(w, r, http.StatusInternalServerError, "Failed to calculate season averages", err)
+			return
+		}
+		respondJSON(w, r, http.StatusOK, rates)
+		return
+	}
+
+	averages, err := h.playerService.GetPlayerSeasonAverages(r.Context(), playerID, seasonID, includeSpecial)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to calculate season averages", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, averages)
+}
+
+// GetPlayerCareer returns career totals, highs, and upcoming milestones
+func (h *Handler) GetPlayerCareer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	playerIDStr := vars["playerID"]
+
+	playerID, err := strconv.Atoi(playerIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
+		return
+	}
+
+	seasonType := r.URL.Query().Get("season_type")
+	switch seasonType {
+	case "", "regular", "playoffs":
+	default:
+		respondError(w, r, http.StatusBadRequest, "season_type must be 'regular' or 'playoffs'", nil)
+		return
+	}
+
+	career, err := h.playerService.GetCareer(r.Context(), playerID, seasonType)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to build player career", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, career)
+}
+
+// GetDraftClass returns a draft year's picks with career stats joined
+func (h *Handler) GetDraftClass(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(mux.Vars(r)["year"])
+	if err != nil || year < 1947 || year > time.Now().Year()+1 {
+		respondError(w, r, http.StatusBadRequest, "Invalid draft year", err)
+		return
+	}
+
+	class, err := h.playerService.GetDraftClass(r.Context(), sportFrom(r), year)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch draft class", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, class)
+}
+
+// GetTeams returns all teams
+func (h *Handler) GetTeams(w http.ResponseWriter, r *http.Request) {
+	teamRepo := repository.NewTeamRepository(h.db)
+	teams, err := teamRepo.GetAll(r.Context())
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch teams", err)
+		return
+	}
+
+	respondList(w, r, "teams", teams, len(teams), 0)
+}
+
+// SearchTeams finds teams by abbreviation, nickname, city, full name, or alias
+// (?q=warriors), exact matches first
+func (h *Handler) SearchTeams(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		respondError(w, r, http.StatusBadRequest, "Missing query parameter 'q'", nil)
+		return
+	}
+
+	league, err := leagueFrom(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	teamRepo := repository.NewTeamRepository(h.db)
+	resolver, err := teamRepo.GetResolver(r.Context(), league)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to search teams", err)
+		return
+	}
+
+	matches := resolver.Search(query)
+	projected, ok := selectFields(w, r, matches)
+	if !ok {
+		return
+	}
+	respondList(w, r, "teams", projected, len(matches), 0)
+}
+
+// GetTeam returns a specific team by ID
+func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamIDStr := vars["teamID"]
+
+	teamID, err := strconv.Atoi(teamIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	teamRepo := repository.NewTeamRepository(h.db)
+	team, err := teamRepo.GetByID(r.Context(), teamID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch team", err)
+		return
+	}
+
+	respondEnvelope(w, r, http.StatusOK, team, nil, map[string]interface{}{"team": team})
+}
+
+// GetTeamRoster returns a team's current roster
+func (h *Handler) GetTeamRoster(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamIDStr := vars["teamID"]
+
+	teamID, err := strconv.Atoi(teamIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	roster, err := h.playerService.GetTeamRoster(r.Context(), teamID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch team roster", err)
+		return
+	}
+
+	projected, ok := selectFields(w, r, roster)
+	if !ok {
+		return
+	}
+	respondList(w, r, "", projected, len(roster), 0)
+}
+
+// GetTeamCoaches returns a team's head coach history, current coach first
+func (h *Handler) GetTeamCoaches(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(mux.Vars(r)["teamID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	coachRepo := repository.NewCoachRepository(h.db)
+	tenures, err := coachRepo.GetByTeam(r.Context(), teamID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch team coaches", err)
+		return
+	}
+	if tenures == nil {
+		tenures = []*store.CoachTenure{}
+	}
+
+	projected, ok := selectFields(w, r, tenures)
+	if !ok {
+		return
+	}
+	respondList(w, r, "", projected, len(tenures), 0)
+}
+
+// GetTeamSchedule returns a team's schedule
+func (h *Handler) GetTeamSchedule(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamIDStr := vars["teamID"]
+
+	teamID, err := strconv.Atoi(teamIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	seasonYear := r.URL.Query().Get("season")
+	if seasonYear == "" {
+		seasonYear = "2025-26" // default to current season
+	}
+
+	league, err := leagueFrom(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	// Lookup season_id from season_year
+	seasonID, err := h.lookupSeasonID(r.Context(), sportFrom(r), league, seasonYear)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid season: %s", seasonYear), err)
+		return
+	}
+
+	limitStr := r.URL.Query().Get("limit")
+	limit := 20 // default
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	gameTypes, err := parseGameTypes(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	schedule, err := h.gameService.GetTeamSchedule(r.Context(), teamID, seasonID, limit, gameTypes)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch team schedule", err)
+		return
+	}
+
+	projected, ok := selectFields(w, r, schedule)
+	if !ok {
+		return
+	}
+	respondList(w, r, "", projected, len(schedule), limit)
+}
+
+// GetTeamGameLogs returns a team's per-game stats joined with opponent stats
+func (h *Handler) GetTeamGameLogs(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamIDStr := vars["teamID"]
+
+	teamID, err := strconv.Atoi(teamIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	seasonYear := r.URL.Query().Get("season")
+	if seasonYear == "" {
+		seasonYear = currentSeasonYear(time.Now())
+	}
+
+	logs, err := h.statsService.GetTeamGameLogs(r.Context(), teamID, seasonYear)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch team game logs", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, logs)
+}
+
+// GetTeamBenchProduction returns starter vs bench production per game and per season
+func (h *Handler) GetTeamBenchProduction(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamIDStr := vars["teamID"]
+
+	teamID, err := strconv.Atoi(teamIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	seasonYear := r.URL.Query().Get("season")
+	if seasonYear == "" {
+		seasonYear = currentSeasonYear(time.Now())
+	}
+
+	production, err := h.statsService.GetTeamBenchProduction(r.Context(), teamID, seasonYear)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch bench production", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, production)
+}
+
+// GetTeamClutch returns a team's clutch-time record and net rating (?season=, all seasons when omitted)
+func (h *Handler) GetTeamClutch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamIDStr := vars["teamID"]
+
+	teamID, err := strconv.Atoi(teamIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	clutch, err := h.clutchService.GetTeamClutch(r.Context(), teamID, r.URL.Query().Get("season"))
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch team clutch stats", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, clutch)
+}
+
+// GetTeamScheduleAnalysis handles GET /teams/{teamID}/schedule-analysis?season=&game_type=:
+// back-to-backs, rest days, opponent strength by net rating, and longest road trips
+func (h *Handler) GetTeamScheduleAnalysis(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(mux.Vars(r)["teamID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	seasonYear := r.URL.Query().Get("season")
+	if seasonYear == "" {
+		seasonYear = currentSeasonYear(time.Now())
+	}
+
+	league, err := leagueFrom(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	gameTypes, err := parseGameTypes(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	seasonID, err := h.lookupSeasonID(r.Context(), sportFrom(r), league, seasonYear)
+	if err != nil {
+		respondError(w, r, http.StatusNotFound, fmt.Sprintf("Invalid season: %s", seasonYear), err)
+		return
+	}
+
+	analysis, err := h.scheduleService.GetScheduleAnalysis(r.Context(), teamID, seasonID, seasonYear, gameTypes)
+	if errors.Is(err, service.ErrTeamNotFound) {
+		respondError(w, r, http.StatusNotFound, "Team not found", err)
+		return
+	}
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to analyse team schedule", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, analysis)
+}
+
+// GetTeamPositionalDefense handles GET /teams/{teamID}/positional-defense?season=:
+// per-game points, rebounds, assists, and threes the team allows to each position
+func (h *Handler) GetTeamPositionalDefense(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(mux.Vars(r)["teamID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	seasonYear, ok := h.positionalDefenseSeason(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.positionalDefense.GetTeam(r.Context(), teamID, seasonYear)
+	if errors.Is(err, service.ErrTeamNotFound) {
+		respondError(w, r, http.StatusNotFound, "Team not found", err)
+		return
+	}
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch positional defense", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, report)
+}
+
+// GetTeamRatingHistory handles GET /teams/{teamID}/rating-history?season=: the
+// team's Elo before and after each final game of the season
+func (h *Handler) GetTeamRatingHistory(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(mux.Vars(r)["teamID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	seasonYear := r.URL.Query().Get("season")
+	if seasonYear == "" {
+		seasonYear = currentSeasonYear(time.Now())
+	}
+	seasonID, err := h.lookupSeasonID(r.Context(), sportFrom(r), store.LeagueNBA, seasonYear)
+	if err != nil {
+		respondError(w, r, http.StatusNotFound, fmt.Sprintf("Invalid season: %s", seasonYear), err)
+		return
+	}
+
+	history, err := h.ratings.GetHistory(r.Context(), teamID, seasonID, seasonYear)
+	if errors.Is(err, service.ErrTeamNotFound) {
+		respondError(w, r, http.StatusNotFound, "Team not found", err)
+		return
+	}
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch team ratings", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, history)
+}
+
+// GetPositionalDefense handles GET /analytics/positional-defense?position=&season=:
+// every team's defense against one position, softest first
+func (h *Handler) GetPositionalDefense(w http.ResponseWriter, r *http.Request) {
+	position := strings.ToUpper(r.URL.Query().Get("position"))
+	if !slices.Contains(store.DefensivePositions, position) {
+		respondError(w, r, http.StatusBadRequest, "position must be one of PG, SG, SF, PF, C", nil)
+		return
+	}
+
+	seasonYear, ok := h.positionalDefenseSeason(w, r)
+	if !ok {
+		return
+	}
+
+	teams, err := h.positionalDefense.GetLeague(r.Context(), seasonYear, position)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch positional defense", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, map[string]interface{}{
+		"season":   seasonYear,
+		"position": position,
+		"teams":    teams,
+	})
 }
 
-// respondJSON writes a JSON response
-func respondJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+// positionalDefenseSeason resolves ?season=, defaulting to the current season.
+// Positional defense is only aggregated for the NBA.
+func (h *Handler) positionalDefenseSeason(w http.ResponseWriter, r *http.Request) (string, bool) {
+	seasonYear := r.URL.Query().Get("season")
+	if seasonYear == "" {
+		seasonYear = currentSeasonYear(time.Now())
+	}
+	if _, err := h.lookupSeasonID(r.Context(), sportFrom(r), store.LeagueNBA, seasonYear); err != nil {
+		respondError(w, r, http.StatusNotFound, fmt.Sprintf("Invalid season: %s", seasonYear), err)
+		return "", false
+	}
+	return seasonYear, true
 }
 
-// respondError writes an error response
-func respondError(w http.ResponseWriter, status int, message string, err error) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	
-	response := map[string]interface{}{
-		"error":   message,
-		"status":  status,
+// GetAttendance handles GET /attendance?season=: league-wide monthly attendance
+// and capacity utilization with suspicious games flagged
+func (h *Handler) GetAttendance(w http.ResponseWriter, r *http.Request) {
+	h.respondAttendance(w, r, 0)
+}
+
+// GetTeamAttendance handles GET /teams/{teamID}/attendance?season=: the same
+// trend over the team's home games
+func (h *Handler) GetTeamAttendance(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(mux.Vars(r)["teamID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+	h.respondAttendance(w, r, teamID)
+}
+
+func (h *Handler) respondAttendance(w http.ResponseWriter, r *http.Request, teamID int) {
+	seasonYear := r.URL.Query().Get("season")
+	if seasonYear == "" {
+		seasonYear = currentSeasonYear(time.Now())
+	}
+	league, err := leagueFrom(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	seasonID, err := h.lookupSeasonID(r.Context(), sportFrom(r), league, seasonYear)
+	if err != nil {
+		respondError(w, r, http.StatusNotFound, fmt.Sprintf("Invalid season: %s", seasonYear), err)
+		return
+	}
+
+	trend, err := h.attendance.GetAttendanceTrend(r.Context(), seasonID, seasonYear, teamID)
+	if errors.Is(err, service.ErrTeamNotFound) {
+		respondError(w, r, http.StatusNotFound, "Team not found", err)
+		return
+	}
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch attendance", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, trend)
+}
+
+// currentSeasonYear names the season in progress (or next to start) on date,
+// e.g. "2025-26"; seasons roll over in October
+func currentSeasonYear(date time.Time) string {
+	year := date.Year()
+	if date.Month() < time.October {
+		year--
+	}
+	return fmt.Sprintf("%d-%02d", year, (year+1)%100)
+}
+
+// GetSeasonGames streams every game in a season (?format=ndjson for newline-delimited JSON)
+func (h *Handler) GetSeasonGames(w http.ResponseWriter, r *http.Request) {
+	seasonYear := mux.Vars(r)["season"]
+	league, err := leagueFrom(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	seasonID, err := h.lookupSeasonID(r.Context(), sportFrom(r), league, seasonYear)
+	if err != nil {
+		respondError(w, r, http.StatusNotFound, fmt.Sprintf("Invalid season: %s", seasonYear), err)
+		return
+	}
+
+	stream := newJSONStream(w, r)
+	gameRepo := repository.NewGameRepository(h.db)
+	err = gameRepo.StreamBySeason(r.Context(), seasonID, func(game *store.Game) error {
+		return stream.Write(game)
+	})
+	stream.Close(err, "Failed to fetch season games")
+}
+
+// ExportSeasonPlayerStats streams every player stat line from a season's final games
+func (h *Handler) ExportSeasonPlayerStats(w http.ResponseWriter, r *http.Request) {
+	seasonYear := mux.Vars(r)["season"]
+	league, err := leagueFrom(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	seasonID, err := h.lookupSeasonID(r.Context(), sportFrom(r), league, seasonYear)
+	if err != nil {
+		respondError(w, r, http.StatusNotFound, fmt.Sprintf("Invalid season: %s", seasonYear), err)
+		return
+	}
+
+	stream := newJSONStream(w, r)
+	statsRepo := repository.NewStatsRepository(h.db)
+	err = statsRepo.StreamSeasonPlayerStats(r.Context(), seasonID, func(stats *store.PlayerGameStats) error {
+		return stream.Write(stats)
+	})
+	stream.Close(err, "Failed to export season player stats")
+}
+
+// ExportMLFeatures streams the feature matrix of every player-game in one or
+// more seasons (?season=2023-24,2024-25) as CSV, or as JSON with ?format=json
+// or ndjson. ?as_of=YYYY-MM-DD leaves out games on or after that date.
+func (h *Handler) ExportMLFeatures(w http.ResponseWriter, r *http.Request) {
+	league, err := leagueFrom(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "", "csv", "json", "ndjson":
+	case "parquet":
+		respondError(w, r, http.StatusBadRequest, "Parquet export is not supported; use format=csv", nil)
+		return
+	default:
+		respondError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid format: %s", format), nil)
+		return
+	}
+
+	var asOf time.Time
+	if asOfStr := r.URL.Query().Get("as_of"); asOfStr != "" {
+		if asOf, err = time.Parse("2006-01-02", asOfStr); err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid as_of date (use YYYY-MM-DD)", err)
+			return
+		}
+	}
+
+	param := r.URL.Query().Get("season")
+	if param == "" {
+		respondError(w, r, http.StatusBadRequest, "season is required", nil)
+		return
+	}
+	var seasons []service.FeatureSeason
+	for _, year := range strings.Split(param, ",") {
+		year = strings.TrimSpace(year)
+		seasonID, err := h.lookupSeasonID(r.Context(), sportFrom(r), league, year)
+		if err != nil {
+			respondError(w, r, http.StatusNotFound, fmt.Sprintf("Invalid season: %s", year), err)
+			return
+		}
+		seasons = append(seasons, service.FeatureSeason{ID: seasonID, Year: year})
+	}
+
+	if format == "json" || format == "ndjson" {
+		stream := newJSONStream(w, r)
+		err = h.featureMatrix.Build(r.Context(), seasons, asOf, func(row *service.FeatureRow) error {
+			return stream.Write(row)
+		})
+		stream.Close(err, "Failed to export ML features")
+		return
+	}
+
+	years := make([]string, len(seasons))
+	for i, season := range seasons {
+		years[i] = season.Year
+	}
+	filename := fmt.Sprintf("ml-features-%s.csv", strings.Join(years, "_"))
+	stream := newCSVStream(w, r, filename, service.FeatureMatrixColumns)
+	err = h.featureMatrix.Build(r.Context(), seasons, asOf, func(row *service.FeatureRow) error {
+		return stream.Write(row.Record())
+	})
+	stream.Close(err, "Failed to export ML features")
+}
+
+// GetPlayerPerformanceTrend returns performance trends for a player
+func (h *Handler) GetPlayerPerformanceTrend(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	playerIDStr := vars["playerID"]
+
+	playerID, err := strconv.Atoi(playerIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
+		return
+	}
+
+	gamesStr := r.URL.Query().Get("games")
+	games := 10 // default
+	if gamesStr != "" {
+		if g, err := strconv.Atoi(gamesStr); err == nil && g > 0 && g <= 50 {
+			games = g
+		}
+	}
+
+	trend, err := h.analyticsService.GetPlayerPerformanceTrend(r.Context(), playerID, games)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to calculate performance trend", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, trend)
+}
+
+// GetPlayerMLFeatures returns ML features for a player
+func (h *Handler) GetPlayerMLFeatures(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	playerIDStr := vars["playerID"]
+
+	playerID, err := strconv.Atoi(playerIDStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
+		return
+	}
+
+	seasonID := r.URL.Query().Get("season")
+	if seasonID == "" {
+		seasonID = "2024-25" // default to current season
+	}
+
+	var asOf time.Time
+	if asOfStr := r.URL.Query().Get("as_of"); asOfStr != "" {
+		if asOf, err = time.Parse("2006-01-02", asOfStr); err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid as_of date (use YYYY-MM-DD)", err)
+			return
+		}
+	}
+
+	var opponentTeamID int
+	if opponentStr := r.URL.Query().Get("opponent"); opponentStr != "" {
+		if opponentTeamID, err = strconv.Atoi(opponentStr); err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid opponent team ID", err)
+			return
+		}
+	}
+
+	features, err := h.analyticsService.GetPlayerMLFeatures(r.Context(), sportFrom(r), playerID, seasonID, asOf, opponentTeamID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to generate ML features", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, features)
+}
+
+// GetTransactions lists roster transactions, newest first. ?team= (ID or
+// abbreviation) narrows to one team; ?since= (YYYY-MM-DD or RFC 3339) defaults to 30 days ago.
+func (h *Handler) GetTransactions(w http.ResponseWriter, r *http.Request) {
+	since := time.Now().AddDate(0, 0, -30)
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		parsed, err := time.Parse("2006-01-02", sinceStr)
+		if err != nil {
+			parsed, err = time.Parse(time.RFC3339, sinceStr)
+		}
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid since (use YYYY-MM-DD or an RFC 3339 timestamp)", err)
+			return
+		}
+		since = parsed
+	}
+
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 500 {
+			limit = l
+		}
+	}
+
+	transactions, err := h.transactions.ListTransactions(r.Context(), sportFrom(r), r.URL.Query().Get("team"), since, limit)
+	if errors.Is(err, service.ErrTeamNotFound) {
+		respondError(w, r, http.StatusNotFound, "Team not found", err)
+		return
+	}
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch transactions", err)
+		return
+	}
+
+	projected, ok := selectFields(w, r, transactions)
+	if !ok {
+		return
+	}
+	respondList(w, r, "", projected, len(transactions), limit)
+}
+
+// GetStatAggregate sums a player's or team's final games over a date window
+// (?entity=player|team&id=&from=YYYY-MM-DD&to=YYYY-MM-DD, both dates inclusive),
+// with per-game averages and, for ?per=per36|per100, normalized rates
+func (h *Handler) GetStatAggregate(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	entity := query.Get("entity")
+	if entity != service.AggregatePlayer && entity != service.AggregateTeam {
+		respondError(w, r, http.StatusBadRequest, service.ErrInvalidEntity.Error(), nil)
+		return
+	}
+
+	id, err := strconv.Atoi(query.Get("id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid id", err)
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", query.Get("from"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid from date format (use YYYY-MM-DD)", err)
+		return
+	}
+	to, err := time.Parse("2006-01-02", query.Get("to"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid to date format (use YYYY-MM-DD)", err)
+		return
+	}
+	if to.Before(from) {
+		respondError(w, r, http.StatusBadRequest, "to must not be before from", nil)
+		return
+	}
+
+	per, err := service.ParsePer(query.Get("per"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	includeSpecial := query.Get("include_special") == "true"
+
+	aggregate, err := h.statsService.GetStatAggregate(r.Context(), entity, id, from, to, includeSpecial, per)
+	if err != nil {
+		respondLookupError(w, r, strings.ToUpper(entity[:1])+entity[1:], err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, aggregate)
+}
+
+// GetPlayerNews returns the newest ESPN articles tagged with a player (?limit=, default 20)
+func (h *Handler) GetPlayerNews(w http.ResponseWriter, r *http.Request) {
+	playerID, err := strconv.Atoi(mux.Vars(r)["playerID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid player ID", err)
+		return
+	}
+
+	limit := newsLimit(r)
+	articles, err := h.news.PlayerNews(r.Context(), sportFrom(r), playerID, limit)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch player news", err)
+		return
+	}
+
+	projected, ok := selectFields(w, r, articles)
+	if !ok {
+		return
+	}
+	respondList(w, r, "", projected, len(articles), limit)
+}
+
+// GetTeamNews returns the newest ESPN articles tagged with a team (?limit=, default 20)
+func (h *Handler) GetTeamNews(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(mux.Vars(r)["teamID"])
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	limit := newsLimit(r)
+	articles, err := h.news.TeamNews(r.Context(), sportFrom(r), teamID, limit)
+	if errors.Is(err, service.ErrTeamNotFound) {
+		respondError(w, r, http.StatusNotFound, "Team not found", err)
+		return
+	}
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to fetch team news", err)
+		return
+	}
+
+	projected, ok := selectFields(w, r, articles)
+	if !ok {
+		return
+	}
+	respondList(w, r, "", projected, len(articles), limit)
+}
+
+func newsLimit(r *http.Request) int {
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+	return limit
+}
+
+// GetClosingLinePerformance grades final games against mapped closing lines.
+// ?team= (ID or abbreviation) narrows to one team and adds its game log; ?season= narrows to one season.
+func (h *Handler) GetClosingLinePerformance(w http.ResponseWriter, r *http.Request) {
+	team := r.URL.Query().Get("team")
+	season := r.URL.Query().Get("season")
+
+	perf, err := h.closingLines.GetPerformance(r.Context(), sportFrom(r), team, season)
+	if errors.Is(err, service.ErrTeamNotFound) {
+		respondError(w, r, http.StatusNotFound, "Team not found", err)
+		return
+	}
+	if errors.Is(err, service.ErrNoClosingLines) {
+		respondError(w, r, http.StatusNotFound, "No closing lines found", err)
+		return
 	}
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to evaluate closing line performance", err)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, perf)
+}
+
+// lookupSeasonID queries the database to get season_id (INT) from a sport's season_year (STRING) in a league
+func (h *Handler) lookupSeasonID(ctx context.Context, sport, league, seasonYear string) (int, error) {
+	ctx, cancel := h.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `SELECT season_id FROM seasons WHERE season_year = $1 AND sport = $2 AND league = $3 LIMIT 1`
 	
+	var seasonID int
+	err := h.db.ReadDB(ctx).QueryRowContext(ctx, query, seasonYear, sport, league).Scan(&seasonID)
 	if err != nil {
-		response["details"] = err.Error()
+		return 0, fmt.Errorf("season '%s' not found in database: %w", seasonYear, err)
 	}
 	
-	json.NewEncoder(w).Encode(response)
+	return seasonID, nil
+}
+
+// selectFields applies the ?fields= sparse fieldset to a payload. It writes a 400
+// and reports false when the selection is malformed.
+func selectFields(w http.ResponseWriter, r *http.Request, v interface{}) (interface{}, bool) {
+	fields, err := service.ParseFieldSet(r.URL.Query().Get("fields"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error(), nil)
+		return nil, false
+	}
+
+	projected, err := fields.Project(v)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to apply fields selection", err)
+		return nil, false
+	}
+	return projected, true
+}
+
+// parseLeagues reads the comma-separated ?league= filter (e.g. "summer_league,g_league").
+// A missing parameter returns nil, which matches NBA games only.
+func parseLeagues(r *http.Request) ([]string, error) {
+	raw := r.URL.Query().Get("league")
+	if raw == "" {
+		return nil, nil
+	}
+
+	var leagues []string
+	for _, l := range strings.Split(raw, ",") {
+		l = strings.TrimSpace(l)
+		if !store.ValidLeague(l) {
+			return nil, fmt.Errorf("unknown league %q", l)
+		}
+		leagues = append(leagues, l)
+	}
+	return leagues, nil
+}
+
+// leagueFrom reads a single ?league= value for season-scoped routes, defaulting to the NBA
+func leagueFrom(r *http.Request) (string, error) {
+	league := r.URL.Query().Get("league")
+	if league == "" {
+		return store.LeagueNBA, nil
+	}
+	if !store.ValidLeague(league) {
+		return "", fmt.Errorf("unknown league %q", league)
+	}
+	return league, nil
 }
 
+// parseGameTypes reads the comma-separated ?game_type= filter (e.g. "regular,playoffs").
+// A missing parameter returns nil, which matches every game type.
+func parseGameTypes(r *http.Request) ([]string, error) {
+	raw := r.URL.Query().Get("game_type")
+	if raw == "" {
+		return nil, nil
+	}
+
+	var gameTypes []string
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.TrimSpace(t)
+		if !store.ValidGameType(t) {
+			return nil, fmt.Errorf("unknown game_type %q", t)
+		}
+		gameTypes = append(gameTypes, t)
+	}
+	return gameTypes, nil
+}
@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/fortuna/minerva/internal/service"
+)
+
+type localeKey struct{}
+
+// localeSelection is the locale a request's JSON responses are translated into
+type localeSelection struct {
+	locale    string
+	localizer *service.Localizer
+}
+
+// Localization picks a locale for each request from ?locale= or, without one,
+// Accept-Language. JSON responses to requests matching a locale with stored
+// translations carry localized team and player names and a Content-Language header.
+type Localization struct {
+	localizer *service.Localizer
+}
+
+// NewLocalization creates the locale middleware over a shared localizer
+func NewLocalization(localizer *service.Localizer) *Localization {
+	return &Localization{localizer: localizer}
+}
+
+// Middleware stores the request's negotiated locale on its context
+func (l *Localization) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+
+		requested := r.URL.Query().Get("locale")
+		if requested == "" {
+			requested = r.Header.Get("Accept-Language")
+		}
+		if requested == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		locale, err := l.localizer.Negotiate(r.Context(), requested)
+		if err != nil {
+			log.Printf("Negotiating locale %q: %v", requested, err)
+		}
+		if locale != "" {
+			ctx := context.WithValue(r.Context(), localeKey{}, localeSelection{locale: locale, localizer: l.localizer})
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// localized returns payload with names translated into the request's locale,
+// setting Content-Language. Without a locale, or if translating fails, payload
+// is returned unchanged.
+func localized(w http.ResponseWriter, r *http.Request, payload interface{}) interface{} {
+	selection, ok := r.Context().Value(localeKey{}).(localeSelection)
+	if !ok {
+		return payload
+	}
+
+	translated, err := selection.localizer.Localize(r.Context(), selection.locale, payload)
+	if err != nil {
+		log.Printf("Localizing response into %s: %v", selection.locale, err)
+		return payload
+	}
+	w.Header().Set("Content-Language", selection.locale)
+	return translated
+}
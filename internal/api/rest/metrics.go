@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/fortuna/minerva/internal/metrics"
+)
+
+// routeUnmatched labels requests no route matched (404s and 405s)
+const routeUnmatched = "unmatched"
+
+// requestDuration is labeled by route name (see server.go), not URL, so one
+// series covers every game or player and per-endpoint SLOs stay cheap to query
+var requestDuration = metrics.NewHistogramVec("minerva_http_request_duration_seconds",
+	"REST request latency by route, method, and status class (2xx, 4xx, 5xx)",
+	[]float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	"route", "method", "status")
+
+// MetricsMiddleware records each request's latency and status class under the
+// name of the route that served it
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		lrw := &loggingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		next.ServeHTTP(lrw, r)
+
+		requestDuration.WithLabelValues(routeName(r), r.Method, statusClass(lrw.statusCode)).
+			Observe(time.Since(start).Seconds())
+	})
+}
+
+// routeName is the matched route's name, its path template when it has none,
+// or routeUnmatched
+func routeName(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return routeUnmatched
+	}
+	if name := route.GetName(); name != "" {
+		return name
+	}
+	if tmpl, err := route.GetPathTemplate(); err == nil {
+		return tmpl
+	}
+	return routeUnmatched
+}
+
+// statusClass buckets a status code as "2xx", "4xx", ...
+func statusClass(code int) string {
+	switch {
+	case code >= 500:
+		return "5xx"
+	case code >= 400:
+		return "4xx"
+	case code >= 300:
+		return "3xx"
+	case code >= 200:
+		return "2xx"
+	}
+	return "1xx"
+}
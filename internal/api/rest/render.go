@@ -0,0 +1,135 @@
+package rest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/fortuna/minerva/internal/service"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// boxScoreRenderer writes a box score in one output format
+type boxScoreRenderer func(w http.ResponseWriter, r *http.Request, box *service.BoxScore)
+
+// boxScoreRenderers are the formats of /games/{gameID}/boxscore.{format}. The
+// extensionless route renders json.
+var boxScoreRenderers = map[string]boxScoreRenderer{
+	"json": renderBoxScoreJSON,
+	"csv":  renderBoxScoreCSV,
+}
+
+func renderBoxScoreJSON(w http.ResponseWriter, r *http.Request, box *service.BoxScore) {
+	projected, ok := selectFields(w, r, box)
+	if !ok {
+		return
+	}
+	respondJSON(w, r, http.StatusOK, projected)
+}
+
+// boxScoreCSVHeader are the columns of the CSV box score
+var boxScoreCSVHeader = []string{
+	"Team", "Player", "Starter", "MIN", "PTS",
+	"FGM", "FGA", "FG%", "3PM", "3PA", "3P%", "FTM", "FTA", "FT%",
+	"OREB", "DREB", "REB", "AST", "STL", "BLK", "TOV", "PF", "+/-",
+}
+
+// renderBoxScoreCSV writes one row per player, away team first, each team's
+// starters ahead of its bench and followed by a totals row
+func renderBoxScoreCSV(w http.ResponseWriter, r *http.Request, box *service.BoxScore) {
+	if clientGone(r) {
+		w.WriteHeader(statusClientClosedRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="boxscore-%s.csv"`, box.Game.ExternalID))
+	w.WriteHeader(http.StatusOK)
+
+	out := csv.NewWriter(w)
+	out.Write(boxScoreCSVHeader)
+	writeTeamCSV(out, box.AwayTeam, box.AwayTeamStats)
+	writeTeamCSV(out, box.HomeTeam, box.HomeTeamStats)
+	out.Flush()
+}
+
+func writeTeamCSV(out *csv.Writer, team *store.Team, lines []*service.PlayerStatLine) {
+	abbr := ""
+	if team != nil {
+		abbr = team.Abbreviation
+	}
+
+	lines = append([]*service.PlayerStatLine(nil), lines...)
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Stats.Starter && !lines[j].Stats.Starter
+	})
+
+	totals := &store.PlayerGameStats{}
+	seconds := 0
+	for _, line := range lines {
+		st := line.Stats
+		out.Write(statRow(abbr, line.Player.FullName, yesNo(st.Starter), st.Minutes, st, plusMinus(st.PlusMinus)))
+
+		if st.SecondsPlayed.Valid {
+			seconds += int(st.SecondsPlayed.Int32)
+		} else {
+			seconds += int(math.Round(st.MinutesPlayed.Float64 * 60))
+		}
+		totals.Points += st.Points
+		totals.FieldGoalsMade += st.FieldGoalsMade
+		totals.FieldGoalsAttempted += st.FieldGoalsAttempted
+		totals.ThreePointersMade += st.ThreePointersMade
+		totals.ThreePointersAttempted += st.ThreePointersAttempted
+		totals.FreeThrowsMade += st.FreeThrowsMade
+		totals.FreeThrowsAttempted += st.FreeThrowsAttempted
+		totals.OffensiveRebounds += st.OffensiveRebounds
+		totals.DefensiveRebounds += st.DefensiveRebounds
+		totals.Rebounds += st.Rebounds
+		totals.Assists += st.Assists
+		totals.Steals += st.Steals
+		totals.Blocks += st.Blocks
+		totals.Turnovers += st.Turnovers
+		totals.PersonalFouls += st.PersonalFouls
+	}
+	out.Write(statRow(abbr, "Team Totals", "", store.FormatMinutes(seconds), totals, ""))
+}
+
+func statRow(team, player, starter, minutes string, st *store.PlayerGameStats, plusMinus string) []string {
+	return []string{
+		team, player, starter, minutes, strconv.Itoa(st.Points),
+		strconv.Itoa(st.FieldGoalsMade), strconv.Itoa(st.FieldGoalsAttempted), shootingPct(st.FieldGoalsMade, st.FieldGoalsAttempted),
+		strconv.Itoa(st.ThreePointersMade), strconv.Itoa(st.ThreePointersAttempted), shootingPct(st.ThreePointersMade, st.ThreePointersAttempted),
+		strconv.Itoa(st.FreeThrowsMade), strconv.Itoa(st.FreeThrowsAttempted), shootingPct(st.FreeThrowsMade, st.FreeThrowsAttempted),
+		strconv.Itoa(st.OffensiveRebounds), strconv.Itoa(st.DefensiveRebounds), strconv.Itoa(st.Rebounds),
+		strconv.Itoa(st.Assists), strconv.Itoa(st.Steals), strconv.Itoa(st.Blocks),
+		strconv.Itoa(st.Turnovers), strconv.Itoa(st.PersonalFouls), plusMinus,
+	}
+}
+
+// shootingPct formats made/attempted as a percentage with one decimal, blank
+// without attempts
+func shootingPct(made, attempted int) string {
+	if attempted == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(float64(made)/float64(attempted)*100, 'f', 1, 64)
+}
+
+func plusMinus(v store.NullInt32) string {
+	if !v.Valid {
+		return ""
+	}
+	if v.Int32 > 0 {
+		return "+" + strconv.Itoa(int(v.Int32))
+	}
+	return strconv.Itoa(int(v.Int32))
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Y"
+	}
+	return "N"
+}
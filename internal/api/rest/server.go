@@ -5,73 +5,222 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/fortuna/minerva/internal/api/cors"
 	"github.com/fortuna/minerva/internal/backfill"
+	"github.com/fortuna/minerva/internal/metrics"
+	"github.com/fortuna/minerva/internal/scheduler"
+	"github.com/fortuna/minerva/internal/service"
 	"github.com/fortuna/minerva/internal/store"
 	"github.com/gorilla/mux"
 )
 
 // Server represents the REST API server
 type Server struct {
-	port    string
-	server  *http.Server
-	handler *Handler
+	port        string
+	server      *http.Server
+	handler     *Handler
+	admin       *AdminHandler
+	format      *ResponseFormat
+	compression *Compression
+	adminAuth   *AdminAuth
+	router      *mux.Router
 }
 
 // NewServer creates a new REST API server
-func NewServer(port string, db *store.Database, backfillSvc *backfill.Service) *Server {
+func NewServer(port string, db *store.Database, backfillSvc *backfill.Service, sched *scheduler.Orchestrator) *Server {
 	handler := NewHandler(db)
 	backfillHandler := NewBackfillHandler(backfillSvc)
+	localizer := service.NewLocalizer(db)
+	adminHandler := NewAdminHandler(db, sched, backfillSvc, localizer)
+	format := &ResponseFormat{}
+	localization := NewLocalization(localizer)
+	compression := NewCompression()
+	adminAuth := NewAdminAuth()
 
 	router := mux.NewRouter()
+	router.NotFoundHandler = MetricsMiddleware(http.NotFoundHandler())
+	router.MethodNotAllowedHandler = MetricsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
 
-	// Apply middleware
+	// Apply middleware. Metrics wraps everything so latency includes compression
+	// and recovered panics count as 5xx. Compression is next so error pages
+	// written by the recovery middleware are encoded consistently with the rest
+	// of the body. Every route is named; the name is its metrics label.
+	router.Use(MetricsMiddleware)
+	router.Use(compression.Middleware)
 	router.Use(RecoveryMiddleware)
 	router.Use(LoggingMiddleware)
-	router.Use(CORSMiddleware)
 
 	// Health check
-	router.HandleFunc("/health", handler.HealthCheck).Methods("GET")
+	router.HandleFunc("/health", handler.HealthCheck).Methods("GET").Name("health")
+	router.HandleFunc("/health/ready", handler.ReadinessCheck).Methods("GET").Name("health.ready")
 
-	// API v1 routes
+	// Prometheus metrics
+	router.Handle("/metrics", metrics.Handler()).Methods("GET").Name("metrics")
+
+	// API v1 routes. Sport-scoped routes are served both under /api/v1/{sport}/...
+	// and, for existing clients, un-namespaced as basketball_nba.
 	api := router.PathPrefix("/api/v1").Subrouter()
+	api.Use(format.Middleware)
+	api.Use(localization.Middleware)
+	registerSportRoutes(api, handler)
 
-	// Games
-	api.HandleFunc("/games/live", handler.GetLiveGames).Methods("GET")
-	api.HandleFunc("/games/today", handler.GetTodaysGames).Methods("GET")
-	api.HandleFunc("/games/upcoming", handler.GetUpcomingGames).Methods("GET")
-	api.HandleFunc("/games/cleanup", handler.CleanupStaleGames).Methods("POST")
-	api.HandleFunc("/games", handler.GetGamesByDate).Methods("GET")
-	api.HandleFunc("/games/{gameID}", handler.GetGame).Methods("GET")
-	api.HandleFunc("/games/{gameID}/boxscore", handler.GetGameBoxScore).Methods("GET")
+	sportAPI := api.PathPrefix("/{sport:[a-z]+_[a-z]+}").Subrouter()
+	sportAPI.Use(SportMiddleware)
+	registerSportRoutes(sportAPI, handler)
 
-	// Players
-	api.HandleFunc("/players/search", handler.SearchPlayers).Methods("GET")
-	api.HandleFunc("/players/{playerID}", handler.GetPlayer).Methods("GET")
-	api.HandleFunc("/players/{playerID}/stats", handler.GetPlayerStats).Methods("GET")
-	api.HandleFunc("/players/{playerID}/averages", handler.GetPlayerSeasonAverages).Methods("GET")
-	api.HandleFunc("/players/{playerID}/trend", handler.GetPlayerPerformanceTrend).Methods("GET")
-	api.HandleFunc("/players/{playerID}/ml-features", handler.GetPlayerMLFeatures).Methods("GET")
+	// Backfill operations
+	api.HandleFunc("/backfill", backfillHandler.HandleBackfillRequest).Methods("POST").Name("backfill.create")
+	api.HandleFunc("/backfill/status", backfillHandler.HandleBackfillStatus).Methods("GET").Name("backfill.status")
 
-	// Teams
-	api.HandleFunc("/teams", handler.GetTeams).Methods("GET")
-	api.HandleFunc("/teams/{teamID}", handler.GetTeam).Methods("GET")
-	api.HandleFunc("/teams/{teamID}/roster", handler.GetTeamRoster).Methods("GET")
-	api.HandleFunc("/teams/{teamID}/schedule", handler.GetTeamSchedule).Methods("GET")
+	// Admin, behind bearer token authentication
+	adminAPI := api.PathPrefix("/admin").Subrouter()
+	adminAPI.Use(adminAuth.Middleware)
+	adminAPI.HandleFunc("/overview", adminHandler.Overview).Methods("GET").Name("admin.overview")
+	adminAPI.HandleFunc("/ingestion-runs", adminHandler.ListIngestionRuns).Methods("GET").Name("admin.ingestion_runs")
+	adminAPI.HandleFunc("/jobs", adminHandler.ListJobs).Methods("GET").Name("admin.jobs")
+	adminAPI.HandleFunc("/data-quality", adminHandler.ListDataQualityIssues).Methods("GET").Name("admin.data_quality")
+	adminAPI.HandleFunc("/enrichment/status", adminHandler.EnrichmentStatus).Methods("GET").Name("admin.enrichment.status")
+	adminAPI.HandleFunc("/player-stats/{statID}", adminHandler.CorrectPlayerStats).Methods("PATCH").Name("admin.player_stats.correct")
+	adminAPI.HandleFunc("/player-stats/{statID}/corrections", adminHandler.ListStatCorrections).Methods("GET").Name("admin.player_stats.corrections")
+	adminAPI.HandleFunc("/stat-corrections", adminHandler.ListStatCorrections).Methods("GET").Name("admin.stat_corrections")
+	adminAPI.HandleFunc("/players/{playerID}/aliases", adminHandler.ListPlayerAliases).Methods("GET").Name("admin.player_aliases.list")
+	adminAPI.HandleFunc("/players/{playerID}/aliases", adminHandler.AddPlayerAlias).Methods("POST").Name("admin.player_aliases.add")
+	adminAPI.HandleFunc("/players/{playerID}/aliases/{aliasID}", adminHandler.DeletePlayerAlias).Methods("DELETE").Name("admin.player_aliases.delete")
+	adminAPI.HandleFunc("/translations", adminHandler.ListTranslations).Methods("GET").Name("admin.translations.list")
+	adminAPI.HandleFunc("/translations/{entityType}/{entityID}/{locale}", adminHandler.PutTranslation).Methods("PUT").Name("admin.translations.put")
+	adminAPI.HandleFunc("/translations/{entityType}/{entityID}/{locale}", adminHandler.DeleteTranslation).Methods("DELETE").Name("admin.translations.delete")
 
-	// Backfill operations
-	api.HandleFunc("/backfill", backfillHandler.HandleBackfillRequest).Methods("POST")
-	api.HandleFunc("/backfill/status", backfillHandler.HandleBackfillStatus).Methods("GET")
+	// The default policy doesn't allow credentials, so it always compiles
+	defaultCORS, _ := cors.NewRoutes(cors.DefaultConfig())
 
 	return &Server{
-		port:    port,
-		handler: handler,
+		port:        port,
+		handler:     handler,
+		admin:       adminHandler,
+		format:      format,
+		compression: compression,
+		adminAuth:   adminAuth,
+		router:      router,
 		server: &http.Server{
 			Addr:    fmt.Sprintf(":%s", port),
-			Handler: router,
+			Handler: defaultCORS.Handler(router),
 		},
 	}
 }
 
+// SetLegacyResponses makes /api/v1 answer with the pre-envelope payloads unless a
+// request opts in with ?envelope=true or X-Response-Format: envelope
+func (s *Server) SetLegacyResponses(legacy bool) {
+	s.format.SetLegacyDefault(legacy)
+}
+
+// SetCompression configures gzip/brotli response compression (on by default)
+func (s *Server) SetCompression(config CompressionConfig) {
+	s.compression.Configure(config)
+}
+
+// SetOverviewSources supplies the Redis, WebSocket, and leader election
+// components reported by /api/v1/admin/overview. Call it before Start.
+func (s *Server) SetOverviewSources(sources OverviewSources) {
+	s.admin.sources = sources
+}
+
+// SetAdminTokens sets the bearer tokens accepted on /api/v1/admin, keyed by
+// the principal each identifies. Without any, admin requests are refused.
+func (s *Server) SetAdminTokens(tokens map[string]string) {
+	s.adminAuth.SetTokens(tokens)
+}
+
+// SetCORS replaces the cross-origin policies (every origin allowed by default).
+// CORS wraps the router rather than running as router middleware, so preflight
+// OPTIONS requests are answered for routes registered only for other methods.
+// Call it before Start.
+func (s *Server) SetCORS(routes *cors.Routes) {
+	s.server.Handler = routes.Handler(s.router)
+}
+
+// registerSportRoutes adds the routes whose data is partitioned by sport
+func registerSportRoutes(r *mux.Router, handler *Handler) {
+	// Games
+	r.HandleFunc("/games/live", handler.GetLiveGames).Methods("GET").Name("games.live")
+	r.HandleFunc("/games/today", handler.GetTodaysGames).Methods("GET").Name("games.today")
+	r.HandleFunc("/games/upcoming", handler.GetUpcomingGames).Methods("GET").Name("games.upcoming")
+	r.HandleFunc("/games/cleanup", handler.CleanupStaleGames).Methods("POST").Name("games.cleanup")
+	r.HandleFunc("/games", handler.GetGamesByDate).Methods("GET").Name("games.by_date")
+	r.HandleFunc("/games/{gameID}", handler.GetGame).Methods("GET").Name("games.get")
+	r.HandleFunc("/games/{gameID}/boxscore", handler.GetGameBoxScore).Methods("GET").Name("games.boxscore")
+	r.HandleFunc("/games/{gameID}/boxscore.{format}", handler.GetGameBoxScore).Methods("GET").Name("games.boxscore_export")
+	r.HandleFunc("/games/{gameID}/recap", handler.GetGameRecap).Methods("GET").Name("games.recap")
+	r.HandleFunc("/games/{gameID}/live-derived", handler.GetGameLiveDerived).Methods("GET").Name("games.live_derived")
+	r.HandleFunc("/games/{gameID}/highlights", handler.GetGameHighlights).Methods("GET").Name("games.highlights")
+	r.HandleFunc("/games/{gameID}/changes", handler.GetGameChanges).Methods("GET").Name("games.changes")
+	r.HandleFunc("/resolve/game", handler.ResolveGame).Methods("GET").Name("resolve.game")
+	r.HandleFunc("/recap", handler.GetDailyRecap).Methods("GET").Name("recap.daily")
+
+	// Players
+	r.HandleFunc("/players/search", handler.SearchPlayers).Methods("GET").Name("players.search")
+	r.HandleFunc("/players/{playerID}", handler.GetPlayer).Methods("GET").Name("players.get")
+	r.HandleFunc("/players/{playerID}/stats", handler.GetPlayerStats).Methods("GET").Name("players.stats")
+	r.HandleFunc("/players/{playerID}/averages", handler.GetPlayerSeasonAverages).Methods("GET").Name("players.averages")
+	r.HandleFunc("/players/{playerID}/career", handler.GetPlayerCareer).Methods("GET").Name("players.career")
+	r.HandleFunc("/players/{playerID}/workload", handler.GetPlayerWorkload).Methods("GET").Name("players.workload")
+	r.HandleFunc("/players/{playerID}/clutch", handler.GetPlayerClutch).Methods("GET").Name("players.clutch")
+	r.HandleFunc("/players/{playerID}/trend", handler.GetPlayerPerformanceTrend).Methods("GET").Name("players.trend")
+	r.HandleFunc("/players/{playerID}/ml-features", handler.GetPlayerMLFeatures).Methods("GET").Name("players.ml_features")
+	r.HandleFunc("/players/{playerID}/news", handler.GetPlayerNews).Methods("GET").Name("players.news")
+
+	// Draft
+	r.HandleFunc("/draft/{year}", handler.GetDraftClass).Methods("GET").Name("draft.class")
+
+	// Teams
+	r.HandleFunc("/teams", handler.GetTeams).Methods("GET").Name("teams.list")
+	r.HandleFunc("/teams/search", handler.SearchTeams).Methods("GET").Name("teams.search")
+	r.HandleFunc("/teams/{teamID}", handler.GetTeam).Methods("GET").Name("teams.get")
+	r.HandleFunc("/teams/{teamID}/roster", handler.GetTeamRoster).Methods("GET").Name("teams.roster")
+	r.HandleFunc("/teams/{teamID}/coaches", handler.GetTeamCoaches).Methods("GET").Name("teams.coaches")
+	r.HandleFunc("/teams/{teamID}/schedule", handler.GetTeamSchedule).Methods("GET").Name("teams.schedule")
+	r.HandleFunc("/teams/{teamID}/schedule-analysis", handler.GetTeamScheduleAnalysis).Methods("GET").Name("teams.schedule_analysis")
+	r.HandleFunc("/teams/{teamID}/attendance", handler.GetTeamAttendance).Methods("GET").Name("teams.attendance")
+	r.HandleFunc("/teams/{teamID}/game-logs", handler.GetTeamGameLogs).Methods("GET").Name("teams.game_logs")
+	r.HandleFunc("/teams/{teamID}/bench-production", handler.GetTeamBenchProduction).Methods("GET").Name("teams.bench_production")
+	r.HandleFunc("/teams/{teamID}/clutch", handler.GetTeamClutch).Methods("GET").Name("teams.clutch")
+	r.HandleFunc("/teams/{teamID}/positional-defense", handler.GetTeamPositionalDefense).Methods("GET").Name("teams.positional_defense")
+	r.HandleFunc("/teams/{teamID}/rating-history", handler.GetTeamRatingHistory).Methods("GET").Name("teams.rating_history")
+	r.HandleFunc("/teams/{teamID}/news", handler.GetTeamNews).Methods("GET").Name("teams.news")
+
+	// Date-range aggregates
+	r.HandleFunc("/stats/aggregate", handler.GetStatAggregate).Methods("GET").Name("stats.aggregate")
+
+	// Attendance
+	r.HandleFunc("/attendance", handler.GetAttendance).Methods("GET").Name("attendance")
+
+	// Roster transactions
+	r.HandleFunc("/transactions", handler.GetTransactions).Methods("GET").Name("transactions")
+
+	// Season-wide exports (streamed)
+	r.HandleFunc("/seasons/{season}/games", handler.GetSeasonGames).Methods("GET").Name("seasons.games")
+	r.HandleFunc("/seasons/{season}/player-stats", handler.ExportSeasonPlayerStats).Methods("GET").Name("seasons.player_stats")
+	r.HandleFunc("/export/ml-features", handler.ExportMLFeatures).Methods("GET").Name("export.ml_features")
+
+	// Playoffs
+	r.HandleFunc("/playoffs/bracket", handler.GetPlayoffBracket).Methods("GET").Name("playoffs.bracket")
+
+	// NBA Cup
+	r.HandleFunc("/tournament/standings", handler.GetTournamentStandings).Methods("GET").Name("tournament.standings")
+
+	// Analytics
+	r.HandleFunc("/analytics/closing-line-performance", handler.GetClosingLinePerformance).Methods("GET").Name("analytics.closing_line_performance")
+	r.HandleFunc("/analytics/positional-defense", handler.GetPositionalDefense).Methods("GET").Name("analytics.positional_defense")
+}
+
+// Handler returns the fully wrapped router (CORS included), for serving the API
+// from an httptest server or another listener
+func (s *Server) Handler() http.Handler {
+	return s.server.Handler
+}
+
 // Start starts the REST API server
 func (s *Server) Start() error {
 	return s.server.ListenAndServe()
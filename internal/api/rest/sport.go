@@ -0,0 +1,35 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/gorilla/mux"
+)
+
+type sportKey struct{}
+
+// SportMiddleware validates the {sport} path segment of namespaced routes
+// (/api/v1/{sport}/...) and stores it on the request context
+func SportMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sport := mux.Vars(r)["sport"]
+		if !store.ValidSport(sport) {
+			respondError(w, r, http.StatusNotFound, fmt.Sprintf("Unknown sport: %s", sport), nil)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), sportKey{}, sport)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// sportFrom returns the request's sport; un-namespaced routes default to store.DefaultSport
+func sportFrom(r *http.Request) string {
+	if sport, ok := r.Context().Value(sportKey{}).(string); ok {
+		return sport
+	}
+	return store.DefaultSport
+}
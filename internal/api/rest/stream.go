@@ -0,0 +1,212 @@
+package rest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// streamFlushEvery bounds how many items are buffered before pushing a chunk to the client
+const streamFlushEvery = 100
+
+// jsonStream writes a large result set item by item instead of marshaling it at once.
+// It emits newline-delimited JSON when the client asks for it (?format=ndjson or
+// Accept: application/x-ndjson) and a chunked JSON array otherwise, wrapped as
+// {"data": [...], "meta": {"count": n}} for enveloped requests.
+//
+// Each Write blocks until the connection accepts the bytes, so a repository that
+// calls Write from its row loop only reads rows as fast as the client consumes them.
+// Write fails as soon as the client disconnects, ending the row loop and freeing
+// its connection rather than draining the query into a dead socket.
+type jsonStream struct {
+	w        http.ResponseWriter
+	r        *http.Request
+	rc       *http.ResponseController
+	enc      *json.Encoder
+	ndjson   bool
+	envelope bool
+	started  bool
+	count    int
+}
+
+func newJSONStream(w http.ResponseWriter, r *http.Request) *jsonStream {
+	ndjson := r.URL.Query().Get("format") == "ndjson" ||
+		strings.Contains(r.Header.Get("Accept"), "application/x-ndjson")
+
+	return &jsonStream{
+		w:        w,
+		r:        r,
+		rc:       http.NewResponseController(w),
+		enc:      json.NewEncoder(w),
+		ndjson:   ndjson,
+		envelope: !ndjson && enveloped(r),
+	}
+}
+
+// Write encodes one item, sending headers before the first
+func (s *jsonStream) Write(v interface{}) error {
+	if err := s.r.Context().Err(); err != nil {
+		return err
+	}
+	if !s.started {
+		s.start()
+	}
+
+	if !s.ndjson {
+		sep := ","
+		if s.count == 0 {
+			sep = s.opener()
+		}
+		if _, err := s.w.Write([]byte(sep)); err != nil {
+			return err
+		}
+	}
+	if err := s.enc.Encode(v); err != nil {
+		return err
+	}
+
+	s.count++
+	if s.count%streamFlushEvery == 0 {
+		return s.rc.Flush()
+	}
+	return nil
+}
+
+// Close finishes the response. An error before anything was sent becomes a normal
+// error response; an error mid-stream aborts the connection so the client sees a
+// truncated body rather than a well-formed but incomplete result. Nothing more is
+// written once the client has disconnected.
+func (s *jsonStream) Close(err error, message string) {
+	if clientGone(s.r) {
+		if s.started {
+			log.Printf("[rest] %s %s: client disconnected after %d items", s.r.Method, s.r.URL.Path, s.count)
+		} else {
+			s.w.WriteHeader(statusClientClosedRequest)
+		}
+		return
+	}
+	if err != nil {
+		if !s.started {
+			respondError(s.w, s.r, http.StatusInternalServerError, message, err)
+			return
+		}
+		log.Printf("[rest] %s %s: stream aborted after %d items: %v", s.r.Method, s.r.URL.Path, s.count, err)
+		panic(http.ErrAbortHandler)
+	}
+
+	if !s.started {
+		s.start()
+	}
+	if !s.ndjson {
+		closer := "]"
+		if s.count == 0 {
+			closer = s.opener() + "]"
+		}
+		if s.envelope {
+			closer += fmt.Sprintf(`,"meta":{"count":%d}}`, s.count)
+		}
+		s.w.Write([]byte(closer))
+	}
+	s.rc.Flush()
+}
+
+func (s *jsonStream) opener() string {
+	if s.envelope {
+		return `{"data":[`
+	}
+	return "["
+}
+
+func (s *jsonStream) start() {
+	if s.ndjson {
+		s.w.Header().Set("Content-Type", "application/x-ndjson")
+	} else {
+		s.w.Header().Set("Content-Type", "application/json")
+	}
+	s.w.WriteHeader(http.StatusOK)
+	s.started = true
+}
+
+// csvStream is jsonStream for CSV: the header row and response headers go out
+// with the first record, and errors are handled as in jsonStream.Close
+type csvStream struct {
+	w        http.ResponseWriter
+	r        *http.Request
+	rc       *http.ResponseController
+	out      *csv.Writer
+	filename string
+	header   []string
+	started  bool
+	count    int
+}
+
+func newCSVStream(w http.ResponseWriter, r *http.Request, filename string, header []string) *csvStream {
+	return &csvStream{
+		w:        w,
+		r:        r,
+		rc:       http.NewResponseController(w),
+		out:      csv.NewWriter(w),
+		filename: filename,
+		header:   header,
+	}
+}
+
+// Write writes one record, sending headers before the first
+func (s *csvStream) Write(record []string) error {
+	if err := s.r.Context().Err(); err != nil {
+		return err
+	}
+	if !s.started {
+		s.start()
+	}
+	if err := s.out.Write(record); err != nil {
+		return err
+	}
+
+	s.count++
+	if s.count%streamFlushEvery == 0 {
+		s.out.Flush()
+		if err := s.out.Error(); err != nil {
+			return err
+		}
+		return s.rc.Flush()
+	}
+	return nil
+}
+
+// Close finishes the response like jsonStream.Close. An empty result is just the header row.
+func (s *csvStream) Close(err error, message string) {
+	if clientGone(s.r) {
+		if s.started {
+			log.Printf("[rest] %s %s: client disconnected after %d rows", s.r.Method, s.r.URL.Path, s.count)
+		} else {
+			s.w.WriteHeader(statusClientClosedRequest)
+		}
+		return
+	}
+	if err != nil {
+		if !s.started {
+			respondError(s.w, s.r, http.StatusInternalServerError, message, err)
+			return
+		}
+		log.Printf("[rest] %s %s: stream aborted after %d rows: %v", s.r.Method, s.r.URL.Path, s.count, err)
+		panic(http.ErrAbortHandler)
+	}
+
+	if !s.started {
+		s.start()
+	}
+	s.out.Flush()
+	s.rc.Flush()
+}
+
+func (s *csvStream) start() {
+	s.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	s.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, s.filename))
+	s.w.WriteHeader(http.StatusOK)
+	s.out.Write(s.header)
+	s.started = true
+}
@@ -1,22 +1,15 @@
 package websocket
 
 import (
+	"errors"
 	"log"
+	"net"
 	"time"
 
 	"github.com/gorilla/websocket"
 )
 
 const (
-	// Time allowed to write a message to the peer
-	writeWait = 10 * time.Second
-
-	// Time allowed to read the next pong message from the peer
-	pongWait = 60 * time.Second
-
-	// Send pings to peer with this period (must be less than pongWait)
-	pingPeriod = (pongWait * 9) / 10
-
 	// Maximum message size allowed from peer
 	maxMessageSize = 512
 )
@@ -30,15 +23,39 @@ type Client struct {
 
 	// Buffered channel of outbound messages
 	send chan []byte
+
+	// Topic the client is subscribed to (TopicLeague or a TeamTopic)
+	topic string
+
+	// Why readPump gave up on the connection; read by the hub on unregister
+	closeReason string
+
+	// Why the hub dropped the client; set before send is closed, so writePump
+	// reads it once the channel is drained
+	dropReason string
+
+	// Closed when writePump has sent the close frame and closed the connection
+	done chan struct{}
 }
 
-// readPump pumps messages from the websocket connection to the hub
+// channel labels the client's topic for metrics without per-team cardinality
+func (c *Client) channel() string {
+	if c.topic == TopicLeague {
+		return "league"
+	}
+	return "team"
+}
+
+// readPump pumps messages from the websocket connection to the hub.
+// Every pong extends the read deadline; a client that stops answering pings
+// for PongWait is disconnected.
 func (c *Client) readPump() {
 	defer func() {
 		c.hub.unregister <- c
 		c.conn.Close()
 	}()
 
+	pongWait := c.hub.config.PongWait
 	c.conn.SetReadLimit(maxMessageSize)
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error {
@@ -49,7 +66,12 @@ func (c *Client) readPump() {
 	for {
 		_, _, err := c.conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+			var netErr net.Error
+			switch {
+			case errors.As(err, &netErr) && netErr.Timeout():
+				c.closeReason = disconnectPongTimeout
+			case websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure, websocket.CloseNormalClosure):
+				c.closeReason = disconnectError
 				log.Printf("WebSocket error: %v", err)
 			}
 			break
@@ -61,10 +83,12 @@ func (c *Client) readPump() {
 
 // writePump pumps messages from the hub to the websocket connection
 func (c *Client) writePump() {
-	ticker := time.NewTicker(pingPeriod)
+	writeWait := c.hub.config.WriteWait
+	ticker := time.NewTicker(c.hub.config.pingPeriod())
 	defer func() {
 		ticker.Stop()
 		c.conn.Close()
+		close(c.done)
 	}()
 
 	for {
@@ -73,7 +97,7 @@ func (c *Client) writePump() {
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
 				// The hub closed the channel
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				c.conn.WriteMessage(websocket.CloseMessage, closeMessage(c.dropReason))
 				return
 			}
 
@@ -103,4 +127,14 @@ func (c *Client) writePump() {
 	}
 }
 
-
+// closeMessage is the close frame sent when the hub drops a client for reason
+func closeMessage(reason string) []byte {
+	switch reason {
+	case disconnectSlowConsumer:
+		return websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "send buffer full")
+	case disconnectShutdown:
+		return websocket.FormatCloseMessage(websocket.CloseServiceRestart, "server shutting down")
+	default:
+		return []byte{}
+	}
+}
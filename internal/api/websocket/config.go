@@ -0,0 +1,51 @@
+package websocket
+
+import (
+	"time"
+
+	"github.com/fortuna/minerva/internal/api/cors"
+)
+
+// Config bounds connection count, per-client buffering, and keepalive timing.
+// CORS is the same origin policy the REST API uses; its allowlist decides
+// which browser origins may upgrade.
+type Config struct {
+	MaxConnections int           // 0 disables the limit
+	SendBufferSize int           // queued messages per client before it is evicted as a slow consumer
+	PongWait       time.Duration // disconnect clients that haven't answered a ping within this window
+	WriteWait      time.Duration // time allowed to write a message to the peer
+	ClockTick      time.Duration // how often running game clocks are re-sent between polls; 0 disables
+	CORS           cors.Config
+}
+
+// DefaultConfig returns the standard WebSocket limits
+func DefaultConfig() Config {
+	return Config{
+		MaxConnections: 1000,
+		SendBufferSize: 256,
+		PongWait:       60 * time.Second,
+		WriteWait:      10 * time.Second,
+		ClockTick:      time.Second,
+		CORS:           cors.DefaultConfig(),
+	}
+}
+
+// withDefaults fills zero fields from DefaultConfig
+func (c Config) withDefaults() Config {
+	defaults := DefaultConfig()
+	if c.SendBufferSize <= 0 {
+		c.SendBufferSize = defaults.SendBufferSize
+	}
+	if c.PongWait <= 0 {
+		c.PongWait = defaults.PongWait
+	}
+	if c.WriteWait <= 0 {
+		c.WriteWait = defaults.WriteWait
+	}
+	return c
+}
+
+// pingPeriod sends pings often enough to beat the pong deadline
+func (c Config) pingPeriod() time.Duration {
+	return (c.PongWait * 9) / 10
+}
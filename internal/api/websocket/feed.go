@@ -0,0 +1,349 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/redis/go-redis/v9"
+)
+
+// Streams the feed subscribes to (written by the live ingester and scheduler)
+const (
+	liveStream  = "games.live.basketball_nba"
+	statsStream = "games.stats.basketball_nba"
+)
+
+// Team event types pushed on team channels
+const (
+	EventTipOff     = "tip_off"
+	EventQuarterEnd = "quarter_end"
+	EventLeadChange = "lead_change"
+	EventFinal      = "final"
+)
+
+// TeamEvent is a score alert delivered on /ws/teams/{teamID}/live
+type TeamEvent struct {
+	Type          string    `json:"type"`
+	GameID        int       `json:"game_id"`
+	TeamID        int       `json:"team_id"`
+	OpponentID    int       `json:"opponent_id"`
+	IsHome        bool      `json:"is_home"`
+	TeamScore     int       `json:"team_score"`
+	OpponentScore int       `json:"opponent_score"`
+	Period        int       `json:"period"`
+	Clock         string    `json:"clock,omitempty"`
+	Status        string    `json:"status"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// gameState is the last observed state of a game, used to derive transitions
+type gameState struct {
+	status string
+	period int
+	leader int // team_id of the last team to hold the lead; ties keep the previous leader
+}
+
+// liveClock is a game whose clock was running at its last live update. The
+// update is re-sent with a fresh estimated_clock between polls.
+type liveClock struct {
+	payload map[string]json.RawMessage
+	clock   publisher.ClockState
+	sent    int // estimated seconds in the last broadcast
+}
+
+// liveClockFields are the live payload fields clock extrapolation reads
+type liveClockFields struct {
+	GameID     int        `json:"game_id"`
+	Period     int        `json:"period"`
+	Clock      string     `json:"clock"`
+	Running    bool       `json:"clock_running"`
+	ObservedAt *time.Time `json:"clock_observed_at"`
+}
+
+// Feed subscribes to the game streams, forwards updates to the league feed, and
+// derives per-team events from successive game states.
+type Feed struct {
+	client *redis.Client
+	hub    *Hub
+
+	mu     sync.Mutex
+	games  map[int]*gameState
+	clocks map[int]*liveClock
+}
+
+// NewFeed creates a feed reading from Redis and publishing into hub
+func NewFeed(client *redis.Client, hub *Hub) *Feed {
+	return &Feed{
+		client: client,
+		hub:    hub,
+		games:  make(map[int]*gameState),
+		clocks: make(map[int]*liveClock),
+	}
+}
+
+// Run reads new stream entries until ctx is cancelled. Only entries written
+// after Run starts are delivered.
+func (f *Feed) Run(ctx context.Context) {
+	start := fmt.Sprintf("%d-0", time.Now().UnixMilli())
+	lastIDs := map[string]string{liveStream: start, statsStream: start}
+
+	if tick := f.hub.config.ClockTick; tick > 0 {
+		go f.tickClocks(ctx, tick)
+	}
+
+	for {
+		streams, err := f.client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{liveStream, statsStream, lastIDs[liveStream], lastIDs[statsStream]},
+			Block:   5 * time.Second,
+			Count:   100,
+		}).Result()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			log.Printf("[ws] ⚠️  Stream read failed: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(2 * time.Second):
+			}
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				lastIDs[stream.Stream] = msg.ID
+				f.handle(stream.Stream, msg)
+			}
+		}
+	}
+}
+
+// handle forwards one stream entry and publishes any team events it implies
+func (f *Feed) handle(stream string, msg redis.XMessage) {
+	data, ok := msg.Values["data"].(string)
+	if !ok {
+		return
+	}
+
+	if stream == liveStream {
+		now := time.Now()
+		f.hub.Broadcast(f.estimateClock(data, now))
+		observeLatency(data, msg.ID, now)
+	}
+
+	var game store.Game
+	if err := json.Unmarshal([]byte(data), &game); err != nil || game.GameID == 0 {
+		return
+	}
+	if game.Status == "final" {
+		f.mu.Lock()
+		delete(f.clocks, game.GameID)
+		f.mu.Unlock()
+	}
+
+	for _, event := range f.observe(&game) {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+		f.hub.Publish(TeamTopic(event.TeamID), payload)
+	}
+}
+
+// observeLatency completes the latency trace of a stamped live update as it
+// is broadcast
+func observeLatency(data, entryID string, now time.Time) {
+	var fields struct {
+		Latency *publisher.LatencyStamps `json:"latency"`
+	}
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		return
+	}
+	publisher.ObserveDelivered(fields.Latency, publisher.StreamEntryTime(entryID), now)
+}
+
+// estimateClock sets estimated_clock on a live update as of now and remembers
+// games whose clock is running for tickClocks. Updates without clock state
+// (older publishers) are forwarded unchanged.
+func (f *Feed) estimateClock(data string, now time.Time) []byte {
+	var fields liveClockFields
+	if err := json.Unmarshal([]byte(data), &fields); err != nil || fields.GameID == 0 || fields.ObservedAt == nil {
+		return []byte(data)
+	}
+	seconds, ok := publisher.ParseClock(fields.Clock)
+	if !ok {
+		return []byte(data)
+	}
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		return []byte(data)
+	}
+
+	entry := &liveClock{
+		payload: payload,
+		clock: publisher.ClockState{
+			Period:     fields.Period,
+			Seconds:    seconds,
+			Running:    fields.Running,
+			ObservedAt: *fields.ObservedAt,
+		},
+	}
+	entry.sent = entry.clock.Estimate(now)
+	message, err := entry.message()
+	if err != nil {
+		return []byte(data)
+	}
+
+	f.mu.Lock()
+	if entry.clock.Running {
+		f.clocks[fields.GameID] = entry
+	} else {
+		delete(f.clocks, fields.GameID)
+	}
+	f.mu.Unlock()
+	return message
+}
+
+// message renders the update with the last estimate
+func (c *liveClock) message() ([]byte, error) {
+	estimate, err := json.Marshal(publisher.FormatClock(c.sent))
+	if err != nil {
+		return nil, err
+	}
+	c.payload["estimated_clock"] = estimate
+	return json.Marshal(c.payload)
+}
+
+// tickClocks re-broadcasts running games each time their estimated clock
+// drops a second, until the next poll, a stoppage, 0:00, or the extrapolation
+// limit
+func (f *Feed) tickClocks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			var messages [][]byte
+			f.mu.Lock()
+			for gameID, entry := range f.clocks {
+				seconds := entry.clock.Estimate(now)
+				if seconds == entry.sent {
+					if seconds == 0 || now.Sub(entry.clock.ObservedAt) >= publisher.MaxClockExtrapolation {
+						delete(f.clocks, gameID)
+					}
+					continue
+				}
+				entry.sent = seconds
+				if message, err := entry.message(); err == nil {
+					messages = append(messages, message)
+				}
+			}
+			f.mu.Unlock()
+
+			for _, message := range messages {
+				f.hub.Broadcast(message)
+			}
+		}
+	}
+}
+
+// observe records the game's latest state and returns the events for both teams
+func (f *Feed) observe(game *store.Game) []TeamEvent {
+	home, away := int(game.HomeScore.Int32), int(game.AwayScore.Int32)
+	period := int(game.Period.Int32)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	prev, seen := f.games[game.GameID]
+	cur := &gameState{status: game.Status, period: period}
+	if seen {
+		cur.leader = prev.leader
+	}
+	switch {
+	case home > away:
+		cur.leader = game.HomeTeamID
+	case away > home:
+		cur.leader = game.AwayTeamID
+	}
+
+	if game.Status == "final" {
+		// Finished games are never polled again
+		delete(f.games, game.GameID)
+	} else {
+		f.games[game.GameID] = cur
+	}
+
+	var types []string
+	switch {
+	case !seen:
+		// A game first seen mid-way (e.g. after a restart) only counts as tipping off
+		// if it is still scoreless
+		if game.Status == "in_progress" && home == 0 && away == 0 {
+			types = append(types, EventTipOff)
+		}
+	default:
+		if game.Status == "in_progress" && prev.status != "in_progress" && prev.status != "final" {
+			types = append(types, EventTipOff)
+		}
+		if prev.period > 0 && period > prev.period {
+			types = append(types, EventQuarterEnd)
+		}
+		if prev.leader != 0 && cur.leader != prev.leader {
+			types = append(types, EventLeadChange)
+		}
+		if game.Status == "final" && prev.status != "final" {
+			types = append(types, EventFinal)
+		}
+	}
+
+	var events []TeamEvent
+	for _, eventType := range types {
+		eventPeriod := period
+		if eventType == EventQuarterEnd {
+			eventPeriod = prev.period
+		}
+		events = append(events,
+			newTeamEvent(eventType, game, game.HomeTeamID, eventPeriod),
+			newTeamEvent(eventType, game, game.AwayTeamID, eventPeriod),
+		)
+	}
+	return events
+}
+
+func newTeamEvent(eventType string, game *store.Game, teamID, period int) TeamEvent {
+	home, away := int(game.HomeScore.Int32), int(game.AwayScore.Int32)
+	event := TeamEvent{
+		Type:      eventType,
+		GameID:    game.GameID,
+		TeamID:    teamID,
+		Period:    period,
+		Clock:     game.Clock.String,
+		Status:    game.Status,
+		Timestamp: time.Now(),
+	}
+	if teamID == game.HomeTeamID {
+		event.IsHome = true
+		event.OpponentID = game.AwayTeamID
+		event.TeamScore, event.OpponentScore = home, away
+	} else {
+		event.OpponentID = game.HomeTeamID
+		event.TeamScore, event.OpponentScore = away, home
+	}
+	return event
+}
@@ -1,17 +1,34 @@
 package websocket
 
 import (
+	"fmt"
 	"log"
 	"sync"
 )
 
+// TopicLeague is the full league feed served on /ws/games/live
+const TopicLeague = "games.live"
+
+// TeamTopic returns the topic carrying a single team's game events
+func TeamTopic(teamID int) string {
+	return fmt.Sprintf("teams.%d.live", teamID)
+}
+
+// topicMessage is a message addressed to the subscribers of one topic
+type topicMessage struct {
+	topic string
+	data  []byte
+}
+
 // Hub maintains the set of active clients and broadcasts messages to the clients
 type Hub struct {
+	config Config
+
 	// Registered clients
 	clients map[*Client]bool
 
-	// Inbound messages from clients
-	broadcast chan []byte
+	// Outbound messages, delivered to clients subscribed to the message topic
+	broadcast chan topicMessage
 
 	// Register requests from clients
 	register chan *Client
@@ -19,18 +36,50 @@ type Hub struct {
 	// Unregister requests from clients
 	unregister chan *Client
 
+	// Connection slots; nil when MaxConnections is unlimited
+	slots chan struct{}
+
+	// Set by CloseAll; clients registering afterwards are closed at once
+	closing bool
+
 	// Mutex for thread-safe operations
 	mu sync.RWMutex
 }
 
 // NewHub creates a new Hub
-func NewHub() *Hub {
-	return &Hub{
+func NewHub(config Config) *Hub {
+	h := &Hub{
+		config:     config,
 		clients:    make(map[*Client]bool),
-		broadcast:  make(chan []byte, 256),
+		broadcast:  make(chan topicMessage, 256),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 	}
+	if config.MaxConnections > 0 {
+		h.slots = make(chan struct{}, config.MaxConnections)
+	}
+	return h
+}
+
+// Acquire reserves a connection slot, reporting false when the server is full.
+// The slot is released when the client is removed from the hub.
+func (h *Hub) Acquire() bool {
+	if h.slots == nil {
+		return true
+	}
+	select {
+	case h.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees a slot taken by Acquire
+func (h *Hub) release() {
+	if h.slots != nil {
+		<-h.slots
+	}
 }
 
 // Run starts the hub's main loop
@@ -40,37 +89,105 @@ func (h *Hub) Run() {
 		case client := <-h.register:
 			h.mu.Lock()
 			h.clients[client] = true
+			total := len(h.clients)
+			closing := h.closing
 			h.mu.Unlock()
-			log.Printf("WebSocket client connected (total: %d)", len(h.clients))
+			connectionsOpen.WithLabelValues(client.channel()).Inc()
+			connectionsAccepted.WithLabelValues(client.channel()).Inc()
+			if closing {
+				h.remove(client, disconnectShutdown)
+				continue
+			}
+			log.Printf("WebSocket client connected (total: %d)", total)
 
 		case client := <-h.unregister:
-			h.mu.Lock()
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.send)
-			}
-			h.mu.Unlock()
-			log.Printf("WebSocket client disconnected (total: %d)", len(h.clients))
+			h.remove(client, client.closeReason)
 
 		case message := <-h.broadcast:
+			// Collect slow consumers first; the map can't change under a read lock
+			var slow []*Client
 			h.mu.RLock()
 			for client := range h.clients {
+				if client.topic != message.topic {
+					continue
+				}
 				select {
-				case client.send <- message:
+				case client.send <- message.data:
+					messagesSent.Inc()
 				default:
-					// Client's send buffer is full, close connection
-					close(client.send)
-					delete(h.clients, client)
+					messagesDropped.Inc()
+					slow = append(slow, client)
 				}
 			}
 			h.mu.RUnlock()
+
+			// Client's send buffer is full: disconnect it rather than stall everyone else
+			for _, client := range slow {
+				h.remove(client, disconnectSlowConsumer)
+			}
 		}
 	}
 }
 
-// Broadcast sends a message to all connected clients
+// remove drops a registered client and closes its send channel, which makes
+// writePump send a close frame and tear down the connection
+func (h *Hub) remove(client *Client, reason string) {
+	h.mu.Lock()
+	_, ok := h.clients[client]
+	if ok {
+		client.dropReason = reason
+		delete(h.clients, client)
+		close(client.send)
+	}
+	total := len(h.clients)
+	h.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	h.release()
+	connectionsOpen.WithLabelValues(client.channel()).Dec()
+	if reason == "" {
+		reason = disconnectClosed
+	}
+	disconnects.WithLabelValues(reason).Inc()
+
+	if reason == disconnectShutdown {
+		return
+	}
+	if reason == disconnectSlowConsumer {
+		log.Printf("⚠️  Evicted slow WebSocket client on %s (send buffer of %d full, total: %d)", client.topic, cap(client.send), total)
+		return
+	}
+	log.Printf("WebSocket client disconnected (total: %d)", total)
+}
+
+// CloseAll drops every client with a close frame for reason and returns them,
+// so the caller can wait on each client's done channel
+func (h *Hub) CloseAll(reason string) []*Client {
+	h.mu.Lock()
+	h.closing = true
+	clients := make([]*Client, 0, len(h.clients))
+	for client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.Unlock()
+
+	for _, client := range clients {
+		h.remove(client, reason)
+	}
+	return clients
+}
+
+// Broadcast sends a message to all clients on the league feed
 func (h *Hub) Broadcast(message []byte) {
-	h.broadcast <- message
+	h.Publish(TopicLeague, message)
+}
+
+// Publish sends a message to the clients subscribed to topic
+func (h *Hub) Publish(topic string, message []byte) {
+	h.broadcast <- topicMessage{topic: topic, data: message}
 }
 
 // ClientCount returns the number of connected clients
@@ -79,5 +196,3 @@ func (h *Hub) ClientCount() int {
 	defer h.mu.RUnlock()
 	return len(h.clients)
 }
-
-
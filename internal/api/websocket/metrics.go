@@ -0,0 +1,42 @@
+package websocket
+
+import "github.com/fortuna/minerva/internal/metrics"
+
+// Routes and outcomes reported on minerva_ws_upgrades_total
+const (
+	routeGamesLive = "ws.games.live"
+	routeTeamLive  = "ws.teams.live"
+
+	upgradeOK       = "upgraded"
+	upgradeRejected = "rejected" // at the connection limit
+	upgradeInvalid  = "invalid"  // bad or unknown team
+	upgradeFailed   = "failed"   // handshake error
+)
+
+// Disconnect reasons reported on minerva_ws_disconnects_total
+const (
+	disconnectClosed       = "closed"
+	disconnectError        = "error"
+	disconnectPongTimeout  = "pong_timeout"
+	disconnectSlowConsumer = "slow_consumer"
+	disconnectShutdown     = "shutdown"
+)
+
+var (
+	connectionsOpen = metrics.NewGaugeVec("minerva_ws_connections",
+		"Open WebSocket connections", "channel")
+	connectionsAccepted = metrics.NewCounterVec("minerva_ws_connections_total",
+		"WebSocket connections accepted", "channel")
+	connectionsRejected = metrics.NewCounterVec("minerva_ws_connections_rejected_total",
+		"WebSocket connections refused before upgrade", "reason")
+	disconnects = metrics.NewCounterVec("minerva_ws_disconnects_total",
+		"WebSocket disconnects by reason", "reason")
+	upgrades = metrics.NewCounterVec("minerva_ws_upgrades_total",
+		"WebSocket upgrade requests by route and outcome", "route", "result")
+	upgradeDuration = metrics.NewHistogramVec("minerva_ws_upgrade_duration_seconds",
+		"Time from WebSocket request to completed upgrade", nil, "route")
+	messagesSent = metrics.NewCounter("minerva_ws_messages_sent_total",
+		"Messages queued to WebSocket clients")
+	messagesDropped = metrics.NewCounter("minerva_ws_messages_dropped_total",
+		"Messages dropped because a client's send buffer was full")
+)
@@ -5,76 +5,164 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/fortuna/minerva/internal/api/cors"
 	"github.com/fortuna/minerva/internal/cache"
 	"github.com/fortuna/minerva/internal/publisher"
 	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
 	"github.com/gorilla/websocket"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true // Allow all origins in development (TODO: restrict in production)
-	},
-}
-
 // Server represents the WebSocket server
 type Server struct {
 	port      string
 	server    *http.Server
 	hub       *Hub
+	feed      *Feed
+	startOnce sync.Once
+	ctx       context.Context
+	cancel    context.CancelFunc
 	db        *store.Database
 	cache     *cache.RedisCache
-	publisher *publisher.RedisPublisher
+	publisher publisher.Publisher
+	upgrader  websocket.Upgrader
+	draining  atomic.Bool
 }
 
-// NewServer creates a new WebSocket server
-func NewServer(db *store.Database, cache *cache.RedisCache, pub *publisher.RedisPublisher) *Server {
-	hub := NewHub()
-	
+// NewServer creates a new WebSocket server with DefaultConfig limits
+func NewServer(db *store.Database, cache *cache.RedisCache, pub publisher.Publisher) *Server {
+	// The default policy doesn't allow credentials, so it always compiles
+	server, _ := NewServerWithConfig(db, cache, pub, DefaultConfig())
+	return server
+}
+
+// NewServerWithConfig creates a new WebSocket server with explicit connection
+// limits. It fails when config.CORS is rejected by cors.NewPolicy.
+func NewServerWithConfig(db *store.Database, cache *cache.RedisCache, pub publisher.Publisher, config Config) (*Server, error) {
+	origins, err := cors.NewPolicy(config.CORS)
+	if err != nil {
+		return nil, err
+	}
+	hub := NewHub(config.withDefaults())
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &Server{
 		hub:       hub,
+		feed:      NewFeed(cache.Client(), hub),
+		ctx:       ctx,
+		cancel:    cancel,
 		db:        db,
 		cache:     cache,
 		publisher: pub,
-	}
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     origins.CheckOrigin,
+		},
+	}, nil
 }
 
-// Start starts the WebSocket server
-func (s *Server) Start(port string) error {
-	s.port = port
-	
-	// Start the hub in a goroutine
-	go s.hub.Run()
+// Start starts the hub and the stream feed. Call it before serving Handler;
+// later calls do nothing. Shutdown stops the feed.
+func (s *Server) Start() {
+	s.startOnce.Do(func() {
+		go s.hub.Run()
 
-	// Set up HTTP routes
-	mux := http.NewServeMux()
-	mux.HandleFunc("/ws/games/live", s.handleLiveGames)
-	mux.HandleFunc("/ws/health", s.handleHealth)
+		// Feed game stream updates into the hub
+		go s.feed.Run(s.ctx)
+	})
+}
 
+// ListenAndServe starts the hub and feed and serves the WebSocket routes on port
+func (s *Server) ListenAndServe(port string) error {
+	s.Start()
+
+	s.port = port
 	s.server = &http.Server{
 		Addr:    fmt.Sprintf(":%s", port),
-		Handler: mux,
+		Handler: s.Handler(),
 	}
 
 	log.Printf("WebSocket server listening on :%s", port)
 	return s.server.ListenAndServe()
 }
 
+// Handler returns the WebSocket routes, for serving them from an httptest
+// server or another listener once Start has been called
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws/games/live", s.handleLiveGames)
+	mux.HandleFunc("/ws/teams/{teamID}/live", s.handleTeamLive)
+	mux.HandleFunc("/ws/health", s.handleHealth)
+	return mux
+}
+
 // handleLiveGames handles WebSocket connections for live game updates
 func (s *Server) handleLiveGames(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+	s.serveClient(w, r, routeGamesLive, TopicLeague)
+}
+
+// handleTeamLive handles WebSocket connections for a single team's game events
+// (tip-off, quarter end, lead changes, final)
+func (s *Server) handleTeamLive(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(r.PathValue("teamID"))
+	if err != nil {
+		upgrades.WithLabelValues(routeTeamLive, upgradeInvalid).Inc()
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := repository.NewTeamRepository(s.db).GetByID(r.Context(), teamID); err != nil {
+		upgrades.WithLabelValues(routeTeamLive, upgradeInvalid).Inc()
+		http.Error(w, "Team not found", http.StatusNotFound)
+		return
+	}
+
+	s.serveClient(w, r, routeTeamLive, TeamTopic(teamID))
+}
+
+// serveClient upgrades the connection and subscribes the client to topic.
+// route labels the upgrade metrics.
+func (s *Server) serveClient(w http.ResponseWriter, r *http.Request, route, topic string) {
+	start := time.Now()
+	if s.draining.Load() {
+		connectionsRejected.WithLabelValues("shutting_down").Inc()
+		upgrades.WithLabelValues(route, upgradeRejected).Inc()
+		w.Header().Set("Retry-After", "5")
+		http.Error(w, "Server shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	if !s.hub.Acquire() {
+		connectionsRejected.WithLabelValues("max_connections").Inc()
+		upgrades.WithLabelValues(route, upgradeRejected).Inc()
+		w.Header().Set("Retry-After", "30")
+		http.Error(w, "Too many WebSocket connections", http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
 	if err != nil {
+		s.hub.release()
+		connectionsRejected.WithLabelValues("upgrade_failed").Inc()
+		upgrades.WithLabelValues(route, upgradeFailed).Inc()
 		log.Printf("Failed to upgrade connection: %v", err)
 		return
 	}
 
+	upgrades.WithLabelValues(route, upgradeOK).Inc()
+	upgradeDuration.WithLabelValues(route).Observe(time.Since(start).Seconds())
+
 	client := &Client{
-		hub:  s.hub,
-		conn: conn,
-		send: make(chan []byte, 256),
+		hub:   s.hub,
+		conn:  conn,
+		send:  make(chan []byte, s.hub.config.SendBufferSize),
+		topic: topic,
+		done:  make(chan struct{}),
 	}
 
 	client.hub.register <- client
@@ -87,7 +175,12 @@ func (s *Server) handleLiveGames(w http.ResponseWriter, r *http.Request) {
 // handleHealth returns WebSocket server health status
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	fmt.Fprintf(w, `{"status": "healthy", "clients": %d}`, s.hub.ClientCount())
+	fmt.Fprintf(w, `{"status": "healthy", "clients": %d, "max_connections": %d}`, s.hub.ClientCount(), s.hub.config.MaxConnections)
+}
+
+// ClientCount returns the number of connected clients
+func (s *Server) ClientCount() int {
+	return s.hub.ClientCount()
 }
 
 // BroadcastLiveUpdate sends a live game update to all connected clients
@@ -95,10 +188,26 @@ func (s *Server) BroadcastLiveUpdate(data []byte) {
 	s.hub.Broadcast(data)
 }
 
-// Shutdown gracefully shuts down the server
+// Shutdown stops accepting connections and the stream feed, then closes every
+// open connection with a service-restart close frame so clients know to
+// reconnect elsewhere. It returns once each close frame is written or ctx expires.
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.draining.Store(true)
+	var err error
 	if s.server != nil {
-		return s.server.Shutdown(ctx)
+		// Upgraded connections are hijacked, so this only waits for pending handshakes
+		err = s.server.Shutdown(ctx)
+	}
+	s.cancel()
+
+	clients := s.hub.CloseAll(disconnectShutdown)
+	for _, client := range clients {
+		select {
+		case <-client.done:
+		case <-ctx.Done():
+			return fmt.Errorf("closing %d WebSocket clients: %w", len(clients), ctx.Err())
+		}
 	}
-	return nil
+	log.Printf("✓ Closed %d WebSocket connections", len(clients))
+	return err
 }
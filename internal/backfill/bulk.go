@@ -0,0 +1,283 @@
+package backfill
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/ingest/espn"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// Season import outcomes reported by BulkImporter
+const (
+	SeasonCompleted = "completed"
+	SeasonSkipped   = "skipped"
+	SeasonFailed    = "failed"
+)
+
+// BulkOptions configures a multi-season import
+type BulkOptions struct {
+	Sport     string
+	RateLimit RateLimit
+	Restart   bool // ignore checkpoints and reimport every season from its first date
+}
+
+// SeasonReport summarizes one season of a bulk import. Dates, games, and rows
+// cover the whole season, including work done by earlier runs that were resumed.
+type SeasonReport struct {
+	Season        string
+	Status        string
+	Dates         int
+	DatesTotal    int
+	Games         int
+	RowsChanged   int
+	RowsUnchanged int
+	Duration      time.Duration // time spent in this run only
+	Err           error
+}
+
+// ParseSeasonRange expands a season selection into season IDs. It accepts an
+// inclusive range ("2015-16..2024-25"), a comma-separated list, or one season.
+func ParseSeasonRange(spec string) ([]string, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("no seasons given")
+	}
+
+	if from, to, ok := strings.Cut(spec, ".."); ok {
+		first, err := seasonStartYear(from)
+		if err != nil {
+			return nil, err
+		}
+		last, err := seasonStartYear(to)
+		if err != nil {
+			return nil, err
+		}
+		if last < first {
+			return nil, fmt.Errorf("season range %q runs backwards", spec)
+		}
+
+		seasons := make([]string, 0, last-first+1)
+		for year := first; year <= last; year++ {
+			seasons = append(seasons, fmt.Sprintf("%d-%02d", year, (year+1)%100))
+		}
+		return seasons, nil
+	}
+
+	var seasons []string
+	for _, season := range strings.Split(spec, ",") {
+		season = strings.TrimSpace(season)
+		if _, err := seasonStartYear(season); err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, season)
+	}
+	return seasons, nil
+}
+
+// seasonStartYear validates a "YYYY-YY" season ID and returns its first year
+func seasonStartYear(season string) (int, error) {
+	season = strings.TrimSpace(season)
+	start, end, ok := strings.Cut(season, "-")
+	if !ok || len(start) != 4 || len(end) != 2 {
+		return 0, fmt.Errorf("invalid season %q (want YYYY-YY)", season)
+	}
+	year, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, fmt.Errorf("invalid season %q (want YYYY-YY)", season)
+	}
+	if next, err := strconv.Atoi(end); err != nil || next != (year+1)%100 {
+		return 0, fmt.Errorf("invalid season %q: years are not consecutive", season)
+	}
+	return year, nil
+}
+
+// BulkImporter ingests many seasons in order, one date-range job per season,
+// checkpointing after every date so a restarted import resumes where it stopped.
+type BulkImporter struct {
+	runner *Runner
+	repo   *Repository
+}
+
+// NewBulkImporter creates an importer that runs its seasons through runner
+func NewBulkImporter(db *store.Database, runner *Runner) *BulkImporter {
+	return &BulkImporter{runner: runner, repo: NewRepository(db)}
+}
+
+// Run imports each season and returns a report per season. A failed season is
+// recorded and the import moves on; cancelling ctx stops it with the checkpoint
+// of the current season intact.
+func (b *BulkImporter) Run(ctx context.Context, seasons []string, opts BulkOptions) ([]SeasonReport, error) {
+	if opts.Sport == "" {
+		opts.Sport = store.DefaultSport
+	}
+
+	checkpoints := make(map[string]*SeasonCheckpoint, len(seasons))
+	progress := &bulkProgress{started: time.Now()}
+	for _, season := range seasons {
+		cp, err := b.repo.GetCheckpoint(ctx, opts.Sport, season)
+		if err != nil {
+			return nil, err
+		}
+		if cp == nil || opts.Restart {
+			cp = &SeasonCheckpoint{Sport: opts.Sport, SeasonYear: season}
+		}
+		checkpoints[season] = cp
+
+		if cp.Status != CheckpointCompleted {
+			start, end := b.window(cp)
+			progress.total += len(enumerateDates(start, end))
+		}
+	}
+	log.Printf("[bulk] Importing %d seasons (%d dates remaining)", len(seasons), progress.total)
+
+	reports := make([]SeasonReport, 0, len(seasons))
+	for _, season := range seasons {
+		cp := checkpoints[season]
+		if cp.Status == CheckpointCompleted {
+			log.Printf("[bulk] %s already imported; skipping (use --restart to reimport)", season)
+			reports = append(reports, checkpointReport(cp, SeasonSkipped, 0, nil))
+			continue
+		}
+
+		report := b.importSeason(ctx, cp, opts, progress)
+		reports = append(reports, report)
+		if ctx.Err() != nil {
+			return reports, ctx.Err()
+		}
+	}
+
+	return reports, nil
+}
+
+// importSeason runs the remaining dates of one season, saving the checkpoint as it goes
+func (b *BulkImporter) importSeason(ctx context.Context, cp *SeasonCheckpoint, opts BulkOptions, progress *bulkProgress) SeasonReport {
+	seasonStart, seasonEnd := SeasonWindow(cp.SeasonYear)
+	start, end := b.window(cp)
+
+	cp.Status = CheckpointRunning
+	cp.DatesTotal = len(enumerateDates(seasonStart, seasonEnd))
+	cp.LastError = sql.NullString{}
+	cp.CompletedAt = sql.NullTime{}
+	if cp.StartedAt.IsZero() {
+		cp.StartedAt = time.Now()
+	}
+	saveCtx := context.WithoutCancel(ctx)
+	if err := b.repo.SaveCheckpoint(saveCtx, cp); err != nil {
+		return checkpointReport(cp, SeasonFailed, 0, err)
+	}
+
+	if cp.LastCompletedDate.Valid {
+		log.Printf("[bulk] Resuming %s at %s (%d/%d dates done)",
+			cp.SeasonYear, start.Format("2006-01-02"), cp.DatesCompleted, cp.DatesTotal)
+	} else {
+		log.Printf("[bulk] Starting %s (%d dates)", cp.SeasonYear, cp.DatesTotal)
+	}
+
+	began := time.Now()
+	spec := JobSpec{
+		Type:      JobTypeDateRange,
+		Sport:     opts.Sport,
+		SeasonID:  cp.SeasonYear,
+		Start:     start,
+		End:       end,
+		RateLimit: opts.RateLimit,
+	}
+	reporter := &checkpointReporter{
+		ConsoleReporter: NewConsoleReporter(false),
+		ctx:             saveCtx,
+		repo:            b.repo,
+		checkpoint:      cp,
+		progress:        progress,
+	}
+	err := b.runner.Run(ctx, spec, reporter)
+
+	if err != nil {
+		cp.Status = CheckpointFailed
+		cp.LastError = sql.NullString{String: err.Error(), Valid: true}
+	} else {
+		cp.Status = CheckpointCompleted
+		cp.CompletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+	if saveErr := b.repo.SaveCheckpoint(saveCtx, cp); saveErr != nil {
+		log.Printf("[bulk] Warning: failed to save %s checkpoint: %v", cp.SeasonYear, saveErr)
+	}
+
+	if err != nil {
+		return checkpointReport(cp, SeasonFailed, time.Since(began), err)
+	}
+	return checkpointReport(cp, SeasonCompleted, time.Since(began), nil)
+}
+
+// window returns the dates of a season still to import
+func (b *BulkImporter) window(cp *SeasonCheckpoint) (time.Time, time.Time) {
+	start, end := SeasonWindow(cp.SeasonYear)
+	if cp.LastCompletedDate.Valid {
+		start = truncateDate(cp.LastCompletedDate.Time).AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+func checkpointReport(cp *SeasonCheckpoint, status string, elapsed time.Duration, err error) SeasonReport {
+	return SeasonReport{
+		Season:        cp.SeasonYear,
+		Status:        status,
+		Dates:         cp.DatesCompleted,
+		DatesTotal:    cp.DatesTotal,
+		Games:         cp.GamesChanged + cp.GamesUnchanged,
+		RowsChanged:   cp.RowsChanged,
+		RowsUnchanged: cp.RowsUnchanged,
+		Duration:      elapsed,
+		Err:           err,
+	}
+}
+
+// bulkProgress tracks dates imported across all seasons of one run for the ETA
+type bulkProgress struct {
+	started time.Time
+	total   int
+	done    int
+}
+
+// eta extrapolates the remaining time from this run's average pace per date
+func (p *bulkProgress) eta() time.Duration {
+	if p.done == 0 || p.done >= p.total {
+		return 0
+	}
+	perDate := time.Since(p.started) / time.Duration(p.done)
+	return perDate * time.Duration(p.total-p.done)
+}
+
+// checkpointReporter logs like ConsoleReporter and saves the season checkpoint
+// after every completed date
+type checkpointReporter struct {
+	*ConsoleReporter
+	ctx        context.Context
+	repo       *Repository
+	checkpoint *SeasonCheckpoint
+	progress   *bulkProgress
+}
+
+func (r *checkpointReporter) OnDateComplete(date time.Time, counts espn.WriteCounts) {
+	cp := r.checkpoint
+	cp.LastCompletedDate = sql.NullTime{Time: date, Valid: true}
+	cp.DatesCompleted++
+	cp.GamesChanged += counts.GamesChanged
+	cp.GamesUnchanged += counts.GamesUnchanged
+	cp.RowsChanged += counts.Changed()
+	cp.RowsUnchanged += counts.Unchanged()
+	if err := r.repo.SaveCheckpoint(r.ctx, cp); err != nil {
+		log.Printf("[bulk] Warning: failed to checkpoint %s at %s: %v", cp.SeasonYear, date.Format("2006-01-02"), err)
+	}
+
+	p := r.progress
+	p.done++
+	log.Printf("[bulk] %s %d/%d dates · overall %d/%d (%.1f%%) · ETA %s",
+		cp.SeasonYear, cp.DatesCompleted, cp.DatesTotal, p.done, p.total,
+		100*float64(p.done)/float64(max(p.total, 1)), p.eta().Round(time.Second))
+}
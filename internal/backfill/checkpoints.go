@@ -0,0 +1,93 @@
+package backfill
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Checkpoint statuses for bulk season imports
+const (
+	CheckpointRunning   = "running"
+	CheckpointCompleted = "completed"
+	CheckpointFailed    = "failed"
+)
+
+// SeasonCheckpoint records how far a bulk import got through one season.
+type SeasonCheckpoint struct {
+	Sport             string
+	SeasonYear        string
+	Status            string
+	LastCompletedDate sql.NullTime
+	DatesCompleted    int
+	DatesTotal        int
+	GamesChanged      int
+	GamesUnchanged    int
+	RowsChanged       int
+	RowsUnchanged     int
+	LastError         sql.NullString
+	StartedAt         time.Time
+	CompletedAt       sql.NullTime
+}
+
+// GetCheckpoint returns the checkpoint for a season, or nil if it was never imported.
+func (r *Repository) GetCheckpoint(ctx context.Context, sport, seasonYear string) (*SeasonCheckpoint, error) {
+	query := `
+		SELECT sport, season_year, status, last_completed_date, dates_completed, dates_total,
+			games_changed, games_unchanged, rows_changed, rows_unchanged,
+			last_error, started_at, completed_at
+		FROM backfill_season_checkpoints
+		WHERE sport = $1 AND season_year = $2
+	`
+
+	cp := &SeasonCheckpoint{}
+	err := r.db.DB().QueryRowContext(ctx, query, sport, seasonYear).Scan(
+		&cp.Sport, &cp.SeasonYear, &cp.Status, &cp.LastCompletedDate, &cp.DatesCompleted, &cp.DatesTotal,
+		&cp.GamesChanged, &cp.GamesUnchanged, &cp.RowsChanged, &cp.RowsUnchanged,
+		&cp.LastError, &cp.StartedAt, &cp.CompletedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get season checkpoint: %w", err)
+	}
+	return cp, nil
+}
+
+// SaveCheckpoint inserts or replaces a season checkpoint.
+func (r *Repository) SaveCheckpoint(ctx context.Context, cp *SeasonCheckpoint) error {
+	query := `
+		INSERT INTO backfill_season_checkpoints (
+			sport, season_year, status, last_completed_date, dates_completed, dates_total,
+			games_changed, games_unchanged, rows_changed, rows_unchanged,
+			last_error, started_at, completed_at, updated_at
+		)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,NOW())
+		ON CONFLICT (sport, season_year) DO UPDATE SET
+			status = EXCLUDED.status,
+			last_completed_date = EXCLUDED.last_completed_date,
+			dates_completed = EXCLUDED.dates_completed,
+			dates_total = EXCLUDED.dates_total,
+			games_changed = EXCLUDED.games_changed,
+			games_unchanged = EXCLUDED.games_unchanged,
+			rows_changed = EXCLUDED.rows_changed,
+			rows_unchanged = EXCLUDED.rows_unchanged,
+			last_error = EXCLUDED.last_error,
+			started_at = EXCLUDED.started_at,
+			completed_at = EXCLUDED.completed_at,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.DB().ExecContext(ctx, query,
+		cp.Sport, cp.SeasonYear, cp.Status, cp.LastCompletedDate, cp.DatesCompleted, cp.DatesTotal,
+		cp.GamesChanged, cp.GamesUnchanged, cp.RowsChanged, cp.RowsUnchanged,
+		cp.LastError, cp.StartedAt, cp.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("save season checkpoint: %w", err)
+	}
+	return nil
+}
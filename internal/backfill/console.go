@@ -0,0 +1,108 @@
+package backfill
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// BuildSpec assembles a JobSpec from command-line style arguments. Exactly one of
+// gameID, season, or a start/end pair is expected, checked in that order.
+func BuildSpec(season, startStr, endStr, gameID string) (JobSpec, error) {
+	spec := JobSpec{
+		Sport:    "basketball_nba",
+		SeasonID: season,
+	}
+
+	switch {
+	case gameID != "":
+		spec.Type = JobTypeGame
+		spec.GameIDs = []string{gameID}
+	case season != "":
+		spec.Type = JobTypeSeason
+		spec.Start, spec.End = SeasonWindow(season)
+	case startStr != "" && endStr != "":
+		spec.Type = JobTypeDateRange
+		start, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			return spec, fmt.Errorf("invalid start date: %w", err)
+		}
+		end, err := time.Parse("2006-01-02", endStr)
+		if err != nil {
+			return spec, fmt.Errorf("invalid end date: %w", err)
+		}
+		spec.Start = start
+		spec.End = end
+	default:
+		return spec, fmt.Errorf("unable to determine job type")
+	}
+
+	return spec, nil
+}
+
+// ConsoleReporter logs runner callbacks for interactive command-line use
+type ConsoleReporter struct {
+	DryRun bool
+}
+
+// NewConsoleReporter creates a reporter that writes progress to the standard logger
+func NewConsoleReporter(dryRun bool) *ConsoleReporter {
+	return &ConsoleReporter{DryRun: dryRun}
+}
+
+func (c *ConsoleReporter) OnJobStart(spec JobSpec) {
+	log.Printf("Starting %s job (dry_run=%v)", spec.Type, c.DryRun)
+}
+
+func (c *ConsoleReporter) OnDateStart(date time.Time, index int, total int) {
+	log.Printf("[%d/%d] %s", index+1, total, date.Format("2006-01-02"))
+}
+
+func (c *ConsoleReporter) OnGameProcessed(gameID string) {
+	log.Printf("Processed game %s", gameID)
+}
+
+func (c *ConsoleReporter) OnProgress(message string, current int, total int) {
+	log.Printf("Progress: %s (%d/%d)", message, current, total)
+}
+
+func (c *ConsoleReporter) OnJobComplete() {
+	log.Println("Job complete")
+}
+
+func (c *ConsoleReporter) OnJobError(err error) {
+	log.Printf("Job error: %v", err)
+}
+
+func (c *ConsoleReporter) OnDryRunReport(report *DryRunReport) {
+	log.Println("Dry-run plan (no data written):")
+	log.Printf("  %-12s %6s %6s %9s", "DATE", "GAMES", "NEW", "EXISTING")
+	for _, d := range report.Dates {
+		label := d.Date
+		if label == "" {
+			label = "(games)"
+		}
+		if d.Error != "" {
+			log.Printf("  %-12s  error: %s", label, d.Error)
+			continue
+		}
+		log.Printf("  %-12s %6d %6d %9d", label, d.Games, len(d.NewGameIDs), len(d.ExistingGameIDs))
+	}
+	log.Printf("  %-12s %6d %6d %9d", "TOTAL", report.TotalGames, report.NewGames, report.ExistingGames)
+	if report.FailedDates > 0 {
+		log.Printf("  ⚠️  %d dates could not be fetched", report.FailedDates)
+	}
+}
+
+// LogSeasonReports prints the per-season summary of a bulk import
+func LogSeasonReports(reports []SeasonReport) {
+	log.Println("Bulk import summary:")
+	log.Printf("  %-8s %-10s %9s %6s %8s %10s %9s", "SEASON", "STATUS", "DATES", "GAMES", "CHANGED", "UNCHANGED", "TIME")
+	for _, r := range reports {
+		log.Printf("  %-8s %-10s %4d/%-4d %6d %8d %10d %9s", r.Season, r.Status, r.Dates, r.DatesTotal,
+			r.Games, r.RowsChanged, r.RowsUnchanged, r.Duration.Round(time.Second))
+		if r.Err != nil {
+			log.Printf("           error: %v", r.Err)
+		}
+	}
+}
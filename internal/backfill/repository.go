@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/fortuna/minerva/internal/store"
 )
@@ -24,22 +25,116 @@ func (r *Repository) CreateJob(ctx context.Context, job *Job) (*Job, error) {
 	query := `
 		INSERT INTO backfill_jobs (
 			job_type, sport, season_id, start_date, end_date, game_ids,
-			status, status_message, progress_current, progress_total
+			status, status_message, progress_current, progress_total, dry_run,
+			requests_per_minute, date_delay_ms
 		)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)
 		RETURNING job_id, job_type, sport, season_id, start_date, end_date, game_ids,
 			status, status_message, progress_current, progress_total,
-			last_error, retry_count, created_at, updated_at, started_at, completed_at
+			last_error, retry_count, created_at, updated_at, started_at, completed_at,
+			dry_run, result, requests_per_minute, date_delay_ms, units_completed
 	`
 
 	row := r.db.DB().QueryRowContext(ctx, query,
 		job.JobType, job.Sport, job.SeasonID, job.StartDate, job.EndDate, job.GameIDs,
-		job.Status, job.StatusMessage, job.ProgressCurrent, job.ProgressTotal,
+		job.Status, job.StatusMessage, job.ProgressCurrent, job.ProgressTotal, job.DryRun,
+		job.RateLimit.RequestsPerMinute, job.RateLimit.DateDelay.Milliseconds(),
 	)
 
 	return scanJob(row)
 }
 
+// GetJob returns a job by ID.
+func (r *Repository) GetJob(ctx context.Context, jobID string) (*Job, error) {
+	query := `
+		SELECT job_id, job_type, sport, season_id, start_date, end_date, game_ids,
+			status, status_message, progress_current, progress_total,
+			last_error, retry_count, created_at, updated_at, started_at, completed_at,
+			dry_run, result, requests_per_minute, date_delay_ms, units_completed
+		FROM backfill_jobs
+		WHERE job_id = $1
+	`
+
+	job, err := scanJob(r.db.DB().QueryRowContext(ctx, query, jobID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.NotFound("backfill job %s", jobID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+	return job, nil
+}
+
+// WithEnqueueLock runs fn while holding the lock that serializes job creation
+// across replicas, so duplicate checks and inserts don't interleave.
+func (r *Repository) WithEnqueueLock(ctx context.Context, fn func() error) error {
+	return r.db.WithAdvisoryLock(ctx, store.LockNamespaceBackfill, "enqueue", fn)
+}
+
+// FindCoveringJob returns the oldest queued or running job of the same sport
+// and dry-run mode whose work includes all of job's: a season or date range
+// job spanning its dates, or a game job listing every one of its game IDs.
+// It returns nil when there is none.
+func (r *Repository) FindCoveringJob(ctx context.Context, job *Job) (*Job, error) {
+	query := `
+		SELECT job_id, job_type, sport, season_id, start_date, end_date, game_ids,
+			status, status_message, progress_current, progress_total,
+			last_error, retry_count, created_at, updated_at, started_at, completed_at,
+			dry_run, result, requests_per_minute, date_delay_ms, units_completed
+		FROM backfill_jobs
+		WHERE status IN ('queued', 'running')
+			AND sport = $1
+			AND dry_run = $2
+			AND CASE WHEN $3::varchar = 'game'
+				THEN job_type = 'game' AND game_ids @> $4::text[]
+				ELSE job_type IN ('season', 'date_range') AND start_date <= $5::date AND end_date >= $6::date
+			END
+		ORDER BY created_at
+		LIMIT 1
+	`
+
+	row := r.db.DB().QueryRowContext(ctx, query,
+		job.Sport, job.DryRun, string(job.JobType), job.GameIDs, job.StartDate, job.EndDate)
+	existing, err := scanJob(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find covering job: %w", err)
+	}
+	return existing, nil
+}
+
+// GetIdempotencyKey returns the job ID an idempotency key was recorded against
+// and the spec hash it was recorded with, or empty strings for an unused key.
+func (r *Repository) GetIdempotencyKey(ctx context.Context, key string) (jobID, requestHash string, err error) {
+	err = r.db.DB().QueryRowContext(ctx, `
+		SELECT job_id, request_hash
+		FROM backfill_idempotency_keys
+		WHERE idempotency_key = $1
+	`, key).Scan(&jobID, &requestHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("get idempotency key: %w", err)
+	}
+	return jobID, requestHash, nil
+}
+
+// SaveIdempotencyKey records the job returned for an idempotency key.
+func (r *Repository) SaveIdempotencyKey(ctx context.Context, key, jobID, requestHash string) error {
+	query := `
+		INSERT INTO backfill_idempotency_keys (idempotency_key, job_id, request_hash)
+		VALUES ($1, $2, $3)
+	`
+
+	if _, err := r.db.DB().ExecContext(ctx, query, key, jobID, requestHash); err != nil {
+		return fmt.Errorf("save idempotency key: %w", store.ConflictError(err))
+	}
+	return nil
+}
+
 // UpdateStatus updates status, message and optional error.
 func (r *Repository) UpdateStatus(ctx context.Context, jobID string, status JobStatus, message string, lastErr error) error {
 	query := `
@@ -82,6 +177,24 @@ func (r *Repository) UpdateProgress(ctx context.Context, jobID string, current,
 	return nil
 }
 
+// SaveJobCheckpoint requeues an interrupted running job, recording how many
+// leading dates or games it finished so the next worker resumes after them.
+func (r *Repository) SaveJobCheckpoint(ctx context.Context, jobID string, unitsCompleted int, message string) error {
+	query := `
+		UPDATE backfill_jobs
+		SET status = 'queued',
+			status_message = $3,
+			units_completed = $2,
+			updated_at = NOW()
+		WHERE job_id = $1 AND status = 'running'
+	`
+
+	if _, err := r.db.DB().ExecContext(ctx, query, jobID, unitsCompleted, message); err != nil {
+		return fmt.Errorf("save job checkpoint: %w", err)
+	}
+	return nil
+}
+
 // AppendEvent stores a log entry for a job.
 func (r *Repository) AppendEvent(ctx context.Context, jobID string, eventType, message string, current, total *int) error {
 	query := `
@@ -104,6 +217,16 @@ func (r *Repository) AppendEvent(ctx context.Context, jobID string, eventType, m
 	return nil
 }
 
+// SaveResult stores the JSON result (dry-run plan) for a job.
+func (r *Repository) SaveResult(ctx context.Context, jobID string, result []byte) error {
+	query := `UPDATE backfill_jobs SET result = $2, updated_at = NOW() WHERE job_id = $1`
+
+	if _, err := r.db.DB().ExecContext(ctx, query, jobID, string(result)); err != nil {
+		return fmt.Errorf("save job result: %w", err)
+	}
+	return nil
+}
+
 // ResetStuckJobs moves running jobs back to queued (used during service restarts).
 func (r *Repository) ResetStuckJobs(ctx context.Context) error {
 	_, err := r.db.DB().ExecContext(ctx, `
@@ -119,6 +242,20 @@ func (r *Repository) ResetStuckJobs(ctx context.Context) error {
 	return nil
 }
 
+// QueueDepth counts queued and running jobs.
+func (r *Repository) QueueDepth(ctx context.Context) (queued int, running int, err error) {
+	err = r.db.DB().QueryRowContext(ctx, `
+		SELECT COUNT(*) FILTER (WHERE status = 'queued'),
+			COUNT(*) FILTER (WHERE status = 'running')
+		FROM backfill_jobs
+		WHERE status IN ('queued', 'running')
+	`).Scan(&queued, &running)
+	if err != nil {
+		return 0, 0, fmt.Errorf("count queued jobs: %w", err)
+	}
+	return queued, running, nil
+}
+
 // MarkNextJobRunning atomically claims the next queued job.
 func (r *Repository) MarkNextJobRunning(ctx context.Context) (*Job, error) {
 	query := `
@@ -143,7 +280,10 @@ func (r *Repository) MarkNextJobRunning(ctx context.Context) (*Job, error) {
 			backfill_jobs.progress_current, backfill_jobs.progress_total,
 			backfill_jobs.last_error, backfill_jobs.retry_count,
 			backfill_jobs.created_at, backfill_jobs.updated_at,
-			backfill_jobs.started_at, backfill_jobs.completed_at
+			backfill_jobs.started_at, backfill_jobs.completed_at,
+			backfill_jobs.dry_run, backfill_jobs.result,
+			backfill_jobs.requests_per_minute, backfill_jobs.date_delay_ms,
+			backfill_jobs.units_completed
 	`
 
 	row := r.db.DB().QueryRowContext(ctx, query)
@@ -162,7 +302,8 @@ func (r *Repository) GetActiveJob(ctx context.Context) (*Job, error) {
 	query := `
 		SELECT job_id, job_type, sport, season_id, start_date, end_date, game_ids,
 			status, status_message, progress_current, progress_total,
-			last_error, retry_count, created_at, updated_at, started_at, completed_at
+			last_error, retry_count, created_at, updated_at, started_at, completed_at,
+			dry_run, result, requests_per_minute, date_delay_ms, units_completed
 		FROM backfill_jobs
 		WHERE status = 'running'
 		ORDER BY started_at DESC
@@ -185,7 +326,8 @@ func (r *Repository) ListRecentJobs(ctx context.Context, limit int) ([]*Job, err
 	query := `
 		SELECT job_id, job_type, sport, season_id, start_date, end_date, game_ids,
 			status, status_message, progress_current, progress_total,
-			last_error, retry_count, created_at, updated_at, started_at, completed_at
+			last_error, retry_count, created_at, updated_at, started_at, completed_at,
+			dry_run, result, requests_per_minute, date_delay_ms, units_completed
 		FROM backfill_jobs
 		ORDER BY created_at DESC
 		LIMIT $1
@@ -213,6 +355,7 @@ func scanJob(scanner interface {
 	Scan(dest ...interface{}) error
 }) (*Job, error) {
 	job := &Job{}
+	var dateDelayMs int64
 	err := scanner.Scan(
 		&job.JobID,
 		&job.JobType,
@@ -231,10 +374,16 @@ func scanJob(scanner interface {
 		&job.UpdatedAt,
 		&job.StartedAt,
 		&job.CompletedAt,
+		&job.DryRun,
+		&job.Result,
+		&job.RateLimit.RequestsPerMinute,
+		&dateDelayMs,
+		&job.UnitsCompleted,
 	)
 	if err != nil {
 		return nil, err
 	}
+	job.RateLimit.DateDelay = time.Duration(dateDelayMs) * time.Millisecond
 	return job, nil
 }
 
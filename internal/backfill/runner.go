@@ -7,12 +7,16 @@ import (
 	"time"
 
 	"github.com/fortuna/minerva/internal/ingest/espn"
+	"github.com/fortuna/minerva/internal/quality"
 	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
 )
 
 // Runner executes backfill specs using the ESPN ingester.
 type Runner struct {
 	ingester *espn.Ingester
+	gameRepo *repository.GameRepository
+	runs     *repository.IngestionRunRepository
 	db       *store.Database
 }
 
@@ -20,6 +24,8 @@ type Runner struct {
 func NewRunner(db *store.Database) *Runner {
 	return &Runner{
 		ingester: espn.NewIngester(db),
+		gameRepo: repository.NewGameRepository(db),
+		runs:     repository.NewIngestionRunRepository(db),
 		db:       db,
 	}
 }
@@ -28,24 +34,87 @@ func NewRunner(db *store.Database) *Runner {
 func NewRunnerWithBaseURL(db *store.Database, baseURL string) *Runner {
 	return &Runner{
 		ingester: espn.NewIngesterWithBaseURL(db, baseURL),
+		gameRepo: repository.NewGameRepository(db),
+		runs:     repository.NewIngestionRunRepository(db),
 		db:       db,
 	}
 }
 
+// SetValidator runs data quality rules on every final game the runner ingests.
+func (r *Runner) SetValidator(validator *quality.Validator) {
+	r.ingester.SetValidator(validator)
+}
+
 // Run executes the job spec, reporting progress via the Reporter if provided.
 func (r *Runner) Run(ctx context.Context, spec JobSpec, reporter Reporter) error {
 	if reporter != nil {
 		reporter.OnJobStart(spec)
 	}
 
+	ctx = espn.WithRequestLimiter(ctx, espn.NewRequestLimiter(spec.RateLimit.RequestsPerMinute))
+	if !spec.RateLimit.IsZero() && reporter != nil {
+		reporter.OnProgress(fmt.Sprintf("Rate limit: %d requests/min, %v between dates",
+			spec.RateLimit.RequestsPerMinute, spec.RateLimit.DateDelay), 0, 0)
+	}
+
 	if spec.DryRun {
 		if reporter != nil {
 			reporter.OnProgress("Dry-run mode: no data will be written", 0, 0)
+		}
+		report, err := r.Plan(ctx, spec, reporter)
+		if err != nil {
+			if reporter != nil {
+				reporter.OnJobError(err)
+			}
+			return fmt.Errorf("dry run: %w", err)
+		}
+		if dr, ok := reporter.(DryRunReporter); ok {
+			dr.OnDryRunReport(report)
+		}
+		if reporter != nil {
+			reporter.OnProgress(fmt.Sprintf("Dry run: %d games (%d new, %d existing)",
+				report.TotalGames, report.NewGames, report.ExistingGames), 0, 0)
 			reporter.OnJobComplete()
 		}
 		return nil
 	}
 
+	return r.recordRun(ctx, spec, func(counts *espn.WriteCounts) error {
+		return r.ingest(ctx, spec, reporter, counts)
+	})
+}
+
+// recordRun wraps a writing backfill with an ingestion_runs row.
+// Failing to record the run never fails the backfill itself.
+func (r *Runner) recordRun(ctx context.Context, spec JobSpec, ingest func(counts *espn.WriteCounts) error) error {
+	var counts espn.WriteCounts
+
+	run := &store.IngestionRun{
+		Sport:     spec.Sport,
+		Source:    store.IngestionSourceBackfill,
+		StartDate: store.NullTime{Time: spec.Start, Valid: !spec.Start.IsZero()},
+		EndDate:   store.NullTime{Time: spec.End, Valid: !spec.End.IsZero()},
+		Metadata:  store.NullString{String: fmt.Sprintf(`{"job_type": %q}`, spec.Type), Valid: true},
+	}
+	if err := r.runs.Start(ctx, run); err != nil {
+		log.Printf("[backfill] Warning: failed to record ingestion run: %v", err)
+		return ingest(&counts)
+	}
+
+	err := ingest(&counts)
+
+	run.GamesProcessed = counts.GamesChanged + counts.GamesUnchanged
+	run.RowsChanged = counts.Changed()
+	run.RowsUnchanged = counts.Unchanged()
+	if finishErr := r.runs.Finish(context.WithoutCancel(ctx), run, err); finishErr != nil {
+		log.Printf("[backfill] Warning: failed to record ingestion run: %v", finishErr)
+	}
+
+	return err
+}
+
+// ingest performs the writing portion of Run, accumulating write counts
+func (r *Runner) ingest(ctx context.Context, spec JobSpec, reporter Reporter, counts *espn.WriteCounts) error {
 	// Lookup season_id (INT) from season_year (STRING) or derive from date
 	var seasonID int
 	var err error
@@ -90,12 +159,14 @@ func (r *Runner) Run(ctx context.Context, spec JobSpec, reporter Reporter) error
 				reporter.OnProgress(fmt.Sprintf("Processing game %s (%d/%d)", gameID, idx+1, total), idx, total)
 			}
 
-			if _, err := r.ingester.IngestGameByID(ctx, seasonID, gameID); err != nil {
+			_, gameCounts, err := r.ingester.IngestGameByID(ctx, seasonID, gameID)
+			if err != nil {
 				if reporter != nil {
 					reporter.OnJobError(err)
 				}
 				return err
 			}
+			counts.Add(gameCounts)
 
 			if reporter != nil {
 				reporter.OnGameProcessed(gameID)
@@ -113,6 +184,11 @@ func (r *Runner) Run(ctx context.Context, spec JobSpec, reporter Reporter) error
 
 		total := len(dates)
 		for idx, date := range dates {
+			if idx > 0 {
+				if err := pauseBetweenDates(ctx, spec.RateLimit.DateDelay); err != nil {
+					return err
+				}
+			}
 			if err := ctx.Err(); err != nil {
 				return err
 			}
@@ -136,12 +212,17 @@ func (r *Runner) Run(ctx context.Context, spec JobSpec, reporter Reporter) error
 				}
 			}
 
-			if _, err := r.ingester.IngestGamesByDate(ctx, dateSeasonID, date); err != nil {
+			_, dateCounts, err := r.ingester.IngestGamesByDate(ctx, dateSeasonID, date)
+			if err != nil {
 				if reporter != nil {
 					reporter.OnJobError(err)
 				}
 				return err
 			}
+			counts.Add(dateCounts)
+			if dc, ok := reporter.(DateCompleteReporter); ok {
+				dc.OnDateComplete(date, dateCounts)
+			}
 
 			if reporter != nil {
 				reporter.OnProgress(fmt.Sprintf("Processed %s", date.Format("Jan 2, 2006")), idx+1, total)
@@ -151,6 +232,10 @@ func (r *Runner) Run(ctx context.Context, spec JobSpec, reporter Reporter) error
 		return fmt.Errorf("unsupported job type %s", spec.Type)
 	}
 
+	log.Printf("[backfill] Write summary: %s", counts)
+	if ws, ok := reporter.(WriteSummaryReporter); ok {
+		ws.OnWriteSummary(*counts)
+	}
 	if reporter != nil {
 		reporter.OnJobComplete()
 	}
@@ -166,7 +251,7 @@ func (r *Runner) lookupSeasonID(ctx context.Context, seasonYear string) (int, er
 
 // lookupSeasonIDWithType queries the database to get season_id by year and type
 func (r *Runner) lookupSeasonIDWithType(ctx context.Context, seasonYear, seasonType string) (int, error) {
-	query := `SELECT season_id FROM seasons WHERE season_year = $1 AND season_type = $2 AND sport = 'basketball_nba' LIMIT 1`
+	query := `SELECT season_id FROM seasons WHERE season_year = $1 AND season_type = $2 AND sport = 'basketball_nba' AND league = 'nba' LIMIT 1`
 
 	var seasonID int
 	err := r.db.DB().QueryRowContext(ctx, query, seasonYear, seasonType).Scan(&seasonID)
@@ -252,7 +337,7 @@ func (r *Runner) lookupSeasonIDByDate(ctx context.Context, date time.Time) (int,
 	query := `
 		SELECT season_id, season_year 
 		FROM seasons 
-		WHERE sport = 'basketball_nba' 
+		WHERE sport = 'basketball_nba' AND league = 'nba'
 		  AND start_date <= $1 
 		  AND end_date >= $1
 		ORDER BY start_date DESC
@@ -272,7 +357,7 @@ func (r *Runner) lookupSeasonIDByDate(ctx context.Context, date time.Time) (int,
 	fallbackQuery := `
 		SELECT season_id, season_year 
 		FROM seasons 
-		WHERE sport = 'basketball_nba'
+		WHERE sport = 'basketball_nba' AND league = 'nba'
 		  AND end_date < $1
 		ORDER BY end_date DESC
 		LIMIT 1
@@ -286,7 +371,7 @@ func (r *Runner) lookupSeasonIDByDate(ctx context.Context, date time.Time) (int,
 	futureQuery := `
 		SELECT season_id, season_year 
 		FROM seasons 
-		WHERE sport = 'basketball_nba'
+		WHERE sport = 'basketball_nba' AND league = 'nba'
 		  AND start_date > $1
 		ORDER BY start_date ASC
 		LIMIT 1
@@ -299,6 +384,21 @@ func (r *Runner) lookupSeasonIDByDate(ctx context.Context, date time.Time) (int,
 	return seasonID, seasonYear, nil
 }
 
+// pauseBetweenDates waits out a job's inter-date delay, returning early if ctx is cancelled
+func pauseBetweenDates(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func enumerateDates(start, end time.Time) []time.Time {
 	if end.Before(start) {
 		start, end = end, start
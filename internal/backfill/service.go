@@ -2,17 +2,29 @@ package backfill
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/fortuna/minerva/internal/alert"
+	"github.com/fortuna/minerva/internal/ingest/espn"
+	"github.com/fortuna/minerva/internal/quality"
 	"github.com/fortuna/minerva/internal/store"
 )
 
+// checkpointTimeout bounds saving an interrupted job's checkpoint, which runs
+// after the worker's context is already cancelled
+const checkpointTimeout = 5 * time.Second
+
 // Request represents a backfill invocation request.
 type Request struct {
 	Sport     string
@@ -21,8 +33,29 @@ type Request struct {
 	EndDate   *time.Time
 	GameIDs   []string
 	DryRun    bool
+	RateLimit RateLimit
+
+	// IdempotencyKey, when set, makes retries of the request return the job
+	// the first attempt got instead of enqueuing again
+	IdempotencyKey string
 }
 
+// ErrIdempotencyKeyReused is returned when an idempotency key is sent again
+// with a request for different work
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different backfill request")
+
+// EnqueueOutcome reports how Enqueue answered a request
+type EnqueueOutcome string
+
+const (
+	// EnqueueCreated means a new job was queued
+	EnqueueCreated EnqueueOutcome = "created"
+	// EnqueueReplayed means the idempotency key was seen before; its job was returned
+	EnqueueReplayed EnqueueOutcome = "replayed"
+	// EnqueueDuplicate means a queued or running job already covers the request
+	EnqueueDuplicate EnqueueOutcome = "duplicate"
+)
+
 // DeriveType infers the job type based on populated fields.
 func (r Request) DeriveType() (JobType, error) {
 	if len(r.GameIDs) > 0 {
@@ -43,6 +76,8 @@ type Service struct {
 	runner *Runner
 
 	historyLimit int
+	limits       Limits
+	alerts       *alert.Dispatcher
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -70,20 +105,57 @@ func NewService(db *store.Database, espnBaseURL string, logger *log.Logger) *Ser
 		repo:         NewRepository(db),
 		runner:       runner,
 		historyLimit: 10,
+		limits:       DefaultLimits(),
 		ctx:          ctx,
 		cancel:       cancel,
 		logger:       logger,
 	}
 }
 
+// SetAlerts reports failed jobs to the given dispatcher. A nil dispatcher disables alerting.
+func (s *Service) SetAlerts(alerts *alert.Dispatcher) {
+	s.alerts = alerts
+}
+
+// SetLimits bounds the date span and game count a single request may enqueue
+func (s *Service) SetLimits(limits Limits) {
+	s.limits = limits.withDefaults()
+}
+
+// SetValidator runs data quality rules on the games each job ingests.
+func (s *Service) SetValidator(validator *quality.Validator) {
+	s.runner.SetValidator(validator)
+}
+
 // Start launches the background worker loop.
 func (s *Service) Start() {
-	if err := s.repo.ResetStuckJobs(s.ctx); err != nil {
-		s.logger.Printf("failed to reset jobs: %v", err)
-	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.work(s.ctx)
+	}()
+}
+
+// Run processes the queue until ctx is cancelled or the service shuts down. Use it
+// instead of Start when workers should only run while this replica is the leader:
+// a job interrupted by ctx is left running and requeued by the next worker.
+func (s *Service) Run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(s.ctx, cancel)
+	defer stop()
 
 	s.wg.Add(1)
-	go s.worker()
+	defer s.wg.Done()
+	s.work(ctx)
+}
+
+// work requeues jobs orphaned by a previous worker, then runs the worker loop
+func (s *Service) work(ctx context.Context) {
+	if err := s.repo.ResetStuckJobs(ctx); err != nil {
+		s.logger.Printf("failed to reset jobs: %v", err)
+	}
+	s.worker(ctx)
 }
 
 // Shutdown stops workers and waits for completion.
@@ -104,15 +176,24 @@ func (s *Service) Shutdown(ctx context.Context) error {
 	}
 }
 
-// Enqueue creates a new job from the provided request.
-func (s *Service) Enqueue(ctx context.Context, req Request) (*Job, error) {
+// Enqueue creates a new job from the provided request. Requests outside the
+// service limits or the sport's seasons fail with a *ValidationError.
+//
+// A request whose idempotency key was seen before returns the job recorded
+// for it (EnqueueReplayed), or ErrIdempotencyKeyReused if it asks for
+// different work. A request whose work a queued or running job of the same
+// sport and dry-run mode already covers returns that job (EnqueueDuplicate).
+func (s *Service) Enqueue(ctx context.Context, req Request) (*Job, EnqueueOutcome, error) {
 	if req.Sport == "" {
-		req.Sport = "basketball_nba"
+		req.Sport = store.DefaultSport
 	}
 
 	jobType, err := req.DeriveType()
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	if err := req.Validate(s.limits, time.Now()); err != nil {
+		return nil, "", err
 	}
 
 	job := &Job{
@@ -121,28 +202,30 @@ func (s *Service) Enqueue(ctx context.Context, req Request) (*Job, error) {
 		Status:         JobStatusQueued,
 		StatusMessage:  sql.NullString{String: "Queued", Valid: true},
 		ProgressCurrent: 0,
+		DryRun:         req.DryRun,
+		RateLimit:      req.RateLimit,
 	}
 
 	switch jobType {
 	case JobTypeGame:
 		if len(req.GameIDs) == 0 {
-			return nil, fmt.Errorf("game job requires at least one game id")
+			return nil, "", fmt.Errorf("game job requires at least one game id")
 		}
 		job.GameIDs = req.GameIDs
 		job.SeasonID = sql.NullString{String: req.SeasonID, Valid: req.SeasonID != ""}
 		job.ProgressTotal = len(req.GameIDs)
 	case JobTypeSeason:
 		if req.SeasonID == "" {
-			return nil, fmt.Errorf("season job requires season_id")
+			return nil, "", fmt.Errorf("season job requires season_id")
 		}
-		start, end := seasonWindow(req.SeasonID)
+		start, end := SeasonWindow(req.SeasonID)
 		job.SeasonID = sql.NullString{String: req.SeasonID, Valid: true}
 		job.StartDate = sql.NullTime{Time: start, Valid: true}
 		job.EndDate = sql.NullTime{Time: end, Valid: true}
 		job.ProgressTotal = len(enumerateDates(start, end))
 	case JobTypeDateRange:
 		if req.StartDate == nil || req.EndDate == nil {
-			return nil, fmt.Errorf("date range job requires start_date and end_date")
+			return nil, "", fmt.Errorf("date range job requires start_date and end_date")
 		}
 		job.SeasonID = sql.NullString{String: req.SeasonID, Valid: req.SeasonID != ""}
 		job.StartDate = sql.NullTime{Time: truncateDate(*req.StartDate), Valid: true}
@@ -150,14 +233,73 @@ func (s *Service) Enqueue(ctx context.Context, req Request) (*Job, error) {
 		job.ProgressTotal = len(enumerateDates(job.StartDate.Time, job.EndDate.Time))
 	}
 
-	stored, err := s.repo.CreateJob(ctx, job)
+	hash := specHash(job)
+	var stored *Job
+	outcome := EnqueueCreated
+	err = s.repo.WithEnqueueLock(ctx, func() error {
+		if req.IdempotencyKey != "" {
+			jobID, keyHash, err := s.repo.GetIdempotencyKey(ctx, req.IdempotencyKey)
+			if err != nil {
+				return err
+			}
+			if jobID != "" {
+				if keyHash != hash {
+					return ErrIdempotencyKeyReused
+				}
+				outcome = EnqueueReplayed
+				stored, err = s.repo.GetJob(ctx, jobID)
+				return err
+			}
+		}
+
+		existing, err := s.repo.FindCoveringJob(ctx, job)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			outcome = EnqueueDuplicate
+			stored = existing
+		} else {
+			if stored, err = s.repo.CreateJob(ctx, job); err != nil {
+				return err
+			}
+			_ = s.repo.AppendEvent(ctx, stored.JobID, "queued", "Job queued", nil, nil)
+		}
+
+		if req.IdempotencyKey != "" {
+			return s.repo.SaveIdempotencyKey(ctx, req.IdempotencyKey, stored.JobID, hash)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	_ = s.repo.AppendEvent(ctx, stored.JobID, "queued", "Job queued", nil, nil)
+	if outcome == EnqueueDuplicate {
+		s.logger.Printf("request for %s job matches queued job %s; not enqueuing", job.JobType, stored.JobID)
+	}
+	return stored, outcome, nil
+}
 
-	return stored, nil
+// specHash digests the work a job was asked to do, so a reused idempotency
+// key can be checked against the request it was first sent with
+func specHash(job *Job) string {
+	gameIDs := append([]string(nil), job.GameIDs...)
+	sort.Strings(gameIDs)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%s|%s|%s|%t|%d|%d",
+		job.JobType, job.Sport, job.SeasonID.String,
+		nullDateKey(job.StartDate), nullDateKey(job.EndDate), strings.Join(gameIDs, ","),
+		job.DryRun, job.RateLimit.RequestsPerMinute, job.RateLimit.DateDelay.Milliseconds())
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func nullDateKey(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format("2006-01-02")
 }
 
 // GetStatus returns the currently running job plus recent history.
@@ -178,18 +320,35 @@ func (s *Service) GetStatus(ctx context.Context) (*StatusSummary, error) {
 	}, nil
 }
 
-func (s *Service) worker() {
-	defer s.wg.Done()
+// QueueDepth returns how many jobs are waiting and running.
+func (s *Service) QueueDepth(ctx context.Context) (queued int, running int, err error) {
+	return s.repo.QueueDepth(ctx)
+}
+
+func (s *Service) alertFailure(job *Job, err error) {
+	s.alerts.Fire(s.ctx, alert.Alert{
+		Key:      "backfill.job:" + job.JobID,
+		Source:   "backfill",
+		Severity: alert.SeverityWarning,
+		Title:    fmt.Sprintf("Backfill job %s failed", job.JobID),
+		Message:  err.Error(),
+		Fields: map[string]string{
+			"sport":    job.Sport,
+			"job_type": string(job.JobType),
+		},
+	})
+}
 
+func (s *Service) worker(ctx context.Context) {
 	ticker := time.NewTicker(3 * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-s.ctx.Done():
+		case <-ctx.Done():
 			return
 		default:
-			job, err := s.repo.MarkNextJobRunning(s.ctx)
+			job, err := s.repo.MarkNextJobRunning(ctx)
 			if err != nil {
 				s.logger.Printf("claim job error: %v", err)
 				time.Sleep(time.Second)
@@ -197,50 +356,84 @@ func (s *Service) worker() {
 			}
 			if job == nil {
 				select {
-				case <-s.ctx.Done():
+				case <-ctx.Done():
 					return
 				case <-ticker.C:
 					continue
 				}
 			}
 
-			s.executeJob(job)
+			s.executeJob(ctx, job)
 		}
 	}
 }
 
-func (s *Service) executeJob(job *Job) {
+func (s *Service) executeJob(ctx context.Context, job *Job) {
 	spec, err := s.buildSpec(job)
 	if err != nil {
 		s.logger.Printf("invalid job spec %s: %v", job.JobID, err)
-		_ = s.repo.UpdateStatus(s.ctx, job.JobID, JobStatusFailed, "Invalid job specification", err)
+		_ = s.repo.UpdateStatus(ctx, job.JobID, JobStatusFailed, "Invalid job specification", err)
+		s.alertFailure(job, err)
 		return
 	}
 
 	reporter := &jobReporter{
-		ctx:   s.ctx,
-		repo:  s.repo,
-		jobID: job.JobID,
-		total: specProgressUnits(spec),
+		ctx:    ctx,
+		repo:   s.repo,
+		jobID:  job.JobID,
+		total:  specProgressUnits(spec) + job.UnitsCompleted,
+		offset: job.UnitsCompleted,
+	}
+	if job.UnitsCompleted > 0 {
+		if reporter.total == reporter.offset {
+			// Interrupted after its last unit but before it was marked complete
+			_ = s.repo.UpdateStatus(ctx, job.JobID, JobStatusCompleted, "Job completed", nil)
+			return
+		}
+		s.logger.Printf("job %s resuming after %d of %d units", job.JobID, job.UnitsCompleted, reporter.total)
 	}
 
 	if job.ProgressTotal == 0 {
-		_ = s.repo.UpdateProgress(s.ctx, job.JobID, 0, reporter.total, "Starting job...")
+		_ = s.repo.UpdateProgress(ctx, job.JobID, 0, reporter.total, "Starting job...")
 	}
 
-	if err := s.runner.Run(s.ctx, spec, reporter); err != nil {
-		_ = s.repo.UpdateStatus(s.ctx, job.JobID, JobStatusFailed, "Job failed", err)
+	if err := s.runner.Run(ctx, spec, reporter); err != nil {
+		if ctx.Err() != nil {
+			s.checkpoint(job, reporter)
+			return
+		}
+		_ = s.repo.UpdateStatus(ctx, job.JobID, JobStatusFailed, "Job failed", err)
+		s.alertFailure(job, err)
 		return
 	}
 
-	_ = s.repo.UpdateStatus(s.ctx, job.JobID, JobStatusCompleted, "Job completed", nil)
+	_ = s.repo.UpdateStatus(ctx, job.JobID, JobStatusCompleted, "Job completed", nil)
+}
+
+// checkpoint requeues a job interrupted by shutdown or lost leadership, so the
+// next worker resumes after the dates or games it finished instead of starting over
+func (s *Service) checkpoint(job *Job, reporter *jobReporter) {
+	ctx, cancel := context.WithTimeout(context.Background(), checkpointTimeout)
+	defer cancel()
+
+	units := reporter.offset + reporter.completed
+	message := fmt.Sprintf("Interrupted after %d of %d; resumes when a worker restarts", units, reporter.total)
+	if err := s.repo.SaveJobCheckpoint(ctx, job.JobID, units, message); err != nil {
+		s.logger.Printf("job %s interrupted; checkpoint failed, it will be requeued from its last checkpoint: %v", job.JobID, err)
+		return
+	}
+	s.logger.Printf("job %s interrupted after %d of %d units; checkpointed and requeued", job.JobID, units, reporter.total)
 }
 
+// buildSpec turns a stored job into a runner spec, skipping the dates or games
+// finished before the job was last interrupted
 func (s *Service) buildSpec(job *Job) (JobSpec, error) {
 	spec := JobSpec{
-		Type:     job.JobType,
-		Sport:    job.Sport,
-		SeasonID: job.SeasonID.String,
+		Type:      job.JobType,
+		Sport:     job.Sport,
+		SeasonID:  job.SeasonID.String,
+		DryRun:    job.DryRun,
+		RateLimit: job.RateLimit,
 	}
 
 	switch job.JobType {
@@ -248,12 +441,12 @@ func (s *Service) buildSpec(job *Job) (JobSpec, error) {
 		if len(job.GameIDs) == 0 {
 			return spec, fmt.Errorf("game job missing game_ids")
 		}
-		spec.GameIDs = job.GameIDs
+		spec.GameIDs = job.GameIDs[min(job.UnitsCompleted, len(job.GameIDs)):]
 	case JobTypeSeason, JobTypeDateRange:
 		if !job.StartDate.Valid || !job.EndDate.Valid {
 			return spec, fmt.Errorf("job missing start/end dates")
 		}
-		spec.Start = job.StartDate.Time
+		spec.Start = job.StartDate.Time.AddDate(0, 0, job.UnitsCompleted)
 		spec.End = job.EndDate.Time
 	default:
 		return spec, fmt.Errorf("unknown job type %s", job.JobType)
@@ -267,30 +460,52 @@ type jobReporter struct {
 	repo  *Repository
 	jobID string
 	total int
+
+	// Units finished before the job was last interrupted; the runner counts
+	// from zero on a resumed job, so its positions are shifted by this much
+	offset int
+	// Units finished in this run
+	completed int
 }
 
 func (r *jobReporter) OnJobStart(spec JobSpec) {
 	if r.total == 0 {
 		r.total = specProgressUnits(spec)
 	}
-	_ = r.repo.UpdateProgress(r.ctx, r.jobID, 0, r.total, "Job starting")
+	_ = r.repo.UpdateProgress(r.ctx, r.jobID, r.offset, r.total, "Job starting")
 }
 
 func (r *jobReporter) OnDateStart(date time.Time, index int, total int) {
-	msg := fmt.Sprintf("Processing %s (%d/%d)", date.Format("Jan 2, 2006"), index+1, total)
-	cur := index
-	if index == 0 {
-		cur = 0
-	}
-	_ = r.repo.UpdateProgress(r.ctx, r.jobID, cur, valueOr(total, r.total), msg)
+	cur, total := r.position(index, total)
+	msg := fmt.Sprintf("Processing %s (%d/%d)", date.Format("Jan 2, 2006"), cur+1, total)
+	_ = r.repo.UpdateProgress(r.ctx, r.jobID, cur, total, msg)
+}
+
+func (r *jobReporter) OnDateComplete(date time.Time, counts espn.WriteCounts) {
+	r.completed++
+}
+
+func (r *jobReporter) OnWriteSummary(counts espn.WriteCounts) {
+	_ = r.repo.AppendEvent(r.ctx, r.jobID, "completed",
+		fmt.Sprintf("Rows changed: %d, unchanged: %d (%s)", counts.Changed(), counts.Unchanged(), counts), nil, nil)
 }
 
 func (r *jobReporter) OnGameProcessed(gameID string) {
+	r.completed++
 	_ = r.repo.AppendEvent(r.ctx, r.jobID, "game", fmt.Sprintf("Game %s processed", gameID), nil, nil)
 }
 
 func (r *jobReporter) OnProgress(message string, current int, total int) {
-	_ = r.repo.UpdateProgress(r.ctx, r.jobID, current, valueOr(total, r.total), message)
+	current, total = r.position(current, total)
+	_ = r.repo.UpdateProgress(r.ctx, r.jobID, current, total, message)
+}
+
+// position shifts the runner's progress past units finished before a restart
+func (r *jobReporter) position(current, total int) (int, int) {
+	if total == 0 {
+		return current + r.offset, r.total
+	}
+	return current + r.offset, total + r.offset
 }
 
 func (r *jobReporter) OnJobComplete() {
@@ -301,6 +516,17 @@ func (r *jobReporter) OnJobError(err error) {
 	_ = r.repo.AppendEvent(r.ctx, r.jobID, "error", err.Error(), nil, nil)
 }
 
+func (r *jobReporter) OnDryRunReport(report *DryRunReport) {
+	payload, err := json.Marshal(report)
+	if err != nil {
+		_ = r.repo.AppendEvent(r.ctx, r.jobID, "error", fmt.Sprintf("encode dry-run report: %v", err), nil, nil)
+		return
+	}
+	_ = r.repo.SaveResult(r.ctx, r.jobID, payload)
+	_ = r.repo.AppendEvent(r.ctx, r.jobID, "dry_run",
+		fmt.Sprintf("Dry run: %d games (%d new, %d existing)", report.TotalGames, report.NewGames, report.ExistingGames), nil, nil)
+}
+
 func specProgressUnits(spec JobSpec) int {
 	switch spec.Type {
 	case JobTypeGame:
@@ -319,7 +545,9 @@ func valueOr(val, fallback int) int {
 	return fallback
 }
 
-func seasonWindow(seasonID string) (time.Time, time.Time) {
+// SeasonWindow returns the October-to-July date window covering an NBA season
+// identified as "2024-25" or "2024".
+func SeasonWindow(seasonID string) (time.Time, time.Time) {
 	parts := strings.Split(seasonID, "-")
 	if len(parts) != 2 {
 		year, _ := strconv.Atoi(seasonID)
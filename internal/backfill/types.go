@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/fortuna/minerva/internal/ingest/espn"
 	"github.com/lib/pq"
 )
 
@@ -46,6 +47,10 @@ type Job struct {
 	UpdatedAt      time.Time
 	StartedAt      sql.NullTime
 	CompletedAt    sql.NullTime
+	DryRun         bool
+	Result         sql.NullString // JSON dry-run plan
+	RateLimit      RateLimit
+	UnitsCompleted int // leading dates or game IDs finished before an interruption
 }
 
 // Copy returns a shallow copy to prevent external mutation.
@@ -66,6 +71,20 @@ type JobSpec struct {
 	End      time.Time
 	GameIDs  []string
 	DryRun   bool
+
+	RateLimit RateLimit
+}
+
+// RateLimit throttles a job's ESPN traffic. Zero values mean no limit, which suits
+// small patch jobs; season-sized backfills should set both to avoid ESPN throttling.
+type RateLimit struct {
+	RequestsPerMinute int           // ESPN requests per minute across the whole job
+	DateDelay         time.Duration // pause between scoreboard dates
+}
+
+// IsZero reports whether the job runs unthrottled
+func (l RateLimit) IsZero() bool {
+	return l.RequestsPerMinute <= 0 && l.DateDelay <= 0
 }
 
 // Reporter receives lifecycle callbacks from the runner.
@@ -78,6 +97,53 @@ type Reporter interface {
 	OnJobError(err error)
 }
 
+// DryRunReporter is implemented by reporters that want the dry-run plan.
+// It is optional so existing reporters keep working unchanged.
+type DryRunReporter interface {
+	OnDryRunReport(report *DryRunReport)
+}
+
+// DateCompleteReporter is implemented by reporters that checkpoint per date.
+// OnDateComplete runs after each scoreboard date of a season or date-range job is ingested.
+type DateCompleteReporter interface {
+	OnDateComplete(date time.Time, counts espn.WriteCounts)
+}
+
+// WriteSummaryReporter is implemented by reporters that record what a job wrote.
+// OnWriteSummary runs once, just before OnJobComplete.
+type WriteSummaryReporter interface {
+	OnWriteSummary(counts espn.WriteCounts)
+}
+
+// DryRunReport summarises what a backfill would write without writing it.
+type DryRunReport struct {
+	Dates         []DryRunDate `json:"dates"`
+	TotalGames    int          `json:"total_games"`
+	NewGames      int          `json:"new_games"`
+	ExistingGames int          `json:"existing_games"`
+	FailedDates   int          `json:"failed_dates"`
+}
+
+// DryRunDate is the plan for a single scoreboard date (or the game list for game jobs).
+type DryRunDate struct {
+	Date            string   `json:"date,omitempty"`
+	Games           int      `json:"games"`
+	NewGameIDs      []string `json:"new_game_ids,omitempty"`
+	ExistingGameIDs []string `json:"existing_game_ids,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// add folds a date into the report totals.
+func (r *DryRunReport) add(d DryRunDate) {
+	r.Dates = append(r.Dates, d)
+	r.TotalGames += d.Games
+	r.NewGames += len(d.NewGameIDs)
+	r.ExistingGames += len(d.ExistingGameIDs)
+	if d.Error != "" {
+		r.FailedDates++
+	}
+}
+
 // StatusSummary is returned to API callers.
 type StatusSummary struct {
 	ActiveJob *Job   `json:"active_job,omitempty"`
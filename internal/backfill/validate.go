@@ -0,0 +1,143 @@
+package backfill
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// Limits bound what a single backfill request may enqueue
+type Limits struct {
+	MaxDateSpanDays int // longest start_date..end_date range, inclusive
+	MaxGameIDs      int // game IDs per game job
+}
+
+// DefaultLimits allow a little over one season per request
+func DefaultLimits() Limits {
+	return Limits{
+		MaxDateSpanDays: 366,
+		MaxGameIDs:      500,
+	}
+}
+
+// withDefaults fills unset limits from DefaultLimits
+func (l Limits) withDefaults() Limits {
+	defaults := DefaultLimits()
+	if l.MaxDateSpanDays <= 0 {
+		l.MaxDateSpanDays = defaults.MaxDateSpanDays
+	}
+	if l.MaxGameIDs <= 0 {
+		l.MaxGameIDs = defaults.MaxGameIDs
+	}
+	return l
+}
+
+// firstSeasonYear is the start year of each sport's earliest season; dates
+// before it cannot have games
+var firstSeasonYear = map[string]int{
+	store.DefaultSport: 1946,
+}
+
+// ValidationError reports a well-formed request whose values are out of bounds
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// IsValidationError reports whether err came from request validation
+func IsValidationError(err error) bool {
+	var verr *ValidationError
+	return errors.As(err, &verr)
+}
+
+func invalid(field, format string, args ...interface{}) error {
+	return &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+var (
+	seasonIDPattern = regexp.MustCompile(`^(\d{4})(?:-(\d{2}))?$`)
+	gameIDPattern   = regexp.MustCompile(`^\d{1,12}$`)
+)
+
+// Validate checks req against limits and the sport's known seasons. now bounds
+// how far into the future a request may reach: the end of the current season.
+func (req Request) Validate(limits Limits, now time.Time) error {
+	limits = limits.withDefaults()
+
+	sport := req.Sport
+	if sport == "" {
+		sport = store.DefaultSport
+	}
+	if !store.ValidSport(sport) {
+		return invalid("sport", "unsupported sport %q", sport)
+	}
+
+	// Seasons run October to June, so the current season started last year until October
+	currentYear := now.Year()
+	if now.Month() < time.October {
+		currentYear--
+	}
+	firstYear := firstSeasonYear[sport]
+	earliest, _ := SeasonWindow(strconv.Itoa(firstYear))
+	_, latest := SeasonWindow(strconv.Itoa(currentYear))
+
+	if req.SeasonID != "" {
+		m := seasonIDPattern.FindStringSubmatch(req.SeasonID)
+		if m == nil {
+			return invalid("season_id", "must look like 2024-25 or 2024")
+		}
+		year, _ := strconv.Atoi(m[1])
+		if m[2] != "" && m[2] != fmt.Sprintf("%02d", (year+1)%100) {
+			return invalid("season_id", "%s does not span consecutive years", req.SeasonID)
+		}
+		if year < firstYear || year > currentYear {
+			return invalid("season_id", "must be between %d and %d", firstYear, currentYear)
+		}
+	}
+
+	if len(req.GameIDs) > limits.MaxGameIDs {
+		return invalid("game_ids", "at most %d game IDs per request, got %d", limits.MaxGameIDs, len(req.GameIDs))
+	}
+	seen := make(map[string]bool, len(req.GameIDs))
+	for _, id := range req.GameIDs {
+		if !gameIDPattern.MatchString(id) {
+			return invalid("game_ids", "%q is not an ESPN game ID", id)
+		}
+		if seen[id] {
+			return invalid("game_ids", "%s is listed more than once", id)
+		}
+		seen[id] = true
+	}
+
+	if (req.StartDate == nil) != (req.EndDate == nil) {
+		return invalid("start_date", "start_date and end_date must be given together")
+	}
+	if req.StartDate != nil && req.EndDate != nil {
+		start, end := truncateDate(*req.StartDate), truncateDate(*req.EndDate)
+		if end.Before(start) {
+			return invalid("end_date", "must not be before start_date")
+		}
+		if start.Before(earliest) {
+			return invalid("start_date", "must be on or after %s", earliest.Format("2006-01-02"))
+		}
+		if end.After(latest) {
+			return invalid("end_date", "must be on or before %s, the end of the current season", latest.Format("2006-01-02"))
+		}
+		if days := int(end.Sub(start).Hours()/24) + 1; days > limits.MaxDateSpanDays {
+			return invalid("end_date", "range covers %d days, the limit is %d; split it into several requests", days, limits.MaxDateSpanDays)
+		}
+	}
+
+	if req.RateLimit.RequestsPerMinute < 0 || req.RateLimit.DateDelay < 0 {
+		return invalid("requests_per_minute", "rate limits must not be negative")
+	}
+	return nil
+}
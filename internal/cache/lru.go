@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is a size-bounded in-process cache whose entries also expire after a TTL.
+// It is safe for concurrent use. A zero or negative TTL keeps entries until
+// they are evicted.
+type LRU[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List // front is most recently used
+	entries map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// NewLRU creates a cache holding at most size entries (minimum 1)
+func NewLRU[K comparable, V any](size int, ttl time.Duration) *LRU[K, V] {
+	if size < 1 {
+		size = 1
+	}
+	return &LRU[K, V]{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[K]*list.Element, size),
+	}
+}
+
+// Get returns the cached value for key, if present and not expired
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*lruEntry[K, V])
+	if c.ttl > 0 && time.Now().After(entry.expiresAt) {
+		c.removeElement(elem)
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set stores value under key, evicting the least recently used entry when full
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruEntry[K, V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Delete removes key from the cache
+func (c *LRU[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+// Purge removes every entry
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = make(map[K]*list.Element, c.size)
+}
+
+// Len returns the number of cached entries, including expired ones not yet evicted
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU[K, V]) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*lruEntry[K, V]).key)
+}
@@ -0,0 +1,119 @@
+// Package bref fetches and parses final box scores from Basketball-Reference.
+// It is a validation source only: nothing it returns is written to the
+// database, it is compared against what the ESPN ingester stored.
+package bref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/fortuna/minerva/internal/ingest/espn"
+)
+
+const (
+	BaseURL = "https://www.basketball-reference.com"
+
+	// UserAgent identifies requests as a regular browser; the site rejects
+	// obvious bots
+	UserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+	// DefaultRequestsPerMinute keeps well under the site's published limit of
+	// 20 requests per minute, past which it blocks the client for an hour
+	DefaultRequestsPerMinute = 15
+)
+
+// maxPageBytes bounds how much of a box score page is read
+const maxPageBytes = 5 << 20
+
+var (
+	// ErrNotFound is returned when there is no box score at the requested URL,
+	// usually a wrong date or home team
+	ErrNotFound = errors.New("box score not found")
+
+	// ErrRateLimited is returned when the site answers 429; back off for an hour
+	ErrRateLimited = errors.New("rate limited by basketball-reference")
+)
+
+// Client fetches Basketball-Reference pages, spaced by a request limiter
+type Client struct {
+	baseURL string
+	http    *http.Client
+	limiter *espn.RequestLimiter
+}
+
+// New creates a client for baseURL (BaseURL when empty) making at most
+// perMinute requests per minute (DefaultRequestsPerMinute when not positive)
+func New(baseURL string, perMinute int) *Client {
+	if baseURL == "" {
+		baseURL = BaseURL
+	}
+	if perMinute <= 0 {
+		perMinute = DefaultRequestsPerMinute
+	}
+	return &Client{
+		baseURL: baseURL,
+		http:    &http.Client{Timeout: 20 * time.Second},
+		limiter: espn.NewRequestLimiter(perMinute),
+	}
+}
+
+// BoxScoreURL is the page of the game played on date (local) at homeCode's arena
+func (c *Client) BoxScoreURL(date time.Time, homeCode string) string {
+	return fmt.Sprintf("%s/boxscores/%s0%s.html", c.baseURL, date.Format("20060102"), homeCode)
+}
+
+// FetchBoxScore fetches and parses the box score of the game played on date
+// at homeCode, a Basketball-Reference team code (see TeamCode)
+func (c *Client) FetchBoxScore(ctx context.Context, date time.Time, homeCode string) (*BoxScore, error) {
+	url := c.BoxScoreURL(date, homeCode)
+	page, err := c.fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	box, err := ParseBoxScore(page)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", url, err)
+	}
+	box.URL = url
+	return box, nil
+}
+
+func (c *Client) fetch(ctx context.Context, url string) (string, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", fmt.Errorf("%w: %s", ErrNotFound, url)
+	case http.StatusTooManyRequests:
+		return "", ErrRateLimited
+	default:
+		return "", fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return "", fmt.Errorf("reading response: %w", err)
+	}
+	return string(body), nil
+}
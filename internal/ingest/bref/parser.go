@@ -0,0 +1,261 @@
+package bref
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// BoxScore is the basic box score of one game, teams in page order (away
+// first, then home)
+type BoxScore struct {
+	URL   string
+	Teams []*TeamBox
+}
+
+// Team returns the team box for a Basketball-Reference code, or nil
+func (b *BoxScore) Team(code string) *TeamBox {
+	for _, team := range b.Teams {
+		if team.Code == code {
+			return team
+		}
+	}
+	return nil
+}
+
+// TeamBox is one team's player lines and totals
+type TeamBox struct {
+	Code    string
+	Players []PlayerLine
+	Totals  Stats
+}
+
+// PlayerLine is one player's row. Players who did not play have Played false
+// and zero stats.
+type PlayerLine struct {
+	Name     string
+	PlayerID string // Basketball-Reference slug, e.g. "tatumja01"
+	Starter  bool
+	Played   bool
+	Seconds  int
+	Stats
+}
+
+// Stats are the counting stats of a row, named as in store.TeamGameStats
+type Stats struct {
+	Points                 int
+	FieldGoalsMade         int
+	FieldGoalsAttempted    int
+	ThreePointersMade      int
+	ThreePointersAttempted int
+	FreeThrowsMade         int
+	FreeThrowsAttempted    int
+	OffensiveRebounds      int
+	DefensiveRebounds      int
+	Rebounds               int
+	Assists                int
+	Steals                 int
+	Blocks                 int
+	Turnovers              int
+	PersonalFouls          int
+}
+
+var (
+	basicTableID = regexp.MustCompile(`^box-([A-Z]{3})-game-basic$`)
+	playerSlug   = regexp.MustCompile(`/players/[a-z]/([a-z0-9]+)\.html`)
+)
+
+// ParseBoxScore parses the basic box score tables of a box score page
+func ParseBoxScore(page string) (*BoxScore, error) {
+	// Some tables ship inside HTML comments and are revealed by script
+	page = strings.NewReplacer("<!--", "", "-->", "").Replace(page)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return nil, err
+	}
+
+	box := &BoxScore{}
+	var parseErr error
+	doc.Find("table[id$='-game-basic']").EachWithBreak(func(_ int, table *goquery.Selection) bool {
+		id, _ := table.Attr("id")
+		match := basicTableID.FindStringSubmatch(id)
+		if match == nil {
+			return true
+		}
+		team, err := parseTeamTable(match[1], table)
+		if err != nil {
+			parseErr = fmt.Errorf("table %s: %w", id, err)
+			return false
+		}
+		box.Teams = append(box.Teams, team)
+		return true
+	})
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	if len(box.Teams) != 2 {
+		return nil, fmt.Errorf("found %d basic box score tables, want 2", len(box.Teams))
+	}
+	return box, nil
+}
+
+func parseTeamTable(code string, table *goquery.Selection) (*TeamBox, error) {
+	team := &TeamBox{Code: code}
+
+	// Starters come first; a header row ("Reserves") separates the bench
+	starters := true
+	var rowErr error
+	table.Find("tbody tr").EachWithBreak(func(_ int, row *goquery.Selection) bool {
+		if row.HasClass("thead") {
+			starters = false
+			return true
+		}
+		nameCell := row.Find("th[data-stat='player']")
+		if nameCell.Length() == 0 {
+			return true
+		}
+
+		line := PlayerLine{
+			Name:    strings.TrimSpace(nameCell.Text()),
+			Starter: starters,
+		}
+		if href, ok := nameCell.Find("a").Attr("href"); ok {
+			if m := playerSlug.FindStringSubmatch(href); m != nil {
+				line.PlayerID = m[1]
+			}
+		}
+
+		// "Did Not Play", "Not With Team", ... replace the stat cells
+		if row.Find("td[data-stat='reason']").Length() > 0 {
+			team.Players = append(team.Players, line)
+			return true
+		}
+
+		line.Played = true
+		line.Seconds = parseMinutes(cell(row, "mp"))
+		stats, err := parseStats(row)
+		if err != nil {
+			rowErr = fmt.Errorf("player %s: %w", line.Name, err)
+			return false
+		}
+		line.Stats = stats
+		team.Players = append(team.Players, line)
+		return true
+	})
+	if rowErr != nil {
+		return nil, rowErr
+	}
+
+	totals := table.Find("tfoot tr").First()
+	if totals.Length() == 0 {
+		return nil, fmt.Errorf("no team totals row")
+	}
+	stats, err := parseStats(totals)
+	if err != nil {
+		return nil, fmt.Errorf("team totals: %w", err)
+	}
+	team.Totals = stats
+	return team, nil
+}
+
+// statColumns maps data-stat attributes to the Stats field they fill
+var statColumns = []struct {
+	name  string
+	field func(*Stats) *int
+}{
+	{"pts", func(s *Stats) *int { return &s.Points }},
+	{"fg", func(s *Stats) *int { return &s.FieldGoalsMade }},
+	{"fga", func(s *Stats) *int { return &s.FieldGoalsAttempted }},
+	{"fg3", func(s *Stats) *int { return &s.ThreePointersMade }},
+	{"fg3a", func(s *Stats) *int { return &s.ThreePointersAttempted }},
+	{"ft", func(s *Stats) *int { return &s.FreeThrowsMade }},
+	{"fta", func(s *Stats) *int { return &s.FreeThrowsAttempted }},
+	{"orb", func(s *Stats) *int { return &s.OffensiveRebounds }},
+	{"drb", func(s *Stats) *int { return &s.DefensiveRebounds }},
+	{"trb", func(s *Stats) *int { return &s.Rebounds }},
+	{"ast", func(s *Stats) *int { return &s.Assists }},
+	{"stl", func(s *Stats) *int { return &s.Steals }},
+	{"blk", func(s *Stats) *int { return &s.Blocks }},
+	{"tov", func(s *Stats) *int { return &s.Turnovers }},
+	{"pf", func(s *Stats) *int { return &s.PersonalFouls }},
+}
+
+func parseStats(row *goquery.Selection) (Stats, error) {
+	var stats Stats
+	for _, col := range statColumns {
+		text := cell(row, col.name)
+		if text == "" {
+			continue
+		}
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return stats, fmt.Errorf("%s: %q is not a number", col.name, text)
+		}
+		*col.field(&stats) = n
+	}
+	return stats, nil
+}
+
+func cell(row *goquery.Selection, stat string) string {
+	return strings.TrimSpace(row.Find(fmt.Sprintf("td[data-stat='%s']", stat)).Text())
+}
+
+// parseMinutes converts "MM:SS" to seconds, 0 when malformed
+func parseMinutes(mp string) int {
+	minutes, seconds, ok := strings.Cut(mp, ":")
+	if !ok {
+		return 0
+	}
+	m, err1 := strconv.Atoi(minutes)
+	s, err2 := strconv.Atoi(seconds)
+	if err1 != nil || err2 != nil {
+		return 0
+	}
+	return m*60 + s
+}
+
+// TeamCode converts a stored team abbreviation to the code Basketball-Reference
+// used for that franchise on date. It differs for a few teams and for
+// franchises that have been renamed.
+func TeamCode(abbr string, date time.Time) string {
+	season := date.Year() // season start year, e.g. 2024 for 2024-25
+	if date.Month() < time.August {
+		season--
+	}
+
+	switch strings.ToUpper(abbr) {
+	case "BKN", "BRK":
+		if season < 2012 {
+			return "NJN"
+		}
+		return "BRK"
+	case "CHA", "CHO":
+		if season < 2014 {
+			return "CHA"
+		}
+		return "CHO"
+	case "NOP", "NO":
+		if season < 2013 {
+			return "NOH"
+		}
+		return "NOP"
+	case "PHX", "PHO":
+		return "PHO"
+	case "GS":
+		return "GSW"
+	case "NY":
+		return "NYK"
+	case "SA":
+		return "SAS"
+	case "UTAH":
+		return "UTA"
+	case "WSH":
+		return "WAS"
+	}
+	return strings.ToUpper(abbr)
+}
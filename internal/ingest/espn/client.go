@@ -12,6 +12,9 @@ import (
 const (
 	BaseURL       = "https://site.api.espn.com/apis/site/v2/sports"
 	BasketballNBA = "basketball/nba"
+
+	// AthleteBaseURL serves athlete profiles, which the site API does not
+	AthleteBaseURL = "https://site.web.api.espn.com/apis/common/v3/sports"
 )
 
 // Client handles ESPN API requests
@@ -58,9 +61,52 @@ func (c *Client) FetchGameSummary(ctx context.Context, sportPath string, gameID
 	return c.fetch(ctx, url)
 }
 
+// FetchTeamRoster fetches a team's current roster by ESPN team ID
+func (c *Client) FetchTeamRoster(ctx context.Context, sportPath string, teamID string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/%s/teams/%s/roster", c.baseURL, sportPath, teamID)
+	return c.fetch(ctx, url)
+}
+
+// FetchTransactions fetches the league's recent roster transactions
+func (c *Client) FetchTransactions(ctx context.Context, sportPath string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/%s/transactions", c.baseURL, sportPath)
+	return c.fetch(ctx, url)
+}
+
+// FetchNews fetches the newest articles of the league's news feed, or of one
+// team's feed when espnTeamID is set
+func (c *Client) FetchNews(ctx context.Context, sportPath string, espnTeamID string, limit int) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/%s/news?limit=%d", c.baseURL, sportPath, limit)
+	if espnTeamID != "" {
+		url += "&team=" + espnTeamID
+	}
+	return c.fetch(ctx, url)
+}
+
+// FetchDraft fetches every pick of a draft year
+func (c *Client) FetchDraft(ctx context.Context, sportPath string, year int) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/%s/draft?season=%d", c.baseURL, sportPath, year)
+	return c.fetch(ctx, url)
+}
+
+// FetchAthlete fetches an athlete's profile (bio, birthplace, college). A
+// client with a custom base URL reads profiles from it too.
+func (c *Client) FetchAthlete(ctx context.Context, sportPath string, athleteID string) (map[string]interface{}, error) {
+	base := c.baseURL
+	if base == BaseURL {
+		base = AthleteBaseURL
+	}
+	url := fmt.Sprintf("%s/%s/athletes/%s", base, sportPath, athleteID)
+	return c.fetch(ctx, url)
+}
+
 // fetch makes an HTTP GET request using curl
 // ESPN blocks Go's HTTP client but curl works reliably
 func (c *Client) fetch(ctx context.Context, url string) (map[string]interface{}, error) {
+	if err := waitForRequest(ctx); err != nil {
+		return nil, err
+	}
+
 	cmd := exec.CommandContext(ctx, "curl", "-s", "-L", "-m", "15", url)
 	
 	// Debug: log the command being run
@@ -0,0 +1,86 @@
+package espn
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// ParsedCoach is the head coach listed in a team roster response
+type ParsedCoach struct {
+	ESPNCoachID string
+	FirstName   string
+	LastName    string
+}
+
+// FullName joins the coach's first and last names
+func (c *ParsedCoach) FullName() string {
+	return strings.TrimSpace(c.FirstName + " " + c.LastName)
+}
+
+// ParseCoach reads the head coach from a team roster response. ESPN lists the
+// head coach first in "coach"; nil means the response names none.
+func ParseCoach(rosterData map[string]interface{}) *ParsedCoach {
+	for _, raw := range extractArray(rosterData, "coach") {
+		coach, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		parsed := &ParsedCoach{
+			ESPNCoachID: extractString(coach, "id"),
+			FirstName:   extractString(coach, "firstName"),
+			LastName:    extractString(coach, "lastName"),
+		}
+		if parsed.ESPNCoachID == "" || parsed.FullName() == "" {
+			continue
+		}
+		return parsed
+	}
+	return nil
+}
+
+// recordHeadCoach stores the coach and makes them the team's head coach as of
+// asOf. It returns a coach.changed event when they replace another coach, and
+// nil when they were already the head coach or the team had none on record.
+func (i *Ingester) recordHeadCoach(ctx context.Context, team *store.Team, parsed *ParsedCoach, seasonID int, asOf time.Time) (*publisher.CoachChangeEvent, error) {
+	coach := &store.Coach{
+		Sport:      team.Sport,
+		ExternalID: parsed.ESPNCoachID,
+		FirstName:  store.NullString{String: parsed.FirstName, Valid: parsed.FirstName != ""},
+		LastName:   store.NullString{String: parsed.LastName, Valid: parsed.LastName != ""},
+		FullName:   parsed.FullName(),
+	}
+	if err := i.coachRepo.Upsert(ctx, coach); err != nil {
+		return nil, err
+	}
+
+	previous, changed, err := i.coachRepo.SetHeadCoach(ctx, team.TeamID, coach.CoachID, seasonID, asOf)
+	if err != nil || !changed || previous == nil {
+		return nil, err
+	}
+
+	return &publisher.CoachChangeEvent{
+		Type:  publisher.CoachChanged,
+		Sport: team.Sport,
+		Team: publisher.GameEventTeam{
+			TeamID:       team.TeamID,
+			ESPNID:       team.ExternalID,
+			Abbreviation: team.Abbreviation,
+		},
+		PreviousCoach: publisher.EventCoach{
+			CoachID:  previous.CoachID,
+			ESPNID:   previous.ExternalID,
+			FullName: previous.FullName,
+		},
+		Coach: publisher.EventCoach{
+			CoachID:  coach.CoachID,
+			ESPNID:   coach.ExternalID,
+			FullName: coach.FullName,
+		},
+		EffectiveDate: asOf.Format("2006-01-02"),
+		OccurredAt:    time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,69 @@
+package espn
+
+import (
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// WriteCounts tallies changed vs unchanged rows for a single ingestion run
+type WriteCounts struct {
+	GamesChanged         int `json:"games_changed"`
+	GamesUnchanged       int `json:"games_unchanged"`
+	PlayerStatsChanged   int `json:"player_stats_changed"`
+	PlayerStatsUnchanged int `json:"player_stats_unchanged"`
+	TeamStatsChanged     int `json:"team_stats_changed"`
+	TeamStatsUnchanged   int `json:"team_stats_unchanged"`
+}
+
+// Changed returns the total number of rows written
+func (c WriteCounts) Changed() int {
+	return c.GamesChanged + c.PlayerStatsChanged + c.TeamStatsChanged
+}
+
+// Unchanged returns the total number of rows skipped because their hash matched
+func (c WriteCounts) Unchanged() int {
+	return c.GamesUnchanged + c.PlayerStatsUnchanged + c.TeamStatsUnchanged
+}
+
+// Add accumulates another run's counts
+func (c *WriteCounts) Add(other WriteCounts) {
+	c.GamesChanged += other.GamesChanged
+	c.GamesUnchanged += other.GamesUnchanged
+	c.PlayerStatsChanged += other.PlayerStatsChanged
+	c.PlayerStatsUnchanged += other.PlayerStatsUnchanged
+	c.TeamStatsChanged += other.TeamStatsChanged
+	c.TeamStatsUnchanged += other.TeamStatsUnchanged
+}
+
+// String formats the counts for log lines
+func (c WriteCounts) String() string {
+	return fmt.Sprintf("games %d changed/%d unchanged, player stats %d/%d, team stats %d/%d",
+		c.GamesChanged, c.GamesUnchanged,
+		c.PlayerStatsChanged, c.PlayerStatsUnchanged,
+		c.TeamStatsChanged, c.TeamStatsUnchanged)
+}
+
+func (c *WriteCounts) recordGame(result store.UpsertResult) {
+	if result.Changed() {
+		c.GamesChanged++
+	} else {
+		c.GamesUnchanged++
+	}
+}
+
+func (c *WriteCounts) recordPlayerStats(result store.UpsertResult) {
+	if result.Changed() {
+		c.PlayerStatsChanged++
+	} else {
+		c.PlayerStatsUnchanged++
+	}
+}
+
+func (c *WriteCounts) recordTeamStats(result store.UpsertResult) {
+	if result.Changed() {
+		c.TeamStatsChanged++
+	} else {
+		c.TeamStatsUnchanged++
+	}
+}
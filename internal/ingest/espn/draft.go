@@ -0,0 +1,139 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// ParsedDraftPick is one selection from the ESPN draft endpoint
+type ParsedDraftPick struct {
+	Round      int
+	Overall    int
+	Player     *ParsedPlayerStats // identity and bio fields only
+	TeamESPNID string
+	TeamAbbr   string
+}
+
+// DraftSyncResult summarizes one draft year's sync
+type DraftSyncResult struct {
+	Year       int `json:"year"`
+	Picks      int `json:"picks"`
+	Updated    int `json:"updated"`     // players whose draft fields changed
+	NewPlayers int `json:"new_players"` // picks not previously stored
+	Skipped    int `json:"skipped"`     // picks without an athlete or pick number
+}
+
+func (r DraftSyncResult) String() string {
+	return fmt.Sprintf("%d: %d picks, %d updated, %d new players, %d skipped", r.Year, r.Picks, r.Updated, r.NewPlayers, r.Skipped)
+}
+
+// ParseDraft reads the picks from an ESPN draft response. ESPN nests the team
+// and athlete under each pick; picks it has not resolved to an athlete (future
+// drafts, forfeited picks) have no athlete ID and are returned with a nil Player.
+func ParseDraft(data map[string]interface{}) []*ParsedDraftPick {
+	var picks []*ParsedDraftPick
+	for _, raw := range extractArray(data, "picks") {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		pick := &ParsedDraftPick{
+			Round:      extractInt(entry, "round"),
+			Overall:    extractInt(entry, "overall"),
+			TeamESPNID: extractString(entry, "teamId"),
+		}
+		if team := extractMap(entry, "team"); len(team) > 0 {
+			pick.TeamESPNID = fallbackString(extractString(team, "id"), pick.TeamESPNID)
+			pick.TeamAbbr = extractString(team, "abbreviation")
+		}
+
+		if athlete := extractMap(entry, "athlete"); len(athlete) > 0 {
+			player := &ParsedPlayerStats{
+				ESPNPlayerID: extractString(athlete, "id"),
+				PlayerName:   fallbackString(extractString(athlete, "displayName"), extractString(athlete, "fullName")),
+			}
+			if position := extractMap(athlete, "position"); len(position) > 0 {
+				player.Position = extractString(position, "abbreviation")
+			}
+			if player.ESPNPlayerID != "" && player.PlayerName != "" {
+				pick.Player = player
+			}
+		}
+
+		picks = append(picks, pick)
+	}
+	return picks
+}
+
+// SyncDraft stores the draft year, round, overall pick, and drafting team of
+// every pick in a draft year. Drafted players not yet stored are created, so
+// the class is complete even for players who never appeared in a box score.
+// Stored draft fields are only overwritten when ESPN's differ.
+func (i *Ingester) SyncDraft(ctx context.Context, year int) (DraftSyncResult, error) {
+	ctx = store.WithPrimary(ctx)
+
+	result := DraftSyncResult{Year: year}
+	if err := i.ensureTeamLookup(ctx); err != nil {
+		return result, err
+	}
+
+	data, err := i.client.FetchDraft(ctx, i.league.ESPNPath, year)
+	if err != nil {
+		return result, fmt.Errorf("fetch %d draft: %w", year, err)
+	}
+
+	picks := ParseDraft(data)
+	var players []*ParsedPlayerStats
+	for _, p := range picks {
+		if p.Player != nil {
+			players = append(players, p.Player)
+		}
+	}
+	i.primePlayerIDs(ctx, players)
+
+	for _, p := range picks {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if p.Player == nil || p.Round <= 0 || p.Overall <= 0 {
+			result.Skipped++
+			continue
+		}
+		result.Picks++
+
+		// Franchises that have since moved or folded are not in teams; the pick
+		// is still recorded without a drafting team
+		var draftTeamID store.NullInt32
+		if teamID, err := i.lookupTeamID(p.TeamAbbr, p.TeamESPNID); err == nil {
+			draftTeamID = store.NullInt32{Int32: int32(teamID), Valid: true}
+		}
+
+		_, known := i.playerIDs.Load(p.Player.ESPNPlayerID)
+		playerID, err := i.resolvePlayerID(ctx, store.DefaultSport, p.Player, 0)
+		if err != nil {
+			log.Printf("[draft] Failed to resolve %s (%d #%d): %v", p.Player.PlayerName, year, p.Overall, err)
+			result.Skipped++
+			continue
+		}
+		if !known {
+			result.NewPlayers++
+		}
+
+		changed, err := i.playerRepo.SetDraft(ctx, playerID, year, p.Round, p.Overall, draftTeamID)
+		if err != nil {
+			return result, err
+		}
+		if changed {
+			result.Updated++
+		}
+	}
+
+	if len(picks) == 0 {
+		return result, fmt.Errorf("no picks in the %d draft response", year)
+	}
+	return result, nil
+}
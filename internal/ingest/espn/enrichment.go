@@ -0,0 +1,171 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// EnrichmentConfig tunes the player_enrichment job
+type EnrichmentConfig struct {
+	BatchSize         int           // players looked up per run
+	RequestsPerMinute int           // ESPN profile fetch budget
+	RetryAfter        time.Duration // wait before retrying a player whose profile had nothing new
+}
+
+// DefaultEnrichmentConfig looks up 100 players a run at 30 requests a minute
+// and retries profiles with nothing new after 30 days
+func DefaultEnrichmentConfig() EnrichmentConfig {
+	return EnrichmentConfig{
+		BatchSize:         100,
+		RequestsPerMinute: 30,
+		RetryAfter:        30 * 24 * time.Hour,
+	}
+}
+
+// enrichmentFailureRetry is how long a player waits after a failed fetch
+const enrichmentFailureRetry = 6 * time.Hour
+
+// EnrichmentResult summarizes one enrichment pass
+type EnrichmentResult struct {
+	Candidates   int `json:"candidates"`
+	Enriched     int `json:"enriched"`
+	NoNewData    int `json:"no_new_data"`
+	NotFound     int `json:"not_found"`
+	Failed       int `json:"failed"`
+	FieldsFilled int `json:"fields_filled"`
+}
+
+func (r EnrichmentResult) String() string {
+	return fmt.Sprintf("%d players, %d enriched (%d fields), %d no new data, %d not found, %d failed",
+		r.Candidates, r.Enriched, r.FieldsFilled, r.NoNewData, r.NotFound, r.Failed)
+}
+
+// ParseAthleteBio reads biographical fields from an athlete profile response.
+// It returns nil when the response has no athlete. ESPN does not publish
+// nationality separately for most players; citizenship is used when present,
+// otherwise the birth country.
+func ParseAthleteBio(data map[string]interface{}) *store.PlayerBio {
+	athlete := extractMap(data, "athlete")
+	if extractString(athlete, "id") == "" {
+		return nil
+	}
+
+	bio := &store.PlayerBio{
+		Height:      nullString(extractString(athlete, "displayHeight")),
+		College:     nullString(extractString(extractMap(athlete, "college"), "name")),
+		HeadshotURL: nullString(extractString(extractMap(athlete, "headshot"), "href")),
+	}
+	if inches := extractInt(athlete, "height"); inches > 0 {
+		bio.HeightInches = store.NullInt32{Int32: int32(inches), Valid: true}
+	}
+	if weight := extractInt(athlete, "weight"); weight > 0 {
+		bio.Weight = store.NullInt32{Int32: int32(weight), Valid: true}
+	}
+	if dob := extractString(athlete, "dateOfBirth"); dob != "" {
+		if ts, err := time.Parse("2006-01-02T15:04Z", dob); err == nil {
+			bio.BirthDate = store.NullTime{Time: ts, Valid: true}
+		} else if ts, err := time.Parse(time.RFC3339, dob); err == nil {
+			bio.BirthDate = store.NullTime{Time: ts, Valid: true}
+		}
+	}
+
+	birthPlace := extractMap(athlete, "birthPlace")
+	bio.BirthCity = nullString(extractString(birthPlace, "city"))
+	bio.BirthCountry = nullString(extractString(birthPlace, "country"))
+	bio.Nationality = nullString(fallbackString(extractString(athlete, "citizenship"), extractString(birthPlace, "country")))
+
+	return bio
+}
+
+func nullString(s string) store.NullString {
+	s = strings.TrimSpace(s)
+	return store.NullString{String: s, Valid: s != ""}
+}
+
+// EnrichPlayers fetches ESPN profiles for up to config.BatchSize players that
+// are missing biographical fields, fills only the missing fields, and records
+// each attempt in player_enrichment so the next run moves on to other players.
+// Requests are spaced to config.RequestsPerMinute.
+func (i *Ingester) EnrichPlayers(ctx context.Context, config EnrichmentConfig) (EnrichmentResult, error) {
+	defaults := DefaultEnrichmentConfig()
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.RetryAfter <= 0 {
+		config.RetryAfter = defaults.RetryAfter
+	}
+
+	ctx = store.WithPrimary(ctx)
+	ctx = WithRequestLimiter(ctx, NewRequestLimiter(config.RequestsPerMinute))
+
+	var result EnrichmentResult
+	candidates, err := i.enrichment.Candidates(ctx, store.DefaultSport, config.BatchSize)
+	if err != nil {
+		return result, err
+	}
+	result.Candidates = len(candidates)
+
+	for _, c := range candidates {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		status, filled, lookupErr := i.enrichPlayer(ctx, c.PlayerID, c.ESPNID)
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		next := time.Now().Add(config.RetryAfter)
+		errMsg := ""
+		switch status {
+		case store.EnrichmentEnriched:
+			result.Enriched++
+			result.FieldsFilled += len(filled)
+		case store.EnrichmentNoNewData:
+			result.NoNewData++
+		case store.EnrichmentNotFound:
+			result.NotFound++
+		case store.EnrichmentFailed:
+			result.Failed++
+			next = time.Now().Add(enrichmentFailureRetry)
+			errMsg = lookupErr.Error()
+			log.Printf("[enrichment] Failed to enrich %s (%d): %v", c.FullName, c.PlayerID, lookupErr)
+		}
+
+		if err := i.enrichment.RecordAttempt(ctx, c.PlayerID, status, filled, errMsg, next); err != nil {
+			return result, err
+		}
+	}
+
+	if result.Candidates > 0 && result.Failed == result.Candidates {
+		return result, fmt.Errorf("all %d profile lookups failed", result.Failed)
+	}
+	return result, nil
+}
+
+// enrichPlayer looks up one profile and fills the player's missing fields
+func (i *Ingester) enrichPlayer(ctx context.Context, playerID int, espnID string) (string, []string, error) {
+	data, err := i.client.FetchAthlete(ctx, i.league.ESPNPath, espnID)
+	if err != nil {
+		return store.EnrichmentFailed, nil, err
+	}
+
+	bio := ParseAthleteBio(data)
+	if bio == nil {
+		return store.EnrichmentNotFound, nil, nil
+	}
+
+	filled, err := i.playerRepo.FillBio(ctx, playerID, bio)
+	if err != nil {
+		return store.EnrichmentFailed, nil, err
+	}
+	if len(filled) == 0 {
+		return store.EnrichmentNoNewData, nil, nil
+	}
+	return store.EnrichmentEnriched, filled, nil
+}
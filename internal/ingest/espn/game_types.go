@@ -0,0 +1,96 @@
+package espn
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// classifyGameType maps ESPN event metadata to a store game type. Special events
+// are recognized from the notes headline ("NBA All-Star Game", "Emirates NBA Cup
+// Championship") and competition type; everything else follows the season phase.
+func classifyGameType(event, comp map[string]interface{}, seasonCode int) string {
+	var text []string
+	for _, raw := range extractArray(comp, "notes") {
+		if note, ok := raw.(map[string]interface{}); ok {
+			text = append(text, extractString(note, "headline"))
+		}
+	}
+	text = append(text, extractString(event, "name"))
+	text = append(text, extractString(extractMap(comp, "type"), "abbreviation"))
+	headline := strings.ToLower(strings.Join(text, " "))
+
+	switch {
+	case strings.Contains(headline, "rising stars"):
+		return store.GameTypeRisingStars
+	case strings.Contains(headline, "all-star"), strings.Contains(headline, "allstar"):
+		return store.GameTypeAllStar
+	case strings.Contains(headline, "nba cup"), strings.Contains(headline, "in-season tournament"):
+		if isTournamentFinal(headline) {
+			return store.GameTypeTournamentFinal
+		}
+		return store.GameTypeTournament
+	case seasonCode == 5, strings.Contains(headline, "play-in"):
+		return store.GameTypePlayIn
+	case seasonCode == 3:
+		return store.GameTypePlayoffs
+	case seasonCode == 1:
+		return store.GameTypePreseason
+	}
+	return store.GameTypeRegular
+}
+
+// isTournamentFinal distinguishes the championship from the knockout rounds
+func isTournamentFinal(headline string) bool {
+	if strings.Contains(headline, "championship") {
+		return true
+	}
+	return strings.Contains(headline, "final") &&
+		!strings.Contains(headline, "semifinal") &&
+		!strings.Contains(headline, "quarterfinal")
+}
+
+var tournamentGroupPattern = regexp.MustCompile(`(?i)\b(east|west)(?:ern)?(?: conference)? group ([a-c])\b`)
+
+// parseTournamentMetadata reads the NBA Cup group or knockout round from the notes
+// headline, e.g. "East Group A - Emirates NBA Cup" or "Emirates NBA Cup - Semifinals".
+// It returns nil for games outside the tournament.
+func parseTournamentMetadata(comp map[string]interface{}) *store.GameMetadata {
+	for _, raw := range extractArray(comp, "notes") {
+		note, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		headline := extractString(note, "headline")
+		lower := strings.ToLower(headline)
+		if !strings.Contains(lower, "nba cup") && !strings.Contains(lower, "in-season tournament") {
+			continue
+		}
+
+		meta := &store.GameMetadata{}
+		if m := tournamentGroupPattern.FindStringSubmatch(headline); m != nil {
+			conference := "West"
+			if strings.EqualFold(m[1], "east") {
+				conference = "East"
+			}
+			meta.TournamentGroup = fmt.Sprintf("%s Group %s", conference, strings.ToUpper(m[2]))
+			meta.TournamentRound = store.TournamentRoundGroup
+			return meta
+		}
+
+		switch {
+		case strings.Contains(lower, "quarterfinal"):
+			meta.TournamentRound = store.TournamentRoundQuarterfinal
+		case strings.Contains(lower, "semifinal"):
+			meta.TournamentRound = store.TournamentRoundSemifinal
+		case isTournamentFinal(lower):
+			meta.TournamentRound = store.TournamentRoundChampionship
+		default:
+			continue
+		}
+		return meta
+	}
+	return nil
+}
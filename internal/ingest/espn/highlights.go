@@ -0,0 +1,56 @@
+package espn
+
+import (
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// ParseHighlights extracts the video clips listed in a game summary. A clip
+// tagged with a play carries that play's ESPN ID in PlayExternalID.
+func ParseHighlights(summaryData map[string]interface{}) []*store.GameHighlight {
+	rawVideos := extractArray(summaryData, "videos")
+	highlights := make([]*store.GameHighlight, 0, len(rawVideos))
+
+	for _, raw := range rawVideos {
+		v, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id := newsID(v, "id")
+		headline := fallbackString(extractString(v, "headline"), extractString(v, "title"))
+		if id == "" || headline == "" {
+			continue
+		}
+
+		h := &store.GameHighlight{ExternalID: id, Headline: headline}
+		if desc := extractString(v, "description"); desc != "" {
+			h.Description = store.NullString{String: desc, Valid: true}
+		}
+		if duration := extractInt(v, "duration"); duration > 0 {
+			h.DurationSeconds = store.NullInt32{Int32: int32(duration), Valid: true}
+		}
+		if thumb := extractString(v, "thumbnail"); thumb != "" {
+			h.ThumbnailURL = store.NullString{String: thumb, Valid: true}
+		}
+
+		links := extractMap(v, "links")
+		if web := extractString(extractMap(links, "web"), "href"); web != "" {
+			h.WebURL = store.NullString{String: web, Valid: true}
+		}
+		source := extractMap(links, "source")
+		if media := fallbackString(extractString(extractMap(source, "HD"), "href"), extractString(source, "href")); media != "" {
+			h.VideoURL = store.NullString{String: media, Valid: true}
+		}
+
+		if playID := newsID(v, "playId"); playID != "" {
+			h.PlayExternalID = store.NullString{String: playID, Valid: true}
+		}
+		published := fallbackString(extractString(v, "originalPublishDate"), extractString(v, "lastModified"))
+		if ts, err := parseNewsTime(published); err == nil {
+			h.PublishedAt = store.NullTime{Time: ts, Valid: true}
+		}
+
+		highlights = append(highlights, h)
+	}
+
+	return highlights
+}
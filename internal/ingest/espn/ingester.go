@@ -3,24 +3,41 @@ package espn
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/quality"
 	"github.com/fortuna/minerva/internal/store"
 	"github.com/fortuna/minerva/internal/store/repository"
 )
 
 // Ingester handles the ingestion of ESPN data into the database.
 type Ingester struct {
-	client    *Client
-	db        *store.Database
-	gameRepo  *repository.GameRepository
-	statsRepo *repository.StatsRepository
-	teamRepo  *repository.TeamRepository
-	playerRepo *repository.PlayerRepository
+	client      *Client
+	db          *store.Database
+	league      store.League
+	gameRepo    *repository.GameRepository
+	statsRepo   *repository.StatsRepository
+	teamRepo    *repository.TeamRepository
+	playerRepo  *repository.PlayerRepository
+	playRepo    *repository.PlayRepository
+	highlights  *repository.HighlightRepository
+	starters    *repository.StarterRepository
+	seriesRepo  *repository.PlayoffSeriesRepository
+	updates     *repository.GameUpdateRepository
+	externalIDs *repository.ExternalIDRepository
+	txnRepo     *repository.TransactionRepository
+	coachRepo   *repository.CoachRepository
+	enrichment  *repository.EnrichmentRepository
+	newsRepo    *repository.NewsRepository
+	events      *publisher.GameEventPublisher
+	statsPub    publisher.Publisher
+	validator   *quality.Validator
 
 	mu        sync.Mutex
 	teamCache *teamLookup
@@ -48,19 +65,69 @@ func NewIngesterWithBaseURL(db *store.Database, baseURL string) *Ingester {
 		client = NewClient()
 	}
 
+	nba, _ := store.LookupLeague(store.LeagueNBA)
 	return &Ingester{
-		client:     client,
-		db:         db,
-		gameRepo:   repository.NewGameRepository(db),
-		statsRepo:  repository.NewStatsRepository(db),
-		teamRepo:   repository.NewTeamRepository(db),
-		playerRepo: repository.NewPlayerRepository(db),
+		client:      client,
+		db:          db,
+		league:      nba,
+		gameRepo:    repository.NewGameRepository(db),
+		statsRepo:   repository.NewStatsRepository(db),
+		teamRepo:    repository.NewTeamRepository(db),
+		playerRepo:  repository.NewPlayerRepository(db),
+		playRepo:    repository.NewPlayRepository(db),
+		highlights:  repository.NewHighlightRepository(db),
+		starters:    repository.NewStarterRepository(db),
+		seriesRepo:  repository.NewPlayoffSeriesRepository(db),
+		updates:     repository.NewGameUpdateRepository(db),
+		externalIDs: repository.NewExternalIDRepository(db),
+		txnRepo:     repository.NewTransactionRepository(db),
+		coachRepo:   repository.NewCoachRepository(db),
+		enrichment:  repository.NewEnrichmentRepository(db),
+		newsRepo:    repository.NewNewsRepository(db),
 	}
 }
 
-// IngestTodaysGames fetches and stores games for the current day.
+// NewLeagueIngester creates an ingester for one league's ESPN feed. Its games
+// are stored under that league and matched against the league's teams.
+func NewLeagueIngester(db *store.Database, league store.League) *Ingester {
+	i := NewIngester(db)
+	i.league = league
+	return i
+}
+
+// League returns the league this ingester reads
+func (i *Ingester) League() store.League {
+	return i.league
+}
+
+// SetGameEvents announces created games and schedule changes through the given
+// publisher. A nil publisher disables game events.
+func (i *Ingester) SetGameEvents(events *publisher.GameEventPublisher) {
+	i.events = events
+}
+
+// SetStatsPublisher re-announces a final game on the games.stats stream when a
+// later ingest corrects its box score. With the outbox the entry commits with
+// the corrected lines. A nil publisher disables it.
+func (i *Ingester) SetStatsPublisher(pub publisher.Publisher) {
+	i.statsPub = pub
+}
+
+// SetValidator checks every final game's box score after its stats are ingested.
+// A nil validator disables post-ingest validation.
+func (i *Ingester) SetValidator(validator *quality.Validator) {
+	i.validator = validator
+}
+
+// Client returns the underlying ESPN API client (read-only access for planning tools).
+func (i *Ingester) Client() *Client {
+	return i.client
+}
+
+// IngestTodaysGames fetches and stores games for the current day, returning
+// what was written.
 // Uses Eastern Time (America/New_York) since NBA games are scheduled in US timezones.
-func (i *Ingester) IngestTodaysGames(ctx context.Context, seasonID int) error {
+func (i *Ingester) IngestTodaysGames(ctx context.Context, seasonID int) (WriteCounts, error) {
 	// Load Eastern Time location
 	loc, err := time.LoadLocation("America/New_York")
 	if err != nil {
@@ -70,94 +137,190 @@ func (i *Ingester) IngestTodaysGames(ctx context.Context, seasonID int) error {
 	
 	// Get current time in Eastern Time
 	now := time.Now().In(loc)
-	_, err = i.IngestGamesByDate(ctx, seasonID, now)
-	return err
+	_, counts, err := i.IngestGamesByDate(ctx, seasonID, now)
+	return counts, err
 }
 
-// IngestGamesByDate fetches and stores games (and stats) for a specific date.
-func (i *Ingester) IngestGamesByDate(ctx context.Context, seasonID int, date time.Time) ([]*store.Game, error) {
-	log.Printf("[ingest] Fetching scoreboard for %s", date.Format("2006-01-02"))
+// IngestGamesByDate fetches and stores games (and stats) for a specific date,
+// returning the games and what was written.
+func (i *Ingester) IngestGamesByDate(ctx context.Context, seasonID int, date time.Time) ([]*store.Game, WriteCounts, error) {
+	// Ingestion reads back what it just wrote, so never route it to a lagging replica
+	ctx = store.WithPrimary(ctx)
+	log.Printf("[ingest] Fetching %s scoreboard for %s", i.league.Name, date.Format("2006-01-02"))
 
 	if err := i.ensureTeamLookup(ctx); err != nil {
-		return nil, err
+		return nil, WriteCounts{}, err
 	}
 
-	scoreboard, err := i.client.FetchScoreboard(ctx, BasketballNBA, date)
+	scoreboard, err := i.client.FetchScoreboard(ctx, i.league.ESPNPath, date)
 	if err != nil {
-		return nil, fmt.Errorf("fetch scoreboard: %w", err)
+		return nil, WriteCounts{}, fmt.Errorf("fetch scoreboard: %w", err)
 	}
 
 	parsedGames, err := ParseScoreboardGamesDetailed(scoreboard, seasonID)
 	if err != nil {
-		return nil, fmt.Errorf("parse scoreboard: %w", err)
+		return nil, WriteCounts{}, fmt.Errorf("parse scoreboard: %w", err)
 	}
 
+	counts := &WriteCounts{}
 	var ingested []*store.Game
 	for _, parsed := range parsedGames {
-		game, err := i.persistParsedGame(ctx, parsed)
+		// Fetched before locking so the lock isn't held across the request
+		summary, fetchErr := i.client.FetchGameSummary(ctx, i.league.ESPNPath, parsed.Game.ExternalID)
+
+		var game *store.Game
+		var event *publisher.GameEvent
+		err := i.db.WithAdvisoryLock(ctx, store.LockNamespaceGame, parsed.Game.ExternalID, func() error {
+			stored, wasFinal, queued, err := i.persistParsedGame(ctx, parsed, counts)
+			if err != nil {
+				log.Printf("[ingest] Error upserting game %s: %v", parsed.Game.ExternalID, err)
+				return nil
+			}
+			game, event = stored, queued
+
+			if fetchErr != nil {
+				log.Printf("[ingest] Error fetching summary for game %d (ESPN ID %s): %v", game.GameID, parsed.Game.ExternalID, fetchErr)
+				return nil
+			}
+			if err := i.ingestStatsFromSummary(ctx, game, wasFinal, summary, counts); err != nil {
+				log.Printf("[ingest] Error ingesting stats for game %d (ESPN ID %s): %v", game.GameID, parsed.Game.ExternalID, err)
+				return nil
+			}
+			i.validateGame(ctx, game)
+			return nil
+		})
 		if err != nil {
-			log.Printf("[ingest] Error upserting game %d: %v", parsed.Game.GameID, err)
-			continue
+			log.Printf("[ingest] Error locking game %s: %v", parsed.Game.ExternalID, err)
 		}
-
-		if err := i.ingestStatsForGameByID(ctx, game.GameID, parsed.Game.ExternalID); err != nil {
-			log.Printf("[ingest] Error ingesting stats for game %d (ESPN ID %s): %v", game.GameID, parsed.Game.ExternalID, err)
+		i.notifyWebhook(ctx, event)
+		if game != nil {
+			ingested = append(ingested, game)
 		}
-
-		ingested = append(ingested, game)
 	}
 
-	log.Printf("[ingest] ✓ Processed %d games for %s", len(ingested), date.Format("2006-01-02"))
-	return ingested, nil
+	log.Printf("[ingest] ✓ Processed %d games for %s (%s)", len(ingested), date.Format("2006-01-02"), counts)
+	return ingested, *counts, nil
 }
 
-// IngestGameByID fetches and stores a single game by ESPN event ID.
-func (i *Ingester) IngestGameByID(ctx context.Context, seasonID int, gameID string) (*store.Game, error) {
+// IngestGameByID fetches and stores a single game by ESPN event ID, returning
+// the game and what was written.
+func (i *Ingester) IngestGameByID(ctx context.Context, seasonID int, gameID string) (*store.Game, WriteCounts, error) {
+	ctx = store.WithPrimary(ctx)
 	if err := i.ensureTeamLookup(ctx); err != nil {
-		return nil, err
+		return nil, WriteCounts{}, err
 	}
 
-	summary, err := i.client.FetchGameSummary(ctx, BasketballNBA, gameID)
+	summary, err := i.client.FetchGameSummary(ctx, i.league.ESPNPath, gameID)
 	if err != nil {
-		return nil, fmt.Errorf("fetch game summary: %w", err)
+		return nil, WriteCounts{}, fmt.Errorf("fetch game summary: %w", err)
 	}
 
 	event := buildEventFromSummary(summary)
 	if event == nil {
-		return nil, fmt.Errorf("summary missing header data for event %s", gameID)
+		return nil, WriteCounts{}, fmt.Errorf("summary missing header data for event %s", gameID)
 	}
 
 	parsed, err := parseGameFromEventDetailed(event, seasonID)
 	if err != nil {
-		return nil, err
+		return nil, WriteCounts{}, err
 	}
 
-	game, err := i.persistParsedGame(ctx, parsed)
+	counts := &WriteCounts{}
+	var game *store.Game
+	var gameEvent *publisher.GameEvent
+	err = i.db.WithAdvisoryLock(ctx, store.LockNamespaceGame, parsed.Game.ExternalID, func() error {
+		stored, wasFinal, queued, err := i.persistParsedGame(ctx, parsed, counts)
+		if err != nil {
+			return err
+		}
+		game, gameEvent = stored, queued
+		if err := i.ingestStatsFromSummary(ctx, game, wasFinal, summary, counts); err != nil {
+			return err
+		}
+		i.validateGame(ctx, game)
+		return nil
+	})
+	i.notifyWebhook(ctx, gameEvent)
 	if err != nil {
-		return nil, err
+		return nil, *counts, err
 	}
 
-	if err := i.ingestStatsForGameByID(ctx, game.GameID, parsed.Game.ExternalID); err != nil {
-		return nil, err
+	log.Printf("[ingest] ✓ Processed game %s (%s)", gameID, counts)
+	return game, *counts, nil
+}
+
+// RefreshGameStats refetches a stored game's box score and re-upserts any stat
+// lines that changed, returning what was written. Used to catch late stat
+// corrections after ESPN marks a game final.
+func (i *Ingester) RefreshGameStats(ctx context.Context, game *store.Game) (WriteCounts, error) {
+	ctx = store.WithPrimary(ctx)
+	if err := i.ensureTeamLookup(ctx); err != nil {
+		return WriteCounts{}, err
 	}
 
-	return game, nil
+	summary, err := i.client.FetchGameSummary(ctx, i.league.ESPNPath, game.ExternalID)
+	if err != nil {
+		return WriteCounts{}, fmt.Errorf("fetch game summary: %w", err)
+	}
+
+	counts := &WriteCounts{}
+	err = i.db.WithAdvisoryLock(ctx, store.LockNamespaceGame, game.ExternalID, func() error {
+		if err := i.ingestStatsFromSummary(ctx, game, game.Status == "final", summary, counts); err != nil {
+			return err
+		}
+		if counts.Changed() > 0 {
+			i.validateGame(ctx, game)
+		}
+		return nil
+	})
+	return *counts, err
 }
 
-func (i *Ingester) ingestStatsForGameByID(ctx context.Context, dbGameID int, espnGameID string) error {
-	summary, err := i.client.FetchGameSummary(ctx, BasketballNBA, espnGameID)
+// validateGame runs post-ingest data quality rules on a final game. Violations are
+// recorded for operators and never fail ingestion.
+func (i *Ingester) validateGame(ctx context.Context, game *store.Game) {
+	if i.validator == nil || game.Status != "final" {
+		return
+	}
+	issues, err := i.validator.ValidateGame(ctx, game.GameID)
 	if err != nil {
-		return fmt.Errorf("fetch game summary: %w", err)
+		log.Printf("[ingest] Data quality check failed for game %d: %v", game.GameID, err)
+		return
+	}
+	if len(issues) > 0 {
+		log.Printf("[ingest] ⚠️  Game %d has %d data quality issues (first: %s: %s)",
+			game.GameID, len(issues), issues[0].Rule, issues[0].Message)
 	}
-	return i.ingestStatsFromSummary(ctx, dbGameID, espnGameID, summary)
 }
 
-func (i *Ingester) ingestStatsFromSummary(ctx context.Context, dbGameID int, espnGameID string, summary map[string]interface{}) error {
-	parsedStats, err := ParseBoxScoreDetailed(summary, espnGameID)
+// ingestStatsFromSummary writes a game's player and team stat lines in one
+// transaction. A line that fails is skipped without losing the others. wasFinal
+// says the game was already stored as final, so changed lines are corrections:
+// the corrected game is published on games.stats in the same transaction.
+func (i *Ingester) ingestStatsFromSummary(ctx context.Context, game *store.Game, wasFinal bool, summary map[string]interface{}, counts *WriteCounts) error {
+	dbGameID := game.GameID
+	parsedStats, err := ParseBoxScoreDetailed(summary, game.ExternalID)
 	if err != nil {
 		return fmt.Errorf("parse box score: %w", err)
 	}
 
+	prevStats := make(map[int]*store.PlayerGameStats)
+	if existing, err := i.statsRepo.GetByGameID(store.WithPrimary(ctx), dbGameID); err == nil {
+		for _, line := range existing {
+			prevStats[line.PlayerID] = line
+		}
+	}
+	var deltas []store.StatDelta
+
+	i.primePlayerIDs(ctx, parsedStats)
+
+	tx, err := i.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning stats write: %w", err)
+	}
+	defer tx.Rollback()
+
+	written := &WriteCounts{}
 	for _, parsed := range parsedStats {
 		teamID, err := i.lookupTeamID(parsed.TeamAbbr, "")
 		if err != nil {
@@ -165,7 +328,7 @@ func (i *Ingester) ingestStatsFromSummary(ctx context.Context, dbGameID int, esp
 			continue
 		}
 
-		playerID, err := i.resolvePlayerID(ctx, parsed, teamID)
+		playerID, err := i.resolvePlayerID(ctx, game.Sport, parsed, teamID)
 		if err != nil {
 			log.Printf("[ingest] Unable to resolve player %s: %v", parsed.PlayerName, err)
 			continue
@@ -176,30 +339,147 @@ func (i *Ingester) ingestStatsFromSummary(ctx context.Context, dbGameID int, esp
 		stats.TeamID = teamID
 		stats.PlayerID = playerID
 
-		if err := i.statsRepo.UpsertPlayerStats(ctx, stats); err != nil {
+		var result store.UpsertResult
+		err = withSavepoint(ctx, tx, func() error {
+			var err error
+			result, err = i.statsRepo.UpsertPlayerStats(ctx, tx, stats)
+			return err
+		})
+		if err != nil {
 			log.Printf("[ingest] Failed to upsert stats for player %d in game %d: %v", playerID, dbGameID, err)
+			continue
+		}
+		written.recordPlayerStats(result)
+
+		if result.Changed() {
+			if moved := store.DiffPlayerStats(prevStats[playerID], stats); len(moved) > 0 {
+				deltas = append(deltas, store.StatDelta{PlayerID: playerID, TeamID: teamID, Deltas: moved})
+			}
 		}
 	}
 
 	// Ingest team stats
-	if err := i.ingestTeamStatsFromSummary(ctx, dbGameID, espnGameID, summary); err != nil {
+	if err := i.ingestTeamStatsFromSummary(ctx, tx, game, summary, written); err != nil {
 		log.Printf("[ingest] Failed to ingest team stats for game %d: %v", dbGameID, err)
 		// Don't return error - team stats are supplementary
 	}
 
+	// The final box score already went out on games.stats; send the corrected
+	// one with the lines that changed
+	if wasFinal && written.Changed() > 0 && i.statsPub != nil {
+		if err := publisher.PublishTx(ctx, i.statsPub, tx, publisher.StatsStream, game); err != nil {
+			log.Printf("[ingest] Failed to publish corrected stats for game %d: %v", dbGameID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing stats for game %d: %w", dbGameID, err)
+	}
+	counts.Add(*written)
+
+	if len(deltas) > 0 {
+		i.recordUpdate(ctx, &store.GameUpdate{GameID: dbGameID, Stats: deltas})
+	}
+	// Pace reads the committed team lines
+	if err := i.statsRepo.UpdateGamePace(ctx, dbGameID); err != nil {
+		log.Printf("[ingest] Failed to update pace for game %d: %v", dbGameID, err)
+	}
+
+	// Ingest quarter scores and play-by-play (supplementary, used by recaps)
+	if err := i.ingestPlaysFromSummary(ctx, dbGameID, summary); err != nil {
+		log.Printf("[ingest] Failed to ingest play-by-play for game %d: %v", dbGameID, err)
+	}
+	if err := i.updateLeadTracking(ctx, dbGameID); err != nil {
+		log.Printf("[ingest] Failed to update lead tracking for game %d: %v", dbGameID, err)
+	}
+
+	// Ingest video highlight links (supplementary)
+	if err := i.ingestHighlightsFromSummary(ctx, dbGameID, summary); err != nil {
+		log.Printf("[ingest] Failed to ingest highlights for game %d: %v", dbGameID, err)
+	}
+
+	return nil
+}
+
+// withSavepoint runs fn inside a savepoint of tx, rolling back to it when fn
+// fails so the transaction stays usable for the remaining writes
+func withSavepoint(ctx context.Context, tx *sql.Tx, fn func() error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT ingest_line"); err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT ingest_line"); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+	_, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT ingest_line")
+	return err
+}
+
+func (i *Ingester) ingestHighlightsFromSummary(ctx context.Context, dbGameID int, summary map[string]interface{}) error {
+	highlights := ParseHighlights(summary)
+	written, err := i.highlights.UpsertForGame(ctx, dbGameID, highlights)
+	if err != nil {
+		return err
+	}
+	if written > 0 {
+		log.Printf("[ingest] ✓ Stored %d/%d highlights for game %d", written, len(highlights), dbGameID)
+	}
 	return nil
 }
 
-func (i *Ingester) ingestTeamStatsFromSummary(ctx context.Context, dbGameID int, espnGameID string, summary map[string]interface{}) error {
-	parsedTeamStats, err := ParseTeamStats(summary, espnGameID)
+func (i *Ingester) ingestPlaysFromSummary(ctx context.Context, dbGameID int, summary map[string]interface{}) error {
+	if scores := ParseQuarterScores(summary); scores != nil {
+		if err := i.gameRepo.SetQuarterScores(ctx, dbGameID, scores); err != nil {
+			return err
+		}
+	}
+
+	parsedPlays := ParsePlays(summary)
+	if len(parsedPlays) == 0 {
+		return nil
+	}
+
+	plays := make([]*store.GamePlay, 0, len(parsedPlays))
+	for _, parsed := range parsedPlays {
+		play := parsed.Play
+		if parsed.ESPNTeamID != "" {
+			if teamID, err := i.lookupTeamID("", parsed.ESPNTeamID); err == nil {
+				play.TeamID = store.NullInt32{Int32: int32(teamID), Valid: true}
+			}
+		}
+		// Box score ingestion has already cached every player who appeared in the game
+		if cached, ok := i.playerIDs.Load(parsed.ESPNPlayerID); ok && parsed.ESPNPlayerID != "" {
+			play.PlayerID = store.NullInt32{Int32: int32(cached.(int)), Valid: true}
+		}
+		plays = append(plays, play)
+	}
+
+	written, err := i.playRepo.UpsertForGame(ctx, dbGameID, plays)
 	if err != nil {
-		return fmt.Errorf("parse team stats: %w", err)
+		return err
+	}
+	if written > 0 {
+		log.Printf("[ingest] ✓ Stored %d/%d plays for game %d", written, len(plays), dbGameID)
 	}
+	return nil
+}
 
-	// Get game to determine home/away
-	game, err := i.gameRepo.GetByID(ctx, dbGameID)
+// updateLeadTracking recomputes largest leads, lead changes, and ties from every
+// scoring play stored for the game, not just the ones this poll wrote
+func (i *Ingester) updateLeadTracking(ctx context.Context, dbGameID int) error {
+	plays, err := i.playRepo.GetByGame(store.WithPrimary(ctx), dbGameID, true)
 	if err != nil {
-		return fmt.Errorf("fetch game: %w", err)
+		return err
+	}
+	return i.gameRepo.SetLeadTracking(ctx, dbGameID, store.TrackLeads(plays))
+}
+
+func (i *Ingester) ingestTeamStatsFromSummary(ctx context.Context, tx *sql.Tx, game *store.Game, summary map[string]interface{}, counts *WriteCounts) error {
+	parsedTeamStats, err := ParseTeamStats(summary, game.ExternalID)
+	if err != nil {
+		return fmt.Errorf("parse team stats: %w", err)
 	}
 
 	for _, parsed := range parsedTeamStats {
@@ -210,39 +490,149 @@ func (i *Ingester) ingestTeamStatsFromSummary(ctx context.Context, dbGameID int,
 		}
 
 		stats := parsed.Stats
-		stats.GameID = dbGameID
+		stats.GameID = game.GameID
 		stats.TeamID = teamID
 		stats.IsHome = (teamID == game.HomeTeamID)
 
-		if err := i.statsRepo.UpsertTeamStats(ctx, stats); err != nil {
-			log.Printf("[ingest] Failed to upsert team stats for team %d in game %d: %v", teamID, dbGameID, err)
+		var result store.UpsertResult
+		err = withSavepoint(ctx, tx, func() error {
+			var err error
+			result, err = i.statsRepo.UpsertTeamStats(ctx, tx, stats)
+			return err
+		})
+		if err != nil {
+			log.Printf("[ingest] Failed to upsert team stats for team %d in game %d: %v", teamID, game.GameID, err)
+			continue
 		}
+		counts.recordTeamStats(result)
 	}
 
 	return nil
 }
 
-func (i *Ingester) persistParsedGame(ctx context.Context, parsed *ParsedGame) (*store.Game, error) {
-	homeID, err := i.lookupTeamID(parsed.HomeTeam.Abbreviation, parsed.HomeTeam.ESPNID)
+// persistParsedGame stores a game and, in the same transaction, queues its
+// game.created or game.updated event. wasFinal reports the game was already
+// stored as final before this write. The queued event is returned for the
+// caller to post to the webhook once it has released the game lock.
+func (i *Ingester) persistParsedGame(ctx context.Context, parsed *ParsedGame, counts *WriteCounts) (game *store.Game, wasFinal bool, event *publisher.GameEvent, err error) {
+	homeID, err := i.resolveTeamID(ctx, parsed.HomeTeam)
 	if err != nil {
-		return nil, fmt.Errorf("lookup home team: %w", err)
+		return nil, false, nil, fmt.Errorf("lookup home team: %w", err)
 	}
-	awayID, err := i.lookupTeamID(parsed.AwayTeam.Abbreviation, parsed.AwayTeam.ESPNID)
+	awayID, err := i.resolveTeamID(ctx, parsed.AwayTeam)
 	if err != nil {
-		return nil, fmt.Errorf("lookup away team: %w", err)
+		return nil, false, nil, fmt.Errorf("lookup away team: %w", err)
 	}
 
 	parsed.Game.HomeTeamID = homeID
 	parsed.Game.AwayTeamID = awayID
+	parsed.Game.League = i.league.Key
 
 	// SeasonType is no longer a field in the Game struct (v2 schema)
 	// Season type is managed through the seasons table
 
-	if err := i.gameRepo.Upsert(ctx, parsed.Game); err != nil {
-		return nil, err
+	// The stored version is the baseline for the update log; a lookup failure
+	// only costs that game's log entry
+	prev, err := i.gameRepo.GetByExternalID(store.WithPrimary(ctx), parsed.Game.ExternalID)
+	if err != nil {
+		prev = nil
+	}
+	wasFinal = prev != nil && prev.Status == "final"
+
+	tx, err := i.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, nil, fmt.Errorf("beginning game write: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, scheduleChanged, err := i.gameRepo.Upsert(ctx, tx, parsed.Game)
+	if err != nil {
+		return nil, false, nil, err
+	}
+
+	// With the outbox the event commits with the game, so a stored game is
+	// always announced and a rolled back one never is
+	if i.events != nil && (result == store.UpsertInserted || scheduleChanged) {
+		event = i.gameEvent(parsed, result)
+		if err := i.events.QueueGameEvent(ctx, tx, event); err != nil {
+			log.Printf("[ingest] Failed to publish %s for game %s: %v", event.Type, parsed.Game.ExternalID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, nil, fmt.Errorf("committing game: %w", err)
 	}
+	counts.recordGame(result)
 
-	return parsed.Game, nil
+	if result == store.UpsertInserted {
+		i.linkExternalID(ctx, store.EntityGame, parsed.Game.ExternalID, parsed.Game.GameID)
+	}
+	if result == store.UpsertUpdated && prev != nil {
+		if fields := store.DiffGame(prev, parsed.Game); len(fields) > 0 {
+			i.recordUpdate(ctx, &store.GameUpdate{GameID: parsed.Game.GameID, Fields: fields})
+		}
+	}
+
+	if parsed.Series != nil && i.league.Key == store.LeagueNBA {
+		// Series tracking is secondary; a failure here shouldn't drop the game
+		if _, err := i.seriesRepo.RecordGame(ctx, parsed.Game, parsed.Series); err != nil {
+			log.Printf("[ingest] Failed to record playoff series for game %s: %v", parsed.Game.ExternalID, err)
+		}
+	}
+
+	return parsed.Game, wasFinal, event, nil
+}
+
+// notifyWebhook posts a game event already queued by persistParsedGame to the
+// optional webhook
+func (i *Ingester) notifyWebhook(ctx context.Context, event *publisher.GameEvent) {
+	if event == nil {
+		return
+	}
+	if err := i.events.NotifyWebhook(ctx, event); err != nil {
+		log.Printf("[ingest] Failed to post %s webhook for game %s: %v", event.Type, event.ESPNID, err)
+	}
+}
+
+// recordUpdate appends a change to the game update log. Like event publishing it
+// is best effort; ingestion never fails because of it.
+func (i *Ingester) recordUpdate(ctx context.Context, update *store.GameUpdate) {
+	if err := i.updates.Record(ctx, update); err != nil {
+		log.Printf("[ingest] Failed to record update for game %d: %v", update.GameID, err)
+	}
+}
+
+// gameEvent describes a new or rescheduled game for downstream services
+func (i *Ingester) gameEvent(parsed *ParsedGame, result store.UpsertResult) *publisher.GameEvent {
+	game := parsed.Game
+	event := &publisher.GameEvent{
+		Type:     publisher.GameEventUpdated,
+		Sport:    game.Sport,
+		GameID:   game.GameID,
+		ESPNID:   game.ExternalID,
+		GameDate: game.GameDate,
+		Status:   game.Status,
+		GameType: game.GameType,
+		League:   game.League,
+		HomeTeam: publisher.GameEventTeam{
+			TeamID:       game.HomeTeamID,
+			ESPNID:       parsed.HomeTeam.ESPNID,
+			Abbreviation: parsed.HomeTeam.Abbreviation,
+		},
+		AwayTeam: publisher.GameEventTeam{
+			TeamID:       game.AwayTeamID,
+			ESPNID:       parsed.AwayTeam.ESPNID,
+			Abbreviation: parsed.AwayTeam.Abbreviation,
+		},
+	}
+	if result == store.UpsertInserted {
+		event.Type = publisher.GameEventCreated
+	}
+	if game.GameTime.Valid {
+		tipOff := game.GameTime.Time
+		event.TipOff = &tipOff
+	}
+	return event
 }
 
 func (i *Ingester) lookupTeamID(abbr string, espnID string) (int, error) {
@@ -300,7 +690,7 @@ func (i *Ingester) ensureTeamLookup(ctx context.Context) error {
 		return nil
 	}
 
-	teams, err := i.teamRepo.GetAll(ctx)
+	teams, err := i.teamRepo.GetByLeague(ctx, i.league.TeamLeague)
 	if err != nil {
 		return fmt.Errorf("load teams: %w", err)
 	}
@@ -318,10 +708,79 @@ func (i *Ingester) ensureTeamLookup(ctx context.Context) error {
 	}
 
 	i.teamCache = lookup
+	if i.league.TeamLeague != store.LeagueNBA {
+		return nil
+	}
+
+	// Alternate ESPN team IDs (relocations, legacy feeds) from the mapping table
+	alternates, err := i.externalIDs.ListBySource(ctx, store.EntityTeam, store.SourceESPN)
+	if err != nil {
+		log.Printf("[ingest] Failed to load alternate ESPN team IDs: %v", err)
+	}
+	for espnID, teamID := range alternates {
+		if _, ok := lookup.byESPN[espnID]; !ok {
+			lookup.byESPN[espnID] = teamID
+		}
+	}
 	return nil
 }
 
-func (i *Ingester) resolvePlayerID(ctx context.Context, parsed *ParsedPlayerStats, teamID int) (int, error) {
+// resolveTeamID maps a scoreboard team to a stored team. Leagues that add teams
+// store one they have not seen before instead of failing the game.
+func (i *Ingester) resolveTeamID(ctx context.Context, meta TeamMeta) (int, error) {
+	teamID, err := i.lookupTeamID(meta.Abbreviation, meta.ESPNID)
+	if err == nil || !i.league.AddTeams || meta.ESPNID == "" {
+		return teamID, err
+	}
+
+	team := &store.Team{
+		Sport:        store.DefaultSport,
+		League:       i.league.TeamLeague,
+		ExternalID:   meta.ESPNID,
+		Abbreviation: normalizeTeamAbbreviation(meta.Abbreviation),
+		FullName:     meta.DisplayName,
+		ShortName:    meta.DisplayName,
+	}
+	if err := i.teamRepo.UpsertESPNTeam(ctx, team); err != nil {
+		return 0, err
+	}
+	log.Printf("[ingest] Added %s team %s (%s)", i.league.Name, team.FullName, team.Abbreviation)
+
+	i.mu.Lock()
+	i.teamCache.byAbbr[team.Abbreviation] = team.TeamID
+	i.teamCache.byESPN[team.ExternalID] = team.TeamID
+	i.mu.Unlock()
+	return team.TeamID, nil
+}
+
+// primePlayerIDs loads the stored players for a box score or roster in one query
+// so resolvePlayerID finds them in the ID cache instead of querying per player
+func (i *Ingester) primePlayerIDs(ctx context.Context, parsed []*ParsedPlayerStats) {
+	var uncached []string
+	for _, p := range parsed {
+		if p.ESPNPlayerID == "" {
+			continue
+		}
+		if _, ok := i.playerIDs.Load(p.ESPNPlayerID); !ok {
+			uncached = append(uncached, p.ESPNPlayerID)
+		}
+	}
+	if len(uncached) == 0 {
+		return
+	}
+
+	players, err := i.playerRepo.GetByExternalIDs(ctx, uncached)
+	if err != nil {
+		log.Printf("[ingest] Failed to preload %d players: %v", len(uncached), err)
+		return
+	}
+	for espnID, player := range players {
+		i.playerIDs.Store(espnID, player.PlayerID)
+		i.linkExternalID(ctx, store.EntityPlayer, espnID, player.PlayerID)
+	}
+}
+
+func (i *Ingester) resolvePlayerID(ctx context.Context, sport string, parsed *ParsedPlayerStats, teamID int) (int, error) {
 	if parsed.ESPNPlayerID != "" {
 		if cached, ok := i.playerIDs.Load(parsed.ESPNPlayerID); ok {
 			return cached.(int), nil
@@ -329,8 +788,23 @@ func (i *Ingester) resolvePlayerID(ctx context.Context, parsed *ParsedPlayerStat
 
 		if player, err := i.playerRepo.GetByExternalID(ctx, parsed.ESPNPlayerID); err == nil {
 			i.playerIDs.Store(parsed.ESPNPlayerID, player.PlayerID)
+			i.linkExternalID(ctx, store.EntityPlayer, parsed.ESPNPlayerID, player.PlayerID)
 			return player.PlayerID, nil
 		}
+
+		// ESPN occasionally reissues a player under an alternate ID
+		if playerID, ok, err := i.externalIDs.Resolve(ctx, store.EntityPlayer, store.SourceESPN, parsed.ESPNPlayerID); err == nil && ok {
+			i.playerIDs.Store(parsed.ESPNPlayerID, playerID)
+			return playerID, nil
+		}
+
+		// ...sometimes with the name formatted differently, which player_aliases covers
+		if playerID, ok := i.matchPlayerByName(ctx, parsed, teamID); ok {
+			log.Printf("[ingest] Matched new ESPN player %s (%s) to player %d by name", parsed.ESPNPlayerID, parsed.PlayerName, playerID)
+			i.playerIDs.Store(parsed.ESPNPlayerID, playerID)
+			i.linkExternalID(ctx, store.EntityPlayer, parsed.ESPNPlayerID, playerID)
+			return playerID, nil
+		}
 	}
 
 	// Parse name into first/last (simple split on last space)
@@ -346,24 +820,24 @@ func (i *Ingester) resolvePlayerID(ctx context.Context, parsed *ParsedPlayerStat
 	}
 
 	player := &store.Player{
-		Sport:        "basketball_nba",
-		ExternalID:   sql.NullString{String: parsed.ESPNPlayerID, Valid: parsed.ESPNPlayerID != ""},
-		FirstName:    sql.NullString{String: firstName, Valid: firstName != ""},
+		Sport:        sport,
+		ExternalID:   store.NullString{String: parsed.ESPNPlayerID, Valid: parsed.ESPNPlayerID != ""},
+		FirstName:    store.NullString{String: firstName, Valid: firstName != ""},
 		LastName:     lastName,
 		FullName:     parsed.PlayerName,
-		DisplayName:  sql.NullString{String: parsed.PlayerName, Valid: true},
-		Position:     sql.NullString{String: parsed.Position, Valid: parsed.Position != ""},
-		JerseyNumber: sql.NullString{String: parsed.Jersey, Valid: parsed.Jersey != ""},
-		Height:       sql.NullString{String: parsed.Height, Valid: parsed.Height != ""},
-		Status:       sql.NullString{String: "active", Valid: true},
+		DisplayName:  store.NullString{String: parsed.PlayerName, Valid: true},
+		Position:     store.NullString{String: parsed.Position, Valid: parsed.Position != ""},
+		JerseyNumber: store.NullString{String: parsed.Jersey, Valid: parsed.Jersey != ""},
+		Height:       store.NullString{String: parsed.Height, Valid: parsed.Height != ""},
+		Status:       store.NullString{String: "active", Valid: true},
 	}
 
 	if parsed.Weight > 0 {
-		player.Weight = sql.NullInt32{Int32: int32(parsed.Weight), Valid: true}
+		player.Weight = store.NullInt32{Int32: int32(parsed.Weight), Valid: true}
 	}
 
 	if parsed.BirthDate != nil {
-		player.BirthDate = sql.NullTime{Time: *parsed.BirthDate, Valid: true}
+		player.BirthDate = store.NullTime{Time: *parsed.BirthDate, Valid: true}
 	}
 
 	if err := i.playerRepo.Upsert(ctx, player); err != nil {
@@ -372,11 +846,47 @@ func (i *Ingester) resolvePlayerID(ctx context.Context, parsed *ParsedPlayerStat
 
 	if parsed.ESPNPlayerID != "" {
 		i.playerIDs.Store(parsed.ESPNPlayerID, player.PlayerID)
+		i.linkExternalID(ctx, store.EntityPlayer, parsed.ESPNPlayerID, player.PlayerID)
 	}
 
 	return player.PlayerID, nil
 }
 
+// matchPlayerByName finds the stored player an unknown ESPN ID belongs to by
+// name or alias. Names alone are not unique, so it only matches when exactly one
+// player has the name and that player is currently on teamID.
+func (i *Ingester) matchPlayerByName(ctx context.Context, parsed *ParsedPlayerStats, teamID int) (int, bool) {
+	if teamID == 0 || parsed.PlayerName == "" {
+		return 0, false
+	}
+
+	candidates, err := i.playerRepo.GetByExactName(ctx, parsed.PlayerName)
+	if err != nil {
+		log.Printf("[ingest] Failed to look up players named %s: %v", parsed.PlayerName, err)
+		return 0, false
+	}
+	if len(candidates) != 1 {
+		return 0, false
+	}
+
+	currentTeamID, err := i.playerRepo.GetCurrentTeamID(ctx, candidates[0].PlayerID)
+	if err != nil || currentTeamID != teamID {
+		return 0, false
+	}
+	return candidates[0].PlayerID, true
+}
+
+// linkExternalID records an ESPN ID in the cross-source mapping table. Mapping is
+// groundwork for reconciliation, so a failure is logged rather than returned.
+func (i *Ingester) linkExternalID(ctx context.Context, entityType, espnID string, internalID int) {
+	if espnID == "" {
+		return
+	}
+	if err := i.externalIDs.Link(ctx, entityType, store.SourceESPN, espnID, internalID); err != nil {
+		log.Printf("[ingest] Failed to map ESPN %s %s: %v", entityType, espnID, err)
+	}
+}
+
 func buildEventFromSummary(summary map[string]interface{}) map[string]interface{} {
 	header := extractMap(summary, "header")
 	if len(header) == 0 {
@@ -0,0 +1,186 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// lineupSize is the number of starters that makes a team's lineup complete
+const lineupSize = 5
+
+// ParsedLineup is one team's starters as listed in a game summary, in ESPN's
+// order. Only the player identity fields of each ParsedPlayerStats are set.
+type ParsedLineup struct {
+	TeamAbbr   string
+	ESPNTeamID string
+	Starters   []*ParsedPlayerStats
+}
+
+// ParseLineups reads the players flagged as starters from a game summary.
+// Before tip-off ESPN flags them in the box score's player lists; teams missing
+// there are read from the summary's rosters. Teams with no starters flagged are
+// left out.
+func ParseLineups(summaryData map[string]interface{}) []*ParsedLineup {
+	var lineups []*ParsedLineup
+	seen := make(map[string]bool)
+
+	for _, teamInterface := range extractArray(extractMap(summaryData, "boxscore"), "players") {
+		teamData, ok := teamInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		statistics := extractArray(teamData, "statistics")
+		if len(statistics) == 0 {
+			continue
+		}
+		statGroup, ok := statistics[0].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if lineup := parseLineup(extractMap(teamData, "team"), extractArray(statGroup, "athletes")); lineup != nil {
+			lineups = append(lineups, lineup)
+			seen[lineup.TeamAbbr] = true
+		}
+	}
+
+	for _, teamInterface := range extractArray(summaryData, "rosters") {
+		teamData, ok := teamInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		lineup := parseLineup(extractMap(teamData, "team"), extractArray(teamData, "roster"))
+		if lineup != nil && !seen[lineup.TeamAbbr] {
+			lineups = append(lineups, lineup)
+			seen[lineup.TeamAbbr] = true
+		}
+	}
+
+	return lineups
+}
+
+// parseLineup collects the athletes flagged as starters from one team's list
+func parseLineup(team map[string]interface{}, athletes []interface{}) *ParsedLineup {
+	lineup := &ParsedLineup{
+		TeamAbbr:   strings.ToUpper(extractString(team, "abbreviation")),
+		ESPNTeamID: extractString(team, "id"),
+	}
+	for _, athleteInterface := range athletes {
+		athleteData, ok := athleteInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if starter, ok := athleteData["starter"].(bool); !ok || !starter {
+			continue
+		}
+
+		athlete := extractMap(athleteData, "athlete")
+		parsed := &ParsedPlayerStats{
+			TeamAbbr:     lineup.TeamAbbr,
+			ESPNPlayerID: extractString(athlete, "id"),
+			PlayerName:   fallbackString(extractString(athlete, "displayName"), extractString(athlete, "shortName")),
+			Jersey:       extractString(athlete, "jersey"),
+		}
+		// The game's listed position, when given, beats the roster position
+		parsed.Position = fallbackString(
+			extractString(extractMap(athleteData, "position"), "abbreviation"),
+			extractString(extractMap(athlete, "position"), "abbreviation"),
+		)
+		if parsed.ESPNPlayerID == "" && parsed.PlayerName == "" {
+			continue
+		}
+		lineup.Starters = append(lineup.Starters, parsed)
+	}
+	if lineup.TeamAbbr == "" || len(lineup.Starters) == 0 {
+		return nil
+	}
+	return lineup
+}
+
+// SyncLineup stores the starting lineups ESPN lists for an upcoming game. It
+// returns a lineups.confirmed event when both teams list five starters and
+// either lineup differs from the one on record, and nil otherwise.
+func (i *Ingester) SyncLineup(ctx context.Context, game *store.Game) (*publisher.LineupEvent, error) {
+	ctx = store.WithPrimary(ctx)
+	if err := i.ensureTeamLookup(ctx); err != nil {
+		return nil, err
+	}
+
+	summary, err := i.client.FetchGameSummary(ctx, i.league.ESPNPath, game.ExternalID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching summary for game %s: %w", game.ExternalID, err)
+	}
+
+	changed := false
+	teams := make(map[int]*publisher.EventLineup)
+	for _, parsed := range ParseLineups(summary) {
+		teamID, err := i.lookupTeamID(parsed.TeamAbbr, parsed.ESPNTeamID)
+		if err != nil || (teamID != game.HomeTeamID && teamID != game.AwayTeamID) {
+			log.Printf("[ingest] Lineup team %s is not in game %s", parsed.TeamAbbr, game.ExternalID)
+			continue
+		}
+
+		lineup := &publisher.EventLineup{
+			Team: publisher.GameEventTeam{TeamID: teamID, ESPNID: parsed.ESPNTeamID, Abbreviation: parsed.TeamAbbr},
+		}
+		var starters []*store.GameStarter
+		i.primePlayerIDs(ctx, parsed.Starters)
+		for _, p := range parsed.Starters {
+			playerID, err := i.resolvePlayerID(ctx, game.Sport, p, teamID)
+			if err != nil {
+				log.Printf("[ingest] Unable to resolve starter %s: %v", p.PlayerName, err)
+				continue
+			}
+			slot := len(starters) + 1
+			starters = append(starters, &store.GameStarter{
+				GameID:     game.GameID,
+				TeamID:     teamID,
+				PlayerID:   playerID,
+				LineupSlot: slot,
+				Position:   store.NullString{String: p.Position, Valid: p.Position != ""},
+			})
+			lineup.Starters = append(lineup.Starters, publisher.EventStarter{
+				PlayerID:   playerID,
+				ESPNID:     p.ESPNPlayerID,
+				FullName:   p.PlayerName,
+				Position:   p.Position,
+				LineupSlot: slot,
+			})
+		}
+		if len(starters) == 0 {
+			continue
+		}
+
+		written, err := i.starters.ReplaceTeamLineup(ctx, game.GameID, teamID, starters)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || written
+		teams[teamID] = lineup
+	}
+
+	home, away := teams[game.HomeTeamID], teams[game.AwayTeamID]
+	if !changed || home == nil || away == nil || len(home.Starters) != lineupSize || len(away.Starters) != lineupSize {
+		return nil, nil
+	}
+
+	event := &publisher.LineupEvent{
+		Type:       publisher.LineupsConfirmed,
+		Sport:      game.Sport,
+		GameID:     game.GameID,
+		ESPNID:     game.ExternalID,
+		HomeTeam:   *home,
+		AwayTeam:   *away,
+		OccurredAt: time.Now().UTC(),
+	}
+	if game.GameTime.Valid {
+		tipOff := game.GameTime.Time
+		event.TipOff = &tipOff
+	}
+	return event, nil
+}
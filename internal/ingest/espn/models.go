@@ -19,6 +19,7 @@ type ParsedGame struct {
 	HomeTeam  TeamMeta
 	AwayTeam  TeamMeta
 	SeasonType string
+	Series     *store.PlayoffSeries // nil outside the playoffs; carries round details only
 }
 
 // ParsedPlayerStats bundles player metadata with box score output.
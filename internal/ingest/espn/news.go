@@ -0,0 +1,224 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// NewsConfig tunes the news_sync job
+type NewsConfig struct {
+	ArticlesPerFeed   int // newest articles requested from each feed
+	RequestsPerMinute int // ESPN news feed fetch budget
+}
+
+// DefaultNewsConfig reads the 25 newest articles of each feed at 30 requests a minute
+func DefaultNewsConfig() NewsConfig {
+	return NewsConfig{
+		ArticlesPerFeed:   25,
+		RequestsPerMinute: 30,
+	}
+}
+
+// ParsedArticle is one article from an ESPN news feed
+type ParsedArticle struct {
+	ExternalID     string
+	Type           string
+	Headline       string
+	Description    string
+	Link           string
+	PublishedAt    time.Time
+	TeamESPNIDs    []string
+	AthleteESPNIDs []string
+}
+
+// NewsSyncResult summarizes one news sync pass
+type NewsSyncResult struct {
+	Feeds   int                  `json:"feeds"`
+	Fetched int                  `json:"fetched"`
+	Added   []*store.NewsArticle `json:"-"`
+	Failed  int                  `json:"failed"` // feeds that could not be fetched
+}
+
+func (r NewsSyncResult) String() string {
+	return fmt.Sprintf("%d feeds, %d articles fetched, %d new, %d feeds failed", r.Feeds, r.Fetched, len(r.Added), r.Failed)
+}
+
+// ParseNews reads the articles of a news feed response. Articles without an ID,
+// headline, or publish time are dropped.
+func ParseNews(data map[string]interface{}) []*ParsedArticle {
+	var parsed []*ParsedArticle
+	for _, raw := range extractArray(data, "articles") {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		a := &ParsedArticle{
+			ExternalID:  newsID(entry, "id"),
+			Type:        extractString(entry, "type"),
+			Headline:    strings.TrimSpace(extractString(entry, "headline")),
+			Description: strings.TrimSpace(extractString(entry, "description")),
+			Link:        extractString(extractMap(extractMap(entry, "links"), "web"), "href"),
+		}
+		published, err := parseNewsTime(extractString(entry, "published"))
+		if a.ExternalID == "" || a.Headline == "" || err != nil {
+			continue
+		}
+		a.PublishedAt = published
+
+		for _, rawCategory := range extractArray(entry, "categories") {
+			category, ok := rawCategory.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			switch extractString(category, "type") {
+			case "team":
+				if id := fallbackString(newsID(category, "teamId"), newsID(extractMap(category, "team"), "id")); id != "" {
+					a.TeamESPNIDs = append(a.TeamESPNIDs, id)
+				}
+			case "athlete":
+				if id := fallbackString(newsID(category, "athleteId"), newsID(extractMap(category, "athlete"), "id")); id != "" {
+					a.AthleteESPNIDs = append(a.AthleteESPNIDs, id)
+				}
+			}
+		}
+
+		parsed = append(parsed, a)
+	}
+	return parsed
+}
+
+// newsID reads an ID that ESPN sends as either a number or a string
+func newsID(m map[string]interface{}, key string) string {
+	if s := extractString(m, key); s != "" {
+		return s
+	}
+	if n := extractInt(m, key); n > 0 {
+		return strconv.Itoa(n)
+	}
+	return ""
+}
+
+func parseNewsTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z"} {
+		if ts, err := time.Parse(layout, value); err == nil {
+			return ts, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized news timestamp %q", value)
+}
+
+// SyncNews reads the league news feed and each team's feed and stores the
+// articles' metadata, linked to the teams and stored players they are tagged
+// with. Athletes that are not stored yet are not linked. Articles already
+// stored from another feed have their links merged; only new articles are
+// returned in Added. A feed that fails is skipped and counted.
+func (i *Ingester) SyncNews(ctx context.Context, config NewsConfig) (NewsSyncResult, error) {
+	defaults := DefaultNewsConfig()
+	if config.ArticlesPerFeed <= 0 {
+		config.ArticlesPerFeed = defaults.ArticlesPerFeed
+	}
+
+	ctx = store.WithPrimary(ctx)
+	ctx = WithRequestLimiter(ctx, NewRequestLimiter(config.RequestsPerMinute))
+
+	var result NewsSyncResult
+	if err := i.ensureTeamLookup(ctx); err != nil {
+		return result, err
+	}
+	teams, err := i.teamRepo.GetByLeague(ctx, i.league.TeamLeague)
+	if err != nil {
+		return result, fmt.Errorf("load teams: %w", err)
+	}
+
+	// The league feed first, then every team with an ESPN ID
+	feeds := []string{""}
+	for _, team := range teams {
+		if team.ExternalID != "" {
+			feeds = append(feeds, team.ExternalID)
+		}
+	}
+
+	for _, espnTeamID := range feeds {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		result.Feeds++
+
+		data, err := i.client.FetchNews(ctx, i.league.ESPNPath, espnTeamID, config.ArticlesPerFeed)
+		if err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			result.Failed++
+			log.Printf("[news] Failed to fetch feed (team=%q): %v", espnTeamID, err)
+			continue
+		}
+
+		parsed := ParseNews(data)
+		result.Fetched += len(parsed)
+		for _, p := range parsed {
+			article, err := i.newsArticle(ctx, p)
+			if err != nil {
+				return result, err
+			}
+			added, err := i.newsRepo.Upsert(ctx, article)
+			if err != nil {
+				return result, err
+			}
+			if added {
+				result.Added = append(result.Added, article)
+			}
+		}
+	}
+
+	if result.Failed > 0 && result.Failed == result.Feeds {
+		return result, fmt.Errorf("all %d news feeds failed", result.Failed)
+	}
+	return result, nil
+}
+
+// newsArticle resolves a parsed article's ESPN team and athlete IDs to stored IDs
+func (i *Ingester) newsArticle(ctx context.Context, p *ParsedArticle) (*store.NewsArticle, error) {
+	article := &store.NewsArticle{
+		Sport:       store.DefaultSport,
+		ExternalID:  p.ExternalID,
+		ArticleType: nullString(p.Type),
+		Headline:    p.Headline,
+		Description: nullString(p.Description),
+		Link:        nullString(p.Link),
+		PublishedAt: p.PublishedAt,
+		TeamIDs:     []int{},
+		PlayerIDs:   []int{},
+	}
+
+	seenTeams := make(map[int]bool)
+	for _, espnID := range p.TeamESPNIDs {
+		if teamID, err := i.lookupTeamID("", espnID); err == nil && !seenTeams[teamID] {
+			seenTeams[teamID] = true
+			article.TeamIDs = append(article.TeamIDs, teamID)
+		}
+	}
+
+	if len(p.AthleteESPNIDs) > 0 {
+		players, err := i.playerRepo.GetByExternalIDs(ctx, p.AthleteESPNIDs)
+		if err != nil {
+			return nil, err
+		}
+		seenPlayers := make(map[int]bool)
+		for _, espnID := range p.AthleteESPNIDs {
+			if player, ok := players[espnID]; ok && !seenPlayers[player.PlayerID] {
+				seenPlayers[player.PlayerID] = true
+				article.PlayerIDs = append(article.PlayerIDs, player.PlayerID)
+			}
+		}
+	}
+
+	return article, nil
+}
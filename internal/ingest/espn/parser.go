@@ -1,8 +1,9 @@
 package espn
 
 import (
-	"database/sql"
+	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -29,6 +30,13 @@ const (
 	statLabelPlusMinus = "+/-"
 )
 
+// Labels of optional columns ESPN adds to some box scores. Player columns are
+// matched against both the abbreviated names and the long keys.
+var (
+	statLabelsTechnical = []string{"TECH", "TF", "technicalFouls"}
+	statLabelsFlagrant  = []string{"FLAG", "FF", "flagrantFouls"}
+)
+
 // ParseScoreboardGames extracts games without metadata (legacy helper).
 func ParseScoreboardGames(scoreboardData map[string]interface{}, seasonID int) ([]*store.Game, error) {
 	detailed, err := ParseScoreboardGamesDetailed(scoreboardData, seasonID)
@@ -90,7 +98,7 @@ func parseGameFromEventDetailed(event map[string]interface{}, seasonID int) (*Pa
 			est, _ := time.LoadLocation("America/New_York")
 			gameTimeEST := gameTime.In(est)
 			game.GameDate = gameTimeEST
-			game.GameTime = sql.NullTime{Time: gameTimeEST, Valid: true}
+			game.GameTime = store.NullTime{Time: gameTimeEST, Valid: true}
 		} else {
 			fmt.Printf("[parser] Warning: Failed to parse date '%s' for game %s: %v\n", dateStr, game.ExternalID, err)
 		}
@@ -102,10 +110,10 @@ func parseGameFromEventDetailed(event map[string]interface{}, seasonID int) (*Pa
 	game.Status = normalizeGameStatus(parseGameStatus(status))
 
 	if period := extractInt(status, "period"); period > 0 {
-		game.Period = sql.NullInt32{Int32: int32(period), Valid: true}
+		game.Period = store.NullInt32{Int32: int32(period), Valid: true}
 	}
 	if clock := extractString(status, "displayClock"); clock != "" {
-		game.Clock = sql.NullString{String: clock, Valid: true}
+		game.Clock = store.NullString{String: clock, Valid: true}
 	}
 
 	competitions := extractArray(event, "competitions")
@@ -136,42 +144,93 @@ func parseGameFromEventDetailed(event map[string]interface{}, seasonID int) (*Pa
 			homeMeta = meta
 			game.HomeTeamID = -1
 			if score > 0 {
-				game.HomeScore = sql.NullInt32{Int32: int32(score), Valid: true}
+				game.HomeScore = store.NullInt32{Int32: int32(score), Valid: true}
 			}
 		} else if homeAway == "away" {
 			awayMeta = meta
 			game.AwayTeamID = -1
 			if score > 0 {
-				game.AwayScore = sql.NullInt32{Int32: int32(score), Valid: true}
+				game.AwayScore = store.NullInt32{Int32: int32(score), Valid: true}
 			}
 		}
 	}
 
 	venue := extractMap(comp, "venue")
 	if venueName := extractString(venue, "fullName"); venueName != "" {
-		game.Venue = sql.NullString{String: venueName, Valid: true}
+		game.Venue = store.NullString{String: venueName, Valid: true}
 	}
 	if attendance := extractInt(comp, "attendance"); attendance > 0 {
-		game.Attendance = sql.NullInt32{Int32: int32(attendance), Valid: true}
+		game.Attendance = store.NullInt32{Int32: int32(attendance), Valid: true}
 	}
+	game.Broadcasts = parseBroadcasts(comp)
 
 	// SeasonType is no longer stored in Game struct (v2 schema)
 	// It's managed through the seasons table
 	var seasonType string
+	var phase int
 	if season := extractMap(event, "season"); len(season) > 0 {
-		if phase := extractInt(season, "type"); phase > 0 {
+		if phase = extractInt(season, "type"); phase > 0 {
 			seasonType = seasonTypeFromCode(phase)
 		}
 	}
 
+	series := parsePlayoffSeries(comp)
+	game.GameType = classifyGameType(event, comp, phase)
+	if series != nil && game.GameType == store.GameTypeRegular {
+		game.GameType = store.GameTypePlayoffs
+	}
+	if tournament := parseTournamentMetadata(comp); tournament != nil {
+		if encoded, err := json.Marshal(tournament); err == nil {
+			game.Metadata = store.NullString{String: string(encoded), Valid: true}
+		}
+	}
+
 	return &ParsedGame{
 		Game:       game,
 		HomeTeam:   homeMeta,
 		AwayTeam:   awayMeta,
 		SeasonType: seasonType,
+		Series:     series,
 	}, nil
 }
 
+// parseBroadcasts returns the networks carrying a competition in ESPN's order,
+// without repeats. Scoreboard events list them as {"market": "national",
+// "names": ["ESPN"]}; summary headers as {"media": {"shortName": "ESPN"}}.
+// geoBroadcasts, in the summary shape, is used when broadcasts is absent.
+func parseBroadcasts(comp map[string]interface{}) []string {
+	entries := extractArray(comp, "broadcasts")
+	if len(entries) == 0 {
+		entries = extractArray(comp, "geoBroadcasts")
+	}
+
+	networks := []string{}
+	seen := make(map[string]bool)
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		networks = append(networks, name)
+	}
+
+	for _, entry := range entries {
+		broadcast, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, name := range extractArray(broadcast, "names") {
+			if str, ok := name.(string); ok {
+				add(str)
+			}
+		}
+		media := extractMap(broadcast, "media")
+		add(fallbackString(extractString(media, "shortName"), extractString(media, "name")))
+	}
+	return networks
+}
+
 // ParseBoxScore returns player stats without metadata (legacy helper).
 func ParseBoxScore(summaryData map[string]interface{}, gameID string) ([]*store.PlayerGameStats, error) {
 	detailed, err := ParseBoxScoreDetailed(summaryData, gameID)
@@ -224,6 +283,13 @@ func ParseBoxScoreDetailed(summaryData map[string]interface{}, gameID string) ([
 				statIndexMap[name] = i
 			}
 		}
+		for i, keyInterface := range extractArray(statGroup, "keys") {
+			if key, ok := keyInterface.(string); ok {
+				if _, taken := statIndexMap[key]; !taken {
+					statIndexMap[key] = i
+				}
+			}
+		}
 
 		athletes := extractArray(statGroup, "athletes")
 
@@ -295,7 +361,7 @@ func parsePlayerStatsDetailed(athleteData map[string]interface{}, gameID string,
 
 	// Parse stats using dynamic labels (robust to API changes)
 	if minStat := getStat(statLabelMinutes); minStat != nil {
-		playerStats.MinutesPlayed = sql.NullFloat64{Float64: parseMinutes(fmt.Sprint(minStat)), Valid: true}
+		playerStats.SetSecondsPlayed(parseSeconds(fmt.Sprint(minStat)))
 	}
 	
 	if ptsStat := getStat(statLabelPoints); ptsStat != nil {
@@ -354,12 +420,25 @@ func parsePlayerStatsDetailed(athleteData map[string]interface{}, gameID string,
 
 	if plusMinusStat := getStat(statLabelPlusMinus); plusMinusStat != nil {
 		if plusMinus := parsePlusMinus(fmt.Sprint(plusMinusStat)); plusMinus != 0 {
-			playerStats.PlusMinus = sql.NullInt32{Int32: int32(plusMinus), Valid: true}
+			playerStats.PlusMinus = store.NullInt32{Int32: int32(plusMinus), Valid: true}
 		}
 	}
 
-	playerStats.TrueShootingPct = calculateTrueShootingPct(playerStats)
-	playerStats.EffectiveFGPct = calculateEffectiveFGPct(playerStats)
+	// Only some box scores carry these; absent columns stay null
+	for _, label := range statLabelsTechnical {
+		if v := getStat(label); v != nil {
+			playerStats.TechnicalFouls = parseOptionalInt(fmt.Sprint(v))
+			break
+		}
+	}
+	for _, label := range statLabelsFlagrant {
+		if v := getStat(label); v != nil {
+			playerStats.FlagrantFouls = parseOptionalInt(fmt.Sprint(v))
+			break
+		}
+	}
+
+	playerStats.UpdateShootingPcts()
 
 	if starter, ok := athleteData["starter"].(bool); ok {
 		playerStats.Starter = starter
@@ -427,9 +506,20 @@ func parseInt(v interface{}) int {
 	}
 }
 
-func parseMinutes(minutesStr string) float64 {
+// parseOptionalInt parses a count that may be missing ("" or "--") as null
+func parseOptionalInt(value string) store.NullInt32 {
+	v, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || v < 0 {
+		return store.NullInt32{}
+	}
+	return store.NullInt32{Int32: int32(v), Valid: true}
+}
+
+// parseSeconds converts a box score minutes value ("34:12", "34", or "34.2") to
+// whole seconds played
+func parseSeconds(minutesStr string) int {
 	if minutesStr == "" || minutesStr == "0" {
-		return 0.0
+		return 0
 	}
 
 	if strings.Contains(minutesStr, ":") {
@@ -439,11 +529,11 @@ func parseMinutes(minutesStr string) float64 {
 		if len(parts) > 1 {
 			secs, _ = strconv.Atoi(parts[1])
 		}
-		return float64(mins) + (float64(secs) / 60.0)
+		return mins*60 + secs
 	}
 
 	f, _ := strconv.ParseFloat(minutesStr, 64)
-	return f
+	return int(math.Round(f * 60))
 }
 
 func parsePlusMinus(pmStr string) int {
@@ -506,29 +596,6 @@ func seasonTypeFromCode(code int) string {
 	}
 }
 
-func calculateTrueShootingPct(stats *store.PlayerGameStats) sql.NullFloat64 {
-	if stats.FieldGoalsAttempted == 0 && stats.FreeThrowsAttempted == 0 {
-		return sql.NullFloat64{Valid: false}
-	}
-
-	denominator := 2.0 * (float64(stats.FieldGoalsAttempted) + (0.44 * float64(stats.FreeThrowsAttempted)))
-	if denominator == 0 {
-		return sql.NullFloat64{Valid: false}
-	}
-
-	ts := float64(stats.Points) / denominator
-	return sql.NullFloat64{Float64: ts, Valid: true}
-}
-
-func calculateEffectiveFGPct(stats *store.PlayerGameStats) sql.NullFloat64 {
-	if stats.FieldGoalsAttempted == 0 {
-		return sql.NullFloat64{Valid: false}
-	}
-
-	efg := (float64(stats.FieldGoalsMade) + (0.5 * float64(stats.ThreePointersMade))) / float64(stats.FieldGoalsAttempted)
-	return sql.NullFloat64{Float64: efg, Valid: true}
-}
-
 // ParsedTeamStats holds team stats with metadata for ingestion
 type ParsedTeamStats struct {
 	Stats    *store.TeamGameStats
@@ -568,9 +635,68 @@ func ParseTeamStats(summaryData map[string]interface{}, gameID string) ([]*Parse
 		}
 	}
 
+	// ESPN rarely reports bench points; the player lines give them
+	bench := benchPointsByTeam(boxscore)
+	for _, parsed := range teamStats {
+		if points, ok := bench[parsed.TeamAbbr]; ok && !parsed.Stats.BenchPoints.Valid {
+			parsed.Stats.BenchPoints = store.NullInt32{Int32: int32(points), Valid: true}
+		}
+	}
+
 	return teamStats, nil
 }
 
+// benchPointsByTeam sums the points of players who did not start, by team
+// abbreviation. Teams whose player lines lack starter flags or points are left out.
+func benchPointsByTeam(boxscore map[string]interface{}) map[string]int {
+	bench := make(map[string]int)
+	for _, teamInterface := range extractArray(boxscore, "players") {
+		teamData, ok := teamInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		teamAbbr := strings.ToUpper(extractString(extractMap(teamData, "team"), "abbreviation"))
+
+		statistics := extractArray(teamData, "statistics")
+		if len(statistics) == 0 {
+			continue
+		}
+		statGroup, ok := statistics[0].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pointsIdx := -1
+		for i, name := range extractArray(statGroup, "names") {
+			if name == statLabelPoints {
+				pointsIdx = i
+			}
+		}
+		if pointsIdx < 0 {
+			continue
+		}
+
+		points, flagged := 0, false
+		for _, athleteInterface := range extractArray(statGroup, "athletes") {
+			athlete, ok := athleteInterface.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			starter, ok := athlete["starter"].(bool)
+			if !ok {
+				continue
+			}
+			flagged = true
+			if stats := extractArray(athlete, "stats"); !starter && pointsIdx < len(stats) {
+				points += parseInt(stats[pointsIdx])
+			}
+		}
+		if flagged {
+			bench[teamAbbr] = points
+		}
+	}
+	return bench
+}
+
 // parseTeamStatsFromStatArray parses ESPN's flat stat array format
 func parseTeamStatsFromStatArray(statistics []interface{}, teamAbbr string) (*ParsedTeamStats, error) {
 	stats := &store.TeamGameStats{
@@ -681,6 +807,16 @@ func parseTeamStatsFromStatArray(statistics []interface{}, teamAbbr string) (*Pa
 		}
 	}
 
+	// Fouls and scoring breakdowns ESPN reports for some games only
+	stats.TechnicalFouls = parseOptionalInt(getStat("totalTechnicalFouls", "technicalFouls", "Technical Fouls"))
+	stats.FlagrantFouls = parseOptionalInt(getStat("flagrantFouls", "Flagrant Fouls"))
+	stats.FastBreakPoints = parseOptionalInt(getStat("fastBreakPoints", "Fast Break Points"))
+	stats.PointsInPaint = parseOptionalInt(getStat("pointsInPaint", "Points in Paint"))
+	stats.PointsOffTurnovers = parseOptionalInt(getStat("turnoverPoints", "turnoversPoints", "Points Off Turnovers"))
+	stats.SecondChancePoints = parseOptionalInt(getStat("secondChancePoints", "Second Chance Points"))
+	stats.BenchPoints = parseOptionalInt(getStat("benchPoints", "Bench Points"))
+	stats.LargestLead = parseOptionalInt(getStat("largestLead", "Largest Lead"))
+
 	// Calculate points from scoring if not directly available
 	// Points = (FGM * 2) + (3PM * 3) + FTM - but we need to subtract the 3PM from FGM first
 	// Actually: Points = ((FGM - 3PM) * 2) + (3PM * 3) + FTM
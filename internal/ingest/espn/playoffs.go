@@ -0,0 +1,77 @@
+package espn
+
+import (
+	"strings"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// parsePlayoffSeries reads the competition's series block and notes headline
+// ("East 1st Round - Game 3"). It returns nil for regular season and play-in games.
+func parsePlayoffSeries(comp map[string]interface{}) *store.PlayoffSeries {
+	series := extractMap(comp, "series")
+	if extractString(series, "type") != "playoff" {
+		return nil
+	}
+
+	title := ""
+	for _, raw := range extractArray(comp, "notes") {
+		if note, ok := raw.(map[string]interface{}); ok {
+			if headline := extractString(note, "headline"); headline != "" {
+				title, _, _ = strings.Cut(headline, " - Game")
+				break
+			}
+		}
+	}
+	if title == "" {
+		title = extractString(series, "title")
+	}
+	if strings.Contains(strings.ToLower(title), "play-in") {
+		return nil
+	}
+
+	parsed := &store.PlayoffSeries{
+		BestOf: extractInt(series, "totalCompetitions"),
+	}
+	if title = strings.TrimSpace(title); title != "" {
+		parsed.RoundName = store.NullString{String: title, Valid: true}
+	}
+
+	round, conference := parseSeriesRound(title)
+	if round > 0 {
+		parsed.Round = store.NullInt32{Int32: int32(round), Valid: true}
+	}
+	if conference != "" {
+		parsed.Conference = store.NullString{String: conference, Valid: true}
+	}
+
+	return parsed
+}
+
+// parseSeriesRound maps titles like "East 1st Round", "West Semifinals",
+// "Eastern Conference Finals", or "NBA Finals" to a round number and conference.
+func parseSeriesRound(title string) (int, string) {
+	lower := strings.ToLower(title)
+
+	conference := ""
+	switch {
+	case strings.Contains(lower, "east"):
+		conference = "East"
+	case strings.Contains(lower, "west"):
+		conference = "West"
+	}
+
+	switch {
+	case strings.Contains(lower, "1st round"), strings.Contains(lower, "first round"):
+		return 1, conference
+	case strings.Contains(lower, "semifinal"):
+		return 2, conference
+	case strings.Contains(lower, "nba finals"):
+		return 4, ""
+	case strings.Contains(lower, "final") && conference != "":
+		return 3, conference
+	case strings.Contains(lower, "final"):
+		return 4, ""
+	}
+	return 0, conference
+}
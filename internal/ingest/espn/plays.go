@@ -0,0 +1,138 @@
+package espn
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// ParsedPlay wraps a play with the ESPN identifiers needed to map team and player.
+type ParsedPlay struct {
+	Play         *store.GamePlay
+	ESPNTeamID   string
+	ESPNPlayerID string // first participant, usually the shooter or rebounder
+}
+
+// ParsePlays extracts play-by-play from a game summary in sequence order.
+func ParsePlays(summaryData map[string]interface{}) []*ParsedPlay {
+	rawPlays := extractArray(summaryData, "plays")
+	plays := make([]*ParsedPlay, 0, len(rawPlays))
+
+	for idx, raw := range rawPlays {
+		p, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		sequence := extractInt(p, "sequenceNumber")
+		if sequence == 0 {
+			sequence = idx + 1
+		}
+
+		play := &store.GamePlay{
+			Sequence:     sequence,
+			Period:       extractInt(extractMap(p, "period"), "number"),
+			ScoringPlay:  extractBool(p, "scoringPlay"),
+			ShootingPlay: extractBool(p, "shootingPlay"),
+			ScoreValue:   extractInt(p, "scoreValue"),
+			HomeScore:    extractInt(p, "homeScore"),
+			AwayScore:    extractInt(p, "awayScore"),
+		}
+		if id := extractString(p, "id"); id != "" {
+			play.ExternalID = store.NullString{String: id, Valid: true}
+		}
+		if clock := extractString(extractMap(p, "clock"), "displayValue"); clock != "" {
+			play.Clock = store.NullString{String: clock, Valid: true}
+			play.ClockSeconds = store.NullInt32{Int32: int32(parseClockSeconds(clock)), Valid: true}
+		}
+		if playType := extractString(extractMap(p, "type"), "text"); playType != "" {
+			play.PlayType = store.NullString{String: playType, Valid: true}
+		}
+		if text := extractString(p, "text"); text != "" {
+			play.Description = store.NullString{String: text, Valid: true}
+		}
+		if wallclock, err := time.Parse(time.RFC3339, extractString(p, "wallclock")); err == nil {
+			play.Wallclock = store.NullTime{Time: wallclock, Valid: true}
+		}
+
+		parsed := &ParsedPlay{
+			Play:       play,
+			ESPNTeamID: extractString(extractMap(p, "team"), "id"),
+		}
+		if participants := extractArray(p, "participants"); len(participants) > 0 {
+			if first, ok := participants[0].(map[string]interface{}); ok {
+				parsed.ESPNPlayerID = extractString(extractMap(first, "athlete"), "id")
+			}
+		}
+
+		plays = append(plays, parsed)
+	}
+
+	return plays
+}
+
+// ParseQuarterScores reads per-period points from the summary header linescores.
+// It returns nil when the game has not started.
+func ParseQuarterScores(summaryData map[string]interface{}) *store.QuarterScores {
+	competitions := extractArray(extractMap(summaryData, "header"), "competitions")
+	if len(competitions) == 0 {
+		return nil
+	}
+	comp, ok := competitions[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	scores := &store.QuarterScores{}
+	for _, raw := range extractArray(comp, "competitors") {
+		competitor, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var periods []int
+		for _, ls := range extractArray(competitor, "linescores") {
+			line, ok := ls.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if _, ok := line["value"]; ok {
+				periods = append(periods, extractInt(line, "value"))
+			} else {
+				periods = append(periods, parseStatValue(extractString(line, "displayValue")))
+			}
+		}
+
+		switch extractString(competitor, "homeAway") {
+		case "home":
+			scores.Home = periods
+		case "away":
+			scores.Away = periods
+		}
+	}
+
+	if len(scores.Home) == 0 && len(scores.Away) == 0 {
+		return nil
+	}
+	return scores
+}
+
+// parseClockSeconds converts "5:23" or "45.2" to whole seconds remaining
+func parseClockSeconds(clock string) int {
+	if mins, secs, ok := strings.Cut(clock, ":"); ok {
+		m, _ := strconv.Atoi(mins)
+		s, _ := strconv.ParseFloat(secs, 64)
+		return m*60 + int(s)
+	}
+	s, _ := strconv.ParseFloat(clock, 64)
+	return int(s)
+}
+
+func extractBool(m map[string]interface{}, key string) bool {
+	if v, ok := m[key].(bool); ok {
+		return v
+	}
+	return false
+}
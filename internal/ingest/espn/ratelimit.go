@@ -0,0 +1,72 @@
+package espn
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RequestLimiter spaces ESPN requests evenly to stay under a requests-per-minute budget.
+// A nil limiter never waits.
+type RequestLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewRequestLimiter returns a limiter allowing perMinute requests per minute,
+// or nil (unlimited) when perMinute is not positive
+func NewRequestLimiter(perMinute int) *RequestLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &RequestLimiter{interval: time.Minute / time.Duration(perMinute)}
+}
+
+// Wait blocks until the next request slot is free or ctx is done
+func (l *RequestLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type limiterKey struct{}
+
+// WithRequestLimiter makes every client request made under ctx wait on limiter.
+// Backfill jobs use it to apply their own throughput settings.
+func WithRequestLimiter(ctx context.Context, limiter *RequestLimiter) context.Context {
+	if limiter == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, limiterKey{}, limiter)
+}
+
+// waitForRequest applies the limiter carried by ctx, if any
+func waitForRequest(ctx context.Context) error {
+	limiter, _ := ctx.Value(limiterKey{}).(*RequestLimiter)
+	return limiter.Wait(ctx)
+}
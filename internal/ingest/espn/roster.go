@@ -0,0 +1,135 @@
+package espn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// RosterSyncResult summarizes one roster sync pass
+type RosterSyncResult struct {
+	Teams        int                           `json:"teams"`
+	Players      int                           `json:"players"`
+	TeamChanges  int                           `json:"team_changes"`
+	FailedTeams  int                           `json:"failed_teams"`
+	CoachChanges []*publisher.CoachChangeEvent `json:"-"` // head coach replacements, for the coaches stream
+}
+
+func (r RosterSyncResult) String() string {
+	return fmt.Sprintf("%d teams, %d players, %d team changes, %d coach changes, %d teams failed",
+		r.Teams, r.Players, r.TeamChanges, len(r.CoachChanges), r.FailedTeams)
+}
+
+// ParseRoster reads the athletes from a team roster response. Only identity and
+// bio fields are populated; Stats is nil.
+func ParseRoster(rosterData map[string]interface{}) []*ParsedPlayerStats {
+	var players []*ParsedPlayerStats
+	for _, raw := range extractArray(rosterData, "athletes") {
+		athlete, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		parsed := &ParsedPlayerStats{
+			ESPNPlayerID: extractString(athlete, "id"),
+			PlayerName:   fallbackString(extractString(athlete, "displayName"), extractString(athlete, "fullName")),
+			Jersey:       extractString(athlete, "jersey"),
+			Height:       fallbackString(extractString(athlete, "displayHeight"), extractString(athlete, "height")),
+		}
+		if parsed.ESPNPlayerID == "" || parsed.PlayerName == "" {
+			continue
+		}
+
+		if w, err := strconv.Atoi(fmt.Sprint(athlete["weight"])); err == nil && w > 0 {
+			parsed.Weight = w
+		}
+		if position := extractMap(athlete, "position"); len(position) > 0 {
+			parsed.Position = extractString(position, "abbreviation")
+		}
+		if dob := extractString(athlete, "dateOfBirth"); dob != "" {
+			if ts, err := time.Parse("2006-01-02T15:04Z", dob); err == nil {
+				parsed.BirthDate = &ts
+			} else if ts, err := time.Parse(time.RFC3339, dob); err == nil {
+				parsed.BirthDate = &ts
+			}
+		}
+
+		players = append(players, parsed)
+	}
+	return players
+}
+
+// SyncRosters pulls every team's current ESPN roster, creates players not yet
+// seen in a box score, and moves players whose team changed in player_team_history.
+// The roster's head coach is recorded in coach_team_history, and replacements
+// are returned in CoachChanges. A team that fails to fetch is counted and skipped.
+func (i *Ingester) SyncRosters(ctx context.Context, seasonID int) (RosterSyncResult, error) {
+	ctx = store.WithPrimary(ctx)
+
+	var result RosterSyncResult
+	teams, err := i.teamRepo.GetAll(ctx)
+	if err != nil {
+		return result, fmt.Errorf("load teams: %w", err)
+	}
+
+	today := time.Now()
+	for _, team := range teams {
+		if team.ExternalID == "" || team.Sport != store.DefaultSport {
+			continue
+		}
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		data, err := i.client.FetchTeamRoster(ctx, BasketballNBA, team.ExternalID)
+		if err != nil {
+			log.Printf("[roster] Failed to fetch %s roster: %v", team.Abbreviation, err)
+			result.FailedTeams++
+			continue
+		}
+		result.Teams++
+
+		if coach := ParseCoach(data); coach != nil {
+			event, err := i.recordHeadCoach(ctx, team, coach, seasonID, today)
+			if err != nil {
+				log.Printf("[roster] Failed to record %s head coach: %v", team.Abbreviation, err)
+			} else if event != nil {
+				result.CoachChanges = append(result.CoachChanges, event)
+			}
+		}
+
+		roster := ParseRoster(data)
+		i.primePlayerIDs(ctx, roster)
+		for _, parsed := range roster {
+			parsed.TeamAbbr = team.Abbreviation
+			playerID, err := i.resolvePlayerID(ctx, team.Sport, parsed, team.TeamID)
+			if err != nil {
+				log.Printf("[roster] Failed to resolve %s (%s): %v", parsed.PlayerName, team.Abbreviation, err)
+				continue
+			}
+			result.Players++
+
+			changed, err := i.playerRepo.SetCurrentTeam(ctx, playerID, team.TeamID, seasonID, today,
+				sql.NullString{String: parsed.Jersey, Valid: parsed.Jersey != ""},
+				sql.NullString{String: parsed.Position, Valid: parsed.Position != ""},
+			)
+			if err != nil {
+				return result, fmt.Errorf("updating team for player %d: %w", playerID, err)
+			}
+			if changed {
+				result.TeamChanges++
+			}
+		}
+	}
+
+	if result.Teams == 0 && result.FailedTeams > 0 {
+		return result, fmt.Errorf("all %d roster fetches failed", result.FailedTeams)
+	}
+	return result, nil
+}
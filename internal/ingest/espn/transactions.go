@@ -0,0 +1,187 @@
+package espn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// ParsedTransaction is one entry from the ESPN transactions feed
+type ParsedTransaction struct {
+	Date         time.Time
+	Description  string
+	Type         string
+	TeamESPNID   string
+	TeamAbbr     string
+	ESPNPlayerID string // empty when ESPN does not link the athlete
+	PlayerName   string
+	Joins        bool // the player joins the team (signing, claim, acquired in a trade)
+	Leaves       bool // the player leaves the team (waived, released, traded away)
+}
+
+// TransactionSyncResult summarizes one transaction sync pass
+type TransactionSyncResult struct {
+	Fetched     int                        `json:"fetched"`
+	Added       []*store.PlayerTransaction `json:"-"`
+	TeamChanges int                        `json:"team_changes"`
+	Skipped     int                        `json:"skipped"` // unknown team or unparseable entry
+}
+
+func (r TransactionSyncResult) String() string {
+	return fmt.Sprintf("%d fetched, %d new, %d team changes, %d skipped", r.Fetched, len(r.Added), r.TeamChanges, r.Skipped)
+}
+
+// ParseTransactions reads the entries of a transactions response. Entries
+// without a date or description are dropped.
+func ParseTransactions(data map[string]interface{}) []*ParsedTransaction {
+	var parsed []*ParsedTransaction
+	for _, raw := range extractArray(data, "transactions") {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		description := strings.TrimSpace(extractString(entry, "description"))
+		date, err := parseTransactionDate(extractString(entry, "date"))
+		if description == "" || err != nil {
+			continue
+		}
+
+		team := extractMap(entry, "team")
+		t := &ParsedTransaction{
+			Date:        date,
+			Description: description,
+			TeamESPNID:  extractString(team, "id"),
+			TeamAbbr:    strings.ToUpper(extractString(team, "abbreviation")),
+		}
+		t.Type, t.Joins, t.Leaves = classifyTransaction(description)
+
+		athlete := extractMap(entry, "athlete")
+		if len(athlete) == 0 {
+			if athletes := extractArray(entry, "athletes"); len(athletes) == 1 {
+				athlete, _ = athletes[0].(map[string]interface{})
+			}
+		}
+		if len(athlete) > 0 {
+			t.ESPNPlayerID = extractString(athlete, "id")
+			t.PlayerName = fallbackString(extractString(athlete, "displayName"), extractString(athlete, "fullName"))
+		}
+
+		parsed = append(parsed, t)
+	}
+	return parsed
+}
+
+func parseTransactionDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04Z", time.RFC3339, "2006-01-02"} {
+		if ts, err := time.Parse(layout, value); err == nil {
+			return ts, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized transaction date %q", value)
+}
+
+// classifyTransaction reads the transaction type and the direction of the move
+// from ESPN's description, e.g. "Waived G John Doe."
+func classifyTransaction(description string) (txType string, joins, leaves bool) {
+	d := strings.ToLower(description)
+	switch {
+	case strings.Contains(d, "claimed"):
+		return store.TransactionClaim, true, false
+	case strings.Contains(d, "acquired"):
+		return store.TransactionTrade, true, false
+	case strings.Contains(d, "traded"):
+		return store.TransactionTrade, false, true
+	case strings.Contains(d, "waived"):
+		return store.TransactionWaiver, false, true
+	case strings.Contains(d, "released"):
+		return store.TransactionRelease, false, true
+	case strings.Contains(d, "signed"):
+		return store.TransactionSigning, true, false
+	case strings.Contains(d, "assigned"):
+		return store.TransactionAssignment, false, false
+	case strings.Contains(d, "recalled"):
+		return store.TransactionRecall, false, false
+	}
+	return store.TransactionOther, false, false
+}
+
+// SyncTransactions stores new entries from the league's transactions feed and
+// moves linked players in player_team_history: signings, claims, and trade
+// acquisitions open a stint on the team; waivers, releases, and trades away
+// close it. Entries already stored are skipped, so each move applies once.
+func (i *Ingester) SyncTransactions(ctx context.Context, seasonID int) (TransactionSyncResult, error) {
+	ctx = store.WithPrimary(ctx)
+
+	var result TransactionSyncResult
+	if err := i.ensureTeamLookup(ctx); err != nil {
+		return result, err
+	}
+
+	data, err := i.client.FetchTransactions(ctx, i.league.ESPNPath)
+	if err != nil {
+		return result, fmt.Errorf("fetch transactions: %w", err)
+	}
+
+	parsed := ParseTransactions(data)
+	result.Fetched = len(parsed)
+	for _, p := range parsed {
+		teamID, err := i.lookupTeamID(p.TeamAbbr, p.TeamESPNID)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+
+		t := &store.PlayerTransaction{
+			Sport:           store.DefaultSport,
+			TransactionDate: p.Date,
+			TransactionType: p.Type,
+			TeamID:          teamID,
+			TeamAbbr:        p.TeamAbbr,
+			PlayerName:      p.PlayerName,
+			Description:     p.Description,
+		}
+		if p.ESPNPlayerID != "" {
+			playerID, err := i.resolvePlayerID(ctx, t.Sport, &ParsedPlayerStats{ESPNPlayerID: p.ESPNPlayerID, PlayerName: p.PlayerName}, teamID)
+			if err != nil {
+				log.Printf("[transactions] Unable to resolve player %s: %v", p.PlayerName, err)
+			} else {
+				t.PlayerID = store.NullInt32{Int32: int32(playerID), Valid: true}
+			}
+		}
+
+		added, err := i.txnRepo.Insert(ctx, t)
+		if err != nil {
+			return result, err
+		}
+		if !added {
+			continue
+		}
+		result.Added = append(result.Added, t)
+
+		if !t.PlayerID.Valid {
+			continue
+		}
+		playerID := int(t.PlayerID.Int32)
+		var changed bool
+		switch {
+		case p.Joins:
+			changed, err = i.playerRepo.SetCurrentTeam(ctx, playerID, teamID, seasonID, p.Date, sql.NullString{}, sql.NullString{})
+		case p.Leaves:
+			changed, err = i.playerRepo.EndTeamStint(ctx, playerID, teamID, p.Date)
+		}
+		if err != nil {
+			return result, fmt.Errorf("updating team history for player %d: %w", playerID, err)
+		}
+		if changed {
+			result.TeamChanges++
+		}
+	}
+
+	return result, nil
+}
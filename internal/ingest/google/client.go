@@ -2,9 +2,12 @@ package google
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
@@ -20,20 +23,85 @@ const (
 	
 	// MinRequestInterval to prevent rate limiting
 	MinRequestInterval = 2 * time.Second
+
+	// maxProxyAttempts caps how many proxies one fetch tries after blocks
+	maxProxyAttempts = 3
 )
 
+// ErrBlocked is returned when Google answers with a CAPTCHA or block page
+var ErrBlocked = errors.New("google served a CAPTCHA or unusual-traffic page")
+
+// blockMarkers are fragments of the pages Google serves instead of results
+// once it suspects automated traffic
+var blockMarkers = []string{
+	"unusual traffic from your computer network",
+	"g-recaptcha",
+	`id="captcha-form"`,
+	"/sorry/index",
+}
+
 // Client handles Google Sports scraping with rate limiting
 type Client struct {
 	lastRequest time.Time
 	interval    time.Duration
 	
-	// Chromedp context for headless browser
-	allocCtx context.Context
-	cancel   context.CancelFunc
+	mode    string
+	baseURL string
+	proxies *proxyPool
+	health  scrapeHealth
+
+	// One headless browser and one HTTP client per proxy, created on first use
+	mu          sync.Mutex
+	allocators  map[string]*allocator
+	httpClients map[string]*http.Client
+}
+
+// allocator is a chromedp browser bound to one proxy
+type allocator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
-// NewClient creates a new Google Sports scraper client
+// NewClient creates a new Google Sports scraper client that connects directly
 func NewClient() (*Client, error) {
+	return NewClientWithConfig(DefaultConfig())
+}
+
+// NewClientWithConfig creates a scraper client that rotates through the configured proxies
+func NewClientWithConfig(config Config) (*Client, error) {
+	switch config.Mode {
+	case "":
+		config.Mode = FetchAuto
+	case FetchAuto, FetchHTTP, FetchBrowser:
+	default:
+		return nil, fmt.Errorf("unknown Google fetch mode %q (want %s, %s, or %s)", config.Mode, FetchAuto, FetchHTTP, FetchBrowser)
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = BaseURL
+	}
+
+	c := &Client{
+		lastRequest: time.Time{},
+		interval:    MinRequestInterval,
+		mode:        config.Mode,
+		baseURL:     config.BaseURL,
+		proxies:     newProxyPool(config),
+		allocators:  make(map[string]*allocator),
+		httpClients: make(map[string]*http.Client),
+	}
+	proxiesAvailable.Set(float64(c.proxies.Available()))
+	return c, nil
+}
+
+// allocatorFor returns the browser for a proxy, starting it if needed
+func (c *Client) allocatorFor(proxy string) context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if a, ok := c.allocators[proxy]; ok {
+		return a.ctx
+	}
+
 	// Create chrome instance with options
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
 		chromedp.Flag("headless", true),
@@ -42,21 +110,22 @@ func NewClient() (*Client, error) {
 		chromedp.Flag("disable-dev-shm-usage", true),
 		chromedp.UserAgent(UserAgent),
 	)
-	
-	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
-	
-	return &Client{
-		lastRequest: time.Time{},
-		interval:    MinRequestInterval,
-		allocCtx:    allocCtx,
-		cancel:      cancel,
-	}, nil
+	if proxy != directProxy {
+		opts = append(opts, chromedp.ProxyServer(proxy))
+	}
+
+	ctx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	c.allocators[proxy] = &allocator{ctx: ctx, cancel: cancel}
+	return ctx
 }
 
 // Close releases resources
 func (c *Client) Close() {
-	if c.cancel != nil {
-		c.cancel()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for proxy, a := range c.allocators {
+		a.cancel()
+		delete(c.allocators, proxy)
 	}
 }
 
@@ -83,33 +152,95 @@ func (c *Client) fetchWithRateLimit(ctx context.Context, query string) (string,
 		}
 	}
 	
-	html, err := c.fetch(ctx, query)
+	html, err := c.fetchPage(ctx, query)
 	c.lastRequest = time.Now()
 	
 	return html, err
 }
 
-// fetch performs the actual HTTP fetch using chromedp
-func (c *Client) fetch(ctx context.Context, query string) (string, error) {
+// fetchPage fetches with the configured mode. Auto mode only starts Chrome when
+// the plain HTTP page fails or carries no games, e.g. when Google serves the
+// scoreboard as a script-rendered widget.
+func (c *Client) fetchPage(ctx context.Context, query string) (string, error) {
+	switch c.mode {
+	case FetchHTTP:
+		return c.fetch(ctx, query, FetchHTTP, c.fetchHTTPVia)
+	case FetchBrowser:
+		return c.fetch(ctx, query, FetchBrowser, c.fetchVia)
+	}
+
+	html, err := c.fetch(ctx, query, FetchHTTP, c.fetchHTTPVia)
+	if err == nil && hasGames(html) {
+		return html, nil
+	}
+	if errors.Is(err, ErrAllProxiesBlocked) {
+		return "", err
+	}
+	if err != nil {
+		log.Printf("[google] HTTP fetch failed (%v); retrying with headless Chrome", err)
+	} else {
+		log.Printf("[google] HTTP page had no games; retrying with headless Chrome")
+	}
+	return c.fetch(ctx, query, FetchBrowser, c.fetchVia)
+}
+
+// fetch loads the results page with one fetcher, moving to the next proxy when
+// Google blocks the current one. A blocked proxy cools down before it is used again.
+func (c *Client) fetch(ctx context.Context, query, mode string, via func(ctx context.Context, proxy, query string) (string, error)) (string, error) {
+	defer func() { proxiesAvailable.Set(float64(c.proxies.Available())) }()
+
+	attempts := min(c.proxies.Size(), maxProxyAttempts)
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		proxy, err := c.proxies.Next(ctx)
+		if err != nil {
+			if lastErr != nil {
+				return "", fmt.Errorf("%w (last attempt: %v)", err, lastErr)
+			}
+			return "", err
+		}
+
+		html, err := via(ctx, proxy, query)
+		if errors.Is(err, ErrBlocked) {
+			c.health.record(mode, scrapeBlocked)
+			c.proxies.MarkBlocked(proxy)
+			log.Printf("[google] Blocked via %s (%s); cooling it down for %v", proxyLabel(proxy), mode, c.proxies.config.Cooldown)
+			lastErr = err
+			continue
+		}
+		if err != nil {
+			c.health.record(mode, scrapeError)
+			return "", err
+		}
+
+		c.health.record(mode, scrapeSuccess)
+		return html, nil
+	}
+	return "", lastErr
+}
+
+// fetchVia performs the actual HTTP fetch using chromedp through one proxy
+func (c *Client) fetchVia(ctx context.Context, proxy, query string) (string, error) {
 	// Create a timeout context
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 	
 	// Create a new browser context
-	browserCtx, cancel := chromedp.NewContext(c.allocCtx)
+	browserCtx, cancel := chromedp.NewContext(c.allocatorFor(proxy))
 	defer cancel()
 	
 	// Combine with timeout context
 	browserCtx, cancel = context.WithTimeout(browserCtx, 30*time.Second)
 	defer cancel()
 	
-	var htmlContent string
-	url := fmt.Sprintf("%s?q=%s", BaseURL, strings.ReplaceAll(query, " ", "+"))
+	var htmlContent, location string
+	url := fmt.Sprintf("%s?q=%s", c.baseURL, strings.ReplaceAll(query, " ", "+"))
 	
 	err := chromedp.Run(browserCtx,
 		chromedp.Navigate(url),
 		chromedp.WaitVisible(`body`, chromedp.ByQuery),
 		chromedp.Sleep(1*time.Second), // Allow JS to render
+		chromedp.Location(&location),
 		chromedp.OuterHTML(`html`, &htmlContent, chromedp.ByQuery),
 	)
 	
@@ -117,6 +248,10 @@ func (c *Client) fetch(ctx context.Context, query string) (string, error) {
 		return "", fmt.Errorf("chromedp error: %w", err)
 	}
 	
+	if isBlockPage(location, htmlContent) {
+		return "", ErrBlocked
+	}
+	
 	if htmlContent == "" {
 		return "", fmt.Errorf("empty HTML content returned")
 	}
@@ -124,6 +259,20 @@ func (c *Client) fetch(ctx context.Context, query string) (string, error) {
 	return htmlContent, nil
 }
 
+// isBlockPage reports whether Google redirected to its /sorry/ interstitial or
+// rendered a CAPTCHA in place of results
+func isBlockPage(location, htmlContent string) bool {
+	if strings.Contains(location, "/sorry/") {
+		return true
+	}
+	for _, marker := range blockMarkers {
+		if strings.Contains(htmlContent, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // ParseHTML converts raw HTML to a goquery Document for parsing
 func ParseHTML(htmlContent string) (*goquery.Document, error) {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
@@ -0,0 +1,88 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxPageBytes bounds how much of a results page the HTTP fetcher reads
+const maxPageBytes = 5 << 20
+
+// httpClientFor returns the HTTP client for a proxy, creating it if needed
+func (c *Client) httpClientFor(proxy string) (*http.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.httpClients[proxy]; ok {
+		return client, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != directProxy {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %q: %w", proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	client := &http.Client{Transport: transport, Timeout: 15 * time.Second}
+	c.httpClients[proxy] = client
+	return client, nil
+}
+
+// fetchHTTPVia fetches the results page with a plain GET and browser-like headers
+func (c *Client) fetchHTTPVia(ctx context.Context, proxy, query string) (string, error) {
+	client, err := c.httpClientFor(proxy)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{"q": {query}, "hl": {"en"}, "gl": {"us"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("http fetch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return "", fmt.Errorf("reading response: %w", err)
+	}
+	htmlContent := string(body)
+
+	if resp.StatusCode == http.StatusTooManyRequests || isBlockPage(resp.Request.URL.String(), htmlContent) {
+		return "", ErrBlocked
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("google returned %s", resp.Status)
+	}
+	if strings.TrimSpace(htmlContent) == "" {
+		return "", fmt.Errorf("empty HTML content returned")
+	}
+
+	return htmlContent, nil
+}
+
+// hasGames reports whether a fetched page parses to at least one game
+func hasGames(htmlContent string) bool {
+	doc, err := ParseHTML(htmlContent)
+	if err != nil {
+		return false
+	}
+	games, err := ParseLiveGames(doc)
+	return err == nil && len(games) > 0
+}
@@ -2,14 +2,36 @@ package google
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+
 	"github.com/fortuna/minerva/internal/cache"
 	"github.com/fortuna/minerva/internal/store"
 )
 
+const (
+	liveGamesCacheKey = "google:live_games:nba"
+	liveGamesCacheTTL = 5 * time.Second
+)
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass makes IngestLiveGames scrape Google even when a cached result
+// is fresh. The new result still refreshes the cache.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
+
 // Ingester handles ingestion of Google Sports data
 type Ingester struct {
 	client *Client
@@ -17,9 +39,9 @@ type Ingester struct {
 	db     *store.Database
 }
 
-// NewIngester creates a new Google Sports ingester
-func NewIngester(cache *cache.RedisCache, db *store.Database) (*Ingester, error) {
-	client, err := NewClient()
+// NewIngester creates a new Google Sports ingester that scrapes through the configured proxies
+func NewIngester(cache *cache.RedisCache, db *store.Database, config Config) (*Ingester, error) {
+	client, err := NewClientWithConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Google client: %w", err)
 	}
@@ -43,13 +65,9 @@ func (i *Ingester) IngestLiveGames(ctx context.Context, seasonID string) ([]Live
 	log.Println("Ingesting live games from Google Sports...")
 	
 	// Check cache first
-	cacheKey := "google:live_games:nba"
-	if i.cache != nil {
-		cached, err := i.cache.Get(ctx, cacheKey)
-		if err == nil && cached != "" {
-			log.Println("  Using cached live games data")
-			// TODO: Deserialize and return cached data
-		}
+	if games, ok := i.cachedLiveGames(ctx); ok {
+		log.Printf("  Using cached live games data (%d games)", len(games))
+		return games, nil
 	}
 	
 	// Fetch from Google
@@ -57,6 +75,7 @@ func (i *Ingester) IngestLiveGames(ctx context.Context, seasonID string) ([]Live
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch live games: %w", err)
 	}
+	fetchedAt := time.Now().UTC()
 	
 	// Parse HTML
 	doc, err := ParseHTML(htmlContent)
@@ -69,18 +88,60 @@ func (i *Ingester) IngestLiveGames(ctx context.Context, seasonID string) ([]Live
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse games: %w", err)
 	}
+	for j := range games {
+		games[j].FetchedAt = fetchedAt
+	}
 	
 	log.Printf("  Found %d live games", len(games))
 	
 	// Cache results (5 second TTL for live data)
-	if i.cache != nil {
-		// TODO: Serialize and cache games
-		_ = i.cache.Set(ctx, cacheKey, fmt.Sprintf("%d games", len(games)), 5*time.Second)
-	}
+	i.cacheLiveGames(ctx, games)
 	
 	return games, nil
 }
 
+// cachedLiveGames returns the games cached by a recent scrape. Cache errors are
+// treated as misses so a Redis outage only costs an extra scrape.
+func (i *Ingester) cachedLiveGames(ctx context.Context) ([]LiveGame, bool) {
+	if i.cache == nil || cacheBypassed(ctx) {
+		return nil, false
+	}
+
+	cached, err := i.cache.Get(ctx, liveGamesCacheKey)
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Printf("  Live games cache read failed: %v", err)
+		}
+		return nil, false
+	}
+
+	var games []LiveGame
+	if err := json.Unmarshal([]byte(cached), &games); err != nil {
+		log.Printf("  Ignoring unreadable live games cache entry: %v", err)
+		return nil, false
+	}
+	return games, true
+}
+
+// cacheLiveGames stores a scrape result for liveGamesCacheTTL
+func (i *Ingester) cacheLiveGames(ctx context.Context, games []LiveGame) {
+	if i.cache == nil {
+		return
+	}
+
+	if games == nil {
+		games = []LiveGame{}
+	}
+	payload, err := json.Marshal(games)
+	if err != nil {
+		log.Printf("  Failed to encode live games for cache: %v", err)
+		return
+	}
+	if err := i.cache.Set(ctx, liveGamesCacheKey, payload, liveGamesCacheTTL); err != nil {
+		log.Printf("  Live games cache write failed: %v", err)
+	}
+}
+
 // IngestGameDetails fetches detailed information for a specific matchup
 func (i *Ingester) IngestGameDetails(ctx context.Context, homeTeam, awayTeam string) (*LiveGame, error) {
 	log.Printf("Ingesting game details for %s vs %s from Google...", homeTeam, awayTeam)
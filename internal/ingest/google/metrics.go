@@ -0,0 +1,54 @@
+package google
+
+import (
+	"sync"
+
+	"github.com/fortuna/minerva/internal/metrics"
+)
+
+// Scrape results reported on minerva_google_scrapes_total
+const (
+	scrapeSuccess = "success"
+	scrapeBlocked = "blocked"
+	scrapeError   = "error"
+)
+
+// successWindow is how many recent scrapes minerva_google_scrape_success_ratio covers
+const successWindow = 50
+
+var (
+	scrapes = metrics.NewCounterVec("minerva_google_scrapes_total",
+		"Google Sports page fetches by fetch mode and result", "mode", "result")
+	scrapeSuccessRatio = metrics.NewGauge("minerva_google_scrape_success_ratio",
+		"Share of the last 50 Google Sports fetches that returned a usable page")
+	proxiesAvailable = metrics.NewGauge("minerva_google_proxies_available",
+		"Proxies (or the direct connection) not cooling down after a block")
+)
+
+// scrapeHealth keeps a ring of recent scrape outcomes for the success ratio
+type scrapeHealth struct {
+	mu      sync.Mutex
+	results [successWindow]bool
+	count   int
+	next    int
+}
+
+func (h *scrapeHealth) record(mode, result string) {
+	scrapes.WithLabelValues(mode, result).Inc()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.results[h.next] = result == scrapeSuccess
+	h.next = (h.next + 1) % successWindow
+	if h.count < successWindow {
+		h.count++
+	}
+
+	ok := 0
+	for i := 0; i < h.count; i++ {
+		if h.results[i] {
+			ok++
+		}
+	}
+	scrapeSuccessRatio.Set(float64(ok) / float64(h.count))
+}
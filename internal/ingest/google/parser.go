@@ -1,12 +1,12 @@
 package google
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
@@ -15,20 +15,21 @@ import (
 
 // LiveGame represents a live game scraped from Google
 type LiveGame struct {
-	HomeTeam      string
-	AwayTeam      string
-	HomeScore     int
-	AwayScore     int
-	HomeRecord    string // W-L record e.g. "11-4"
-	AwayRecord    string // W-L record e.g. "10-9"
-	HomeLogoURL   string // Team logo URL from Google CDN
-	AwayLogoURL   string // Team logo URL from Google CDN
-	GameStatus    string
-	Period        int
-	TimeRemaining string
-	IsLive        bool
-	IsScheduled   bool
-	IsFinal       bool
+	HomeTeam      string    `json:"home_team"`
+	AwayTeam      string    `json:"away_team"`
+	HomeScore     int       `json:"home_score"`
+	AwayScore     int       `json:"away_score"`
+	HomeRecord    string    `json:"home_record,omitempty"`   // W-L record e.g. "11-4"
+	AwayRecord    string    `json:"away_record,omitempty"`   // W-L record e.g. "10-9"
+	HomeLogoURL   string    `json:"home_logo_url,omitempty"` // Team logo URL from Google CDN
+	AwayLogoURL   string    `json:"away_logo_url,omitempty"` // Team logo URL from Google CDN
+	GameStatus    string    `json:"game_status"`
+	Period        int       `json:"period"`
+	TimeRemaining string    `json:"time_remaining,omitempty"`
+	IsLive        bool      `json:"is_live"`
+	IsScheduled   bool      `json:"is_scheduled"`
+	IsFinal       bool      `json:"is_final"`
+	FetchedAt     time.Time `json:"fetched_at"` // when the page was scraped; kept through the cache
 }
 
 // ParseLiveGames extracts live NBA games from Google search results
@@ -262,18 +263,18 @@ func ConvertToStoreGame(liveGame LiveGame, seasonID int) *store.Game {
 		ExternalID: generateGameID(liveGame),
 		SeasonID:   seasonID,
 		GameDate:   time.Now(), // Use current date for live games
-		GameTime:   sql.NullTime{Time: time.Now(), Valid: true},
-		HomeScore:  sql.NullInt32{Int32: int32(liveGame.HomeScore), Valid: true},
-		AwayScore:  sql.NullInt32{Int32: int32(liveGame.AwayScore), Valid: true},
+		GameTime:   store.NullTime{Time: time.Now(), Valid: true},
+		HomeScore:  store.NullInt32{Int32: int32(liveGame.HomeScore), Valid: true},
+		AwayScore:  store.NullInt32{Int32: int32(liveGame.AwayScore), Valid: true},
 		Status:     parseGameStatus(liveGame),
 	}
 
 	if liveGame.Period > 0 {
-		game.Period = sql.NullInt32{Int32: int32(liveGame.Period), Valid: true}
+		game.Period = store.NullInt32{Int32: int32(liveGame.Period), Valid: true}
 	}
 
 	if liveGame.TimeRemaining != "" {
-		game.Clock = sql.NullString{String: liveGame.TimeRemaining, Valid: true}
+		game.Clock = store.NullString{String: liveGame.TimeRemaining, Valid: true}
 	}
 
 	// Team IDs - try to resolve from abbreviation lookup
@@ -351,57 +352,42 @@ func parseGameStatus(game LiveGame) string {
 	return "scheduled"
 }
 
-// TeamNameToAbbreviation maps common team names to abbreviations
-// This should be replaced with a database lookup in production
-var TeamNameToAbbreviation = map[string]string{
-	"lakers":       "LAL",
-	"celtics":      "BOS",
-	"warriors":     "GSW",
-	"nets":         "BKN",
-	"knicks":       "NYK",
-	"heat":         "MIA",
-	"bucks":        "MIL",
-	"bulls":        "CHI",
-	"cavaliers":    "CLE",
-	"mavericks":    "DAL",
-	"nuggets":      "DEN",
-	"rockets":      "HOU",
-	"clippers":     "LAC",
-	"grizzlies":    "MEM",
-	"timberwolves": "MIN",
-	"pelicans":     "NOP",
-	"thunder":      "OKC",
-	"magic":        "ORL",
-	"76ers":        "PHI",
-	"suns":         "PHX",
-	"blazers":      "POR",
-	"kings":        "SAC",
-	"spurs":        "SAS",
-	"raptors":      "TOR",
-	"jazz":         "UTA",
-	"wizards":      "WAS",
-	"hawks":        "ATL",
-	"hornets":      "CHA",
-	"pistons":      "DET",
-	"pacers":       "IND",
-}
-
-// GetTeamAbbreviation returns team abbreviation from full name
-func GetTeamAbbreviation(teamName string) string {
-	nameLower := strings.ToLower(strings.TrimSpace(teamName))
+var (
+	teamResolverMu sync.RWMutex
+	teamResolver   *store.TeamResolver
+	seedResolver   = sync.OnceValue(func() *store.TeamResolver {
+		r, err := store.SeedTeamResolver()
+		if err != nil {
+			log.Printf("[google] Failed to load seed teams for name matching: %v", err)
+			return store.NewTeamResolver(nil, nil)
+		}
+		return r
+	})
+)
 
-	// Try exact match first
-	if abbr, ok := TeamNameToAbbreviation[nameLower]; ok {
-		return abbr
-	}
+// SetTeamResolver replaces the resolver used to turn scraped team names into
+// abbreviations. Until it is called, names resolve against the embedded team
+// dataset without aliases.
+func SetTeamResolver(r *store.TeamResolver) {
+	teamResolverMu.Lock()
+	teamResolver = r
+	teamResolverMu.Unlock()
+}
 
-	// Try partial match
-	for key, abbr := range TeamNameToAbbreviation {
-		if strings.Contains(nameLower, key) {
-			return abbr
-		}
+// TeamResolver returns the resolver used by GetTeamAbbreviation
+func TeamResolver() *store.TeamResolver {
+	teamResolverMu.RLock()
+	r := teamResolver
+	teamResolverMu.RUnlock()
+	if r == nil {
+		return seedResolver()
 	}
+	return r
+}
 
-	// Return original if no match
-	return teamName
+// GetTeamAbbreviation returns the abbreviation for a scraped team name
+// ("Warriors", "LA Clippers", "Sixers"), or the name unchanged if it does
+// not resolve to exactly one team
+func GetTeamAbbreviation(teamName string) string {
+	return TeamResolver().Abbreviation(teamName)
 }
@@ -0,0 +1,212 @@
+package google
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAllProxiesBlocked is returned while every proxy (or the direct connection,
+// when no proxies are configured) is cooling down after a block
+var ErrAllProxiesBlocked = errors.New("google: every proxy is cooling down after a block")
+
+// directProxy stands for connecting without a proxy
+const directProxy = ""
+
+// Fetch modes
+const (
+	FetchAuto    = "auto"    // plain HTTP first, headless Chrome when the page parses to no games
+	FetchHTTP    = "http"    // plain HTTP only
+	FetchBrowser = "browser" // headless Chrome only
+)
+
+// Config controls how the scraper reaches Google. Chrome cannot send proxy
+// credentials, so proxies must allow the scraper's IP.
+type Config struct {
+	Mode            string        // FetchAuto, FetchHTTP, or FetchBrowser
+	Proxies         []string      // proxy URLs, e.g. http://host:3128 or socks5://host:1080
+	ProviderURL     string        // optional endpoint returning one proxy URL per line
+	ProviderRefresh time.Duration // how often to reload the provider list
+	Cooldown        time.Duration // how long a proxy rests after Google serves it a CAPTCHA
+	BaseURL         string        // search endpoint; empty uses BaseURL (set by mock servers)
+}
+
+// DefaultConfig returns a direct-connection, auto-mode config with a 15 minute cool-down
+func DefaultConfig() Config {
+	return Config{
+		Mode:            FetchAuto,
+		ProviderRefresh: 10 * time.Minute,
+		Cooldown:        15 * time.Minute,
+	}
+}
+
+// proxyPool rotates through proxies round-robin, skipping those cooling down
+type proxyPool struct {
+	config Config
+	client *http.Client
+
+	mu          sync.Mutex
+	proxies     []string
+	next        int
+	blocked     map[string]time.Time // proxy -> cool-down end
+	refreshedAt time.Time
+}
+
+func newProxyPool(config Config) *proxyPool {
+	defaults := DefaultConfig()
+	if config.Cooldown <= 0 {
+		config.Cooldown = defaults.Cooldown
+	}
+	if config.ProviderRefresh <= 0 {
+		config.ProviderRefresh = defaults.ProviderRefresh
+	}
+
+	p := &proxyPool{
+		config:  config,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		blocked: make(map[string]time.Time),
+	}
+	p.proxies = p.rotation(nil)
+	return p
+}
+
+// rotation combines provider proxies with the configured list, falling back to
+// the direct connection when both are empty
+func (p *proxyPool) rotation(provided []string) []string {
+	proxies := append([]string{}, provided...)
+	for _, proxy := range p.config.Proxies {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	if len(proxies) == 0 {
+		proxies = []string{directProxy}
+	}
+	return proxies
+}
+
+// Next returns the next proxy that is not cooling down
+func (p *proxyPool) Next(ctx context.Context) (string, error) {
+	p.refresh(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for range p.proxies {
+		proxy := p.proxies[p.next%len(p.proxies)]
+		p.next = (p.next + 1) % len(p.proxies)
+		if until, ok := p.blocked[proxy]; ok && now.Before(until) {
+			continue
+		}
+		delete(p.blocked, proxy)
+		return proxy, nil
+	}
+	return "", ErrAllProxiesBlocked
+}
+
+// MarkBlocked rests a proxy for the configured cool-down
+func (p *proxyPool) MarkBlocked(proxy string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.blocked[proxy] = time.Now().Add(p.config.Cooldown)
+}
+
+// Size returns the number of proxies in rotation
+func (p *proxyPool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.proxies)
+}
+
+// Available returns the number of proxies not cooling down
+func (p *proxyPool) Available() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	available := 0
+	for _, proxy := range p.proxies {
+		if until, ok := p.blocked[proxy]; !ok || !now.Before(until) {
+			available++
+		}
+	}
+	return available
+}
+
+// refresh reloads the provider list when it is due. A failed reload keeps the
+// current list and is retried on the next refresh interval.
+func (p *proxyPool) refresh(ctx context.Context) {
+	if p.config.ProviderURL == "" {
+		return
+	}
+
+	p.mu.Lock()
+	due := time.Since(p.refreshedAt) >= p.config.ProviderRefresh
+	if due {
+		p.refreshedAt = time.Now()
+	}
+	p.mu.Unlock()
+	if !due {
+		return
+	}
+
+	proxies, err := p.fetchProvider(ctx)
+	if err != nil {
+		log.Printf("[google] Failed to refresh proxy list: %v", err)
+		return
+	}
+
+	p.mu.Lock()
+	p.proxies = p.rotation(proxies)
+	p.next = 0
+	p.mu.Unlock()
+	log.Printf("[google] Loaded %d proxies from provider", len(proxies))
+}
+
+// fetchProvider downloads the provider's newline-separated proxy list
+func (p *proxyPool) fetchProvider(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.ProviderURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building provider request: %w", err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching provider: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("provider returned %s", resp.Status)
+	}
+
+	var proxies []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		proxies = append(proxies, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading provider: %w", err)
+	}
+	if len(proxies) == 0 {
+		return nil, fmt.Errorf("provider returned no proxies")
+	}
+	return proxies, nil
+}
+
+// proxyLabel names a proxy in logs
+func proxyLabel(proxy string) string {
+	if proxy == directProxy {
+		return "direct"
+	}
+	return proxy
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync/atomic"
 	"time"
 
 	"github.com/fortuna/minerva/internal/cache"
 	"github.com/fortuna/minerva/internal/ingest/espn"
 	"github.com/fortuna/minerva/internal/ingest/google"
 	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/quality"
 	"github.com/fortuna/minerva/internal/reconciliation"
 	"github.com/fortuna/minerva/internal/store"
 	"github.com/fortuna/minerva/internal/store/repository"
@@ -24,14 +26,18 @@ type LiveIngester struct {
 	reconciler     *reconciliation.Engine
 	matcher        *reconciliation.Matcher
 	cache          *cache.RedisCache
-	publisher      *publisher.RedisStreamPublisher
+	publisher      publisher.Publisher
 	db             *store.Database
+	replay         *Replay
+
+	metrics atomic.Pointer[reconciliation.Metrics]  // snapshot after the last reconcile
+	latency atomic.Pointer[publisher.LatencyStamps] // source and reconcile times of the last poll
 }
 
 // NewLiveIngester creates a new live game ingester with fallback support
-func NewLiveIngester(cache *cache.RedisCache, publisher *publisher.RedisStreamPublisher, db *store.Database) (*LiveIngester, error) {
+func NewLiveIngester(cache *cache.RedisCache, publisher publisher.Publisher, db *store.Database, googleConfig google.Config) (*LiveIngester, error) {
 	// Initialize Google ingester (primary)
-	googleIngester, err := google.NewIngester(cache, db)
+	googleIngester, err := google.NewIngester(cache, db, googleConfig)
 	if err != nil {
 		log.Printf("Warning: Failed to initialize Google ingester: %v", err)
 		// Continue without Google - ESPN will be the only source
@@ -39,17 +45,19 @@ func NewLiveIngester(cache *cache.RedisCache, publisher *publisher.RedisStreamPu
 
 	// Initialize ESPN ingester (fallback)
 	espnIngester := espn.NewIngester(db)
+	espnIngester.SetStatsPublisher(publisher)
 
 	// Initialize reconciliation engine
 	reconciler := reconciliation.NewEngine(reconciliation.SmartMerge)
 
-	// Load teams for matching
+	// Load teams and aliases for matching; the Google parser and the matcher share the resolver
 	teamRepo := repository.NewTeamRepository(db)
-	teams, err := teamRepo.GetAll(context.Background())
+	resolver, err := teamRepo.GetResolver(context.Background(), store.LeagueNBA)
 	if err != nil {
 		return nil, err
 	}
-	matcher := reconciliation.NewMatcher(teams)
+	google.SetTeamResolver(resolver)
+	matcher := reconciliation.NewMatcher(resolver)
 
 	return &LiveIngester{
 		googleIngester: googleIngester,
@@ -62,6 +70,47 @@ func NewLiveIngester(cache *cache.RedisCache, publisher *publisher.RedisStreamPu
 	}, nil
 }
 
+// SetGameEvents announces games the ESPN pass creates or reschedules
+func (li *LiveIngester) SetGameEvents(events *publisher.GameEventPublisher) {
+	li.espnIngester.SetGameEvents(events)
+}
+
+// SetValidator runs data quality rules on games that finish during live polling
+func (li *LiveIngester) SetValidator(validator *quality.Validator) {
+	li.espnIngester.SetValidator(validator)
+}
+
+// SetReplay replaces the Google and ESPN feeds with an archived game replayed
+// as if it were live. Call it before polling starts.
+func (li *LiveIngester) SetReplay(replay *Replay) {
+	li.replay = replay
+}
+
+// ReconciliationMetrics returns the Google/ESPN reconciliation counters as of
+// the last poll that had both sources, or nil before the first one
+func (li *LiveIngester) ReconciliationMetrics() *reconciliation.Metrics {
+	return li.metrics.Load()
+}
+
+// PollLatency returns when the last poll's source was fetched and when its
+// games were reconciled, for stamping the updates it publishes. It is zero
+// before the first poll with data.
+func (li *LiveIngester) PollLatency() publisher.LatencyStamps {
+	if stamps := li.latency.Load(); stamps != nil {
+		return *stamps
+	}
+	return publisher.LatencyStamps{}
+}
+
+// recordLatency notes the source of the games a poll returns as of now
+func (li *LiveIngester) recordLatency(source string, fetchedAt time.Time) {
+	li.latency.Store(&publisher.LatencyStamps{
+		Source:       source,
+		FetchedAt:    fetchedAt.UTC(),
+		ReconciledAt: time.Now().UTC(),
+	})
+}
+
 // Close releases resources
 func (li *LiveIngester) Close() {
 	if li.googleIngester != nil {
@@ -72,6 +121,10 @@ func (li *LiveIngester) Close() {
 // IngestLiveGames fetches and reconciles live games from both sources
 // Google is primary (fast), ESPN is fallback (reliable)
 func (li *LiveIngester) IngestLiveGames(ctx context.Context, seasonID string) ([]*store.Game, error) {
+	if li.replay != nil {
+		return li.ingestReplay()
+	}
+
 	log.Println("Ingesting live games (Google primary, ESPN fallback)...")
 
 	// Convert seasonID string to int for database operations
@@ -97,14 +150,15 @@ func (li *LiveIngester) IngestLiveGames(ctx context.Context, seasonID string) ([
 	}
 
 	// Always fetch from ESPN (fallback + authoritative data)
-	espnErr = li.espnIngester.IngestTodaysGames(ctx, seasonIDInt)
+	espnFetchedAt := time.Now()
+	_, espnErr = li.espnIngester.IngestTodaysGames(ctx, seasonIDInt)
 	if espnErr != nil {
 		log.Printf("⚠️  ESPN ingestion failed: %v", espnErr)
 	} else {
 		// Fetch today's games from database (all statuses)
 		gameRepo := repository.NewGameRepository(li.db)
 		today := time.Now().Truncate(24 * time.Hour)
-		espnGames, _ = gameRepo.GetByDate(ctx, today)
+		espnGames, _ = gameRepo.GetByDate(store.WithPrimary(ctx), store.DefaultSport, today, nil, nil)
 		log.Printf("✓ ESPN: Ingested %d games for today", len(espnGames))
 	}
 
@@ -117,6 +171,7 @@ func (li *LiveIngester) IngestLiveGames(ctx context.Context, seasonID string) ([
 	// If only ESPN available, use it directly (fallback)
 	if (googleErr != nil || len(googleGames) == 0) && len(espnGames) > 0 {
 		log.Println("→ Using ESPN data only (Google unavailable)")
+		li.recordLatency("espn", espnFetchedAt)
 		return espnGames, nil
 	}
 
@@ -128,6 +183,7 @@ func (li *LiveIngester) IngestLiveGames(ctx context.Context, seasonID string) ([
 		for _, g := range googleGames {
 			games = append(games, google.ConvertToStoreGame(g, seasonIDInt))
 		}
+		li.recordLatency("google", googleFetchedAt(googleGames))
 		return games, nil
 	}
 
@@ -136,20 +192,59 @@ func (li *LiveIngester) IngestLiveGames(ctx context.Context, seasonID string) ([
 	reconciledGames, err := li.matcher.MatchAndReconcileAll(espnGames, googleGames, li.reconciler)
 	if err != nil {
 		log.Printf("⚠️  Reconciliation error: %v (falling back to ESPN)", err)
+		li.recordLatency("espn", espnFetchedAt)
 		return espnGames, nil
 	}
 
 	// Log metrics
-	metrics := li.reconciler.GetMetrics()
+	metrics := li.storeMetrics()
 	log.Printf("✓ Reconciliation complete: Total=%d, Conflicts=%d, Google=%d, ESPN=%d",
 		metrics.TotalReconciliations,
 		metrics.Conflicts,
 		metrics.GooglePreferred,
 		metrics.ESPNPreferred)
 
+	li.recordLatency("google", googleFetchedAt(googleGames))
 	return reconciledGames, nil
 }
 
+// googleFetchedAt is when the oldest of a scrape's games was fetched (cached
+// games keep their original scrape time)
+func googleFetchedAt(games []google.LiveGame) time.Time {
+	var oldest time.Time
+	for _, game := range games {
+		if !game.FetchedAt.IsZero() && (oldest.IsZero() || game.FetchedAt.Before(oldest)) {
+			oldest = game.FetchedAt
+		}
+	}
+	if oldest.IsZero() {
+		return time.Now()
+	}
+	return oldest
+}
+
+// ingestReplay reconciles the replayed game as the Google and ESPN feeds would
+// report it at this point of the replay
+func (li *LiveIngester) ingestReplay() ([]*store.Game, error) {
+	polledAt := time.Now()
+	espnGame, googleGame := li.replay.poll(polledAt)
+	games, err := li.matcher.MatchAndReconcileAll([]*store.Game{espnGame}, []google.LiveGame{googleGame}, li.reconciler)
+	if err != nil {
+		return nil, fmt.Errorf("reconciling replay: %w", err)
+	}
+	li.storeMetrics()
+	li.recordLatency("replay", polledAt)
+	return games, nil
+}
+
+// storeMetrics snapshots the reconciler's counters for ReconciliationMetrics
+func (li *LiveIngester) storeMetrics() *reconciliation.Metrics {
+	metrics := li.reconciler.GetMetrics()
+	snapshot := *metrics
+	li.metrics.Store(&snapshot)
+	return metrics
+}
+
 // PollLiveGames continuously polls for live game updates
 func (li *LiveIngester) PollLiveGames(ctx context.Context, seasonID string, interval time.Duration) {
 	ticker := time.NewTicker(interval)
@@ -186,7 +281,7 @@ func (li *LiveIngester) PollLiveGames(ctx context.Context, seasonID string, inte
 
 // lookupSeasonID queries the database to get season_id (INT) from season_year (STRING)
 func (li *LiveIngester) lookupSeasonID(ctx context.Context, seasonYear string) (int, error) {
-	query := `SELECT season_id FROM seasons WHERE season_year = $1 AND sport = 'basketball_nba' LIMIT 1`
+	query := `SELECT season_id FROM seasons WHERE season_year = $1 AND sport = 'basketball_nba' AND league = 'nba' LIMIT 1`
 	
 	var seasonID int
 	err := li.db.DB().QueryRowContext(ctx, query, seasonYear).Scan(&seasonID)
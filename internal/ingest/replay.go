@@ -0,0 +1,239 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fortuna/minerva/internal/ingest/espn"
+	"github.com/fortuna/minerva/internal/ingest/google"
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// Period lengths used to place plays on a timeline when ESPN sent no wallclock
+const (
+	replayPeriodLength   = 12 * time.Minute
+	replayOvertimeLength = 5 * time.Minute
+)
+
+// ReplayConfig selects an archived game to replay as if it were live
+type ReplayConfig struct {
+	GameID string  // ESPN external ID or database game ID
+	Speed  float64 // game time per unit of real time; 10 replays a game in a tenth of its length
+}
+
+// replayFrame is the scoreboard after one play
+type replayFrame struct {
+	offset    time.Duration // since tip-off
+	period    int
+	clock     string
+	homeScore int
+	awayScore int
+}
+
+// Replay plays an archived game's score progression back through the live
+// pipeline. Set on a LiveIngester, each poll reports the game as it stood at
+// that point of the replay from both the Google and ESPN side, so
+// reconciliation and publishing run as they would for a real game. Nothing is
+// written to the database.
+type Replay struct {
+	game     *store.Game
+	homeName string
+	awayName string
+	frames   []replayFrame
+	speed    float64
+
+	mu       sync.Mutex
+	started  time.Time
+	reported bool // the final frame has been returned by a poll
+	done     chan struct{}
+}
+
+// NewReplay loads a stored game and its play-by-play. Plays are fetched from
+// ESPN first when none are stored.
+func NewReplay(ctx context.Context, db *store.Database, config ReplayConfig) (*Replay, error) {
+	if config.Speed <= 0 {
+		return nil, fmt.Errorf("replay speed must be positive, got %v", config.Speed)
+	}
+
+	games := repository.NewGameRepository(db)
+	game, err := games.GetByExternalID(ctx, config.GameID)
+	if store.IsNotFound(err) {
+		if id, convErr := strconv.Atoi(config.GameID); convErr == nil {
+			game, err = games.GetByID(ctx, id)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	plays := repository.NewPlayRepository(db)
+	stored, err := plays.GetByGame(ctx, game.GameID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(stored) == 0 {
+		log.Printf("[replay] No plays stored for game %s; fetching from ESPN", game.ExternalID)
+		if _, err := espn.NewIngester(db).RefreshGameStats(ctx, game); err != nil {
+			return nil, fmt.Errorf("fetching plays for game %s: %w", game.ExternalID, err)
+		}
+		if stored, err = plays.GetByGame(store.WithPrimary(ctx), game.GameID, false); err != nil {
+			return nil, err
+		}
+	}
+	if len(stored) == 0 {
+		return nil, fmt.Errorf("game %s has no play-by-play to replay", game.ExternalID)
+	}
+
+	teams := repository.NewTeamRepository(db)
+	home, err := teams.GetByID(ctx, game.HomeTeamID)
+	if err != nil {
+		return nil, err
+	}
+	away, err := teams.GetByID(ctx, game.AwayTeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replay{
+		game:     game,
+		homeName: home.FullName,
+		awayName: away.FullName,
+		frames:   replayFrames(stored),
+		speed:    config.Speed,
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// replayFrames places each play on a timeline from tip-off. ESPN wallclocks
+// keep stoppages in the pacing; without them plays are spaced by game clock.
+func replayFrames(plays []*store.GamePlay) []replayFrame {
+	first, last := plays[0].Wallclock, plays[len(plays)-1].Wallclock
+	useWallclock := first.Valid && last.Valid && last.Time.After(first.Time)
+
+	frames := make([]replayFrame, 0, len(plays)+1)
+	frames = append(frames, replayFrame{period: 1, clock: formatReplayClock(replayPeriodLength)})
+	for _, play := range plays {
+		prev := frames[len(frames)-1]
+		offset := gameClockOffset(play)
+		if useWallclock {
+			offset = prev.offset
+			if play.Wallclock.Valid && play.Wallclock.Time.Sub(first.Time) > offset {
+				offset = play.Wallclock.Time.Sub(first.Time)
+			}
+		}
+		if offset < prev.offset {
+			offset = prev.offset
+		}
+		frames = append(frames, replayFrame{
+			offset:    offset,
+			period:    play.Period,
+			clock:     play.Clock.String,
+			homeScore: play.HomeScore,
+			awayScore: play.AwayScore,
+		})
+	}
+	return frames
+}
+
+// gameClockOffset is the game time elapsed before a play
+func gameClockOffset(play *store.GamePlay) time.Duration {
+	var elapsed time.Duration
+	for p := 1; p < play.Period; p++ {
+		elapsed += periodLength(p)
+	}
+	if play.ClockSeconds.Valid {
+		elapsed += periodLength(play.Period) - time.Duration(play.ClockSeconds.Int32)*time.Second
+	}
+	return elapsed
+}
+
+func periodLength(period int) time.Duration {
+	if period > 4 {
+		return replayOvertimeLength
+	}
+	return replayPeriodLength
+}
+
+func formatReplayClock(d time.Duration) string {
+	seconds := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// Game returns the stored game being replayed
+func (r *Replay) Game() *store.Game {
+	return r.game
+}
+
+// Duration is how long the replay takes in real time
+func (r *Replay) Duration() time.Duration {
+	return time.Duration(float64(r.frames[len(r.frames)-1].offset) / r.speed)
+}
+
+// Done is closed on the first poll after the final score was reported, so the
+// final update has already been published when it fires
+func (r *Replay) Done() <-chan struct{} {
+	return r.done
+}
+
+// poll returns the game as each source would report it now. The replay clock
+// starts on the first poll.
+func (r *Replay) poll(now time.Time) (*store.Game, google.LiveGame) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started.IsZero() {
+		r.started = now
+	}
+	elapsed := time.Duration(float64(now.Sub(r.started)) * r.speed)
+	i := sort.Search(len(r.frames), func(i int) bool { return r.frames[i].offset > elapsed }) - 1
+	frame := r.frames[i]
+	final := i == len(r.frames)-1
+
+	if final {
+		if r.reported {
+			select {
+			case <-r.done:
+			default:
+				close(r.done)
+			}
+		}
+		r.reported = true
+	}
+
+	espnGame := *r.game
+	espnGame.Status = "in_progress"
+	espnGame.HomeScore = store.NullInt32{Int32: int32(frame.homeScore), Valid: true}
+	espnGame.AwayScore = store.NullInt32{Int32: int32(frame.awayScore), Valid: true}
+	espnGame.Period = store.NullInt32{Int32: int32(frame.period), Valid: true}
+	espnGame.Clock = store.NullString{String: frame.clock, Valid: frame.clock != ""}
+
+	googleGame := google.LiveGame{
+		HomeTeam:      r.homeName,
+		AwayTeam:      r.awayName,
+		HomeScore:     frame.homeScore,
+		AwayScore:     frame.awayScore,
+		GameStatus:    "Live",
+		Period:        frame.period,
+		TimeRemaining: frame.clock,
+		IsLive:        true,
+	}
+
+	if final {
+		// The stored final score wins over the last play's, which can miss a late correction
+		if r.game.HomeScore.Valid && r.game.AwayScore.Valid {
+			espnGame.HomeScore, espnGame.AwayScore = r.game.HomeScore, r.game.AwayScore
+			googleGame.HomeScore, googleGame.AwayScore = int(r.game.HomeScore.Int32), int(r.game.AwayScore.Int32)
+		}
+		espnGame.Status = "final"
+		espnGame.Clock = store.NullString{String: "0:00", Valid: true}
+		googleGame.GameStatus, googleGame.TimeRemaining = "Final", ""
+		googleGame.IsLive, googleGame.IsFinal = false, true
+	}
+	return &espnGame, googleGame
+}
@@ -0,0 +1,173 @@
+// Package leader elects one replica to run background work through a Redis lease.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// releaseTimeout bounds the best-effort lease release when stepping down
+const releaseTimeout = 2 * time.Second
+
+// renewScript extends the lease only if this replica still holds it
+var renewScript = redis.NewScript(`
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	end
+	return 0
+`)
+
+// releaseScript deletes the lease only if this replica still holds it
+var releaseScript = redis.NewScript(`
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	end
+	return 0
+`)
+
+// Config holds leader election settings
+type Config struct {
+	Enabled  bool          // false makes every replica a leader (single-instance deployments)
+	Key      string        // Redis key holding the lease
+	LeaseTTL time.Duration // how long a dead leader blocks failover
+	ID       string        // this replica's identity; defaults to hostname-pid
+}
+
+// DefaultConfig returns default leader election configuration
+func DefaultConfig() Config {
+	return Config{
+		Enabled:  true,
+		Key:      "minerva:leader",
+		LeaseTTL: 15 * time.Second,
+	}
+}
+
+// Elector campaigns for a single lease. The holder renews it every third of the
+// TTL; if the holder dies or loses Redis, the key expires and another replica
+// takes over within one TTL.
+type Elector struct {
+	client *redis.Client
+	config Config
+	leader atomic.Bool
+}
+
+// NewElector creates a leader elector
+func NewElector(client *redis.Client, config Config) *Elector {
+	defaults := DefaultConfig()
+	if config.Key == "" {
+		config.Key = defaults.Key
+	}
+	if config.LeaseTTL <= 0 {
+		config.LeaseTTL = defaults.LeaseTTL
+	}
+	if config.ID == "" {
+		host, _ := os.Hostname()
+		config.ID = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	return &Elector{client: client, config: config}
+}
+
+// ID returns this replica's identity in the lease
+func (e *Elector) ID() string {
+	return e.config.ID
+}
+
+// IsLeader reports whether this replica currently holds the lease
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Run campaigns until ctx is cancelled. Each time this replica wins the lease,
+// lead runs with a context that is cancelled when the lease is lost; Run waits
+// for lead to return before campaigning again, so terms never overlap.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	if !e.config.Enabled {
+		log.Println("Leader election disabled; this replica runs background workers")
+		e.leader.Store(true)
+		lead(ctx)
+		e.leader.Store(false)
+		return
+	}
+
+	log.Printf("→ Campaigning for leadership as %s (lease %v)", e.config.ID, e.config.LeaseTTL)
+
+	ticker := time.NewTicker(e.config.LeaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		acquired, err := e.client.SetNX(ctx, e.config.Key, e.config.ID, e.config.LeaseTTL).Result()
+		if err != nil && ctx.Err() == nil {
+			log.Printf("  ⚠️  Leader election: %v", err)
+		}
+		if acquired {
+			e.hold(ctx, lead)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// hold runs one leadership term, renewing the lease until it is lost, lead
+// returns, or ctx is cancelled
+func (e *Elector) hold(ctx context.Context, lead func(ctx context.Context)) {
+	log.Printf("✓ Elected leader (%s)", e.config.ID)
+	e.leader.Store(true)
+
+	termCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lead(termCtx)
+	}()
+
+	ticker := time.NewTicker(e.config.LeaseTTL / 3)
+	defer ticker.Stop()
+
+	// A failed renewal is retried until the lease may have expired; a renewal that
+	// finds another holder steps down immediately
+	renewed := time.Now()
+	deadline := e.config.LeaseTTL - e.config.LeaseTTL/3
+
+renew:
+	for {
+		select {
+		case <-ctx.Done():
+			break renew
+		case <-done:
+			break renew
+		case <-ticker.C:
+			held, err := renewScript.Run(ctx, e.client, []string{e.config.Key}, e.config.ID, e.config.LeaseTTL.Milliseconds()).Int()
+			switch {
+			case err == nil && held == 1:
+				renewed = time.Now()
+			case err == nil:
+				log.Printf("  ⚠️  Leadership lost to another replica")
+				break renew
+			case time.Since(renewed) >= deadline:
+				log.Printf("  ⚠️  Leadership lease could not be renewed: %v", err)
+				break renew
+			}
+		}
+	}
+
+	cancel()
+	<-done
+	e.leader.Store(false)
+
+	releaseCtx, releaseCancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer releaseCancel()
+	if err := releaseScript.Run(releaseCtx, e.client, []string{e.config.Key}, e.config.ID).Err(); err != nil {
+		log.Printf("  ⚠️  Failed to release leadership lease: %v", err)
+	}
+	log.Printf("→ Stepped down as leader (%s)", e.config.ID)
+}
@@ -0,0 +1,354 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/ingest/bref"
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// Discrepancy is one stat that differs between a stored box score and the
+// Basketball-Reference box score of the same game. Player is empty for team
+// totals.
+type Discrepancy struct {
+	GameID     int       `json:"game_id"`
+	ExternalID string    `json:"external_id"`
+	GameDate   time.Time `json:"game_date"`
+	Team       string    `json:"team"`
+	Player     string    `json:"player,omitempty"`
+	Field      string    `json:"field"` // store JSON name, e.g. "offensive_rebounds"
+	Stored     int       `json:"stored"`
+	Reference  int       `json:"reference"`
+}
+
+// GameCrosscheck is the comparison of one game
+type GameCrosscheck struct {
+	GameID         int           `json:"game_id"`
+	ExternalID     string        `json:"external_id"`
+	GameDate       time.Time     `json:"game_date"`
+	URL            string        `json:"url,omitempty"`
+	Discrepancies  []Discrepancy `json:"discrepancies,omitempty"`
+	MissingPlayers []string      `json:"missing_players,omitempty"` // played per Basketball-Reference, no stored line
+	ExtraPlayers   []string      `json:"extra_players,omitempty"`   // stored line, did not play per Basketball-Reference
+	Error          string        `json:"error,omitempty"`
+}
+
+// Clean reports whether the game matched the reference exactly
+func (g *GameCrosscheck) Clean() bool {
+	return g.Error == "" && len(g.Discrepancies) == 0 && len(g.MissingPlayers) == 0 && len(g.ExtraPlayers) == 0
+}
+
+// CrosscheckReport aggregates the comparison of many games. Counts by field
+// separate systemic parsing errors (one field off in many games) from one-off
+// scorer corrections.
+type CrosscheckReport struct {
+	Games          []*GameCrosscheck `json:"games"`
+	Checked        int               `json:"checked"`
+	Clean          int               `json:"clean"`
+	Failed         int               `json:"failed"`
+	ByField        map[string]int    `json:"by_field"`       // discrepancies per field
+	GamesByField   map[string]int    `json:"games_by_field"` // games with at least one discrepancy in the field
+	MissingPlayers int               `json:"missing_players"`
+	ExtraPlayers   int               `json:"extra_players"`
+}
+
+// Fields returns the fields with discrepancies, most widespread first
+func (r *CrosscheckReport) Fields() []string {
+	fields := make([]string, 0, len(r.GamesByField))
+	for field := range r.GamesByField {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if r.GamesByField[fields[i]] != r.GamesByField[fields[j]] {
+			return r.GamesByField[fields[i]] > r.GamesByField[fields[j]]
+		}
+		return fields[i] < fields[j]
+	})
+	return fields
+}
+
+func (r *CrosscheckReport) add(result *GameCrosscheck) {
+	r.Games = append(r.Games, result)
+	if result.Error != "" {
+		r.Failed++
+		return
+	}
+	r.Checked++
+	if result.Clean() {
+		r.Clean++
+	}
+	seen := make(map[string]bool)
+	for _, d := range result.Discrepancies {
+		r.ByField[d.Field]++
+		if !seen[d.Field] {
+			seen[d.Field] = true
+			r.GamesByField[d.Field]++
+		}
+	}
+	r.MissingPlayers += len(result.MissingPlayers)
+	r.ExtraPlayers += len(result.ExtraPlayers)
+}
+
+// Crosschecker compares stored final box scores with Basketball-Reference.
+// It only reads from the database.
+type Crosschecker struct {
+	client  *bref.Client
+	stats   *repository.StatsRepository
+	players *repository.PlayerRepository
+	teams   *repository.TeamRepository
+}
+
+// NewCrosschecker creates a crosschecker fetching reference box scores with client
+func NewCrosschecker(db *store.Database, client *bref.Client) *Crosschecker {
+	return &Crosschecker{
+		client:  client,
+		stats:   repository.NewStatsRepository(db),
+		players: repository.NewPlayerRepository(db),
+		teams:   repository.NewTeamRepository(db),
+	}
+}
+
+// Run checks each game in turn, calling progress (if set) after each. A game
+// that cannot be fetched or loaded is recorded as failed; Run stops early only
+// when ctx is done or the reference site rate limits us.
+func (c *Crosschecker) Run(ctx context.Context, games []*store.Game, progress func(*GameCrosscheck)) (*CrosscheckReport, error) {
+	report := &CrosscheckReport{ByField: make(map[string]int), GamesByField: make(map[string]int)}
+	for _, game := range games {
+		result, err := c.CheckGame(ctx, game)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, bref.ErrRateLimited) {
+				return report, err
+			}
+			result = &GameCrosscheck{GameID: game.GameID, ExternalID: game.ExternalID, GameDate: game.GameDate, Error: err.Error()}
+		}
+		report.add(result)
+		if progress != nil {
+			progress(result)
+		}
+	}
+	return report, nil
+}
+
+// CheckGame compares one stored final game with its reference box score
+func (c *Crosschecker) CheckGame(ctx context.Context, game *store.Game) (*GameCrosscheck, error) {
+	home, err := c.teams.GetByID(ctx, game.HomeTeamID)
+	if err != nil {
+		return nil, fmt.Errorf("load home team: %w", err)
+	}
+	away, err := c.teams.GetByID(ctx, game.AwayTeamID)
+	if err != nil {
+		return nil, fmt.Errorf("load away team: %w", err)
+	}
+
+	ref, err := c.client.FetchBoxScore(ctx, game.GameDate, bref.TeamCode(home.Abbreviation, game.GameDate))
+	if err != nil {
+		return nil, err
+	}
+
+	teamStats, err := c.stats.GetTeamStatsByGameID(ctx, game.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("load team stats: %w", err)
+	}
+	playerStats, err := c.stats.GetByGameID(ctx, game.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("load player stats: %w", err)
+	}
+	ids := make([]int, 0, len(playerStats))
+	for _, line := range playerStats {
+		ids = append(ids, line.PlayerID)
+	}
+	players, err := c.players.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load players: %w", err)
+	}
+
+	result := &GameCrosscheck{GameID: game.GameID, ExternalID: game.ExternalID, GameDate: game.GameDate, URL: ref.URL}
+	for _, team := range []*store.Team{home, away} {
+		code := bref.TeamCode(team.Abbreviation, game.GameDate)
+		refTeam := ref.Team(code)
+		if refTeam == nil {
+			return nil, fmt.Errorf("%s has no box score for %s", ref.URL, code)
+		}
+
+		discrepancy := func(player, field string, stored, reference int) {
+			result.Discrepancies = append(result.Discrepancies, Discrepancy{
+				GameID: game.GameID, ExternalID: game.ExternalID, GameDate: game.GameDate,
+				Team: team.Abbreviation, Player: player, Field: field, Stored: stored, Reference: reference,
+			})
+		}
+
+		var stored *store.TeamGameStats
+		for _, ts := range teamStats {
+			if ts.TeamID == team.TeamID {
+				stored = ts
+			}
+		}
+		if stored == nil {
+			return nil, fmt.Errorf("no stored team stats for %s", team.Abbreviation)
+		}
+		compareStats(teamStatsValues(stored), refTeam.Totals, func(field string, s, r int) {
+			discrepancy("", field, s, r)
+		})
+
+		refPlayers := make(map[string]bref.PlayerLine)
+		for _, line := range refTeam.Players {
+			if line.Played {
+				refPlayers[normalizePlayerName(line.Name)] = line
+			}
+		}
+		for _, line := range playerStats {
+			if line.TeamID != team.TeamID {
+				continue
+			}
+			name := fmt.Sprintf("player %d", line.PlayerID)
+			if p := players[line.PlayerID]; p != nil {
+				name = p.FullName
+			}
+			key := normalizePlayerName(name)
+			refLine, ok := refPlayers[key]
+			if !ok {
+				result.ExtraPlayers = append(result.ExtraPlayers, team.Abbreviation+" "+name)
+				continue
+			}
+			delete(refPlayers, key)
+			compareStats(playerStatsValues(line), refLine.Stats, func(field string, s, r int) {
+				discrepancy(name, field, s, r)
+			})
+		}
+		for _, line := range refTeam.Players {
+			if _, missing := refPlayers[normalizePlayerName(line.Name)]; missing {
+				result.MissingPlayers = append(result.MissingPlayers, team.Abbreviation+" "+line.Name)
+			}
+		}
+	}
+	return result, nil
+}
+
+// compareStats calls differ for each field where stored and reference disagree
+func compareStats(stored, reference bref.Stats, differ func(field string, stored, reference int)) {
+	pairs := []struct {
+		field string
+		s, r  int
+	}{
+		{"points", stored.Points, reference.Points},
+		{"field_goals_made", stored.FieldGoalsMade, reference.FieldGoalsMade},
+		{"field_goals_attempted", stored.FieldGoalsAttempted, reference.FieldGoalsAttempted},
+		{"three_pointers_made", stored.ThreePointersMade, reference.ThreePointersMade},
+		{"three_pointers_attempted", stored.ThreePointersAttempted, reference.ThreePointersAttempted},
+		{"free_throws_made", stored.FreeThrowsMade, reference.FreeThrowsMade},
+		{"free_throws_attempted", stored.FreeThrowsAttempted, reference.FreeThrowsAttempted},
+		{"offensive_rebounds", stored.OffensiveRebounds, reference.OffensiveRebounds},
+		{"defensive_rebounds", stored.DefensiveRebounds, reference.DefensiveRebounds},
+		{"rebounds", stored.Rebounds, reference.Rebounds},
+		{"assists", stored.Assists, reference.Assists},
+		{"steals", stored.Steals, reference.Steals},
+		{"blocks", stored.Blocks, reference.Blocks},
+		{"turnovers", stored.Turnovers, reference.Turnovers},
+		{"personal_fouls", stored.PersonalFouls, reference.PersonalFouls},
+	}
+	for _, p := range pairs {
+		if p.s != p.r {
+			differ(p.field, p.s, p.r)
+		}
+	}
+}
+
+func teamStatsValues(t *store.TeamGameStats) bref.Stats {
+	return bref.Stats{
+		Points:                 t.Points,
+		FieldGoalsMade:         t.FieldGoalsMade,
+		FieldGoalsAttempted:    t.FieldGoalsAttempted,
+		ThreePointersMade:      t.ThreePointersMade,
+		ThreePointersAttempted: t.ThreePointersAttempted,
+		FreeThrowsMade:         t.FreeThrowsMade,
+		FreeThrowsAttempted:    t.FreeThrowsAttempted,
+		OffensiveRebounds:      t.OffensiveRebounds,
+		DefensiveRebounds:      t.DefensiveRebounds,
+		Rebounds:               t.Rebounds,
+		Assists:                t.Assists,
+		Steals:                 t.Steals,
+		Blocks:                 t.Blocks,
+		Turnovers:              t.Turnovers,
+		PersonalFouls:          t.PersonalFouls,
+	}
+}
+
+func playerStatsValues(p *store.PlayerGameStats) bref.Stats {
+	return bref.Stats{
+		Points:                 p.Points,
+		FieldGoalsMade:         p.FieldGoalsMade,
+		FieldGoalsAttempted:    p.FieldGoalsAttempted,
+		ThreePointersMade:      p.ThreePointersMade,
+		ThreePointersAttempted: p.ThreePointersAttempted,
+		FreeThrowsMade:         p.FreeThrowsMade,
+		FreeThrowsAttempted:    p.FreeThrowsAttempted,
+		OffensiveRebounds:      p.OffensiveRebounds,
+		DefensiveRebounds:      p.DefensiveRebounds,
+		Rebounds:               p.Rebounds,
+		Assists:                p.Assists,
+		Steals:                 p.Steals,
+		Blocks:                 p.Blocks,
+		Turnovers:              p.Turnovers,
+		PersonalFouls:          p.PersonalFouls,
+	}
+}
+
+// nameFolds strips the accents Basketball-Reference keeps and ESPN drops
+var nameFolds = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a", "ā", "a",
+	"ç", "c", "ć", "c", "č", "c",
+	"đ", "d",
+	"é", "e", "è", "e", "ê", "e", "ë", "e", "ė", "e", "ę", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ł", "l",
+	"ñ", "n", "ń", "n", "ņ", "n",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"š", "s", "ś", "s", "ş", "s",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u", "ū", "u",
+	"ý", "y",
+	"ž", "z", "ź", "z", "ż", "z",
+	".", "", "'", "", "’", "", "-", " ",
+)
+
+// normalizePlayerName reduces a name to a form both sources agree on:
+// lowercase, unaccented, no punctuation, no generational suffix
+func normalizePlayerName(name string) string {
+	words := strings.Fields(nameFolds.Replace(strings.ToLower(name)))
+	if n := len(words); n > 1 {
+		switch words[n-1] {
+		case "jr", "sr", "ii", "iii", "iv":
+			words = words[:n-1]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// SampleGames picks up to n final games at random (deterministically for a
+// seed), returned in date order. n <= 0 keeps every final game.
+func SampleGames(games []*store.Game, n int, seed int64) []*store.Game {
+	var finals []*store.Game
+	for _, game := range games {
+		if game.Status == "final" {
+			finals = append(finals, game)
+		}
+	}
+	if n > 0 && n < len(finals) {
+		rng := rand.New(rand.NewSource(seed))
+		rng.Shuffle(len(finals), func(i, j int) { finals[i], finals[j] = finals[j], finals[i] })
+		finals = finals[:n]
+	}
+	sort.Slice(finals, func(i, j int) bool {
+		if !finals[i].GameDate.Equal(finals[j].GameDate) {
+			return finals[i].GameDate.Before(finals[j].GameDate)
+		}
+		return finals[i].GameID < finals[j].GameID
+	})
+	return finals
+}
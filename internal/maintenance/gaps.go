@@ -0,0 +1,123 @@
+// Package maintenance contains data-health checks shared by the CLI and scheduled jobs.
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// MissingStats is a final game that lacks player or team box score rows
+type MissingStats struct {
+	GameID             int       `json:"game_id"`
+	ExternalID         string    `json:"external_id"`
+	GameDate           time.Time `json:"game_date"`
+	MissingPlayerStats bool      `json:"missing_player_stats"`
+	MissingTeamStats   bool      `json:"missing_team_stats"`
+}
+
+// GapReport summarises holes in stored data for a date window
+type GapReport struct {
+	Sport             string         `json:"sport"`
+	Start             time.Time      `json:"start"`
+	End               time.Time      `json:"end"`
+	MissingStats      []MissingStats `json:"missing_stats"`
+	DatesWithoutGames []time.Time    `json:"dates_without_games"`
+}
+
+// GapScanner finds final games without stats and dates with no stored games
+type GapScanner struct {
+	db *store.Database
+}
+
+// NewGapScanner creates a new gap scanner
+func NewGapScanner(db *store.Database) *GapScanner {
+	return &GapScanner{db: db}
+}
+
+// Scan inspects games between start and end (inclusive)
+func (s *GapScanner) Scan(ctx context.Context, sport string, start, end time.Time) (*GapReport, error) {
+	report := &GapReport{Sport: sport, Start: start, End: end}
+
+	missing, err := s.missingStats(ctx, sport, start, end)
+	if err != nil {
+		return nil, err
+	}
+	report.MissingStats = missing
+
+	dates, err := s.datesWithoutGames(ctx, sport, start, end)
+	if err != nil {
+		return nil, err
+	}
+	report.DatesWithoutGames = dates
+
+	return report, nil
+}
+
+func (s *GapScanner) missingStats(ctx context.Context, sport string, start, end time.Time) ([]MissingStats, error) {
+	ctx, cancel := s.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT g.game_id, g.external_id, g.game_date,
+			NOT EXISTS (SELECT 1 FROM player_game_stats p WHERE p.game_id = g.game_id AND p.deleted_at IS NULL) AS missing_player,
+			NOT EXISTS (SELECT 1 FROM team_game_stats t WHERE t.game_id = g.game_id) AS missing_team
+		FROM games g
+		WHERE g.sport = $1
+			AND g.status = 'final'
+			AND g.game_date BETWEEN $2 AND $3
+		ORDER BY g.game_date, g.game_id
+	`
+
+	rows, err := s.db.ReadDB(ctx).QueryContext(ctx, query, sport, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("querying games missing stats: %w", err)
+	}
+	defer rows.Close()
+
+	var missing []MissingStats
+	for rows.Next() {
+		var m MissingStats
+		if err := rows.Scan(&m.GameID, &m.ExternalID, &m.GameDate, &m.MissingPlayerStats, &m.MissingTeamStats); err != nil {
+			return nil, fmt.Errorf("scanning game: %w", err)
+		}
+		if m.MissingPlayerStats || m.MissingTeamStats {
+			missing = append(missing, m)
+		}
+	}
+
+	return missing, rows.Err()
+}
+
+func (s *GapScanner) datesWithoutGames(ctx context.Context, sport string, start, end time.Time) ([]time.Time, error) {
+	ctx, cancel := s.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT d::date
+		FROM generate_series($2::date, $3::date, interval '1 day') AS d
+		WHERE NOT EXISTS (
+			SELECT 1 FROM games g WHERE g.sport = $1 AND g.game_date = d::date
+		)
+		ORDER BY d
+	`
+
+	rows, err := s.db.ReadDB(ctx).QueryContext(ctx, query, sport, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("querying dates without games: %w", err)
+	}
+	defer rows.Close()
+
+	var dates []time.Time
+	for rows.Next() {
+		var d time.Time
+		if err := rows.Scan(&d); err != nil {
+			return nil, fmt.Errorf("scanning date: %w", err)
+		}
+		dates = append(dates, d)
+	}
+
+	return dates, rows.Err()
+}
@@ -0,0 +1,313 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/ingest/espn"
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// Where TeamStatsBackfill gets missing team box score rows
+const (
+	TeamStatsFromESPN        = "espn"         // refetch the game's ESPN box score
+	TeamStatsFromPlayerStats = "player_stats" // sum the stored player stat lines
+	TeamStatsFromAuto        = "auto"         // ESPN, then player stats for games ESPN can't fill
+)
+
+// TeamStatsBackfillOptions controls a team stats backfill
+type TeamStatsBackfillOptions struct {
+	Source string        // espn, player_stats, or auto (default)
+	Delay  time.Duration // pause between ESPN fetches
+	DryRun bool          // report missing games without writing
+}
+
+// TeamStatsCoverage counts final games in a window with both teams' box score rows
+type TeamStatsCoverage struct {
+	FinalGames int     `json:"final_games"`
+	Covered    int     `json:"covered"`
+	Missing    int     `json:"missing"`
+	Pct        float64 `json:"pct"`
+}
+
+// TeamStatsGameResult is the outcome of backfilling one game
+type TeamStatsGameResult struct {
+	GameID     int       `json:"game_id"`
+	ExternalID string    `json:"external_id"`
+	GameDate   time.Time `json:"game_date"`
+	Source     string    `json:"source,omitempty"` // where the rows came from; empty when still missing
+	Error      string    `json:"error,omitempty"`
+}
+
+// TeamStatsBackfillReport summarises a team stats backfill
+type TeamStatsBackfillReport struct {
+	Sport           string                `json:"sport"`
+	Start           time.Time             `json:"start"`
+	End             time.Time             `json:"end"`
+	Source          string                `json:"source"`
+	DryRun          bool                  `json:"dry_run"`
+	Before          TeamStatsCoverage     `json:"before"`
+	After           TeamStatsCoverage     `json:"after"`
+	FromESPN        int                   `json:"from_espn"`
+	FromPlayerStats int                   `json:"from_player_stats"`
+	Games           []TeamStatsGameResult `json:"games"`
+}
+
+// StillMissing returns the games the backfill could not fill
+func (r *TeamStatsBackfillReport) StillMissing() []TeamStatsGameResult {
+	var missing []TeamStatsGameResult
+	for _, g := range r.Games {
+		if g.Source == "" {
+			missing = append(missing, g)
+		}
+	}
+	return missing
+}
+
+// TeamStatsBackfill fills team_game_stats for final games stored before team
+// stats were ingested
+type TeamStatsBackfill struct {
+	db        *store.Database
+	games     *repository.GameRepository
+	stats     *repository.StatsRepository
+	ingesters map[string]*espn.Ingester
+}
+
+// NewTeamStatsBackfill creates a new team stats backfill
+func NewTeamStatsBackfill(db *store.Database) *TeamStatsBackfill {
+	return &TeamStatsBackfill{
+		db:        db,
+		games:     repository.NewGameRepository(db),
+		stats:     repository.NewStatsRepository(db),
+		ingesters: make(map[string]*espn.Ingester),
+	}
+}
+
+// Run backfills final games between start and end (inclusive) that lack a team
+// stats row for either team, and reports coverage before and after
+func (b *TeamStatsBackfill) Run(ctx context.Context, sport string, start, end time.Time, opts TeamStatsBackfillOptions) (*TeamStatsBackfillReport, error) {
+	switch opts.Source {
+	case "":
+		opts.Source = TeamStatsFromAuto
+	case TeamStatsFromESPN, TeamStatsFromPlayerStats, TeamStatsFromAuto:
+	default:
+		return nil, fmt.Errorf("unknown team stats source %q (use espn, player_stats, or auto)", opts.Source)
+	}
+
+	// Read back our own writes when measuring coverage
+	ctx = store.WithPrimary(ctx)
+	report := &TeamStatsBackfillReport{Sport: sport, Start: start, End: end, Source: opts.Source, DryRun: opts.DryRun}
+
+	before, err := b.coverage(ctx, sport, start, end)
+	if err != nil {
+		return nil, err
+	}
+	report.Before = before
+
+	missing, err := b.missingTeamStats(ctx, sport, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, game := range missing {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		result := TeamStatsGameResult{GameID: game.GameID, ExternalID: game.ExternalID, GameDate: game.GameDate}
+		if !opts.DryRun {
+			result.Source, err = b.fill(ctx, game, opts.Source)
+			if err != nil {
+				result.Error = err.Error()
+			}
+			switch result.Source {
+			case TeamStatsFromESPN:
+				report.FromESPN++
+			case TeamStatsFromPlayerStats:
+				report.FromPlayerStats++
+			}
+		}
+		report.Games = append(report.Games, result)
+
+		if opts.Delay > 0 && opts.Source != TeamStatsFromPlayerStats && !opts.DryRun && i < len(missing)-1 {
+			select {
+			case <-ctx.Done():
+				return report, ctx.Err()
+			case <-time.After(opts.Delay):
+			}
+		}
+	}
+
+	report.After = report.Before
+	if !opts.DryRun {
+		if report.After, err = b.coverage(ctx, sport, start, end); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// fill writes the missing rows for one game and returns where they came from,
+// or "" if the game is still missing a team
+func (b *TeamStatsBackfill) fill(ctx context.Context, game *store.Game, source string) (string, error) {
+	var espnErr error
+	if source != TeamStatsFromPlayerStats {
+		espnErr = b.fillFromESPN(ctx, game)
+		if espnErr == nil {
+			return TeamStatsFromESPN, nil
+		}
+		if source == TeamStatsFromESPN {
+			return "", espnErr
+		}
+	}
+
+	if err := b.fillFromPlayerStats(ctx, game); err != nil {
+		if espnErr != nil {
+			return "", fmt.Errorf("espn: %v; player stats: %w", espnErr, err)
+		}
+		return "", err
+	}
+	return TeamStatsFromPlayerStats, nil
+}
+
+func (b *TeamStatsBackfill) fillFromESPN(ctx context.Context, game *store.Game) error {
+	if strings.HasPrefix(game.ExternalID, store.SourceGoogle+"_") {
+		return fmt.Errorf("game %d has no ESPN box score", game.GameID)
+	}
+	ingester, err := b.ingesterFor(game.League)
+	if err != nil {
+		return err
+	}
+	if _, err := ingester.RefreshGameStats(ctx, game); err != nil {
+		return err
+	}
+	return b.requireBothTeams(ctx, game)
+}
+
+// fillFromPlayerStats writes summed player stats for teams without a row,
+// leaving any ESPN row already stored for the other team alone
+func (b *TeamStatsBackfill) fillFromPlayerStats(ctx context.Context, game *store.Game) error {
+	existing, err := b.stats.GetTeamStatsByGameID(ctx, game.GameID)
+	if err != nil {
+		return err
+	}
+	stored := make(map[int]bool, len(existing))
+	for _, t := range existing {
+		stored[t.TeamID] = true
+	}
+
+	summed, err := b.stats.SumPlayerStatsByTeam(ctx, game.GameID)
+	if err != nil {
+		return err
+	}
+	for _, t := range summed {
+		if stored[t.TeamID] {
+			continue
+		}
+		if _, err := b.stats.UpsertTeamStats(ctx, nil, t); err != nil {
+			return err
+		}
+	}
+	if err := b.stats.UpdateGamePace(ctx, game.GameID); err != nil {
+		return err
+	}
+	return b.requireBothTeams(ctx, game)
+}
+
+func (b *TeamStatsBackfill) requireBothTeams(ctx context.Context, game *store.Game) error {
+	rows, err := b.stats.GetTeamStatsByGameID(ctx, game.GameID)
+	if err != nil {
+		return err
+	}
+	if len(rows) < 2 {
+		return fmt.Errorf("game %d still has team stats for %d of 2 teams", game.GameID, len(rows))
+	}
+	return nil
+}
+
+// ingesterFor returns a cached ESPN ingester for a game's league
+func (b *TeamStatsBackfill) ingesterFor(key string) (*espn.Ingester, error) {
+	if key == "" {
+		key = store.LeagueNBA
+	}
+	if ingester, ok := b.ingesters[key]; ok {
+		return ingester, nil
+	}
+	league, ok := store.LookupLeague(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown league %q", key)
+	}
+	ingester := espn.NewLeagueIngester(b.db, league)
+	b.ingesters[key] = ingester
+	return ingester, nil
+}
+
+func (b *TeamStatsBackfill) missingTeamStats(ctx context.Context, sport string, start, end time.Time) ([]*store.Game, error) {
+	ctx, cancel := b.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT g.game_id
+		FROM games g
+		WHERE g.sport = $1
+			AND g.status = 'final'
+			AND g.game_date BETWEEN $2 AND $3
+			AND (SELECT COUNT(*) FROM team_game_stats t WHERE t.game_id = g.game_id) < 2
+		ORDER BY g.game_date, g.game_id
+	`
+
+	rows, err := b.db.ReadDB(ctx).QueryContext(ctx, query, sport, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("querying games missing team stats: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning game: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	games := make([]*store.Game, 0, len(ids))
+	for _, id := range ids {
+		game, err := b.games.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+func (b *TeamStatsBackfill) coverage(ctx context.Context, sport string, start, end time.Time) (TeamStatsCoverage, error) {
+	ctx, cancel := b.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE (SELECT COUNT(*) FROM team_game_stats t WHERE t.game_id = g.game_id) >= 2)
+		FROM games g
+		WHERE g.sport = $1
+			AND g.status = 'final'
+			AND g.game_date BETWEEN $2 AND $3
+	`
+
+	var c TeamStatsCoverage
+	if err := b.db.ReadDB(ctx).QueryRowContext(ctx, query, sport, start, end).Scan(&c.FinalGames, &c.Covered); err != nil {
+		return c, fmt.Errorf("querying team stats coverage: %w", err)
+	}
+	c.Missing = c.FinalGames - c.Covered
+	if c.FinalGames > 0 {
+		c.Pct = float64(c.Covered) / float64(c.FinalGames) * 100
+	}
+	return c, nil
+}
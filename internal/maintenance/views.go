@@ -0,0 +1,24 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// MaterializedViews lists the views RefreshViews rebuilds. Each needs a unique
+// index so it can be refreshed without blocking readers.
+var MaterializedViews = []string{
+	"player_season_averages",
+}
+
+// RefreshViews rebuilds every materialized view concurrently, stopping at the first failure
+func RefreshViews(ctx context.Context, db *store.Database) error {
+	for _, view := range MaterializedViews {
+		if _, err := db.DB().ExecContext(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+view); err != nil {
+			return fmt.Errorf("refreshing %s: %w", view, err)
+		}
+	}
+	return nil
+}
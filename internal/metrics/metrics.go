@@ -0,0 +1,412 @@
+// Package metrics provides a small in-process metrics registry rendered in the
+// Prometheus text exposition format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Sample is a single labeled value produced by a collector function
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// metric is anything the registry knows how to render
+type metric interface {
+	name() string
+	write(w io.Writer)
+}
+
+// Registry holds registered metrics in registration order
+type Registry struct {
+	mu      sync.RWMutex
+	metrics []metric
+	byName  map[string]metric
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{byName: make(map[string]metric)}
+}
+
+// Default is the process-wide registry served on /metrics
+var Default = NewRegistry()
+
+// register adds m, returning the existing metric when the name is already taken
+// so package-level metrics can be declared from several call sites safely.
+func (r *Registry) register(m metric) metric {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.byName[m.name()]; ok {
+		return existing
+	}
+	r.byName[m.name()] = m
+	r.metrics = append(r.metrics, m)
+	return m
+}
+
+// Write renders every metric in the text exposition format
+func (r *Registry) Write(w io.Writer) {
+	r.mu.RLock()
+	metrics := make([]metric, len(r.metrics))
+	copy(metrics, r.metrics)
+	r.mu.RUnlock()
+
+	for _, m := range metrics {
+		m.write(w)
+	}
+}
+
+// Handler serves the registry over HTTP
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.Write(w)
+	})
+}
+
+// Handler serves the default registry
+func Handler() http.Handler {
+	return Default.Handler()
+}
+
+// ---------------------------------------------------------------------------
+// Counters and gauges
+// ---------------------------------------------------------------------------
+
+// valueVec stores one float per label combination
+type valueVec struct {
+	metricName string
+	help       string
+	kind       string
+	labelNames []string
+
+	mu     sync.Mutex
+	values map[string]*Value
+}
+
+// Value is a single counter or gauge series
+type Value struct {
+	mu     sync.Mutex
+	labels []string
+	v      float64
+}
+
+// Inc adds one
+func (v *Value) Inc() { v.Add(1) }
+
+// Dec subtracts one
+func (v *Value) Dec() { v.Add(-1) }
+
+// Add adds delta
+func (v *Value) Add(delta float64) {
+	v.mu.Lock()
+	v.v += delta
+	v.mu.Unlock()
+}
+
+// Set replaces the current value
+func (v *Value) Set(value float64) {
+	v.mu.Lock()
+	v.v = value
+	v.mu.Unlock()
+}
+
+// Get returns the current value
+func (v *Value) Get() float64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.v
+}
+
+func newValueVec(name, help, kind string, labelNames []string) *valueVec {
+	return &valueVec{
+		metricName: name,
+		help:       help,
+		kind:       kind,
+		labelNames: labelNames,
+		values:     make(map[string]*Value),
+	}
+}
+
+func (vv *valueVec) name() string { return vv.metricName }
+
+func (vv *valueVec) with(labelValues ...string) *Value {
+	if len(labelValues) != len(vv.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", vv.metricName, len(vv.labelNames), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	vv.mu.Lock()
+	defer vv.mu.Unlock()
+
+	v, ok := vv.values[key]
+	if !ok {
+		v = &Value{labels: append([]string(nil), labelValues...)}
+		vv.values[key] = v
+	}
+	return v
+}
+
+func (vv *valueVec) write(w io.Writer) {
+	vv.mu.Lock()
+	keys := make([]string, 0, len(vv.values))
+	for k := range vv.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	series := make([]*Value, 0, len(keys))
+	for _, k := range keys {
+		series = append(series, vv.values[k])
+	}
+	vv.mu.Unlock()
+
+	writeHeader(w, vv.metricName, vv.help, vv.kind)
+	for _, s := range series {
+		fmt.Fprintf(w, "%s%s %s\n", vv.metricName, formatLabels(vv.labelNames, s.labels, "", ""), formatFloat(s.Get()))
+	}
+}
+
+// CounterVec is a family of counters partitioned by labels
+type CounterVec struct{ vec *valueVec }
+
+// NewCounterVec registers a labeled counter in the default registry
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	m := Default.register(newValueVec(name, help, "counter", labelNames))
+	return &CounterVec{vec: m.(*valueVec)}
+}
+
+// WithLabelValues returns the counter for the given label values
+func (c *CounterVec) WithLabelValues(values ...string) *Value {
+	return c.vec.with(values...)
+}
+
+// NewCounter registers an unlabeled counter in the default registry
+func NewCounter(name, help string) *Value {
+	return NewCounterVec(name, help).WithLabelValues()
+}
+
+// GaugeVec is a family of gauges partitioned by labels
+type GaugeVec struct{ vec *valueVec }
+
+// NewGaugeVec registers a labeled gauge in the default registry
+func NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	m := Default.register(newValueVec(name, help, "gauge", labelNames))
+	return &GaugeVec{vec: m.(*valueVec)}
+}
+
+// WithLabelValues returns the gauge for the given label values
+func (g *GaugeVec) WithLabelValues(values ...string) *Value {
+	return g.vec.with(values...)
+}
+
+// NewGauge registers an unlabeled gauge in the default registry
+func NewGauge(name, help string) *Value {
+	return NewGaugeVec(name, help).WithLabelValues()
+}
+
+// ---------------------------------------------------------------------------
+// Collector functions
+// ---------------------------------------------------------------------------
+
+type funcMetric struct {
+	metricName string
+	help       string
+	kind       string
+	collect    func() []Sample
+}
+
+func (f *funcMetric) name() string { return f.metricName }
+
+func (f *funcMetric) write(w io.Writer) {
+	samples := f.collect()
+	writeHeader(w, f.metricName, f.help, f.kind)
+	for _, s := range samples {
+		names := make([]string, 0, len(s.Labels))
+		for k := range s.Labels {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		values := make([]string, len(names))
+		for i, k := range names {
+			values[i] = s.Labels[k]
+		}
+		fmt.Fprintf(w, "%s%s %s\n", f.metricName, formatLabels(names, values, "", ""), formatFloat(s.Value))
+	}
+}
+
+// RegisterGaugeFunc registers a gauge whose samples are computed at scrape time
+func RegisterGaugeFunc(name, help string, collect func() []Sample) {
+	Default.register(&funcMetric{metricName: name, help: help, kind: "gauge", collect: collect})
+}
+
+// RegisterCounterFunc registers a counter whose samples are computed at scrape time
+func RegisterCounterFunc(name, help string, collect func() []Sample) {
+	Default.register(&funcMetric{metricName: name, help: help, kind: "counter", collect: collect})
+}
+
+// ---------------------------------------------------------------------------
+// Histograms
+// ---------------------------------------------------------------------------
+
+// DefaultBuckets are latency buckets in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// HistogramVec is a family of histograms partitioned by labels
+type HistogramVec struct {
+	metricName string
+	help       string
+	buckets    []float64
+	labelNames []string
+
+	mu     sync.Mutex
+	series map[string]*Histogram
+}
+
+// Histogram tracks the distribution of observed values
+type Histogram struct {
+	mu      sync.Mutex
+	labels  []string
+	buckets []float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+}
+
+// NewHistogramVec registers a labeled histogram in the default registry
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+
+	m := Default.register(&HistogramVec{
+		metricName: name,
+		help:       help,
+		buckets:    sorted,
+		labelNames: labelNames,
+		series:     make(map[string]*Histogram),
+	})
+	return m.(*HistogramVec)
+}
+
+func (h *HistogramVec) name() string { return h.metricName }
+
+// WithLabelValues returns the histogram for the given label values
+func (h *HistogramVec) WithLabelValues(values ...string) *Histogram {
+	if len(values) != len(h.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", h.metricName, len(h.labelNames), len(values)))
+	}
+
+	key := strings.Join(values, "\xff")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &Histogram{
+			labels:  append([]string(nil), values...),
+			buckets: h.buckets,
+			counts:  make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+	return s
+}
+
+// Observe records a single value
+func (s *Histogram) Observe(v float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, upper := range s.buckets {
+		if v <= upper {
+			s.counts[i]++
+		}
+	}
+	s.sum += v
+	s.count++
+}
+
+// Quantile estimates the q-th quantile (0..1) from bucket counts
+func (s *Histogram) Quantile(q float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.count == 0 {
+		return 0
+	}
+	rank := q * float64(s.count)
+	for i, c := range s.counts {
+		if float64(c) >= rank {
+			return s.buckets[i]
+		}
+	}
+	return math.Inf(1)
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	series := make([]*Histogram, 0, len(keys))
+	for _, k := range keys {
+		series = append(series, h.series[k])
+	}
+	h.mu.Unlock()
+
+	writeHeader(w, h.metricName, h.help, "histogram")
+	for _, s := range series {
+		s.mu.Lock()
+		for i, upper := range s.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName, formatLabels(h.labelNames, s.labels, "le", formatFloat(upper)), s.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName, formatLabels(h.labelNames, s.labels, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.metricName, formatLabels(h.labelNames, s.labels, "", ""), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.metricName, formatLabels(h.labelNames, s.labels, "", ""), s.count)
+		s.mu.Unlock()
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Formatting helpers
+// ---------------------------------------------------------------------------
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+func formatLabels(names, values []string, extraName, extraValue string) string {
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+
+	parts := make([]string, 0, len(names)+1)
+	for i, n := range names {
+		parts = append(parts, fmt.Sprintf("%s=%q", n, values[i]))
+	}
+	if extraName != "" {
+		parts = append(parts, fmt.Sprintf("%s=%q", extraName, extraValue))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return fmt.Sprintf("%g", v)
+}
@@ -0,0 +1,126 @@
+package publisher
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// MaxClockExtrapolation bounds how far a running clock is projected past its
+// last observation, so a stalled poller cannot run the clock down to 0:00
+const MaxClockExtrapolation = 30 * time.Second
+
+// ClockState is a live game's clock as last polled. Running is inferred from
+// successive polls: the clock must have moved since the previous one.
+type ClockState struct {
+	Period     int
+	Seconds    int // remaining in the period
+	Running    bool
+	ObservedAt time.Time
+}
+
+// Estimate projects the clock remaining at now. Stopped clocks (timeouts, free
+// throws, reviews, period breaks) are returned as observed.
+func (c ClockState) Estimate(now time.Time) int {
+	if !c.Running {
+		return c.Seconds
+	}
+	elapsed := now.Sub(c.ObservedAt)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if elapsed > MaxClockExtrapolation {
+		elapsed = MaxClockExtrapolation
+	}
+	return max(c.Seconds-int(elapsed.Seconds()), 0)
+}
+
+// FormatClock renders seconds remaining as M:SS
+func FormatClock(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// ParseClock converts "5:23" or "45.2" to whole seconds remaining
+func ParseClock(clock string) (int, bool) {
+	clock = strings.TrimSpace(clock)
+	if clock == "" {
+		return 0, false
+	}
+	if mins, secs, ok := strings.Cut(clock, ":"); ok {
+		m, errM := strconv.Atoi(mins)
+		s, errS := strconv.ParseFloat(secs, 64)
+		if errM != nil || errS != nil {
+			return 0, false
+		}
+		return m*60 + int(s), true
+	}
+	s, err := strconv.ParseFloat(clock, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int(s), true
+}
+
+// ClockTracker remembers each live game's last clock observation. It is kept
+// in memory by the poller: after a restart a clock counts as stopped until it
+// is seen moving again.
+type ClockTracker struct {
+	mu    sync.Mutex
+	games map[int]ClockState
+}
+
+// NewClockTracker creates an empty tracker
+func NewClockTracker() *ClockTracker {
+	return &ClockTracker{games: make(map[int]ClockState)}
+}
+
+// Observe records the game's polled clock and returns its state. The clock is
+// running only while the game is in progress, time remains, and it has gone
+// down since the previous poll of the same period; an unchanged clock is a
+// stoppage and 0:00 is a period break.
+func (t *ClockTracker) Observe(game *store.Game, now time.Time) ClockState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if game.Status != "in_progress" {
+		delete(t.games, game.GameID)
+		return ClockState{Period: int(game.Period.Int32), ObservedAt: now}
+	}
+
+	seconds, ok := ParseClock(game.Clock.String)
+	state := ClockState{Period: int(game.Period.Int32), Seconds: seconds, ObservedAt: now}
+	if !ok {
+		delete(t.games, game.GameID)
+		return state
+	}
+
+	prev, seen := t.games[game.GameID]
+	state.Running = seen && prev.Period == state.Period && seconds > 0 && seconds < prev.Seconds
+	t.games[game.GameID] = state
+	return state
+}
+
+// LiveGameUpdate is the live stream payload: the game plus the clock state
+// subscribers need to extrapolate between polls
+type LiveGameUpdate struct {
+	*store.Game
+	ClockRunning    bool           `json:"clock_running"`
+	ClockObservedAt *time.Time     `json:"clock_observed_at,omitempty"`
+	EstimatedClock  string         `json:"estimated_clock,omitempty"`
+	Latency         *LatencyStamps `json:"latency,omitempty"` // see StampLatency
+}
+
+// NewLiveGameUpdate wraps a polled game with its clock state
+func NewLiveGameUpdate(game *store.Game, clock ClockState) *LiveGameUpdate {
+	update := &LiveGameUpdate{Game: game, ClockRunning: clock.Running}
+	if game.Clock.Valid && game.Clock.String != "" {
+		observedAt := clock.ObservedAt.UTC()
+		update.ClockObservedAt = &observedAt
+		update.EstimatedClock = FormatClock(clock.Estimate(clock.ObservedAt))
+	}
+	return update
+}
@@ -0,0 +1,25 @@
+package publisher
+
+import "time"
+
+// CoachChanged is the type of a CoachChangeEvent
+const CoachChanged = "coach.changed"
+
+// EventCoach identifies a coach in a CoachChangeEvent
+type EventCoach struct {
+	CoachID  int    `json:"coach_id"`
+	ESPNID   string `json:"espn_id"`
+	FullName string `json:"full_name"`
+}
+
+// CoachChangeEvent announces that roster sync found a new head coach for a
+// team. It is published on the coaches stream.
+type CoachChangeEvent struct {
+	Type          string        `json:"type"`
+	Sport         string        `json:"sport"`
+	Team          GameEventTeam `json:"team"`
+	PreviousCoach EventCoach    `json:"previous_coach"`
+	Coach         EventCoach    `json:"coach"`
+	EffectiveDate string        `json:"effective_date"` // YYYY-MM-DD of the sync that saw the change
+	OccurredAt    time.Time     `json:"occurred_at"`
+}
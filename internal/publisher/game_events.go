@@ -0,0 +1,164 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// Game lifecycle event types
+const (
+	GameEventCreated = "game.created"
+	GameEventUpdated = "game.updated"
+	GameEventFinal   = "game.final"
+)
+
+// eventsStreamPrefix is followed by the sport key, e.g. games.events.basketball_nba
+const eventsStreamPrefix = "games.events."
+
+// gameEventWebhookTimeout bounds the optional webhook call so ingestion never stalls on it
+const gameEventWebhookTimeout = 5 * time.Second
+
+// GameEventTeam identifies one side of a game in both Minerva and ESPN terms
+type GameEventTeam struct {
+	TeamID       int    `json:"team_id"`
+	ESPNID       string `json:"espn_id,omitempty"`
+	Abbreviation string `json:"abbreviation"`
+}
+
+// GameEvent announces a new game or a change to its schedule, teams, or status,
+// so downstream services (Alexandria) can map odds events without polling us.
+// game.final also carries the complete box score.
+type GameEvent struct {
+	Type       string        `json:"type"`
+	Sport      string        `json:"sport"`
+	GameID     int           `json:"game_id"`
+	ESPNID     string        `json:"espn_id"`
+	GameDate   time.Time     `json:"game_date"`
+	TipOff     *time.Time    `json:"tip_off,omitempty"`
+	Status     string        `json:"status"`
+	GameType   string        `json:"game_type"`
+	League     string        `json:"league"`
+	HomeTeam   GameEventTeam `json:"home_team"`
+	AwayTeam   GameEventTeam `json:"away_team"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	BoxScore   interface{}   `json:"box_score,omitempty"` // game.final only, as served by /games/{game_id}/boxscore
+}
+
+// GameEventPublisher writes game lifecycle events to games.events.{sport}
+// through a Publisher and, when configured, POSTs each one to a webhook. A nil
+// publisher drops events.
+type GameEventPublisher struct {
+	pub        Publisher
+	webhookURL string
+	httpClient *http.Client
+	validate   bool
+}
+
+// NewGameEventPublisher creates a game event publisher sending to the streams
+// through pub (the outbox when it is enabled). An empty webhookURL publishes to
+// the stream only.
+func NewGameEventPublisher(pub Publisher, webhookURL string) *GameEventPublisher {
+	return &GameEventPublisher{
+		pub:        pub,
+		webhookURL: strings.TrimSpace(webhookURL),
+		httpClient: &http.Client{Timeout: gameEventWebhookTimeout},
+	}
+}
+
+// SetValidation makes publishes fail for events that don't match the
+// games.events schema, before anything is sent
+func (p *GameEventPublisher) SetValidation(enabled bool) {
+	if p != nil {
+		p.validate = enabled
+	}
+}
+
+// PublishGameEvent sends one event to the stream and the webhook. Both are
+// attempted even if one fails; the errors are joined.
+func (p *GameEventPublisher) PublishGameEvent(ctx context.Context, event *GameEvent) error {
+	if p == nil {
+		return nil
+	}
+	if _, err := p.encode(event); err != nil {
+		return err
+	}
+	streamErr := p.QueueGameEvent(ctx, nil, event)
+	return errors.Join(streamErr, p.NotifyWebhook(ctx, event))
+}
+
+// QueueGameEvent sends an event to the stream only. With the outbox it is
+// queued in exec's transaction, committing with the game it announces; call
+// NotifyWebhook once that transaction commits.
+func (p *GameEventPublisher) QueueGameEvent(ctx context.Context, exec repository.Execer, event *GameEvent) error {
+	if p == nil {
+		return nil
+	}
+	if _, err := p.encode(event); err != nil {
+		return err
+	}
+	if err := PublishTx(ctx, p.pub, exec, eventsStreamPrefix+event.Sport, event); err != nil {
+		return fmt.Errorf("publishing %s to stream: %w", event.Type, err)
+	}
+	return nil
+}
+
+// NotifyWebhook POSTs an event to the webhook, if one is configured
+func (p *GameEventPublisher) NotifyWebhook(ctx context.Context, event *GameEvent) error {
+	if p == nil || p.webhookURL == "" {
+		return nil
+	}
+	data, err := p.encode(event)
+	if err != nil {
+		return err
+	}
+	return postWebhook(ctx, p.httpClient, p.webhookURL, event.Type, data)
+}
+
+// encode stamps an event's occurrence time and marshals it, checking it
+// against the games.events schema when validation is on
+func (p *GameEventPublisher) encode(event *GameEvent) ([]byte, error) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	if p.validate {
+		if err := validateOutgoing(eventsStreamPrefix+event.Sport, data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// postWebhook POSTs an event body, naming its type in X-Minerva-Event
+func postWebhook(ctx context.Context, client *http.Client, url, eventType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Minerva-Event", eventType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting %s webhook: %w", eventType, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s webhook returned %d: %s", eventType, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// Ingestion event types
+const (
+	DailyIngestionComplete = "daily.ingestion.complete"
+)
+
+// ingestionStreamPrefix is followed by the sport key, e.g. ingestion.events.basketball_nba
+const ingestionStreamPrefix = "ingestion.events."
+
+// DailyIngestionEvent announces that a day's games and box scores are stored,
+// so downstream jobs (model training) can start without polling
+type DailyIngestionEvent struct {
+	Type             string                   `json:"type"`
+	Sport            string                   `json:"sport"`
+	Date             string                   `json:"date"` // YYYY-MM-DD, US Eastern
+	Games            int                      `json:"games"`
+	GamesChanged     int                      `json:"games_changed"`
+	PlayersUpdated   int                      `json:"players_updated"` // player stat lines written
+	TeamStatsUpdated int                      `json:"team_stats_updated"`
+	DataQuality      store.DataQualitySummary `json:"data_quality"`
+	DurationMs       int64                    `json:"duration_ms"`
+	CompletedAt      time.Time                `json:"completed_at"`
+}
+
+// IngestionEventPublisher writes ingestion lifecycle events to
+// ingestion.events.{sport} through a Publisher and, when configured, POSTs
+// each to a webhook. A nil publisher drops events.
+type IngestionEventPublisher struct {
+	pub        Publisher
+	webhookURL string
+	httpClient *http.Client
+}
+
+// NewIngestionEventPublisher creates an ingestion event publisher sending to
+// the streams through pub (the outbox when it is enabled). An empty webhookURL
+// publishes to the stream only.
+func NewIngestionEventPublisher(pub Publisher, webhookURL string) *IngestionEventPublisher {
+	return &IngestionEventPublisher{
+		pub:        pub,
+		webhookURL: strings.TrimSpace(webhookURL),
+		httpClient: &http.Client{Timeout: gameEventWebhookTimeout},
+	}
+}
+
+// PublishDailyComplete sends a daily.ingestion.complete event to the stream and
+// the webhook. Both are attempted even if one fails; the errors are joined.
+func (p *IngestionEventPublisher) PublishDailyComplete(ctx context.Context, event *DailyIngestionEvent) error {
+	if p == nil {
+		return nil
+	}
+	event.Type = DailyIngestionComplete
+	if event.CompletedAt.IsZero() {
+		event.CompletedAt = time.Now().UTC()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	streamErr := p.pub.Publish(ctx, ingestionStreamPrefix+event.Sport, event)
+	if streamErr != nil {
+		streamErr = fmt.Errorf("publishing %s to stream: %w", event.Type, streamErr)
+	}
+
+	var webhookErr error
+	if p.webhookURL != "" {
+		webhookErr = postWebhook(ctx, p.httpClient, p.webhookURL, event.Type, data)
+	}
+
+	return errors.Join(streamErr, webhookErr)
+}
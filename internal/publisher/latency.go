@@ -0,0 +1,85 @@
+package publisher
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FreshnessTarget is how long after its source was fetched a live update
+// should reach WebSocket clients
+const FreshnessTarget = 15 * time.Second
+
+// Live pipeline stages reported on minerva_live_latency_seconds
+const (
+	LatencyReconcile = "reconcile" // source fetched to reconciled
+	LatencyPublish   = "publish"   // reconciled to handed to the publisher
+	LatencyStream    = "stream"    // handed to the publisher to appended to the stream (outbox relay included)
+	LatencyDeliver   = "deliver"   // appended to the stream to broadcast to WebSocket clients
+	LatencyTotal     = "total"     // source fetched to broadcast
+)
+
+// LatencyStamps trace a live update from its source through publishing. The
+// stream entry ID supplies the time it was appended, and the WebSocket feed
+// completes the trace when it broadcasts the update.
+type LatencyStamps struct {
+	Source       string    `json:"source"`     // google, espn, or replay
+	FetchedAt    time.Time `json:"fetched_at"` // when the source page or API was read
+	ReconciledAt time.Time `json:"reconciled_at"`
+	PublishedAt  time.Time `json:"published_at,omitempty"`
+}
+
+// StampLatency attaches a poll's timestamps to the update as it is handed to
+// the publisher. Polls without timestamps leave the update unstamped.
+func (u *LiveGameUpdate) StampLatency(poll LatencyStamps, now time.Time) {
+	if poll.FetchedAt.IsZero() {
+		return
+	}
+	poll.PublishedAt = now.UTC()
+	u.Latency = &poll
+}
+
+// ObservePublished records the reconcile and publish stages of a stamped update
+func ObservePublished(stamps *LatencyStamps) {
+	if stamps == nil {
+		return
+	}
+	observeStage(LatencyReconcile, stamps.ReconciledAt.Sub(stamps.FetchedAt))
+	observeStage(LatencyPublish, stamps.PublishedAt.Sub(stamps.ReconciledAt))
+}
+
+// ObserveDelivered records the stream, deliver, and total stages of an update
+// appended to its stream at appended and broadcast at now
+func ObserveDelivered(stamps *LatencyStamps, appended, now time.Time) {
+	if stamps == nil || stamps.FetchedAt.IsZero() {
+		return
+	}
+	if !appended.IsZero() {
+		observeStage(LatencyStream, appended.Sub(stamps.PublishedAt))
+		observeStage(LatencyDeliver, now.Sub(appended))
+	}
+	total := now.Sub(stamps.FetchedAt)
+	observeStage(LatencyTotal, total)
+	if total > FreshnessTarget {
+		liveUpdatesStale.Inc()
+	}
+}
+
+// observeStage drops negative spans, which only clock skew between hosts produces
+func observeStage(stage string, d time.Duration) {
+	if d < 0 {
+		return
+	}
+	liveLatency.WithLabelValues(stage).Observe(d.Seconds())
+}
+
+// StreamEntryTime is when Redis appended a stream entry, from its
+// <milliseconds>-<sequence> ID
+func StreamEntryTime(id string) time.Time {
+	ms, _, _ := strings.Cut(id, "-")
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(n)
+}
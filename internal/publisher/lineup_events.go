@@ -0,0 +1,35 @@
+package publisher
+
+import "time"
+
+// LineupsConfirmed is the type of a LineupEvent
+const LineupsConfirmed = "lineups.confirmed"
+
+// EventStarter is one player in a LineupEvent's starting lineup
+type EventStarter struct {
+	PlayerID   int    `json:"player_id"`
+	ESPNID     string `json:"espn_id"`
+	FullName   string `json:"full_name"`
+	Position   string `json:"position,omitempty"`
+	LineupSlot int    `json:"lineup_slot"`
+}
+
+// EventLineup is one team's starting five in a LineupEvent
+type EventLineup struct {
+	Team     GameEventTeam  `json:"team"`
+	Starters []EventStarter `json:"starters"`
+}
+
+// LineupEvent announces that ESPN lists five starters for both teams of an
+// upcoming game, or that a confirmed lineup changed before tip-off. It is
+// published on the lineups stream.
+type LineupEvent struct {
+	Type       string      `json:"type"`
+	Sport      string      `json:"sport"`
+	GameID     int         `json:"game_id"`
+	ESPNID     string      `json:"espn_id"`
+	TipOff     *time.Time  `json:"tip_off,omitempty"`
+	HomeTeam   EventLineup `json:"home_team"`
+	AwayTeam   EventLineup `json:"away_team"`
+	OccurredAt time.Time   `json:"occurred_at"`
+}
@@ -0,0 +1,97 @@
+package publisher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// liveStateTTL expires the last-published state of games nobody polls anymore
+const liveStateTTL = 12 * time.Hour
+
+// LiveStateTracker remembers a fingerprint of what was last published for each
+// game and stream, so pollers can skip games whose score, period, and clock have
+// not moved. State lives in Redis so it survives restarts and leader failover.
+type LiveStateTracker struct {
+	client *redis.Client
+}
+
+// NewLiveStateTracker creates a tracker backed by the given Redis client
+func NewLiveStateTracker(client *redis.Client) *LiveStateTracker {
+	return &LiveStateTracker{client: client}
+}
+
+func liveStateKey(stream string, gameID int) string {
+	return fmt.Sprintf("minerva:published:%s:%d", stream, gameID)
+}
+
+func fingerprintHash(fingerprint string) string {
+	sum := sha256.Sum256([]byte(fingerprint))
+	return hex.EncodeToString(sum[:])
+}
+
+// ShouldPublish reports whether the game's fingerprint differs from the last
+// one published to stream, or whether heartbeat has passed since that publish.
+// A zero heartbeat publishes on change only. Redis errors fail open.
+func (t *LiveStateTracker) ShouldPublish(ctx context.Context, stream string, gameID int, fingerprint string, heartbeat time.Duration) bool {
+	if t == nil {
+		return true
+	}
+
+	state, err := t.client.HGetAll(ctx, liveStateKey(stream, gameID)).Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Printf("[publisher] Failed to read published state for game %d: %v", gameID, err)
+		}
+		return true
+	}
+	if state["hash"] != fingerprintHash(fingerprint) {
+		return true
+	}
+	if heartbeat <= 0 {
+		return false
+	}
+
+	publishedAt, err := strconv.ParseInt(state["published_at"], 10, 64)
+	if err != nil {
+		return true
+	}
+	return time.Since(time.Unix(publishedAt, 0)) >= heartbeat
+}
+
+// Published reports whether anything has been published to stream for the
+// game within the state's TTL. Redis errors report false.
+func (t *LiveStateTracker) Published(ctx context.Context, stream string, gameID int) bool {
+	if t == nil {
+		return false
+	}
+
+	n, err := t.client.Exists(ctx, liveStateKey(stream, gameID)).Result()
+	if err != nil {
+		log.Printf("[publisher] Failed to read published state for game %d: %v", gameID, err)
+		return false
+	}
+	return n > 0
+}
+
+// MarkPublished records the fingerprint that was just published to stream
+func (t *LiveStateTracker) MarkPublished(ctx context.Context, stream string, gameID int, fingerprint string) {
+	if t == nil {
+		return
+	}
+
+	key := liveStateKey(stream, gameID)
+	pipe := t.client.TxPipeline()
+	pipe.HSet(ctx, key, "hash", fingerprintHash(fingerprint), "published_at", time.Now().Unix())
+	pipe.Expire(ctx, key, liveStateTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("[publisher] Failed to record published state for game %d: %v", gameID, err)
+	}
+}
@@ -0,0 +1,25 @@
+package publisher
+
+import "github.com/fortuna/minerva/internal/metrics"
+
+var (
+	streamLength = metrics.NewGaugeVec("minerva_redis_stream_length",
+		"Entries in each published Redis stream as of the last trim", "stream")
+	streamEntriesTrimmed = metrics.NewCounterVec("minerva_redis_stream_trimmed_total",
+		"Entries removed from each Redis stream by the age-based trim job", "stream")
+
+	outboxPending = metrics.NewGauge("minerva_outbox_pending",
+		"Outbox entries waiting to be published to Redis")
+	outboxPublished = metrics.NewCounter("minerva_outbox_published_total",
+		"Outbox entries delivered to Redis streams")
+	outboxFailures = metrics.NewCounter("minerva_outbox_publish_failures_total",
+		"Failed attempts to deliver an outbox entry; the entry is retried")
+	outboxLag = metrics.NewHistogramVec("minerva_outbox_delivery_lag_seconds",
+		"Time from enqueue to delivery for outbox entries", nil, "stream")
+
+	liveLatency = metrics.NewHistogramVec("minerva_live_latency_seconds",
+		"Time live updates spend in each pipeline stage, from source fetch to WebSocket broadcast",
+		[]float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15, 20, 30, 60}, "stage")
+	liveUpdatesStale = metrics.NewCounter("minerva_live_updates_stale_total",
+		"Live updates broadcast more than the 15s freshness target after their source was fetched")
+)
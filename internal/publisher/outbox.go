@@ -0,0 +1,185 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// OutboxConfig tunes the outbox relay
+type OutboxConfig struct {
+	PollInterval time.Duration // how often the relay looks for pending entries
+	BatchSize    int           // entries published per drain transaction
+	Retention    time.Duration // how long published entries are kept for inspection
+}
+
+// DefaultOutboxConfig polls every half second and keeps published entries for a day
+func DefaultOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		PollInterval: 500 * time.Millisecond,
+		BatchSize:    200,
+		Retention:    24 * time.Hour,
+	}
+}
+
+// outboxPurgeInterval is how often the relay deletes old published entries
+const outboxPurgeInterval = time.Hour
+
+// OutboxPublisher queues publishes in the event_outbox table instead of writing
+// to Redis directly. A publish succeeds once Postgres has it; the OutboxRelay
+// delivers it to the stream, retrying while Redis is unavailable.
+type OutboxPublisher struct {
+	outbox   *repository.OutboxRepository
+	validate bool
+}
+
+// NewOutboxPublisher creates a publisher that writes to the outbox
+func NewOutboxPublisher(db *store.Database) *OutboxPublisher {
+	return &OutboxPublisher{outbox: repository.NewOutboxRepository(db)}
+}
+
+// SetValidation makes enqueues fail for messages that don't match their
+// stream's schema, so an invalid message never reaches the relay
+func (op *OutboxPublisher) SetValidation(enabled bool) {
+	op.validate = enabled
+}
+
+// PublishLiveGameUpdate queues a live game update for the live stream
+func (op *OutboxPublisher) PublishLiveGameUpdate(ctx context.Context, gameData interface{}) error {
+	return op.Enqueue(ctx, nil, LiveStream, gameData)
+}
+
+// PublishGameStats queues final game stats for the stats stream
+func (op *OutboxPublisher) PublishGameStats(ctx context.Context, statsData interface{}) error {
+	return op.Enqueue(ctx, nil, StatsStream, statsData)
+}
+
+// Publish queues any JSON-encodable value for stream
+func (op *OutboxPublisher) Publish(ctx context.Context, stream string, value interface{}) error {
+	return op.Enqueue(ctx, nil, stream, value)
+}
+
+// Enqueue queues any JSON-encodable value for stream. Pass the writer's
+// transaction as exec to commit the publish atomically with the data it
+// describes; nil uses the primary pool.
+func (op *OutboxPublisher) Enqueue(ctx context.Context, exec repository.Execer, stream string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if op.validate {
+		if err := validateOutgoing(stream, data); err != nil {
+			return err
+		}
+	}
+	return op.outbox.Enqueue(ctx, exec, stream, data)
+}
+
+// Enqueuer is a Publisher that can queue an entry inside the caller's
+// database transaction. OutboxPublisher implements it.
+type Enqueuer interface {
+	Publisher
+	Enqueue(ctx context.Context, exec repository.Execer, stream string, value interface{}) error
+}
+
+// PublishTx publishes value on stream as part of exec's transaction when pub
+// is an Enqueuer, so the entry commits or rolls back with the data it
+// describes. Other publishers (and a nil exec) publish right away.
+func PublishTx(ctx context.Context, pub Publisher, exec repository.Execer, stream string, value interface{}) error {
+	if enqueuer, ok := pub.(Enqueuer); ok && exec != nil {
+		return enqueuer.Enqueue(ctx, exec, stream, value)
+	}
+	return pub.Publish(ctx, stream, value)
+}
+
+// OutboxRelay drains the outbox into Redis streams. Run it on one replica (the
+// leader); concurrent relays are safe but may reorder entries across replicas.
+type OutboxRelay struct {
+	outbox *repository.OutboxRepository
+	redis  *RedisPublisher
+	config OutboxConfig
+}
+
+// NewOutboxRelay creates a relay publishing through redis
+func NewOutboxRelay(db *store.Database, redis *RedisPublisher, config OutboxConfig) *OutboxRelay {
+	defaults := DefaultOutboxConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	return &OutboxRelay{
+		outbox: repository.NewOutboxRepository(db),
+		redis:  redis,
+		config: config,
+	}
+}
+
+// Run drains the outbox until ctx is cancelled
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	log.Printf("[outbox] Relay started (poll every %v)", r.config.PollInterval)
+	lastPurge := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("[outbox] Relay stopped")
+			return
+		case <-ticker.C:
+		}
+
+		r.drain(ctx)
+
+		if r.config.Retention > 0 && time.Since(lastPurge) >= outboxPurgeInterval {
+			lastPurge = time.Now()
+			if n, err := r.outbox.PurgePublished(ctx, time.Now().Add(-r.config.Retention)); err != nil {
+				log.Printf("[outbox] Failed to purge published entries: %v", err)
+			} else if n > 0 {
+				log.Printf("[outbox] Purged %d published entries", n)
+			}
+		}
+	}
+}
+
+// Flush publishes pending entries until the outbox is empty, a batch fails,
+// or ctx expires, and returns how many are still pending. Shutdown calls it
+// once the relay has stopped so queued updates reach Redis before exit.
+func (r *OutboxRelay) Flush(ctx context.Context) (int, error) {
+	r.drain(ctx)
+	return r.outbox.Pending(ctx)
+}
+
+// drain publishes full batches until the outbox is empty or a batch fails
+func (r *OutboxRelay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		failed := 0
+		delivered, err := r.outbox.Drain(ctx, r.config.BatchSize, func(entry *store.OutboxEntry) error {
+			if err := r.redis.PublishRaw(ctx, entry.Stream, entry.Payload); err != nil {
+				failed++
+				return err
+			}
+			outboxLag.WithLabelValues(entry.Stream).Observe(time.Since(entry.CreatedAt).Seconds())
+			return nil
+		})
+		if err != nil {
+			log.Printf("[outbox] Drain failed: %v", err)
+			break
+		}
+		outboxPublished.Add(float64(delivered))
+		outboxFailures.Add(float64(failed))
+		if failed > 0 || delivered < r.config.BatchSize {
+			break
+		}
+	}
+
+	if pending, err := r.outbox.Pending(ctx); err == nil {
+		outboxPending.Set(float64(pending))
+	}
+}
@@ -3,26 +3,47 @@ package publisher
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-// RedisStreamPublisher publishes events to Redis streams
-type RedisStreamPublisher struct {
-	client *redis.Client
-}
+// Stream names for live updates, derived live metrics, final box scores,
+// roster transactions, news, and starting lineups
+const (
+	LiveStream         = "games.live.basketball_nba"
+	DerivedStream      = "games.live.derived.basketball_nba"
+	StatsStream        = "games.stats.basketball_nba"
+	TransactionsStream = "transactions"
+	CoachesStream      = "coaches"
+	NewsStream         = "news"
+	LineupsStream      = "lineups"
+)
 
-// NewRedisStreamPublisher creates a new Redis stream publisher from existing client
-func NewRedisStreamPublisher(client *redis.Client) *RedisStreamPublisher {
-	return &RedisStreamPublisher{
-		client: client,
-	}
+// Publisher sends live updates, final box scores, and other stream entries to
+// downstream consumers. RedisPublisher writes straight to the streams;
+// OutboxPublisher queues in Postgres for at-least-once delivery.
+type Publisher interface {
+	PublishLiveGameUpdate(ctx context.Context, gameData interface{}) error
+	PublishGameStats(ctx context.Context, statsData interface{}) error
+	Publish(ctx context.Context, stream string, value interface{}) error
 }
 
-// RedisPublisher publishes events to Redis streams (legacy name)
+// RedisPublisher publishes events to Redis streams
 type RedisPublisher struct {
-	client *redis.Client
+	client   *redis.Client
+	owned    bool // Close closes the client only if the publisher opened it
+	maxLen   int64
+	validate bool
+}
+
+// NewRedisStreamPublisher creates a Redis stream publisher on an existing client
+func NewRedisStreamPublisher(client *redis.Client) *RedisPublisher {
+	return &RedisPublisher{
+		client: client,
+		maxLen: DefaultRetentionConfig().MaxLen,
+	}
 }
 
 // NewRedisPublisher creates a new Redis stream publisher
@@ -44,83 +65,82 @@ func NewRedisPublisher(redisURL string) (*RedisPublisher, error) {
 
 	return &RedisPublisher{
 		client: client,
+		owned:  true,
+		maxLen: DefaultRetentionConfig().MaxLen,
 	}, nil
 }
 
-// Close closes the Redis connection
-func (rp *RedisPublisher) Close() error {
-	return rp.client.Close()
+// SetMaxLen caps each stream at about n entries; 0 leaves streams unbounded
+func (rp *RedisPublisher) SetMaxLen(n int64) {
+	rp.maxLen = n
 }
 
-// PublishLiveGameUpdate publishes a live game update to the stream (for RedisStreamPublisher)
-func (rsp *RedisStreamPublisher) PublishLiveGameUpdate(ctx context.Context, gameData interface{}) error {
-	streamName := "games.live.basketball_nba"
-	
-	data, err := json.Marshal(gameData)
-	if err != nil {
-		return err
-	}
-
-	return rsp.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: streamName,
-		Values: map[string]interface{}{
-			"data":      string(data),
-			"timestamp": time.Now().Unix(),
-		},
-	}).Err()
+// SetValidation makes publishes fail for messages that don't match their
+// stream's schema (see ValidateMessage). Meant for tests and verification runs.
+func (rp *RedisPublisher) SetValidation(enabled bool) {
+	rp.validate = enabled
 }
 
-// PublishGameStats publishes final game stats to the stream (for RedisStreamPublisher)
-func (rsp *RedisStreamPublisher) PublishGameStats(ctx context.Context, statsData interface{}) error {
-	streamName := "games.stats.basketball_nba"
-	
-	data, err := json.Marshal(statsData)
-	if err != nil {
-		return err
+// Close closes the Redis connection if the publisher opened it
+func (rp *RedisPublisher) Close() error {
+	if !rp.owned {
+		return nil
 	}
-
-	return rsp.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: streamName,
-		Values: map[string]interface{}{
-			"data":      string(data),
-			"timestamp": time.Now().Unix(),
-		},
-	}).Err()
+	return rp.client.Close()
 }
 
-// PublishLiveGameUpdate publishes a live game update to the stream (for RedisPublisher)
+// PublishLiveGameUpdate publishes a live game update to the stream
 func (rp *RedisPublisher) PublishLiveGameUpdate(ctx context.Context, gameData interface{}) error {
-	streamName := "games.live.basketball_nba"
-	
-	data, err := json.Marshal(gameData)
-	if err != nil {
-		return err
-	}
-
-	return rp.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: streamName,
-		Values: map[string]interface{}{
-			"data":      string(data),
-			"timestamp": time.Now().Unix(),
-		},
-	}).Err()
+	return rp.publishJSON(ctx, LiveStream, gameData)
 }
 
 // PublishGameStats publishes final game stats to the stream
 func (rp *RedisPublisher) PublishGameStats(ctx context.Context, statsData interface{}) error {
-	streamName := "games.stats.basketball_nba"
-	
-	data, err := json.Marshal(statsData)
+	return rp.publishJSON(ctx, StatsStream, statsData)
+}
+
+// Publish appends any JSON-encodable value to a stream
+func (rp *RedisPublisher) Publish(ctx context.Context, stream string, value interface{}) error {
+	return rp.publishJSON(ctx, stream, value)
+}
+
+func (rp *RedisPublisher) publishJSON(ctx context.Context, stream string, value interface{}) error {
+	data, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
+	if rp.validate {
+		if err := validateOutgoing(stream, data); err != nil {
+			return err
+		}
+	}
+	return rp.PublishRaw(ctx, stream, data)
+}
 
-	return rp.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: streamName,
-		Values: map[string]interface{}{
-			"data":      string(data),
-			"timestamp": time.Now().Unix(),
-		},
-	}).Err()
+// PublishRaw appends an already encoded JSON payload to a stream. Entries on
+// the game and ingestion event streams also carry the event's type.
+func (rp *RedisPublisher) PublishRaw(ctx context.Context, stream string, data []byte) error {
+	values := map[string]interface{}{
+		"data":      string(data),
+		"timestamp": time.Now().Unix(),
+	}
+	if eventType := entryType(stream, data); eventType != "" {
+		values["type"] = eventType
+	}
+	return rp.client.XAdd(ctx, xaddArgs(stream, rp.maxLen, values)).Err()
 }
 
+// entryType reads the type of an event published on games.events.* or
+// ingestion.events.*, so consumers can filter entries without decoding data
+func entryType(stream string, data []byte) string {
+	if !strings.HasPrefix(stream, eventsStreamPrefix) && !strings.HasPrefix(stream, ingestionStreamPrefix) {
+		return ""
+	}
+	var event struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return ""
+	}
+	return event.Type
+}
@@ -0,0 +1,84 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Streams lists every stream Minerva publishes to, in the order the trim job visits them
+var Streams = []string{LiveStream, DerivedStream, StatsStream, eventsStreamPrefix + "basketball_nba", ingestionStreamPrefix + "basketball_nba", TransactionsStream, CoachesStream, NewsStream, LineupsStream}
+
+// RetentionConfig bounds how much history each stream keeps. MaxLen is applied
+// on every XADD (approximately, so Redis trims whole nodes); MaxAge is enforced
+// by the periodic trim job. Zero disables either bound.
+type RetentionConfig struct {
+	MaxLen int64
+	MaxAge time.Duration
+}
+
+// DefaultRetentionConfig keeps roughly the last 10,000 entries and one day of history
+func DefaultRetentionConfig() RetentionConfig {
+	return RetentionConfig{
+		MaxLen: 10000,
+		MaxAge: 24 * time.Hour,
+	}
+}
+
+// xaddArgs builds XADD arguments, capping the stream at about maxLen entries
+func xaddArgs(stream string, maxLen int64, values map[string]interface{}) *redis.XAddArgs {
+	args := &redis.XAddArgs{Stream: stream, Values: values}
+	if maxLen > 0 {
+		args.MaxLen = maxLen
+		args.Approx = true
+	}
+	return args
+}
+
+// StreamTrimmer drops stream entries older than the configured age and reports
+// stream lengths
+type StreamTrimmer struct {
+	client *redis.Client
+	config RetentionConfig
+}
+
+// NewStreamTrimmer creates a trimmer for the published streams
+func NewStreamTrimmer(client *redis.Client, config RetentionConfig) *StreamTrimmer {
+	return &StreamTrimmer{client: client, config: config}
+}
+
+// Trim removes entries older than MaxAge from every stream (approximately, via
+// XTRIM MINID ~) and updates the stream length gauges. Stream IDs start with
+// the entry's millisecond timestamp, so the cut-off time is a valid minimum ID.
+func (t *StreamTrimmer) Trim(ctx context.Context) (int64, error) {
+	minID := ""
+	if t.config.MaxAge > 0 {
+		minID = strconv.FormatInt(time.Now().Add(-t.config.MaxAge).UnixMilli(), 10)
+	}
+
+	var trimmed int64
+	var errs []error
+	for _, stream := range Streams {
+		if minID != "" {
+			n, err := t.client.XTrimMinIDApprox(ctx, stream, minID, 0).Result()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("trimming %s: %w", stream, err))
+				continue
+			}
+			trimmed += n
+			streamEntriesTrimmed.WithLabelValues(stream).Add(float64(n))
+		}
+
+		length, err := t.client.XLen(ctx, stream).Result()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("measuring %s: %w", stream, err))
+			continue
+		}
+		streamLength.WithLabelValues(stream).Set(float64(length))
+	}
+	return trimmed, errors.Join(errs...)
+}
@@ -0,0 +1,264 @@
+package publisher
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message schemas are JSON Schema documents, one per stream family, published
+// for downstream consumers. The validator below covers the subset they use.
+//
+//go:embed schemas/*.schema.json
+var schemaFiles embed.FS
+
+// streamSchemas maps a stream family (the stream name without its sport key)
+// to its schema document
+var streamSchemas = map[string]string{
+	"games.live":         "schemas/games.live.schema.json",
+	"games.live.derived": "schemas/games.live.derived.schema.json",
+	"games.stats":        "schemas/games.stats.schema.json",
+	"games.events":       "schemas/games.events.schema.json",
+}
+
+// ErrNoSchema is returned for streams without a published schema
+var ErrNoSchema = errors.New("no schema for stream")
+
+// Schema is the subset of JSON Schema (draft 2020-12) the message schemas use
+type Schema struct {
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 schemaTypes        `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Format               string             `json:"format,omitempty"` // only date-time is checked
+	Minimum              *float64           `json:"minimum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+}
+
+// schemaTypes accepts "type" as a single name or a list (e.g. ["integer", "null"])
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("schema type must be a string or list of strings: %w", err)
+	}
+	*t = many
+	return nil
+}
+
+// ValidationError lists every way a message departs from its stream's schema
+type ValidationError struct {
+	Stream   string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("message does not match %s schema: %s", e.Stream, strings.Join(e.Problems, "; "))
+}
+
+var (
+	schemasOnce sync.Once
+	schemas     map[string]*Schema
+	schemasErr  error
+)
+
+func loadSchemas() (map[string]*Schema, error) {
+	schemasOnce.Do(func() {
+		schemas = make(map[string]*Schema, len(streamSchemas))
+		for family, path := range streamSchemas {
+			data, err := schemaFiles.ReadFile(path)
+			if err != nil {
+				schemasErr = err
+				return
+			}
+			var schema Schema
+			if err := json.Unmarshal(data, &schema); err != nil {
+				schemasErr = fmt.Errorf("parsing %s: %w", path, err)
+				return
+			}
+			schemas[family] = &schema
+		}
+	})
+	return schemas, schemasErr
+}
+
+// schemaFamily returns the family of stream, e.g. games.live for
+// games.live.basketball_nba. The longest match wins, so
+// games.live.derived.basketball_nba is games.live.derived.
+func schemaFamily(stream string) (string, bool) {
+	match := ""
+	for family := range streamSchemas {
+		if (stream == family || strings.HasPrefix(stream, family+".")) && len(family) > len(match) {
+			match = family
+		}
+	}
+	return match, match != ""
+}
+
+// SchemaStreams lists the stream families that have a schema
+func SchemaStreams() []string {
+	families := make([]string, 0, len(streamSchemas))
+	for family := range streamSchemas {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+	return families
+}
+
+// SchemaDocument returns the JSON Schema document for stream as published
+func SchemaDocument(stream string) ([]byte, error) {
+	family, ok := schemaFamily(stream)
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrNoSchema, stream)
+	}
+	return schemaFiles.ReadFile(streamSchemas[family])
+}
+
+// ValidateMessage checks a message payload against its stream's schema. It
+// returns ErrNoSchema for streams without one and a *ValidationError listing
+// each problem for payloads that don't match.
+func ValidateMessage(stream string, data []byte) error {
+	family, ok := schemaFamily(stream)
+	if !ok {
+		return fmt.Errorf("%w %s", ErrNoSchema, stream)
+	}
+	loaded, err := loadSchemas()
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return &ValidationError{Stream: stream, Problems: []string{"invalid JSON: " + err.Error()}}
+	}
+
+	var problems []string
+	loaded[family].validate("$", value, &problems)
+	if len(problems) > 0 {
+		return &ValidationError{Stream: stream, Problems: problems}
+	}
+	return nil
+}
+
+// validateOutgoing checks a payload before it is published, letting streams
+// without a schema through
+func validateOutgoing(stream string, data []byte) error {
+	if err := ValidateMessage(stream, data); err != nil && !errors.Is(err, ErrNoSchema) {
+		return err
+	}
+	return nil
+}
+
+func (s *Schema) validate(path string, value interface{}, problems *[]string) {
+	if len(s.Type) > 0 && !s.matchesType(value) {
+		*problems = append(*problems, fmt.Sprintf("%s: expected %s, got %s", path, strings.Join(s.Type, " or "), jsonType(value)))
+		return
+	}
+
+	if len(s.Enum) > 0 && !s.inEnum(value) {
+		*problems = append(*problems, fmt.Sprintf("%s: %v is not one of %v", path, value, s.Enum))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*problems = append(*problems, fmt.Sprintf("%s: missing required property %q", path, name))
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if prop, ok := s.Properties[name]; ok {
+				prop.validate(path+"."+name, v[name], problems)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				*problems = append(*problems, fmt.Sprintf("%s: unexpected property %q", path, name))
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+	case string:
+		if s.MinLength != nil && len(v) < *s.MinLength {
+			*problems = append(*problems, fmt.Sprintf("%s: shorter than %d characters", path, *s.MinLength))
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				*problems = append(*problems, fmt.Sprintf("%s: %q is not an RFC 3339 date-time", path, v))
+			}
+		}
+	case json.Number:
+		if s.Minimum != nil {
+			if n, err := v.Float64(); err == nil && n < *s.Minimum {
+				*problems = append(*problems, fmt.Sprintf("%s: %s is below the minimum %v", path, v, *s.Minimum))
+			}
+		}
+	}
+}
+
+func (s *Schema) matchesType(value interface{}) bool {
+	actual := jsonType(value)
+	for _, want := range s.Type {
+		if want == actual || (want == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) inEnum(value interface{}) bool {
+	for _, allowed := range s.Enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType names the JSON Schema type of a value decoded with UseNumber
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(v.String()); ok && r.IsInt() {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
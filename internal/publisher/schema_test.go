@@ -0,0 +1,277 @@
+package publisher_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/service"
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/redis/go-redis/v9"
+)
+
+var tipOff = time.Date(2025, 1, 15, 0, 30, 0, 0, time.UTC)
+
+// liveGame is a game in the fourth quarter with every column the stream
+// payloads carry filled in
+func liveGame() *store.Game {
+	return &store.Game{
+		GameID:     42,
+		Sport:      store.DefaultSport,
+		SeasonID:   7,
+		ExternalID: "401705000",
+		GameDate:   time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC),
+		GameTime:   store.NullTime{Time: tipOff, Valid: true},
+		HomeTeamID: 2,
+		AwayTeamID: 14,
+		HomeScore:  store.NullInt32{Int32: 98, Valid: true},
+		AwayScore:  store.NullInt32{Int32: 95, Valid: true},
+		Status:     "in_progress",
+		GameType:   store.GameTypeRegular,
+		League:     store.LeagueNBA,
+		Period:     store.NullInt32{Int32: 4, Valid: true},
+		Clock:      store.NullString{String: "3:12", Valid: true},
+		Venue:      store.NullString{String: "TD Garden", Valid: true},
+		Attendance: store.NullInt32{Int32: 19156, Valid: true},
+		Broadcasts: []string{"ESPN"},
+		Metadata:   store.NullString{String: `{"tournament_group":"East Group A"}`, Valid: true},
+		CreatedAt:  tipOff.Add(-48 * time.Hour),
+		UpdatedAt:  tipOff.Add(2 * time.Hour),
+	}
+}
+
+// finalGame is liveGame once it finished
+func finalGame() *store.Game {
+	game := liveGame()
+	game.Status = "final"
+	game.HomeScore = store.NullInt32{Int32: 112, Valid: true}
+	game.AwayScore = store.NullInt32{Int32: 108, Valid: true}
+	game.Clock = store.NullString{String: "0:00", Valid: true}
+	game.FinalizedAt = store.NullTime{Time: tipOff.Add(150 * time.Minute), Valid: true}
+	game.StatsComplete = true
+	return game
+}
+
+// scheduledGame has only the columns a game has before tip-off
+func scheduledGame() *store.Game {
+	return &store.Game{
+		GameID:     43,
+		Sport:      store.DefaultSport,
+		SeasonID:   7,
+		ExternalID: "401705001",
+		GameDate:   time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC),
+		HomeTeamID: 5,
+		AwayTeamID: 9,
+		Status:     "scheduled",
+		GameType:   store.GameTypeRegular,
+		League:     store.LeagueNBA,
+	}
+}
+
+func gameEvent(eventType string, game *store.Game) *publisher.GameEvent {
+	event := &publisher.GameEvent{
+		Type:       eventType,
+		Sport:      game.Sport,
+		GameID:     game.GameID,
+		ESPNID:     game.ExternalID,
+		GameDate:   game.GameDate,
+		Status:     game.Status,
+		GameType:   game.GameType,
+		League:     game.League,
+		HomeTeam:   publisher.GameEventTeam{TeamID: game.HomeTeamID, ESPNID: "2", Abbreviation: "BOS"},
+		AwayTeam:   publisher.GameEventTeam{TeamID: game.AwayTeamID, Abbreviation: "LAL"},
+		OccurredAt: tipOff.Add(3 * time.Hour),
+	}
+	if game.GameTime.Valid {
+		event.TipOff = &game.GameTime.Time
+	}
+	return event
+}
+
+func boxScore(game *store.Game) *service.BoxScore {
+	return &service.BoxScore{
+		Game:          game,
+		StatsComplete: game.StatsComplete,
+		HomeTeam:      &store.Team{TeamID: game.HomeTeamID, Abbreviation: "BOS", FullName: "Boston Celtics"},
+		AwayTeam:      &store.Team{TeamID: game.AwayTeamID, Abbreviation: "LAL", FullName: "Los Angeles Lakers"},
+		HomeTeamStats: []*service.PlayerStatLine{{
+			Player: &store.Player{PlayerID: 1, FullName: "Jayson Tatum"},
+			Stats:  &store.PlayerGameStats{GameID: game.GameID, PlayerID: 1, TeamID: game.HomeTeamID, Points: 31},
+		}},
+		AwayTeamStats: []*service.PlayerStatLine{},
+	}
+}
+
+func liveUpdate() *publisher.LiveGameUpdate {
+	observed := tipOff.Add(2 * time.Hour)
+	update := publisher.NewLiveGameUpdate(liveGame(), publisher.ClockState{Period: 4, Seconds: 192, Running: true, ObservedAt: observed})
+	update.StampLatency(publisher.LatencyStamps{Source: "google", FetchedAt: observed, ReconciledAt: observed}, observed)
+	return update
+}
+
+func liveDerived(t *testing.T) *service.LiveDerived {
+	// Without a game ID Derive works from the score and clock alone
+	game := liveGame()
+	game.GameID = 0
+	derived, err := service.NewLiveDerivedService(nil).Derive(context.Background(), game)
+	if err != nil {
+		t.Fatalf("deriving live metrics: %v", err)
+	}
+	run := &service.ScoringRun{
+		TeamID: 2, Points: 10, Period: 4, StartClock: "6:40", EndClock: "3:12",
+		HomeScore: 98, AwayScore: 95, Description: "BOS 10-0 run",
+	}
+	derived.CurrentRun = run
+	derived.Runs = append(derived.Runs, run)
+	return derived
+}
+
+// TestMessagesMatchSchemas marshals every message published on a stream with a
+// schema and validates it, so a field added, renamed, or retyped on the Go side
+// fails here before it reaches consumers
+func TestMessagesMatchSchemas(t *testing.T) {
+	cases := []struct {
+		name    string
+		stream  string
+		message func(t *testing.T) interface{}
+	}{
+		{"live update", publisher.LiveStream, func(t *testing.T) interface{} { return liveUpdate() }},
+		{"live update before tip-off", publisher.LiveStream, func(t *testing.T) interface{} {
+			return publisher.NewLiveGameUpdate(scheduledGame(), publisher.ClockState{})
+		}},
+		{"final live update", publisher.LiveStream, func(t *testing.T) interface{} {
+			return publisher.NewLiveGameUpdate(finalGame(), publisher.ClockState{})
+		}},
+		{"live derived", publisher.DerivedStream, func(t *testing.T) interface{} { return liveDerived(t) }},
+		{"final stats", publisher.StatsStream, func(t *testing.T) interface{} { return finalGame() }},
+		{"game created", "games.events." + store.DefaultSport, func(t *testing.T) interface{} {
+			return gameEvent(publisher.GameEventCreated, scheduledGame())
+		}},
+		{"game updated", "games.events." + store.DefaultSport, func(t *testing.T) interface{} {
+			return gameEvent(publisher.GameEventUpdated, liveGame())
+		}},
+		{"game final", "games.events." + store.DefaultSport, func(t *testing.T) interface{} {
+			game := finalGame()
+			event := gameEvent(publisher.GameEventFinal, game)
+			event.BoxScore = boxScore(game)
+			return event
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(tc.message(t))
+			if err != nil {
+				t.Fatalf("marshaling: %v", err)
+			}
+			if err := publisher.ValidateMessage(tc.stream, data); err != nil {
+				t.Errorf("%v\n%s", err, data)
+			}
+		})
+	}
+}
+
+func TestValidateMessageRejectsDrift(t *testing.T) {
+	cases := []struct {
+		name   string
+		stream string
+		data   string
+	}{
+		{"missing required", publisher.StatsStream, `{"game_id": 1}`},
+		{"wrong type", publisher.LiveStream, `{"game_id": "42", "sport": "basketball_nba", "external_id": "1",
+			"home_team_id": 1, "away_team_id": 2, "home_score": 0, "away_score": 0, "status": "in_progress",
+			"period": 1, "clock": "12:00"}`},
+		{"unknown status", publisher.StatsStream, `{"game_id": 1, "sport": "basketball_nba", "season_id": 7,
+			"external_id": "1", "game_date": "2025-01-14T00:00:00Z", "home_team_id": 1, "away_team_id": 2,
+			"home_score": 100, "away_score": 90, "status": "postponed", "game_type": "regular"}`},
+		{"unknown event type", "games.events." + store.DefaultSport, `{"type": "game.deleted"}`},
+		{"invalid JSON", publisher.DerivedStream, `{`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var verr *publisher.ValidationError
+			if err := publisher.ValidateMessage(tc.stream, []byte(tc.data)); !errors.As(err, &verr) {
+				t.Fatalf("got %v, want a *ValidationError", err)
+			}
+		})
+	}
+
+	if err := publisher.ValidateMessage(publisher.NewsStream, []byte(`{}`)); !errors.Is(err, publisher.ErrNoSchema) {
+		t.Errorf("news: got %v, want ErrNoSchema", err)
+	}
+}
+
+// recordingPublisher keeps what it was asked to publish
+type recordingPublisher struct {
+	streams []string
+}
+
+func (p *recordingPublisher) PublishLiveGameUpdate(ctx context.Context, gameData interface{}) error {
+	return p.Publish(ctx, publisher.LiveStream, gameData)
+}
+
+func (p *recordingPublisher) PublishGameStats(ctx context.Context, statsData interface{}) error {
+	return p.Publish(ctx, publisher.StatsStream, statsData)
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, stream string, value interface{}) error {
+	p.streams = append(p.streams, stream)
+	return nil
+}
+
+func TestSetValidationRejectsBeforePublishing(t *testing.T) {
+	ctx := context.Background()
+	invalid := gameEvent("game.deleted", liveGame())
+
+	t.Run("redis", func(t *testing.T) {
+		// Nothing listens here; a message that got past validation would fail to connect
+		client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+		defer client.Close()
+		pub := publisher.NewRedisStreamPublisher(client)
+		pub.SetValidation(true)
+
+		var verr *publisher.ValidationError
+		if err := pub.Publish(ctx, "games.events."+store.DefaultSport, invalid); !errors.As(err, &verr) {
+			t.Errorf("invalid event: got %v, want a *ValidationError", err)
+		}
+		if err := pub.PublishGameStats(ctx, finalGame()); err == nil || errors.As(err, &verr) {
+			t.Errorf("valid stats: got %v, want a connection error", err)
+		}
+	})
+
+	t.Run("outbox", func(t *testing.T) {
+		// Rejected before the outbox table is touched, so no database is needed
+		pub := publisher.NewOutboxPublisher(nil)
+		pub.SetValidation(true)
+
+		var verr *publisher.ValidationError
+		if err := pub.Publish(ctx, "games.events."+store.DefaultSport, invalid); !errors.As(err, &verr) {
+			t.Errorf("got %v, want a *ValidationError", err)
+		}
+	})
+
+	t.Run("game events", func(t *testing.T) {
+		recorder := &recordingPublisher{}
+		events := publisher.NewGameEventPublisher(recorder, "")
+		events.SetValidation(true)
+
+		var verr *publisher.ValidationError
+		if err := events.PublishGameEvent(ctx, invalid); !errors.As(err, &verr) {
+			t.Errorf("invalid event: got %v, want a *ValidationError", err)
+		}
+		if len(recorder.streams) != 0 {
+			t.Fatalf("invalid event reached the publisher on %v", recorder.streams)
+		}
+
+		if err := events.PublishGameEvent(ctx, gameEvent(publisher.GameEventCreated, scheduledGame())); err != nil {
+			t.Fatalf("valid event: %v", err)
+		}
+		if want := "games.events." + store.DefaultSport; len(recorder.streams) != 1 || recorder.streams[0] != want {
+			t.Errorf("published on %v, want [%s]", recorder.streams, want)
+		}
+	})
+}
@@ -0,0 +1,224 @@
+// Package quality validates ingested box scores against consistency rules and
+// records the violations for operators.
+package quality
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// Rule names, usable in Config.DisabledRules and the ?rule= filter
+const (
+	RuleTeamPoints     = "team_points_match"
+	RuleShotsAttempted = "shots_made_le_attempted"
+	RuleTeamMinutes    = "team_minutes_total"
+	RuleRebounds       = "rebounds_consistent"
+)
+
+// Severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Regulation and overtime lengths in minutes, per team (five players on the floor)
+const (
+	regulationTeamMinutes = 240
+	overtimeTeamMinutes   = 25
+	regulationPeriods     = 4
+)
+
+// Config tunes the validation rules
+type Config struct {
+	Enabled          bool
+	PointsTolerance  int      // allowed gap between team points and the sum of player points
+	MinutesTolerance float64  // allowed gap between team minutes and 240 (+25 per OT)
+	DisabledRules    []string // rule names to skip
+}
+
+// DefaultConfig returns default validation settings. Player minutes are rounded
+// per player in ESPN box scores, so the minutes check allows a few minutes of slack.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:          true,
+		PointsTolerance:  0,
+		MinutesTolerance: 5,
+	}
+}
+
+// BoxScore is the stored data for one game that the rules inspect
+type BoxScore struct {
+	Game    *store.Game
+	Teams   []*store.TeamGameStats
+	Players []*store.PlayerGameStats
+}
+
+// Rule checks one consistency property of a box score
+type Rule struct {
+	Name     string
+	Severity string
+	Check    func(box *BoxScore, config Config) []store.DataQualityIssue
+}
+
+// Rules lists every validation rule in evaluation order
+var Rules = []Rule{
+	{Name: RuleTeamPoints, Severity: SeverityError, Check: checkTeamPoints},
+	{Name: RuleShotsAttempted, Severity: SeverityError, Check: checkShotsAttempted},
+	{Name: RuleTeamMinutes, Severity: SeverityWarning, Check: checkTeamMinutes},
+	{Name: RuleRebounds, Severity: SeverityWarning, Check: checkRebounds},
+}
+
+// checkTeamPoints compares each team's total with the sum of its players' points
+func checkTeamPoints(box *BoxScore, config Config) []store.DataQualityIssue {
+	sums := playerSums(box, func(p *store.PlayerGameStats) float64 { return float64(p.Points) })
+
+	var issues []store.DataQualityIssue
+	for _, team := range box.Teams {
+		sum, ok := sums[team.TeamID]
+		if !ok {
+			continue
+		}
+		if math.Abs(float64(team.Points)-sum) > float64(config.PointsTolerance) {
+			issues = append(issues, measured(teamIssue(team.TeamID,
+				fmt.Sprintf("team scored %d but player points sum to %.0f", team.Points, sum)), float64(team.Points), sum))
+		}
+	}
+	return issues
+}
+
+// checkShotsAttempted flags makes exceeding attempts on team and player lines
+func checkShotsAttempted(box *BoxScore, config Config) []store.DataQualityIssue {
+	var issues []store.DataQualityIssue
+	for _, team := range box.Teams {
+		for _, msg := range shootingErrors(team.FieldGoalsMade, team.FieldGoalsAttempted,
+			team.ThreePointersMade, team.ThreePointersAttempted, team.FreeThrowsMade, team.FreeThrowsAttempted) {
+			issues = append(issues, teamIssue(team.TeamID, msg))
+		}
+	}
+	for _, player := range box.Players {
+		for _, msg := range shootingErrors(player.FieldGoalsMade, player.FieldGoalsAttempted,
+			player.ThreePointersMade, player.ThreePointersAttempted, player.FreeThrowsMade, player.FreeThrowsAttempted) {
+			issues = append(issues, playerIssue(player, msg))
+		}
+	}
+	return issues
+}
+
+func shootingErrors(fgm, fga, tpm, tpa, ftm, fta int) []string {
+	var errs []string
+	if fgm > fga {
+		errs = append(errs, fmt.Sprintf("field goals made %d > attempted %d", fgm, fga))
+	}
+	if tpm > tpa {
+		errs = append(errs, fmt.Sprintf("threes made %d > attempted %d", tpm, tpa))
+	}
+	if ftm > fta {
+		errs = append(errs, fmt.Sprintf("free throws made %d > attempted %d", ftm, fta))
+	}
+	if tpm > fgm {
+		errs = append(errs, fmt.Sprintf("threes made %d > field goals made %d", tpm, fgm))
+	}
+	if tpa > fga {
+		errs = append(errs, fmt.Sprintf("threes attempted %d > field goals attempted %d", tpa, fga))
+	}
+	return errs
+}
+
+// checkTeamMinutes compares each team's summed player minutes with the game length
+func checkTeamMinutes(box *BoxScore, config Config) []store.DataQualityIssue {
+	periods := regulationPeriods
+	if box.Game != nil && box.Game.Period.Valid && int(box.Game.Period.Int32) > periods {
+		periods = int(box.Game.Period.Int32)
+	}
+	expected := float64(regulationTeamMinutes + overtimeTeamMinutes*(periods-regulationPeriods))
+
+	tracked := map[int]bool{}
+	for _, p := range box.Players {
+		if p.MinutesPlayed.Valid {
+			tracked[p.TeamID] = true
+		}
+	}
+	sums := playerSums(box, func(p *store.PlayerGameStats) float64 { return p.MinutesPlayed.Float64 })
+
+	var issues []store.DataQualityIssue
+	for _, teamID := range sortedTeams(sums) {
+		if !tracked[teamID] {
+			continue
+		}
+		if minutes := sums[teamID]; math.Abs(minutes-expected) > config.MinutesTolerance {
+			issues = append(issues, measured(teamIssue(teamID,
+				fmt.Sprintf("player minutes sum to %.1f, expected %.0f for %d periods", minutes, expected, periods)), expected, minutes))
+		}
+	}
+	return issues
+}
+
+// checkRebounds requires offensive + defensive = total on every line, and team
+// totals (which include team rebounds) to be at least the players' sum
+func checkRebounds(box *BoxScore, config Config) []store.DataQualityIssue {
+	var issues []store.DataQualityIssue
+	for _, team := range box.Teams {
+		if split := team.OffensiveRebounds + team.DefensiveRebounds; split != team.Rebounds {
+			issues = append(issues, measured(teamIssue(team.TeamID,
+				fmt.Sprintf("offensive %d + defensive %d rebounds != total %d", team.OffensiveRebounds, team.DefensiveRebounds, team.Rebounds)),
+				float64(team.Rebounds), float64(split)))
+		}
+	}
+	for _, player := range box.Players {
+		if split := player.OffensiveRebounds + player.DefensiveRebounds; split != player.Rebounds {
+			issues = append(issues, measured(playerIssue(player,
+				fmt.Sprintf("offensive %d + defensive %d rebounds != total %d", player.OffensiveRebounds, player.DefensiveRebounds, player.Rebounds)),
+				float64(player.Rebounds), float64(split)))
+		}
+	}
+
+	sums := playerSums(box, func(p *store.PlayerGameStats) float64 { return float64(p.Rebounds) })
+	for _, team := range box.Teams {
+		if sum, ok := sums[team.TeamID]; ok && float64(team.Rebounds) < sum {
+			issues = append(issues, measured(teamIssue(team.TeamID,
+				fmt.Sprintf("team rebounds %d < player rebounds %.0f", team.Rebounds, sum)), sum, float64(team.Rebounds)))
+		}
+	}
+	return issues
+}
+
+// playerSums totals a per-player value by team
+func playerSums(box *BoxScore, value func(*store.PlayerGameStats) float64) map[int]float64 {
+	sums := make(map[int]float64)
+	for _, p := range box.Players {
+		sums[p.TeamID] += value(p)
+	}
+	return sums
+}
+
+func sortedTeams(sums map[int]float64) []int {
+	ids := make([]int, 0, len(sums))
+	for id := range sums {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func teamIssue(teamID int, message string) store.DataQualityIssue {
+	return store.DataQualityIssue{
+		TeamID:  store.NullInt32{Int32: int32(teamID), Valid: true},
+		Message: message,
+	}
+}
+
+func playerIssue(p *store.PlayerGameStats, message string) store.DataQualityIssue {
+	issue := teamIssue(p.TeamID, message)
+	issue.PlayerID = store.NullInt32{Int32: int32(p.PlayerID), Valid: true}
+	return issue
+}
+
+// measured attaches the expected and actual values a rule compared
+func measured(issue store.DataQualityIssue, expected, actual float64) store.DataQualityIssue {
+	issue.Expected = store.NullFloat64{Float64: expected, Valid: true}
+	issue.Actual = store.NullFloat64{Float64: actual, Valid: true}
+	return issue
+}
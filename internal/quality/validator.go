@@ -0,0 +1,80 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// Validator runs the enabled rules against a stored game and records the result
+type Validator struct {
+	config   Config
+	disabled map[string]bool
+	games    *repository.GameRepository
+	stats    *repository.StatsRepository
+	issues   *repository.DataQualityRepository
+}
+
+// NewValidator creates a validator
+func NewValidator(db *store.Database, config Config) *Validator {
+	disabled := make(map[string]bool, len(config.DisabledRules))
+	for _, name := range config.DisabledRules {
+		disabled[name] = true
+	}
+	return &Validator{
+		config:   config,
+		disabled: disabled,
+		games:    repository.NewGameRepository(db),
+		stats:    repository.NewStatsRepository(db),
+		issues:   repository.NewDataQualityRepository(db),
+	}
+}
+
+// Check evaluates the enabled rules against a box score without storing anything
+func (v *Validator) Check(box *BoxScore) []store.DataQualityIssue {
+	var issues []store.DataQualityIssue
+	for _, rule := range Rules {
+		if v.disabled[rule.Name] {
+			continue
+		}
+		for _, issue := range rule.Check(box, v.config) {
+			issue.Rule = rule.Name
+			issue.Severity = rule.Severity
+			if box.Game != nil {
+				issue.GameID = box.Game.GameID
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// ValidateGame loads a game's box score, checks it, and replaces its stored
+// issues with the result. A disabled validator (or nil) does nothing.
+func (v *Validator) ValidateGame(ctx context.Context, gameID int) ([]store.DataQualityIssue, error) {
+	if v == nil || !v.config.Enabled {
+		return nil, nil
+	}
+
+	ctx = store.WithPrimary(ctx)
+	game, err := v.games.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("load game %d: %w", gameID, err)
+	}
+	players, err := v.stats.GetByGameID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("load player stats for game %d: %w", gameID, err)
+	}
+	teams, err := v.stats.GetTeamStatsByGameID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("load team stats for game %d: %w", gameID, err)
+	}
+
+	issues := v.Check(&BoxScore{Game: game, Teams: teams, Players: players})
+	if err := v.issues.ReplaceForGame(ctx, gameID, issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
@@ -1,7 +1,6 @@
 package reconciliation
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
 	"time"
@@ -33,11 +32,11 @@ const (
 
 // Metrics tracks reconciliation statistics
 type Metrics struct {
-	TotalReconciliations int
-	Conflicts            int
-	GooglePreferred      int
-	ESPNPreferred        int
-	LastReconciliation   time.Time
+	TotalReconciliations int       `json:"total_reconciliations"`
+	Conflicts            int       `json:"conflicts"`
+	GooglePreferred      int       `json:"google_preferred"`
+	ESPNPreferred        int       `json:"espn_preferred"`
+	LastReconciliation   time.Time `json:"last_reconciliation"`
 }
 
 // NewEngine creates a new reconciliation engine
@@ -123,8 +122,10 @@ func (e *Engine) reconcileSmartMerge(espnGame *store.Game, googleGame *google.Li
 	merged.HomeTeamID = espnGame.HomeTeamID
 	merged.AwayTeamID = espnGame.AwayTeamID
 	merged.GameDate = espnGame.GameDate
+	merged.GameType = espnGame.GameType
 	merged.Venue = espnGame.Venue
 	merged.Attendance = espnGame.Attendance
+	merged.Broadcasts = espnGame.Broadcasts
 	
 	// Game state determines which source to trust for live data
 	gameState := determineGameState(espnGame, googleGame)
@@ -146,8 +147,8 @@ func (e *Engine) reconcileSmartMerge(espnGame *store.Game, googleGame *google.Li
 		
 		// Use Google scores if available, otherwise fall back to ESPN
 		if googleGame.HomeScore > 0 || googleGame.AwayScore > 0 {
-			merged.HomeScore = sql.NullInt32{Int32: int32(googleGame.HomeScore), Valid: true}
-			merged.AwayScore = sql.NullInt32{Int32: int32(googleGame.AwayScore), Valid: true}
+			merged.HomeScore = store.NullInt32{Int32: int32(googleGame.HomeScore), Valid: true}
+			merged.AwayScore = store.NullInt32{Int32: int32(googleGame.AwayScore), Valid: true}
 		} else if espnGame.HomeScore.Valid || espnGame.AwayScore.Valid {
 			merged.HomeScore = espnGame.HomeScore
 			merged.AwayScore = espnGame.AwayScore
@@ -155,14 +156,14 @@ func (e *Engine) reconcileSmartMerge(espnGame *store.Game, googleGame *google.Li
 		
 		// Use Google period if available, otherwise fall back to ESPN
 		if googleGame.Period > 0 {
-			merged.Period = sql.NullInt32{Int32: int32(googleGame.Period), Valid: true}
+			merged.Period = store.NullInt32{Int32: int32(googleGame.Period), Valid: true}
 		} else if espnGame.Period.Valid {
 			merged.Period = espnGame.Period
 		}
 		
 		// Use Google time if available, otherwise fall back to ESPN
 		if googleGame.TimeRemaining != "" {
-			merged.Clock = sql.NullString{String: googleGame.TimeRemaining, Valid: true}
+			merged.Clock = store.NullString{String: googleGame.TimeRemaining, Valid: true}
 		} else if espnGame.Clock.Valid {
 			merged.Clock = espnGame.Clock
 		}
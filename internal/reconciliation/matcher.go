@@ -9,17 +9,20 @@ import (
 
 // Matcher handles matching games across different data sources
 type Matcher struct {
+	resolver          *store.TeamResolver
 	teamAbbreviations map[int]string // teamID -> abbreviation
 }
 
-// NewMatcher creates a new game matcher
-func NewMatcher(teams []*store.Team) *Matcher {
+// NewMatcher creates a game matcher that reads Google team names with resolver,
+// the same one the Google parser uses
+func NewMatcher(resolver *store.TeamResolver) *Matcher {
 	abbrevMap := make(map[int]string)
-	for _, team := range teams {
+	for _, team := range resolver.Teams() {
 		abbrevMap[team.TeamID] = team.Abbreviation
 	}
 	
 	return &Matcher{
+		resolver:          resolver,
 		teamAbbreviations: abbrevMap,
 	}
 }
@@ -42,8 +45,8 @@ func (m *Matcher) FindMatchingGoogleGame(espnGame *store.Game, googleGames []goo
 		game := &googleGames[i]
 		
 		// Normalize Google team names
-		googleHomeAbbr := google.GetTeamAbbreviation(game.HomeTeam)
-		googleAwayAbbr := google.GetTeamAbbreviation(game.AwayTeam)
+		googleHomeAbbr := m.resolver.Abbreviation(game.HomeTeam)
+		googleAwayAbbr := m.resolver.Abbreviation(game.AwayTeam)
 		
 		// Check if teams match
 		if matchTeams(homeAbbr, googleHomeAbbr) && matchTeams(awayAbbr, googleAwayAbbr) {
@@ -57,8 +60,8 @@ func (m *Matcher) FindMatchingGoogleGame(espnGame *store.Game, googleGames []goo
 // FindMatchingESPNGame finds an ESPN game that matches a Google game
 func (m *Matcher) FindMatchingESPNGame(googleGame *google.LiveGame, espnGames []*store.Game) *store.Game {
 	// Normalize Google team names
-	googleHomeAbbr := google.GetTeamAbbreviation(googleGame.HomeTeam)
-	googleAwayAbbr := google.GetTeamAbbreviation(googleGame.AwayTeam)
+	googleHomeAbbr := m.resolver.Abbreviation(googleGame.HomeTeam)
+	googleAwayAbbr := m.resolver.Abbreviation(googleGame.AwayTeam)
 	
 	// Try to find matching game
 	for _, espnGame := range espnGames {
@@ -81,37 +84,10 @@ func (m *Matcher) FindMatchingESPNGame(googleGame *google.LiveGame, espnGames []
 	return nil
 }
 
-// matchTeams checks if two team identifiers match
+// matchTeams checks if two team abbreviations match. Google names are resolved
+// to abbreviations (aliases included) before they get here.
 func matchTeams(abbr1, abbr2 string) bool {
-	// Exact match
-	if strings.EqualFold(abbr1, abbr2) {
-		return true
-	}
-	
-	// Handle special cases
-	specialCases := map[string][]string{
-		"LAL": {"Lakers", "Los Angeles Lakers", "LA Lakers"},
-		"LAC": {"Clippers", "Los Angeles Clippers", "LA Clippers"},
-		"GSW": {"Warriors", "Golden State Warriors", "GS Warriors"},
-		"BKN": {"Nets", "Brooklyn Nets"},
-		"NYK": {"Knicks", "New York Knicks", "NY Knicks"},
-		"PHX": {"Suns", "Phoenix Suns"},
-		"SAS": {"Spurs", "San Antonio Spurs", "SA Spurs"},
-	}
-	
-	// Check if either abbr matches any variant
-	for key, variants := range specialCases {
-		if strings.EqualFold(abbr1, key) || strings.EqualFold(abbr2, key) {
-			for _, variant := range variants {
-				if strings.Contains(strings.ToLower(abbr1), strings.ToLower(variant)) ||
-					strings.Contains(strings.ToLower(abbr2), strings.ToLower(variant)) {
-					return true
-				}
-			}
-		}
-	}
-	
-	return false
+	return strings.EqualFold(abbr1, abbr2)
 }
 
 // MatchAndReconcileAll matches and reconciles all games from both sources
@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit bounds Next so an impossible schedule (e.g. "0 0 31 2 *") fails
+// instead of looping forever
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// Schedule is a parsed five-field cron expression: minute, hour, day of month,
+// month, and day of week. Fields accept "*", single values, ranges ("1-5"),
+// lists ("1,15"), and steps ("*/15", "0-30/10"). Day of week runs 0-6 from
+// Sunday, with 7 also accepted for Sunday.
+type Schedule struct {
+	spec   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDom bool
+	anyDow bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseSchedule parses a five-field cron expression
+func ParseSchedule(spec string) (*Schedule, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("cron expression %q: expected 5 fields, got %d", spec, len(parts))
+	}
+
+	var sets [5]uint64
+	for i, part := range parts {
+		set, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", spec, err)
+		}
+		sets[i] = set
+	}
+
+	// Fold 7 into Sunday
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = sets[4]&^(1<<7) | 1
+	}
+
+	return &Schedule{
+		spec:   strings.Join(parts, " "),
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		anyDom: parts[2] == "*",
+		anyDow: parts[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, bounds cronField) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step := item, 1
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			n, err := strconv.Atoi(item[idx+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s: invalid step in %q", bounds.name, item)
+			}
+			rangePart, step = item[:idx], n
+		}
+
+		lo, hi := bounds.min, bounds.max
+		if rangePart != "*" {
+			var err error
+			if idx := strings.Index(rangePart, "-"); idx >= 0 {
+				if lo, err = strconv.Atoi(rangePart[:idx]); err == nil {
+					hi, err = strconv.Atoi(rangePart[idx+1:])
+				}
+			} else {
+				lo, err = strconv.Atoi(rangePart)
+				if step == 1 {
+					hi = lo
+				}
+			}
+			if err != nil {
+				return 0, fmt.Errorf("%s: invalid value %q", bounds.name, item)
+			}
+		}
+		if lo < bounds.min || hi > bounds.max || lo > hi {
+			return 0, fmt.Errorf("%s: %q out of range %d-%d", bounds.name, item, bounds.min, bounds.max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// String returns the normalized expression
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first minute strictly after t that matches the schedule, in
+// t's location, or the zero time if none exists within five years
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches follows cron semantics: when both day fields are restricted, a day
+// matching either one is enough
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDom && s.anyDow:
+		return true
+	case s.anyDom:
+		return dowMatch
+	case s.anyDow:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
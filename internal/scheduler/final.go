@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// errAwaitingESPN defers a game Google reports final until ESPN, whose box
+// score is ingested, agrees
+var errAwaitingESPN = errors.New("not final in ESPN yet")
+
+// finalizeGames ingests the full box score of each game live polling saw go
+// from in progress to final and announces it with a game.final event, instead
+// of leaving the final stats to daily ingestion. A game whose ingestion or
+// event fails is retried on the next poll.
+func (o *Orchestrator) finalizeGames(ctx context.Context, games []*store.Game) {
+	for _, game := range games {
+		if game.GameID == 0 || !o.liveState.Published(ctx, publisher.LiveStream, game.GameID) {
+			continue // never published live, so not a transition this poller saw
+		}
+		if !o.liveState.ShouldPublish(ctx, publisher.GameEventFinal, game.GameID, game.Status, 0) {
+			continue
+		}
+
+		err := o.finalizeGame(ctx, game.GameID)
+		if errors.Is(err, errAwaitingESPN) {
+			continue
+		}
+		if err != nil {
+			finalIngestions.WithLabelValues("error").Inc()
+			log.Printf("  ⚠️  Failed to finalize game %d: %v", game.GameID, err)
+			continue
+		}
+		finalIngestions.WithLabelValues("ok").Inc()
+		o.liveState.MarkPublished(ctx, publisher.GameEventFinal, game.GameID, game.Status)
+	}
+}
+
+// finalizeGame refetches a final game's box score and publishes it
+func (o *Orchestrator) finalizeGame(ctx context.Context, gameID int) error {
+	// Read back what was just written, never a lagging replica
+	ctx = store.WithPrimary(ctx)
+	game, err := o.games.GetByID(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if game.Status != "final" {
+		return errAwaitingESPN
+	}
+	ingester := o.ingesterFor(game.League)
+	if ingester == nil {
+		return fmt.Errorf("league %s is not ingested", game.League)
+	}
+
+	counts, err := ingester.RefreshGameStats(ctx, game)
+	if err != nil {
+		return fmt.Errorf("ingesting final stats: %w", err)
+	}
+	box, err := o.stats.GetGameBoxScore(ctx, game.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	event := &publisher.GameEvent{
+		Type:     publisher.GameEventFinal,
+		Sport:    game.Sport,
+		GameID:   game.GameID,
+		ESPNID:   game.ExternalID,
+		GameDate: game.GameDate,
+		Status:   game.Status,
+		GameType: game.GameType,
+		League:   game.League,
+		HomeTeam: publisher.GameEventTeam{
+			TeamID:       box.HomeTeam.TeamID,
+			ESPNID:       box.HomeTeam.ExternalID,
+			Abbreviation: box.HomeTeam.Abbreviation,
+		},
+		AwayTeam: publisher.GameEventTeam{
+			TeamID:       box.AwayTeam.TeamID,
+			ESPNID:       box.AwayTeam.ExternalID,
+			Abbreviation: box.AwayTeam.Abbreviation,
+		},
+		BoxScore: box,
+	}
+	if game.GameTime.Valid {
+		tipOff := game.GameTime.Time
+		event.TipOff = &tipOff
+	}
+	if err := o.gameEvents.PublishGameEvent(ctx, event); err != nil {
+		return err
+	}
+
+	log.Printf("  ✓ Game %s final: box score ingested (%s) and published", game.ExternalID, counts)
+	return nil
+}
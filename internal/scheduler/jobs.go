@@ -0,0 +1,175 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Job run outcomes reported in JobStatus.LastResult
+const (
+	JobResultSuccess = "success"
+	JobResultFailed  = "failed"
+)
+
+// JobConfig is the operator-controlled part of a job: when it runs and whether it runs at all
+type JobConfig struct {
+	Schedule string // five-field cron expression, evaluated in local time
+	Enabled  bool
+}
+
+// JobFunc is the work a scheduled job performs
+type JobFunc func(ctx context.Context) error
+
+// JobStatus is a job's configuration and the outcome of its most recent run
+type JobStatus struct {
+	Name           string     `json:"name"`
+	Schedule       string     `json:"schedule"`
+	Enabled        bool       `json:"enabled"`
+	Running        bool       `json:"running"`
+	NextRunAt      *time.Time `json:"next_run_at,omitempty"`
+	LastStartedAt  *time.Time `json:"last_started_at,omitempty"`
+	LastFinishedAt *time.Time `json:"last_finished_at,omitempty"`
+	LastDuration   string     `json:"last_duration,omitempty"`
+	LastResult     string     `json:"last_result,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	RunCount       int        `json:"run_count"`
+	FailureCount   int        `json:"failure_count"`
+}
+
+type job struct {
+	schedule *Schedule
+	run      JobFunc
+
+	mu     sync.Mutex
+	status JobStatus
+}
+
+// JobRegistry runs named tasks on cron schedules and tracks their last-run status.
+// A job never overlaps itself: a tick that arrives while it is still running is skipped.
+type JobRegistry struct {
+	mu   sync.RWMutex
+	jobs map[string]*job
+}
+
+// NewJobRegistry creates an empty job registry
+func NewJobRegistry() *JobRegistry {
+	return &JobRegistry{jobs: make(map[string]*job)}
+}
+
+// Register adds a job. Disabled jobs are listed but never run.
+func (r *JobRegistry) Register(name string, cfg JobConfig, run JobFunc) error {
+	schedule, err := ParseSchedule(cfg.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.jobs[name]; exists {
+		return fmt.Errorf("job %s already registered", name)
+	}
+	r.jobs[name] = &job{
+		schedule: schedule,
+		run:      run,
+		status: JobStatus{
+			Name:     name,
+			Schedule: schedule.String(),
+			Enabled:  cfg.Enabled,
+		},
+	}
+	return nil
+}
+
+// Start launches a loop per enabled job; they stop when ctx is cancelled
+func (r *JobRegistry) Start(ctx context.Context) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for name, j := range r.jobs {
+		if !j.status.Enabled {
+			log.Printf("→ Job %s disabled", name)
+			continue
+		}
+		go r.loop(ctx, name, j)
+	}
+}
+
+// Statuses returns every registered job, ordered by name
+func (r *JobRegistry) Statuses() []JobStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	statuses := make([]JobStatus, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		j.mu.Lock()
+		statuses = append(statuses, j.status)
+		j.mu.Unlock()
+	}
+	sort.Slice(statuses, func(a, b int) bool { return statuses[a].Name < statuses[b].Name })
+	return statuses
+}
+
+func (r *JobRegistry) loop(ctx context.Context, name string, j *job) {
+	log.Printf("→ Job %s scheduled (%s)", name, j.schedule)
+
+	for {
+		next := j.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Printf("  ⚠️  Job %s has no upcoming run for %q; stopping", name, j.schedule)
+			return
+		}
+		j.mu.Lock()
+		j.status.NextRunAt = &next
+		j.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			log.Printf("→ Job %s stopped", name)
+			return
+		case <-timer.C:
+			if err := r.execute(ctx, name, j); err != nil {
+				log.Printf("❌ Job %s failed: %v", name, err)
+			}
+		}
+	}
+}
+
+func (r *JobRegistry) execute(ctx context.Context, name string, j *job) error {
+	j.mu.Lock()
+	if j.status.Running {
+		j.mu.Unlock()
+		return fmt.Errorf("job %s is already running", name)
+	}
+	started := time.Now()
+	j.status.Running = true
+	j.status.LastStartedAt = &started
+	j.mu.Unlock()
+
+	log.Printf("═══ Job %s starting ═══", name)
+	err := j.run(ctx)
+	finished := time.Now()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.status.Running = false
+	j.status.LastFinishedAt = &finished
+	j.status.LastDuration = finished.Sub(started).Round(time.Millisecond).String()
+	j.status.RunCount++
+	if err != nil {
+		j.status.LastResult = JobResultFailed
+		j.status.LastError = err.Error()
+		j.status.FailureCount++
+		return err
+	}
+	j.status.LastResult = JobResultSuccess
+	j.status.LastError = ""
+	log.Printf("═══ Job %s complete in %v ═══", name, finished.Sub(started).Round(time.Second))
+	return nil
+}
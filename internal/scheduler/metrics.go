@@ -0,0 +1,17 @@
+package scheduler
+
+import "github.com/fortuna/minerva/internal/metrics"
+
+var (
+	livePollDuration = metrics.NewHistogramVec("minerva_live_poll_cycle_seconds",
+		"Wall time of each live polling cycle, fetch through publish",
+		[]float64{0.5, 1, 2.5, 5, 10, 15, 20, 30, 60, 120}, "result")
+	livePollSkipped = metrics.NewCounter("minerva_live_poll_cycles_skipped_total",
+		"Live polling cycles that came due while the previous one was still running and were not run")
+	livePollBacklog = metrics.NewGauge("minerva_live_poll_backlog",
+		"Live polling cycles that came due during the last cycle; above zero means polls are outrunning ingestion")
+	livePollInterval = metrics.NewGauge("minerva_live_poll_interval_seconds",
+		"Current live polling interval, stretched from LivePollInterval while cycles overrun it")
+	finalIngestions = metrics.NewCounterVec("minerva_game_final_ingestions_total",
+		"Box score ingestions triggered by live polling seeing a game go final", "result")
+)
@@ -2,84 +2,192 @@ package scheduler
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/fortuna/minerva/internal/alert"
 	"github.com/fortuna/minerva/internal/cache"
 	"github.com/fortuna/minerva/internal/ingest"
 	"github.com/fortuna/minerva/internal/ingest/espn"
+	"github.com/fortuna/minerva/internal/ingest/google"
 	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/quality"
+	"github.com/fortuna/minerva/internal/reconciliation"
+	"github.com/fortuna/minerva/internal/service"
 	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
 )
 
 // Orchestrator manages scheduled tasks for data ingestion
 type Orchestrator struct {
 	db            *store.Database
 	cache         *cache.RedisCache
-	publisher     *publisher.RedisPublisher
+	publisher     publisher.Publisher
+	liveState     *publisher.LiveStateTracker
+	clock         *publisher.ClockTracker
+	trimmer       *publisher.StreamTrimmer
+	ingestEvents  *publisher.IngestionEventPublisher
+	gameEvents    *publisher.GameEventPublisher
+	quality       *repository.DataQualityRepository
 	config        *Config
 	liveIngester  *ingest.LiveIngester
 	espnIngester  *espn.Ingester
+	devIngesters  []*espn.Ingester // enabled development leagues
+	runs          *repository.IngestionRunRepository
+	games         *repository.GameRepository
+	workload      *service.WorkloadService
+	defense       *service.PositionalDefenseService
+	ratings       *service.RatingService
+	players       *service.PlayerService
+	derived       *service.LiveDerivedService
+	stats         *service.StatsService
+	alerts        *alert.Dispatcher
+	jobs          *JobRegistry
+	pacer         *livePacer
 	cancel        context.CancelFunc
 	
 	// Task coordination
 	liveGamesCtx    context.Context
 	liveGamesCancel context.CancelFunc
-	dailyCtx        context.Context
-	dailyCancel     context.CancelFunc
 }
 
+// Alert keys raised by the orchestrator
+const (
+	alertKeyLivePolling    = "scheduler.live_polling"
+	alertKeyDailyIngestion = "scheduler.daily_ingestion"
+	alertKeyEmptyGameDay   = "scheduler.empty_game_day"
+)
+
 // Config holds scheduler configuration
 type Config struct {
-	LivePollInterval     time.Duration // Default: 10s
-	DailyIngestionHour   int           // Default: 3 (3 AM)
-	CurrentSeasonID      string        // e.g., "2024-25"
-	EnableLivePolling    bool          // Default: true
-	EnableDailyIngestion bool          // Default: true
-	MaxRetries           int           // Default: 3
-	RetryDelay           time.Duration // Default: 5s
+	LivePollInterval  time.Duration             // Default: 10s
+	LivePollMax       time.Duration             // Ceiling the interval stretches to while cycles overrun it; 0 keeps it fixed. Default: 60s
+	LiveHeartbeat     time.Duration             // Republish unchanged live games this often; 0 publishes on change only. Default: 60s
+	CurrentSeasonID   string                    // e.g., "2024-25"
+	EnableLivePolling bool                      // Default: true
+	MaxRetries        int                       // Default: 3
+	RetryDelay        time.Duration             // Default: 5s
+	Jobs              map[string]JobConfig      // Cron jobs by name; missing entries use DefaultJobConfigs
+	Google            google.Config             // Proxy rotation for the Google Sports scraper
+	Retention         publisher.RetentionConfig // Stream length cap and age-based trimming
+	Leagues           []string                  // Development leagues ingested daily beside the NBA, e.g. g_league
+	Enrichment        espn.EnrichmentConfig     // Batch size and ESPN request budget for player_enrichment
+	News              espn.NewsConfig           // Articles per feed and ESPN request budget for news_sync
 }
 
 // DefaultConfig returns default scheduler configuration
 func DefaultConfig() *Config {
 	return &Config{
-		LivePollInterval:     10 * time.Second,
-		DailyIngestionHour:   3,
-		CurrentSeasonID:      "2025-26",
-		EnableLivePolling:    true,
-		EnableDailyIngestion: true,
-		MaxRetries:           3,
-		RetryDelay:           5 * time.Second,
+		LivePollInterval:  10 * time.Second,
+		LivePollMax:       60 * time.Second,
+		LiveHeartbeat:     60 * time.Second,
+		CurrentSeasonID:   "2025-26",
+		EnableLivePolling: true,
+		MaxRetries:        3,
+		RetryDelay:        5 * time.Second,
+		Jobs:              DefaultJobConfigs(),
+		Google:            google.DefaultConfig(),
+		Retention:         publisher.DefaultRetentionConfig(),
+		Enrichment:        espn.DefaultEnrichmentConfig(),
+		News:              espn.DefaultNewsConfig(),
 	}
 }
 
 // NewOrchestrator creates a new scheduler orchestrator
-func NewOrchestrator(db *store.Database, cache *cache.RedisCache, redisPublisher *publisher.RedisPublisher, config *Config) (*Orchestrator, error) {
+func NewOrchestrator(db *store.Database, cache *cache.RedisCache, pub publisher.Publisher, config *Config) (*Orchestrator, error) {
 	if config == nil {
 		config = DefaultConfig()
 	}
 	
-	// Create stream publisher from Redis cache client
-	streamPublisher := publisher.NewRedisStreamPublisher(cache.Client())
-	
 	// Initialize live ingester (Google + ESPN with fallback)
-	liveIngester, err := ingest.NewLiveIngester(cache, streamPublisher, db)
+	liveIngester, err := ingest.NewLiveIngester(cache, pub, db, config.Google)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Initialize ESPN ingester for daily/historical tasks
 	espnIngester := espn.NewIngester(db)
+	espnIngester.SetStatsPublisher(pub)
 	
-	return &Orchestrator{
+	o := &Orchestrator{
 		db:           db,
 		cache:        cache,
-		publisher:    redisPublisher,
+		publisher:    pub,
+		liveState:    publisher.NewLiveStateTracker(cache.Client()),
+		clock:        publisher.NewClockTracker(),
+		trimmer:      publisher.NewStreamTrimmer(cache.Client(), config.Retention),
 		config:       config,
 		liveIngester: liveIngester,
 		espnIngester: espnIngester,
-	}, nil
+		runs:         repository.NewIngestionRunRepository(db),
+		games:        repository.NewGameRepository(db),
+		quality:      repository.NewDataQualityRepository(db),
+		workload:     service.NewWorkloadService(db),
+		defense:      service.NewPositionalDefenseService(db),
+		ratings:      service.NewRatingService(db),
+		players:      service.NewPlayerService(db),
+		derived:      service.NewLiveDerivedService(db),
+		stats:        service.NewStatsService(db),
+		jobs:         NewJobRegistry(),
+		pacer:        newLivePacer(config.LivePollInterval, config.LivePollMax),
+	}
+	for _, key := range config.Leagues {
+		if key == store.LeagueNBA {
+			continue
+		}
+		league, ok := store.LookupLeague(key)
+		if !ok {
+			return nil, fmt.Errorf("unknown league %q", key)
+		}
+		o.devIngesters = append(o.devIngesters, espn.NewLeagueIngester(db, league))
+	}
+	if err := o.registerJobs(); err != nil {
+		return nil, err
+	}
+	
+	return o, nil
+}
+
+// SetAlerts routes sustained polling failures, daily ingestion failures, and
+// empty game days to the given dispatcher. A nil dispatcher disables alerting.
+func (o *Orchestrator) SetAlerts(alerts *alert.Dispatcher) {
+	o.alerts = alerts
+}
+
+// SetGameEvents publishes game.created/game.updated events from live and daily
+// ingestion, and game.final when live polling sees a game finish. A nil
+// publisher disables them.
+func (o *Orchestrator) SetGameEvents(events *publisher.GameEventPublisher) {
+	o.gameEvents = events
+	o.liveIngester.SetGameEvents(events)
+	o.espnIngester.SetGameEvents(events)
+	for _, ingester := range o.devIngesters {
+		ingester.SetGameEvents(events)
+	}
+}
+
+// SetIngestionEvents publishes daily.ingestion.complete after each successful
+// daily ingestion. A nil publisher disables it.
+func (o *Orchestrator) SetIngestionEvents(events *publisher.IngestionEventPublisher) {
+	o.ingestEvents = events
+}
+
+// SetValidator runs data quality rules on final games ingested by live and
+// daily ingestion. A nil validator disables them.
+func (o *Orchestrator) SetValidator(validator *quality.Validator) {
+	o.liveIngester.SetValidator(validator)
+	o.espnIngester.SetValidator(validator)
+	for _, ingester := range o.devIngesters {
+		ingester.SetValidator(validator)
+	}
+}
+
+// SetReplay polls an archived game replayed as if it were live instead of the
+// Google and ESPN feeds
+func (o *Orchestrator) SetReplay(replay *ingest.Replay) {
+	o.liveIngester.SetReplay(replay)
 }
 
 // Start begins all scheduled tasks
@@ -88,7 +196,9 @@ func (o *Orchestrator) Start(ctx context.Context) {
 	log.Println("║   Minerva Scheduler Orchestrator      ║")
 	log.Println("╚════════════════════════════════════════╝")
 	log.Printf("Live polling: %v (interval: %v)", o.config.EnableLivePolling, o.config.LivePollInterval)
-	log.Printf("Daily ingestion: %v (at %02d:00)", o.config.EnableDailyIngestion, o.config.DailyIngestionHour)
+	for _, job := range o.jobs.Statuses() {
+		log.Printf("Job %s: %v (%s)", job.Name, job.Enabled, job.Schedule)
+	}
 	log.Printf("Season: %s", o.config.CurrentSeasonID)
 	log.Println()
 	
@@ -102,44 +212,55 @@ func (o *Orchestrator) Start(ctx context.Context) {
 		go o.runLiveGamePolling(o.liveGamesCtx)
 	}
 	
-	// Start daily ingestion scheduler
-	if o.config.EnableDailyIngestion {
-		o.dailyCtx, o.dailyCancel = context.WithCancel(ctx)
-		go o.runDailyIngestion(o.dailyCtx)
-	}
+	// Start cron jobs (daily ingestion, roster sync, view refresh, cleanup, gap detection)
+	o.jobs.Start(ctx)
 	
 	// Wait for context cancellation
 	<-ctx.Done()
 	log.Println("Scheduler orchestrator stopping...")
 }
 
-// runLiveGamePolling polls for live game updates
+// runLiveGamePolling polls for live game updates. Cycles never overlap: the
+// next one is scheduled when the last finishes, paced by how long it took.
 func (o *Orchestrator) runLiveGamePolling(ctx context.Context) {
-	log.Printf("→ Live game polling started (interval: %v)", o.config.LivePollInterval)
+	log.Printf("→ Live game polling started (interval: %v, max: %v)", o.config.LivePollInterval, o.pacer.max)
 	log.Println("  Source priority: Google (primary) → ESPN (fallback)")
 	
-	ticker := time.NewTicker(o.config.LivePollInterval)
-	defer ticker.Stop()
-	
 	consecutiveErrors := 0
 	maxConsecutiveErrors := 5
 	
 	// Run immediately on start
-	o.pollLiveGamesWithRetry(ctx, &consecutiveErrors, maxConsecutiveErrors)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
 	
 	for {
 		select {
 		case <-ctx.Done():
 			log.Println("→ Live game polling stopped")
 			return
-		case <-ticker.C:
-			o.pollLiveGamesWithRetry(ctx, &consecutiveErrors, maxConsecutiveErrors)
+		case <-timer.C:
+		}
+		
+		started := time.Now()
+		result := "ok"
+		if err := o.pollLiveGamesWithRetry(ctx, &consecutiveErrors, maxConsecutiveErrors); err != nil {
+			result = "error"
 		}
+		took := time.Since(started)
+		livePollDuration.WithLabelValues(result).Observe(took.Seconds())
+		
+		previous := o.pacer.interval
+		wait := o.pacer.next(took)
+		if o.pacer.interval != previous {
+			log.Printf("  ⏱  Live poll took %v; polling every %v", took.Round(time.Millisecond), o.pacer.interval)
+		}
+		timer.Reset(wait)
 	}
 }
 
-// pollLiveGamesWithRetry polls live games with retry logic
-func (o *Orchestrator) pollLiveGamesWithRetry(ctx context.Context, consecutiveErrors *int, maxConsecutiveErrors int) {
+// pollLiveGamesWithRetry polls live games with retry logic, returning the last
+// error once every retry failed
+func (o *Orchestrator) pollLiveGamesWithRetry(ctx context.Context, consecutiveErrors *int, maxConsecutiveErrors int) error {
 	var games []*store.Game
 	var err error
 	
@@ -149,6 +270,11 @@ func (o *Orchestrator) pollLiveGamesWithRetry(ctx context.Context, consecutiveEr
 		
 		if err == nil {
 			*consecutiveErrors = 0 // Reset on success
+			o.alerts.Resolve(ctx, alert.Alert{
+				Key:    alertKeyLivePolling,
+				Source: "scheduler",
+				Title:  "Live game polling recovered",
+			})
 			break
 		}
 		
@@ -159,7 +285,7 @@ func (o *Orchestrator) pollLiveGamesWithRetry(ctx context.Context, consecutiveEr
 			log.Printf("  Retrying in %v...", o.config.RetryDelay)
 			select {
 			case <-ctx.Done():
-				return
+				return ctx.Err()
 			case <-time.After(o.config.RetryDelay):
 				// Continue to next attempt
 			}
@@ -174,67 +300,94 @@ func (o *Orchestrator) pollLiveGamesWithRetry(ctx context.Context, consecutiveEr
 		
 		// If too many consecutive errors, reduce polling frequency
 		if *consecutiveErrors >= maxConsecutiveErrors {
+			o.alerts.Fire(ctx, alert.Alert{
+				Key:      alertKeyLivePolling,
+				Source:   "scheduler",
+				Severity: alert.SeverityCritical,
+				Title:    "Live game polling is failing",
+				Message:  fmt.Sprintf("%d consecutive polls failed after %d retries each (Google and ESPN)", *consecutiveErrors, o.config.MaxRetries),
+				Fields: map[string]string{
+					"season":     o.config.CurrentSeasonID,
+					"last_error": err.Error(),
+				},
+			})
 			log.Printf("  ⚠️  High error rate detected. Slowing polling to 30s...")
 			time.Sleep(20 * time.Second) // Additional delay
 		}
-		return
+		return err
 	}
 	
-	// Success - publish games
-	liveGameCount := 0
+	// Success - publish games whose score, period, or clock moved since the
+	// last publish, plus a heartbeat for live games that have been quiet
+	pollLatency := o.liveIngester.PollLatency()
+	liveGameCount, skipped := 0, 0
+	var finished []*store.Game
 	for _, game := range games {
+		clock := o.clock.Observe(game, time.Now())
+		fingerprint := liveFingerprint(game)
 		if game.Status == "in_progress" {
+			// A clock that stops without moving still changes the payload, so
+			// subscribers stop extrapolating during timeouts
+			fingerprint += fmt.Sprintf("|%t", clock.Running)
+			if !o.liveState.ShouldPublish(ctx, publisher.LiveStream, game.GameID, fingerprint, o.config.LiveHeartbeat) {
+				skipped++
+				continue
+			}
 			liveGameCount++
-			if err := o.publisher.PublishLiveGameUpdate(ctx, game); err != nil {
+			update := publisher.NewLiveGameUpdate(game, clock)
+			update.StampLatency(pollLatency, time.Now())
+			if err := o.publisher.PublishLiveGameUpdate(ctx, update); err != nil {
 				log.Printf("  ⚠️  Failed to publish game %s: %v", game.GameID, err)
+				continue
 			}
+			publisher.ObservePublished(update.Latency)
+			o.liveState.MarkPublished(ctx, publisher.LiveStream, game.GameID, fingerprint)
+			o.publishDerived(ctx, game)
 		} else if game.Status == "final" {
-			// Publish final stats
+			finished = append(finished, game)
+			// Publish final stats once per final score
+			if !o.liveState.ShouldPublish(ctx, publisher.StatsStream, game.GameID, fingerprint, 0) {
+				continue
+			}
 			if err := o.publisher.PublishGameStats(ctx, game); err != nil {
 				log.Printf("  ⚠️  Failed to publish final stats for game %s: %v", game.GameID, err)
+				continue
 			}
+			o.liveState.MarkPublished(ctx, publisher.StatsStream, game.GameID, fingerprint)
 		}
 	}
 	
-	if liveGameCount > 0 {
-		log.Printf("  ✓ Published %d live games to Redis streams", liveGameCount)
+	if liveGameCount > 0 || skipped > 0 {
+		log.Printf("  ✓ Published %d live games to Redis streams (%d unchanged)", liveGameCount, skipped)
 	}
+	
+	// Games that just finished get their full box score now, after the live
+	// updates above have gone out
+	o.finalizeGames(ctx, finished)
+	return nil
 }
 
-// runDailyIngestion runs daily historical data ingestion
-func (o *Orchestrator) runDailyIngestion(ctx context.Context) {
-	log.Printf("→ Daily ingestion scheduler started (runs at %02d:00 daily)", o.config.DailyIngestionHour)
-	
-	for {
-		// Calculate time until next run
-		now := time.Now()
-		nextRun := time.Date(now.Year(), now.Month(), now.Day(), o.config.DailyIngestionHour, 0, 0, 0, now.Location())
-		
-		// If we've passed today's run time, schedule for tomorrow
-		if now.After(nextRun) {
-			nextRun = nextRun.Add(24 * time.Hour)
-		}
-		
-		waitDuration := time.Until(nextRun)
-		log.Printf("  Next daily ingestion: %s (in %v)", nextRun.Format("2006-01-02 15:04:05"), waitDuration.Round(time.Second))
-		
-		// Wait until next run time
-		select {
-		case <-ctx.Done():
-			log.Println("→ Daily ingestion scheduler stopped")
-			return
-		case <-time.After(waitDuration):
-			log.Println()
-			log.Println("═══ Daily Ingestion Starting ═══")
-			o.runDailyIngestionTask(ctx)
-			log.Println("═══ Daily Ingestion Complete ═══")
-			log.Println()
-		}
+// publishDerived publishes pace, projections, and scoring runs for a live game
+// alongside its update. Failures are logged; the live update already went out.
+func (o *Orchestrator) publishDerived(ctx context.Context, game *store.Game) {
+	derived, err := o.derived.Derive(ctx, game)
+	if err != nil {
+		log.Printf("  ⚠️  Failed to derive live metrics for game %d: %v", game.GameID, err)
+		return
 	}
+	if err := o.publisher.Publish(ctx, publisher.DerivedStream, derived); err != nil {
+		log.Printf("  ⚠️  Failed to publish live metrics for game %d: %v", game.GameID, err)
+	}
+}
+
+// liveFingerprint summarizes the parts of a game that a live update reports
+func liveFingerprint(game *store.Game) string {
+	return fmt.Sprintf("%s|%d|%d|%d|%s",
+		game.Status, game.HomeScore.Int32, game.AwayScore.Int32, game.Period.Int32, game.Clock.String)
 }
 
 // runDailyIngestionTask performs the daily ingestion
-func (o *Orchestrator) runDailyIngestionTask(ctx context.Context) {
+func (o *Orchestrator) runDailyIngestionTask(ctx context.Context) error {
 	startTime := time.Now()
 	
 	// Ingest yesterday's games (ESPN has complete data by now)
@@ -244,18 +397,180 @@ func (o *Orchestrator) runDailyIngestionTask(ctx context.Context) {
 	// Lookup season_id from season_year
 	seasonID, err := o.lookupSeasonID(ctx, o.config.CurrentSeasonID)
 	if err != nil {
-		log.Printf("❌ Failed to lookup season ID: %v", err)
-		return
+		return err
 	}
 	
-	err = o.espnIngester.IngestTodaysGames(ctx, seasonID)
+	counts, err := o.recordRun(ctx, store.IngestionSourceDaily, func() (espn.WriteCounts, error) {
+		return o.espnIngester.IngestTodaysGames(ctx, seasonID)
+	})
 	if err != nil {
-		log.Printf("❌ Daily ingestion failed: %v", err)
-		return
+		o.alerts.Fire(ctx, alert.Alert{
+			Key:      alertKeyDailyIngestion,
+			Source:   "scheduler",
+			Severity: alert.SeverityCritical,
+			Title:    "Daily ingestion failed",
+			Message:  err.Error(),
+			Fields:   map[string]string{"season": o.config.CurrentSeasonID},
+		})
+		return err
+	}
+	o.alerts.Resolve(ctx, alert.Alert{
+		Key:    alertKeyDailyIngestion,
+		Source: "scheduler",
+		Title:  "Daily ingestion succeeded",
+	})
+	
+	if counts.GamesChanged+counts.GamesUnchanged == 0 {
+		o.checkEmptyGameDay(ctx, time.Now())
+	}
+	
+	o.ingestDevelopmentLeagues(ctx)
+	
+	// Snapshot player workloads through yesterday's games
+	if n, err := o.workload.Refresh(ctx, store.DefaultSport, yesterday); err != nil {
+		log.Printf("  ⚠️  Failed to refresh player workloads: %v", err)
+	} else {
+		log.Printf("  ✓ Refreshed workloads for %d players", n)
+	}
+
+	// Re-aggregate what each team allows by position over the season to date
+	if n, err := o.defense.Refresh(ctx, o.config.CurrentSeasonID); err != nil {
+		log.Printf("  ⚠️  Failed to refresh positional defense: %v", err)
+	} else {
+		log.Printf("  ✓ Refreshed positional defense for %d team-positions", n)
+	}
+
+	// Move team Elo ratings by the new final scores
+	if n, err := o.ratings.Update(ctx); err != nil {
+		log.Printf("  ⚠️  Failed to update team ratings: %v", err)
+	} else if n > 0 {
+		log.Printf("  ✓ Rated %d games", n)
+	}
+
+	// Cache season averages for everyone who played, ahead of the morning's reads
+	if n, err := o.players.WarmSeasonAverages(ctx, store.DefaultSport, yesterday); err != nil {
+		log.Printf("  ⚠️  Failed to warm season averages: %v", err)
+	} else if n > 0 {
+		log.Printf("  ✓ Cached season averages for %d players", n)
 	}
 	
 	duration := time.Since(startTime)
-	log.Printf("✓ Daily ingestion complete in %v", duration.Round(time.Second))
+	o.publishDailyComplete(ctx, counts, duration)
+	log.Printf("✓ Daily ingestion complete in %v (%s)", duration.Round(time.Second), counts)
+	return nil
+}
+
+// ingestDevelopmentLeagues stores today's games for each enabled development
+// league that has a season covering today. A league's failure is logged and
+// never fails NBA ingestion.
+func (o *Orchestrator) ingestDevelopmentLeagues(ctx context.Context) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.UTC
+	}
+	today := time.Now().In(loc)
+	
+	for _, ingester := range o.devIngesters {
+		league := ingester.League()
+		seasonID, err := o.lookupLeagueSeasonID(ctx, league.Key, today)
+		if err == sql.ErrNoRows {
+			log.Printf("  No %s season covers %s; skipping", league.Name, today.Format("2006-01-02"))
+			continue
+		}
+		if err != nil {
+			log.Printf("  ⚠️  Failed to look up %s season: %v", league.Name, err)
+			continue
+		}
+		
+		games, counts, err := ingester.IngestGamesByDate(ctx, seasonID, today)
+		if err != nil {
+			log.Printf("  ⚠️  %s ingestion failed: %v", league.Name, err)
+			continue
+		}
+		log.Printf("  ✓ Ingested %d %s games (%s)", len(games), league.Name, counts)
+	}
+}
+
+// ingesterFor returns the ingester that reads a league's feed, or nil when the
+// league is not enabled
+func (o *Orchestrator) ingesterFor(league string) *espn.Ingester {
+	if league == "" || league == store.LeagueNBA {
+		return o.espnIngester
+	}
+	for _, ingester := range o.devIngesters {
+		if ingester.League().Key == league {
+			return ingester
+		}
+	}
+	return nil
+}
+
+// publishDailyComplete announces the finished ingestion day with its write
+// counts and data quality summary. Failures are logged, never returned.
+func (o *Orchestrator) publishDailyComplete(ctx context.Context, counts espn.WriteCounts, duration time.Duration) {
+	if o.ingestEvents == nil {
+		return
+	}
+
+	// Daily ingestion stores the current Eastern date's scoreboard
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.UTC
+	}
+	date := time.Now().In(loc)
+
+	summary, err := o.quality.Summarize(ctx, store.DefaultSport, date)
+	if err != nil {
+		log.Printf("  ⚠️  Failed to summarize data quality for %s: %v", date.Format("2006-01-02"), err)
+		summary = &store.DataQualitySummary{ByRule: map[string]int{}}
+	}
+
+	event := &publisher.DailyIngestionEvent{
+		Sport:            store.DefaultSport,
+		Date:             date.Format("2006-01-02"),
+		Games:            counts.GamesChanged + counts.GamesUnchanged,
+		GamesChanged:     counts.GamesChanged,
+		PlayersUpdated:   counts.PlayerStatsChanged,
+		TeamStatsUpdated: counts.TeamStatsChanged,
+		DataQuality:      *summary,
+		DurationMs:       duration.Milliseconds(),
+	}
+	if err := o.ingestEvents.PublishDailyComplete(ctx, event); err != nil {
+		log.Printf("  ⚠️  Failed to publish %s: %v", publisher.DailyIngestionComplete, err)
+	}
+}
+
+// checkEmptyGameDay alerts when ingestion found no games on a date inside the
+// current season's window, which usually means the source returned an empty
+// scoreboard rather than a genuine off day.
+func (o *Orchestrator) checkEmptyGameDay(ctx context.Context, date time.Time) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM seasons
+			WHERE season_year = $1 AND sport = 'basketball_nba' AND league = 'nba'
+				AND $2::date BETWEEN start_date AND end_date
+		)
+	`
+	
+	var inSeason bool
+	if err := o.db.DB().QueryRowContext(ctx, query, o.config.CurrentSeasonID, date).Scan(&inSeason); err != nil {
+		log.Printf("  ⚠️  Failed to check season window: %v", err)
+		return
+	}
+	if !inSeason {
+		return
+	}
+	
+	day := date.Format("2006-01-02")
+	log.Printf("  ⚠️  No games ingested for %s during the %s season", day, o.config.CurrentSeasonID)
+	o.alerts.Fire(ctx, alert.Alert{
+		Key:      alertKeyEmptyGameDay + ":" + day,
+		Source:   "scheduler",
+		Severity: alert.SeverityWarning,
+		Title:    fmt.Sprintf("No games ingested for %s", day),
+		Message:  "Daily ingestion succeeded but the scoreboard had no games on an in-season date",
+		Fields:   map[string]string{"season": o.config.CurrentSeasonID, "date": day},
+	})
 }
 
 // Stop gracefully stops the scheduler
@@ -267,11 +582,6 @@ func (o *Orchestrator) Stop() {
 		o.liveGamesCancel()
 	}
 	
-	// Cancel daily ingestion
-	if o.dailyCancel != nil {
-		o.dailyCancel()
-	}
-	
 	// Cancel main orchestrator
 	if o.cancel != nil {
 		o.cancel()
@@ -297,7 +607,9 @@ func (o *Orchestrator) TriggerManualIngestion(ctx context.Context, date time.Tim
 	
 	// This would use the backfill system or ESPN ingester
 	// For now, delegate to ESPN ingester
-	err = o.espnIngester.IngestTodaysGames(ctx, seasonID)
+	_, err = o.recordRun(ctx, store.IngestionSourceManual, func() (espn.WriteCounts, error) {
+		return o.espnIngester.IngestTodaysGames(ctx, seasonID)
+	})
 	if err != nil {
 		return err
 	}
@@ -306,20 +618,74 @@ func (o *Orchestrator) TriggerManualIngestion(ctx context.Context, date time.Tim
 	return nil
 }
 
+// recordRun wraps an ESPN ingestion pass with an ingestion_runs row and
+// returns what the pass wrote. Failing to record the run never fails the
+// ingestion itself.
+func (o *Orchestrator) recordRun(ctx context.Context, source string, ingest func() (espn.WriteCounts, error)) (espn.WriteCounts, error) {
+	today := time.Now()
+	run := &store.IngestionRun{
+		Source:    source,
+		StartDate: store.NullTime{Time: today, Valid: true},
+		EndDate:   store.NullTime{Time: today, Valid: true},
+	}
+
+	if err := o.runs.Start(ctx, run); err != nil {
+		log.Printf("  ⚠️  Failed to record ingestion run: %v", err)
+		return ingest()
+	}
+
+	counts, err := ingest()
+
+	run.GamesProcessed = counts.GamesChanged + counts.GamesUnchanged
+	run.RowsChanged = counts.Changed()
+	run.RowsUnchanged = counts.Unchanged()
+	if finishErr := o.runs.Finish(context.WithoutCancel(ctx), run, err); finishErr != nil {
+		log.Printf("  ⚠️  Failed to record ingestion run: %v", finishErr)
+	}
+
+	return counts, err
+}
+
 // GetStatus returns current scheduler status
 func (o *Orchestrator) GetStatus() map[string]interface{} {
 	return map[string]interface{}{
-		"live_polling_enabled":    o.config.EnableLivePolling,
-		"live_poll_interval":      o.config.LivePollInterval.String(),
-		"daily_ingestion_enabled": o.config.EnableDailyIngestion,
-		"daily_ingestion_hour":    o.config.DailyIngestionHour,
-		"current_season":          o.config.CurrentSeasonID,
+		"live_polling_enabled": o.config.EnableLivePolling,
+		"live_poll_interval":   o.config.LivePollInterval.String(),
+		"live_poll_pacing":     o.pacer.status(),
+		"current_season":       o.config.CurrentSeasonID,
+		"jobs":                 o.jobs.Statuses(),
 	}
 }
 
+// ReconciliationMetrics returns live polling's Google/ESPN reconciliation
+// counters, or nil when no poll has reconciled both sources yet
+func (o *Orchestrator) ReconciliationMetrics() *reconciliation.Metrics {
+	return o.liveIngester.ReconciliationMetrics()
+}
+
+// Jobs returns the schedule, enable flag, and last-run status of every cron job
+func (o *Orchestrator) Jobs() []JobStatus {
+	return o.jobs.Statuses()
+}
+
+// lookupLeagueSeasonID finds the league's season whose dates cover date
+func (o *Orchestrator) lookupLeagueSeasonID(ctx context.Context, league string, date time.Time) (int, error) {
+	query := `
+		SELECT season_id FROM seasons
+		WHERE sport = 'basketball_nba' AND league = $1
+			AND $2::date BETWEEN start_date AND end_date
+		ORDER BY start_date DESC
+		LIMIT 1
+	`
+	
+	var seasonID int
+	err := o.db.DB().QueryRowContext(ctx, query, league, date.Format("2006-01-02")).Scan(&seasonID)
+	return seasonID, err
+}
+
 // lookupSeasonID queries the database to get season_id (INT) from season_year (STRING)
 func (o *Orchestrator) lookupSeasonID(ctx context.Context, seasonYear string) (int, error) {
-	query := `SELECT season_id FROM seasons WHERE season_year = $1 AND sport = 'basketball_nba' LIMIT 1`
+	query := `SELECT season_id FROM seasons WHERE season_year = $1 AND sport = 'basketball_nba' AND league = 'nba' LIMIT 1`
 	
 	var seasonID int
 	err := o.db.DB().QueryRowContext(ctx, query, seasonYear).Scan(&seasonID)
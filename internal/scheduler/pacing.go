@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// livePacer spaces live polling cycles by how long the last one took. A cycle
+// that overruns the interval, usually because database writes are lagging,
+// doubles the interval up to max so polls stop stacking onto a struggling
+// database; cycles finishing in under half the interval halve it back toward
+// base. Ticks that fell due during a cycle are dropped and counted as skipped
+// rather than run back to back.
+type livePacer struct {
+	base, max time.Duration
+
+	mu       sync.Mutex
+	interval time.Duration
+	skipped  int64
+	lastRun  time.Duration
+}
+
+func newLivePacer(base, ceiling time.Duration) *livePacer {
+	if ceiling < base {
+		ceiling = base
+	}
+	livePollInterval.Set(base.Seconds())
+	return &livePacer{base: base, max: ceiling, interval: base}
+}
+
+// next records a finished cycle and returns how long to wait before the next
+func (p *livePacer) next(took time.Duration) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lastRun = took
+	overdue := int64(took / p.interval)
+	p.skipped += overdue
+	livePollSkipped.Add(float64(overdue))
+	livePollBacklog.Set(float64(overdue))
+
+	wait := p.interval - took
+	switch {
+	case took >= p.interval:
+		// Give the database a full, longer interval to catch up
+		p.interval = min(2*p.interval, p.max)
+		wait = p.interval
+	case took < p.interval/2 && p.interval > p.base:
+		p.interval = max(p.interval/2, p.base)
+		wait = max(p.interval-took, 0)
+	}
+	livePollInterval.Set(p.interval.Seconds())
+	return wait
+}
+
+// status reports the pacer's state for the scheduler status endpoint
+func (p *livePacer) status() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return map[string]interface{}{
+		"interval":       p.interval.String(),
+		"last_cycle":     p.lastRun.Round(time.Millisecond).String(),
+		"cycles_skipped": p.skipped,
+	}
+}
@@ -0,0 +1,320 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/alert"
+	"github.com/fortuna/minerva/internal/maintenance"
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// Scheduled job names, as listed by the admin endpoint and used in JOB_<NAME>_* settings
+const (
+	JobDailyIngestion = "daily_ingestion"
+	JobRosterSync     = "roster_sync"
+	JobRefreshViews   = "refresh_views"
+	JobCleanup        = "cleanup_stale_games"
+	JobGapDetection   = "gap_detection"
+	JobStreamTrim     = "stream_trim"
+	JobStatsVerify    = "stats_verification"
+	JobTransactions   = "transaction_sync"
+	JobEnrichment     = "player_enrichment"
+	JobNews           = "news_sync"
+	JobLineups        = "lineup_sync"
+)
+
+// gapDetectionDays is how far back the nightly gap scan looks
+const gapDetectionDays = 7
+
+const alertKeyGapDetection = "scheduler.gap_detection"
+
+// Final box scores are refetched once they have been final for statsSettleDelay;
+// a refetch that changes nothing, or any refetch after statsSettleDeadline,
+// marks the game's stats complete
+const (
+	statsSettleDelay    = 2 * time.Hour
+	statsSettleDeadline = 4 * time.Hour
+)
+
+// lineupWindow is how close to tip-off lineup sync starts checking a game for
+// starters. ESPN lists them about half an hour before.
+const lineupWindow = 45 * time.Minute
+
+// DefaultJobConfigs returns the built-in schedule for every job. Daily work runs
+// in the early morning once ESPN has complete box scores, views are rebuilt after
+// ingestion, stale live games are swept every half hour, Redis streams are
+// trimmed every fifteen minutes, recently final box scores are re-checked
+// every twenty, starting lineups of games about to tip off every five, and the
+// transactions feed, player profile enrichment, and the news feeds run hourly.
+func DefaultJobConfigs() map[string]JobConfig {
+	return map[string]JobConfig{
+		JobDailyIngestion: {Schedule: "0 3 * * *", Enabled: true},
+		JobRefreshViews:   {Schedule: "30 3 * * *", Enabled: true},
+		JobRosterSync:     {Schedule: "0 5 * * *", Enabled: true},
+		JobGapDetection:   {Schedule: "0 6 * * *", Enabled: true},
+		JobCleanup:        {Schedule: "*/30 * * * *", Enabled: true},
+		JobStreamTrim:     {Schedule: "*/15 * * * *", Enabled: true},
+		JobStatsVerify:    {Schedule: "*/20 * * * *", Enabled: true},
+		JobTransactions:   {Schedule: "10 * * * *", Enabled: true},
+		JobEnrichment:     {Schedule: "40 * * * *", Enabled: true},
+		JobNews:           {Schedule: "25 * * * *", Enabled: true},
+		JobLineups:        {Schedule: "*/5 * * * *", Enabled: true},
+	}
+}
+
+// registerJobs adds every built-in job, taking schedules and enable flags from
+// config and falling back to DefaultJobConfigs
+func (o *Orchestrator) registerJobs() error {
+	tasks := map[string]JobFunc{
+		JobDailyIngestion: o.runDailyIngestionTask,
+		JobRosterSync:     o.runRosterSync,
+		JobRefreshViews:   o.runRefreshViews,
+		JobCleanup:        o.runCleanup,
+		JobGapDetection:   o.runGapDetection,
+		JobStreamTrim:     o.runStreamTrim,
+		JobStatsVerify:    o.runStatsVerification,
+		JobTransactions:   o.runTransactionSync,
+		JobEnrichment:     o.runEnrichment,
+		JobNews:           o.runNewsSync,
+		JobLineups:        o.runLineupSync,
+	}
+
+	defaults := DefaultJobConfigs()
+	for name, task := range tasks {
+		cfg, ok := o.config.Jobs[name]
+		if !ok {
+			cfg = defaults[name]
+		}
+		if err := o.jobs.Register(name, cfg, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runRosterSync refreshes every team's roster, current-team history, and head
+// coach, publishing coaching changes on the coaches stream
+func (o *Orchestrator) runRosterSync(ctx context.Context) error {
+	seasonID, err := o.lookupSeasonID(ctx, o.config.CurrentSeasonID)
+	if err != nil {
+		return err
+	}
+
+	result, err := o.espnIngester.SyncRosters(ctx, seasonID)
+	for _, event := range result.CoachChanges {
+		if pubErr := o.publisher.Publish(ctx, publisher.CoachesStream, event); pubErr != nil {
+			log.Printf("  ⚠️  Failed to publish %s coach change: %v", event.Team.Abbreviation, pubErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("  ✓ Roster sync: %s", result)
+	return nil
+}
+
+// runTransactionSync stores new roster transactions, applies them to team
+// history, and publishes each on the transactions stream
+func (o *Orchestrator) runTransactionSync(ctx context.Context) error {
+	seasonID, err := o.lookupSeasonID(ctx, o.config.CurrentSeasonID)
+	if err != nil {
+		return err
+	}
+
+	result, err := o.espnIngester.SyncTransactions(ctx, seasonID)
+	for _, t := range result.Added {
+		if pubErr := o.publisher.Publish(ctx, publisher.TransactionsStream, t); pubErr != nil {
+			log.Printf("  ⚠️  Failed to publish transaction %d: %v", t.TransactionID, pubErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(result.Added) > 0 {
+		log.Printf("  ✓ Transaction sync: %s", result)
+	}
+	return nil
+}
+
+// runNewsSync stores new articles from the ESPN news feeds and publishes each
+// on the news stream
+func (o *Orchestrator) runNewsSync(ctx context.Context) error {
+	result, err := o.espnIngester.SyncNews(ctx, o.config.News)
+	for _, article := range result.Added {
+		if pubErr := o.publisher.Publish(ctx, publisher.NewsStream, article); pubErr != nil {
+			log.Printf("  ⚠️  Failed to publish news article %s: %v", article.ExternalID, pubErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(result.Added) > 0 || result.Failed > 0 {
+		log.Printf("  ✓ News sync: %s", result)
+	}
+	return nil
+}
+
+// runLineupSync stores the starting lineups ESPN lists for games tipping off
+// within lineupWindow and publishes each newly confirmed or changed pair of
+// lineups on the lineups stream
+func (o *Orchestrator) runLineupSync(ctx context.Context) error {
+	games, err := o.games.ListStartingSoon(ctx, store.DefaultSport, lineupWindow)
+	if err != nil {
+		return err
+	}
+
+	confirmed := 0
+	for _, game := range games {
+		ingester := o.ingesterFor(game.League)
+		if ingester == nil {
+			continue
+		}
+		event, err := ingester.SyncLineup(ctx, game)
+		if err != nil {
+			log.Printf("  ⚠️  Lineup sync failed for game %s: %v", game.ExternalID, err)
+			continue
+		}
+		if event == nil {
+			continue
+		}
+		confirmed++
+		if pubErr := o.publisher.Publish(ctx, publisher.LineupsStream, event); pubErr != nil {
+			log.Printf("  ⚠️  Failed to publish lineups for game %s: %v", game.ExternalID, pubErr)
+		}
+	}
+
+	if confirmed > 0 {
+		log.Printf("  ✓ Lineup sync: %d of %d upcoming games confirmed", confirmed, len(games))
+	}
+	return ctx.Err()
+}
+
+// runEnrichment fills missing biographical fields for the next batch of players
+func (o *Orchestrator) runEnrichment(ctx context.Context) error {
+	result, err := o.espnIngester.EnrichPlayers(ctx, o.config.Enrichment)
+	if err != nil {
+		return err
+	}
+	if result.Candidates > 0 {
+		log.Printf("  ✓ Player enrichment: %s", result)
+	}
+	return nil
+}
+
+// runRefreshViews rebuilds the materialized views read by the stats endpoints
+func (o *Orchestrator) runRefreshViews(ctx context.Context) error {
+	return maintenance.RefreshViews(ctx, o.db)
+}
+
+// runCleanup marks games stuck in progress long after tip-off as final
+func (o *Orchestrator) runCleanup(ctx context.Context) error {
+	n, err := o.games.CleanupStaleGames(ctx)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Printf("  ✓ Marked %d stale in-progress games final", n)
+	}
+	return nil
+}
+
+// runStreamTrim drops stream entries older than the retention age and refreshes
+// the stream length metrics
+func (o *Orchestrator) runStreamTrim(ctx context.Context) error {
+	n, err := o.trimmer.Trim(ctx)
+	if n > 0 {
+		log.Printf("  ✓ Trimmed %d old Redis stream entries", n)
+	}
+	return err
+}
+
+// runStatsVerification refetches box scores of games that went final two to
+// four hours ago and marks them complete once they stop changing
+func (o *Orchestrator) runStatsVerification(ctx context.Context) error {
+	games, err := o.games.ListStatsUnsettled(ctx, store.DefaultSport, statsSettleDelay)
+	if err != nil {
+		return err
+	}
+
+	settled, corrected := 0, 0
+	for _, game := range games {
+		ingester := o.ingesterFor(game.League)
+		if ingester == nil {
+			// League no longer ingested; nothing to refetch from
+			if err := o.games.MarkStatsComplete(ctx, game.GameID); err != nil {
+				return err
+			}
+			settled++
+			continue
+		}
+		counts, err := ingester.RefreshGameStats(ctx, game)
+		if err != nil {
+			log.Printf("  ⚠️  Stats verification failed for game %s: %v", game.ExternalID, err)
+			continue
+		}
+		if counts.Changed() > 0 {
+			corrected++
+			log.Printf("  Late stat corrections for game %s (%s)", game.ExternalID, counts)
+			if time.Since(game.FinalizedAt.Time) < statsSettleDeadline {
+				continue
+			}
+		}
+		if err := o.games.MarkStatsComplete(ctx, game.GameID); err != nil {
+			return err
+		}
+		settled++
+	}
+
+	if len(games) > 0 {
+		log.Printf("  ✓ Stats verification: %d games checked, %d corrected, %d marked complete", len(games), corrected, settled)
+	}
+	return ctx.Err()
+}
+
+// runGapDetection scans the last week for final games without box scores and
+// alerts when any are found
+func (o *Orchestrator) runGapDetection(ctx context.Context) error {
+	end := time.Now().AddDate(0, 0, -1)
+	start := end.AddDate(0, 0, -(gapDetectionDays - 1))
+
+	report, err := maintenance.NewGapScanner(o.db).Scan(ctx, store.DefaultSport, start, end)
+	if err != nil {
+		return err
+	}
+	if len(report.DatesWithoutGames) > 0 {
+		log.Printf("  Gap scan: %d dates without games since %s", len(report.DatesWithoutGames), start.Format("2006-01-02"))
+	}
+
+	if len(report.MissingStats) == 0 {
+		o.alerts.Resolve(ctx, alert.Alert{
+			Key:    alertKeyGapDetection,
+			Source: "scheduler",
+			Title:  "No games missing stats",
+		})
+		return nil
+	}
+
+	ids := make([]string, 0, len(report.MissingStats))
+	for _, m := range report.MissingStats {
+		ids = append(ids, m.ExternalID)
+	}
+	log.Printf("  ⚠️  %d final games missing stats: %s", len(ids), strings.Join(ids, ", "))
+	o.alerts.Fire(ctx, alert.Alert{
+		Key:      alertKeyGapDetection,
+		Source:   "scheduler",
+		Severity: alert.SeverityWarning,
+		Title:    fmt.Sprintf("%d final games missing stats", len(ids)),
+		Message:  "Run `minerva backfill --game <id>` for each game or a date-range backfill",
+		Fields: map[string]string{
+			"start": start.Format("2006-01-02"),
+			"end":   end.Format("2006-01-02"),
+			"games": strings.Join(ids, ","),
+		},
+	})
+	return nil
+}
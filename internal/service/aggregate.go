@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// Entities accepted by GET /stats/aggregate?entity=
+const (
+	AggregatePlayer = "player"
+	AggregateTeam   = "team"
+)
+
+// ErrInvalidEntity is returned for an unknown ?entity= value
+var ErrInvalidEntity = errors.New("entity must be player or team")
+
+// StatAggregate is a player's or team's production over a date window: summed
+// counting stats, per-game averages, and shooting percentages. Averages is nil
+// when no games fall in the window; Rates is set when per36 or per100 is asked.
+type StatAggregate struct {
+	Entity        string            `json:"entity"`
+	ID            int               `json:"id"`
+	Name          string            `json:"name"`
+	From          string            `json:"from"`
+	To            string            `json:"to"`
+	GamesPlayed   int               `json:"games_played"`
+	Minutes       float64           `json:"minutes"`
+	Totals        *store.StatTotals `json:"totals"`
+	Averages      *store.StatRates  `json:"averages"`
+	Rates         *store.StatRates  `json:"rates,omitempty"`
+	FieldGoalPct  *float64          `json:"field_goal_pct"`
+	ThreePointPct *float64          `json:"three_point_pct"`
+	FreeThrowPct  *float64          `json:"free_throw_pct"`
+}
+
+// GetStatAggregate sums a player's or team's final NBA games dated from through
+// to, inclusive. All-Star, Rising Stars, and NBA Cup final games are left out
+// unless includeSpecial is set.
+func (s *StatsService) GetStatAggregate(ctx context.Context, entity string, id int, from, to time.Time, includeSpecial bool, per string) (*StatAggregate, error) {
+	excluded := store.SpecialGameTypes
+	if includeSpecial {
+		excluded = nil
+	}
+
+	result := &StatAggregate{
+		Entity: entity,
+		ID:     id,
+		From:   from.Format("2006-01-02"),
+		To:     to.Format("2006-01-02"),
+	}
+
+	var totals *store.StatTotals
+	switch entity {
+	case AggregatePlayer:
+		player, err := s.playerRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("fetching player: %w", err)
+		}
+		result.Name = player.FullName
+		if totals, err = s.statsRepo.GetPlayerTotalsBetween(ctx, id, from, to, excluded); err != nil {
+			return nil, fmt.Errorf("calculating player totals: %w", err)
+		}
+	case AggregateTeam:
+		team, err := cachedTeam(ctx, s.teamRepo, id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %d (%v)", ErrTeamNotFound, id, err)
+		}
+		result.Name = team.FullName
+		if totals, err = s.statsRepo.GetTeamTotalsBetween(ctx, id, from, to, excluded); err != nil {
+			return nil, fmt.Errorf("calculating team totals: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidEntity, entity)
+	}
+
+	result.GamesPlayed = totals.Games
+	result.Minutes = round1(float64(totals.Seconds) / 60)
+	result.Totals = totals
+	result.Averages = NormalizeStats(PerGame, totals)
+	if per != PerGame {
+		result.Rates = NormalizeStats(per, totals)
+	}
+	result.FieldGoalPct = pct(totals.FieldGoalsMade, totals.FieldGoalsAtt)
+	result.ThreePointPct = pct(totals.ThreePointersMade, totals.ThreePointersAtt)
+	result.FreeThrowPct = pct(totals.FreeThrowsMade, totals.FreeThrowsAtt)
+	return result, nil
+}
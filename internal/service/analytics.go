@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/fortuna/minerva/internal/store"
 	"github.com/fortuna/minerva/internal/store/repository"
@@ -14,6 +15,8 @@ type AnalyticsService struct {
 	statsRepo  *repository.StatsRepository
 	playerRepo *repository.PlayerRepository
 	gameRepo   *repository.GameRepository
+	workload   *WorkloadService
+	defense    *PositionalDefenseService
 }
 
 // NewAnalyticsService creates a new analytics service
@@ -22,6 +25,8 @@ func NewAnalyticsService(db *store.Database) *AnalyticsService {
 		statsRepo:  repository.NewStatsRepository(db),
 		playerRepo: repository.NewPlayerRepository(db),
 		gameRepo:   repository.NewGameRepository(db),
+		workload:   NewWorkloadService(db),
+		defense:    NewPositionalDefenseService(db),
 	}
 }
 
@@ -81,16 +86,19 @@ func (s *AnalyticsService) GetPlayerPerformanceTrend(ctx context.Context, player
 	return trend, nil
 }
 
-// GetPlayerMLFeatures generates ML features for a player's recent performance
-func (s *AnalyticsService) GetPlayerMLFeatures(ctx context.Context, playerID int, seasonID string) (*MLFeatures, error) {
+// GetPlayerMLFeatures generates ML features for a player's recent performance.
+// A non-zero asOf restricts every aggregation to games dated strictly before it,
+// so features for a game never include that game; zero uses everything stored.
+// A non-zero opponentTeamID adds what that team allows to the player's position.
+func (s *AnalyticsService) GetPlayerMLFeatures(ctx context.Context, sport string, playerID int, seasonID string, asOf time.Time, opponentTeamID int) (*MLFeatures, error) {
 	// Get season averages
-	seasonAvg, err := s.statsRepo.GetPlayerSeasonAverages(ctx, playerID, seasonID)
+	seasonAvg, err := s.statsRepo.GetPlayerSeasonAveragesBefore(ctx, playerID, seasonID, store.SpecialGameTypes, asOf)
 	if err != nil {
 		return nil, fmt.Errorf("fetching season averages: %w", err)
 	}
 
 	// Get last 10 games for recent form
-	recentStats, err := s.statsRepo.GetPlayerRecentStats(ctx, playerID, 10)
+	recentStats, err := s.statsRepo.GetPlayerRecentStatsBefore(ctx, playerID, 10, asOf)
 	if err != nil {
 		return nil, fmt.Errorf("fetching recent stats: %w", err)
 	}
@@ -112,6 +120,16 @@ func (s *AnalyticsService) GetPlayerMLFeatures(ctx context.Context, playerID int
 		last10Usage /= float64(len(recentStats))
 	}
 
+	var workload *store.PlayerWorkload
+	if asOf.IsZero() {
+		workload, err = s.workload.GetCurrent(ctx, sport, playerID)
+	} else {
+		workload, err = s.workload.GetBefore(ctx, sport, playerID, asOf)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching workload: %w", err)
+	}
+
 	features := &MLFeatures{
 		PlayerID: playerID,
 		SeasonID: seasonID,
@@ -129,14 +147,57 @@ func (s *AnalyticsService) GetPlayerMLFeatures(ctx context.Context, playerID int
 		Last10PPG: last10PPG,
 		Last10MPG: last10MPG,
 		Last10Usage: last10Usage,
+
+		// Workload (trailing days)
+		Minutes3d: workload.Minutes3d,
+		Minutes7d: workload.Minutes7d,
+		Minutes14d: workload.Minutes14d,
+		BackToBacks14d: workload.BackToBacks14d,
+		FatigueIndex: workload.FatigueIndex,
 		
 		// Games played
 		GamesPlayed: int(seasonAvg["games_played"]),
 	}
+	if !asOf.IsZero() {
+		features.AsOf = asOf.Format("2006-01-02")
+	}
+
+	if opponentTeamID > 0 {
+		if err := s.addOpponentDefense(ctx, features, seasonID, asOf, opponentTeamID); err != nil {
+			return nil, err
+		}
+	}
 
 	return features, nil
 }
 
+// addOpponentDefense fills the opponent's defense against the player's
+// position. Players without a mapped position, and opponents yet to face the
+// position, leave the defense fields null.
+func (s *AnalyticsService) addOpponentDefense(ctx context.Context, features *MLFeatures, seasonID string, asOf time.Time, opponentTeamID int) error {
+	player, err := s.playerRepo.GetByID(ctx, features.PlayerID)
+	if err != nil {
+		return fmt.Errorf("fetching player: %w", err)
+	}
+	features.OpponentTeamID = opponentTeamID
+	features.Position = store.DefensivePosition(player.Position.String)
+	if features.Position == "" {
+		return nil
+	}
+
+	defense, err := s.defense.Against(ctx, opponentTeamID, seasonID, features.Position, asOf)
+	if err != nil {
+		return err
+	}
+	if defense != nil {
+		features.OpponentPosPointsAllowed = &defense.PointsPerGame
+		features.OpponentPosReboundsAllowed = &defense.ReboundsPerGame
+		features.OpponentPosAssistsAllowed = &defense.AssistsPerGame
+		features.OpponentPosPointsVsLeague = &defense.PointsVsLeague
+	}
+	return nil
+}
+
 // PerformanceTrend contains trending performance metrics
 type PerformanceTrend struct {
 	PlayerID      int     `json:"player_id"`
@@ -156,6 +217,7 @@ type PerformanceTrend struct {
 type MLFeatures struct {
 	PlayerID       int     `json:"player_id"`
 	SeasonID       string  `json:"season_id"`
+	AsOf           string  `json:"as_of,omitempty"` // features use games before this date only
 	GamesPlayed    int     `json:"games_played"`
 	
 	// Season averages
@@ -171,6 +233,21 @@ type MLFeatures struct {
 	Last10PPG      float64 `json:"last_10_ppg"`
 	Last10MPG      float64 `json:"last_10_mpg"`
 	Last10Usage    float64 `json:"last_10_usage"`
+	
+	// Workload
+	Minutes3d      float64 `json:"minutes_3d"`
+	Minutes7d      float64 `json:"minutes_7d"`
+	Minutes14d     float64 `json:"minutes_14d"`
+	BackToBacks14d int     `json:"back_to_backs_14d"`
+	FatigueIndex   float64 `json:"fatigue_index"`
+	
+	// Opponent defense against the player's position (with an opponent)
+	Position                   string   `json:"position,omitempty"` // PG, SG, SF, PF, or C
+	OpponentTeamID             int      `json:"opponent_team_id,omitempty"`
+	OpponentPosPointsAllowed   *float64 `json:"opponent_pos_points_allowed,omitempty"`
+	OpponentPosReboundsAllowed *float64 `json:"opponent_pos_rebounds_allowed,omitempty"`
+	OpponentPosAssistsAllowed  *float64 `json:"opponent_pos_assists_allowed,omitempty"`
+	OpponentPosPointsVsLeague  *float64 `json:"opponent_pos_points_vs_league,omitempty"` // allowed minus league average
 }
 
 // safeDiv performs division with zero check
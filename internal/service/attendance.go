@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// AttendanceService builds attendance and capacity utilization trends
+type AttendanceService struct {
+	attendanceRepo *repository.AttendanceRepository
+	teamRepo       *repository.TeamRepository
+}
+
+// NewAttendanceService creates a new attendance service
+func NewAttendanceService(db *store.Database) *AttendanceService {
+	return &AttendanceService{
+		attendanceRepo: repository.NewAttendanceRepository(db),
+		teamRepo:       repository.NewTeamRepository(db),
+	}
+}
+
+// AttendanceTrend is a season of home attendance for the league or one team,
+// with monthly aggregates and games whose reported attendance looks wrong
+type AttendanceTrend struct {
+	Season          string                     `json:"season"`
+	Team            *store.Team                `json:"team,omitempty"`
+	Games           int                        `json:"games"`
+	TotalAttendance int64                      `json:"total_attendance"`
+	AvgAttendance   float64                    `json:"avg_attendance"`
+	Sellouts        int                        `json:"sellouts"`
+	AvgUtilization  *float64                   `json:"avg_capacity_utilization_pct"`
+	Months          []*store.AttendanceMonth   `json:"months"`
+	Outliers        []*store.AttendanceOutlier `json:"outliers"`
+}
+
+// GetAttendanceTrend returns a season's attendance trend. teamID 0 covers the
+// whole league; otherwise the team's home games.
+func (s *AttendanceService) GetAttendanceTrend(ctx context.Context, seasonID int, seasonYear string, teamID int) (*AttendanceTrend, error) {
+	trend := &AttendanceTrend{Season: seasonYear}
+	if teamID != 0 {
+		team, err := cachedTeam(ctx, s.teamRepo, teamID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %d (%v)", ErrTeamNotFound, teamID, err)
+		}
+		trend.Team = team
+	}
+
+	months, err := s.attendanceRepo.GetMonthly(ctx, seasonID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching monthly attendance: %w", err)
+	}
+	outliers, err := s.attendanceRepo.GetOutliers(ctx, seasonID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching attendance outliers: %w", err)
+	}
+
+	trend.Months = months
+	if trend.Months == nil {
+		trend.Months = []*store.AttendanceMonth{}
+	}
+	trend.Outliers = outliers
+	if trend.Outliers == nil {
+		trend.Outliers = []*store.AttendanceOutlier{}
+	}
+
+	var utilization float64
+	capacityGames := 0
+	for _, m := range months {
+		trend.Games += m.Games
+		trend.TotalAttendance += m.TotalAttendance
+		trend.Sellouts += m.Sellouts
+		m.AvgAttendance = math.Round(m.AvgAttendance)
+		if m.AvgUtilization != nil {
+			utilization += *m.AvgUtilization * float64(m.CapacityGames)
+			capacityGames += m.CapacityGames
+			rounded := round1(*m.AvgUtilization)
+			m.AvgUtilization = &rounded
+		}
+	}
+	if trend.Games > 0 {
+		trend.AvgAttendance = math.Round(float64(trend.TotalAttendance) / float64(trend.Games))
+	}
+	if capacityGames > 0 {
+		avg := round1(utilization / float64(capacityGames))
+		trend.AvgUtilization = &avg
+	}
+
+	return trend, nil
+}
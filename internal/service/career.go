@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// PlayerCareer aggregates a player's stored box scores across every season
+type PlayerCareer struct {
+	Player     *store.Player         `json:"player"`
+	SeasonType string                `json:"season_type,omitempty"` // empty when all season types are included
+	Totals     *store.CareerTotals   `json:"totals"`
+	PerGame    *CareerPerGame        `json:"per_game"`
+	Highs      []*store.CareerHigh   `json:"highs"`
+	Seasons    []*store.CareerTotals `json:"seasons"`
+	Milestones []*MilestoneProximity `json:"milestones"`
+}
+
+// CareerPerGame holds career averages and shooting percentages
+type CareerPerGame struct {
+	Minutes           float64  `json:"minutes"`
+	Points            float64  `json:"points"`
+	Rebounds          float64  `json:"rebounds"`
+	Assists           float64  `json:"assists"`
+	Steals            float64  `json:"steals"`
+	Blocks            float64  `json:"blocks"`
+	Turnovers         float64  `json:"turnovers"`
+	ThreePointersMade float64  `json:"three_pointers_made"`
+	FieldGoalPct      *float64 `json:"field_goal_pct,omitempty"`
+	ThreePointPct     *float64 `json:"three_point_pct,omitempty"`
+	FreeThrowPct      *float64 `json:"free_throw_pct,omitempty"`
+}
+
+// MilestoneProximity is the next configured threshold for one career stat
+type MilestoneProximity struct {
+	Stat           string  `json:"stat"`
+	Threshold      int     `json:"threshold"`
+	Label          string  `json:"label,omitempty"`
+	Current        int     `json:"current"`
+	Remaining      int     `json:"remaining"`
+	PerGame        float64 `json:"per_game"`
+	EstimatedGames *int    `json:"estimated_games,omitempty"` // at the career per-game rate
+	Upcoming       bool    `json:"upcoming"`                  // within the threshold's proximity window
+}
+
+// GetCareer builds career totals, per-game averages, highs, and milestone proximity.
+// seasonType restricts the aggregation to 'regular' or 'playoffs'; empty includes both.
+func (s *PlayerService) GetCareer(ctx context.Context, playerID int, seasonType string) (*PlayerCareer, error) {
+	player, err := cachedPlayer(ctx, s.playerRepo, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching player: %w", err)
+	}
+
+	seasons, err := s.statsRepo.GetCareerSeasons(ctx, playerID, seasonType)
+	if err != nil {
+		return nil, fmt.Errorf("fetching career seasons: %w", err)
+	}
+
+	highs, err := s.statsRepo.GetCareerHighs(ctx, playerID, seasonType)
+	if err != nil {
+		return nil, fmt.Errorf("fetching career highs: %w", err)
+	}
+
+	totals := &store.CareerTotals{}
+	for _, season := range seasons {
+		totals.Add(season)
+	}
+
+	career := &PlayerCareer{
+		Player:     player,
+		SeasonType: seasonType,
+		Totals:     totals,
+		PerGame:    careerPerGame(totals),
+		Highs:      highs,
+		Seasons:    seasons,
+		Milestones: []*MilestoneProximity{},
+	}
+	if career.Highs == nil {
+		career.Highs = []*store.CareerHigh{}
+	}
+	if career.Seasons == nil {
+		career.Seasons = []*store.CareerTotals{}
+	}
+
+	thresholds, err := s.milestoneRepo.GetThresholds(ctx, player.Sport)
+	if err != nil {
+		return nil, fmt.Errorf("fetching milestone thresholds: %w", err)
+	}
+	career.Milestones = nextMilestones(totals, thresholds)
+
+	return career, nil
+}
+
+// careerPerGame divides totals by games played
+func careerPerGame(t *store.CareerTotals) *CareerPerGame {
+	pg := &CareerPerGame{}
+	if t.GamesPlayed == 0 {
+		return pg
+	}
+
+	games := float64(t.GamesPlayed)
+	pg.Minutes = round1(t.Minutes / games)
+	pg.Points = round1(float64(t.Points) / games)
+	pg.Rebounds = round1(float64(t.Rebounds) / games)
+	pg.Assists = round1(float64(t.Assists) / games)
+	pg.Steals = round1(float64(t.Steals) / games)
+	pg.Blocks = round1(float64(t.Blocks) / games)
+	pg.Turnovers = round1(float64(t.Turnovers) / games)
+	pg.ThreePointersMade = round1(float64(t.ThreePointersMade) / games)
+	pg.FieldGoalPct = pct(t.FieldGoalsMade, t.FieldGoalsAttempted)
+	pg.ThreePointPct = pct(t.ThreePointersMade, t.ThreePointersAttempted)
+	pg.FreeThrowPct = pct(t.FreeThrowsMade, t.FreeThrowsAttempted)
+	return pg
+}
+
+// nextMilestones picks the lowest unreached threshold for each stat.
+// Thresholds arrive ordered by stat then threshold.
+func nextMilestones(t *store.CareerTotals, thresholds []*store.MilestoneThreshold) []*MilestoneProximity {
+	milestones := []*MilestoneProximity{}
+	seen := make(map[string]bool)
+
+	for _, th := range thresholds {
+		if seen[th.Stat] {
+			continue
+		}
+		current, ok := t.Stat(th.Stat)
+		if !ok || current >= th.Threshold {
+			continue
+		}
+		seen[th.Stat] = true
+
+		m := &MilestoneProximity{
+			Stat:      th.Stat,
+			Threshold: th.Threshold,
+			Label:     th.Label.String,
+			Current:   current,
+			Remaining: th.Threshold - current,
+			Upcoming:  th.Threshold-current <= th.Proximity,
+		}
+		if t.GamesPlayed > 0 {
+			perGame := float64(current) / float64(t.GamesPlayed)
+			m.PerGame = round1(perGame)
+			if perGame > 0 {
+				games := int(math.Ceil(float64(m.Remaining) / perGame))
+				m.EstimatedGames = &games
+			}
+		}
+		milestones = append(milestones, m)
+	}
+
+	return milestones
+}
+
+// pct returns made/attempted rounded to three places, or nil with no attempts
+func pct(made, attempted int) *float64 {
+	if attempted == 0 {
+		return nil
+	}
+	v := math.Round(float64(made)/float64(attempted)*1000) / 1000
+	return &v
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+var (
+	// ErrNoClosingLines is returned when no final game has a mapped closing line
+	ErrNoClosingLines = errors.New("no closing lines on record")
+	// ErrTeamNotFound is returned when a team reference matches no team
+	ErrTeamNotFound = fmt.Errorf("team %w", store.ErrNotFound)
+)
+
+// ATS and over/under outcomes
+const (
+	LineResultCover = "cover"
+	LineResultLoss  = "loss"
+	LineResultOver  = "over"
+	LineResultUnder = "under"
+	LineResultPush  = "push"
+)
+
+// ClosingLineService evaluates stored results against mapped closing lines
+type ClosingLineService struct {
+	oddsRepo *repository.OddsRepository
+	teamRepo *repository.TeamRepository
+}
+
+// NewClosingLineService creates a new closing line service
+func NewClosingLineService(db *store.Database) *ClosingLineService {
+	return &ClosingLineService{
+		oddsRepo: repository.NewOddsRepository(db),
+		teamRepo: repository.NewTeamRepository(db),
+	}
+}
+
+// ClosingLinePerformance holds per-team records against the closing line
+type ClosingLinePerformance struct {
+	Season string                   `json:"season,omitempty"`
+	Teams  []*TeamClosingLineRecord `json:"teams"`
+}
+
+// TeamClosingLineRecord is one team's against-the-spread and over/under record
+type TeamClosingLineRecord struct {
+	Team      *store.Team          `json:"team"`
+	Games     int                  `json:"games"`
+	ATS       ATSRecord            `json:"ats"`
+	HomeATS   ATSRecord            `json:"home_ats"`
+	AwayATS   ATSRecord            `json:"away_ats"`
+	Favorite  ATSRecord            `json:"favorite_ats"`
+	Underdog  ATSRecord            `json:"underdog_ats"`
+	OverUnder OverUnderRecord      `json:"over_under"`
+	AvgMargin *float64             `json:"avg_cover_margin,omitempty"` // mean points beyond the spread
+	GameLog   []*ClosingLineResult `json:"game_log,omitempty"`         // only for a single-team request
+
+	teamID      int
+	marginTotal float64
+}
+
+// ATSRecord counts results against the spread. CoverPct ignores pushes.
+type ATSRecord struct {
+	Wins     int      `json:"wins"`
+	Losses   int      `json:"losses"`
+	Pushes   int      `json:"pushes"`
+	CoverPct *float64 `json:"cover_pct"`
+}
+
+// OverUnderRecord counts results against the closing total. OverPct ignores pushes.
+type OverUnderRecord struct {
+	Overs   int      `json:"overs"`
+	Unders  int      `json:"unders"`
+	Pushes  int      `json:"pushes"`
+	OverPct *float64 `json:"over_pct"`
+}
+
+// ClosingLineResult is one game graded from a team's perspective
+type ClosingLineResult struct {
+	GameID            int       `json:"game_id"`
+	GameDate          time.Time `json:"game_date"`
+	OpponentID        int       `json:"opponent_id"`
+	Home              bool      `json:"home"`
+	TeamScore         int       `json:"team_score"`
+	OpponentScore     int       `json:"opponent_score"`
+	Spread            *float64  `json:"spread,omitempty"` // team line; negative when favored
+	ATSResult         string    `json:"ats_result,omitempty"`
+	Total             *float64  `json:"total,omitempty"`
+	TotalResult       string    `json:"total_result,omitempty"`
+	Bookmaker         string    `json:"bookmaker,omitempty"`
+	AlexandriaEventID string    `json:"alexandria_event_id"`
+}
+
+// GetPerformance grades final games against their closing lines and aggregates the
+// results per team. team is a team ID or abbreviation; when empty, every team with
+// graded games is returned, best cover rate first. seasonYear optionally narrows the
+// games to one season such as "2024-25".
+func (s *ClosingLineService) GetPerformance(ctx context.Context, sport, team, seasonYear string) (*ClosingLinePerformance, error) {
+	var teamID int
+	var only *store.Team
+	if team != "" {
+		t, err := resolveTeam(ctx, s.teamRepo, team)
+		if err != nil {
+			return nil, err
+		}
+		teamID, only = t.TeamID, t
+	}
+
+	lines, err := s.oddsRepo.GetClosingLines(ctx, sport, teamID, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("fetching closing lines: %w", err)
+	}
+	if len(lines) == 0 {
+		return nil, ErrNoClosingLines
+	}
+
+	records := make(map[int]*TeamClosingLineRecord)
+	record := func(id int) *TeamClosingLineRecord {
+		rec, ok := records[id]
+		if !ok {
+			rec = &TeamClosingLineRecord{teamID: id}
+			records[id] = rec
+		}
+		return rec
+	}
+
+	for _, line := range lines {
+		for _, home := range []bool{true, false} {
+			id := teamIDFor(line, home)
+			if teamID != 0 && id != teamID {
+				continue
+			}
+			result := gradeClosingLine(line, home)
+			rec := record(id)
+			rec.add(result)
+			if only != nil {
+				rec.GameLog = append(rec.GameLog, result)
+			}
+		}
+	}
+
+	perf := &ClosingLinePerformance{Season: seasonYear}
+	for _, rec := range records {
+		rec.finish()
+		if only != nil {
+			rec.Team = only
+		} else {
+			t, err := cachedTeam(ctx, s.teamRepo, rec.teamID)
+			if err != nil {
+				return nil, fmt.Errorf("fetching team %d: %w", rec.teamID, err)
+			}
+			rec.Team = t
+		}
+		perf.Teams = append(perf.Teams, rec)
+	}
+
+	sort.SliceStable(perf.Teams, func(i, j int) bool {
+		a, b := perf.Teams[i], perf.Teams[j]
+		if coverPct(a) != coverPct(b) {
+			return coverPct(a) > coverPct(b)
+		}
+		return a.Team.Abbreviation < b.Team.Abbreviation
+	})
+
+	return perf, nil
+}
+
+// resolveTeam accepts a numeric team ID or an abbreviation such as "BOS"
+func resolveTeam(ctx context.Context, teamRepo *repository.TeamRepository, team string) (*store.Team, error) {
+	var t *store.Team
+	var err error
+	if id, convErr := strconv.Atoi(team); convErr == nil {
+		t, err = cachedTeam(ctx, teamRepo, id)
+	} else {
+		t, err = teamRepo.GetByAbbreviation(ctx, strings.ToUpper(team))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrTeamNotFound, team, err)
+	}
+	return t, nil
+}
+
+// gradeClosingLine grades a game from the home or away team's perspective
+func gradeClosingLine(line *store.GameClosingLine, home bool) *ClosingLineResult {
+	result := &ClosingLineResult{
+		GameID:            line.GameID,
+		GameDate:          line.GameDate,
+		Home:              home,
+		AlexandriaEventID: line.AlexandriaEventID,
+		Bookmaker:         line.Bookmaker.String,
+	}
+	if home {
+		result.OpponentID = line.AwayTeamID
+		result.TeamScore, result.OpponentScore = line.HomeScore, line.AwayScore
+	} else {
+		result.OpponentID = line.HomeTeamID
+		result.TeamScore, result.OpponentScore = line.AwayScore, line.HomeScore
+	}
+
+	if line.HomeSpread.Valid {
+		spread := line.HomeSpread.Float64
+		if !home {
+			spread = -spread
+		}
+		result.Spread = &spread
+		result.ATSResult = LineResultPush
+		switch margin := float64(result.TeamScore-result.OpponentScore) + spread; {
+		case margin > 0:
+			result.ATSResult = LineResultCover
+		case margin < 0:
+			result.ATSResult = LineResultLoss
+		}
+	}
+
+	if line.Total.Valid {
+		total := line.Total.Float64
+		result.Total = &total
+		result.TotalResult = LineResultPush
+		switch points := float64(line.HomeScore + line.AwayScore); {
+		case points > total:
+			result.TotalResult = LineResultOver
+		case points < total:
+			result.TotalResult = LineResultUnder
+		}
+	}
+
+	return result
+}
+
+func (rec *TeamClosingLineRecord) add(result *ClosingLineResult) {
+	rec.Games++
+
+	if result.Spread != nil {
+		rec.ATS.add(result.ATSResult)
+		if result.Home {
+			rec.HomeATS.add(result.ATSResult)
+		} else {
+			rec.AwayATS.add(result.ATSResult)
+		}
+		switch {
+		case *result.Spread < 0:
+			rec.Favorite.add(result.ATSResult)
+		case *result.Spread > 0:
+			rec.Underdog.add(result.ATSResult)
+		}
+		rec.marginTotal += float64(result.TeamScore-result.OpponentScore) + *result.Spread
+	}
+
+	switch result.TotalResult {
+	case LineResultOver:
+		rec.OverUnder.Overs++
+	case LineResultUnder:
+		rec.OverUnder.Unders++
+	case LineResultPush:
+		rec.OverUnder.Pushes++
+	}
+}
+
+func (rec *TeamClosingLineRecord) finish() {
+	for _, ats := range []*ATSRecord{&rec.ATS, &rec.HomeATS, &rec.AwayATS, &rec.Favorite, &rec.Underdog} {
+		ats.CoverPct = pct(ats.Wins, ats.Wins+ats.Losses)
+	}
+	rec.OverUnder.OverPct = pct(rec.OverUnder.Overs, rec.OverUnder.Overs+rec.OverUnder.Unders)
+
+	if graded := rec.ATS.Wins + rec.ATS.Losses + rec.ATS.Pushes; graded > 0 {
+		avg := round1(rec.marginTotal / float64(graded))
+		rec.AvgMargin = &avg
+	}
+}
+
+func (a *ATSRecord) add(result string) {
+	switch result {
+	case LineResultCover:
+		a.Wins++
+	case LineResultLoss:
+		a.Losses++
+	case LineResultPush:
+		a.Pushes++
+	}
+}
+
+func teamIDFor(line *store.GameClosingLine, home bool) int {
+	if home {
+		return line.HomeTeamID
+	}
+	return line.AwayTeamID
+}
+
+// coverPct sorts teams without a graded spread last
+func coverPct(rec *TeamClosingLineRecord) float64 {
+	if rec.ATS.CoverPct == nil {
+		return math.Inf(-1)
+	}
+	return *rec.ATS.CoverPct
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// ClutchService summarizes clutch-time play-by-play for players and teams
+type ClutchService struct {
+	clutchRepo *repository.ClutchRepository
+	teamRepo   *repository.TeamRepository
+}
+
+// NewClutchService creates a new clutch service
+func NewClutchService(db *store.Database) *ClutchService {
+	return &ClutchService{
+		clutchRepo: repository.NewClutchRepository(db),
+		teamRepo:   repository.NewTeamRepository(db),
+	}
+}
+
+// PlayerClutchStats is a player's scoring and shooting in clutch time
+type PlayerClutchStats struct {
+	PlayerID       int                            `json:"player_id"`
+	Season         string                         `json:"season,omitempty"`
+	ClutchGames    int                            `json:"clutch_games"`
+	Points         int                            `json:"points"`
+	PointsPerGame  float64                        `json:"points_per_game"`
+	FieldGoalsMade int                            `json:"field_goals_made"`
+	FieldGoalsAtt  int                            `json:"field_goals_attempted"`
+	FieldGoalPct   *float64                       `json:"field_goal_pct"`
+	ThreesMade     int                            `json:"three_pointers_made"`
+	ThreesAtt      int                            `json:"three_pointers_attempted"`
+	ThreePointPct  *float64                       `json:"three_point_pct"`
+	FreeThrowsMade int                            `json:"free_throws_made"`
+	FreeThrowsAtt  int                            `json:"free_throws_attempted"`
+	FreeThrowPct   *float64                       `json:"free_throw_pct"`
+	Games          []*repository.PlayerClutchGame `json:"games"`
+}
+
+// TeamClutchStats is a team's record and efficiency in clutch time. Ratings are
+// points per 100 possessions, estimated from play-by-play as FGA + 0.44*FTA + TOV - OREB.
+type TeamClutchStats struct {
+	Team          *store.Team                  `json:"team"`
+	Season        string                       `json:"season,omitempty"`
+	ClutchGames   int                          `json:"clutch_games"`
+	Wins          int                          `json:"wins"`
+	Losses        int                          `json:"losses"`
+	PointsFor     int                          `json:"points_for"`
+	PointsAgainst int                          `json:"points_against"`
+	Possessions   float64                      `json:"possessions"`
+	OffRating     *float64                     `json:"offensive_rating"`
+	DefRating     *float64                     `json:"defensive_rating"`
+	NetRating     *float64                     `json:"net_rating"`
+	Games         []*repository.TeamClutchGame `json:"games"`
+}
+
+// GetPlayerClutch totals a player's clutch-time production. An empty seasonYear covers every season.
+func (s *ClutchService) GetPlayerClutch(ctx context.Context, playerID int, seasonYear string) (*PlayerClutchStats, error) {
+	games, err := s.clutchRepo.GetPlayerClutchGames(ctx, playerID, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("fetching player clutch games: %w", err)
+	}
+
+	stats := &PlayerClutchStats{
+		PlayerID:    playerID,
+		Season:      seasonYear,
+		ClutchGames: len(games),
+		Games:       games,
+	}
+	if stats.Games == nil {
+		stats.Games = []*repository.PlayerClutchGame{}
+	}
+
+	for _, g := range games {
+		stats.Points += g.Points
+		stats.FieldGoalsMade += g.FieldGoalsMade
+		stats.FieldGoalsAtt += g.FieldGoalsAtt
+		stats.ThreesMade += g.ThreesMade
+		stats.ThreesAtt += g.ThreesAtt
+		stats.FreeThrowsMade += g.FreeThrowsMade
+		stats.FreeThrowsAtt += g.FreeThrowsAtt
+	}
+	if len(games) > 0 {
+		stats.PointsPerGame = round1(float64(stats.Points) / float64(len(games)))
+	}
+	stats.FieldGoalPct = pct(stats.FieldGoalsMade, stats.FieldGoalsAtt)
+	stats.ThreePointPct = pct(stats.ThreesMade, stats.ThreesAtt)
+	stats.FreeThrowPct = pct(stats.FreeThrowsMade, stats.FreeThrowsAtt)
+
+	return stats, nil
+}
+
+// GetTeamClutch totals a team's clutch-time record and ratings. An empty seasonYear covers every season.
+func (s *ClutchService) GetTeamClutch(ctx context.Context, teamID int, seasonYear string) (*TeamClutchStats, error) {
+	team, err := cachedTeam(ctx, s.teamRepo, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching team: %w", err)
+	}
+
+	games, err := s.clutchRepo.GetTeamClutchGames(ctx, teamID, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("fetching team clutch games: %w", err)
+	}
+
+	stats := &TeamClutchStats{
+		Team:        team,
+		Season:      seasonYear,
+		ClutchGames: len(games),
+		Games:       games,
+	}
+	if stats.Games == nil {
+		stats.Games = []*repository.TeamClutchGame{}
+	}
+
+	var own, opp float64
+	for _, g := range games {
+		if g.Won {
+			stats.Wins++
+		} else {
+			stats.Losses++
+		}
+		stats.PointsFor += g.PointsFor
+		stats.PointsAgainst += g.PointsAgainst
+		own += possessions(g.FieldGoalsAtt, g.FreeThrowsAtt, g.Turnovers, g.OffRebounds)
+		opp += possessions(g.OppFieldGoalsAtt, g.OppFreeThrowsAtt, g.OppTurnovers, g.OppOffRebounds)
+	}
+
+	// Both sides have roughly the same possessions; averaging smooths estimate noise
+	stats.Possessions = round1((own + opp) / 2)
+	if stats.Possessions > 0 {
+		off := round1(float64(stats.PointsFor) / stats.Possessions * 100)
+		def := round1(float64(stats.PointsAgainst) / stats.Possessions * 100)
+		net := round1(off - def)
+		stats.OffRating, stats.DefRating, stats.NetRating = &off, &def, &net
+	}
+
+	return stats, nil
+}
+
+func possessions(fga, fta, turnovers, offRebounds int) float64 {
+	return float64(fga) + 0.44*float64(fta) + float64(turnovers) - float64(offRebounds)
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// dailyTopPerformances is how many stat lines a daily recap ranks
+const dailyTopPerformances = 5
+
+// DailyRecap is a league-wide summary of one date's final games, for
+// newsletter generation
+type DailyRecap struct {
+	Date            string            `json:"date"`
+	Games           []*DailyRecapGame `json:"games"`
+	TopPerformances []*RecapPerformer `json:"top_performances"`
+	Milestones      []*RecapMilestone `json:"milestones"`
+	Standings       []*StandingsMove  `json:"standings"`
+}
+
+// DailyRecapGame is one final game in a daily recap
+type DailyRecapGame struct {
+	GameID          string    `json:"game_id"`
+	GameType        string    `json:"game_type"`
+	Headline        string    `json:"headline"`
+	HomeTeam        RecapTeam `json:"home_team"`
+	AwayTeam        RecapTeam `json:"away_team"`
+	WinnerTeamID    *int      `json:"winner_team_id,omitempty"`
+	Margin          int       `json:"margin"`
+	OvertimePeriods int       `json:"overtime_periods"`
+}
+
+// StandingsMove is a team's conference standing after the recap date next to
+// the day before. PreviousRank is nil before a team's first game; RankChange is
+// positive when the team moved up.
+type StandingsMove struct {
+	TeamID       int    `json:"team_id"`
+	Abbreviation string `json:"abbreviation"`
+	Name         string `json:"name"`
+	Conference   string `json:"conference"`
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"`
+	Rank         int    `json:"rank"`
+	PreviousRank *int   `json:"previous_rank"`
+	RankChange   int    `json:"rank_change"`
+	PlayedToday  bool   `json:"played"`
+}
+
+// GetDailyRecap summarizes a date's final games in sport: scores and headlines,
+// the day's best stat lines by game score, milestones, and the conference
+// standings of every team that played or moved
+func (s *RecapService) GetDailyRecap(ctx context.Context, sport string, date time.Time) (*DailyRecap, error) {
+	games, err := s.gameRepo.GetByDate(ctx, sport, date, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching games: %w", err)
+	}
+
+	recap := &DailyRecap{
+		Date:            date.Format("2006-01-02"),
+		Games:           []*DailyRecapGame{},
+		TopPerformances: []*RecapPerformer{},
+		Milestones:      []*RecapMilestone{},
+		Standings:       []*StandingsMove{},
+	}
+
+	names := make(map[int]string)
+	var lines []*store.PlayerGameStats
+	gameIDs := make(map[int]string)
+	played := make(map[int]bool)
+	seasonID := 0
+	for _, game := range games {
+		if game.Status != "final" {
+			continue
+		}
+		stats, err := s.statsRepo.GetByGameID(ctx, game.GameID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching box score: %w", err)
+		}
+
+		summary, err := s.dailyGame(ctx, game, stats, names)
+		if err != nil {
+			return nil, err
+		}
+		recap.Games = append(recap.Games, summary)
+
+		for _, m := range s.milestones(ctx, stats, game, names) {
+			m.GameID = game.ExternalID
+			recap.Milestones = append(recap.Milestones, m)
+		}
+		lines = append(lines, stats...)
+		gameIDs[game.GameID] = game.ExternalID
+		if game.GameType == store.GameTypeRegular || game.GameType == store.GameTypeTournament {
+			played[game.HomeTeamID], played[game.AwayTeamID] = true, true
+			seasonID = game.SeasonID
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool { return gameScore(lines[i]) > gameScore(lines[j]) })
+	for _, st := range lines {
+		if len(recap.TopPerformances) == dailyTopPerformances {
+			break
+		}
+		recap.TopPerformances = append(recap.TopPerformances, &RecapPerformer{
+			PlayerID:  st.PlayerID,
+			Name:      s.playerName(ctx, st.PlayerID, names),
+			TeamID:    st.TeamID,
+			GameID:    gameIDs[st.GameID],
+			Points:    st.Points,
+			Rebounds:  st.Rebounds,
+			Assists:   st.Assists,
+			Steals:    st.Steals,
+			Blocks:    st.Blocks,
+			GameScore: round1(gameScore(st)),
+			Line:      statLine(st),
+		})
+	}
+
+	if seasonID != 0 {
+		if recap.Standings, err = s.standingsMoves(ctx, seasonID, date, played); err != nil {
+			return nil, err
+		}
+	}
+	return recap, nil
+}
+
+// dailyGame summarizes one final game with the same headline as its game recap
+func (s *RecapService) dailyGame(ctx context.Context, game *store.Game, stats []*store.PlayerGameStats, names map[int]string) (*DailyRecapGame, error) {
+	homeTeam, err := cachedTeam(ctx, s.teamRepo, game.HomeTeamID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching home team: %w", err)
+	}
+	awayTeam, err := cachedTeam(ctx, s.teamRepo, game.AwayTeamID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching away team: %w", err)
+	}
+	quarters, err := s.gameRepo.GetQuarterScores(ctx, game.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching quarter scores: %w", err)
+	}
+	if quarters == nil {
+		quarters = &store.QuarterScores{}
+	}
+
+	gameRecap := &GameRecap{
+		HomeTeam:      recapTeam(homeTeam, int(game.HomeScore.Int32), quarters.Home),
+		AwayTeam:      recapTeam(awayTeam, int(game.AwayScore.Int32), quarters.Away),
+		TopPerformers: s.topPerformers(ctx, stats, names),
+	}
+	gameRecap.HomeTeam.LargestLead = int(game.HomeLargestLead.Int32)
+	gameRecap.AwayTeam.LargestLead = int(game.AwayLargestLead.Int32)
+	gameRecap.Margin = abs(gameRecap.HomeTeam.Score - gameRecap.AwayTeam.Score)
+	if gameRecap.Margin > 0 {
+		winner := game.HomeTeamID
+		if gameRecap.AwayTeam.Score > gameRecap.HomeTeam.Score {
+			winner = game.AwayTeamID
+		}
+		gameRecap.WinnerTeamID = &winner
+	}
+
+	return &DailyRecapGame{
+		GameID:          game.ExternalID,
+		GameType:        game.GameType,
+		Headline:        recapHeadline(gameRecap, game),
+		HomeTeam:        gameRecap.HomeTeam,
+		AwayTeam:        gameRecap.AwayTeam,
+		WinnerTeamID:    gameRecap.WinnerTeamID,
+		Margin:          gameRecap.Margin,
+		OvertimePeriods: max(int(game.Period.Int32)-4, 0),
+	}, nil
+}
+
+// standingsMoves ranks each conference by winning percentage through date and
+// the day before, keeping the teams that played on date or changed rank
+func (s *RecapService) standingsMoves(ctx context.Context, seasonID int, date time.Time, played map[int]bool) ([]*StandingsMove, error) {
+	records, err := s.gameRepo.GetRecordsThrough(ctx, seasonID, date)
+	if err != nil {
+		return nil, fmt.Errorf("fetching standings: %w", err)
+	}
+
+	teams := make(map[int]*store.Team, len(records))
+	conferences := make(map[string][]*repository.TeamRecord)
+	for _, rec := range records {
+		team, err := cachedTeam(ctx, s.teamRepo, rec.TeamID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching team: %w", err)
+		}
+		teams[rec.TeamID] = team
+		conferences[team.Conference.String] = append(conferences[team.Conference.String], rec)
+	}
+
+	moves := []*StandingsMove{}
+	for conference, recs := range conferences {
+		after := rankRecords(recs, teams, func(r *repository.TeamRecord) (int, int) { return r.Wins, r.Losses })
+		before := rankRecords(recs, teams, func(r *repository.TeamRecord) (int, int) { return r.WinsBefore, r.LossesBefore })
+
+		for _, rec := range recs {
+			team := teams[rec.TeamID]
+			move := &StandingsMove{
+				TeamID:       rec.TeamID,
+				Abbreviation: team.Abbreviation,
+				Name:         team.FullName,
+				Conference:   conference,
+				Wins:         rec.Wins,
+				Losses:       rec.Losses,
+				Rank:         after[rec.TeamID],
+				PlayedToday:  played[rec.TeamID],
+			}
+			if rec.WinsBefore+rec.LossesBefore > 0 {
+				prev := before[rec.TeamID]
+				move.PreviousRank = &prev
+				move.RankChange = prev - move.Rank
+			}
+			if move.PlayedToday || move.RankChange != 0 {
+				moves = append(moves, move)
+			}
+		}
+	}
+
+	sort.SliceStable(moves, func(i, j int) bool {
+		if moves[i].Conference != moves[j].Conference {
+			return moves[i].Conference < moves[j].Conference
+		}
+		return moves[i].Rank < moves[j].Rank
+	})
+	return moves, nil
+}
+
+// rankRecords orders teams by winning percentage, then wins, then abbreviation,
+// and returns each team's 1-based rank. Teams without games rank last.
+func rankRecords(records []*repository.TeamRecord, teams map[int]*store.Team, record func(*repository.TeamRecord) (int, int)) map[int]int {
+	winPct := func(r *repository.TeamRecord) float64 {
+		w, l := record(r)
+		if w+l == 0 {
+			return -1
+		}
+		return float64(w) / float64(w+l)
+	}
+
+	ordered := make([]*repository.TeamRecord, len(records))
+	copy(ordered, records)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		pi, pj := winPct(ordered[i]), winPct(ordered[j])
+		if pi != pj {
+			return pi > pj
+		}
+		wi, _ := record(ordered[i])
+		wj, _ := record(ordered[j])
+		if wi != wj {
+			return wi > wj
+		}
+		return teams[ordered[i].TeamID].Abbreviation < teams[ordered[j].TeamID].Abbreviation
+	})
+
+	ranks := make(map[int]int, len(ordered))
+	for i, r := range ordered {
+		ranks[r.TeamID] = i + 1
+	}
+	return ranks
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// DraftClass is every stored pick from one draft year
+type DraftClass struct {
+	Year  int               `json:"year"`
+	Picks []*DraftClassPick `json:"picks"`
+}
+
+// DraftClassPick is a draft pick with the player's career per-game averages
+type DraftClassPick struct {
+	*store.DraftPick
+	PerGame *CareerPerGame `json:"per_game"`
+}
+
+// GetDraftClass returns a draft year's picks in overall order with each
+// player's NBA regular-season career totals and averages. Players who never
+// appeared in a stored game have zero totals.
+func (s *PlayerService) GetDraftClass(ctx context.Context, sport string, year int) (*DraftClass, error) {
+	picks, err := s.statsRepo.GetDraftClass(ctx, sport, year)
+	if err != nil {
+		return nil, fmt.Errorf("fetching draft class: %w", err)
+	}
+
+	class := &DraftClass{Year: year, Picks: make([]*DraftClassPick, 0, len(picks))}
+	for _, pick := range picks {
+		class.Picks = append(class.Picks, &DraftClassPick{
+			DraftPick: pick,
+			PerGame:   careerPerGame(pick.Career),
+		})
+	}
+	return class, nil
+}
@@ -0,0 +1,534 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// featureRecentGames is the window of the last-N form features
+const featureRecentGames = 5
+
+// FeatureSeason is one season of a feature matrix export
+type FeatureSeason struct {
+	ID   int
+	Year string // e.g. "2024-25"
+}
+
+// FeatureMatrixService builds training rows for every player-game of a season
+// in one pass over the season's stat lines, instead of a GetPlayerMLFeatures
+// call per player and date
+type FeatureMatrixService struct {
+	gameRepo   *repository.GameRepository
+	statsRepo  *repository.StatsRepository
+	playerRepo *repository.PlayerRepository
+}
+
+// NewFeatureMatrixService creates a new feature matrix service
+func NewFeatureMatrixService(db *store.Database) *FeatureMatrixService {
+	return &FeatureMatrixService{
+		gameRepo:   repository.NewGameRepository(db),
+		statsRepo:  repository.NewStatsRepository(db),
+		playerRepo: repository.NewPlayerRepository(db),
+	}
+}
+
+// FeatureRow is one player-game: features known before tip-off and the stats
+// the player went on to record. Prior features only count the player's and
+// teams' earlier games of the same season, so nothing from the game itself or
+// later leaks in; they are null before a first game.
+type FeatureRow struct {
+	Season         string    `json:"season"`
+	GameID         string    `json:"game_id"` // external (ESPN) ID
+	GameDate       time.Time `json:"game_date"`
+	GameType       string    `json:"game_type"`
+	PlayerID       int       `json:"player_id"`
+	TeamID         int       `json:"team_id"`
+	OpponentTeamID int       `json:"opponent_team_id"`
+	IsHome         bool      `json:"is_home"`
+
+	// Player form before the game
+	PriorGames     int      `json:"prior_games"`
+	PriorMinutes   *float64 `json:"prior_minutes"`
+	PriorPoints    *float64 `json:"prior_points"`
+	PriorRebounds  *float64 `json:"prior_rebounds"`
+	PriorAssists   *float64 `json:"prior_assists"`
+	PriorThrees    *float64 `json:"prior_threes"`
+	PriorSteals    *float64 `json:"prior_steals"`
+	PriorBlocks    *float64 `json:"prior_blocks"`
+	PriorTurnovers *float64 `json:"prior_turnovers"`
+	PriorFGPct     *float64 `json:"prior_fg_pct"`
+	PriorThreePct  *float64 `json:"prior_three_pct"`
+	PriorFTPct     *float64 `json:"prior_ft_pct"`
+	Last5Points    *float64 `json:"last_5_points"`
+	Last5Minutes   *float64 `json:"last_5_minutes"`
+
+	// Rest, in days since the previous game (1 is a back-to-back)
+	PlayerRestDays   *int `json:"player_rest_days"`
+	TeamRestDays     *int `json:"team_rest_days"`
+	OpponentRestDays *int `json:"opponent_rest_days"`
+	BackToBack       bool `json:"back_to_back"`
+
+	// Team and opponent ratings before the game, per 100 possessions
+	TeamOffRating     *float64 `json:"team_off_rating"`
+	TeamPace          *float64 `json:"team_pace"`
+	OpponentOffRating *float64 `json:"opponent_off_rating"`
+	OpponentDefRating *float64 `json:"opponent_def_rating"`
+	OpponentPace      *float64 `json:"opponent_pace"`
+
+	// What the opponent has allowed per game to the player's position group
+	// (PG, SG, SF, PF, or C), as in team_positional_defense
+	Position                   string   `json:"position"`
+	OpponentPosPointsAllowed   *float64 `json:"opponent_pos_points_allowed"`
+	OpponentPosReboundsAllowed *float64 `json:"opponent_pos_rebounds_allowed"`
+	OpponentPosAssistsAllowed  *float64 `json:"opponent_pos_assists_allowed"`
+
+	// Targets
+	Minutes   float64 `json:"minutes"`
+	Points    int     `json:"points"`
+	Rebounds  int     `json:"rebounds"`
+	Assists   int     `json:"assists"`
+	Threes    int     `json:"threes"`
+	Steals    int     `json:"steals"`
+	Blocks    int     `json:"blocks"`
+	Turnovers int     `json:"turnovers"`
+}
+
+// FeatureMatrixColumns is the CSV header, in FeatureRow.Record order
+var FeatureMatrixColumns = []string{
+	"season", "game_id", "game_date", "game_type", "player_id", "team_id", "opponent_team_id", "is_home",
+	"prior_games", "prior_minutes", "prior_points", "prior_rebounds", "prior_assists", "prior_threes",
+	"prior_steals", "prior_blocks", "prior_turnovers", "prior_fg_pct", "prior_three_pct", "prior_ft_pct",
+	"last_5_points", "last_5_minutes",
+	"player_rest_days", "team_rest_days", "opponent_rest_days", "back_to_back",
+	"team_off_rating", "team_pace", "opponent_off_rating", "opponent_def_rating", "opponent_pace",
+	"position", "opponent_pos_points_allowed", "opponent_pos_rebounds_allowed", "opponent_pos_assists_allowed",
+	"minutes", "points", "rebounds", "assists", "threes", "steals", "blocks", "turnovers",
+}
+
+// Record formats the row as CSV fields; null features are empty and booleans 0 or 1
+func (f *FeatureRow) Record() []string {
+	return []string{
+		f.Season, f.GameID, f.GameDate.Format("2006-01-02"), f.GameType,
+		strconv.Itoa(f.PlayerID), strconv.Itoa(f.TeamID), strconv.Itoa(f.OpponentTeamID), boolField(f.IsHome),
+		strconv.Itoa(f.PriorGames), floatField(f.PriorMinutes), floatField(f.PriorPoints), floatField(f.PriorRebounds),
+		floatField(f.PriorAssists), floatField(f.PriorThrees), floatField(f.PriorSteals), floatField(f.PriorBlocks),
+		floatField(f.PriorTurnovers), floatField(f.PriorFGPct), floatField(f.PriorThreePct), floatField(f.PriorFTPct),
+		floatField(f.Last5Points), floatField(f.Last5Minutes),
+		intField(f.PlayerRestDays), intField(f.TeamRestDays), intField(f.OpponentRestDays), boolField(f.BackToBack),
+		floatField(f.TeamOffRating), floatField(f.TeamPace), floatField(f.OpponentOffRating),
+		floatField(f.OpponentDefRating), floatField(f.OpponentPace),
+		f.Position, floatField(f.OpponentPosPointsAllowed), floatField(f.OpponentPosReboundsAllowed),
+		floatField(f.OpponentPosAssistsAllowed),
+		strconv.FormatFloat(f.Minutes, 'f', -1, 64), strconv.Itoa(f.Points), strconv.Itoa(f.Rebounds),
+		strconv.Itoa(f.Assists), strconv.Itoa(f.Threes), strconv.Itoa(f.Steals), strconv.Itoa(f.Blocks),
+		strconv.Itoa(f.Turnovers),
+	}
+}
+
+// Build calls emit for the player-games of each season in turn, in game date
+// order. Only lines with minutes played become rows. Form and ratings start
+// over every season. A non-zero asOf drops games dated on or after it, as
+// GetPlayerMLFeatures does, so a matrix built on a given day can be rebuilt
+// exactly later.
+func (s *FeatureMatrixService) Build(ctx context.Context, seasons []FeatureSeason, asOf time.Time, emit func(*FeatureRow) error) error {
+	for _, season := range seasons {
+		if err := s.buildSeason(ctx, season, asOf, emit); err != nil {
+			return fmt.Errorf("season %s: %w", season.Year, err)
+		}
+	}
+	return nil
+}
+
+// featureBatch is the running state of one season's pass
+type featureBatch struct {
+	season  FeatureSeason
+	games   map[int]*store.Game
+	totals  map[int][]*repository.TeamGameTotals // by game ID
+	days    []*featureDay                        // final games by date, ascending
+	applied int                                  // days folded into the team state
+	players map[int]*playerForm
+	teams   map[int]*teamForm
+	defense map[positionKey]*positionForm
+	roles   map[int]string           // player ID to defensive position
+	pending []*store.PlayerGameStats // lines of the date being read
+}
+
+// positionKey is a defending team and the position it defended
+type positionKey struct {
+	teamID   int
+	position string
+}
+
+type featureDay struct {
+	date  time.Time
+	games []*store.Game
+}
+
+type playerForm struct {
+	games, fgm, fga, tpm, tpa, ftm, fta        int
+	minutes, points, rebounds, assists, threes float64
+	steals, blocks, turnovers                  float64
+	recentPoints, recentMinutes                []float64
+	lastGame                                   time.Time
+}
+
+type positionForm struct {
+	games                     int
+	points, rebounds, assists float64
+}
+
+type teamForm struct {
+	points, allowed, possessions, oppPossessions, minutes float64
+	games                                                 int
+	lastGame                                              time.Time
+}
+
+func (s *FeatureMatrixService) buildSeason(ctx context.Context, season FeatureSeason, asOf time.Time, emit func(*FeatureRow) error) error {
+	b := &featureBatch{
+		season:  season,
+		games:   make(map[int]*store.Game),
+		totals:  make(map[int][]*repository.TeamGameTotals),
+		players: make(map[int]*playerForm),
+		teams:   make(map[int]*teamForm),
+		defense: make(map[positionKey]*positionForm),
+		roles:   make(map[int]string),
+	}
+
+	positions, err := s.playerRepo.GetSeasonPositions(ctx, season.ID)
+	if err != nil {
+		return fmt.Errorf("fetching player positions: %w", err)
+	}
+	for playerID, position := range positions {
+		if role := store.DefensivePosition(position); role != "" {
+			b.roles[playerID] = role
+		}
+	}
+
+	err = s.gameRepo.StreamBySeason(ctx, season.ID, func(game *store.Game) error {
+		if game.Status != "final" || (!asOf.IsZero() && !game.GameDate.Before(asOf)) {
+			return nil
+		}
+		b.games[game.GameID] = game
+		if n := len(b.days); n == 0 || game.GameDate.After(b.days[n-1].date) {
+			b.days = append(b.days, &featureDay{date: game.GameDate})
+		}
+		day := b.days[len(b.days)-1]
+		day.games = append(day.games, game)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("fetching games: %w", err)
+	}
+
+	totals, err := s.statsRepo.GetSeasonTeamTotals(ctx, season.ID)
+	if err != nil {
+		return fmt.Errorf("fetching team totals: %w", err)
+	}
+	for _, t := range totals {
+		b.totals[t.GameID] = append(b.totals[t.GameID], t)
+	}
+
+	err = s.statsRepo.StreamSeasonPlayerStats(ctx, season.ID, func(line *store.PlayerGameStats) error {
+		game := b.games[line.GameID]
+		if game == nil || !line.MinutesPlayed.Valid || line.MinutesPlayed.Float64 <= 0 {
+			return nil
+		}
+		if len(b.pending) > 0 && !b.games[b.pending[0].GameID].GameDate.Equal(game.GameDate) {
+			if err := b.flush(emit); err != nil {
+				return err
+			}
+		}
+		b.pending = append(b.pending, line)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("streaming player stats: %w", err)
+	}
+	return b.flush(emit)
+}
+
+// flush emits the rows of the pending date, then folds its lines into the
+// player and positional defense state so they only count toward later dates
+func (b *featureBatch) flush(emit func(*FeatureRow) error) error {
+	if len(b.pending) == 0 {
+		return nil
+	}
+	date := b.games[b.pending[0].GameID].GameDate
+	b.applyTeamsBefore(date)
+
+	for _, line := range b.pending {
+		if err := emit(b.row(line)); err != nil {
+			return err
+		}
+	}
+	for _, line := range b.pending {
+		b.player(line.PlayerID).add(line, date)
+	}
+	b.applyDefense()
+	b.pending = b.pending[:0]
+	return nil
+}
+
+// applyTeamsBefore folds every game date before date into the team state,
+// including dates no stat lines were read for
+func (b *featureBatch) applyTeamsBefore(date time.Time) {
+	for ; b.applied < len(b.days) && b.days[b.applied].date.Before(date); b.applied++ {
+		day := b.days[b.applied]
+		for _, game := range day.games {
+			b.team(game.HomeTeamID).lastGame = day.date
+			b.team(game.AwayTeamID).lastGame = day.date
+			for _, t := range b.totals[game.GameID] {
+				b.team(t.TeamID).add(t)
+			}
+		}
+	}
+}
+
+// applyDefense folds the pending lines into what each team allowed to each
+// position. Like team_positional_defense, a team's game counts once per
+// position it faced, and exhibition game types are left out.
+func (b *featureBatch) applyDefense() {
+	type gamePosition struct {
+		positionKey
+		gameID int
+	}
+	games := make(map[gamePosition]*positionForm)
+	var order []gamePosition
+	for _, line := range b.pending {
+		game := b.games[line.GameID]
+		role := b.roles[line.PlayerID]
+		if role == "" || slices.Contains(store.SpecialGameTypes, game.GameType) {
+			continue
+		}
+		key := gamePosition{positionKey{opponentOf(game, line.TeamID), role}, game.GameID}
+		g, ok := games[key]
+		if !ok {
+			g = &positionForm{games: 1}
+			games[key] = g
+			order = append(order, key)
+		}
+		g.points += float64(line.Points)
+		g.rebounds += float64(line.Rebounds)
+		g.assists += float64(line.Assists)
+	}
+	for _, key := range order {
+		d, ok := b.defense[key.positionKey]
+		if !ok {
+			d = &positionForm{}
+			b.defense[key.positionKey] = d
+		}
+		g := games[key]
+		d.games += g.games
+		d.points += g.points
+		d.rebounds += g.rebounds
+		d.assists += g.assists
+	}
+}
+
+func (b *featureBatch) row(line *store.PlayerGameStats) *FeatureRow {
+	game := b.games[line.GameID]
+	opponentID := opponentOf(game, line.TeamID)
+
+	row := &FeatureRow{
+		Season:         b.season.Year,
+		GameID:         game.ExternalID,
+		GameDate:       game.GameDate,
+		GameType:       game.GameType,
+		PlayerID:       line.PlayerID,
+		TeamID:         line.TeamID,
+		OpponentTeamID: opponentID,
+		IsHome:         line.TeamID == game.HomeTeamID,
+		Minutes:        line.MinutesPlayed.Float64,
+		Points:         line.Points,
+		Rebounds:       line.Rebounds,
+		Assists:        line.Assists,
+		Threes:         line.ThreePointersMade,
+		Steals:         line.Steals,
+		Blocks:         line.Blocks,
+		Turnovers:      line.Turnovers,
+	}
+
+	if p := b.players[line.PlayerID]; p != nil && p.games > 0 {
+		n := float64(p.games)
+		row.PriorGames = p.games
+		row.PriorMinutes = featureValue(p.minutes / n)
+		row.PriorPoints = featureValue(p.points / n)
+		row.PriorRebounds = featureValue(p.rebounds / n)
+		row.PriorAssists = featureValue(p.assists / n)
+		row.PriorThrees = featureValue(p.threes / n)
+		row.PriorSteals = featureValue(p.steals / n)
+		row.PriorBlocks = featureValue(p.blocks / n)
+		row.PriorTurnovers = featureValue(p.turnovers / n)
+		row.PriorFGPct = featureRatio(p.fgm, p.fga)
+		row.PriorThreePct = featureRatio(p.tpm, p.tpa)
+		row.PriorFTPct = featureRatio(p.ftm, p.fta)
+		row.Last5Points = featureValue(mean(p.recentPoints))
+		row.Last5Minutes = featureValue(mean(p.recentMinutes))
+		row.PlayerRestDays = restDays(p.lastGame, game.GameDate)
+	}
+
+	if t := b.teams[row.TeamID]; t != nil {
+		row.TeamRestDays = restDays(t.lastGame, game.GameDate)
+		row.BackToBack = row.TeamRestDays != nil && *row.TeamRestDays == 1
+		row.TeamOffRating = t.offRating()
+		row.TeamPace = t.pace()
+	}
+	if o := b.teams[opponentID]; o != nil {
+		row.OpponentRestDays = restDays(o.lastGame, game.GameDate)
+		row.OpponentOffRating = o.offRating()
+		row.OpponentDefRating = o.defRating()
+		row.OpponentPace = o.pace()
+	}
+
+	row.Position = b.roles[line.PlayerID]
+	if d := b.defense[positionKey{opponentID, row.Position}]; d != nil && d.games > 0 {
+		n := float64(d.games)
+		row.OpponentPosPointsAllowed = featureValue(d.points / n)
+		row.OpponentPosReboundsAllowed = featureValue(d.rebounds / n)
+		row.OpponentPosAssistsAllowed = featureValue(d.assists / n)
+	}
+	return row
+}
+
+// opponentOf is the other team in game
+func opponentOf(game *store.Game, teamID int) int {
+	if teamID == game.HomeTeamID {
+		return game.AwayTeamID
+	}
+	return game.HomeTeamID
+}
+
+func (b *featureBatch) player(id int) *playerForm {
+	p, ok := b.players[id]
+	if !ok {
+		p = &playerForm{}
+		b.players[id] = p
+	}
+	return p
+}
+
+func (b *featureBatch) team(id int) *teamForm {
+	t, ok := b.teams[id]
+	if !ok {
+		t = &teamForm{}
+		b.teams[id] = t
+	}
+	return t
+}
+
+func (p *playerForm) add(line *store.PlayerGameStats, date time.Time) {
+	p.games++
+	p.minutes += line.MinutesPlayed.Float64
+	p.points += float64(line.Points)
+	p.rebounds += float64(line.Rebounds)
+	p.assists += float64(line.Assists)
+	p.threes += float64(line.ThreePointersMade)
+	p.steals += float64(line.Steals)
+	p.blocks += float64(line.Blocks)
+	p.turnovers += float64(line.Turnovers)
+	p.fgm += line.FieldGoalsMade
+	p.fga += line.FieldGoalsAttempted
+	p.tpm += line.ThreePointersMade
+	p.tpa += line.ThreePointersAttempted
+	p.ftm += line.FreeThrowsMade
+	p.fta += line.FreeThrowsAttempted
+	p.recentPoints = lastN(append(p.recentPoints, float64(line.Points)), featureRecentGames)
+	p.recentMinutes = lastN(append(p.recentMinutes, line.MinutesPlayed.Float64), featureRecentGames)
+	p.lastGame = date
+}
+
+func (t *teamForm) add(totals *repository.TeamGameTotals) {
+	t.games++
+	t.points += float64(totals.Points)
+	t.allowed += float64(totals.PointsAllowed)
+	t.possessions += totals.Possessions
+	t.oppPossessions += totals.OppPossessions
+	t.minutes += float64(totals.GameMinutes)
+}
+
+func (t *teamForm) offRating() *float64 {
+	if t.possessions <= 0 {
+		return nil
+	}
+	return featureValue(100 * t.points / t.possessions)
+}
+
+func (t *teamForm) defRating() *float64 {
+	if t.oppPossessions <= 0 {
+		return nil
+	}
+	return featureValue(100 * t.allowed / t.oppPossessions)
+}
+
+// pace averages both sides' possessions per 48 minutes, so overtime doesn't inflate it
+func (t *teamForm) pace() *float64 {
+	if t.minutes <= 0 {
+		return nil
+	}
+	return featureValue(48 * (t.possessions + t.oppPossessions) / 2 / t.minutes)
+}
+
+func lastN(values []float64, n int) []float64 {
+	if len(values) > n {
+		return values[len(values)-n:]
+	}
+	return values
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return safeDiv(sum, float64(len(values)))
+}
+
+// featureValue rounds to three decimals, enough for training and a readable CSV
+func featureValue(v float64) *float64 {
+	v = math.Round(v*1000) / 1000
+	return &v
+}
+
+func featureRatio(made, attempted int) *float64 {
+	if attempted == 0 {
+		return nil
+	}
+	return featureValue(float64(made) / float64(attempted))
+}
+
+// restDays counts days since the last game, nil before a first one
+func restDays(last, date time.Time) *int {
+	if last.IsZero() {
+		return nil
+	}
+	days := daysBetween(last, date)
+	return &days
+}
+
+func floatField(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+func intField(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func boolField(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
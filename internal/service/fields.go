@@ -0,0 +1,112 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidFields is returned for a malformed ?fields= selection
+var ErrInvalidFields = errors.New("invalid fields selection")
+
+// FieldSet is a sparse fieldset parsed from a comma-separated list of dotted JSON
+// paths, e.g. "game.home_score,home_team.abbreviation,home_team_stats.stats.points".
+// A path selects the whole value at its end; paths through arrays apply to every
+// element. A nil FieldSet selects everything.
+type FieldSet map[string]FieldSet
+
+// ParseFieldSet parses a ?fields= value. An empty value returns nil.
+func ParseFieldSet(raw string) (FieldSet, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	fields := FieldSet{}
+	for _, path := range strings.Split(raw, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		node := fields
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			if part == "" {
+				return nil, fmt.Errorf("%w: empty segment in %q", ErrInvalidFields, path)
+			}
+			if i == len(parts)-1 {
+				// Selecting a whole value overrides any narrower selection under it
+				node[part] = nil
+				break
+			}
+
+			child, seen := node[part]
+			if seen && child == nil {
+				// Already selected in full; the narrower path adds nothing
+				break
+			}
+			if !seen {
+				child = FieldSet{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// Project returns v reduced to the selected fields, as generic JSON values ready
+// to marshal. Fields missing from v are ignored. With a nil FieldSet v is returned as-is.
+func (f FieldSet) Project(v interface{}) (interface{}, error) {
+	if f == nil {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling for projection: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("decoding for projection: %w", err)
+	}
+
+	return f.project(generic), nil
+}
+
+func (f FieldSet) project(v interface{}) interface{} {
+	switch val := v.(type) {
+	case []interface{}:
+		for i, item := range val {
+			val[i] = f.project(item)
+		}
+		return val
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(f))
+		for key, sub := range f {
+			child, ok := val[key]
+			if !ok {
+				continue
+			}
+			if sub == nil {
+				out[key] = child
+			} else {
+				out[key] = sub.project(child)
+			}
+		}
+		return out
+	default:
+		// A scalar or null where the selection expected an object
+		return val
+	}
+}
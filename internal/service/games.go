@@ -2,54 +2,301 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fortuna/minerva/internal/store"
 	"github.com/fortuna/minerva/internal/store/repository"
 )
 
+// Game resolution errors
+var (
+	ErrUnknownIDSource = errors.New("unknown game id source")
+	ErrGameNotResolved = fmt.Errorf("game %w", store.ErrNotFound)
+)
+
 // GameService handles game-related business logic
 type GameService struct {
-	gameRepo *repository.GameRepository
-	teamRepo *repository.TeamRepository
+	gameRepo    *repository.GameRepository
+	teamRepo    *repository.TeamRepository
+	seriesRepo  *repository.PlayoffSeriesRepository
+	updateRepo  *repository.GameUpdateRepository
+	highlights  *repository.HighlightRepository
+	starters    *repository.StarterRepository
+	externalIDs *repository.ExternalIDRepository
+	ratings     *RatingService
 }
 
 // NewGameService creates a new game service
 func NewGameService(db *store.Database) *GameService {
 	return &GameService{
-		gameRepo: repository.NewGameRepository(db),
-		teamRepo: repository.NewTeamRepository(db),
+		gameRepo:    repository.NewGameRepository(db),
+		teamRepo:    repository.NewTeamRepository(db),
+		seriesRepo:  repository.NewPlayoffSeriesRepository(db),
+		updateRepo:  repository.NewGameUpdateRepository(db),
+		highlights:  repository.NewHighlightRepository(db),
+		starters:    repository.NewStarterRepository(db),
+		externalIDs: repository.NewExternalIDRepository(db),
+		ratings:     NewRatingService(db),
 	}
 }
 
-// GetGame retrieves a game by ID with team details
+// GetGame retrieves a game by ID with team details, pregame Elo ratings, and
+// starting lineups
 func (s *GameService) GetGame(ctx context.Context, gameID string) (*GameSummary, error) {
 	game, err := s.gameRepo.GetByExternalID(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching game: %w", err)
 	}
+	summary, err := s.summarize(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.Ratings, err = s.ratings.GetGameRatings(ctx, game); err != nil {
+		return nil, fmt.Errorf("fetching game ratings: %w", err)
+	}
+	if summary.Starters, err = s.getLineups(ctx, game); err != nil {
+		return nil, fmt.Errorf("fetching starting lineups: %w", err)
+	}
+	return summary, nil
+}
+
+// getLineups splits a game's stored starters by side; nil when none are stored
+func (s *GameService) getLineups(ctx context.Context, game *store.Game) (*GameLineups, error) {
+	starters, err := s.starters.GetByGame(ctx, game.GameID)
+	if err != nil || len(starters) == 0 {
+		return nil, err
+	}
 
-	homeTeam, err := s.teamRepo.GetByID(ctx, game.HomeTeamID)
+	lineups := &GameLineups{Home: []*store.GameStarter{}, Away: []*store.GameStarter{}}
+	for _, starter := range starters {
+		switch starter.TeamID {
+		case game.HomeTeamID:
+			lineups.Home = append(lineups.Home, starter)
+		case game.AwayTeamID:
+			lineups.Away = append(lineups.Away, starter)
+		}
+	}
+	lineups.Confirmed = len(lineups.Home) == 5 && len(lineups.Away) == 5
+	return lineups, nil
+}
+
+// summarize adds team details and playoff series info to a game
+func (s *GameService) summarize(ctx context.Context, game *store.Game) (*GameSummary, error) {
+	homeTeam, err := cachedTeam(ctx, s.teamRepo, game.HomeTeamID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching home team: %w", err)
 	}
 
-	awayTeam, err := s.teamRepo.GetByID(ctx, game.AwayTeamID)
+	awayTeam, err := cachedTeam(ctx, s.teamRepo, game.AwayTeamID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching away team: %w", err)
 	}
 
+	playoff, err := s.seriesRepo.GetGameInfo(ctx, []int{game.GameID})
+	if err != nil {
+		return nil, fmt.Errorf("fetching playoff info: %w", err)
+	}
+
 	return &GameSummary{
 		Game:     game,
 		HomeTeam: homeTeam,
 		AwayTeam: awayTeam,
+		Playoff:  playoff[game.GameID],
+	}, nil
+}
+
+// GetGameChanges folds a game's logged updates after since into a single diff
+func (s *GameService) GetGameChanges(ctx context.Context, gameID string, since time.Time) (*GameChanges, error) {
+	game, err := s.gameRepo.GetByExternalID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching game: %w", err)
+	}
+
+	updates, err := s.updateRepo.ListSince(ctx, game.GameID, since)
+	if err != nil {
+		return nil, fmt.Errorf("fetching game updates: %w", err)
+	}
+
+	changes := &GameChanges{
+		GameID:  game.ExternalID,
+		Sport:   game.Sport,
+		Since:   since,
+		AsOf:    since,
+		Updates: len(updates),
+		Fields:  map[string]store.FieldChange{},
+		Stats:   []store.StatDelta{},
+	}
+
+	stats := map[int]*store.StatDelta{}
+	var order []int
+	for _, update := range updates {
+		changes.AsOf = update.ChangedAt
+		for name, change := range update.Fields {
+			if first, ok := changes.Fields[name]; ok {
+				change.From = first.From
+			}
+			changes.Fields[name] = change
+		}
+		for _, delta := range update.Stats {
+			total, ok := stats[delta.PlayerID]
+			if !ok {
+				total = &store.StatDelta{PlayerID: delta.PlayerID, TeamID: delta.TeamID, Deltas: map[string]float64{}}
+				stats[delta.PlayerID] = total
+				order = append(order, delta.PlayerID)
+			}
+			for name, value := range delta.Deltas {
+				total.Deltas[name] += value
+			}
+		}
+	}
+
+	// Changes that cancelled out (a score corrected back, a stat reversed) are dropped
+	for name, change := range changes.Fields {
+		if change.From == change.To {
+			delete(changes.Fields, name)
+		}
+	}
+	for _, playerID := range order {
+		total := stats[playerID]
+		for name, value := range total.Deltas {
+			if value == 0 {
+				delete(total.Deltas, name)
+			}
+		}
+		if len(total.Deltas) > 0 {
+			changes.Stats = append(changes.Stats, *total)
+		}
+	}
+
+	return changes, nil
+}
+
+// GameResolution is the canonical game behind one source's identifier, with
+// every identifier known for it
+type GameResolution struct {
+	Source      string              `json:"source"`
+	ID          string              `json:"id"`
+	Game        *GameSummary        `json:"game"`
+	Identifiers map[string][]string `json:"identifiers"` // source -> IDs, including minerva
+}
+
+// ResolveGame finds the game source knows as id. Sources are minerva (game_id),
+// google (synthetic google_ IDs), or an external_ids source (espn, alexandria,
+// nba_stats, basketball_reference). ESPN IDs missing from external_ids fall
+// back to the game's own external_id.
+func (s *GameService) ResolveGame(ctx context.Context, source, id string) (*GameResolution, error) {
+	id = strings.TrimSpace(id)
+
+	var game *store.Game
+	var err error
+	switch source {
+	case store.SourceMinerva:
+		gameID, convErr := strconv.Atoi(id)
+		if convErr != nil {
+			return nil, fmt.Errorf("%w: %s %q", ErrGameNotResolved, source, id)
+		}
+		game, err = s.gameRepo.GetByID(ctx, gameID)
+	case store.SourceGoogle:
+		game, err = s.gameRepo.GetByExternalID(ctx, id)
+	case store.SourceESPN, store.SourceAlexandria, store.SourceNBAStats, store.SourceBasketballReference:
+		gameID, ok, resolveErr := s.externalIDs.Resolve(ctx, store.EntityGame, source, id)
+		switch {
+		case resolveErr != nil:
+			return nil, resolveErr
+		case ok:
+			game, err = s.gameRepo.GetByID(ctx, gameID)
+		case source == store.SourceESPN:
+			game, err = s.gameRepo.GetByExternalID(ctx, id)
+		default:
+			return nil, fmt.Errorf("%w: %s %s", ErrGameNotResolved, source, id)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownIDSource, source)
+	}
+	if store.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s %s", ErrGameNotResolved, source, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching game: %w", err)
+	}
+
+	summary, err := s.summarize(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := s.externalIDs.ListForEntity(ctx, store.EntityGame, game.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching game identifiers: %w", err)
+	}
+	identifiers := map[string][]string{store.SourceMinerva: {strconv.Itoa(game.GameID)}}
+	for _, m := range mappings {
+		identifiers[m.Source] = append(identifiers[m.Source], m.SourceID)
+	}
+	ownSource := store.SourceESPN
+	if strings.HasPrefix(game.ExternalID, store.SourceGoogle+"_") {
+		ownSource = store.SourceGoogle
+	}
+	if !containsString(identifiers[ownSource], game.ExternalID) {
+		identifiers[ownSource] = append([]string{game.ExternalID}, identifiers[ownSource]...)
+	}
+
+	return &GameResolution{
+		Source:      source,
+		ID:          id,
+		Game:        summary,
+		Identifiers: identifiers,
+	}, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// GameHighlights lists a game's ESPN video clips, linked clips first in play order
+type GameHighlights struct {
+	GameID     string                 `json:"game_id"`
+	Sport      string                 `json:"sport"`
+	Count      int                    `json:"count"`
+	Highlights []*store.GameHighlight `json:"highlights"`
+}
+
+// GetGameHighlights returns the stored video highlights for a game by external (ESPN) ID
+func (s *GameService) GetGameHighlights(ctx context.Context, gameID string) (*GameHighlights, error) {
+	game, err := s.gameRepo.GetByExternalID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching game: %w", err)
+	}
+
+	highlights, err := s.highlights.GetByGame(ctx, game.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching highlights: %w", err)
+	}
+	if highlights == nil {
+		highlights = []*store.GameHighlight{}
+	}
+
+	return &GameHighlights{
+		GameID:     game.ExternalID,
+		Sport:      game.Sport,
+		Count:      len(highlights),
+		Highlights: highlights,
 	}, nil
 }
 
 // GetLiveGames retrieves all currently live games
-func (s *GameService) GetLiveGames(ctx context.Context) ([]*GameSummary, error) {
-	games, err := s.gameRepo.GetLiveGames(ctx)
+func (s *GameService) GetLiveGames(ctx context.Context, sport string, leagues, gameTypes []string) ([]*GameSummary, error) {
+	games, err := s.gameRepo.GetLiveGames(ctx, sport, leagues, gameTypes)
 	if err != nil {
 		return nil, fmt.Errorf("fetching live games: %w", err)
 	}
@@ -58,8 +305,8 @@ func (s *GameService) GetLiveGames(ctx context.Context) ([]*GameSummary, error)
 }
 
 // GetGamesByDate retrieves all games on a specific date
-func (s *GameService) GetGamesByDate(ctx context.Context, date time.Time) ([]*GameSummary, error) {
-	games, err := s.gameRepo.GetByDate(ctx, date)
+func (s *GameService) GetGamesByDate(ctx context.Context, sport string, date time.Time, leagues, gameTypes []string) ([]*GameSummary, error) {
+	games, err := s.gameRepo.GetByDate(ctx, sport, date, leagues, gameTypes)
 	if err != nil {
 		return nil, fmt.Errorf("fetching games by date: %w", err)
 	}
@@ -68,8 +315,8 @@ func (s *GameService) GetGamesByDate(ctx context.Context, date time.Time) ([]*Ga
 }
 
 // GetUpcomingGames retrieves upcoming scheduled games
-func (s *GameService) GetUpcomingGames(ctx context.Context, limit int) ([]*GameSummary, error) {
-	games, err := s.gameRepo.GetUpcomingGames(ctx, limit)
+func (s *GameService) GetUpcomingGames(ctx context.Context, sport string, limit int, leagues, gameTypes []string) ([]*GameSummary, error) {
+	games, err := s.gameRepo.GetUpcomingGames(ctx, sport, limit, leagues, gameTypes)
 	if err != nil {
 		return nil, fmt.Errorf("fetching upcoming games: %w", err)
 	}
@@ -78,8 +325,8 @@ func (s *GameService) GetUpcomingGames(ctx context.Context, limit int) ([]*GameS
 }
 
 // GetTodaysGames retrieves all games for today (live, scheduled, and final)
-func (s *GameService) GetTodaysGames(ctx context.Context) ([]*GameSummary, error) {
-	games, err := s.gameRepo.GetTodaysGames(ctx)
+func (s *GameService) GetTodaysGames(ctx context.Context, sport string, leagues, gameTypes []string) ([]*GameSummary, error) {
+	games, err := s.gameRepo.GetTodaysGames(ctx, sport, leagues, gameTypes)
 	if err != nil {
 		return nil, fmt.Errorf("fetching today's games: %w", err)
 	}
@@ -88,8 +335,8 @@ func (s *GameService) GetTodaysGames(ctx context.Context) ([]*GameSummary, error
 }
 
 // GetTeamSchedule retrieves games for a specific team
-func (s *GameService) GetTeamSchedule(ctx context.Context, teamID int, seasonID int, limit int) ([]*GameSummary, error) {
-	games, err := s.gameRepo.GetByTeam(ctx, teamID, seasonID, limit)
+func (s *GameService) GetTeamSchedule(ctx context.Context, teamID int, seasonID int, limit int, gameTypes []string) ([]*GameSummary, error) {
+	games, err := s.gameRepo.GetByTeam(ctx, teamID, seasonID, limit, gameTypes)
 	if err != nil {
 		return nil, fmt.Errorf("fetching team schedule: %w", err)
 	}
@@ -110,13 +357,22 @@ func (s *GameService) CleanupStaleGames(ctx context.Context) (int64, error) {
 func (s *GameService) enrichGamesWithTeams(ctx context.Context, games []*store.Game) ([]*GameSummary, error) {
 	summaries := make([]*GameSummary, 0, len(games))
 
+	gameIDs := make([]int, 0, len(games))
 	for _, game := range games {
-		homeTeam, err := s.teamRepo.GetByID(ctx, game.HomeTeamID)
+		gameIDs = append(gameIDs, game.GameID)
+	}
+	playoff, err := s.seriesRepo.GetGameInfo(ctx, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("fetching playoff info: %w", err)
+	}
+
+	for _, game := range games {
+		homeTeam, err := cachedTeam(ctx, s.teamRepo, game.HomeTeamID)
 		if err != nil {
 			return nil, fmt.Errorf("fetching home team for game %s: %w", game.GameID, err)
 		}
 
-		awayTeam, err := s.teamRepo.GetByID(ctx, game.AwayTeamID)
+		awayTeam, err := cachedTeam(ctx, s.teamRepo, game.AwayTeamID)
 		if err != nil {
 			return nil, fmt.Errorf("fetching away team for game %s: %w", game.GameID, err)
 		}
@@ -125,16 +381,40 @@ func (s *GameService) enrichGamesWithTeams(ctx context.Context, games []*store.G
 			Game:     game,
 			HomeTeam: homeTeam,
 			AwayTeam: awayTeam,
+			Playoff:  playoff[game.GameID],
 		})
 	}
 
 	return summaries, nil
 }
 
+// GameChanges is what changed in a game between Since and AsOf. Clients poll
+// again with ?since= set to AsOf.
+type GameChanges struct {
+	GameID  string                       `json:"game_id"`
+	Sport   string                       `json:"sport"`
+	Since   time.Time                    `json:"since"`
+	AsOf    time.Time                    `json:"as_of"`
+	Updates int                          `json:"updates"` // log entries folded into this diff
+	Fields  map[string]store.FieldChange `json:"fields"`  // score, status, period, clock: first from, last to
+	Stats   []store.StatDelta            `json:"stats"`   // summed per player
+}
+
 // GameSummary contains game details with team information
 type GameSummary struct {
-	Game     *store.Game `json:"game"`
-	HomeTeam *store.Team `json:"home_team"`
-	AwayTeam *store.Team `json:"away_team"`
+	Game     *store.Game            `json:"game"`
+	HomeTeam *store.Team            `json:"home_team"`
+	AwayTeam *store.Team            `json:"away_team"`
+	Playoff  *store.PlayoffGameInfo `json:"playoff,omitempty"` // set for postseason games
+	Ratings  *GameRatings           `json:"ratings,omitempty"` // pregame Elo, on single-game reads of NBA games
+	Starters *GameLineups           `json:"starters,omitempty"` // on single-game reads once ESPN lists starters
+}
+
+// GameLineups holds the starters ESPN listed for each side of a game, in lineup
+// order. Confirmed is set once both sides list five.
+type GameLineups struct {
+	Home      []*store.GameStarter `json:"home"`
+	Away      []*store.GameStarter `json:"away"`
+	Confirmed bool                 `json:"confirmed"`
 }
 
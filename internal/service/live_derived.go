@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// ErrLiveDerivedUnavailable is returned for games that have not tipped off
+var ErrLiveDerivedUnavailable = errors.New("live metrics unavailable before tip-off")
+
+const (
+	regulationPeriods    = 4
+	quarterSeconds       = 12 * 60
+	overtimeSeconds      = 5 * 60
+	liveDerivedMinRun    = recapMinRunPoints
+	liveProjectionMinSec = 60 // game time played before projecting
+)
+
+// LiveDerivedService computes in-game pace, score projections, and scoring runs
+type LiveDerivedService struct {
+	gameRepo  *repository.GameRepository
+	statsRepo *repository.StatsRepository
+	playRepo  *repository.PlayRepository
+}
+
+// NewLiveDerivedService creates a new live derived metrics service
+func NewLiveDerivedService(db *store.Database) *LiveDerivedService {
+	return &LiveDerivedService{
+		gameRepo:  repository.NewGameRepository(db),
+		statsRepo: repository.NewStatsRepository(db),
+		playRepo:  repository.NewPlayRepository(db),
+	}
+}
+
+// LiveDerived is the games.live.derived payload: metrics computed from a live
+// game's score, clock, box score, and play-by-play. Projections extrapolate each
+// team's scoring so far over the regulation time left (or the current overtime),
+// and are null until a minute has been played.
+type LiveDerived struct {
+	GameID             int           `json:"game_id"`
+	ExternalID         string        `json:"external_id"`
+	Sport              string        `json:"sport"`
+	Status             string        `json:"status"`
+	HomeTeamID         int           `json:"home_team_id"`
+	AwayTeamID         int           `json:"away_team_id"`
+	HomeScore          int           `json:"home_score"`
+	AwayScore          int           `json:"away_score"`
+	Period             int           `json:"period"`
+	Clock              string        `json:"clock,omitempty"`
+	ElapsedSeconds     int           `json:"elapsed_seconds"`
+	RemainingSeconds   int           `json:"remaining_seconds"`
+	Possessions        *float64      `json:"possessions"` // per team, estimated from the box score
+	Pace               *float64      `json:"pace"`        // possessions per 48 minutes
+	PointsPer48        *float64      `json:"points_per_48"`
+	ProjectedHomeScore *float64      `json:"projected_home_score"`
+	ProjectedAwayScore *float64      `json:"projected_away_score"`
+	ProjectedTotal     *float64      `json:"projected_total"`
+	ProjectedMargin    *float64      `json:"projected_margin"` // home minus away
+	CurrentRun         *ScoringRun   `json:"current_run"`
+	Runs               []*ScoringRun `json:"runs"` // runs of 8+ unanswered points, in order
+	ComputedAt         time.Time     `json:"computed_at"`
+}
+
+// GetLiveDerived computes the live metrics for a game by external (ESPN) ID
+func (s *LiveDerivedService) GetLiveDerived(ctx context.Context, gameID string) (*LiveDerived, error) {
+	game, err := s.gameRepo.GetByExternalID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching game: %w", err)
+	}
+	if game.Status != "in_progress" && game.Status != "final" {
+		return nil, ErrLiveDerivedUnavailable
+	}
+	return s.Derive(ctx, game)
+}
+
+// Derive computes the live metrics for a polled game. The score and clock come
+// from game; possessions and runs from what ESPN ingestion has stored, which can
+// trail a Google score by a poll.
+func (s *LiveDerivedService) Derive(ctx context.Context, game *store.Game) (*LiveDerived, error) {
+	derived := &LiveDerived{
+		GameID:     game.GameID,
+		ExternalID: game.ExternalID,
+		Sport:      game.Sport,
+		Status:     game.Status,
+		HomeTeamID: game.HomeTeamID,
+		AwayTeamID: game.AwayTeamID,
+		HomeScore:  int(game.HomeScore.Int32),
+		AwayScore:  int(game.AwayScore.Int32),
+		Period:     int(game.Period.Int32),
+		Clock:      game.Clock.String,
+		Runs:       []*ScoringRun{},
+		ComputedAt: time.Now().UTC(),
+	}
+	derived.ElapsedSeconds, derived.RemainingSeconds = gameTime(game)
+
+	if game.GameID > 0 {
+		teams, err := s.statsRepo.GetTeamStatsByGameID(ctx, game.GameID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching team stats: %w", err)
+		}
+		if len(teams) < 2 {
+			if teams, err = s.statsRepo.SumPlayerStatsByTeam(ctx, game.GameID); err != nil {
+				return nil, fmt.Errorf("summing player stats: %w", err)
+			}
+		}
+		if len(teams) == 2 {
+			var total float64
+			for _, t := range teams {
+				total += possessions(t.FieldGoalsAttempted, t.FreeThrowsAttempted, t.Turnovers, t.OffensiveRebounds)
+			}
+			poss := round1(total / 2)
+			derived.Possessions = &poss
+		}
+
+		plays, err := s.playRepo.GetByGame(ctx, game.GameID, true)
+		if err != nil {
+			return nil, fmt.Errorf("fetching plays: %w", err)
+		}
+		derived.CurrentRun, derived.Runs = liveRuns(game, plays)
+	}
+
+	if derived.ElapsedSeconds >= liveProjectionMinSec {
+		derived.project()
+	}
+	return derived, nil
+}
+
+// project fills pace and the projections from the score and time elapsed
+func (d *LiveDerived) project() {
+	minutes := float64(d.ElapsedSeconds) / 60
+	per48 := round1(float64(d.HomeScore+d.AwayScore) / minutes * 48)
+	d.PointsPer48 = &per48
+	if d.Possessions != nil && *d.Possessions > 0 {
+		pace := round1(*d.Possessions / minutes * 48)
+		d.Pace = &pace
+	}
+
+	// Points per possession times the possessions left at the current pace
+	// reduces to each team's scoring rate over the time left
+	left := float64(d.RemainingSeconds) / float64(d.ElapsedSeconds)
+	home := round1(float64(d.HomeScore) * (1 + left))
+	away := round1(float64(d.AwayScore) * (1 + left))
+	total := round1(home + away)
+	margin := round1(home - away)
+	d.ProjectedHomeScore, d.ProjectedAwayScore = &home, &away
+	d.ProjectedTotal, d.ProjectedMargin = &total, &margin
+}
+
+// gameTime returns the seconds played and the seconds left in regulation, or in
+// the current overtime once regulation is over. A missing clock counts as the
+// end of the period, which is when ESPN blanks it.
+func gameTime(game *store.Game) (elapsed, remaining int) {
+	period := int(game.Period.Int32)
+	if game.Status == "final" {
+		period = max(period, regulationPeriods)
+		return periodStart(period + 1), 0
+	}
+	if period <= 0 {
+		return 0, regulationPeriods * quarterSeconds
+	}
+
+	clock, _ := publisher.ParseClock(game.Clock.String)
+	length := quarterSeconds
+	if period > regulationPeriods {
+		length = overtimeSeconds
+	}
+	clock = min(clock, length)
+
+	elapsed = periodStart(period) + length - clock
+	if period > regulationPeriods {
+		return elapsed, clock
+	}
+	return elapsed, (regulationPeriods-period)*quarterSeconds + clock
+}
+
+// periodStart is the game time at which period begins
+func periodStart(period int) int {
+	if period <= regulationPeriods {
+		return (period - 1) * quarterSeconds
+	}
+	return regulationPeriods*quarterSeconds + (period-regulationPeriods-1)*overtimeSeconds
+}
+
+// liveRuns splits scoring plays into stretches of unanswered points. Unlike
+// recap runs they carry across period breaks, and the stretch still going is
+// returned as the current run whatever its size.
+func liveRuns(game *store.Game, plays []*store.GamePlay) (*ScoringRun, []*ScoringRun) {
+	runs := []*ScoringRun{}
+	var current *ScoringRun
+
+	prevHome, prevAway := 0, 0
+	for _, p := range plays {
+		homePts, awayPts := p.HomeScore-prevHome, p.AwayScore-prevAway
+		prevHome, prevAway = p.HomeScore, p.AwayScore
+		if homePts <= 0 && awayPts <= 0 {
+			continue
+		}
+
+		scorer, points := game.HomeTeamID, homePts
+		if awayPts > 0 {
+			scorer, points = game.AwayTeamID, awayPts
+		}
+
+		clock := p.Clock.String
+		if current != nil && current.TeamID == scorer {
+			current.Points += points
+			current.EndClock = clock
+		} else {
+			if current != nil && current.Points >= liveDerivedMinRun {
+				runs = append(runs, current)
+			}
+			current = &ScoringRun{TeamID: scorer, Points: points, Period: p.Period, StartClock: clock, EndClock: clock}
+		}
+		current.HomeScore, current.AwayScore = p.HomeScore, p.AwayScore
+	}
+	if current == nil {
+		return nil, runs
+	}
+
+	if current.Points >= liveDerivedMinRun {
+		runs = append(runs, current)
+	}
+	for _, run := range append(runs, current) {
+		run.Description = fmt.Sprintf("%d-0 run since %s in %s", run.Points, run.StartClock, periodLabel(run.Period))
+	}
+	return current, runs
+}
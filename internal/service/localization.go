@@ -0,0 +1,367 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// ErrInvalidLocale is returned for a locale that is not a language tag
+var ErrInvalidLocale = errors.New("locale must be a language tag such as es or es-MX")
+
+// ErrInvalidTranslation is returned for a translation missing its name or
+// carrying fields its entity type doesn't have
+var ErrInvalidTranslation = errors.New("invalid translation")
+
+// translationTTL bounds how long translations written by other replicas take
+// to reach this one
+const translationTTL = 5 * time.Minute
+
+// localePattern is a BCP 47 tag in the shape clients send: a 2-3 letter
+// language followed by optional script, region, or variant subtags
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{1,8})*$`)
+
+// NormalizeLocale lowercases a language tag and accepts '_' as a separator
+// ("es_MX" becomes "es-mx")
+func NormalizeLocale(tag string) (string, error) {
+	locale := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if len(locale) > 35 || !localePattern.MatchString(locale) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidLocale, tag)
+	}
+	return locale, nil
+}
+
+// Localizer rewrites team and player names in API responses using the
+// translations stored for a locale. Every translation is held in memory,
+// reloaded after translationTTL and whenever this process changes one.
+type Localizer struct {
+	translations *repository.TranslationRepository
+	teamRepo     *repository.TeamRepository
+	playerRepo   *repository.PlayerRepository
+
+	mu       sync.Mutex
+	locales  map[string]*localeNames
+	loadedAt time.Time
+}
+
+// localeNames are one locale's translations by entity ID
+type localeNames struct {
+	teams   map[int]*store.NameTranslation
+	players map[int]*store.NameTranslation
+}
+
+// NewLocalizer creates a new localizer
+func NewLocalizer(db *store.Database) *Localizer {
+	return &Localizer{
+		translations: repository.NewTranslationRepository(db),
+		teamRepo:     repository.NewTeamRepository(db),
+		playerRepo:   repository.NewPlayerRepository(db),
+	}
+}
+
+// snapshot returns every locale's translations, reloading them once stale
+func (l *Localizer) snapshot(ctx context.Context) (map[string]*localeNames, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.locales != nil && time.Since(l.loadedAt) < translationTTL {
+		return l.locales, nil
+	}
+
+	all, err := l.translations.List(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	locales := make(map[string]*localeNames)
+	for _, t := range all {
+		names := locales[t.Locale]
+		if names == nil {
+			names = &localeNames{
+				teams:   make(map[int]*store.NameTranslation),
+				players: make(map[int]*store.NameTranslation),
+			}
+			locales[t.Locale] = names
+		}
+		switch t.EntityType {
+		case store.EntityTeam:
+			names.teams[t.EntityID] = t
+		case store.EntityPlayer:
+			names.players[t.EntityID] = t
+		}
+	}
+
+	l.locales = locales
+	l.loadedAt = time.Now()
+	return locales, nil
+}
+
+// invalidate makes the next request reload translations
+func (l *Localizer) invalidate() {
+	l.mu.Lock()
+	l.locales = nil
+	l.mu.Unlock()
+}
+
+// Negotiate picks the locale to answer in from an Accept-Language header (or a
+// single tag). Tags are tried by descending quality; each matches a locale with
+// translations exactly or, failing that, by its base language ("es-ar" falls
+// back to "es"). It returns "" when nothing matches.
+func (l *Localizer) Negotiate(ctx context.Context, acceptLanguage string) (string, error) {
+	tags := parseAcceptLanguage(acceptLanguage)
+	if len(tags) == 0 {
+		return "", nil
+	}
+
+	locales, err := l.snapshot(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(locales) == 0 {
+		return "", nil
+	}
+
+	for _, tag := range tags {
+		if _, ok := locales[tag]; ok {
+			return tag, nil
+		}
+		if base, _, found := strings.Cut(tag, "-"); found {
+			if _, ok := locales[base]; ok {
+				return base, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// parseAcceptLanguage returns the normalized tags of an Accept-Language value,
+// highest quality first. Wildcards, malformed tags, and q=0 are dropped.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		locale, err := NormalizeLocale(tag)
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: locale, quality: quality})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	out := make([]string, len(tags))
+	for i, t := range tags {
+		out[i] = t.tag
+	}
+	return out
+}
+
+// Localize returns v as generic JSON values with team and player names
+// replaced by their translations in locale. Objects are matched by their
+// player_id or, lacking one, team_id member:
+//
+//   - players: full_name, and display_name and name (the translated short
+//     name, or full name without one)
+//   - teams: full_name, short_name, city, and name (the short name when the
+//     object carried the team's short name, otherwise the full name)
+//
+// Names without a translation are left as they are.
+func (l *Localizer) Localize(ctx context.Context, locale string, v interface{}) (interface{}, error) {
+	locales, err := l.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := locales[locale]
+	if names == nil {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling for localization: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("decoding for localization: %w", err)
+	}
+
+	l.localize(ctx, names, generic)
+	return generic, nil
+}
+
+func (l *Localizer) localize(ctx context.Context, names *localeNames, v interface{}) {
+	switch val := v.(type) {
+	case []interface{}:
+		for _, item := range val {
+			l.localize(ctx, names, item)
+		}
+	case map[string]interface{}:
+		for _, child := range val {
+			l.localize(ctx, names, child)
+		}
+
+		if id, ok := jsonID(val["player_id"]); ok {
+			if t := names.players[id]; t != nil {
+				short := translatedShortName(t)
+				replaceName(val, "full_name", t.FullName)
+				replaceName(val, "display_name", short)
+				replaceName(val, "name", short)
+			}
+		} else if id, ok := jsonID(val["team_id"]); ok {
+			if t := names.teams[id]; t != nil {
+				l.localizeTeam(ctx, val, id, t)
+			}
+		}
+	}
+}
+
+func (l *Localizer) localizeTeam(ctx context.Context, obj map[string]interface{}, teamID int, t *store.NameTranslation) {
+	replaceName(obj, "full_name", t.FullName)
+	if t.ShortName.Valid {
+		replaceName(obj, "short_name", t.ShortName.String)
+	}
+	if t.City.Valid {
+		replaceName(obj, "city", t.City.String)
+	}
+
+	name, ok := obj["name"].(string)
+	if !ok {
+		return
+	}
+	// Summaries label teams by either name; keep the one they used
+	if t.ShortName.Valid {
+		if team, err := cachedTeam(ctx, l.teamRepo, teamID); err == nil && name == team.ShortName {
+			obj["name"] = t.ShortName.String
+			return
+		}
+	}
+	obj["name"] = t.FullName
+}
+
+// translatedShortName is a player's translated display name, or full name
+func translatedShortName(t *store.NameTranslation) string {
+	if t.ShortName.Valid && t.ShortName.String != "" {
+		return t.ShortName.String
+	}
+	return t.FullName
+}
+
+// replaceName sets obj[key] to name when obj has a string there
+func replaceName(obj map[string]interface{}, key, name string) {
+	if _, ok := obj[key].(string); ok && name != "" {
+		obj[key] = name
+	}
+}
+
+// jsonID reads an integer ID from a generic JSON value
+func jsonID(v interface{}) (int, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	id, err := n.Int64()
+	if err != nil {
+		return 0, false
+	}
+	return int(id), true
+}
+
+// ListTranslations returns stored translations, optionally narrowed to one
+// locale and entity type
+func (l *Localizer) ListTranslations(ctx context.Context, locale, entityType string) ([]*store.NameTranslation, error) {
+	if locale != "" {
+		normalized, err := NormalizeLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		locale = normalized
+	}
+	switch entityType {
+	case "", store.EntityTeam, store.EntityPlayer:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidEntity, entityType)
+	}
+	return l.translations.List(ctx, locale, entityType)
+}
+
+// PutTranslation stores a team or player's name in a locale, replacing any it
+// had. The entity must exist; an unknown one is a store.ErrNotFound.
+func (l *Localizer) PutTranslation(ctx context.Context, t *store.NameTranslation) error {
+	locale, err := NormalizeLocale(t.Locale)
+	if err != nil {
+		return err
+	}
+	t.Locale = locale
+
+	t.FullName = strings.TrimSpace(t.FullName)
+	if t.FullName == "" {
+		return fmt.Errorf("%w: full_name is required", ErrInvalidTranslation)
+	}
+
+	switch t.EntityType {
+	case store.EntityTeam:
+		_, err = l.teamRepo.GetByID(ctx, t.EntityID)
+	case store.EntityPlayer:
+		if t.City.Valid {
+			return fmt.Errorf("%w: city applies to teams only", ErrInvalidTranslation)
+		}
+		_, err = l.playerRepo.GetByID(ctx, t.EntityID)
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidEntity, t.EntityType)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := l.translations.Upsert(ctx, t); err != nil {
+		return err
+	}
+	l.invalidate()
+	return nil
+}
+
+// DeleteTranslation removes a team or player's name in a locale
+func (l *Localizer) DeleteTranslation(ctx context.Context, entityType string, entityID int, locale string) error {
+	normalized, err := NormalizeLocale(locale)
+	if err != nil {
+		return err
+	}
+	if entityType != store.EntityTeam && entityType != store.EntityPlayer {
+		return fmt.Errorf("%w: %q", ErrInvalidEntity, entityType)
+	}
+
+	if err := l.translations.Delete(ctx, entityType, entityID, normalized); err != nil {
+		return err
+	}
+	l.invalidate()
+	return nil
+}
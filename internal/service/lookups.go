@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fortuna/minerva/internal/cache"
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// LookupCacheConfig sizes the in-process player and team cache shared by every
+// service. A Size of zero disables it.
+type LookupCacheConfig struct {
+	Size int           // entries per entity type
+	TTL  time.Duration // bounds staleness from writes made by other replicas
+}
+
+// DefaultLookupCacheConfig covers every active player and team for ten minutes
+func DefaultLookupCacheConfig() LookupCacheConfig {
+	return LookupCacheConfig{
+		Size: 5000,
+		TTL:  10 * time.Minute,
+	}
+}
+
+// lookupCache holds player and team records by ID. Cached records are shared
+// between requests and must not be modified.
+type lookupCache struct {
+	players *cache.LRU[int, *store.Player]
+	teams   *cache.LRU[int, *store.Team]
+}
+
+var (
+	lookupsMu     sync.RWMutex
+	lookups       = newLookupCache(DefaultLookupCacheConfig())
+	registerHooks sync.Once
+)
+
+func newLookupCache(config LookupCacheConfig) *lookupCache {
+	if config.Size <= 0 {
+		return nil
+	}
+	return &lookupCache{
+		players: cache.NewLRU[int, *store.Player](config.Size, config.TTL),
+		teams:   cache.NewLRU[int, *store.Team](config.Size, config.TTL),
+	}
+}
+
+// ConfigureLookupCache replaces the shared player and team cache. Call it at
+// startup before serving requests.
+func ConfigureLookupCache(config LookupCacheConfig) {
+	lookupsMu.Lock()
+	lookups = newLookupCache(config)
+	lookupsMu.Unlock()
+}
+
+// sharedLookups returns the current cache (nil when disabled), registering the
+// write hook that evicts players and teams as repositories update them
+func sharedLookups() *lookupCache {
+	registerHooks.Do(func() {
+		repository.OnEntityWrite(func(entityType string, id int) {
+			c := sharedLookups()
+			if c == nil {
+				return
+			}
+			switch entityType {
+			case store.EntityPlayer:
+				c.players.Delete(id)
+			case store.EntityTeam:
+				c.teams.Delete(id)
+			}
+		})
+	})
+
+	lookupsMu.RLock()
+	defer lookupsMu.RUnlock()
+	return lookups
+}
+
+// cachedPlayer returns a player by ID, reading through the shared cache
+func cachedPlayer(ctx context.Context, repo *repository.PlayerRepository, playerID int) (*store.Player, error) {
+	c := sharedLookups()
+	if c == nil {
+		return repo.GetByID(ctx, playerID)
+	}
+	if player, ok := c.players.Get(playerID); ok {
+		lookupRequests.WithLabelValues(store.EntityPlayer, "hit").Inc()
+		return player, nil
+	}
+	lookupRequests.WithLabelValues(store.EntityPlayer, "miss").Inc()
+
+	player, err := repo.GetByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	c.players.Set(playerID, player)
+	return player, nil
+}
+
+// cachedTeam returns a team by ID, reading through the shared cache
+func cachedTeam(ctx context.Context, repo *repository.TeamRepository, teamID int) (*store.Team, error) {
+	c := sharedLookups()
+	if c == nil {
+		return repo.GetByID(ctx, teamID)
+	}
+	if team, ok := c.teams.Get(teamID); ok {
+		lookupRequests.WithLabelValues(store.EntityTeam, "hit").Inc()
+		return team, nil
+	}
+	lookupRequests.WithLabelValues(store.EntityTeam, "miss").Inc()
+
+	team, err := repo.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	c.teams.Set(teamID, team)
+	return team, nil
+}
+
+// cachedPlayers returns players by ID, serving hits from the shared cache and
+// loading every miss in one query. Missing players are absent from the map.
+func cachedPlayers(ctx context.Context, repo *repository.PlayerRepository, playerIDs []int) (map[int]*store.Player, error) {
+	c := sharedLookups()
+	if c == nil {
+		return repo.GetByIDs(ctx, playerIDs)
+	}
+
+	players := make(map[int]*store.Player, len(playerIDs))
+	var misses []int
+	for _, id := range playerIDs {
+		if player, ok := c.players.Get(id); ok {
+			players[id] = player
+			continue
+		}
+		misses = append(misses, id)
+	}
+	lookupRequests.WithLabelValues(store.EntityPlayer, "hit").Add(float64(len(players)))
+	lookupRequests.WithLabelValues(store.EntityPlayer, "miss").Add(float64(len(misses)))
+
+	loaded, err := repo.GetByIDs(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	for id, player := range loaded {
+		c.players.Set(id, player)
+		players[id] = player
+	}
+	return players, nil
+}
@@ -0,0 +1,9 @@
+package service
+
+import "github.com/fortuna/minerva/internal/metrics"
+
+var lookupRequests = metrics.NewCounterVec("minerva_lookup_cache_requests_total",
+	"Player and team lookups served from the in-process cache (hit) or the database (miss)", "entity", "result")
+
+var seasonAverageRequests = metrics.NewCounterVec("minerva_season_average_cache_requests_total",
+	"Player season averages served from Redis (hit), computed (miss), or computed after a cache error (error)", "result")
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// NewsService reads stored ESPN news article metadata
+type NewsService struct {
+	newsRepo *repository.NewsRepository
+	teamRepo *repository.TeamRepository
+}
+
+// NewNewsService creates a new news service
+func NewNewsService(db *store.Database) *NewsService {
+	return &NewsService{
+		newsRepo: repository.NewNewsRepository(db),
+		teamRepo: repository.NewTeamRepository(db),
+	}
+}
+
+// TeamNews returns the newest articles tagged with a team
+func (s *NewsService) TeamNews(ctx context.Context, sport string, teamID, limit int) ([]*store.NewsArticle, error) {
+	if _, err := cachedTeam(ctx, s.teamRepo, teamID); err != nil {
+		return nil, fmt.Errorf("%w: %d (%v)", ErrTeamNotFound, teamID, err)
+	}
+
+	articles, err := s.newsRepo.ListByTeam(ctx, sport, teamID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if articles == nil {
+		articles = []*store.NewsArticle{}
+	}
+	return articles, nil
+}
+
+// PlayerNews returns the newest articles tagged with a player
+func (s *NewsService) PlayerNews(ctx context.Context, sport string, playerID, limit int) ([]*store.NewsArticle, error) {
+	articles, err := s.newsRepo.ListByPlayer(ctx, sport, playerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if articles == nil {
+		articles = []*store.NewsArticle{}
+	}
+	return articles, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// Stat normalization bases accepted by ?per=
+const (
+	PerGame = "game"
+	Per36   = "per36"
+	Per100  = "per100"
+)
+
+// ErrInvalidPer is returned for an unknown ?per= value
+var ErrInvalidPer = errors.New("per must be one of game, per36, per100")
+
+// ParsePer validates a ?per= value; empty means PerGame
+func ParsePer(raw string) (string, error) {
+	switch per := strings.ToLower(strings.TrimSpace(raw)); per {
+	case "":
+		return PerGame, nil
+	case PerGame, Per36, Per100:
+		return per, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidPer, raw)
+	}
+}
+
+// NormalizeStats scales totals to per: per game, per 36 minutes played, or per
+// 100 on-court possessions. Per-36 and per-100 rates use the time and possessions
+// actually played, so overtime games don't inflate them. It returns nil when the
+// basis is zero (no games, no recorded seconds, or no possession estimates).
+func NormalizeStats(per string, totals *store.StatTotals) *store.StatRates {
+	var basis float64
+	switch per {
+	case PerGame:
+		basis = float64(totals.Games)
+	case Per36:
+		basis = float64(totals.Seconds) / (36 * 60)
+	case Per100:
+		basis = totals.Possessions / 100
+	}
+	if basis <= 0 {
+		return nil
+	}
+
+	rate := func(n int) float64 { return round1(float64(n) / basis) }
+	return &store.StatRates{
+		Per:                 per,
+		Points:              rate(totals.Points),
+		Rebounds:            rate(totals.Rebounds),
+		OffensiveRebounds:   rate(totals.OffensiveRebounds),
+		DefensiveRebounds:   rate(totals.DefensiveRebounds),
+		Assists:             rate(totals.Assists),
+		Steals:              rate(totals.Steals),
+		Blocks:              rate(totals.Blocks),
+		Turnovers:           rate(totals.Turnovers),
+		ThreePointersMade:   rate(totals.ThreePointersMade),
+		FieldGoalsMade:      rate(totals.FieldGoalsMade),
+		FieldGoalsAttempted: rate(totals.FieldGoalsAtt),
+		FreeThrowsMade:      rate(totals.FreeThrowsMade),
+		FreeThrowsAttempted: rate(totals.FreeThrowsAtt),
+	}
+}
+
+// gameTotals wraps one stat line as totals for NormalizeStats
+func gameTotals(stats *store.PlayerGameStats, possessions store.NullFloat64) *store.StatTotals {
+	return &store.StatTotals{
+		Games:             1,
+		Seconds:           int64(stats.SecondsPlayed.Int32),
+		Possessions:       possessions.Float64,
+		Points:            stats.Points,
+		Rebounds:          stats.Rebounds,
+		OffensiveRebounds: stats.OffensiveRebounds,
+		DefensiveRebounds: stats.DefensiveRebounds,
+		Assists:           stats.Assists,
+		Steals:            stats.Steals,
+		Blocks:            stats.Blocks,
+		Turnovers:         stats.Turnovers,
+		ThreePointersMade: stats.ThreePointersMade,
+		ThreePointersAtt:  stats.ThreePointersAttempted,
+		FieldGoalsMade:    stats.FieldGoalsMade,
+		FieldGoalsAtt:     stats.FieldGoalsAttempted,
+		FreeThrowsMade:    stats.FreeThrowsMade,
+		FreeThrowsAtt:     stats.FreeThrowsAttempted,
+	}
+}
@@ -13,6 +13,8 @@ type PlayerService struct {
 	playerRepo *repository.PlayerRepository
 	statsRepo  *repository.StatsRepository
 	teamRepo   *repository.TeamRepository
+
+	milestoneRepo *repository.MilestoneRepository
 }
 
 // NewPlayerService creates a new player service
@@ -21,12 +23,14 @@ func NewPlayerService(db *store.Database) *PlayerService {
 		playerRepo: repository.NewPlayerRepository(db),
 		statsRepo:  repository.NewStatsRepository(db),
 		teamRepo:   repository.NewTeamRepository(db),
+
+		milestoneRepo: repository.NewMilestoneRepository(db),
 	}
 }
 
 // GetPlayer retrieves a player by ID with team details
 func (s *PlayerService) GetPlayer(ctx context.Context, playerID int) (*PlayerProfile, error) {
-	player, err := s.playerRepo.GetByID(ctx, playerID)
+	player, err := cachedPlayer(ctx, s.playerRepo, playerID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching player: %w", err)
 	}
@@ -34,7 +38,7 @@ func (s *PlayerService) GetPlayer(ctx context.Context, playerID int) (*PlayerPro
 	// Lookup current team from player_team_history table
 	var team *store.Team
 	if teamID, err := s.playerRepo.GetCurrentTeamID(ctx, playerID); err == nil {
-		team, _ = s.teamRepo.GetByID(ctx, teamID)
+		team, _ = cachedTeam(ctx, s.teamRepo, teamID)
 	}
 
 	return &PlayerProfile{
@@ -43,19 +47,24 @@ func (s *PlayerService) GetPlayer(ctx context.Context, playerID int) (*PlayerPro
 	}, nil
 }
 
-// SearchPlayers searches for players by name
-func (s *PlayerService) SearchPlayers(ctx context.Context, name string) ([]*PlayerProfile, error) {
-	players, err := s.playerRepo.GetByName(ctx, name)
+// SearchPlayers searches for players by name, fuzzy and accent-insensitive, best match first
+func (s *PlayerService) SearchPlayers(ctx context.Context, name string, limit int) ([]*PlayerProfile, error) {
+	players, err := s.playerRepo.GetByName(ctx, name, limit)
 	if err != nil {
 		return nil, fmt.Errorf("searching players: %w", err)
 	}
 
 	profiles := make([]*PlayerProfile, 0, len(players))
 	for _, player := range players {
+		// Team lookup failures are ignored below, so stop here once the caller is gone
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		// Lookup current team from player_team_history table
 		var team *store.Team
 		if teamID, err := s.playerRepo.GetCurrentTeamID(ctx, player.PlayerID); err == nil {
-			team, _ = s.teamRepo.GetByID(ctx, teamID)
+			team, _ = cachedTeam(ctx, s.teamRepo, teamID)
 		}
 
 		profiles = append(profiles, &PlayerProfile{
@@ -67,6 +76,18 @@ func (s *PlayerService) SearchPlayers(ctx context.Context, name string) ([]*Play
 	return profiles, nil
 }
 
+// AutocompletePlayers returns compact records for players whose name starts with prefix
+func (s *PlayerService) AutocompletePlayers(ctx context.Context, prefix string, limit int) ([]*store.PlayerSuggestion, error) {
+	suggestions, err := s.playerRepo.Autocomplete(ctx, prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("autocompleting players: %w", err)
+	}
+	if suggestions == nil {
+		suggestions = []*store.PlayerSuggestion{}
+	}
+	return suggestions, nil
+}
+
 // GetTeamRoster retrieves all players on a team
 func (s *PlayerService) GetTeamRoster(ctx context.Context, teamID int) ([]*PlayerProfile, error) {
 	players, err := s.playerRepo.GetByCurrentTeam(ctx, teamID)
@@ -74,7 +95,7 @@ func (s *PlayerService) GetTeamRoster(ctx context.Context, teamID int) ([]*Playe
 		return nil, fmt.Errorf("fetching team roster: %w", err)
 	}
 
-	team, err := s.teamRepo.GetByID(ctx, teamID)
+	team, err := cachedTeam(ctx, s.teamRepo, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching team: %w", err)
 	}
@@ -90,19 +111,27 @@ func (s *PlayerService) GetTeamRoster(ctx context.Context, teamID int) ([]*Playe
 	return profiles, nil
 }
 
-// GetPlayerStats retrieves a player's recent game stats with enriched game context
-func (s *PlayerService) GetPlayerStats(ctx context.Context, playerID int, limit int) ([]*repository.EnrichedPlayerStats, error) {
+// GetPlayerStats retrieves a player's recent game stats with enriched game context.
+// For per Per36 or Per100 each line also carries its normalized rates.
+func (s *PlayerService) GetPlayerStats(ctx context.Context, playerID int, limit int, per string) ([]*repository.EnrichedPlayerStats, error) {
 	stats, err := s.statsRepo.GetPlayerRecentStatsEnriched(ctx, playerID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("fetching player stats: %w", err)
 	}
 
+	if per != PerGame {
+		for _, line := range stats {
+			line.Rates = NormalizeStats(per, gameTotals(line.PlayerGameStats, line.Possessions))
+		}
+	}
+
 	return stats, nil
 }
 
-// GetPlayerSeasonAverages retrieves a player's season averages
-func (s *PlayerService) GetPlayerSeasonAverages(ctx context.Context, playerID int, seasonID string) (map[string]float64, error) {
-	averages, err := s.statsRepo.GetPlayerSeasonAverages(ctx, playerID, seasonID)
+// GetPlayerSeasonAverages retrieves a player's season averages, through the Redis cache when configured.
+// All-Star, Rising Stars, and NBA Cup final games are left out unless includeSpecial is set.
+func (s *PlayerService) GetPlayerSeasonAverages(ctx context.Context, playerID int, seasonID string, includeSpecial bool) (map[string]float64, error) {
+	averages, err := cachedSeasonAverages(ctx, s.statsRepo, playerID, seasonID, includeSpecial)
 	if err != nil {
 		return nil, fmt.Errorf("calculating season averages: %w", err)
 	}
@@ -110,6 +139,42 @@ func (s *PlayerService) GetPlayerSeasonAverages(ctx context.Context, playerID in
 	return averages, nil
 }
 
+// PlayerSeasonRates are a player's season stats normalized per 36 minutes or per
+// 100 possessions. Rates is nil when the player has no recorded time or possessions.
+type PlayerSeasonRates struct {
+	PlayerID      int              `json:"player_id"`
+	Season        string           `json:"season"`
+	GamesPlayed   int              `json:"games_played"`
+	OvertimeGames int              `json:"overtime_games"`
+	Minutes       float64          `json:"minutes"`
+	Possessions   float64          `json:"possessions"` // estimated on-court possessions
+	Rates         *store.StatRates `json:"rates"`
+}
+
+// GetPlayerSeasonRates normalizes a player's season totals to per (Per36 or
+// Per100), with the same game filters as GetPlayerSeasonAverages
+func (s *PlayerService) GetPlayerSeasonRates(ctx context.Context, playerID int, seasonID string, includeSpecial bool, per string) (*PlayerSeasonRates, error) {
+	excluded := store.SpecialGameTypes
+	if includeSpecial {
+		excluded = nil
+	}
+
+	totals, err := s.statsRepo.GetPlayerSeasonTotals(ctx, playerID, seasonID, excluded)
+	if err != nil {
+		return nil, fmt.Errorf("calculating season totals: %w", err)
+	}
+
+	return &PlayerSeasonRates{
+		PlayerID:      playerID,
+		Season:        seasonID,
+		GamesPlayed:   totals.Games,
+		OvertimeGames: totals.OvertimeGames,
+		Minutes:       round1(float64(totals.Seconds) / 60),
+		Possessions:   round1(totals.Possessions),
+		Rates:         NormalizeStats(per, totals),
+	}, nil
+}
+
 // PlayerProfile contains player details with team information
 type PlayerProfile struct {
 	Player *store.Player `json:"player"`
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// ErrNoPlayoffSeries is returned when no series are on record for the season
+var ErrNoPlayoffSeries = errors.New("no playoff series on record")
+
+// PlayoffService builds playoff brackets from stored series
+type PlayoffService struct {
+	seriesRepo *repository.PlayoffSeriesRepository
+	teamRepo   *repository.TeamRepository
+}
+
+// NewPlayoffService creates a new playoff service
+func NewPlayoffService(db *store.Database) *PlayoffService {
+	return &PlayoffService{
+		seriesRepo: repository.NewPlayoffSeriesRepository(db),
+		teamRepo:   repository.NewTeamRepository(db),
+	}
+}
+
+// PlayoffBracket is a season's postseason as rounds and as a tree
+type PlayoffBracket struct {
+	Season   string          `json:"season"`
+	Champion *store.Team     `json:"champion,omitempty"`
+	Rounds   []*BracketRound `json:"rounds"`
+	// Tree roots are the latest series on each path: the finals once it is set,
+	// otherwise the furthest series reached on each side
+	Tree []*BracketNode `json:"tree"`
+}
+
+// BracketRound groups the series of one round
+type BracketRound struct {
+	Round  int              `json:"round"`
+	Series []*BracketSeries `json:"series"`
+}
+
+// BracketNode is a series with the earlier-round series that fed it
+type BracketNode struct {
+	Series   *BracketSeries `json:"series"`
+	Children []*BracketNode `json:"children,omitempty"`
+}
+
+// BracketSeries is a series with team details and its games
+type BracketSeries struct {
+	SeriesID   int                        `json:"series_id"`
+	Round      int                        `json:"round"`
+	RoundName  string                     `json:"round_name,omitempty"`
+	Conference string                     `json:"conference,omitempty"`
+	BestOf     int                        `json:"best_of"`
+	Status     string                     `json:"status"`
+	Summary    string                     `json:"summary"` // e.g. "BOS leads 2-1"
+	TeamA      *BracketEntry              `json:"team_a"`
+	TeamB      *BracketEntry              `json:"team_b"`
+	Winner     *store.Team                `json:"winner,omitempty"`
+	Games      []*store.PlayoffSeriesGame `json:"games"`
+
+	teamAID, teamBID int
+}
+
+// BracketEntry is one side of a series
+type BracketEntry struct {
+	Team *store.Team `json:"team"`
+	Wins int         `json:"wins"`
+}
+
+// GetBracket returns the bracket for a season year such as "2024-25".
+// An empty seasonYear selects the most recent postseason on record.
+func (s *PlayoffService) GetBracket(ctx context.Context, sport, seasonYear string) (*PlayoffBracket, error) {
+	if seasonYear == "" {
+		latest, err := s.seriesRepo.LatestSeasonYear(ctx, sport)
+		if err != nil {
+			return nil, ErrNoPlayoffSeries
+		}
+		seasonYear = latest
+	}
+
+	series, err := s.seriesRepo.GetBySeasonYear(ctx, sport, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("fetching playoff series: %w", err)
+	}
+	if len(series) == 0 {
+		return nil, ErrNoPlayoffSeries
+	}
+
+	seriesIDs := make([]int, 0, len(series))
+	for _, ps := range series {
+		seriesIDs = append(seriesIDs, ps.SeriesID)
+	}
+	games, err := s.seriesRepo.GetGamesForSeries(ctx, seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("fetching series games: %w", err)
+	}
+	gamesBySeries := make(map[int][]*store.PlayoffSeriesGame)
+	for _, g := range games {
+		gamesBySeries[g.SeriesID] = append(gamesBySeries[g.SeriesID], g)
+	}
+
+	teams := make(map[int]*store.Team)
+	team := func(id int) (*store.Team, error) {
+		if t, ok := teams[id]; ok {
+			return t, nil
+		}
+		t, err := cachedTeam(ctx, s.teamRepo, id)
+		if err != nil {
+			return nil, fmt.Errorf("fetching team %d: %w", id, err)
+		}
+		teams[id] = t
+		return t, nil
+	}
+
+	rounds := inferRounds(series)
+
+	bracket := &PlayoffBracket{Season: seasonYear}
+	byRound := make(map[int][]*BracketSeries)
+	maxRound := 0
+	for _, ps := range series {
+		teamA, err := team(ps.TeamAID)
+		if err != nil {
+			return nil, err
+		}
+		teamB, err := team(ps.TeamBID)
+		if err != nil {
+			return nil, err
+		}
+
+		bs := &BracketSeries{
+			SeriesID:   ps.SeriesID,
+			Round:      rounds[ps.SeriesID],
+			RoundName:  ps.RoundName.String,
+			Conference: ps.Conference.String,
+			BestOf:     ps.BestOf,
+			Status:     ps.Status,
+			TeamA:      &BracketEntry{Team: teamA, Wins: ps.TeamAWins},
+			TeamB:      &BracketEntry{Team: teamB, Wins: ps.TeamBWins},
+			Games:      gamesBySeries[ps.SeriesID],
+			teamAID:    ps.TeamAID,
+			teamBID:    ps.TeamBID,
+		}
+		if bs.Games == nil {
+			bs.Games = []*store.PlayoffSeriesGame{}
+		}
+		if ps.WinnerTeamID.Valid {
+			bs.Winner = teams[int(ps.WinnerTeamID.Int32)]
+		}
+		bs.Summary = seriesSummary(bs)
+
+		byRound[bs.Round] = append(byRound[bs.Round], bs)
+		if bs.Round > maxRound {
+			maxRound = bs.Round
+		}
+	}
+
+	for round := 1; round <= maxRound; round++ {
+		if len(byRound[round]) == 0 {
+			continue
+		}
+		bracket.Rounds = append(bracket.Rounds, &BracketRound{Round: round, Series: byRound[round]})
+	}
+
+	bracket.Tree = buildBracketTree(byRound, maxRound)
+	if len(bracket.Tree) == 1 && bracket.Tree[0].Series.Round == 4 {
+		bracket.Champion = bracket.Tree[0].Series.Winner
+	}
+
+	return bracket, nil
+}
+
+// inferRounds fills rounds ESPN didn't label: a series is one round past the
+// latest earlier series either team played. Series arrive in start date order.
+func inferRounds(series []*store.PlayoffSeries) map[int]int {
+	rounds := make(map[int]int, len(series))
+	teamRound := make(map[int]int)
+
+	for _, ps := range series {
+		round := int(ps.Round.Int32)
+		if !ps.Round.Valid {
+			round = max(teamRound[ps.TeamAID], teamRound[ps.TeamBID]) + 1
+		}
+		rounds[ps.SeriesID] = round
+		teamRound[ps.TeamAID] = max(teamRound[ps.TeamAID], round)
+		teamRound[ps.TeamBID] = max(teamRound[ps.TeamBID], round)
+	}
+
+	return rounds
+}
+
+// buildBracketTree links each series to the previous-round series its teams came
+// from, returning the series that no later series has claimed
+func buildBracketTree(byRound map[int][]*BracketSeries, maxRound int) []*BracketNode {
+	nodes := make(map[int]*BracketNode)
+	claimed := make(map[int]bool)
+
+	for round := 1; round <= maxRound; round++ {
+		for _, bs := range byRound[round] {
+			node := &BracketNode{Series: bs}
+			for _, prev := range byRound[round-1] {
+				if prev.involves(bs.teamAID) || prev.involves(bs.teamBID) {
+					node.Children = append(node.Children, nodes[prev.SeriesID])
+					claimed[prev.SeriesID] = true
+				}
+			}
+			nodes[bs.SeriesID] = node
+		}
+	}
+
+	roots := []*BracketNode{}
+	for round := maxRound; round >= 1; round-- {
+		for _, bs := range byRound[round] {
+			if !claimed[bs.SeriesID] {
+				roots = append(roots, nodes[bs.SeriesID])
+			}
+		}
+	}
+	return roots
+}
+
+func (bs *BracketSeries) involves(teamID int) bool {
+	return bs.teamAID == teamID || bs.teamBID == teamID
+}
+
+// seriesSummary renders the series state, e.g. "BOS leads 2-1" or "Series tied 1-1"
+func seriesSummary(bs *BracketSeries) string {
+	a, b := bs.TeamA, bs.TeamB
+	if b.Wins > a.Wins {
+		a, b = b, a
+	}
+
+	switch {
+	case a.Wins+b.Wins == 0:
+		return "Series not started"
+	case a.Wins == b.Wins:
+		return fmt.Sprintf("Series tied %d-%d", a.Wins, b.Wins)
+	case bs.Status == "completed":
+		return fmt.Sprintf("%s wins %d-%d", a.Team.Abbreviation, a.Wins, b.Wins)
+	default:
+		return fmt.Sprintf("%s leads %d-%d", a.Team.Abbreviation, a.Wins, b.Wins)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// PositionalDefenseService reports what each team allows to each position
+type PositionalDefenseService struct {
+	defenseRepo *repository.PositionalDefenseRepository
+	teamRepo    *repository.TeamRepository
+}
+
+// NewPositionalDefenseService creates a new positional defense service
+func NewPositionalDefenseService(db *store.Database) *PositionalDefenseService {
+	return &PositionalDefenseService{
+		defenseRepo: repository.NewPositionalDefenseRepository(db),
+		teamRepo:    repository.NewTeamRepository(db),
+	}
+}
+
+// TeamPositionalDefenseReport is one team's positional defense for a season
+type TeamPositionalDefenseReport struct {
+	Team      *store.Team                    `json:"team"`
+	Season    string                         `json:"season"`
+	Positions []*store.TeamPositionalDefense `json:"positions"` // PG, SG, SF, PF, C
+}
+
+// Refresh recomputes a season's stored positional defense, returning how many
+// team-position rows were written
+func (s *PositionalDefenseService) Refresh(ctx context.Context, seasonYear string) (int64, error) {
+	n, err := s.defenseRepo.Refresh(ctx, seasonYear)
+	if err != nil {
+		return 0, fmt.Errorf("refreshing positional defense: %w", err)
+	}
+	return n, nil
+}
+
+// GetTeam returns a team's stored positional defense for a season
+func (s *PositionalDefenseService) GetTeam(ctx context.Context, teamID int, seasonYear string) (*TeamPositionalDefenseReport, error) {
+	team, err := cachedTeam(ctx, s.teamRepo, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d (%v)", ErrTeamNotFound, teamID, err)
+	}
+
+	rows, err := s.defenseRepo.GetBySeason(ctx, seasonYear, teamID, "")
+	if err != nil {
+		return nil, fmt.Errorf("fetching positional defense: %w", err)
+	}
+	byPosition := make(map[string]*store.TeamPositionalDefense, len(rows))
+	for _, row := range rows {
+		byPosition[row.Position] = row
+	}
+
+	report := &TeamPositionalDefenseReport{Team: team, Season: seasonYear, Positions: []*store.TeamPositionalDefense{}}
+	for _, position := range store.DefensivePositions {
+		if row, ok := byPosition[position]; ok {
+			report.Positions = append(report.Positions, row)
+		}
+	}
+	return report, nil
+}
+
+// GetLeague returns every team's stored defense against one position, softest first
+func (s *PositionalDefenseService) GetLeague(ctx context.Context, seasonYear, position string) ([]*store.TeamPositionalDefense, error) {
+	rows, err := s.defenseRepo.GetBySeason(ctx, seasonYear, 0, position)
+	if err != nil {
+		return nil, fmt.Errorf("fetching positional defense: %w", err)
+	}
+	if rows == nil {
+		rows = []*store.TeamPositionalDefense{}
+	}
+	return rows, nil
+}
+
+// Against returns what a team has allowed to a position group, or nil before it
+// has faced one. A non-zero before aggregates only earlier games instead of
+// reading the nightly table, which covers the season to date.
+func (s *PositionalDefenseService) Against(ctx context.Context, teamID int, seasonYear, position string, before time.Time) (*store.TeamPositionalDefense, error) {
+	var rows []*store.TeamPositionalDefense
+	var err error
+	if before.IsZero() {
+		rows, err = s.defenseRepo.GetBySeason(ctx, seasonYear, teamID, position)
+	} else {
+		rows, err = s.defenseRepo.Compute(ctx, seasonYear, before, teamID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching positional defense: %w", err)
+	}
+	for _, row := range rows {
+		if row.Position == position {
+			return row, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// eloPointsPerSpread converts a rating gap, home court included, to points
+const eloPointsPerSpread = 28.0
+
+// RatingService maintains Elo team ratings from final scores
+type RatingService struct {
+	ratingRepo *repository.TeamRatingRepository
+	teamRepo   *repository.TeamRepository
+}
+
+// NewRatingService creates a new rating service
+func NewRatingService(db *store.Database) *RatingService {
+	return &RatingService{
+		ratingRepo: repository.NewTeamRatingRepository(db),
+		teamRepo:   repository.NewTeamRepository(db),
+	}
+}
+
+// TeamRatingHistory is a team's Elo over one season
+type TeamRatingHistory struct {
+	Team       *store.Team         `json:"team"`
+	Season     string              `json:"season"`
+	CurrentElo *float64            `json:"current_elo"` // after the team's latest rated game, any season
+	Ratings    []*store.TeamRating `json:"ratings"`     // one per game, oldest first
+}
+
+// GameRatings are both teams' pregame ratings and what they imply
+type GameRatings struct {
+	HomeElo            float64 `json:"home_elo"`
+	AwayElo            float64 `json:"away_elo"`
+	HomeWinProbability float64 `json:"home_win_probability"` // home court included
+	HomeSpread         float64 `json:"home_spread"`          // implied point spread, negative when the home team is favored
+}
+
+// Update rates every final game not yet rated and returns how many games were
+// rated. Ratings chain game to game, so when the earliest unrated game falls
+// before already rated ones, everything from its date on is rated again.
+func (s *RatingService) Update(ctx context.Context) (int, error) {
+	from, ok, err := s.ratingRepo.FirstUnratedDate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, nil
+	}
+
+	current, err := s.ratingRepo.GetLatest(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+	games, err := s.ratingRepo.ListGamesFrom(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+
+	ratings := make([]*store.TeamRating, 0, 2*len(games))
+	for _, game := range games {
+		home, away := rateGame(game, current)
+		current[home.TeamID], current[away.TeamID] = home, away
+		ratings = append(ratings, home, away)
+	}
+
+	if err := s.ratingRepo.Replace(ctx, from, ratings); err != nil {
+		return 0, err
+	}
+	return len(games), nil
+}
+
+// rateGame moves both teams' ratings by a final score, starting from their
+// latest ratings in current
+func rateGame(game *store.Game, current map[int]*store.TeamRating) (home, away *store.TeamRating) {
+	homeElo := pregameElo(current[game.HomeTeamID], game.SeasonID)
+	awayElo := pregameElo(current[game.AwayTeamID], game.SeasonID)
+	probability := store.EloWinProbability(homeElo, awayElo)
+	shift := store.EloShift(homeElo, awayElo, int(game.HomeScore.Int32), int(game.AwayScore.Int32))
+
+	home = &store.TeamRating{
+		TeamID:         game.HomeTeamID,
+		GameID:         game.GameID,
+		SeasonID:       game.SeasonID,
+		RatingDate:     game.GameDate,
+		OpponentTeamID: game.AwayTeamID,
+		IsHome:         true,
+		EloBefore:      homeElo,
+		Elo:            round1(homeElo + shift),
+		WinProbability: round3(probability),
+	}
+	away = &store.TeamRating{
+		TeamID:         game.AwayTeamID,
+		GameID:         game.GameID,
+		SeasonID:       game.SeasonID,
+		RatingDate:     game.GameDate,
+		OpponentTeamID: game.HomeTeamID,
+		EloBefore:      awayElo,
+		Elo:            round1(awayElo - shift),
+		WinProbability: round3(1 - probability),
+	}
+	return home, away
+}
+
+// pregameElo is a team's rating going into a game of season, reverted toward
+// the mean when its latest game was in an earlier season
+func pregameElo(latest *store.TeamRating, seasonID int) float64 {
+	switch {
+	case latest == nil:
+		return store.EloInitial
+	case latest.SeasonID != seasonID:
+		return round1(store.EloCarryOver(latest.Elo))
+	default:
+		return latest.Elo
+	}
+}
+
+// GetHistory returns a team's ratings over a season
+func (s *RatingService) GetHistory(ctx context.Context, teamID, seasonID int, seasonYear string) (*TeamRatingHistory, error) {
+	team, err := cachedTeam(ctx, s.teamRepo, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d (%v)", ErrTeamNotFound, teamID, err)
+	}
+
+	ratings, err := s.ratingRepo.GetHistory(ctx, teamID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := s.ratingRepo.GetLatest(ctx, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	history := &TeamRatingHistory{Team: team, Season: seasonYear, Ratings: ratings}
+	if current, ok := latest[teamID]; ok {
+		history.CurrentElo = &current.Elo
+	}
+	return history, nil
+}
+
+// GetGameRatings returns the pregame ratings of an NBA game: those it was rated
+// from once final, otherwise each team's latest before the game date. Nil for
+// games that don't move ratings and before either team has been rated.
+func (s *RatingService) GetGameRatings(ctx context.Context, game *store.Game) (*GameRatings, error) {
+	if game.League != store.LeagueNBA || !slices.Contains(store.EloGameTypes, game.GameType) {
+		return nil, nil
+	}
+
+	if game.Status == "final" {
+		rated, err := s.ratingRepo.GetByGame(ctx, game.GameID)
+		if err != nil {
+			return nil, err
+		}
+		home, away := rated[game.HomeTeamID], rated[game.AwayTeamID]
+		if home != nil && away != nil {
+			return gameRatings(home.EloBefore, away.EloBefore), nil
+		}
+	}
+
+	latest, err := s.ratingRepo.GetLatest(ctx, game.GameDate)
+	if err != nil {
+		return nil, err
+	}
+	home, away := latest[game.HomeTeamID], latest[game.AwayTeamID]
+	if home == nil && away == nil {
+		return nil, nil
+	}
+	return gameRatings(pregameElo(home, game.SeasonID), pregameElo(away, game.SeasonID)), nil
+}
+
+func gameRatings(homeElo, awayElo float64) *GameRatings {
+	return &GameRatings{
+		HomeElo:            homeElo,
+		AwayElo:            awayElo,
+		HomeWinProbability: round3(store.EloWinProbability(homeElo, awayElo)),
+		HomeSpread:         round1(-(homeElo + store.EloHomeAdvantage - awayElo) / eloPointsPerSpread),
+	}
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
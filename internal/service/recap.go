@@ -0,0 +1,477 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// ErrRecapUnavailable is returned for games that have not tipped off
+var ErrRecapUnavailable = errors.New("recap unavailable before tip-off")
+
+const (
+	recapPerformersPerTeam = 2
+	recapMaxRuns           = 3
+	recapMinRunPoints      = 8
+)
+
+// RecapService builds structured game recaps from stored box scores and play-by-play
+type RecapService struct {
+	gameRepo   *repository.GameRepository
+	teamRepo   *repository.TeamRepository
+	playerRepo *repository.PlayerRepository
+	statsRepo  *repository.StatsRepository
+	playRepo   *repository.PlayRepository
+}
+
+// NewRecapService creates a new recap service
+func NewRecapService(db *store.Database) *RecapService {
+	return &RecapService{
+		gameRepo:   repository.NewGameRepository(db),
+		teamRepo:   repository.NewTeamRepository(db),
+		playerRepo: repository.NewPlayerRepository(db),
+		statsRepo:  repository.NewStatsRepository(db),
+		playRepo:   repository.NewPlayRepository(db),
+	}
+}
+
+// GameRecap is a templated summary of a game suitable for rendering headlines
+type GameRecap struct {
+	GameID        string            `json:"game_id"`
+	Sport         string            `json:"sport"`
+	Status        string            `json:"status"`
+	GameDate      string            `json:"game_date"`
+	Headline      string            `json:"headline"`
+	Summary       string            `json:"summary"`
+	HomeTeam      RecapTeam         `json:"home_team"`
+	AwayTeam      RecapTeam         `json:"away_team"`
+	WinnerTeamID  *int              `json:"winner_team_id,omitempty"`
+	Margin        int               `json:"margin"`
+	TopPerformers []*RecapPerformer `json:"top_performers"`
+	KeyRuns       []*ScoringRun     `json:"key_runs"`
+	Milestones    []*RecapMilestone `json:"milestones"`
+	LeadChanges   int               `json:"lead_changes"`
+	TimesTied     int               `json:"times_tied"`
+	PlayByPlay    bool              `json:"play_by_play_available"`
+}
+
+// RecapTeam is one side of the recap
+type RecapTeam struct {
+	TeamID        int    `json:"team_id"`
+	Abbreviation  string `json:"abbreviation"`
+	Name          string `json:"name"`
+	Score         int    `json:"score"`
+	QuarterScores []int  `json:"quarter_scores"`
+	LargestLead   int    `json:"largest_lead"`
+}
+
+// RecapPerformer is a standout stat line. GameID is set in daily recaps.
+type RecapPerformer struct {
+	PlayerID  int     `json:"player_id"`
+	Name      string  `json:"name"`
+	TeamID    int     `json:"team_id"`
+	GameID    string  `json:"game_id,omitempty"`
+	Points    int     `json:"points"`
+	Rebounds  int     `json:"rebounds"`
+	Assists   int     `json:"assists"`
+	Steals    int     `json:"steals"`
+	Blocks    int     `json:"blocks"`
+	GameScore float64 `json:"game_score"`
+	Line      string  `json:"line"` // "31 PTS, 12 REB, 7 AST"
+}
+
+// ScoringRun is a stretch of unanswered points by one team
+type ScoringRun struct {
+	TeamID      int    `json:"team_id"`
+	Points      int    `json:"points"`
+	Period      int    `json:"period"`
+	StartClock  string `json:"start_clock"`
+	EndClock    string `json:"end_clock"`
+	HomeScore   int    `json:"home_score"` // score when the run ended
+	AwayScore   int    `json:"away_score"`
+	Description string `json:"description"`
+}
+
+// RecapMilestone is a notable individual achievement in the game. GameID is
+// set in daily recaps.
+type RecapMilestone struct {
+	PlayerID    int    `json:"player_id"`
+	Name        string `json:"name"`
+	GameID      string `json:"game_id,omitempty"`
+	Type        string `json:"type"` // triple_double, double_double, scoring, rebounding, assists, career_high
+	Description string `json:"description"`
+}
+
+// GetGameRecap builds the recap for a game by external (ESPN) ID
+func (s *RecapService) GetGameRecap(ctx context.Context, gameID string) (*GameRecap, error) {
+	game, err := s.gameRepo.GetByExternalID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching game: %w", err)
+	}
+	if game.Status != "in_progress" && game.Status != "final" {
+		return nil, ErrRecapUnavailable
+	}
+
+	homeTeam, err := cachedTeam(ctx, s.teamRepo, game.HomeTeamID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching home team: %w", err)
+	}
+	awayTeam, err := cachedTeam(ctx, s.teamRepo, game.AwayTeamID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching away team: %w", err)
+	}
+
+	stats, err := s.statsRepo.GetByGameID(ctx, game.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching box score: %w", err)
+	}
+
+	plays, err := s.playRepo.GetByGame(ctx, game.GameID, true)
+	if err != nil {
+		return nil, fmt.Errorf("fetching plays: %w", err)
+	}
+
+	quarters, err := s.gameRepo.GetQuarterScores(ctx, game.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching quarter scores: %w", err)
+	}
+	if quarters == nil {
+		quarters = quarterScoresFromPlays(plays)
+	}
+
+	recap := &GameRecap{
+		GameID:     game.ExternalID,
+		Sport:      game.Sport,
+		Status:     game.Status,
+		GameDate:   game.GameDate.Format("2006-01-02"),
+		HomeTeam:   recapTeam(homeTeam, int(game.HomeScore.Int32), quarters.Home),
+		AwayTeam:   recapTeam(awayTeam, int(game.AwayScore.Int32), quarters.Away),
+		KeyRuns:    []*ScoringRun{},
+		Milestones: []*RecapMilestone{},
+		PlayByPlay: len(plays) > 0,
+	}
+	recap.Margin = abs(recap.HomeTeam.Score - recap.AwayTeam.Score)
+
+	names := make(map[int]string)
+	recap.TopPerformers = s.topPerformers(ctx, stats, names)
+	recap.Milestones = s.milestones(ctx, stats, game, names)
+
+	s.analyzePlays(recap, plays)
+
+	if game.Status == "final" && recap.Margin > 0 {
+		winner := game.HomeTeamID
+		if recap.AwayTeam.Score > recap.HomeTeam.Score {
+			winner = game.AwayTeamID
+		}
+		recap.WinnerTeamID = &winner
+	}
+
+	recap.Headline = recapHeadline(recap, game)
+	recap.Summary = recapSummary(recap)
+
+	return recap, nil
+}
+
+// topPerformers ranks players by Hollinger game score and keeps the best per team
+func (s *RecapService) topPerformers(ctx context.Context, stats []*store.PlayerGameStats, names map[int]string) []*RecapPerformer {
+	ranked := make([]*store.PlayerGameStats, len(stats))
+	copy(ranked, stats)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return gameScore(ranked[i]) > gameScore(ranked[j])
+	})
+
+	performers := []*RecapPerformer{}
+	perTeam := make(map[int]int)
+	for _, st := range ranked {
+		if perTeam[st.TeamID] >= recapPerformersPerTeam {
+			continue
+		}
+		perTeam[st.TeamID]++
+
+		performers = append(performers, &RecapPerformer{
+			PlayerID:  st.PlayerID,
+			Name:      s.playerName(ctx, st.PlayerID, names),
+			TeamID:    st.TeamID,
+			Points:    st.Points,
+			Rebounds:  st.Rebounds,
+			Assists:   st.Assists,
+			Steals:    st.Steals,
+			Blocks:    st.Blocks,
+			GameScore: round1(gameScore(st)),
+			Line:      statLine(st),
+		})
+	}
+
+	sort.SliceStable(performers, func(i, j int) bool {
+		return performers[i].GameScore > performers[j].GameScore
+	})
+	return performers
+}
+
+// milestones flags multi-category games, big single-stat nights, and career highs
+func (s *RecapService) milestones(ctx context.Context, stats []*store.PlayerGameStats, game *store.Game, names map[int]string) []*RecapMilestone {
+	milestones := []*RecapMilestone{}
+	add := func(st *store.PlayerGameStats, kind, format string, args ...interface{}) {
+		name := s.playerName(ctx, st.PlayerID, names)
+		milestones = append(milestones, &RecapMilestone{
+			PlayerID:    st.PlayerID,
+			Name:        name,
+			Type:        kind,
+			Description: name + " " + fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, st := range stats {
+		doubles := 0
+		for _, v := range []int{st.Points, st.Rebounds, st.Assists, st.Steals, st.Blocks} {
+			if v >= 10 {
+				doubles++
+			}
+		}
+		switch {
+		case doubles >= 3:
+			add(st, "triple_double", "recorded a triple-double (%s)", statLine(st))
+		case doubles == 2 && st.Points >= 20:
+			add(st, "double_double", "posted a double-double (%s)", statLine(st))
+		}
+
+		if st.Points >= 40 {
+			add(st, "scoring", "scored %d points", st.Points)
+		}
+		if st.Rebounds >= 20 {
+			add(st, "rebounding", "grabbed %d rebounds", st.Rebounds)
+		}
+		if st.Assists >= 15 {
+			add(st, "assists", "dished %d assists", st.Assists)
+		}
+
+		// Career highs are only meaningful for finished games and real scoring nights
+		if game.Status == "final" && st.Points >= 20 {
+			high, err := s.statsRepo.GetPointsHighBefore(ctx, st.PlayerID, game.GameDate)
+			if err == nil && high.Valid && st.Points > int(high.Int32) {
+				add(st, "career_high", "set a career high with %d points (previous %d)", st.Points, high.Int32)
+			}
+		}
+	}
+
+	return milestones
+}
+
+// analyzePlays derives scoring runs, lead changes, ties, and largest leads from scoring plays
+func (s *RecapService) analyzePlays(recap *GameRecap, plays []*store.GamePlay) {
+	var runs []*ScoringRun
+	var current *ScoringRun
+	if leads := store.TrackLeads(plays); leads != nil {
+		recap.HomeTeam.LargestLead, recap.AwayTeam.LargestLead = leads.HomeLargestLead, leads.AwayLargestLead
+		recap.LeadChanges, recap.TimesTied = leads.LeadChanges, leads.TimesTied
+	}
+
+	prevHome, prevAway := 0, 0
+	for _, p := range plays {
+		homePts, awayPts := p.HomeScore-prevHome, p.AwayScore-prevAway
+		prevHome, prevAway = p.HomeScore, p.AwayScore
+		if homePts <= 0 && awayPts <= 0 {
+			continue
+		}
+
+		scorer := recap.HomeTeam.TeamID
+		points := homePts
+		if awayPts > 0 {
+			scorer, points = recap.AwayTeam.TeamID, awayPts
+		}
+
+		clock := p.Clock.String
+		if current != nil && current.TeamID == scorer && current.Period == p.Period {
+			current.Points += points
+			current.EndClock = clock
+		} else {
+			if current != nil && current.Points >= recapMinRunPoints {
+				runs = append(runs, current)
+			}
+			current = &ScoringRun{TeamID: scorer, Points: points, Period: p.Period, StartClock: clock, EndClock: clock}
+		}
+		current.HomeScore, current.AwayScore = p.HomeScore, p.AwayScore
+	}
+	if current != nil && current.Points >= recapMinRunPoints {
+		runs = append(runs, current)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].Points > runs[j].Points })
+	if len(runs) > recapMaxRuns {
+		runs = runs[:recapMaxRuns]
+	}
+	for _, run := range runs {
+		run.Description = fmt.Sprintf("%s went on a %d-0 run in %s (%s to %s)",
+			recap.teamName(run.TeamID), run.Points, periodLabel(run.Period), run.StartClock, run.EndClock)
+	}
+	recap.KeyRuns = append(recap.KeyRuns, runs...)
+}
+
+func (s *RecapService) playerName(ctx context.Context, playerID int, names map[int]string) string {
+	if name, ok := names[playerID]; ok {
+		return name
+	}
+	name := fmt.Sprintf("Player %d", playerID)
+	if player, err := cachedPlayer(ctx, s.playerRepo, playerID); err == nil {
+		name = player.FullName
+		if player.DisplayName.Valid && player.DisplayName.String != "" {
+			name = player.DisplayName.String
+		}
+	}
+	names[playerID] = name
+	return name
+}
+
+func (r *GameRecap) teamName(teamID int) string {
+	if teamID == r.HomeTeam.TeamID {
+		return r.HomeTeam.Name
+	}
+	return r.AwayTeam.Name
+}
+
+func recapHeadline(r *GameRecap, game *store.Game) string {
+	home, away := r.HomeTeam, r.AwayTeam
+	leader, trailer := home, away
+	if away.Score > home.Score {
+		leader, trailer = away, home
+	}
+
+	var star string
+	for _, p := range r.TopPerformers {
+		if p.TeamID == leader.TeamID {
+			star = fmt.Sprintf(" behind %s's %d points", p.Name, p.Points)
+			break
+		}
+	}
+
+	if game.Status == "final" {
+		if r.WinnerTeamID == nil {
+			return fmt.Sprintf("%s and %s finish tied %d-%d", home.Name, away.Name, home.Score, away.Score)
+		}
+		verb := "beat"
+		switch {
+		case r.Margin >= 20:
+			verb = "rout"
+		case r.Margin <= 3:
+			verb = "edge"
+		}
+		return fmt.Sprintf("%s %s %s %d-%d%s", leader.Name, verb, trailer.Name, leader.Score, trailer.Score, star)
+	}
+
+	period := periodLabel(int(game.Period.Int32))
+	if home.Score == away.Score {
+		return fmt.Sprintf("%s and %s tied %d-%d in %s", home.Name, away.Name, home.Score, away.Score, period)
+	}
+	return fmt.Sprintf("%s lead %s %d-%d in %s%s", leader.Name, trailer.Name, leader.Score, trailer.Score, period, star)
+}
+
+func recapSummary(r *GameRecap) string {
+	var parts []string
+	if len(r.TopPerformers) > 0 {
+		p := r.TopPerformers[0]
+		parts = append(parts, fmt.Sprintf("%s led all players with %s.", p.Name, p.Line))
+	}
+	if len(r.KeyRuns) > 0 {
+		parts = append(parts, r.KeyRuns[0].Description+".")
+	}
+	if r.PlayByPlay {
+		parts = append(parts, fmt.Sprintf("The lead changed hands %d times and the game was tied %d times.", r.LeadChanges, r.TimesTied))
+	}
+	for _, m := range r.Milestones {
+		parts = append(parts, m.Description+".")
+	}
+	return strings.Join(parts, " ")
+}
+
+func recapTeam(team *store.Team, score int, quarters []int) RecapTeam {
+	if quarters == nil {
+		quarters = []int{}
+	}
+	name := team.ShortName
+	if name == "" {
+		name = team.FullName
+	}
+	return RecapTeam{
+		TeamID:        team.TeamID,
+		Abbreviation:  team.Abbreviation,
+		Name:          name,
+		Score:         score,
+		QuarterScores: quarters,
+	}
+}
+
+// quarterScoresFromPlays rebuilds per-period points from the score after each scoring play
+func quarterScoresFromPlays(plays []*store.GamePlay) *store.QuarterScores {
+	scores := &store.QuarterScores{}
+	homeBase, awayBase := 0, 0
+	for _, p := range plays {
+		for len(scores.Home) < p.Period {
+			homeBase, awayBase = sum(scores.Home), sum(scores.Away)
+			scores.Home = append(scores.Home, 0)
+			scores.Away = append(scores.Away, 0)
+		}
+		scores.Home[p.Period-1] = p.HomeScore - homeBase
+		scores.Away[p.Period-1] = p.AwayScore - awayBase
+	}
+	return scores
+}
+
+// gameScore is John Hollinger's single-number game rating
+func gameScore(st *store.PlayerGameStats) float64 {
+	return float64(st.Points) + 0.4*float64(st.FieldGoalsMade) - 0.7*float64(st.FieldGoalsAttempted) -
+		0.4*float64(st.FreeThrowsAttempted-st.FreeThrowsMade) + 0.7*float64(st.OffensiveRebounds) +
+		0.3*float64(st.DefensiveRebounds) + float64(st.Steals) + 0.7*float64(st.Assists) +
+		0.7*float64(st.Blocks) - 0.4*float64(st.PersonalFouls) - float64(st.Turnovers)
+}
+
+func statLine(st *store.PlayerGameStats) string {
+	parts := []string{fmt.Sprintf("%d PTS", st.Points)}
+	for _, c := range []struct {
+		value int
+		label string
+	}{{st.Rebounds, "REB"}, {st.Assists, "AST"}, {st.Steals, "STL"}, {st.Blocks, "BLK"}} {
+		if c.value >= 5 || (c.label == "REB" || c.label == "AST") && c.value > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.value, c.label))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func periodLabel(period int) string {
+	switch {
+	case period <= 0:
+		return "the game"
+	case period <= 4:
+		return []string{"", "the 1st quarter", "the 2nd quarter", "the 3rd quarter", "the 4th quarter"}[period]
+	case period == 5:
+		return "overtime"
+	default:
+		return strconv.Itoa(period-4) + "OT"
+	}
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// ScheduleService derives rest and schedule strength from the games table
+type ScheduleService struct {
+	gameRepo *repository.GameRepository
+	teamRepo *repository.TeamRepository
+}
+
+// NewScheduleService creates a new schedule service
+func NewScheduleService(db *store.Database) *ScheduleService {
+	return &ScheduleService{
+		gameRepo: repository.NewGameRepository(db),
+		teamRepo: repository.NewTeamRepository(db),
+	}
+}
+
+// scheduleGameTypes are analysed when the caller does not filter: the games
+// that make up the regular-season schedule
+var scheduleGameTypes = []string{store.GameTypeRegular, store.GameTypeTournament}
+
+// ScheduleAnalysis is a team's scheduling context for one season. Postponed and
+// cancelled games are left out; rest days count calendar days off between games.
+type ScheduleAnalysis struct {
+	Team              *store.Team      `json:"team"`
+	Season            string           `json:"season"`
+	Games             int              `json:"games"`
+	Played            int              `json:"played"`
+	Remaining         int              `json:"remaining"`
+	BackToBacks       BackToBacks      `json:"back_to_backs"`
+	ThreeInFour       int              `json:"three_in_four"` // games that are a third in four nights
+	RestDays          map[string]int   `json:"rest_days"`     // games by days off before them: "0" (back-to-back), "1", "2", "3+"
+	PlayedStrength    OpponentStrength `json:"played_opponent_strength"`
+	RemainingStrength OpponentStrength `json:"remaining_opponent_strength"`
+	LongestRoadTrip   ScheduleStretch  `json:"longest_road_trip"`
+	LongestHomestand  ScheduleStretch  `json:"longest_homestand"`
+}
+
+// BackToBacks counts games played the day after another game
+type BackToBacks struct {
+	Total          int `json:"total"`
+	Remaining      int `json:"remaining"`
+	RoadSecondLegs int `json:"road_second_legs"` // second night played away
+}
+
+// OpponentStrength averages opponents' season net rating, game by game. Rank 1
+// is the hardest slate in the league; teams without box scores yet are skipped.
+type OpponentStrength struct {
+	Games        int      `json:"games"`
+	AvgNetRating *float64 `json:"avg_opponent_net_rating"`
+	Rank         *int     `json:"rank,omitempty"`
+}
+
+// ScheduleStretch is a run of consecutive road or home games
+type ScheduleStretch struct {
+	Games     int    `json:"games"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+// GetScheduleAnalysis analyses a team's season. Nil gameTypes covers regular
+// season and NBA Cup games; opponent strength is ranked across every team.
+func (s *ScheduleService) GetScheduleAnalysis(ctx context.Context, teamID, seasonID int, seasonYear string, gameTypes []string) (*ScheduleAnalysis, error) {
+	team, err := cachedTeam(ctx, s.teamRepo, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d (%v)", ErrTeamNotFound, teamID, err)
+	}
+	if len(gameTypes) == 0 {
+		gameTypes = scheduleGameTypes
+	}
+
+	seasonGames, err := s.gameRepo.GetBySeason(ctx, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching season games: %w", err)
+	}
+	ratings, err := s.teamRepo.GetSeasonNetRatings(ctx, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching net ratings: %w", err)
+	}
+
+	// Every team's schedule, so opponent strength can be ranked
+	schedules := make(map[int][]*store.Game)
+	for _, game := range seasonGames {
+		if game.Status == "postponed" || game.Status == "cancelled" || !slices.Contains(gameTypes, game.GameType) {
+			continue
+		}
+		schedules[game.HomeTeamID] = append(schedules[game.HomeTeamID], game)
+		schedules[game.AwayTeamID] = append(schedules[game.AwayTeamID], game)
+	}
+
+	analysis := &ScheduleAnalysis{
+		Team:     team,
+		Season:   seasonYear,
+		RestDays: map[string]int{"0": 0, "1": 0, "2": 0, "3+": 0},
+	}
+	games := schedules[teamID]
+	sort.SliceStable(games, func(i, j int) bool { return games[i].GameDate.Before(games[j].GameDate) })
+	analysis.Games = len(games)
+
+	var road, home, curRoad, curHome ScheduleStretch
+	for i, game := range games {
+		played := game.Status == "final"
+		if played {
+			analysis.Played++
+		} else {
+			analysis.Remaining++
+		}
+
+		if i > 0 {
+			rest := daysBetween(games[i-1].GameDate, game.GameDate) - 1
+			switch {
+			case rest <= 0:
+				analysis.RestDays["0"]++
+				analysis.BackToBacks.Total++
+				if !played {
+					analysis.BackToBacks.Remaining++
+				}
+				if game.AwayTeamID == teamID {
+					analysis.BackToBacks.RoadSecondLegs++
+				}
+			case rest >= 3:
+				analysis.RestDays["3+"]++
+			default:
+				analysis.RestDays[fmt.Sprint(rest)]++
+			}
+		}
+		if i >= 2 && daysBetween(games[i-2].GameDate, game.GameDate) <= 3 {
+			analysis.ThreeInFour++
+		}
+
+		date := game.GameDate.Format("2006-01-02")
+		if game.AwayTeamID == teamID {
+			curRoad = extendStretch(curRoad, date)
+			curHome = ScheduleStretch{}
+		} else {
+			curHome = extendStretch(curHome, date)
+			curRoad = ScheduleStretch{}
+		}
+		if curRoad.Games > road.Games {
+			road = curRoad
+		}
+		if curHome.Games > home.Games {
+			home = curHome
+		}
+	}
+	analysis.LongestRoadTrip, analysis.LongestHomestand = road, home
+
+	played := make(map[int]OpponentStrength, len(schedules))
+	remaining := make(map[int]OpponentStrength, len(schedules))
+	for id, teamGames := range schedules {
+		played[id] = opponentStrength(id, teamGames, ratings, true)
+		remaining[id] = opponentStrength(id, teamGames, ratings, false)
+	}
+	analysis.PlayedStrength = rankStrength(teamID, played)
+	analysis.RemainingStrength = rankStrength(teamID, remaining)
+
+	return analysis, nil
+}
+
+// opponentStrength averages the net rating of the opponents in a team's played
+// or remaining games
+func opponentStrength(teamID int, games []*store.Game, ratings map[int]float64, played bool) OpponentStrength {
+	var strength OpponentStrength
+	var total float64
+	rated := 0
+	for _, game := range games {
+		if (game.Status == "final") != played {
+			continue
+		}
+		strength.Games++
+		opponent := game.HomeTeamID
+		if opponent == teamID {
+			opponent = game.AwayTeamID
+		}
+		if rating, ok := ratings[opponent]; ok {
+			total += rating
+			rated++
+		}
+	}
+	if rated > 0 {
+		avg := round1(total / float64(rated))
+		strength.AvgNetRating = &avg
+	}
+	return strength
+}
+
+// rankStrength returns teamID's strength ranked hardest-first among all teams
+func rankStrength(teamID int, strengths map[int]OpponentStrength) OpponentStrength {
+	strength := strengths[teamID]
+	if strength.AvgNetRating == nil {
+		return strength
+	}
+	rank := 1
+	for id, other := range strengths {
+		if id != teamID && other.AvgNetRating != nil && *other.AvgNetRating > *strength.AvgNetRating {
+			rank++
+		}
+	}
+	strength.Rank = &rank
+	return strength
+}
+
+func extendStretch(s ScheduleStretch, date string) ScheduleStretch {
+	if s.Games == 0 {
+		s.StartDate = date
+	}
+	s.Games++
+	s.EndDate = date
+	return s
+}
+
+// daysBetween counts calendar days from a to b
+func daysBetween(a, b time.Time) int {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
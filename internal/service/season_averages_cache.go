@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// SeasonAverageCacheConfig tunes the Redis read-through cache for player season
+// averages. A zero TTL disables it.
+type SeasonAverageCacheConfig struct {
+	TTL time.Duration // bounds how long a player's averages can go unread before recomputing
+}
+
+// DefaultSeasonAverageCacheConfig keeps averages for a day; new stat lines evict them sooner
+func DefaultSeasonAverageCacheConfig() SeasonAverageCacheConfig {
+	return SeasonAverageCacheConfig{TTL: 24 * time.Hour}
+}
+
+// seasonAverageKeyPrefix is followed by the player ID. Each player's hash holds
+// one field per season and game filter, so new stats for the player drop every
+// cached season in one DEL.
+const seasonAverageKeyPrefix = "season_averages:"
+
+// seasonAverageInvalidateTimeout bounds the DEL issued from a repository write hook
+const seasonAverageInvalidateTimeout = 2 * time.Second
+
+// seasonAverageCache stores GetPlayerSeasonAverages results in Redis. Unlike the
+// lookup cache it is shared by every replica, so invalidation is seen by all.
+type seasonAverageCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+var (
+	seasonAveragesMu   sync.RWMutex
+	seasonAverages     *seasonAverageCache
+	registerStatsHooks sync.Once
+)
+
+// ConfigureSeasonAverageCache enables the season average cache on client. Call it
+// at startup; without it (or with a nil client) averages are always computed.
+func ConfigureSeasonAverageCache(client *redis.Client, config SeasonAverageCacheConfig) {
+	var c *seasonAverageCache
+	if client != nil && config.TTL > 0 {
+		c = &seasonAverageCache{client: client, ttl: config.TTL}
+	}
+
+	seasonAveragesMu.Lock()
+	seasonAverages = c
+	seasonAveragesMu.Unlock()
+
+	registerStatsHooks.Do(func() {
+		repository.OnEntityWrite(func(entityType string, id int) {
+			if entityType != store.EntityPlayerStats {
+				return
+			}
+			if c := sharedSeasonAverages(); c != nil {
+				c.invalidate(id)
+			}
+		})
+	})
+}
+
+// sharedSeasonAverages returns the current cache, nil when disabled
+func sharedSeasonAverages() *seasonAverageCache {
+	seasonAveragesMu.RLock()
+	defer seasonAveragesMu.RUnlock()
+	return seasonAverages
+}
+
+func seasonAverageKey(playerID int) string {
+	return seasonAverageKeyPrefix + strconv.Itoa(playerID)
+}
+
+func seasonAverageField(seasonYear string, includeSpecial bool) string {
+	if includeSpecial {
+		return seasonYear + ":all"
+	}
+	return seasonYear
+}
+
+func (c *seasonAverageCache) get(ctx context.Context, playerID int, field string) (map[string]float64, bool) {
+	data, err := c.client.HGet(ctx, seasonAverageKey(playerID), field).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			seasonAverageRequests.WithLabelValues("error").Inc()
+			return nil, false
+		}
+		seasonAverageRequests.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+
+	var averages map[string]float64
+	if err := json.Unmarshal(data, &averages); err != nil {
+		seasonAverageRequests.WithLabelValues("error").Inc()
+		return nil, false
+	}
+	seasonAverageRequests.WithLabelValues("hit").Inc()
+	return averages, true
+}
+
+func (c *seasonAverageCache) set(ctx context.Context, playerID int, field string, averages map[string]float64) error {
+	data, err := json.Marshal(averages)
+	if err != nil {
+		return err
+	}
+	key := seasonAverageKey(playerID)
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, key, field, data)
+	pipe.Expire(ctx, key, c.ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (c *seasonAverageCache) invalidate(playerID int) {
+	ctx, cancel := context.WithTimeout(context.Background(), seasonAverageInvalidateTimeout)
+	defer cancel()
+	if err := c.client.Del(ctx, seasonAverageKey(playerID)).Err(); err != nil {
+		log.Printf("[cache] Failed to invalidate season averages for player %d: %v", playerID, err)
+	}
+}
+
+// cachedSeasonAverages reads a player's season averages through the Redis cache.
+// Misses are computed on the primary: a fill from a lagging replica would be
+// served stale until the TTL, after the write that invalidated it.
+func cachedSeasonAverages(ctx context.Context, repo *repository.StatsRepository, playerID int, seasonYear string, includeSpecial bool) (map[string]float64, error) {
+	excluded := store.SpecialGameTypes
+	if includeSpecial {
+		excluded = nil
+	}
+
+	c := sharedSeasonAverages()
+	if c == nil {
+		return repo.GetPlayerSeasonAverages(ctx, playerID, seasonYear, excluded)
+	}
+
+	field := seasonAverageField(seasonYear, includeSpecial)
+	if averages, ok := c.get(ctx, playerID, field); ok {
+		return averages, nil
+	}
+
+	averages, err := repo.GetPlayerSeasonAverages(store.WithPrimary(ctx), playerID, seasonYear, excluded)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.set(ctx, playerID, field, averages); err != nil {
+		log.Printf("[cache] Failed to cache season averages for player %d: %v", playerID, err)
+	}
+	return averages, nil
+}
+
+// WarmSeasonAverages computes and caches the default season averages of every
+// player with a stat line in a final game on date. It returns the number of
+// players cached; with the cache disabled it does nothing.
+func (s *PlayerService) WarmSeasonAverages(ctx context.Context, sport string, date time.Time) (int, error) {
+	c := sharedSeasonAverages()
+	if c == nil {
+		return 0, nil
+	}
+	// Warming runs right after ingestion; read what it wrote, not a replica
+	ctx = store.WithPrimary(ctx)
+
+	players, err := s.statsRepo.GetPlayerSeasonsOnDate(ctx, sport, date)
+	if err != nil {
+		return 0, fmt.Errorf("listing players on %s: %w", date.Format("2006-01-02"), err)
+	}
+
+	warmed := 0
+	for _, p := range players {
+		averages, err := s.statsRepo.GetPlayerSeasonAverages(ctx, p.PlayerID, p.SeasonYear, store.SpecialGameTypes)
+		if err != nil {
+			return warmed, fmt.Errorf("calculating season averages for player %d: %w", p.PlayerID, err)
+		}
+		if err := c.set(ctx, p.PlayerID, seasonAverageField(p.SeasonYear, false), averages); err != nil {
+			return warmed, fmt.Errorf("caching season averages for player %d: %w", p.PlayerID, err)
+		}
+		warmed++
+	}
+	return warmed, nil
+}
@@ -14,6 +14,7 @@ type StatsService struct {
 	playerRepo *repository.PlayerRepository
 	teamRepo   *repository.TeamRepository
 	gameRepo   *repository.GameRepository
+	highlights *repository.HighlightRepository
 }
 
 // NewStatsService creates a new stats service
@@ -23,6 +24,7 @@ func NewStatsService(db *store.Database) *StatsService {
 		playerRepo: repository.NewPlayerRepository(db),
 		teamRepo:   repository.NewTeamRepository(db),
 		gameRepo:   repository.NewGameRepository(db),
+		highlights: repository.NewHighlightRepository(db),
 	}
 }
 
@@ -40,19 +42,41 @@ func (s *StatsService) GetGameBoxScore(ctx context.Context, gameID string) (*Box
 		return nil, fmt.Errorf("fetching box score: %w", err)
 	}
 
+	// Clips tied to a play flag the player in that play for deep-linking
+	highlights, err := s.highlights.GetByGame(ctx, game.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching highlights: %w", err)
+	}
+	highlighted := make(map[int]bool)
+	for _, h := range highlights {
+		if h.PlayerID.Valid {
+			highlighted[int(h.PlayerID.Int32)] = true
+		}
+	}
+
 	// Organize stats by team
 	homeTeamStats := make([]*PlayerStatLine, 0)
 	awayTeamStats := make([]*PlayerStatLine, 0)
 
+	playerIDs := make([]int, len(playerStats))
+	for i, stat := range playerStats {
+		playerIDs[i] = stat.PlayerID
+	}
+	players, err := cachedPlayers(ctx, s.playerRepo, playerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("fetching players: %w", err)
+	}
+
 	for _, stat := range playerStats {
-		player, err := s.playerRepo.GetByID(ctx, stat.PlayerID)
-		if err != nil {
+		player, ok := players[stat.PlayerID]
+		if !ok {
 			continue // Skip if player not found
 		}
 
 		statLine := &PlayerStatLine{
-			Player: player,
-			Stats:  stat,
+			Player:        player,
+			Stats:         stat,
+			HasHighlights: highlighted[stat.PlayerID],
 		}
 
 		if stat.TeamID == game.HomeTeamID {
@@ -62,22 +86,41 @@ func (s *StatsService) GetGameBoxScore(ctx context.Context, gameID string) (*Box
 		}
 	}
 
-	homeTeam, err := s.teamRepo.GetByID(ctx, game.HomeTeamID)
+	homeTeam, err := cachedTeam(ctx, s.teamRepo, game.HomeTeamID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching home team: %w", err)
 	}
 
-	awayTeam, err := s.teamRepo.GetByID(ctx, game.AwayTeamID)
+	awayTeam, err := cachedTeam(ctx, s.teamRepo, game.AwayTeamID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching away team: %w", err)
 	}
 
+	teamTotals, err := s.statsRepo.GetTeamStatsByGameID(ctx, game.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching team stats: %w", err)
+	}
+	var homeTotals, awayTotals *store.TeamGameStats
+	for _, totals := range teamTotals {
+		switch totals.TeamID {
+		case game.HomeTeamID:
+			homeTotals = totals
+		case game.AwayTeamID:
+			awayTotals = totals
+		}
+	}
+
 	return &BoxScore{
-		Game:          game,
-		HomeTeam:      homeTeam,
-		AwayTeam:      awayTeam,
-		HomeTeamStats: homeTeamStats,
-		AwayTeamStats: awayTeamStats,
+		Game:                game,
+		StatsComplete:       game.StatsComplete,
+		HighlightsAvailable: len(highlights) > 0,
+		HighlightCount:      len(highlights),
+		HomeTeam:            homeTeam,
+		AwayTeam:            awayTeam,
+		HomeTeamStats:       homeTeamStats,
+		AwayTeamStats:       awayTeamStats,
+		HomeTeamTotals:      homeTotals,
+		AwayTeamTotals:      awayTotals,
 	}, nil
 }
 
@@ -88,7 +131,7 @@ func (s *StatsService) GetPlayerGameStats(ctx context.Context, gameID string, pl
 		return nil, fmt.Errorf("fetching player game stats: %w", err)
 	}
 
-	player, err := s.playerRepo.GetByID(ctx, playerID)
+	player, err := cachedPlayer(ctx, s.playerRepo, playerID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching player: %w", err)
 	}
@@ -101,16 +144,148 @@ func (s *StatsService) GetPlayerGameStats(ctx context.Context, gameID string, pl
 
 // BoxScore contains the complete box score for a game
 type BoxScore struct {
-	Game          *store.Game        `json:"game"`
-	HomeTeam      *store.Team        `json:"home_team"`
-	AwayTeam      *store.Team        `json:"away_team"`
-	HomeTeamStats []*PlayerStatLine  `json:"home_team_stats"`
-	AwayTeamStats []*PlayerStatLine  `json:"away_team_stats"`
+	Game                *store.Game          `json:"game"`
+	StatsComplete       bool                 `json:"stats_complete"` // false until the post-final refetch finds no late corrections
+	HighlightsAvailable bool                 `json:"highlights_available"`
+	HighlightCount      int                  `json:"highlight_count"`
+	HomeTeam            *store.Team          `json:"home_team"`
+	AwayTeam            *store.Team          `json:"away_team"`
+	HomeTeamStats       []*PlayerStatLine    `json:"home_team_stats"`
+	AwayTeamStats       []*PlayerStatLine    `json:"away_team_stats"`
+	HomeTeamTotals      *store.TeamGameStats `json:"home_team_totals,omitempty"` // team box score, with scoring breakdowns when reported
+	AwayTeamTotals      *store.TeamGameStats `json:"away_team_totals,omitempty"`
 }
 
 // PlayerStatLine combines player info with their game stats
 type PlayerStatLine struct {
-	Player *store.Player          `json:"player"`
-	Stats  *store.PlayerGameStats `json:"stats"`
+	Player        *store.Player          `json:"player"`
+	Stats         *store.PlayerGameStats `json:"stats"`
+	HasHighlights bool                   `json:"has_highlights"` // a clip is linked to one of the player's plays
 }
 
+
+// TeamGameLogs is a team's season of game logs with a record summary
+type TeamGameLogs struct {
+	Team         *store.Team               `json:"team"`
+	Season       string                    `json:"season"`
+	Wins         int                       `json:"wins"`
+	Losses       int                       `json:"losses"`
+	AvgMargin    float64                   `json:"avg_margin"`
+	AvgRebMargin float64                   `json:"avg_rebound_margin"`
+	Games        []*repository.TeamGameLog `json:"games"`
+}
+
+// GetTeamGameLogs retrieves per-game team stats with opponent context for a season
+func (s *StatsService) GetTeamGameLogs(ctx context.Context, teamID int, seasonYear string) (*TeamGameLogs, error) {
+	team, err := cachedTeam(ctx, s.teamRepo, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching team: %w", err)
+	}
+
+	logs, err := s.statsRepo.GetTeamGameLogs(ctx, teamID, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("fetching team game logs: %w", err)
+	}
+
+	result := &TeamGameLogs{
+		Team:   team,
+		Season: seasonYear,
+		Games:  logs,
+	}
+	if result.Games == nil {
+		result.Games = []*repository.TeamGameLog{}
+	}
+
+	var margin, rebMargin int
+	for _, l := range logs {
+		if l.Result == "W" {
+			result.Wins++
+		} else {
+			result.Losses++
+		}
+		margin += l.Margin
+		rebMargin += l.ReboundMargin
+	}
+	if len(logs) > 0 {
+		result.AvgMargin = round1(float64(margin) / float64(len(logs)))
+		result.AvgRebMargin = round1(float64(rebMargin) / float64(len(logs)))
+	}
+
+	return result, nil
+}
+
+// TeamBenchProduction compares a team's starters and bench over a season
+type TeamBenchProduction struct {
+	Team        *store.Team                 `json:"team"`
+	Season      string                      `json:"season"`
+	GamesPlayed int                         `json:"games_played"`
+	Starters    UnitAverages                `json:"starters"`
+	Bench       UnitAverages                `json:"bench"`
+	BenchShare  *float64                    `json:"bench_points_share,omitempty"` // bench share of team points
+	Games       []*repository.TeamUnitSplit `json:"games"`
+}
+
+// UnitAverages is a starter or bench unit's per-game production
+type UnitAverages struct {
+	Minutes   float64 `json:"minutes"`
+	Points    float64 `json:"points"`
+	Rebounds  float64 `json:"rebounds"`
+	Assists   float64 `json:"assists"`
+	PlusMinus float64 `json:"plus_minus"`
+}
+
+// GetTeamBenchProduction splits a team's season production between starters and bench
+func (s *StatsService) GetTeamBenchProduction(ctx context.Context, teamID int, seasonYear string) (*TeamBenchProduction, error) {
+	team, err := cachedTeam(ctx, s.teamRepo, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching team: %w", err)
+	}
+
+	splits, err := s.statsRepo.GetTeamUnitSplits(ctx, teamID, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("fetching unit splits: %w", err)
+	}
+
+	result := &TeamBenchProduction{
+		Team:        team,
+		Season:      seasonYear,
+		GamesPlayed: len(splits),
+		Games:       splits,
+	}
+	if result.Games == nil {
+		result.Games = []*repository.TeamUnitSplit{}
+	}
+	if len(splits) == 0 {
+		return result, nil
+	}
+
+	var starters, bench repository.UnitLine
+	for _, sp := range splits {
+		addUnitLine(&starters, sp.Starters)
+		addUnitLine(&bench, sp.Bench)
+	}
+	games := float64(len(splits))
+	result.Starters = unitAverages(starters, games)
+	result.Bench = unitAverages(bench, games)
+	result.BenchShare = pct(bench.Points, starters.Points+bench.Points)
+
+	return result, nil
+}
+
+func addUnitLine(total *repository.UnitLine, game repository.UnitLine) {
+	total.Minutes += game.Minutes
+	total.Points += game.Points
+	total.Rebounds += game.Rebounds
+	total.Assists += game.Assists
+	total.PlusMinus += game.PlusMinus
+}
+
+func unitAverages(total repository.UnitLine, games float64) UnitAverages {
+	return UnitAverages{
+		Minutes:   round1(total.Minutes / games),
+		Points:    round1(float64(total.Points) / games),
+		Rebounds:  round1(float64(total.Rebounds) / games),
+		Assists:   round1(float64(total.Assists) / games),
+		PlusMinus: round1(float64(total.PlusMinus) / games),
+	}
+}
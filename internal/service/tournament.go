@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// ErrNoTournamentGames is returned when no NBA Cup games are on record for the season
+var ErrNoTournamentGames = errors.New("no tournament games on record")
+
+// TournamentService computes NBA Cup standings from stored games
+type TournamentService struct {
+	tournamentRepo *repository.TournamentRepository
+	teamRepo       *repository.TeamRepository
+}
+
+// NewTournamentService creates a new tournament service
+func NewTournamentService(db *store.Database) *TournamentService {
+	return &TournamentService{
+		tournamentRepo: repository.NewTournamentRepository(db),
+		teamRepo:       repository.NewTeamRepository(db),
+	}
+}
+
+// TournamentStandings holds group tables, wild cards, and the knockout bracket
+type TournamentStandings struct {
+	Season    string                  `json:"season"`
+	Groups    []*TournamentGroup      `json:"groups"`
+	WildCards []*TournamentStanding   `json:"wild_cards"` // best second-place team per conference
+	Knockout  []*store.TournamentGame `json:"knockout"`
+}
+
+// TournamentGroup is one group's table in rank order
+type TournamentGroup struct {
+	Name       string                `json:"name"`
+	Conference string                `json:"conference"`
+	Teams      []*TournamentStanding `json:"teams"`
+}
+
+// TournamentStanding is a team's group stage record
+type TournamentStanding struct {
+	Rank              int         `json:"rank"`
+	Team              *store.Team `json:"team"`
+	Group             string      `json:"group"`
+	Wins              int         `json:"wins"`
+	Losses            int         `json:"losses"`
+	PointsFor         int         `json:"points_for"`
+	PointsAgainst     int         `json:"points_against"`
+	PointDifferential int         `json:"point_differential"`
+	GroupWinner       bool        `json:"group_winner"`
+	WildCard          bool        `json:"wild_card"`
+
+	teamID int
+}
+
+// GetStandings returns group standings for a season year such as "2024-25".
+// An empty seasonYear selects the most recent tournament on record.
+func (s *TournamentService) GetStandings(ctx context.Context, sport, seasonYear string) (*TournamentStandings, error) {
+	if seasonYear == "" {
+		latest, err := s.tournamentRepo.LatestSeasonYear(ctx, sport)
+		if err != nil {
+			return nil, ErrNoTournamentGames
+		}
+		seasonYear = latest
+	}
+
+	games, err := s.tournamentRepo.GetGames(ctx, sport, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("fetching tournament games: %w", err)
+	}
+	if len(games) == 0 {
+		return nil, ErrNoTournamentGames
+	}
+
+	standings := &TournamentStandings{
+		Season:    seasonYear,
+		WildCards: []*TournamentStanding{},
+		Knockout:  []*store.TournamentGame{},
+	}
+
+	records := make(map[int]*TournamentStanding)
+	var groupNames []string
+	var groupGames []*store.TournamentGame
+	for _, g := range games {
+		if g.Round != store.TournamentRoundGroup {
+			standings.Knockout = append(standings.Knockout, g)
+			continue
+		}
+
+		for _, teamID := range []int{g.HomeTeamID, g.AwayTeamID} {
+			if _, ok := records[teamID]; !ok {
+				records[teamID] = &TournamentStanding{Group: g.Group, teamID: teamID}
+				if !slices.Contains(groupNames, g.Group) {
+					groupNames = append(groupNames, g.Group)
+				}
+			}
+		}
+		if g.Status != "final" || !g.HomeScore.Valid || !g.AwayScore.Valid {
+			continue
+		}
+		groupGames = append(groupGames, g)
+
+		home, away := records[g.HomeTeamID], records[g.AwayTeamID]
+		homePts, awayPts := int(g.HomeScore.Int32), int(g.AwayScore.Int32)
+		home.PointsFor += homePts
+		home.PointsAgainst += awayPts
+		away.PointsFor += awayPts
+		away.PointsAgainst += homePts
+		if homePts > awayPts {
+			home.Wins++
+			away.Losses++
+		} else {
+			away.Wins++
+			home.Losses++
+		}
+	}
+
+	for _, rec := range records {
+		rec.PointDifferential = rec.PointsFor - rec.PointsAgainst
+		team, err := cachedTeam(ctx, s.teamRepo, rec.teamID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching team %d: %w", rec.teamID, err)
+		}
+		rec.Team = team
+	}
+
+	sort.Strings(groupNames)
+	runnersUp := make(map[string][]*TournamentStanding)
+	for _, name := range groupNames {
+		group := &TournamentGroup{Name: name, Conference: groupConference(name)}
+		for _, rec := range records {
+			if rec.Group == name {
+				group.Teams = append(group.Teams, rec)
+			}
+		}
+		rankGroup(group.Teams, groupGames)
+
+		for i, rec := range group.Teams {
+			rec.Rank = i + 1
+		}
+		if len(group.Teams) > 0 {
+			group.Teams[0].GroupWinner = true
+		}
+		if len(group.Teams) > 1 {
+			runnersUp[group.Conference] = append(runnersUp[group.Conference], group.Teams[1])
+		}
+		standings.Groups = append(standings.Groups, group)
+	}
+
+	for _, conference := range []string{"East", "West"} {
+		candidates := runnersUp[conference]
+		if len(candidates) == 0 {
+			continue
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return outranks(candidates[i], candidates[j])
+		})
+		candidates[0].WildCard = true
+		standings.WildCards = append(standings.WildCards, candidates[0])
+	}
+
+	return standings, nil
+}
+
+// rankGroup orders a group by wins. Tied teams are ordered by head-to-head wins
+// among themselves, then point differential, then points scored.
+func rankGroup(teams []*TournamentStanding, games []*store.TournamentGame) {
+	tiedWins := make(map[int]int)
+	for _, rec := range teams {
+		tiedWins[rec.Wins]++
+	}
+
+	headToHead := make(map[int]int)
+	for _, rec := range teams {
+		if tiedWins[rec.Wins] < 2 {
+			continue
+		}
+		for _, g := range games {
+			if g.HomeTeamID != rec.teamID && g.AwayTeamID != rec.teamID {
+				continue
+			}
+			opponent := g.AwayTeamID
+			if opponent == rec.teamID {
+				opponent = g.HomeTeamID
+			}
+			if !tiedWith(teams, opponent, rec.Wins) {
+				continue
+			}
+			if winner(g) == rec.teamID {
+				headToHead[rec.teamID]++
+			}
+		}
+	}
+
+	sort.SliceStable(teams, func(i, j int) bool {
+		a, b := teams[i], teams[j]
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		if headToHead[a.teamID] != headToHead[b.teamID] {
+			return headToHead[a.teamID] > headToHead[b.teamID]
+		}
+		return outranks(a, b)
+	})
+}
+
+// outranks compares teams on record, point differential, points scored, then name
+func outranks(a, b *TournamentStanding) bool {
+	if a.Wins != b.Wins {
+		return a.Wins > b.Wins
+	}
+	if a.PointDifferential != b.PointDifferential {
+		return a.PointDifferential > b.PointDifferential
+	}
+	if a.PointsFor != b.PointsFor {
+		return a.PointsFor > b.PointsFor
+	}
+	return a.Team.Abbreviation < b.Team.Abbreviation
+}
+
+func tiedWith(teams []*TournamentStanding, teamID, wins int) bool {
+	for _, rec := range teams {
+		if rec.teamID == teamID {
+			return rec.Wins == wins
+		}
+	}
+	return false
+}
+
+func winner(g *store.TournamentGame) int {
+	if g.HomeScore.Int32 > g.AwayScore.Int32 {
+		return g.HomeTeamID
+	}
+	return g.AwayTeamID
+}
+
+// groupConference reads the conference from a group name like "East Group A"
+func groupConference(group string) string {
+	conference, _, _ := strings.Cut(group, " ")
+	return conference
+}
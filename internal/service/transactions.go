@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// TransactionService reads the roster transaction feed
+type TransactionService struct {
+	txnRepo  *repository.TransactionRepository
+	teamRepo *repository.TeamRepository
+}
+
+// NewTransactionService creates a new transaction service
+func NewTransactionService(db *store.Database) *TransactionService {
+	return &TransactionService{
+		txnRepo:  repository.NewTransactionRepository(db),
+		teamRepo: repository.NewTeamRepository(db),
+	}
+}
+
+// ListTransactions returns a sport's transactions on or after since, newest
+// first. team is a team ID or abbreviation; empty matches every team.
+func (s *TransactionService) ListTransactions(ctx context.Context, sport, team string, since time.Time, limit int) ([]*store.PlayerTransaction, error) {
+	teamID := 0
+	if team != "" {
+		t, err := resolveTeam(ctx, s.teamRepo, team)
+		if err != nil {
+			return nil, err
+		}
+		teamID = t.TeamID
+	}
+
+	transactions, err := s.txnRepo.List(ctx, sport, teamID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	if transactions == nil {
+		transactions = []*store.PlayerTransaction{}
+	}
+	return transactions, nil
+}
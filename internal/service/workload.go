@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// Fatigue index reference loads: a heavy week is four 42-minute games, a heavy
+// three days is two, and three back-to-backs in a fortnight is a full schedule.
+const (
+	heavyMinutes7d      = 168.0
+	heavyMinutes3d      = 84.0
+	heavyBackToBacks14d = 3.0
+)
+
+// WorkloadService tracks rolling minute loads and fatigue per player
+type WorkloadService struct {
+	workloadRepo *repository.WorkloadRepository
+}
+
+// NewWorkloadService creates a new workload service
+func NewWorkloadService(db *store.Database) *WorkloadService {
+	return &WorkloadService{
+		workloadRepo: repository.NewWorkloadRepository(db),
+	}
+}
+
+// PlayerWorkloadReport is a player's current load plus stored nightly history
+type PlayerWorkloadReport struct {
+	PlayerID int                     `json:"player_id"`
+	Current  *store.PlayerWorkload   `json:"current"`
+	History  []*store.PlayerWorkload `json:"history"`
+}
+
+// Refresh computes and stores snapshots as of a date for every player who appeared
+// in the trailing 14 days, returning how many were written
+func (s *WorkloadService) Refresh(ctx context.Context, sport string, asOf time.Time) (int, error) {
+	workloads, err := s.workloadRepo.ComputeWindows(ctx, sport, asOf, 0)
+	if err != nil {
+		return 0, fmt.Errorf("computing workloads: %w", err)
+	}
+	for _, w := range workloads {
+		w.FatigueIndex = FatigueIndex(w)
+	}
+
+	if err := s.workloadRepo.Save(ctx, workloads); err != nil {
+		return 0, err
+	}
+	return len(workloads), nil
+}
+
+// GetCurrent computes a player's load as of today from stored box scores. A player
+// with no games in the trailing 14 days gets an all-zero workload.
+func (s *WorkloadService) GetCurrent(ctx context.Context, sport string, playerID int) (*store.PlayerWorkload, error) {
+	return s.getAsOf(ctx, sport, playerID, time.Now())
+}
+
+// GetBefore computes a player's workload from the games dated strictly before
+// date, i.e. the windows ending the day before
+func (s *WorkloadService) GetBefore(ctx context.Context, sport string, playerID int, date time.Time) (*store.PlayerWorkload, error) {
+	return s.getAsOf(ctx, sport, playerID, date.AddDate(0, 0, -1))
+}
+
+func (s *WorkloadService) getAsOf(ctx context.Context, sport string, playerID int, asOf time.Time) (*store.PlayerWorkload, error) {
+	workloads, err := s.workloadRepo.ComputeWindows(ctx, sport, asOf, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("computing workload: %w", err)
+	}
+	if len(workloads) == 0 {
+		return &store.PlayerWorkload{
+			PlayerID: playerID,
+			AsOfDate: time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC),
+		}, nil
+	}
+
+	w := workloads[0]
+	w.FatigueIndex = FatigueIndex(w)
+	return w, nil
+}
+
+// GetPlayerWorkload returns a player's current load and up to days nightly snapshots
+func (s *WorkloadService) GetPlayerWorkload(ctx context.Context, sport string, playerID, days int) (*PlayerWorkloadReport, error) {
+	current, err := s.GetCurrent(ctx, sport, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.workloadRepo.GetHistory(ctx, playerID, days)
+	if err != nil {
+		return nil, fmt.Errorf("fetching workload history: %w", err)
+	}
+
+	return &PlayerWorkloadReport{PlayerID: playerID, Current: current, History: history}, nil
+}
+
+// FatigueIndex scores a workload from 0 (rested) to 100. The last week carries half
+// the weight, the last three days 30%, and back-to-backs in the fortnight 20%; each
+// component saturates at its heavy reference load.
+func FatigueIndex(w *store.PlayerWorkload) float64 {
+	score := 0.5*math.Min(w.Minutes7d/heavyMinutes7d, 1) +
+		0.3*math.Min(w.Minutes3d/heavyMinutes3d, 1) +
+		0.2*math.Min(float64(w.BackToBacks14d)/heavyBackToBacks14d, 1)
+	return round1(100 * score)
+}
@@ -0,0 +1,199 @@
+// Package startup waits for the service's backing stores before it starts,
+// retrying each with exponential backoff and jitter.
+package startup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Dependency states reported by Status
+const (
+	StateWaiting = "waiting"
+	StateReady   = "ready"
+)
+
+// Config holds startup retry settings
+type Config struct {
+	InitialBackoff time.Duration // first retry delay, doubled per attempt
+	MaxBackoff     time.Duration // ceiling on a single retry delay
+	MaxWait        time.Duration // give up on a dependency after this long; 0 waits indefinitely
+	Degraded       bool          // past MaxWait, serve dependency status on the API port and keep retrying instead of exiting
+}
+
+// DefaultConfig returns default startup configuration
+func DefaultConfig() Config {
+	return Config{
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		MaxWait:        2 * time.Minute,
+	}
+}
+
+// Dependency is a store the service needs before it can start. Connect makes
+// one attempt and keeps whatever it connected on success.
+type Dependency struct {
+	Name    string
+	Connect func(ctx context.Context) error
+}
+
+// DependencyStatus is one dependency's progress
+type DependencyStatus struct {
+	Name      string     `json:"name"`
+	State     string     `json:"state"`
+	Attempts  int        `json:"attempts"`
+	LastError string     `json:"last_error,omitempty"`
+	ReadyAt   *time.Time `json:"ready_at,omitempty"`
+}
+
+// Manager connects dependencies concurrently and tracks their progress
+type Manager struct {
+	config Config
+
+	mu       sync.Mutex
+	statuses map[string]*DependencyStatus
+}
+
+// NewManager creates a startup dependency manager
+func NewManager(config Config) *Manager {
+	defaults := DefaultConfig()
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaults.InitialBackoff
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = max(defaults.MaxBackoff, config.InitialBackoff)
+	}
+	return &Manager{config: config, statuses: make(map[string]*DependencyStatus)}
+}
+
+// Wait connects every dependency not yet ready, retrying each until it
+// connects, maxWait passes (0 never gives up), or ctx is cancelled. The error
+// names every dependency still unavailable.
+func (m *Manager) Wait(ctx context.Context, maxWait time.Duration, deps ...Dependency) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(deps))
+	for i, dep := range deps {
+		if m.ready(dep.Name) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = m.connect(ctx, maxWait, dep)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (m *Manager) connect(ctx context.Context, maxWait time.Duration, dep Dependency) error {
+	if maxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, maxWait)
+		defer cancel()
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := dep.Connect(ctx)
+		m.record(dep.Name, err)
+		if err == nil {
+			if attempt > 0 {
+				log.Printf("✓ %s available after %d attempts", dep.Name, attempt+1)
+			}
+			return nil
+		}
+
+		delay := Backoff(attempt, m.config.InitialBackoff, m.config.MaxBackoff)
+		log.Printf("%s not available (attempt %d): %v (retrying in %v)", dep.Name, attempt+1, err, delay.Round(time.Millisecond))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s unavailable after %d attempts: %w", dep.Name, attempt+1, err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Backoff is the delay before retry attempt+1: initial doubled per attempt up
+// to ceiling, with the upper half drawn at random so replicas restarting
+// together don't retry in lockstep
+func Backoff(attempt int, initial, ceiling time.Duration) time.Duration {
+	delay := ceiling
+	if attempt < 32 {
+		delay = min(initial<<attempt, ceiling)
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+func (m *Manager) record(name string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status, ok := m.statuses[name]
+	if !ok {
+		status = &DependencyStatus{Name: name}
+		m.statuses[name] = status
+	}
+	status.Attempts++
+	if err != nil {
+		status.State = StateWaiting
+		status.LastError = err.Error()
+		return
+	}
+	status.State = StateReady
+	status.LastError = ""
+	now := time.Now().UTC()
+	status.ReadyAt = &now
+}
+
+func (m *Manager) ready(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status, ok := m.statuses[name]
+	return ok && status.State == StateReady
+}
+
+// Status reports every dependency attempted so far, by name
+func (m *Manager) Status() []DependencyStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]DependencyStatus, 0, len(m.statuses))
+	for _, status := range m.statuses {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// DegradedHandler answers every request with 503 and the dependency status,
+// so health checks and clients see why the service hasn't started
+func (m *Manager) DegradedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		statuses := m.Status()
+		var waiting []string
+		for _, status := range statuses {
+			if status.State != StateReady {
+				waiting = append(waiting, status.Name)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":       "degraded",
+			"error":        "waiting for " + strings.Join(waiting, ", "),
+			"dependencies": statuses,
+		})
+	})
+}
@@ -0,0 +1,21 @@
+package store
+
+import "context"
+
+type contextKey int
+
+const primaryKey contextKey = iota
+
+// WithPrimary marks the context so reads are served by the primary database.
+// Use it for read-after-write paths that cannot tolerate replica lag.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey, true)
+}
+
+func primaryRequired(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	required, _ := ctx.Value(primaryKey).(bool)
+	return required
+}
@@ -3,189 +3,249 @@ package store
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log"
-	"os"
-	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/lib/pq"
 )
 
+// replicaCheckInterval controls how often the read replica is probed
+const replicaCheckInterval = 10 * time.Second
+
+// PoolConfig controls connection pool sizing for the primary and replica pools
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// DefaultPoolConfig returns the pool settings used when nothing is configured
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    20,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: time.Hour,
+		ConnMaxIdleTime: 10 * time.Minute,
+	}
+}
+
+// apply configures a *sql.DB with the pool settings
+func (p PoolConfig) apply(db *sql.DB) {
+	db.SetMaxOpenConns(p.MaxOpenConns)
+	db.SetMaxIdleConns(p.MaxIdleConns)
+	db.SetConnMaxLifetime(p.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+}
+
 // Database represents the Atlas PostgreSQL database connection
 type Database struct {
 	conn *sql.DB
 	dsn  string
+	pool PoolConfig
+
+	timeouts QueryTimeouts
+	observer *queryObserver
+
+	// Optional directory overriding the embedded migrations
+	migrationsDir string
+
+	// Optional read replica. Reads fall back to the primary while it is unhealthy.
+	replica        *sql.DB
+	replicaProbe   driver.Connector
+	replicaHealthy atomic.Bool
+	stopMonitor    chan struct{}
+	closeOnce      sync.Once
 }
 
-// NewDatabase creates a new database connection to Atlas
+// NewDatabase creates a new database connection to Atlas using the default pool settings
 func NewDatabase(dsn string) (*Database, error) {
-	db, err := sql.Open("postgres", dsn)
+	return NewDatabaseWithPool(dsn, DefaultPoolConfig())
+}
+
+// NewDatabaseWithPool creates a new database connection with explicit pool settings
+func NewDatabaseWithPool(dsn string, pool PoolConfig) (*Database, error) {
+	observer := newQueryObserver()
+	db, err := openInstrumented(dsn, observer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	// Configure connection pool
-	db.SetMaxOpenConns(20)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(time.Hour)
-	db.SetConnMaxIdleTime(10 * time.Minute)
+	pool.apply(db)
 
 	// Test connection
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	trackPool("primary", db)
+
 	return &Database{
-		conn: db,
-		dsn:  dsn,
+		conn:     db,
+		dsn:      dsn,
+		pool:     pool,
+		timeouts: DefaultQueryTimeouts(),
+		observer: observer,
 	}, nil
 }
 
-// Close closes the database connection
-func (db *Database) Close() error {
-	if db.conn != nil {
-		return db.conn.Close()
+// NewDatabaseFromDB wraps an already open pool, such as one on a stub driver in
+// tests. Its queries aren't instrumented and it has no replica.
+func NewDatabaseFromDB(conn *sql.DB) *Database {
+	return &Database{
+		conn:     conn,
+		timeouts: DefaultQueryTimeouts(),
+		observer: newQueryObserver(),
 	}
-	return nil
 }
 
-// DB returns the underlying *sql.DB for queries
-func (db *Database) DB() *sql.DB {
-	return db.conn
+// openInstrumented opens a pool whose connections report to observer
+func openInstrumented(dsn string, observer *queryObserver) (*sql.DB, error) {
+	connector, err := newInstrumentedConnector(dsn, observer)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector), nil
 }
 
-// RunMigrations executes all migration files in order
-func (db *Database) RunMigrations() error {
-	log.Println("Running database migrations...")
-
-	// Create migrations tracking table
-	if err := db.createMigrationsTable(); err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
+func newInstrumentedConnector(dsn string, observer *queryObserver) (*instrumentedConnector, error) {
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
 	}
+	return &instrumentedConnector{Connector: connector, observer: observer}, nil
+}
 
-	// Get list of migration files (v2 schema)
-	migrations := []string{
-		"010_drop_all_tables.sql",
-		"011_create_seasons_v2.sql",
-		"012_create_teams_v2.sql",
-		"013_create_players_v2.sql",
-		"014_create_player_team_history.sql",
-		"015_create_games_v2.sql",
-		"016_create_player_game_stats_v2.sql",
-		"017_create_team_game_stats_v2.sql",
-		"018_create_odds_mappings_v2.sql",
-		"019_create_backfill_jobs_v2.sql",
-		"020_create_triggers.sql",
-		"021_create_materialized_views.sql",
+// AttachReplica connects a read replica used by ReadDB.
+// The replica is probed periodically; while it is unreachable reads go to the
+// primary. A query that fails because the replica went away is retried on the
+// primary (see replicaConn).
+func (db *Database) AttachReplica(dsn string) error {
+	replica, err := newInstrumentedConnector(dsn, db.observer)
+	if err != nil {
+		return fmt.Errorf("failed to open read replica: %w", err)
 	}
-
-	// Run each migration
-	for _, migration := range migrations {
-		if err := db.runMigration(migration); err != nil {
-			return fmt.Errorf("failed to run migration %s: %w", migration, err)
-		}
+	primary, err := newInstrumentedConnector(db.dsn, db.observer)
+	if err != nil {
+		return fmt.Errorf("failed to open primary fallback for read replica: %w", err)
 	}
-
-	log.Println("✓ All migrations completed successfully")
-
+	db.attachReplica(replica, primary)
 	return nil
 }
 
-// createMigrationsTable creates a table to track which migrations have been run
-func (db *Database) createMigrationsTable() error {
-	query := `
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			version VARCHAR(255) PRIMARY KEY,
-			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
-		)
-	`
-	_, err := db.conn.Exec(query)
-	return err
-}
-
-// runMigration runs a single migration file if it hasn't been applied yet
-func (db *Database) runMigration(filename string) error {
-	// Check if already applied
-	var exists bool
-	err := db.conn.QueryRow("SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = $1)", filename).Scan(&exists)
-	if err != nil {
-		return err
-	}
-
-	if exists {
-		log.Printf("  ⊘ Skipping %s (already applied)", filename)
-		return nil
+// attachReplica sets up the replica pool over replica, falling back to primary
+func (db *Database) attachReplica(replica, primary driver.Connector) {
+	db.replicaProbe = replica
+	db.replica = sql.OpenDB(&replicaConnector{replica: replica, primary: primary, db: db})
+	db.pool.apply(db.replica)
+	trackPool("replica", db.replica)
+
+	db.stopMonitor = make(chan struct{})
+	db.replicaHealthy.Store(db.pingReplica() == nil)
+	if !db.replicaHealthy.Load() {
+		log.Println("⚠️  Read replica unreachable at startup, reads will use the primary")
 	}
 
-	// Read migration file from disk
-	migrationPath := filepath.Join("infra", "atlas", "migrations", filename)
-	content, err := os.ReadFile(migrationPath)
-	if err != nil {
-		// Try alternate path for Docker container
-		migrationPath = filepath.Join("migrations", filename)
-		content, err = os.ReadFile(migrationPath)
-		if err != nil {
-			return fmt.Errorf("failed to read migration file: %w", err)
+	go db.monitorReplica()
+}
+// monitorReplica flips the replica health flag as pings succeed or fail
+func (db *Database) monitorReplica() {
+	ticker := time.NewTicker(replicaCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-db.stopMonitor:
+			return
+		case <-ticker.C:
+			healthy := db.pingReplica() == nil
+			if previous := db.replicaHealthy.Swap(healthy); previous != healthy {
+				if healthy {
+					log.Println("✓ Read replica recovered, routing reads to replica")
+				} else {
+					log.Println("⚠️  Read replica unhealthy, falling back to primary for reads")
+				}
+			}
 		}
 	}
+}
 
-	// Execute migration in a transaction
-	tx, err := db.conn.Begin()
+// pingReplica connects to the replica itself; the replica pool would answer
+// from the primary while the replica is marked down
+func (db *Database) pingReplica() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	conn, err := db.replicaProbe.Connect(ctx)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+	defer conn.Close()
+	return conn.(driver.Pinger).Ping(ctx)
+}
 
-	if _, err := tx.Exec(string(content)); err != nil {
-		return fmt.Errorf("failed to execute migration: %w", err)
-	}
+// Close closes the database connection
+func (db *Database) Close() error {
+	var err error
+	db.closeOnce.Do(func() {
+		if db.stopMonitor != nil {
+			close(db.stopMonitor)
+		}
+		if db.replica != nil {
+			if rerr := db.replica.Close(); rerr != nil {
+				log.Printf("⚠️  Failed to close read replica: %v", rerr)
+			}
+		}
+		if db.conn != nil {
+			err = db.conn.Close()
+		}
+	})
+	return err
+}
 
-	// Record migration as applied
-	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", filename); err != nil {
-		return err
-	}
+// DB returns the underlying *sql.DB for queries
+func (db *Database) DB() *sql.DB {
+	return db.conn
+}
 
-	if err := tx.Commit(); err != nil {
-		return err
+// ReadDB returns the connection to use for read-only queries.
+// It routes to the read replica when one is attached and healthy, unless the
+// context was marked with WithPrimary (e.g. read-after-write during ingestion).
+// A replica query that fails to reach the replica is retried on the primary;
+// one that loses the replica while its rows are being read returns the error.
+func (db *Database) ReadDB(ctx context.Context) *sql.DB {
+	if db.replica == nil || !db.replicaHealthy.Load() || primaryRequired(ctx) {
+		return db.conn
 	}
-
-	log.Printf("  ✓ Applied %s", filename)
-	return nil
+	return db.replica
 }
 
-// runSeedData runs seed data files (teams and seasons)
-// SeedData inserts initial data into the database
-func (db *Database) SeedData() error {
-	log.Println("Running seed data...")
+// HasReplica reports whether a read replica is configured
+func (db *Database) HasReplica() bool {
+	return db.replica != nil
+}
 
-	seedFiles := []string{
-		"001_teams.sql",
-		"002_seasons.sql",
+// ReplicaHealthCheck pings the read replica, if configured
+func (db *Database) ReplicaHealthCheck() error {
+	if db.replica == nil {
+		return nil
 	}
+	return db.pingReplica()
+}
 
-	for _, seedFile := range seedFiles {
-		// Read seed file from disk
-		seedPath := filepath.Join("infra", "atlas", "seed", seedFile)
-		content, err := os.ReadFile(seedPath)
-		if err != nil {
-			// Try alternate path for Docker container
-			seedPath = filepath.Join("seed", seedFile)
-			content, err = os.ReadFile(seedPath)
-			if err != nil {
-				return fmt.Errorf("failed to read seed file %s: %w", seedFile, err)
-			}
-		}
-
-		if _, err := db.conn.Exec(string(content)); err != nil {
-			return fmt.Errorf("failed to execute seed file %s: %w", seedFile, err)
-		}
-
-		log.Printf("  ✓ Seeded %s", seedFile)
+// PoolStats returns connection pool statistics for the primary and, if attached, the replica
+func (db *Database) PoolStats() map[string]sql.DBStats {
+	stats := map[string]sql.DBStats{"primary": db.conn.Stats()}
+	if db.replica != nil {
+		stats["replica"] = db.replica.Stats()
 	}
-
-	log.Println("✓ Seed data completed successfully")
-	return nil
+	return stats
 }
 
 // HealthCheck performs a health check on the database
@@ -0,0 +1,59 @@
+package store
+
+// DiffGame returns the live-state columns (score, status, period, clock) that
+// differ between two versions of a game
+func DiffGame(prev, next *Game) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	if prev.HomeScore != next.HomeScore {
+		changes["home_score"] = FieldChange{From: prev.HomeScore, To: next.HomeScore}
+	}
+	if prev.AwayScore != next.AwayScore {
+		changes["away_score"] = FieldChange{From: prev.AwayScore, To: next.AwayScore}
+	}
+	if prev.Status != next.Status {
+		changes["status"] = FieldChange{From: prev.Status, To: next.Status}
+	}
+	if prev.Period != next.Period {
+		changes["period"] = FieldChange{From: prev.Period, To: next.Period}
+	}
+	if prev.Clock != next.Clock {
+		changes["clock"] = FieldChange{From: prev.Clock, To: next.Clock}
+	}
+	return changes
+}
+
+// DiffPlayerStats returns the counting stats that moved between two versions of
+// a stat line. A nil prev is treated as an empty line, so a new line's deltas
+// are its totals.
+func DiffPlayerStats(prev, next *PlayerGameStats) map[string]float64 {
+	if prev == nil {
+		prev = &PlayerGameStats{}
+	}
+
+	deltas := make(map[string]float64)
+	add := func(name string, before, after float64) {
+		if after != before {
+			deltas[name] = after - before
+		}
+	}
+	add("points", float64(prev.Points), float64(next.Points))
+	add("rebounds", float64(prev.Rebounds), float64(next.Rebounds))
+	add("offensive_rebounds", float64(prev.OffensiveRebounds), float64(next.OffensiveRebounds))
+	add("defensive_rebounds", float64(prev.DefensiveRebounds), float64(next.DefensiveRebounds))
+	add("assists", float64(prev.Assists), float64(next.Assists))
+	add("steals", float64(prev.Steals), float64(next.Steals))
+	add("blocks", float64(prev.Blocks), float64(next.Blocks))
+	add("turnovers", float64(prev.Turnovers), float64(next.Turnovers))
+	add("personal_fouls", float64(prev.PersonalFouls), float64(next.PersonalFouls))
+	add("technical_fouls", float64(prev.TechnicalFouls.Int32), float64(next.TechnicalFouls.Int32))
+	add("flagrant_fouls", float64(prev.FlagrantFouls.Int32), float64(next.FlagrantFouls.Int32))
+	add("field_goals_made", float64(prev.FieldGoalsMade), float64(next.FieldGoalsMade))
+	add("field_goals_attempted", float64(prev.FieldGoalsAttempted), float64(next.FieldGoalsAttempted))
+	add("three_pointers_made", float64(prev.ThreePointersMade), float64(next.ThreePointersMade))
+	add("three_pointers_attempted", float64(prev.ThreePointersAttempted), float64(next.ThreePointersAttempted))
+	add("free_throws_made", float64(prev.FreeThrowsMade), float64(next.FreeThrowsMade))
+	add("free_throws_attempted", float64(prev.FreeThrowsAttempted), float64(next.FreeThrowsAttempted))
+	add("minutes_played", prev.MinutesPlayed.Float64, next.MinutesPlayed.Float64)
+	add("plus_minus", float64(prev.PlusMinus.Int32), float64(next.PlusMinus.Int32))
+	return deltas
+}
@@ -0,0 +1,39 @@
+package store
+
+import "math"
+
+// Elo parameters, after FiveThirtyEight's NBA model
+const (
+	EloInitial         = 1500.0 // rating of a team's first game, and the league mean
+	EloK               = 20.0
+	EloHomeAdvantage   = 100.0
+	EloSeasonCarryover = 0.75 // share of a rating kept into the next season; the rest reverts to the mean
+)
+
+// EloGameTypes are the game types that move ratings. Preseason and exhibition
+// games are left out.
+var EloGameTypes = []string{GameTypeRegular, GameTypePlayIn, GameTypeTournament, GameTypeTournamentFinal, GameTypePlayoffs}
+
+// EloWinProbability is the chance the home team wins, from both pregame ratings
+func EloWinProbability(homeElo, awayElo float64) float64 {
+	return 1 / (1 + math.Pow(10, -(homeElo+EloHomeAdvantage-awayElo)/400))
+}
+
+// EloShift is how many points the home team gains from a final score, and the
+// away team loses. Wins by more move ratings further, damped for favorites so
+// their expected blowouts don't inflate them.
+func EloShift(homeElo, awayElo float64, homeScore, awayScore int) float64 {
+	expected := EloWinProbability(homeElo, awayElo)
+	margin := homeScore - awayScore
+	result, winnerEdge := 1.0, homeElo+EloHomeAdvantage-awayElo
+	if margin < 0 {
+		margin, result, winnerEdge = -margin, 0, -winnerEdge
+	}
+	multiplier := math.Pow(float64(margin)+3, 0.8) / (7.5 + 0.006*winnerEdge)
+	return EloK * multiplier * (result - expected)
+}
+
+// EloCarryOver is a rating at the start of a new season
+func EloCarryOver(elo float64) float64 {
+	return EloSeasonCarryover*elo + (1-EloSeasonCarryover)*EloInitial
+}
@@ -0,0 +1,43 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// Errors repositories wrap so callers can tell a missing or clashing record
+// from a database failure. Test with errors.Is.
+var (
+	// ErrNotFound is returned when a lookup matches no record
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a write clashes with an existing record
+	ErrConflict = errors.New("conflict")
+)
+
+// NotFound returns ErrNotFound annotated with what was looked up, e.g.
+// NotFound("player %d", id) reads "player 42: not found"
+func NotFound(format string, args ...interface{}) error {
+	return fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), ErrNotFound)
+}
+
+// IsNotFound reports whether err means a lookup found nothing, whether typed
+// by a repository or a bare sql.ErrNoRows
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, sql.ErrNoRows)
+}
+
+// ConflictError types a write error as ErrConflict when Postgres rejected it
+// for violating a unique or exclusion constraint, and returns err unchanged otherwise
+func ConflictError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// 23505 = unique_violation, 23P01 = exclusion_violation
+		if pqErr.Code == "23505" || pqErr.Code == "23P01" {
+			return fmt.Errorf("%w: %v", ErrConflict, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,27 @@
+package store
+
+// Game types stored in games.game_type
+const (
+	GameTypeRegular         = "regular"
+	GameTypePreseason       = "preseason"
+	GameTypePlayoffs        = "playoffs"
+	GameTypePlayIn          = "play_in"
+	GameTypeTournament      = "tournament"       // NBA Cup group and knockout games; count toward the season
+	GameTypeTournamentFinal = "tournament_final" // NBA Cup championship; does not count
+	GameTypeAllStar         = "all_star"
+	GameTypeRisingStars     = "rising_stars"
+)
+
+// SpecialGameTypes are exhibition and event games left out of season averages,
+// leaderboards, and career totals unless a caller asks for them
+var SpecialGameTypes = []string{GameTypeAllStar, GameTypeRisingStars, GameTypeTournamentFinal}
+
+// ValidGameType reports whether t is a known game type
+func ValidGameType(t string) bool {
+	switch t {
+	case GameTypeRegular, GameTypePreseason, GameTypePlayoffs, GameTypePlayIn,
+		GameTypeTournament, GameTypeTournamentFinal, GameTypeAllStar, GameTypeRisingStars:
+		return true
+	}
+	return false
+}
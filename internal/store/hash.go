@@ -0,0 +1,112 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UpsertResult reports what an idempotent upsert did to the stored row
+type UpsertResult int
+
+const (
+	// UpsertInserted means the row did not exist and was created
+	UpsertInserted UpsertResult = iota
+	// UpsertUpdated means the row existed and its content changed
+	UpsertUpdated
+	// UpsertUnchanged means the stored content hash matched and the write was skipped
+	UpsertUnchanged
+)
+
+// Changed reports whether the upsert wrote anything
+func (r UpsertResult) Changed() bool {
+	return r != UpsertUnchanged
+}
+
+// String returns a readable name for the result
+func (r UpsertResult) String() string {
+	switch r {
+	case UpsertInserted:
+		return "inserted"
+	case UpsertUpdated:
+		return "updated"
+	default:
+		return "unchanged"
+	}
+}
+
+// ContentHash returns a stable digest of the ingested game fields.
+// Timestamps managed by the database are deliberately excluded.
+func (g *Game) ContentHash() string {
+	values := []interface{}{
+		g.Sport, g.SeasonID, g.ExternalID, g.GameDate.Format("2006-01-02"), nullTimeKey(g.GameTime.Valid, g.GameTime.Time),
+		g.HomeTeamID, g.AwayTeamID, g.HomeScore, g.AwayScore, g.Status,
+		g.Period, g.Clock, g.Venue, g.Attendance, g.Metadata, g.GameType,
+	}
+	values = appendIfSet(values, len(g.Broadcasts) > 0, strings.Join(g.Broadcasts, ","))
+	values = appendIfSet(values, g.League != "" && g.League != LeagueNBA, g.League)
+	return hashFields(values...)
+}
+
+// ContentHash returns a stable digest of the ingested stat line
+func (s *PlayerGameStats) ContentHash() string {
+	values := []interface{}{
+		s.GameID, s.PlayerID, s.TeamID, s.Points, s.Rebounds, s.Assists,
+		s.Steals, s.Blocks, s.Turnovers, s.FieldGoalsMade, s.FieldGoalsAttempted,
+		s.ThreePointersMade, s.ThreePointersAttempted, s.FreeThrowsMade, s.FreeThrowsAttempted,
+		s.OffensiveRebounds, s.DefensiveRebounds, s.PersonalFouls, s.MinutesPlayed, s.PlusMinus,
+		s.Starter, s.TrueShootingPct, s.EffectiveFGPct, s.UsageRate,
+	}
+	values = appendIfSet(values, s.TechnicalFouls.Valid || s.FlagrantFouls.Valid, s.TechnicalFouls, s.FlagrantFouls)
+	return hashFields(values...)
+}
+
+// ContentHash returns a stable digest of the ingested team totals
+func (s *TeamGameStats) ContentHash() string {
+	values := []interface{}{
+		s.GameID, s.TeamID, s.IsHome, s.Points,
+		s.FieldGoalsMade, s.FieldGoalsAttempted,
+		s.ThreePointersMade, s.ThreePointersAttempted,
+		s.FreeThrowsMade, s.FreeThrowsAttempted,
+		s.OffensiveRebounds, s.DefensiveRebounds, s.Rebounds,
+		s.Assists, s.Steals, s.Blocks, s.Turnovers, s.PersonalFouls,
+	}
+	optional := []NullInt32{
+		s.TechnicalFouls, s.FlagrantFouls, s.FastBreakPoints, s.PointsInPaint,
+		s.PointsOffTurnovers, s.SecondChancePoints, s.BenchPoints, s.LargestLead,
+	}
+	reported := false
+	for _, v := range optional {
+		reported = reported || v.Valid
+	}
+	values = appendIfSet(values, reported, optional)
+	return hashFields(values...)
+}
+
+// appendIfSet adds fields that joined a hash after rows were stored with it.
+// They count only when set, so rows stored without them keep their hashes.
+func appendIfSet(values []interface{}, set bool, fields ...interface{}) []interface{} {
+	if !set {
+		return values
+	}
+	return append(values, fields...)
+}
+
+// hashFields writes each value in order with a separator and returns the hex SHA-256
+func hashFields(values ...interface{}) string {
+	h := sha256.New()
+	for _, v := range values {
+		fmt.Fprintf(h, "%v|", v)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// nullTimeKey renders a nullable time in UTC so the hash does not depend on the local zone
+func nullTimeKey(valid bool, t time.Time) string {
+	if !valid {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
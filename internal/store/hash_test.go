@@ -0,0 +1,18 @@
+package store
+
+import "testing"
+
+func TestGameContentHashLeague(t *testing.T) {
+	game := &Game{Sport: DefaultSport, ExternalID: "401705000", HomeTeamID: 2, AwayTeamID: 14, Status: "scheduled"}
+	stored := game.ContentHash()
+
+	game.League = LeagueNBA
+	if game.ContentHash() != stored {
+		t.Error("the default league changed the hash of games stored before leagues")
+	}
+
+	game.League = LeagueGLeague
+	if game.ContentHash() == stored {
+		t.Error("moving a game to another league kept its hash, so the upsert would skip it")
+	}
+}
@@ -0,0 +1,268 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fortuna/minerva/internal/metrics"
+)
+
+// DefaultSlowQueryThreshold is the duration above which queries are logged
+const DefaultSlowQueryThreshold = 500 * time.Millisecond
+
+// slowQueryMaxLen caps how much SQL text a slow-query log line carries
+const slowQueryMaxLen = 500
+
+var (
+	queryDuration = metrics.NewHistogramVec("minerva_db_query_duration_seconds",
+		"Time spent in the database driver per query, by calling function", nil, "caller", "op")
+	queryRows = metrics.NewCounterVec("minerva_db_query_rows_total",
+		"Rows returned or affected, by calling function", "caller", "op")
+	queryErrors = metrics.NewCounterVec("minerva_db_query_errors_total",
+		"Queries that returned an error, by calling function", "caller", "op")
+	slowQueries = metrics.NewCounterVec("minerva_db_slow_queries_total",
+		"Queries exceeding the slow-query threshold, by calling function", "caller", "op")
+)
+
+// queryObserver records every query that passes through an instrumented connection
+type queryObserver struct {
+	slowThreshold atomic.Int64 // nanoseconds; zero or less disables the slow-query log
+}
+
+func newQueryObserver() *queryObserver {
+	o := &queryObserver{}
+	o.slowThreshold.Store(int64(DefaultSlowQueryThreshold))
+	return o
+}
+
+// SetSlowQueryThreshold changes the duration above which queries are logged.
+// Zero disables the slow-query log; metrics are recorded regardless.
+func (db *Database) SetSlowQueryThreshold(threshold time.Duration) {
+	db.observer.slowThreshold.Store(int64(threshold))
+}
+
+// queryCall is one statement in flight. Duration only counts time spent inside
+// the driver, so a slow consumer iterating rows does not look like a slow query.
+type queryCall struct {
+	observer *queryObserver
+	op       string
+	caller   string
+	query    string
+	args     []driver.NamedValue
+	elapsed  time.Duration
+	rows     int64
+}
+
+func (o *queryObserver) begin(op, query string, args []driver.NamedValue) *queryCall {
+	return &queryCall{observer: o, op: op, caller: queryCaller(), query: query, args: args}
+}
+
+func (c *queryCall) finish(err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+
+	queryDuration.WithLabelValues(c.caller, c.op).Observe(c.elapsed.Seconds())
+	queryRows.WithLabelValues(c.caller, c.op).Add(float64(c.rows))
+	if err != nil {
+		queryErrors.WithLabelValues(c.caller, c.op).Inc()
+	}
+
+	threshold := time.Duration(c.observer.slowThreshold.Load())
+	if threshold <= 0 || c.elapsed < threshold {
+		return
+	}
+	slowQueries.WithLabelValues(c.caller, c.op).Inc()
+	log.Printf("⚠️  Slow query: %s took %v (%d rows) %s [%s]",
+		c.caller, c.elapsed.Round(time.Millisecond), c.rows, compactSQL(c.query), redactArgs(c.args))
+}
+
+// queryCaller names the first function up the stack outside database/sql and
+// this file, e.g. "repository.GameRepository.GetByID"
+func queryCaller() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "database/sql.") &&
+			!strings.HasPrefix(frame.Function, "runtime.") &&
+			!strings.HasSuffix(frame.File, "internal/store/instrument.go") {
+			name := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+			return strings.NewReplacer("(*", "", ")", "").Replace(name)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// compactSQL collapses whitespace so multi-line queries fit on one log line
+func compactSQL(query string) string {
+	compact := strings.Join(strings.Fields(query), " ")
+	if len(compact) > slowQueryMaxLen {
+		compact = compact[:slowQueryMaxLen] + "..."
+	}
+	return compact
+}
+
+// redactArgs describes arguments by type and size only; values may identify users
+func redactArgs(args []driver.NamedValue) string {
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		var desc string
+		switch v := arg.Value.(type) {
+		case nil:
+			desc = "NULL"
+		case string:
+			desc = fmt.Sprintf("string(%d)", len(v))
+		case []byte:
+			desc = fmt.Sprintf("bytes(%d)", len(v))
+		default:
+			desc = fmt.Sprintf("%T", v)
+		}
+		parts = append(parts, fmt.Sprintf("$%d=%s", arg.Ordinal, desc))
+	}
+	return strings.Join(parts, " ")
+}
+
+// ---------------------------------------------------------------------------
+// Driver wrappers
+// ---------------------------------------------------------------------------
+
+// instrumentedConnector wraps the pq connector so every pooled connection is observed
+type instrumentedConnector struct {
+	driver.Connector
+	observer *queryObserver
+}
+
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{Conn: conn, observer: c.observer}, nil
+}
+
+// instrumentedConn forwards to the pq connection, timing queries and statements.
+// pq implements every optional interface used here.
+type instrumentedConn struct {
+	driver.Conn
+	observer *queryObserver
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	call := c.observer.begin("query", query, args)
+	start := time.Now()
+	rows, err := c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+	call.elapsed = time.Since(start)
+	if err != nil {
+		call.finish(err)
+		return nil, withContextCause(ctx, err)
+	}
+	return &instrumentedRows{Rows: rows, call: call, ctx: ctx}, nil
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	call := c.observer.begin("exec", query, args)
+	start := time.Now()
+	result, err := c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+	call.elapsed = time.Since(start)
+	if err == nil {
+		call.rows, _ = result.RowsAffected()
+	}
+	call.finish(err)
+	return result, withContextCause(ctx, err)
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedStmt{Stmt: stmt, query: query, observer: c.observer}, nil
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	return c.Conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	return c.Conn.(driver.Validator).IsValid()
+}
+
+// instrumentedStmt times executions of a prepared statement
+type instrumentedStmt struct {
+	driver.Stmt
+	query    string
+	observer *queryObserver
+}
+
+func (s *instrumentedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	call := s.observer.begin("query", s.query, args)
+	start := time.Now()
+	rows, err := s.Stmt.(driver.StmtQueryContext).QueryContext(ctx, args)
+	call.elapsed = time.Since(start)
+	if err != nil {
+		call.finish(err)
+		return nil, withContextCause(ctx, err)
+	}
+	return &instrumentedRows{Rows: rows, call: call, ctx: ctx}, nil
+}
+
+func (s *instrumentedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	call := s.observer.begin("exec", s.query, args)
+	start := time.Now()
+	result, err := s.Stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+	call.elapsed = time.Since(start)
+	if err == nil {
+		call.rows, _ = result.RowsAffected()
+	}
+	call.finish(err)
+	return result, withContextCause(ctx, err)
+}
+
+// instrumentedRows counts rows and driver time until the result set is closed
+type instrumentedRows struct {
+	driver.Rows
+	call *queryCall
+	ctx  context.Context // the query's, for errors while streaming rows
+	err  error
+}
+
+func (r *instrumentedRows) Next(dest []driver.Value) error {
+	start := time.Now()
+	err := r.Rows.Next(dest)
+	r.call.elapsed += time.Since(start)
+
+	if err == nil {
+		r.call.rows++
+	} else if err != io.EOF {
+		r.err = err
+		return withContextCause(r.ctx, err)
+	}
+	return err
+}
+
+func (r *instrumentedRows) Close() error {
+	err := r.Rows.Close()
+	r.call.finish(r.err)
+	return err
+}
@@ -0,0 +1,51 @@
+package store
+
+// LeadTracking summarizes how close a game was from its scoring plays
+type LeadTracking struct {
+	HomeLargestLead int `json:"home_largest_lead"`
+	AwayLargestLead int `json:"away_largest_lead"`
+	LeadChanges     int `json:"lead_changes"` // lead passing from one team to the other
+	TimesTied       int `json:"times_tied"`   // scoring plays that tied the game
+}
+
+// TrackLeads walks scoring plays in sequence order. Plays that did not move the
+// score are skipped, and a tie between two leads by the same team is not a
+// lead change. Returns nil without plays.
+func TrackLeads(plays []*GamePlay) *LeadTracking {
+	if len(plays) == 0 {
+		return nil
+	}
+
+	leads := &LeadTracking{}
+	lastLeader := 0 // 1 home, -1 away
+	prevHome, prevAway := 0, 0
+	for _, p := range plays {
+		homePts, awayPts := p.HomeScore-prevHome, p.AwayScore-prevAway
+		prevHome, prevAway = p.HomeScore, p.AwayScore
+		if homePts <= 0 && awayPts <= 0 {
+			continue
+		}
+
+		diff := p.HomeScore - p.AwayScore
+		leads.HomeLargestLead = max(leads.HomeLargestLead, diff)
+		leads.AwayLargestLead = max(leads.AwayLargestLead, -diff)
+
+		leader := 0
+		switch {
+		case diff > 0:
+			leader = 1
+		case diff < 0:
+			leader = -1
+		}
+		switch {
+		case leader == 0:
+			leads.TimesTied++
+		case lastLeader != 0 && leader != lastLeader:
+			leads.LeadChanges++
+		}
+		if leader != 0 {
+			lastLeader = leader
+		}
+	}
+	return leads
+}
@@ -0,0 +1,42 @@
+package store
+
+// Leagues stored in games.league, seasons.league, and teams.league
+const (
+	LeagueNBA          = "nba"
+	LeagueSummerLeague = "summer_league"
+	LeagueGLeague      = "g_league"
+)
+
+// League describes where a league's games come from. Development leagues are
+// stored alongside NBA games but in their own seasons, and every NBA season
+// aggregate filters them out.
+type League struct {
+	Key        string
+	Name       string
+	ESPNPath   string // ESPN site API sport path
+	TeamLeague string // league whose teams play these games; Summer League uses NBA franchises
+	AddTeams   bool   // create unknown teams on first sight rather than skipping the game
+}
+
+// Leagues are the leagues Minerva can ingest, NBA first
+var Leagues = []League{
+	{Key: LeagueNBA, Name: "NBA", ESPNPath: "basketball/nba", TeamLeague: LeagueNBA},
+	{Key: LeagueSummerLeague, Name: "NBA Summer League", ESPNPath: "basketball/nba-summer-league", TeamLeague: LeagueNBA},
+	{Key: LeagueGLeague, Name: "NBA G League", ESPNPath: "basketball/nba-development", TeamLeague: LeagueGLeague, AddTeams: true},
+}
+
+// LookupLeague returns the league with the given key
+func LookupLeague(key string) (League, bool) {
+	for _, league := range Leagues {
+		if league.Key == key {
+			return league, true
+		}
+	}
+	return League{}, false
+}
+
+// ValidLeague reports whether key is a known league
+func ValidLeague(key string) bool {
+	_, ok := LookupLeague(key)
+	return ok
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Advisory lock namespaces, the first key of pg_advisory_lock(int, int), so
+// unrelated locks never collide on a hashed second key
+const (
+	LockNamespaceGame     int32 = 1
+	LockNamespaceBackfill int32 = 2
+)
+
+// unlockTimeout bounds releasing a lock after the caller's context is done
+const unlockTimeout = 5 * time.Second
+
+// WithAdvisoryLock runs fn while holding a session-level advisory lock on
+// (namespace, hashtext(key)). Concurrent callers with the same key wait their turn.
+// The lock is held on a connection reserved for it outside any transaction, and
+// fn's own writes use other pooled connections; keep network calls out of fn,
+// since the reserved connection stays checked out until fn returns. The lock is
+// released when fn returns, or when the connection drops if the process dies.
+func (db *Database) WithAdvisoryLock(ctx context.Context, namespace int32, key string, fn func() error) (err error) {
+	conn, err := db.DB().Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("reserving lock connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1, hashtext($2))`, namespace, key); err != nil {
+		return fmt.Errorf("acquiring advisory lock %d/%s: %w", namespace, key, err)
+	}
+
+	defer func() {
+		// Release even if ctx is done: a lock left on a pooled connection would
+		// block the key for as long as the connection lives
+		unlockCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unlockTimeout)
+		defer cancel()
+		if _, unlockErr := conn.ExecContext(unlockCtx, `SELECT pg_advisory_unlock($1, hashtext($2))`, namespace, key); unlockErr != nil {
+			// Closing the session releases it instead
+			conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+			err = errors.Join(err, fmt.Errorf("releasing advisory lock %d/%s: %w", namespace, key, unlockErr))
+		}
+	}()
+
+	return fn()
+}
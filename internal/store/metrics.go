@@ -0,0 +1,70 @@
+package store
+
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/fortuna/minerva/internal/metrics"
+)
+
+var (
+	poolsMu       sync.Mutex
+	pools         = map[string]*sql.DB{}
+	registerPools sync.Once
+)
+
+// trackPool exposes sql.DBStats for the pool under the given role label.
+// The most recently opened pool for a role wins.
+func trackPool(role string, db *sql.DB) {
+	poolsMu.Lock()
+	pools[role] = db
+	poolsMu.Unlock()
+
+	registerPools.Do(registerPoolMetrics)
+}
+
+func registerPoolMetrics() {
+	gauge := func(name, help string, value func(sql.DBStats) float64) {
+		metrics.RegisterGaugeFunc(name, help, func() []metrics.Sample {
+			return poolSamples(value)
+		})
+	}
+	counter := func(name, help string, value func(sql.DBStats) float64) {
+		metrics.RegisterCounterFunc(name, help, func() []metrics.Sample {
+			return poolSamples(value)
+		})
+	}
+
+	gauge("minerva_db_pool_max_open_connections", "Maximum number of open connections to the database",
+		func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) })
+	gauge("minerva_db_pool_open_connections", "Established connections, both in use and idle",
+		func(s sql.DBStats) float64 { return float64(s.OpenConnections) })
+	gauge("minerva_db_pool_in_use_connections", "Connections currently in use",
+		func(s sql.DBStats) float64 { return float64(s.InUse) })
+	gauge("minerva_db_pool_idle_connections", "Idle connections",
+		func(s sql.DBStats) float64 { return float64(s.Idle) })
+	counter("minerva_db_pool_wait_count_total", "Total connections waited for",
+		func(s sql.DBStats) float64 { return float64(s.WaitCount) })
+	counter("minerva_db_pool_wait_duration_seconds_total", "Total time blocked waiting for a new connection",
+		func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() })
+	counter("minerva_db_pool_max_idle_closed_total", "Connections closed due to SetMaxIdleConns",
+		func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) })
+	counter("minerva_db_pool_max_idle_time_closed_total", "Connections closed due to SetConnMaxIdleTime",
+		func(s sql.DBStats) float64 { return float64(s.MaxIdleTimeClosed) })
+	counter("minerva_db_pool_max_lifetime_closed_total", "Connections closed due to SetConnMaxLifetime",
+		func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) })
+}
+
+func poolSamples(value func(sql.DBStats) float64) []metrics.Sample {
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+
+	samples := make([]metrics.Sample, 0, len(pools))
+	for role, db := range pools {
+		samples = append(samples, metrics.Sample{
+			Labels: map[string]string{"pool": role},
+			Value:  value(db.Stats()),
+		})
+	}
+	return samples
+}
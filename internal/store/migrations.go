@@ -0,0 +1,321 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fortuna/minerva/infra/atlas"
+)
+
+// downSuffix marks the rollback companion of a migration (NNN_name.down.sql)
+const downSuffix = ".down.sql"
+
+// Migration is a single schema migration file and its optional rollback
+type Migration struct {
+	Version string // file name, recorded in schema_migrations
+	SQL     string
+	DownSQL string // empty when the migration is irreversible
+}
+
+// Reversible reports whether the migration has a down companion
+func (m Migration) Reversible() bool {
+	return m.DownSQL != ""
+}
+
+// MigrationState reports whether a migration has been applied
+type MigrationState struct {
+	Version    string
+	Applied    bool
+	AppliedAt  sql.NullTime
+	Reversible bool
+}
+
+// SetMigrationsDir reads migrations from a directory instead of the copies embedded
+// in the binary. An empty dir restores the embedded set.
+func (db *Database) SetMigrationsDir(dir string) {
+	db.migrationsDir = dir
+}
+
+// migrationFS returns the filesystem migrations are read from
+func (db *Database) migrationFS() (fs.FS, error) {
+	if db.migrationsDir != "" {
+		return os.DirFS(db.migrationsDir), nil
+	}
+	return fs.Sub(atlas.Migrations, "migrations")
+}
+
+// LoadMigrations returns every migration in version order
+func (db *Database) LoadMigrations() ([]Migration, error) {
+	fsys, err := db.migrationFS()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations: %w", err)
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || path.Ext(name) != ".sql" || strings.HasSuffix(name, downSuffix) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	migrations := make([]Migration, 0, len(names))
+	for _, name := range names {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %w", name, err)
+		}
+		migration := Migration{Version: name, SQL: string(content)}
+
+		downName := strings.TrimSuffix(name, ".sql") + downSuffix
+		if down, err := fs.ReadFile(fsys, downName); err == nil {
+			migration.DownSQL = string(down)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read migration file %s: %w", downName, err)
+		}
+
+		migrations = append(migrations, migration)
+	}
+
+	return migrations, nil
+}
+
+// RunMigrations executes all pending migration files in order
+func (db *Database) RunMigrations() error {
+	log.Println("Running database migrations...")
+
+	// Create migrations tracking table
+	if err := db.createMigrationsTable(); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	migrations, err := db.LoadMigrations()
+	if err != nil {
+		return err
+	}
+
+	// Run each migration
+	for _, migration := range migrations {
+		if err := db.runMigration(migration); err != nil {
+			return fmt.Errorf("failed to run migration %s: %w", migration.Version, err)
+		}
+	}
+
+	log.Println("✓ All migrations completed successfully")
+
+	return nil
+}
+
+// MigrationStatus lists every known migration alongside whether it has been applied
+func (db *Database) MigrationStatus(ctx context.Context) ([]MigrationState, error) {
+	if err := db.createMigrationsTable(); err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	migrations, err := db.LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]time.Time)
+	for rows.Next() {
+		var version string
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	states := make([]MigrationState, 0, len(migrations))
+	for _, m := range migrations {
+		state := MigrationState{Version: m.Version, Reversible: m.Reversible()}
+		if at, ok := applied[m.Version]; ok {
+			state.Applied = true
+			state.AppliedAt = sql.NullTime{Time: at, Valid: true}
+			delete(applied, m.Version)
+		}
+		states = append(states, state)
+	}
+
+	// Versions recorded in the database but missing from the source are still reported
+	var orphaned []string
+	for version := range applied {
+		orphaned = append(orphaned, version)
+	}
+	sort.Strings(orphaned)
+	for _, version := range orphaned {
+		states = append(states, MigrationState{
+			Version:   version,
+			Applied:   true,
+			AppliedAt: sql.NullTime{Time: applied[version], Valid: true},
+		})
+	}
+
+	return states, nil
+}
+
+// createMigrationsTable creates a table to track which migrations have been run
+func (db *Database) createMigrationsTable() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version VARCHAR(255) PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`
+	_, err := db.conn.Exec(query)
+	return err
+}
+
+// runMigration runs a single migration if it hasn't been applied yet
+func (db *Database) runMigration(migration Migration) error {
+	// Check if already applied
+	var exists bool
+	err := db.conn.QueryRow("SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = $1)", migration.Version).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		log.Printf("  ⊘ Skipping %s (already applied)", migration.Version)
+		return nil
+	}
+
+	// Execute migration in a transaction
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(migration.SQL); err != nil {
+		return fmt.Errorf("failed to execute migration: %w", err)
+	}
+
+	// Record migration as applied
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", migration.Version); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	log.Printf("  ✓ Applied %s", migration.Version)
+	return nil
+}
+
+// PendingMigrations reports the number of migrations not yet applied
+func (db *Database) PendingMigrations(ctx context.Context) (int, error) {
+	states, err := db.MigrationStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, s := range states {
+		if !s.Applied {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// PlanDown returns the applied migrations that rolling back to target would revert,
+// newest first. target is a migration file name or its numeric prefix (e.g. "021");
+// target itself stays applied.
+func (db *Database) PlanDown(ctx context.Context, target string) ([]Migration, error) {
+	migrations, err := db.LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	targetIndex := -1
+	for i, m := range migrations {
+		if m.Version == target || strings.HasPrefix(m.Version, target+"_") {
+			targetIndex = i
+			break
+		}
+	}
+	if targetIndex < 0 {
+		return nil, fmt.Errorf("unknown migration version %q", target)
+	}
+
+	states, err := db.MigrationStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[string]bool, len(states))
+	for _, s := range states {
+		applied[s.Version] = s.Applied
+	}
+
+	var plan []Migration
+	for i := len(migrations) - 1; i > targetIndex; i-- {
+		m := migrations[i]
+		if !applied[m.Version] {
+			continue
+		}
+		if !m.Reversible() {
+			return nil, fmt.Errorf("migration %s has no %s companion and cannot be rolled back", m.Version, downSuffix)
+		}
+		plan = append(plan, m)
+	}
+
+	return plan, nil
+}
+
+// MigrateDown reverts the given migrations in order, each in its own transaction,
+// removing them from schema_migrations. Callers should obtain the plan from PlanDown.
+func (db *Database) MigrateDown(ctx context.Context, plan []Migration) error {
+	for _, m := range plan {
+		if !m.Reversible() {
+			return fmt.Errorf("migration %s cannot be rolled back", m.Version)
+		}
+
+		tx, err := db.conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, m.DownSQL); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to roll back %s: %w", m.Version, err)
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", m.Version); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		log.Printf("  ↩ Rolled back %s", m.Version)
+	}
+	return nil
+}
@@ -1,199 +1,918 @@
 package store
 
 import (
-	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
 	"time"
 )
 
 // Season represents an NBA season (v2 schema)
 type Season struct {
-	SeasonID    int            `json:"season_id" db:"season_id"`
-	Sport       string         `json:"sport" db:"sport"`
-	SeasonYear  string         `json:"season_year" db:"season_year"`
-	SeasonType  string         `json:"season_type" db:"season_type"`
-	StartDate   time.Time      `json:"start_date" db:"start_date"`
-	EndDate     time.Time      `json:"end_date" db:"end_date"`
-	IsActive    bool           `json:"is_active" db:"is_active"`
-	TotalGames  sql.NullInt32  `json:"total_games,omitempty" db:"total_games"`
-	Metadata    sql.NullString `json:"metadata,omitempty" db:"metadata"`
-	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
+	SeasonID   int        `json:"season_id" db:"season_id"`
+	Sport      string     `json:"sport" db:"sport"`
+	SeasonYear string     `json:"season_year" db:"season_year"`
+	SeasonType string     `json:"season_type" db:"season_type"`
+	League     string     `json:"league" db:"league"`
+	StartDate  time.Time  `json:"start_date" db:"start_date"`
+	EndDate    time.Time  `json:"end_date" db:"end_date"`
+	IsActive   bool       `json:"is_active" db:"is_active"`
+	TotalGames NullInt32  `json:"total_games,omitempty" db:"total_games"`
+	Metadata   NullString `json:"metadata,omitempty" db:"metadata"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // Team represents an NBA franchise (v2 schema)
 type Team struct {
-	TeamID        int            `json:"team_id" db:"team_id"`
-	Sport         string         `json:"sport" db:"sport"`
-	ExternalID    string         `json:"external_id" db:"external_id"`
-	Abbreviation  string         `json:"abbreviation" db:"abbreviation"`
-	FullName      string         `json:"full_name" db:"full_name"`
-	ShortName     string         `json:"short_name" db:"short_name"`
-	City          sql.NullString `json:"city,omitempty" db:"city"`
-	State         sql.NullString `json:"state,omitempty" db:"state"`
-	Conference    sql.NullString `json:"conference,omitempty" db:"conference"`
-	Division      sql.NullString `json:"division,omitempty" db:"division"`
-	VenueName     sql.NullString `json:"venue_name,omitempty" db:"venue_name"`
-	VenueCapacity sql.NullInt32  `json:"venue_capacity,omitempty" db:"venue_capacity"`
-	FoundedYear   sql.NullInt32  `json:"founded_year,omitempty" db:"founded_year"`
-	LogoURL       sql.NullString `json:"logo_url,omitempty" db:"logo_url"`
-	Colors        sql.NullString `json:"colors,omitempty" db:"colors"`
-	SocialMedia   sql.NullString `json:"social_media,omitempty" db:"social_media"`
-	Metadata      sql.NullString `json:"metadata,omitempty" db:"metadata"`
-	IsActive      bool           `json:"is_active" db:"is_active"`
-	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
+	TeamID        int        `json:"team_id" db:"team_id"`
+	Sport         string     `json:"sport" db:"sport"`
+	League        string     `json:"league" db:"league"`
+	ExternalID    string     `json:"external_id" db:"external_id"`
+	Abbreviation  string     `json:"abbreviation" db:"abbreviation"`
+	FullName      string     `json:"full_name" db:"full_name"`
+	ShortName     string     `json:"short_name" db:"short_name"`
+	City          NullString `json:"city,omitempty" db:"city"`
+	State         NullString `json:"state,omitempty" db:"state"`
+	Conference    NullString `json:"conference,omitempty" db:"conference"`
+	Division      NullString `json:"division,omitempty" db:"division"`
+	VenueName     NullString `json:"venue_name,omitempty" db:"venue_name"`
+	VenueCapacity NullInt32  `json:"venue_capacity,omitempty" db:"venue_capacity"`
+	FoundedYear   NullInt32  `json:"founded_year,omitempty" db:"founded_year"`
+	LogoURL       NullString `json:"logo_url,omitempty" db:"logo_url"`
+	Colors        NullString `json:"colors,omitempty" db:"colors"`
+	SocialMedia   NullString `json:"social_media,omitempty" db:"social_media"`
+	Metadata      NullString `json:"metadata,omitempty" db:"metadata"`
+	IsActive      bool       `json:"is_active" db:"is_active"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // Player represents a player (v2 schema)
 type Player struct {
-	PlayerID      int            `json:"player_id" db:"player_id"`
-	Sport         string         `json:"sport" db:"sport"`
-	ExternalID    sql.NullString `json:"external_id,omitempty" db:"external_id"`
-	FirstName     sql.NullString `json:"first_name,omitempty" db:"first_name"`
-	LastName      string         `json:"last_name" db:"last_name"`
-	FullName      string         `json:"full_name" db:"full_name"`
-	DisplayName   sql.NullString `json:"display_name,omitempty" db:"display_name"`
-	BirthDate     sql.NullTime   `json:"birth_date,omitempty" db:"birth_date"`
-	BirthCity     sql.NullString `json:"birth_city,omitempty" db:"birth_city"`
-	BirthCountry  sql.NullString `json:"birth_country,omitempty" db:"birth_country"`
-	Nationality   sql.NullString `json:"nationality,omitempty" db:"nationality"`
-	Height        sql.NullString `json:"height,omitempty" db:"height"`
-	HeightInches  sql.NullInt32  `json:"height_inches,omitempty" db:"height_inches"`
-	Weight        sql.NullInt32  `json:"weight,omitempty" db:"weight"`
-	Position      sql.NullString `json:"position,omitempty" db:"position"`
-	College       sql.NullString `json:"college,omitempty" db:"college"`
-	HighSchool    sql.NullString `json:"high_school,omitempty" db:"high_school"`
-	DraftYear     sql.NullInt32  `json:"draft_year,omitempty" db:"draft_year"`
-	DraftRound    sql.NullInt32  `json:"draft_round,omitempty" db:"draft_round"`
-	DraftPick     sql.NullInt32  `json:"draft_pick,omitempty" db:"draft_pick"`
-	DraftTeamID   sql.NullInt32  `json:"draft_team_id,omitempty" db:"draft_team_id"`
-	HeadshotURL   sql.NullString `json:"headshot_url,omitempty" db:"headshot_url"`
-	JerseyNumber  sql.NullString `json:"jersey_number,omitempty" db:"jersey_number"`
-	Status        sql.NullString `json:"status,omitempty" db:"status"`
-	Metadata      sql.NullString `json:"metadata,omitempty" db:"metadata"`
-	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
-	
+	PlayerID     int        `json:"player_id" db:"player_id"`
+	Sport        string     `json:"sport" db:"sport"`
+	ExternalID   NullString `json:"external_id,omitempty" db:"external_id"`
+	FirstName    NullString `json:"first_name,omitempty" db:"first_name"`
+	LastName     string     `json:"last_name" db:"last_name"`
+	FullName     string     `json:"full_name" db:"full_name"`
+	DisplayName  NullString `json:"display_name,omitempty" db:"display_name"`
+	BirthDate    NullTime   `json:"birth_date,omitempty" db:"birth_date"`
+	BirthCity    NullString `json:"birth_city,omitempty" db:"birth_city"`
+	BirthCountry NullString `json:"birth_country,omitempty" db:"birth_country"`
+	Nationality  NullString `json:"nationality,omitempty" db:"nationality"`
+	Height       NullString `json:"height,omitempty" db:"height"`
+	HeightInches NullInt32  `json:"height_inches,omitempty" db:"height_inches"`
+	Weight       NullInt32  `json:"weight,omitempty" db:"weight"`
+	Position     NullString `json:"position,omitempty" db:"position"`
+	College      NullString `json:"college,omitempty" db:"college"`
+	HighSchool   NullString `json:"high_school,omitempty" db:"high_school"`
+	DraftYear    NullInt32  `json:"draft_year,omitempty" db:"draft_year"`
+	DraftRound   NullInt32  `json:"draft_round,omitempty" db:"draft_round"`
+	DraftPick    NullInt32  `json:"draft_pick,omitempty" db:"draft_pick"`
+	DraftTeamID  NullInt32  `json:"draft_team_id,omitempty" db:"draft_team_id"`
+	HeadshotURL  NullString `json:"headshot_url,omitempty" db:"headshot_url"`
+	JerseyNumber NullString `json:"jersey_number,omitempty" db:"jersey_number"`
+	Status       NullString `json:"status,omitempty" db:"status"`
+	Metadata     NullString `json:"metadata,omitempty" db:"metadata"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+
 	// Not in database - populated from player_team_history for API responses
 	CurrentTeamID int `json:"current_team_id,omitempty" db:"-"`
 }
 
+// PlayerSuggestion is the compact player record returned by search autocomplete
+type PlayerSuggestion struct {
+	PlayerID    int        `json:"player_id"`
+	Name        string     `json:"name"`
+	TeamID      NullInt32  `json:"team_id"`
+	Team        NullString `json:"team"` // current team abbreviation
+	HeadshotURL NullString `json:"headshot_url"`
+}
+
+// PlayerAlias is an alternate name for a player, matched by search and by
+// ingestion name resolution
+type PlayerAlias struct {
+	AliasID   int       `json:"alias_id"`
+	PlayerID  int       `json:"player_id"`
+	Alias     string    `json:"alias"`
+	AliasType string    `json:"alias_type"` // PlayerAliasNickname, PlayerAliasSpelling, PlayerAliasTransliteration
+	Source    string    `json:"source"`     // PlayerAliasSourceManual or PlayerAliasSourceMerge
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Player alias types and sources
+const (
+	PlayerAliasNickname        = "nickname"
+	PlayerAliasSpelling        = "spelling"
+	PlayerAliasTransliteration = "transliteration"
+
+	PlayerAliasSourceManual = "manual"
+	PlayerAliasSourceMerge  = "merge"
+)
+
+// NameTranslation is a team or player's display name in one locale, applied to
+// API responses for requests in that locale
+type NameTranslation struct {
+	EntityType string     `json:"entity_type" db:"entity_type"` // EntityTeam or EntityPlayer
+	EntityID   int        `json:"entity_id" db:"entity_id"`
+	Locale     string     `json:"locale" db:"locale"` // Lowercase BCP 47 tag, e.g. "es" or "es-mx"
+	FullName   string     `json:"full_name" db:"full_name"`
+	ShortName  NullString `json:"short_name" db:"short_name"` // Team nickname or player display name
+	City       NullString `json:"city" db:"city"`             // Teams only
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
 // PlayerSeason represents a player's participation in a season
 type PlayerSeason struct {
-	ID            int             `json:"id" db:"id"`
-	PlayerID      int             `json:"player_id" db:"player_id"`
-	SeasonID      string          `json:"season_id" db:"season_id"`
-	TeamID        sql.NullInt32   `json:"team_id,omitempty" db:"team_id"`
-	WasActive     bool            `json:"was_active" db:"was_active"`
-	GamesPlayed   int             `json:"games_played" db:"games_played"`
-	SeasonPPG     sql.NullFloat64 `json:"season_ppg,omitempty" db:"season_ppg"`
-	SeasonRPG     sql.NullFloat64 `json:"season_rpg,omitempty" db:"season_rpg"`
-	SeasonAPG     sql.NullFloat64 `json:"season_apg,omitempty" db:"season_apg"`
-	SeasonMinutes sql.NullFloat64 `json:"season_minutes,omitempty" db:"season_minutes"`
-	SeasonFGPct   sql.NullFloat64 `json:"season_fg_pct,omitempty" db:"season_fg_pct"`
-	Season3PPct   sql.NullFloat64 `json:"season_3p_pct,omitempty" db:"season_3p_pct"`
-	SeasonFTPct   sql.NullFloat64 `json:"season_ft_pct,omitempty" db:"season_ft_pct"`
-	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+	ID            int         `json:"id" db:"id"`
+	PlayerID      int         `json:"player_id" db:"player_id"`
+	SeasonID      string      `json:"season_id" db:"season_id"`
+	TeamID        NullInt32   `json:"team_id,omitempty" db:"team_id"`
+	WasActive     bool        `json:"was_active" db:"was_active"`
+	GamesPlayed   int         `json:"games_played" db:"games_played"`
+	SeasonPPG     NullFloat64 `json:"season_ppg,omitempty" db:"season_ppg"`
+	SeasonRPG     NullFloat64 `json:"season_rpg,omitempty" db:"season_rpg"`
+	SeasonAPG     NullFloat64 `json:"season_apg,omitempty" db:"season_apg"`
+	SeasonMinutes NullFloat64 `json:"season_minutes,omitempty" db:"season_minutes"`
+	SeasonFGPct   NullFloat64 `json:"season_fg_pct,omitempty" db:"season_fg_pct"`
+	Season3PPct   NullFloat64 `json:"season_3p_pct,omitempty" db:"season_3p_pct"`
+	SeasonFTPct   NullFloat64 `json:"season_ft_pct,omitempty" db:"season_ft_pct"`
+	CreatedAt     time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at" db:"updated_at"`
 }
 
 // Game represents an NBA game (v2 schema)
 type Game struct {
-	GameID        int            `json:"game_id" db:"game_id"`
-	Sport         string         `json:"sport" db:"sport"`
-	SeasonID      int            `json:"season_id" db:"season_id"`
-	ExternalID    string         `json:"external_id" db:"external_id"`
-	GameDate      time.Time      `json:"game_date" db:"game_date"`
-	GameTime      sql.NullTime   `json:"game_time,omitempty" db:"game_time"`
-	HomeTeamID    int            `json:"home_team_id" db:"home_team_id"`
-	AwayTeamID    int            `json:"away_team_id" db:"away_team_id"`
-	HomeScore     sql.NullInt32  `json:"home_score,omitempty" db:"home_score"`
-	AwayScore     sql.NullInt32  `json:"away_score,omitempty" db:"away_score"`
-	Status        string         `json:"status" db:"status"`
-	Period        sql.NullInt32  `json:"period,omitempty" db:"period"`
-	Clock         sql.NullString `json:"clock,omitempty" db:"clock"`
-	Venue         sql.NullString `json:"venue,omitempty" db:"venue"`
-	Attendance    sql.NullInt32  `json:"attendance,omitempty" db:"attendance"`
-	Metadata      sql.NullString `json:"metadata,omitempty" db:"metadata"`
-	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
+	GameID          int        `json:"game_id" db:"game_id"`
+	Sport           string     `json:"sport" db:"sport"`
+	SeasonID        int        `json:"season_id" db:"season_id"`
+	ExternalID      string     `json:"external_id" db:"external_id"`
+	GameDate        time.Time  `json:"game_date" db:"game_date"`
+	GameTime        NullTime   `json:"game_time,omitempty" db:"game_time"`
+	HomeTeamID      int        `json:"home_team_id" db:"home_team_id"`
+	AwayTeamID      int        `json:"away_team_id" db:"away_team_id"`
+	HomeScore       NullInt32  `json:"home_score,omitempty" db:"home_score"`
+	AwayScore       NullInt32  `json:"away_score,omitempty" db:"away_score"`
+	Status          string     `json:"status" db:"status"`
+	GameType        string     `json:"game_type" db:"game_type"`
+	League          string     `json:"league" db:"league"`
+	Period          NullInt32  `json:"period,omitempty" db:"period"`
+	Clock           NullString `json:"clock,omitempty" db:"clock"`
+	Venue           NullString `json:"venue,omitempty" db:"venue"`
+	Attendance      NullInt32  `json:"attendance,omitempty" db:"attendance"`
+	Broadcasts      []string   `json:"broadcasts" db:"broadcasts"` // network short names, e.g. ["ESPN", "TNT"]
+	Metadata        NullString `json:"metadata,omitempty" db:"metadata"`
+	FinalizedAt     NullTime   `json:"finalized_at,omitempty" db:"finalized_at"`
+	StatsComplete   bool       `json:"stats_complete" db:"stats_complete"` // box score verified settled after going final
+	HomeLargestLead NullInt32  `json:"home_largest_lead,omitempty" db:"home_largest_lead"`
+	AwayLargestLead NullInt32  `json:"away_largest_lead,omitempty" db:"away_largest_lead"`
+	LeadChanges     NullInt32  `json:"lead_changes,omitempty" db:"lead_changes"`
+	TimesTied       NullInt32  `json:"times_tied,omitempty" db:"times_tied"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// GameMetadata is the structured content of games.metadata
+type GameMetadata struct {
+	TournamentGroup string `json:"tournament_group,omitempty"` // 'East Group A'
+	TournamentRound string `json:"tournament_round,omitempty"` // 'group', 'quarterfinal', 'semifinal', 'championship'
+}
+
+// NBA Cup rounds stored in games.metadata tournament_round
+const (
+	TournamentRoundGroup        = "group"
+	TournamentRoundQuarterfinal = "quarterfinal"
+	TournamentRoundSemifinal    = "semifinal"
+	TournamentRoundChampionship = "championship"
+)
+
+// TournamentGame is an NBA Cup game with its group or knockout round
+type TournamentGame struct {
+	GameID     string    `json:"game_id"` // external (ESPN) ID
+	GameDate   time.Time `json:"game_date"`
+	Group      string    `json:"group,omitempty"`
+	Round      string    `json:"round"`
+	HomeTeamID int       `json:"home_team_id"`
+	AwayTeamID int       `json:"away_team_id"`
+	HomeScore  NullInt32 `json:"home_score,omitempty"`
+	AwayScore  NullInt32 `json:"away_score,omitempty"`
+	Status     string    `json:"status"`
 }
 
 // PlayerGameStats represents player stats for a single game
 type PlayerGameStats struct {
-	ID                    int             `json:"id" db:"id"`
-	GameID                int             `json:"game_id" db:"game_id"`
-	PlayerID              int             `json:"player_id" db:"player_id"`
-	TeamID                int             `json:"team_id" db:"team_id"`
-	Points                int             `json:"points" db:"points"`
-	Rebounds              int             `json:"rebounds" db:"rebounds"`
-	Assists               int             `json:"assists" db:"assists"`
-	Steals                int             `json:"steals" db:"steals"`
-	Blocks                int             `json:"blocks" db:"blocks"`
-	Turnovers             int             `json:"turnovers" db:"turnovers"`
-	FieldGoalsMade        int             `json:"field_goals_made" db:"field_goals_made"`
-	FieldGoalsAttempted   int             `json:"field_goals_attempted" db:"field_goals_attempted"`
-	ThreePointersMade     int             `json:"three_pointers_made" db:"three_pointers_made"`
-	ThreePointersAttempted int            `json:"three_pointers_attempted" db:"three_pointers_attempted"`
-	FreeThrowsMade        int             `json:"free_throws_made" db:"free_throws_made"`
-	FreeThrowsAttempted   int             `json:"free_throws_attempted" db:"free_throws_attempted"`
-	OffensiveRebounds     int             `json:"offensive_rebounds" db:"offensive_rebounds"`
-	DefensiveRebounds     int             `json:"defensive_rebounds" db:"defensive_rebounds"`
-	PersonalFouls         int             `json:"personal_fouls" db:"personal_fouls"`
-	MinutesPlayed         sql.NullFloat64 `json:"minutes_played,omitempty" db:"minutes_played"`
-	PlusMinus             sql.NullInt32   `json:"plus_minus,omitempty" db:"plus_minus"`
-	Starter               bool            `json:"starter" db:"starter"`
-	TrueShootingPct       sql.NullFloat64 `json:"true_shooting_pct,omitempty" db:"true_shooting_pct"`
-	EffectiveFGPct        sql.NullFloat64 `json:"effective_fg_pct,omitempty" db:"effective_fg_pct"`
-	UsageRate             sql.NullFloat64 `json:"usage_rate,omitempty" db:"usage_rate"`
-	CreatedAt             time.Time       `json:"created_at" db:"created_at"`
-	UpdatedAt             time.Time       `json:"updated_at" db:"updated_at"`
+	ID                     int         `json:"id" db:"id"`
+	GameID                 int         `json:"game_id" db:"game_id"`
+	PlayerID               int         `json:"player_id" db:"player_id"`
+	TeamID                 int         `json:"team_id" db:"team_id"`
+	Points                 int         `json:"points" db:"points"`
+	Rebounds               int         `json:"rebounds" db:"rebounds"`
+	Assists                int         `json:"assists" db:"assists"`
+	Steals                 int         `json:"steals" db:"steals"`
+	Blocks                 int         `json:"blocks" db:"blocks"`
+	Turnovers              int         `json:"turnovers" db:"turnovers"`
+	FieldGoalsMade         int         `json:"field_goals_made" db:"field_goals_made"`
+	FieldGoalsAttempted    int         `json:"field_goals_attempted" db:"field_goals_attempted"`
+	ThreePointersMade      int         `json:"three_pointers_made" db:"three_pointers_made"`
+	ThreePointersAttempted int         `json:"three_pointers_attempted" db:"three_pointers_attempted"`
+	FreeThrowsMade         int         `json:"free_throws_made" db:"free_throws_made"`
+	FreeThrowsAttempted    int         `json:"free_throws_attempted" db:"free_throws_attempted"`
+	OffensiveRebounds      int         `json:"offensive_rebounds" db:"offensive_rebounds"`
+	DefensiveRebounds      int         `json:"defensive_rebounds" db:"defensive_rebounds"`
+	PersonalFouls          int         `json:"personal_fouls" db:"personal_fouls"`
+	TechnicalFouls         NullInt32   `json:"technical_fouls,omitempty" db:"technical_fouls"` // null when ESPN did not report them
+	FlagrantFouls          NullInt32   `json:"flagrant_fouls,omitempty" db:"flagrant_fouls"`
+	MinutesPlayed          NullFloat64 `json:"minutes_played,omitempty" db:"minutes_played"`
+	SecondsPlayed          NullInt32   `json:"seconds_played,omitempty" db:"seconds_played"`
+	Minutes                string      `json:"minutes,omitempty" db:"-"` // MM:SS, derived from SecondsPlayed
+	PlusMinus              NullInt32   `json:"plus_minus,omitempty" db:"plus_minus"`
+	Starter                bool        `json:"starter" db:"starter"`
+	TrueShootingPct        NullFloat64 `json:"true_shooting_pct,omitempty" db:"true_shooting_pct"`
+	EffectiveFGPct         NullFloat64 `json:"effective_fg_pct,omitempty" db:"effective_fg_pct"`
+	UsageRate              NullFloat64 `json:"usage_rate,omitempty" db:"usage_rate"`
+	Corrected              bool        `json:"corrected" db:"corrected"` // amended by an operator; ingestion no longer overwrites it
+	CreatedAt              time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt              time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// SetSecondsPlayed records exact playing time and derives the fractional
+// minutes_played and MM:SS fields from it
+func (s *PlayerGameStats) SetSecondsPlayed(seconds int) {
+	s.SecondsPlayed = NullInt32{Int32: int32(seconds), Valid: true}
+	s.MinutesPlayed = NullFloat64{Float64: float64(seconds/60) + float64(seconds%60)/60.0, Valid: true}
+	s.Minutes = FormatMinutes(seconds)
+}
+
+// FillMinutes sets the MM:SS display after a row is scanned, falling back to
+// minutes_played for rows without seconds_played
+func (s *PlayerGameStats) FillMinutes() {
+	switch {
+	case s.SecondsPlayed.Valid:
+		s.Minutes = FormatMinutes(int(s.SecondsPlayed.Int32))
+	case s.MinutesPlayed.Valid:
+		s.Minutes = FormatMinutes(int(math.Round(s.MinutesPlayed.Float64 * 60)))
+	default:
+		s.Minutes = ""
+	}
+}
+
+// FormatMinutes renders playing time as MM:SS, e.g. 2052 seconds as "34:12"
+func FormatMinutes(seconds int) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// UpdateShootingPcts recomputes true shooting and effective field goal percentage
+// from the counting stats. Both are null when there were no attempts.
+func (s *PlayerGameStats) UpdateShootingPcts() {
+	s.TrueShootingPct = NullFloat64{}
+	if s.FieldGoalsAttempted > 0 || s.FreeThrowsAttempted > 0 {
+		denominator := 2.0 * (float64(s.FieldGoalsAttempted) + 0.44*float64(s.FreeThrowsAttempted))
+		s.TrueShootingPct = NullFloat64{Float64: float64(s.Points) / denominator, Valid: true}
+	}
+
+	s.EffectiveFGPct = NullFloat64{}
+	if s.FieldGoalsAttempted > 0 {
+		efg := (float64(s.FieldGoalsMade) + 0.5*float64(s.ThreePointersMade)) / float64(s.FieldGoalsAttempted)
+		s.EffectiveFGPct = NullFloat64{Float64: efg, Valid: true}
+	}
+}
+
+// StatCorrection is an operator's amendment to one player stat line.
+// Nil fields are left unchanged; Deleted soft-deletes (true) or restores (false) the row.
+type StatCorrection struct {
+	Points                 *int     `json:"points,omitempty"`
+	Rebounds               *int     `json:"rebounds,omitempty"`
+	Assists                *int     `json:"assists,omitempty"`
+	Steals                 *int     `json:"steals,omitempty"`
+	Blocks                 *int     `json:"blocks,omitempty"`
+	Turnovers              *int     `json:"turnovers,omitempty"`
+	FieldGoalsMade         *int     `json:"field_goals_made,omitempty"`
+	FieldGoalsAttempted    *int     `json:"field_goals_attempted,omitempty"`
+	ThreePointersMade      *int     `json:"three_pointers_made,omitempty"`
+	ThreePointersAttempted *int     `json:"three_pointers_attempted,omitempty"`
+	FreeThrowsMade         *int     `json:"free_throws_made,omitempty"`
+	FreeThrowsAttempted    *int     `json:"free_throws_attempted,omitempty"`
+	OffensiveRebounds      *int     `json:"offensive_rebounds,omitempty"`
+	DefensiveRebounds      *int     `json:"defensive_rebounds,omitempty"`
+	PersonalFouls          *int     `json:"personal_fouls,omitempty"`
+	MinutesPlayed          *float64 `json:"minutes_played,omitempty"` // rounded to the nearest second
+	SecondsPlayed          *int     `json:"seconds_played,omitempty"` // takes precedence over minutes_played
+	PlusMinus              *int     `json:"plus_minus,omitempty"`
+	Starter                *bool    `json:"starter,omitempty"`
+	Deleted                *bool    `json:"deleted,omitempty"`
+	Reason                 string   `json:"reason"`
+	CorrectedBy            string   `json:"-"` // the authenticated principal, never the request body
+}
+
+// StatCorrectionLog is one audited change to a player stat line
+type StatCorrectionLog struct {
+	CorrectionID int             `json:"correction_id"`
+	StatID       int             `json:"stat_id"`
+	Action       string          `json:"action"`  // "correct", "delete", or "restore"
+	Changes      json.RawMessage `json:"changes"` // field -> {"old": ..., "new": ...}
+	Reason       string          `json:"reason"`
+	CorrectedBy  string          `json:"corrected_by"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// DataQualityIssue is one validation rule violation found in a stored box score
+type DataQualityIssue struct {
+	IssueID    int         `json:"issue_id"`
+	GameID     int         `json:"game_id"`
+	ExternalID string      `json:"external_id,omitempty"`
+	GameDate   time.Time   `json:"game_date"`
+	TeamID     NullInt32   `json:"team_id"`
+	PlayerID   NullInt32   `json:"player_id"`
+	Rule       string      `json:"rule"`
+	Severity   string      `json:"severity"` // "error" or "warning"
+	Message    string      `json:"message"`
+	Expected   NullFloat64 `json:"expected"`
+	Actual     NullFloat64 `json:"actual"`
+	DetectedAt time.Time   `json:"detected_at"`
+}
+
+// DataQualitySummary counts the validation issues recorded for a set of games
+type DataQualitySummary struct {
+	Issues   int            `json:"issues"`
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+	ByRule   map[string]int `json:"by_rule"`
+}
+
+// GameUpdate is one ingested change to a game, as recorded in game_update_log
+type GameUpdate struct {
+	UpdateID  int64                  `json:"update_id"`
+	GameID    int                    `json:"game_id"`
+	Fields    map[string]FieldChange `json:"fields,omitempty"`
+	Stats     []StatDelta            `json:"stats,omitempty"`
+	ChangedAt time.Time              `json:"changed_at"`
+}
+
+// FieldChange is a game column's value before and after an update
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// StatDelta is how much one player's box score line moved
+type StatDelta struct {
+	PlayerID int                `json:"player_id"`
+	TeamID   int                `json:"team_id"`
+	Deltas   map[string]float64 `json:"deltas"` // keyed by the PlayerGameStats JSON name
 }
 
 // TeamGameStats represents team stats for a single game
 type TeamGameStats struct {
-	ID                     int             `json:"id" db:"id"`
-	GameID                 int             `json:"game_id" db:"game_id"`
-	TeamID                 int             `json:"team_id" db:"team_id"`
-	IsHome                 bool            `json:"is_home" db:"is_home"`
-	Points                 int             `json:"points" db:"points"`
-	FieldGoalsMade         int             `json:"field_goals_made" db:"field_goals_made"`
-	FieldGoalsAttempted    int             `json:"field_goals_attempted" db:"field_goals_attempted"`
-	ThreePointersMade      int             `json:"three_pointers_made" db:"three_pointers_made"`
-	ThreePointersAttempted int             `json:"three_pointers_attempted" db:"three_pointers_attempted"`
-	FreeThrowsMade         int             `json:"free_throws_made" db:"free_throws_made"`
-	FreeThrowsAttempted    int             `json:"free_throws_attempted" db:"free_throws_attempted"`
-	OffensiveRebounds      int             `json:"offensive_rebounds" db:"offensive_rebounds"`
-	DefensiveRebounds      int             `json:"defensive_rebounds" db:"defensive_rebounds"`
-	Rebounds               int             `json:"rebounds" db:"rebounds"`
-	Assists                int             `json:"assists" db:"assists"`
-	Steals                 int             `json:"steals" db:"steals"`
-	Blocks                 int             `json:"blocks" db:"blocks"`
-	Turnovers              int             `json:"turnovers" db:"turnovers"`
-	PersonalFouls          int             `json:"personal_fouls" db:"personal_fouls"`
-	TrueShootingPct        sql.NullFloat64 `json:"true_shooting_pct,omitempty" db:"true_shooting_pct"`
-	EffectiveFGPct         sql.NullFloat64 `json:"effective_fg_pct,omitempty" db:"effective_fg_pct"`
-	TurnoverPct            sql.NullFloat64 `json:"turnover_pct,omitempty" db:"turnover_pct"`
-	OffensiveReboundPct    sql.NullFloat64 `json:"offensive_rebound_pct,omitempty" db:"offensive_rebound_pct"`
-	DefensiveReboundPct    sql.NullFloat64 `json:"defensive_rebound_pct,omitempty" db:"defensive_rebound_pct"`
-	FreeThrowRate          sql.NullFloat64 `json:"free_throw_rate,omitempty" db:"free_throw_rate"`
-	Possessions            sql.NullFloat64 `json:"possessions,omitempty" db:"possessions"`
-	Pace                   sql.NullFloat64 `json:"pace,omitempty" db:"pace"`
-	OffensiveRating        sql.NullFloat64 `json:"offensive_rating,omitempty" db:"offensive_rating"`
-	DefensiveRating        sql.NullFloat64 `json:"defensive_rating,omitempty" db:"defensive_rating"`
-	NetRating              sql.NullFloat64 `json:"net_rating,omitempty" db:"net_rating"`
-	CreatedAt              time.Time       `json:"created_at" db:"created_at"`
-	UpdatedAt              time.Time       `json:"updated_at" db:"updated_at"`
+	ID                     int         `json:"id" db:"id"`
+	GameID                 int         `json:"game_id" db:"game_id"`
+	TeamID                 int         `json:"team_id" db:"team_id"`
+	IsHome                 bool        `json:"is_home" db:"is_home"`
+	Points                 int         `json:"points" db:"points"`
+	FieldGoalsMade         int         `json:"field_goals_made" db:"field_goals_made"`
+	FieldGoalsAttempted    int         `json:"field_goals_attempted" db:"field_goals_attempted"`
+	ThreePointersMade      int         `json:"three_pointers_made" db:"three_pointers_made"`
+	ThreePointersAttempted int         `json:"three_pointers_attempted" db:"three_pointers_attempted"`
+	FreeThrowsMade         int         `json:"free_throws_made" db:"free_throws_made"`
+	FreeThrowsAttempted    int         `json:"free_throws_attempted" db:"free_throws_attempted"`
+	OffensiveRebounds      int         `json:"offensive_rebounds" db:"offensive_rebounds"`
+	DefensiveRebounds      int         `json:"defensive_rebounds" db:"defensive_rebounds"`
+	Rebounds               int         `json:"rebounds" db:"rebounds"`
+	Assists                int         `json:"assists" db:"assists"`
+	Steals                 int         `json:"steals" db:"steals"`
+	Blocks                 int         `json:"blocks" db:"blocks"`
+	Turnovers              int         `json:"turnovers" db:"turnovers"`
+	PersonalFouls          int         `json:"personal_fouls" db:"personal_fouls"`
+	TechnicalFouls         NullInt32   `json:"technical_fouls,omitempty" db:"technical_fouls"` // null when ESPN did not report them
+	FlagrantFouls          NullInt32   `json:"flagrant_fouls,omitempty" db:"flagrant_fouls"`
+	FastBreakPoints        NullInt32   `json:"fast_break_points,omitempty" db:"fast_break_points"`
+	PointsInPaint          NullInt32   `json:"points_in_paint,omitempty" db:"points_in_paint"`
+	PointsOffTurnovers     NullInt32   `json:"points_off_turnovers,omitempty" db:"points_off_turnovers"`
+	SecondChancePoints     NullInt32   `json:"second_chance_points,omitempty" db:"second_chance_points"`
+	BenchPoints            NullInt32   `json:"bench_points,omitempty" db:"bench_points"`
+	LargestLead            NullInt32   `json:"largest_lead,omitempty" db:"largest_lead"`
+	TrueShootingPct        NullFloat64 `json:"true_shooting_pct,omitempty" db:"true_shooting_pct"`
+	EffectiveFGPct         NullFloat64 `json:"effective_fg_pct,omitempty" db:"effective_fg_pct"`
+	TurnoverPct            NullFloat64 `json:"turnover_pct,omitempty" db:"turnover_pct"`
+	OffensiveReboundPct    NullFloat64 `json:"offensive_rebound_pct,omitempty" db:"offensive_rebound_pct"`
+	DefensiveReboundPct    NullFloat64 `json:"defensive_rebound_pct,omitempty" db:"defensive_rebound_pct"`
+	FreeThrowRate          NullFloat64 `json:"free_throw_rate,omitempty" db:"free_throw_rate"`
+	Possessions            NullFloat64 `json:"possessions,omitempty" db:"possessions"`
+	Pace                   NullFloat64 `json:"pace,omitempty" db:"pace"`
+	OffensiveRating        NullFloat64 `json:"offensive_rating,omitempty" db:"offensive_rating"`
+	DefensiveRating        NullFloat64 `json:"defensive_rating,omitempty" db:"defensive_rating"`
+	NetRating              NullFloat64 `json:"net_rating,omitempty" db:"net_rating"`
+	CreatedAt              time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt              time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// GamePlay is a single play-by-play event
+type GamePlay struct {
+	PlayID       int64      `json:"play_id" db:"play_id"`
+	GameID       int        `json:"game_id" db:"game_id"`
+	Sequence     int        `json:"sequence" db:"sequence"`
+	ExternalID   NullString `json:"external_id,omitempty" db:"external_id"`
+	Period       int        `json:"period" db:"period"`
+	Clock        NullString `json:"clock,omitempty" db:"clock"`
+	ClockSeconds NullInt32  `json:"clock_seconds,omitempty" db:"clock_seconds"`
+	TeamID       NullInt32  `json:"team_id,omitempty" db:"team_id"`
+	PlayerID     NullInt32  `json:"player_id,omitempty" db:"player_id"`
+	PlayType     NullString `json:"play_type,omitempty" db:"play_type"`
+	Description  NullString `json:"description,omitempty" db:"description"`
+	ScoringPlay  bool       `json:"scoring_play" db:"scoring_play"`
+	ShootingPlay bool       `json:"shooting_play" db:"shooting_play"`
+	ScoreValue   int        `json:"score_value" db:"score_value"`
+	HomeScore    int        `json:"home_score" db:"home_score"`
+	AwayScore    int        `json:"away_score" db:"away_score"`
+	Wallclock    NullTime   `json:"wallclock,omitempty" db:"wallclock"`
+}
+
+// GameHighlight is an ESPN video clip for a game. The play fields are filled
+// when ESPN tags the clip with a play that is stored in game_plays.
+type GameHighlight struct {
+	HighlightID     int        `json:"highlight_id" db:"highlight_id"`
+	GameID          int        `json:"game_id" db:"game_id"`
+	ExternalID      string     `json:"external_id" db:"external_id"`
+	Headline        string     `json:"headline" db:"headline"`
+	Description     NullString `json:"description" db:"description"`
+	DurationSeconds NullInt32  `json:"duration_seconds" db:"duration_seconds"`
+	ThumbnailURL    NullString `json:"thumbnail_url" db:"thumbnail_url"`
+	WebURL          NullString `json:"web_url" db:"web_url"`
+	VideoURL        NullString `json:"video_url" db:"video_url"`
+	PlayExternalID  NullString `json:"play_external_id" db:"play_external_id"`
+	PublishedAt     NullTime   `json:"published_at" db:"published_at"`
+	PlaySequence    NullInt32  `json:"play_sequence" db:"-"`
+	Period          NullInt32  `json:"period" db:"-"`
+	Clock           NullString `json:"clock" db:"-"`
+	TeamID          NullInt32  `json:"team_id" db:"-"`
+	PlayerID        NullInt32  `json:"player_id" db:"-"`
+}
+
+// GameStarter is a player ESPN lists in a team's starting lineup for a game.
+// The player fields are filled from players on reads.
+type GameStarter struct {
+	GameID     int        `json:"game_id" db:"game_id"`
+	TeamID     int        `json:"team_id" db:"team_id"`
+	PlayerID   int        `json:"player_id" db:"player_id"`
+	LineupSlot int        `json:"lineup_slot" db:"lineup_slot"`
+	Position   NullString `json:"position" db:"position"`
+	CapturedAt time.Time  `json:"captured_at" db:"captured_at"`
+	FullName   string     `json:"full_name" db:"-"`
+	ExternalID NullString `json:"external_id" db:"-"`
+}
+
+// QuarterScores holds per-period points (overtimes appended), stored in games.game_data
+type QuarterScores struct {
+	Home []int `json:"home"`
+	Away []int `json:"away"`
+}
+
+// CareerTotals sums a player's box scores, for one season or a whole career
+type CareerTotals struct {
+	SeasonYear             string  `json:"season_year,omitempty"`
+	SeasonType             string  `json:"season_type,omitempty"`
+	GamesPlayed            int     `json:"games_played"`
+	GamesStarted           int     `json:"games_started"`
+	Minutes                float64 `json:"minutes"`
+	Points                 int     `json:"points"`
+	Rebounds               int     `json:"rebounds"`
+	OffensiveRebounds      int     `json:"offensive_rebounds"`
+	DefensiveRebounds      int     `json:"defensive_rebounds"`
+	Assists                int     `json:"assists"`
+	Steals                 int     `json:"steals"`
+	Blocks                 int     `json:"blocks"`
+	Turnovers              int     `json:"turnovers"`
+	PersonalFouls          int     `json:"personal_fouls"`
+	FieldGoalsMade         int     `json:"field_goals_made"`
+	FieldGoalsAttempted    int     `json:"field_goals_attempted"`
+	ThreePointersMade      int     `json:"three_pointers_made"`
+	ThreePointersAttempted int     `json:"three_pointers_attempted"`
+	FreeThrowsMade         int     `json:"free_throws_made"`
+	FreeThrowsAttempted    int     `json:"free_throws_attempted"`
+}
+
+// Add accumulates another season's totals
+func (t *CareerTotals) Add(o *CareerTotals) {
+	t.GamesPlayed += o.GamesPlayed
+	t.GamesStarted += o.GamesStarted
+	t.Minutes += o.Minutes
+	t.Points += o.Points
+	t.Rebounds += o.Rebounds
+	t.OffensiveRebounds += o.OffensiveRebounds
+	t.DefensiveRebounds += o.DefensiveRebounds
+	t.Assists += o.Assists
+	t.Steals += o.Steals
+	t.Blocks += o.Blocks
+	t.Turnovers += o.Turnovers
+	t.PersonalFouls += o.PersonalFouls
+	t.FieldGoalsMade += o.FieldGoalsMade
+	t.FieldGoalsAttempted += o.FieldGoalsAttempted
+	t.ThreePointersMade += o.ThreePointersMade
+	t.ThreePointersAttempted += o.ThreePointersAttempted
+	t.FreeThrowsMade += o.FreeThrowsMade
+	t.FreeThrowsAttempted += o.FreeThrowsAttempted
+}
+
+// Stat returns the total for a milestone stat name
+func (t *CareerTotals) Stat(name string) (int, bool) {
+	switch name {
+	case "games_played":
+		return t.GamesPlayed, true
+	case "points":
+		return t.Points, true
+	case "rebounds":
+		return t.Rebounds, true
+	case "assists":
+		return t.Assists, true
+	case "steals":
+		return t.Steals, true
+	case "blocks":
+		return t.Blocks, true
+	case "three_pointers_made":
+		return t.ThreePointersMade, true
+	}
+	return 0, false
+}
+
+// CareerHigh is a player's best single-game value for one stat
+type CareerHigh struct {
+	Stat     string    `json:"stat"`
+	Value    int       `json:"value"`
+	GameID   string    `json:"game_id"` // external (ESPN) ID
+	GameDate time.Time `json:"game_date"`
+}
+
+// MilestoneThreshold is a configured career total worth flagging
+type MilestoneThreshold struct {
+	ThresholdID int        `json:"threshold_id" db:"threshold_id"`
+	Sport       string     `json:"sport" db:"sport"`
+	Stat        string     `json:"stat" db:"stat"`
+	Threshold   int        `json:"threshold" db:"threshold"`
+	Proximity   int        `json:"proximity" db:"proximity"`
+	Label       NullString `json:"label,omitempty" db:"label"`
+}
+
+// PlayerWorkload is a player's rolling minute load as of one night
+type PlayerWorkload struct {
+	PlayerID             int       `json:"player_id" db:"player_id"`
+	AsOfDate             time.Time `json:"as_of_date" db:"as_of_date"`
+	Minutes3d            float64   `json:"minutes_3d" db:"minutes_3d"`
+	Minutes7d            float64   `json:"minutes_7d" db:"minutes_7d"`
+	Minutes14d           float64   `json:"minutes_14d" db:"minutes_14d"`
+	Games7d              int       `json:"games_7d" db:"games_7d"`
+	Games14d             int       `json:"games_14d" db:"games_14d"`
+	BackToBacks14d       int       `json:"back_to_backs_14d" db:"back_to_backs_14d"`
+	BackToBackMinutes14d float64   `json:"back_to_back_minutes_14d" db:"back_to_back_minutes_14d"`
+	FatigueIndex         float64   `json:"fatigue_index" db:"fatigue_index"`
+}
+
+// TeamPositionalDefense is what players at one position produced per game
+// against a team over a season
+type TeamPositionalDefense struct {
+	TeamID          int         `json:"team_id" db:"team_id"`
+	SeasonID        int         `json:"season_id" db:"season_id"`
+	Position        string      `json:"position" db:"position"`
+	Games           int         `json:"games" db:"games"`
+	PointsPerGame   float64     `json:"points_per_game" db:"points_per_game"`
+	ReboundsPerGame float64     `json:"rebounds_per_game" db:"rebounds_per_game"`
+	AssistsPerGame  float64     `json:"assists_per_game" db:"assists_per_game"`
+	ThreesPerGame   float64     `json:"threes_per_game" db:"threes_per_game"`
+	FGPct           NullFloat64 `json:"fg_pct,omitempty" db:"fg_pct"`
+	PointsVsLeague  float64     `json:"points_vs_league" db:"points_vs_league"` // above zero is softer than average
+}
+
+// TeamRating is a team's Elo rating before and after one final game
+type TeamRating struct {
+	TeamID         int       `json:"team_id" db:"team_id"`
+	GameID         int       `json:"game_id" db:"game_id"`
+	SeasonID       int       `json:"season_id" db:"season_id"`
+	RatingDate     time.Time `json:"rating_date" db:"rating_date"`
+	OpponentTeamID int       `json:"opponent_team_id" db:"opponent_team_id"`
+	IsHome         bool      `json:"is_home" db:"is_home"`
+	EloBefore      float64   `json:"elo_before" db:"elo_before"`
+	Elo            float64   `json:"elo" db:"elo"`
+	WinProbability float64   `json:"win_probability" db:"win_probability"` // pregame
+}
+
+// PlayoffSeries is a postseason matchup. TeamA is the lower team_id of the pair.
+type PlayoffSeries struct {
+	SeriesID     int        `json:"series_id" db:"series_id"`
+	Sport        string     `json:"sport" db:"sport"`
+	SeasonID     int        `json:"season_id" db:"season_id"`
+	Round        NullInt32  `json:"round,omitempty" db:"round"`
+	RoundName    NullString `json:"round_name,omitempty" db:"round_name"`
+	Conference   NullString `json:"conference,omitempty" db:"conference"`
+	TeamAID      int        `json:"team_a_id" db:"team_a_id"`
+	TeamBID      int        `json:"team_b_id" db:"team_b_id"`
+	TeamAWins    int        `json:"team_a_wins" db:"team_a_wins"`
+	TeamBWins    int        `json:"team_b_wins" db:"team_b_wins"`
+	BestOf       int        `json:"best_of" db:"best_of"`
+	WinnerTeamID NullInt32  `json:"winner_team_id,omitempty" db:"winner_team_id"`
+	Status       string     `json:"status" db:"status"`
+	StartDate    NullTime   `json:"start_date,omitempty"` // first linked game
+}
+
+// PlayoffSeriesGame is one game of a playoff series
+type PlayoffSeriesGame struct {
+	SeriesID   int       `json:"series_id"`
+	GameNumber int       `json:"game_number"`
+	GameID     string    `json:"game_id"` // external (ESPN) ID
+	GameDate   time.Time `json:"game_date"`
+	HomeTeamID int       `json:"home_team_id"`
+	AwayTeamID int       `json:"away_team_id"`
+	HomeScore  NullInt32 `json:"home_score,omitempty"`
+	AwayScore  NullInt32 `json:"away_score,omitempty"`
+	Status     string    `json:"status"`
+}
+
+// PlayoffGameInfo marks a game as postseason in game responses
+type PlayoffGameInfo struct {
+	SeriesID   int        `json:"series_id"`
+	Round      NullInt32  `json:"round,omitempty"`
+	RoundName  NullString `json:"round_name,omitempty"`
+	GameNumber int        `json:"game_number"`
+	TeamAID    int        `json:"team_a_id"`
+	TeamAWins  int        `json:"team_a_wins"`
+	TeamBID    int        `json:"team_b_id"`
+	TeamBWins  int        `json:"team_b_wins"`
 }
 
 // OddsMapping links ESPN games to Alexandria events
 type OddsMapping struct {
-	ID                  int            `json:"id" db:"id"`
-	ESPNGameID          string         `json:"espn_game_id" db:"espn_game_id"`
-	AlexandriaEventID   sql.NullString `json:"alexandria_event_id,omitempty" db:"alexandria_event_id"`
-	MappingConfidence   float64        `json:"mapping_confidence" db:"mapping_confidence"`
-	MappedAt            time.Time      `json:"mapped_at" db:"mapped_at"`
-	CreatedAt           time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt           time.Time      `json:"updated_at" db:"updated_at"`
+	ID                int        `json:"id" db:"id"`
+	ESPNGameID        string     `json:"espn_game_id" db:"espn_game_id"`
+	AlexandriaEventID NullString `json:"alexandria_event_id,omitempty" db:"alexandria_event_id"`
+	MappingConfidence float64    `json:"mapping_confidence" db:"mapping_confidence"`
+	MappedAt          time.Time  `json:"mapped_at" db:"mapped_at"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// GameClosingLine pairs a final score with the closing line of its mapped Alexandria event
+type GameClosingLine struct {
+	GameID            int         `json:"game_id"`
+	GameDate          time.Time   `json:"game_date"`
+	HomeTeamID        int         `json:"home_team_id"`
+	AwayTeamID        int         `json:"away_team_id"`
+	HomeScore         int         `json:"home_score"`
+	AwayScore         int         `json:"away_score"`
+	AlexandriaEventID string      `json:"alexandria_event_id"`
+	HomeSpread        NullFloat64 `json:"home_spread,omitempty"`
+	Total             NullFloat64 `json:"total,omitempty"`
+	Bookmaker         NullString  `json:"bookmaker,omitempty"`
+}
+
+// Entity types in external_ids
+const (
+	EntityPlayer = "player"
+	EntityTeam   = "team"
+	EntityGame   = "game"
+)
+
+// EntityPlayerStats is the write hook entity type for a player's box score
+// lines, with the player ID. It is not used in external_ids.
+const EntityPlayerStats = "player_stats"
+
+// ID sources in external_ids
+const (
+	SourceESPN                = "espn"
+	SourceNBAStats            = "nba_stats"
+	SourceBasketballReference = "basketball_reference"
+	SourceAlexandria          = "alexandria"
+)
+
+// Game ID sources accepted by game resolution that are not external_ids rows:
+// Minerva's own game_id and the synthetic google_ IDs of scraped games
+const (
+	SourceMinerva = "minerva"
+	SourceGoogle  = "google"
+)
+
+// ExternalIDMapping ties one source's ID for a player, team, or game to its Minerva ID
+type ExternalIDMapping struct {
+	EntityType string    `json:"entity_type"`
+	Source     string    `json:"source"`
+	SourceID   string    `json:"source_id"`
+	InternalID int       `json:"internal_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// PlayerTransaction is a roster move from the ESPN transactions feed
+type PlayerTransaction struct {
+	TransactionID   int       `json:"transaction_id" db:"transaction_id"`
+	Sport           string    `json:"sport" db:"sport"`
+	TransactionDate time.Time `json:"transaction_date" db:"transaction_date"`
+	TransactionType string    `json:"transaction_type" db:"transaction_type"`
+	TeamID          int       `json:"team_id" db:"team_id"`
+	TeamAbbr        string    `json:"team_abbreviation,omitempty" db:"-"`
+	PlayerID        NullInt32 `json:"player_id,omitempty" db:"player_id"`
+	PlayerName      string    `json:"player_name,omitempty" db:"-"`
+	Description     string    `json:"description" db:"description"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// NewsArticle is an article's metadata from the ESPN news feeds
+type NewsArticle struct {
+	ArticleID   int        `json:"article_id" db:"article_id"`
+	Sport       string     `json:"sport" db:"sport"`
+	ExternalID  string     `json:"external_id" db:"external_id"`
+	ArticleType NullString `json:"article_type,omitempty" db:"article_type"`
+	Headline    string     `json:"headline" db:"headline"`
+	Description NullString `json:"description,omitempty" db:"description"`
+	Link        NullString `json:"link,omitempty" db:"link"`
+	PublishedAt time.Time  `json:"published_at" db:"published_at"`
+	TeamIDs     []int      `json:"team_ids" db:"team_ids"`
+	PlayerIDs   []int      `json:"player_ids" db:"player_ids"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// DraftPick is one selection in a draft class with the player's stored NBA
+// regular-season career totals
+type DraftPick struct {
+	DraftYear     int           `json:"draft_year"`
+	Round         int           `json:"round"`
+	Pick          int           `json:"pick"` // overall
+	DraftTeamID   NullInt32     `json:"draft_team_id,omitempty"`
+	DraftTeamAbbr string        `json:"draft_team_abbreviation,omitempty"`
+	Player        *Player       `json:"player"`
+	Career        *CareerTotals `json:"career"`
+}
+
+// PlayerBio is the biographical data read from an athlete profile. Invalid
+// fields are unknown and never overwrite stored values.
+type PlayerBio struct {
+	BirthDate    NullTime
+	BirthCity    NullString
+	BirthCountry NullString
+	Nationality  NullString
+	Height       NullString
+	HeightInches NullInt32
+	Weight       NullInt32
+	College      NullString
+	HeadshotURL  NullString
+}
+
+// Player enrichment outcomes, recorded per attempt in player_enrichment
+const (
+	EnrichmentEnriched  = "enriched"    // at least one missing field was filled
+	EnrichmentNoNewData = "no_new_data" // the profile had nothing the player was missing
+	EnrichmentNotFound  = "not_found"   // ESPN has no profile for the ID
+	EnrichmentFailed    = "failed"      // the fetch or update failed
+)
+
+// EnrichmentStatus reports biographical coverage and the enrichment job's progress
+type EnrichmentStatus struct {
+	Players        int            `json:"players"`
+	MissingAny     int            `json:"missing_any"`      // players missing at least one tracked field
+	MissingByField map[string]int `json:"missing_by_field"` // birth_date, birth_city, height_inches, college, nationality
+	Attempted      int            `json:"attempted"`
+	ByStatus       map[string]int `json:"by_status"` // last outcome per attempted player
+	Pending        int            `json:"pending"`   // missing fields, have an ESPN ID, and are due for an attempt
+	LastAttemptAt  *time.Time     `json:"last_attempt_at,omitempty"`
+}
+
+// Coach is a head coach seen in an ESPN team roster
+type Coach struct {
+	CoachID    int        `json:"coach_id" db:"coach_id"`
+	Sport      string     `json:"sport" db:"sport"`
+	ExternalID string     `json:"external_id" db:"external_id"`
+	FirstName  NullString `json:"first_name,omitempty" db:"first_name"`
+	LastName   NullString `json:"last_name,omitempty" db:"last_name"`
+	FullName   string     `json:"full_name" db:"full_name"`
+}
+
+// CoachTenure is one coach's stint as a team's head coach. StartDate is the
+// first roster sync that saw the coach; EndDate is null while the stint is open.
+type CoachTenure struct {
+	HistoryID  int       `json:"history_id" db:"history_id"`
+	CoachID    int       `json:"coach_id" db:"coach_id"`
+	ExternalID string    `json:"external_id" db:"-"`
+	FullName   string    `json:"full_name" db:"-"`
+	TeamID     int       `json:"team_id" db:"team_id"`
+	SeasonID   NullInt32 `json:"season_id,omitempty" db:"season_id"`
+	StartDate  time.Time `json:"start_date" db:"start_date"`
+	EndDate    NullTime  `json:"end_date" db:"end_date"`
+	IsCurrent  bool      `json:"is_current" db:"-"`
+}
+
+// OutboxEntry is one stream publish waiting in (or delivered from) the event outbox
+type OutboxEntry struct {
+	OutboxID  int64
+	Stream    string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+	Attempts  int
+}
+
+// Ingestion run sources
+const (
+	IngestionSourceDaily    = "scheduler_daily"
+	IngestionSourceManual   = "scheduler_manual"
+	IngestionSourceBackfill = "backfill"
+)
+
+// IngestionRun records a single scheduler or backfill ingestion pass
+type IngestionRun struct {
+	RunID          int        `json:"run_id" db:"run_id"`
+	Sport          string     `json:"sport" db:"sport"`
+	Source         string     `json:"source" db:"source"`
+	StartDate      NullTime   `json:"start_date,omitempty" db:"start_date"`
+	EndDate        NullTime   `json:"end_date,omitempty" db:"end_date"`
+	Status         string     `json:"status" db:"status"`
+	GamesProcessed int        `json:"games_processed" db:"games_processed"`
+	RowsChanged    int        `json:"rows_changed" db:"rows_changed"`
+	RowsUnchanged  int        `json:"rows_unchanged" db:"rows_unchanged"`
+	ErrorCount     int        `json:"error_count" db:"error_count"`
+	LastError      NullString `json:"last_error,omitempty" db:"last_error"`
+	StartedAt      time.Time  `json:"started_at" db:"started_at"`
+	CompletedAt    NullTime   `json:"completed_at,omitempty" db:"completed_at"`
+	DurationMs     NullInt64  `json:"duration_ms,omitempty" db:"duration_ms"`
+	Metadata       NullString `json:"metadata,omitempty" db:"metadata"`
+}
+
+// SourceFreshness is the most recent run for one ingestion source
+type SourceFreshness struct {
+	Source              string    `json:"source"`
+	LastRunAt           time.Time `json:"last_run_at"`
+	LastRunStatus       string    `json:"last_run_status"`
+	LastSuccessAt       NullTime  `json:"last_success_at,omitempty"`
+	SecondsSinceSuccess *int64    `json:"seconds_since_success,omitempty"`
+}
+
+// DataFreshness summarises how recently stored data was refreshed
+type DataFreshness struct {
+	LastGameUpdate NullTime          `json:"last_game_update,omitempty"`
+	LastFinalGame  NullTime          `json:"last_final_game_date,omitempty"`
+	Sources        []SourceFreshness `json:"sources"`
 }
 
+// AttendanceMonth aggregates reported home attendance for one calendar month.
+// Zero attendance is treated as missing and left out.
+type AttendanceMonth struct {
+	Month           string   `json:"month"` // YYYY-MM
+	Games           int      `json:"games"`
+	TotalAttendance int64    `json:"total_attendance"`
+	AvgAttendance   float64  `json:"avg_attendance"`
+	Sellouts        int      `json:"sellouts"`                     // attendance at or above venue capacity
+	AvgUtilization  *float64 `json:"avg_capacity_utilization_pct"` // nil when no home venue capacity is known
+	CapacityGames   int      `json:"-"`                            // games with a known capacity, for weighting
+}
+
+// AttendanceOutlier is a final game whose reported attendance looks wrong
+type AttendanceOutlier struct {
+	GameID        int       `json:"game_id"`
+	ExternalID    string    `json:"external_id"`
+	GameDate      time.Time `json:"game_date"`
+	HomeTeamID    int       `json:"home_team_id"`
+	Attendance    int       `json:"attendance"`
+	VenueCapacity NullInt32 `json:"venue_capacity"`
+	TeamMedian    float64   `json:"team_median_attendance"`
+	Reason        string    `json:"reason"` // zero or far_below_median
+}
+
+// StatTotals sums a player's or team's counting stats with the playing time and
+// on-court possessions they were produced in, for per-36 and per-100 normalization
+type StatTotals struct {
+	Games             int     `json:"games"`
+	OvertimeGames     int     `json:"overtime_games"`
+	Seconds           int64   `json:"seconds_played"`
+	Possessions       float64 `json:"possessions"` // team possessions scaled by the player's share of game time
+	Points            int     `json:"points"`
+	Rebounds          int     `json:"rebounds"`
+	OffensiveRebounds int     `json:"offensive_rebounds"`
+	DefensiveRebounds int     `json:"defensive_rebounds"`
+	Assists           int     `json:"assists"`
+	Steals            int     `json:"steals"`
+	Blocks            int     `json:"blocks"`
+	Turnovers         int     `json:"turnovers"`
+	ThreePointersMade int     `json:"three_pointers_made"`
+	ThreePointersAtt  int     `json:"three_pointers_attempted"`
+	FieldGoalsMade    int     `json:"field_goals_made"`
+	FieldGoalsAtt     int     `json:"field_goals_attempted"`
+	FreeThrowsMade    int     `json:"free_throws_made"`
+	FreeThrowsAtt     int     `json:"free_throws_attempted"`
+}
+
+// StatRates are counting stats on a common basis: per game, per 36 minutes, or
+// per 100 possessions
+type StatRates struct {
+	Per                 string  `json:"per"`
+	Points              float64 `json:"points"`
+	Rebounds            float64 `json:"rebounds"`
+	OffensiveRebounds   float64 `json:"offensive_rebounds"`
+	DefensiveRebounds   float64 `json:"defensive_rebounds"`
+	Assists             float64 `json:"assists"`
+	Steals              float64 `json:"steals"`
+	Blocks              float64 `json:"blocks"`
+	Turnovers           float64 `json:"turnovers"`
+	ThreePointersMade   float64 `json:"three_pointers_made"`
+	FieldGoalsMade      float64 `json:"field_goals_made"`
+	FieldGoalsAttempted float64 `json:"field_goals_attempted"`
+	FreeThrowsMade      float64 `json:"free_throws_made"`
+	FreeThrowsAttempted float64 `json:"free_throws_attempted"`
+}
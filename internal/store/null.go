@@ -0,0 +1,189 @@
+package store
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+// Nullable column types. They scan and bind exactly like their database/sql
+// counterparts (same fields, so literals and .Valid checks read the same) but
+// marshal to JSON as the bare value or null instead of {"String":"","Valid":false}.
+
+// NullString is a nullable string column
+type NullString sql.NullString
+
+// NullInt32 is a nullable int32 column
+type NullInt32 sql.NullInt32
+
+// NullInt64 is a nullable int64 column
+type NullInt64 sql.NullInt64
+
+// NullFloat64 is a nullable float64 column
+type NullFloat64 sql.NullFloat64
+
+// NullTime is a nullable timestamp column
+type NullTime sql.NullTime
+
+var jsonNull = []byte("null")
+
+func isJSONNull(data []byte) bool {
+	return string(data) == "null"
+}
+
+// Scan implements sql.Scanner
+func (n *NullString) Scan(value interface{}) error {
+	return (*sql.NullString)(n).Scan(value)
+}
+
+// Value implements driver.Valuer
+func (n NullString) Value() (driver.Value, error) {
+	return sql.NullString(n).Value()
+}
+
+// MarshalJSON writes the string or null
+func (n NullString) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.String)
+}
+
+// UnmarshalJSON reads a string or null
+func (n *NullString) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		*n = NullString{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.String); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Scan implements sql.Scanner
+func (n *NullInt32) Scan(value interface{}) error {
+	return (*sql.NullInt32)(n).Scan(value)
+}
+
+// Value implements driver.Valuer
+func (n NullInt32) Value() (driver.Value, error) {
+	return sql.NullInt32(n).Value()
+}
+
+// MarshalJSON writes the number or null
+func (n NullInt32) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.Int32)
+}
+
+// UnmarshalJSON reads a number or null
+func (n *NullInt32) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		*n = NullInt32{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.Int32); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Scan implements sql.Scanner
+func (n *NullInt64) Scan(value interface{}) error {
+	return (*sql.NullInt64)(n).Scan(value)
+}
+
+// Value implements driver.Valuer
+func (n NullInt64) Value() (driver.Value, error) {
+	return sql.NullInt64(n).Value()
+}
+
+// MarshalJSON writes the number or null
+func (n NullInt64) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.Int64)
+}
+
+// UnmarshalJSON reads a number or null
+func (n *NullInt64) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		*n = NullInt64{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.Int64); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Scan implements sql.Scanner
+func (n *NullFloat64) Scan(value interface{}) error {
+	return (*sql.NullFloat64)(n).Scan(value)
+}
+
+// Value implements driver.Valuer
+func (n NullFloat64) Value() (driver.Value, error) {
+	return sql.NullFloat64(n).Value()
+}
+
+// MarshalJSON writes the number or null
+func (n NullFloat64) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.Float64)
+}
+
+// UnmarshalJSON reads a number or null
+func (n *NullFloat64) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		*n = NullFloat64{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.Float64); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Scan implements sql.Scanner
+func (n *NullTime) Scan(value interface{}) error {
+	return (*sql.NullTime)(n).Scan(value)
+}
+
+// Value implements driver.Valuer
+func (n NullTime) Value() (driver.Value, error) {
+	return sql.NullTime(n).Value()
+}
+
+// MarshalJSON writes the RFC 3339 timestamp or null
+func (n NullTime) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.Time)
+}
+
+// UnmarshalJSON reads an RFC 3339 timestamp or null
+func (n *NullTime) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		*n = NullTime{}
+		return nil
+	}
+	var t time.Time
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+	*n = NullTime{Time: t, Valid: true}
+	return nil
+}
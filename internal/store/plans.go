@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// planCheckedTables are the tables large enough that a sequential scan on a hot
+// path is a bug. Small dimension tables (teams, seasons) are cheaper to scan.
+var planCheckedTables = []string{"games", "player_game_stats", "team_game_stats", "game_plays"}
+
+// PlanCheck is a hot query whose plan must stay on indexes
+type PlanCheck struct {
+	Name  string
+	Query string
+	Args  []interface{}
+}
+
+// planNode is the subset of EXPLAIN (FORMAT JSON) output inspected for scans
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	IndexName    string     `json:"Index Name"`
+	Plans        []planNode `json:"Plans"`
+}
+
+// SeqScans EXPLAINs a check with sequential scans disabled and returns the large
+// tables the planner still reads by Seq Scan, i.e. the ones no index can serve.
+// Disabling seqscan keeps the result stable on small development databases, where
+// the planner would otherwise scan tiny tables regardless of indexes.
+func (db *Database) SeqScans(ctx context.Context, check PlanCheck) ([]string, error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning plan check: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SET LOCAL enable_seqscan = off"); err != nil {
+		return nil, fmt.Errorf("disabling seqscan: %w", err)
+	}
+
+	var raw []byte
+	err = tx.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+check.Query, check.Args...).Scan(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("explaining %s: %w", check.Name, err)
+	}
+
+	var plans []struct {
+		Plan planNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(raw, &plans); err != nil {
+		return nil, fmt.Errorf("parsing plan for %s: %w", check.Name, err)
+	}
+
+	var scans []string
+	for _, p := range plans {
+		scans = collectSeqScans(p.Plan, scans)
+	}
+	return scans, nil
+}
+
+func collectSeqScans(node planNode, scans []string) []string {
+	if node.NodeType == "Seq Scan" && slices.Contains(planCheckedTables, node.RelationName) &&
+		!slices.Contains(scans, node.RelationName) {
+		scans = append(scans, node.RelationName)
+	}
+	for _, child := range node.Plans {
+		scans = collectSeqScans(child, scans)
+	}
+	return scans
+}
+
+// Explain plans a check without running it, so a query naming a column or table
+// the schema no longer has fails here rather than at request time
+func (db *Database) Explain(ctx context.Context, check PlanCheck) error {
+	rows, err := db.conn.QueryContext(ctx, "EXPLAIN "+check.Query, check.Args...)
+	if err != nil {
+		return fmt.Errorf("explaining %s: %w", check.Name, err)
+	}
+	return rows.Close()
+}
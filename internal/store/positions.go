@@ -0,0 +1,26 @@
+package store
+
+import "strings"
+
+// DefensivePositions are the groups positional defense is aggregated by
+var DefensivePositions = []string{"PG", "SG", "SF", "PF", "C"}
+
+// DefensivePosition maps a listed position to its positional defense group:
+// generic G counts as SG, F and G-F as SF, F-C as PF, and C-F as C. It returns
+// "" for unknown positions. Keep in step with the CASE in the positional
+// defense query.
+func DefensivePosition(position string) string {
+	switch strings.ToUpper(strings.TrimSpace(position)) {
+	case "PG":
+		return "PG"
+	case "SG", "G":
+		return "SG"
+	case "SF", "F", "G-F":
+		return "SF"
+	case "PF", "F-C":
+		return "PF"
+	case "C", "C-F":
+		return "C"
+	}
+	return ""
+}
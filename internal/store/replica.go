@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// replicaConnector opens the replica pool's connections. While the replica is
+// down it hands out primary connections instead, so a read already routed to
+// the replica pool when the replica failed is retried on the primary.
+type replicaConnector struct {
+	replica driver.Connector
+	primary driver.Connector
+	db      *Database
+}
+
+func (c *replicaConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if c.db.replicaHealthy.Load() {
+		conn, err := c.replica.Connect(ctx)
+		if err == nil {
+			return &replicaConn{instrumentedConn: conn.(*instrumentedConn), db: c.db}, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		c.db.markReplicaDown(err)
+	}
+
+	conn, err := c.primary.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &fallbackConn{conn.(*instrumentedConn)}, nil
+}
+
+func (c *replicaConnector) Driver() driver.Driver {
+	return c.replica.Driver()
+}
+
+// replicaConn turns a query failing because the replica went away into
+// driver.ErrBadConn. database/sql retries such a query on a fresh connection,
+// which replicaConnector opens on the primary once the replica is marked down.
+// Only the query itself is retried: an error while reading its rows is
+// returned to the caller.
+type replicaConn struct {
+	*instrumentedConn
+	db *Database
+}
+
+func (c *replicaConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.instrumentedConn.QueryContext(ctx, query, args)
+	if err != nil && ctx.Err() == nil && isConnectionError(err) {
+		c.db.markReplicaDown(err)
+		return nil, driver.ErrBadConn
+	}
+	return rows, err
+}
+
+// fallbackConn is a primary connection serving the replica pool. It is closed
+// after each use rather than kept idle, so the pool refills from the replica
+// once it recovers.
+type fallbackConn struct {
+	*instrumentedConn
+}
+
+func (c *fallbackConn) IsValid() bool {
+	return false
+}
+
+// markReplicaDown routes reads to the primary until monitorReplica sees the
+// replica answer again
+func (db *Database) markReplicaDown(err error) {
+	if db.replicaHealthy.Swap(false) {
+		log.Printf("⚠️  Read replica failed (%v), falling back to primary for reads", err)
+	}
+}
+
+// isConnectionError reports whether err means the server could not be reached
+// or dropped the connection, as opposed to rejecting the query
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// Class 08 = connection exception; 57P01-57P03 = the server shutting
+		// down or not yet accepting connections
+		return pqErr.Code.Class() == "08" || strings.HasPrefix(string(pqErr.Code), "57P")
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+// serverConnector stands in for one Postgres server: every query answers with
+// name, or fails with err when it is set
+type serverConnector struct {
+	name    string
+	err     error
+	queries int
+}
+
+func (c *serverConnector) Connect(context.Context) (driver.Conn, error) { return &serverConn{c}, nil }
+func (c *serverConnector) Driver() driver.Driver                        { return nil }
+
+type serverConn struct{ c *serverConnector }
+
+func (c *serverConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *serverConn) Close() error                        { return nil }
+func (c *serverConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+func (c *serverConn) Ping(context.Context) error          { return c.c.err }
+func (c *serverConn) ResetSession(context.Context) error  { return nil }
+func (c *serverConn) IsValid() bool                       { return true }
+
+func (c *serverConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	c.c.queries++
+	if c.c.err != nil {
+		return nil, c.c.err
+	}
+	return &serverRows{name: c.c.name}, nil
+}
+
+type serverRows struct {
+	name string
+	done bool
+}
+
+func (r *serverRows) Columns() []string { return []string{"server"} }
+func (r *serverRows) Close() error      { return nil }
+
+func (r *serverRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.name
+	return nil
+}
+
+// replicaPool opens a replica pool over two stub servers with the replica healthy
+func replicaPool(replica, primary *serverConnector) (*Database, *sql.DB) {
+	observer := newQueryObserver()
+	db := &Database{observer: observer}
+	db.replicaHealthy.Store(true)
+	pool := sql.OpenDB(&replicaConnector{
+		replica: &instrumentedConnector{Connector: replica, observer: observer},
+		primary: &instrumentedConnector{Connector: primary, observer: observer},
+		db:      db,
+	})
+	return db, pool
+}
+
+func TestReplicaReadRetriesOnPrimary(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+	}{
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}},
+		{"server shutting down", &pq.Error{Code: "57P01", Message: "terminating connection due to administrator command"}},
+		{"connection lost", io.ErrUnexpectedEOF},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			replica := &serverConnector{name: "replica", err: tc.err}
+			primary := &serverConnector{name: "primary"}
+			db, pool := replicaPool(replica, primary)
+			defer pool.Close()
+
+			var server string
+			if err := pool.QueryRowContext(context.Background(), "SELECT 1").Scan(&server); err != nil {
+				t.Fatalf("query: %v", err)
+			}
+			if server != "primary" {
+				t.Errorf("answered by %s, want primary", server)
+			}
+			if db.replicaHealthy.Load() {
+				t.Error("replica still marked healthy")
+			}
+			if replica.queries != 1 {
+				t.Errorf("replica saw %d queries, want 1", replica.queries)
+			}
+		})
+	}
+}
+
+func TestReplicaQueryErrorIsNotRetried(t *testing.T) {
+	replica := &serverConnector{name: "replica", err: &pq.Error{Code: "42P01", Message: `relation "games" does not exist`}}
+	primary := &serverConnector{name: "primary"}
+	db, pool := replicaPool(replica, primary)
+	defer pool.Close()
+
+	var pqErr *pq.Error
+	if err := pool.QueryRowContext(context.Background(), "SELECT 1").Scan(new(string)); !errors.As(err, &pqErr) {
+		t.Fatalf("got %v, want the replica's error", err)
+	}
+	if !db.replicaHealthy.Load() {
+		t.Error("replica marked down for a query error")
+	}
+	if primary.queries != 0 {
+		t.Errorf("primary saw %d queries, want 0", primary.queries)
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/lib/pq"
+)
+
+// AttendanceOutlierRatio flags games drawing less than this share of the home
+// team's median attendance for the season
+const AttendanceOutlierRatio = 0.5
+
+// AttendanceRepository aggregates games.attendance against the home team's
+// venue capacity. Exhibition and neutral-site event games (store.SpecialGameTypes)
+// are left out.
+type AttendanceRepository struct {
+	db *store.Database
+}
+
+// NewAttendanceRepository creates a new attendance repository
+func NewAttendanceRepository(db *store.Database) *AttendanceRepository {
+	return &AttendanceRepository{db: db}
+}
+
+// attendanceGamesCTE selects a season's final games with reported attendance,
+// for one home team ($2) or the league ($2 = 0)
+const attendanceGamesCTE = `
+	WITH home AS (
+		SELECT g.game_id, COALESCE(g.external_id, '') AS external_id, g.game_date,
+			g.home_team_id, g.attendance, t.venue_capacity
+		FROM games g
+		JOIN teams t ON t.team_id = g.home_team_id
+		WHERE g.season_id = $1 AND g.status = 'final' AND g.attendance IS NOT NULL
+			AND ($2 = 0 OR g.home_team_id = $2)
+			AND g.game_type <> ALL($3)
+	)
+`
+
+// GetMonthly returns attendance by calendar month, oldest first
+func (r *AttendanceRepository) GetMonthly(ctx context.Context, seasonID, teamID int) ([]*store.AttendanceMonth, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := attendanceGamesCTE + `
+	SELECT to_char(date_trunc('month', game_date), 'YYYY-MM'),
+		COUNT(*),
+		SUM(attendance),
+		AVG(attendance),
+		COUNT(*) FILTER (WHERE venue_capacity > 0 AND attendance >= venue_capacity),
+		AVG(100.0 * attendance / venue_capacity) FILTER (WHERE venue_capacity > 0),
+		COUNT(*) FILTER (WHERE venue_capacity > 0)
+	FROM home
+	WHERE attendance > 0
+	GROUP BY 1
+	ORDER BY 1
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, seasonID, teamID, pq.Array(store.SpecialGameTypes))
+	if err != nil {
+		return nil, fmt.Errorf("querying monthly attendance: %w", err)
+	}
+	defer rows.Close()
+
+	var months []*store.AttendanceMonth
+	for rows.Next() {
+		m := &store.AttendanceMonth{}
+		var utilization store.NullFloat64
+		if err := rows.Scan(&m.Month, &m.Games, &m.TotalAttendance, &m.AvgAttendance,
+			&m.Sellouts, &utilization, &m.CapacityGames); err != nil {
+			return nil, fmt.Errorf("scanning monthly attendance: %w", err)
+		}
+		if utilization.Valid {
+			m.AvgUtilization = &utilization.Float64
+		}
+		months = append(months, m)
+	}
+	return months, rows.Err()
+}
+
+// GetOutliers returns games reporting zero attendance or less than
+// AttendanceOutlierRatio of their home team's season median, oldest first
+func (r *AttendanceRepository) GetOutliers(ctx context.Context, seasonID, teamID int) ([]*store.AttendanceOutlier, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := attendanceGamesCTE + `,
+	medians AS (
+		SELECT home_team_id, percentile_cont(0.5) WITHIN GROUP (ORDER BY attendance) AS median
+		FROM home
+		WHERE attendance > 0
+		GROUP BY home_team_id
+	)
+	SELECT h.game_id, h.external_id, h.game_date, h.home_team_id, h.attendance, h.venue_capacity,
+		COALESCE(m.median, 0),
+		CASE WHEN h.attendance = 0 THEN 'zero' ELSE 'far_below_median' END
+	FROM home h
+	LEFT JOIN medians m ON m.home_team_id = h.home_team_id
+	WHERE h.attendance = 0 OR h.attendance < m.median * $4
+	ORDER BY h.game_date, h.game_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, seasonID, teamID, pq.Array(store.SpecialGameTypes), AttendanceOutlierRatio)
+	if err != nil {
+		return nil, fmt.Errorf("querying attendance outliers: %w", err)
+	}
+	defer rows.Close()
+
+	var outliers []*store.AttendanceOutlier
+	for rows.Next() {
+		o := &store.AttendanceOutlier{}
+		if err := rows.Scan(&o.GameID, &o.ExternalID, &o.GameDate, &o.HomeTeamID, &o.Attendance,
+			&o.VenueCapacity, &o.TeamMedian, &o.Reason); err != nil {
+			return nil, fmt.Errorf("scanning attendance outlier: %w", err)
+		}
+		outliers = append(outliers, o)
+	}
+	return outliers, rows.Err()
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// ClutchRepository aggregates play-by-play in clutch time: the last five minutes
+// of the fourth quarter or overtime with the margin at five points or fewer
+type ClutchRepository struct {
+	db *store.Database
+}
+
+// NewClutchRepository creates a new clutch repository
+func NewClutchRepository(db *store.Database) *ClutchRepository {
+	return &ClutchRepository{db: db}
+}
+
+// clutchPlaysCTE defines "clutch" as every play in the given games that starts in
+// clutch time. The margin is read from the score before the play, so a shot that
+// stretches a lead to six still counts. $2 is a season year, or empty for every season.
+func clutchPlaysCTE(games string) string {
+	return `
+	WITH plays AS (
+		SELECT gp.game_id, gp.period, gp.clock_seconds, gp.team_id, gp.player_id,
+			gp.scoring_play, gp.shooting_play, gp.score_value,
+			COALESCE(gp.play_type, '') ILIKE 'Free Throw%' AS free_throw,
+			COALESCE(gp.description, '') ILIKE '%three point%' AS three,
+			COALESCE(gp.play_type, '') ILIKE '%Turnover%' AS turnover,
+			COALESCE(gp.play_type, '') = 'Offensive Rebound' AS offensive_rebound,
+			LAG(gp.home_score, 1, 0) OVER (PARTITION BY gp.game_id ORDER BY gp.sequence) AS home_before,
+			LAG(gp.away_score, 1, 0) OVER (PARTITION BY gp.game_id ORDER BY gp.sequence) AS away_before
+		FROM game_plays gp
+		JOIN games g ON gp.game_id = g.game_id
+		JOIN seasons s ON g.season_id = s.season_id
+		WHERE g.status = 'final' AND g.league = 'nba' AND ($2 = '' OR s.season_year = $2)
+			AND gp.game_id IN (` + games + `)
+	),
+	clutch AS (
+		SELECT * FROM plays
+		WHERE period >= 4 AND clock_seconds <= 300 AND ABS(home_before - away_before) <= 5
+	)`
+}
+
+// PlayerClutchGame is a player's shooting in one game's clutch time
+type PlayerClutchGame struct {
+	GameID         string `json:"game_id"` // external (ESPN) ID
+	GameDate       string `json:"game_date"`
+	Points         int    `json:"points"`
+	FieldGoalsMade int    `json:"field_goals_made"`
+	FieldGoalsAtt  int    `json:"field_goals_attempted"`
+	ThreesMade     int    `json:"three_pointers_made"`
+	ThreesAtt      int    `json:"three_pointers_attempted"`
+	FreeThrowsMade int    `json:"free_throws_made"`
+	FreeThrowsAtt  int    `json:"free_throws_attempted"`
+}
+
+// GetPlayerClutchGames returns one row per game the player appeared in that reached
+// clutch time, most recent first
+func (r *ClutchRepository) GetPlayerClutchGames(ctx context.Context, playerID int, seasonYear string) ([]*PlayerClutchGame, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := clutchPlaysCTE(`SELECT game_id FROM player_game_stats WHERE player_id = $1 AND deleted_at IS NULL`) + `
+	SELECT COALESCE(g.external_id, ''), g.game_date,
+		COALESCE(SUM(c.score_value) FILTER (WHERE c.player_id = $1 AND c.scoring_play), 0),
+		COUNT(*) FILTER (WHERE c.player_id = $1 AND c.shooting_play AND NOT c.free_throw AND c.scoring_play),
+		COUNT(*) FILTER (WHERE c.player_id = $1 AND c.shooting_play AND NOT c.free_throw),
+		COUNT(*) FILTER (WHERE c.player_id = $1 AND c.shooting_play AND c.three AND c.scoring_play),
+		COUNT(*) FILTER (WHERE c.player_id = $1 AND c.shooting_play AND c.three),
+		COUNT(*) FILTER (WHERE c.player_id = $1 AND c.free_throw AND c.scoring_play),
+		COUNT(*) FILTER (WHERE c.player_id = $1 AND c.free_throw AND c.shooting_play)
+	FROM clutch c
+	JOIN games g ON c.game_id = g.game_id
+	GROUP BY g.game_id, g.external_id, g.game_date
+	ORDER BY g.game_date DESC, g.game_id DESC
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, playerID, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("querying player clutch stats: %w", err)
+	}
+	defer rows.Close()
+
+	var games []*PlayerClutchGame
+	for rows.Next() {
+		cg := &PlayerClutchGame{}
+		var gameDate time.Time
+		err := rows.Scan(
+			&cg.GameID, &gameDate, &cg.Points, &cg.FieldGoalsMade, &cg.FieldGoalsAtt,
+			&cg.ThreesMade, &cg.ThreesAtt, &cg.FreeThrowsMade, &cg.FreeThrowsAtt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning player clutch game: %w", err)
+		}
+		cg.GameDate = gameDate.Format("2006-01-02")
+		games = append(games, cg)
+	}
+
+	return games, rows.Err()
+}
+
+// TeamClutchGame is both sides' production in one game's clutch time, from the
+// team's perspective
+type TeamClutchGame struct {
+	GameID        string `json:"game_id"` // external (ESPN) ID
+	GameDate      string `json:"game_date"`
+	Won           bool   `json:"won"`
+	PointsFor     int    `json:"points_for"`
+	PointsAgainst int    `json:"points_against"`
+
+	FieldGoalsAtt    int `json:"-"`
+	FreeThrowsAtt    int `json:"-"`
+	Turnovers        int `json:"-"`
+	OffRebounds      int `json:"-"`
+	OppFieldGoalsAtt int `json:"-"`
+	OppFreeThrowsAtt int `json:"-"`
+	OppTurnovers     int `json:"-"`
+	OppOffRebounds   int `json:"-"`
+}
+
+// GetTeamClutchGames returns one row per final game of the team that reached
+// clutch time, most recent first
+func (r *ClutchRepository) GetTeamClutchGames(ctx context.Context, teamID int, seasonYear string) ([]*TeamClutchGame, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := clutchPlaysCTE(`SELECT game_id FROM games WHERE home_team_id = $1 OR away_team_id = $1`) + `
+	SELECT COALESCE(g.external_id, ''), g.game_date,
+		CASE WHEN g.home_team_id = $1 THEN g.home_score > g.away_score ELSE g.away_score > g.home_score END,
+		COALESCE(SUM(c.score_value) FILTER (WHERE c.team_id = $1 AND c.scoring_play), 0),
+		COALESCE(SUM(c.score_value) FILTER (WHERE c.team_id <> $1 AND c.scoring_play), 0),
+		COUNT(*) FILTER (WHERE c.team_id = $1 AND c.shooting_play AND NOT c.free_throw),
+		COUNT(*) FILTER (WHERE c.team_id = $1 AND c.shooting_play AND c.free_throw),
+		COUNT(*) FILTER (WHERE c.team_id = $1 AND c.turnover),
+		COUNT(*) FILTER (WHERE c.team_id = $1 AND c.offensive_rebound),
+		COUNT(*) FILTER (WHERE c.team_id <> $1 AND c.shooting_play AND NOT c.free_throw),
+		COUNT(*) FILTER (WHERE c.team_id <> $1 AND c.shooting_play AND c.free_throw),
+		COUNT(*) FILTER (WHERE c.team_id <> $1 AND c.turnover),
+		COUNT(*) FILTER (WHERE c.team_id <> $1 AND c.offensive_rebound)
+	FROM clutch c
+	JOIN games g ON c.game_id = g.game_id
+	GROUP BY g.game_id, g.external_id, g.game_date, g.home_team_id, g.home_score, g.away_score
+	ORDER BY g.game_date DESC, g.game_id DESC
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, teamID, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("querying team clutch stats: %w", err)
+	}
+	defer rows.Close()
+
+	var games []*TeamClutchGame
+	for rows.Next() {
+		cg := &TeamClutchGame{}
+		var gameDate time.Time
+		err := rows.Scan(
+			&cg.GameID, &gameDate, &cg.Won, &cg.PointsFor, &cg.PointsAgainst,
+			&cg.FieldGoalsAtt, &cg.FreeThrowsAtt, &cg.Turnovers, &cg.OffRebounds,
+			&cg.OppFieldGoalsAtt, &cg.OppFreeThrowsAtt, &cg.OppTurnovers, &cg.OppOffRebounds,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning team clutch game: %w", err)
+		}
+		cg.GameDate = gameDate.Format("2006-01-02")
+		games = append(games, cg)
+	}
+
+	return games, rows.Err()
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// CoachRepository stores head coaches and their team tenures
+type CoachRepository struct {
+	db *store.Database
+}
+
+// NewCoachRepository creates a new coach repository
+func NewCoachRepository(db *store.Database) *CoachRepository {
+	return &CoachRepository{db: db}
+}
+
+// Upsert inserts or renames a coach by sport and ESPN ID and sets CoachID
+func (r *CoachRepository) Upsert(ctx context.Context, c *store.Coach) error {
+	query := `
+		INSERT INTO coaches (sport, external_id, first_name, last_name, full_name)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (sport, external_id) DO UPDATE SET
+			first_name = EXCLUDED.first_name,
+			last_name = EXCLUDED.last_name,
+			full_name = EXCLUDED.full_name
+		RETURNING coach_id
+	`
+
+	err := r.db.DB().QueryRowContext(ctx, query,
+		c.Sport, c.ExternalID, c.FirstName, c.LastName, c.FullName,
+	).Scan(&c.CoachID)
+	if err != nil {
+		return fmt.Errorf("upserting coach %s: %w", c.ExternalID, err)
+	}
+	return nil
+}
+
+// SetHeadCoach makes coachID the team's head coach as of a date, closing the
+// previous coach's tenure the day before. It reports whether a new tenure was
+// opened and returns the coach it replaced, which is nil when the team had none.
+func (r *CoachRepository) SetHeadCoach(ctx context.Context, teamID, coachID, seasonID int, asOf time.Time) (*store.Coach, bool, error) {
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("beginning coach history update: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previous *store.Coach
+	prev := &store.Coach{}
+	err = tx.QueryRowContext(ctx, `
+		SELECT c.coach_id, c.sport, c.external_id, c.first_name, c.last_name, c.full_name
+		FROM coach_team_history h
+		JOIN coaches c ON c.coach_id = h.coach_id
+		WHERE h.team_id = $1 AND h.end_date IS NULL
+		FOR UPDATE OF h
+	`, teamID).Scan(&prev.CoachID, &prev.Sport, &prev.ExternalID, &prev.FirstName, &prev.LastName, &prev.FullName)
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		return nil, false, fmt.Errorf("checking current coach: %w", err)
+	case prev.CoachID == coachID:
+		return nil, false, nil
+	default:
+		previous = prev
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE coach_team_history
+		SET end_date = GREATEST(start_date, $2::date - 1)
+		WHERE team_id = $1 AND end_date IS NULL
+	`, teamID, asOf)
+	if err != nil {
+		return nil, false, fmt.Errorf("closing previous coach tenure: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO coach_team_history (coach_id, team_id, season_id, start_date)
+		VALUES ($1, $2, NULLIF($3, 0), $4::date)
+		ON CONFLICT (coach_id, team_id, start_date) DO UPDATE SET end_date = NULL
+	`, coachID, teamID, seasonID, asOf)
+	if err != nil {
+		return nil, false, fmt.Errorf("inserting coach tenure: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("committing coach history update: %w", err)
+	}
+	return previous, true, nil
+}
+
+// GetByTeam returns a team's head coach tenures, most recent first
+func (r *CoachRepository) GetByTeam(ctx context.Context, teamID int) ([]*store.CoachTenure, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT h.history_id, h.coach_id, c.external_id, c.full_name, h.team_id,
+			h.season_id, h.start_date, h.end_date
+		FROM coach_team_history h
+		JOIN coaches c ON c.coach_id = h.coach_id
+		WHERE h.team_id = $1
+		ORDER BY h.start_date DESC, h.history_id DESC
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("querying coach history: %w", err)
+	}
+	defer rows.Close()
+
+	var tenures []*store.CoachTenure
+	for rows.Next() {
+		t := &store.CoachTenure{}
+		err := rows.Scan(
+			&t.HistoryID, &t.CoachID, &t.ExternalID, &t.FullName, &t.TeamID,
+			&t.SeasonID, &t.StartDate, &t.EndDate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning coach tenure: %w", err)
+		}
+		t.IsCurrent = !t.EndDate.Valid
+		tenures = append(tenures, t)
+	}
+
+	return tenures, rows.Err()
+}
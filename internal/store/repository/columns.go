@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// column pairs a selected column with the field its value is scanned into
+type column[T any] struct {
+	name  string
+	field func(*T) interface{}
+}
+
+// columnSet is the one definition of the columns a repository reads into T. Query
+// SELECT lists and Scan destinations are both generated from it, so the two can't
+// drift apart; field references are checked by the compiler, and column names
+// against the live schema by `minerva migrate queries` (see ColumnSetChecks).
+type columnSet[T any] struct {
+	table   string
+	columns []column[T]
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// list returns the comma-separated column list, each qualified with alias when
+// one is given ("pgs" gives "pgs.stat_id, pgs.game_id, ...")
+func (cs *columnSet[T]) list(alias string) string {
+	prefix := ""
+	if alias != "" {
+		prefix = alias + "."
+	}
+	names := make([]string, len(cs.columns))
+	for i, c := range cs.columns {
+		names[i] = prefix + c.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// dest returns Scan destinations for v, in list order, followed by extra for
+// any columns a query selects after the set
+func (cs *columnSet[T]) dest(v *T, extra ...interface{}) []interface{} {
+	dest := make([]interface{}, 0, len(cs.columns)+len(extra))
+	for _, c := range cs.columns {
+		dest = append(dest, c.field(v))
+	}
+	return append(dest, extra...)
+}
+
+// scan reads a row selected with list into a new T
+func (cs *columnSet[T]) scan(row rowScanner, extra ...interface{}) (*T, error) {
+	v := new(T)
+	if err := row.Scan(cs.dest(v, extra...)...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// check is a query selecting every column of the set, for validating the set
+// against the live schema
+func (cs *columnSet[T]) check(name string) store.PlanCheck {
+	return store.PlanCheck{
+		Name:  name,
+		Query: "SELECT " + cs.list("") + " FROM " + cs.table + " LIMIT 0",
+	}
+}
+
+// ColumnSetChecks returns one query per repository column set. EXPLAINing them
+// fails with the offending name when a column was renamed or dropped.
+func ColumnSetChecks() []store.PlanCheck {
+	return []store.PlanCheck{
+		gameColumns.check("games columns"),
+		playerColumns.check("players columns"),
+		teamColumns.check("teams columns"),
+		playerStatsColumns.check("player_game_stats columns"),
+		teamRatingColumns.check("team_ratings columns"),
+	}
+}
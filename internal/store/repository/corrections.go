@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// ErrInvalidCorrection is returned when a correction would leave a stat line inconsistent
+var ErrInvalidCorrection = errors.New("invalid stat correction")
+
+// StatCorrectionRepository applies and audits manual player stat corrections
+type StatCorrectionRepository struct {
+	db *store.Database
+}
+
+// NewStatCorrectionRepository creates a new stat correction repository
+func NewStatCorrectionRepository(db *store.Database) *StatCorrectionRepository {
+	return &StatCorrectionRepository{db: db}
+}
+
+// fieldChange is one entry of a correction's audit diff
+type fieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// Apply amends a stat line, marks it corrected so ingestion stops overwriting it, and
+// records the diff in the audit log. Shooting percentages are recomputed from the
+// corrected counts. It returns store.ErrNotFound when statID does not exist, and a nil
+// log entry when the correction changes nothing.
+func (r *StatCorrectionRepository) Apply(ctx context.Context, statID int, c *store.StatCorrection) (*store.PlayerGameStats, *store.StatCorrectionLog, error) {
+	if strings.TrimSpace(c.Reason) == "" {
+		return nil, nil, fmt.Errorf("%w: reason is required", ErrInvalidCorrection)
+	}
+	if strings.TrimSpace(c.CorrectedBy) == "" {
+		return nil, nil, fmt.Errorf("%w: no corrector to attribute it to", ErrInvalidCorrection)
+	}
+
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("beginning correction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stats := &store.PlayerGameStats{}
+	var deletedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT `+playerStatsColumns.list("")+`, deleted_at
+		FROM player_game_stats
+		WHERE stat_id = $1
+		FOR UPDATE
+	`, statID).Scan(playerStatsColumns.dest(stats, &deletedAt)...)
+	if err == sql.ErrNoRows {
+		return nil, nil, store.NotFound("stat line %d", statID)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading stat %d: %w", statID, err)
+	}
+	stats.FillMinutes()
+
+	changes := make(map[string]fieldChange)
+	setInt := func(name string, field *int, value *int) {
+		if value != nil && *value != *field {
+			changes[name] = fieldChange{Old: *field, New: *value}
+			*field = *value
+		}
+	}
+	setInt("points", &stats.Points, c.Points)
+	setInt("rebounds", &stats.Rebounds, c.Rebounds)
+	setInt("assists", &stats.Assists, c.Assists)
+	setInt("steals", &stats.Steals, c.Steals)
+	setInt("blocks", &stats.Blocks, c.Blocks)
+	setInt("turnovers", &stats.Turnovers, c.Turnovers)
+	setInt("field_goals_made", &stats.FieldGoalsMade, c.FieldGoalsMade)
+	setInt("field_goals_attempted", &stats.FieldGoalsAttempted, c.FieldGoalsAttempted)
+	setInt("three_pointers_made", &stats.ThreePointersMade, c.ThreePointersMade)
+	setInt("three_pointers_attempted", &stats.ThreePointersAttempted, c.ThreePointersAttempted)
+	setInt("free_throws_made", &stats.FreeThrowsMade, c.FreeThrowsMade)
+	setInt("free_throws_attempted", &stats.FreeThrowsAttempted, c.FreeThrowsAttempted)
+	setInt("offensive_rebounds", &stats.OffensiveRebounds, c.OffensiveRebounds)
+	setInt("defensive_rebounds", &stats.DefensiveRebounds, c.DefensiveRebounds)
+	setInt("personal_fouls", &stats.PersonalFouls, c.PersonalFouls)
+
+	seconds := c.SecondsPlayed
+	if seconds == nil && c.MinutesPlayed != nil {
+		rounded := int(math.Round(*c.MinutesPlayed * 60))
+		seconds = &rounded
+	}
+	if seconds != nil && (!stats.SecondsPlayed.Valid || int(stats.SecondsPlayed.Int32) != *seconds) {
+		oldMinutes, oldSeconds := nullValue(stats.MinutesPlayed), nullValue(stats.SecondsPlayed)
+		stats.SetSecondsPlayed(*seconds)
+		changes["minutes_played"] = fieldChange{Old: oldMinutes, New: stats.MinutesPlayed.Float64}
+		changes["seconds_played"] = fieldChange{Old: oldSeconds, New: *seconds}
+	}
+	if c.PlusMinus != nil && (!stats.PlusMinus.Valid || int(stats.PlusMinus.Int32) != *c.PlusMinus) {
+		changes["plus_minus"] = fieldChange{Old: nullValue(stats.PlusMinus), New: *c.PlusMinus}
+		stats.PlusMinus = store.NullInt32{Int32: int32(*c.PlusMinus), Valid: true}
+	}
+	if c.Starter != nil && *c.Starter != stats.Starter {
+		changes["starter"] = fieldChange{Old: stats.Starter, New: *c.Starter}
+		stats.Starter = *c.Starter
+	}
+
+	action := "correct"
+	if c.Deleted != nil && *c.Deleted != deletedAt.Valid {
+		changes["deleted"] = fieldChange{Old: deletedAt.Valid, New: *c.Deleted}
+		action = "restore"
+		if *c.Deleted {
+			action = "delete"
+		}
+	}
+
+	if len(changes) == 0 {
+		return stats, nil, nil
+	}
+	if err := checkStatLine(stats); err != nil {
+		return nil, nil, err
+	}
+	stats.UpdateShootingPcts()
+	stats.Corrected = true
+
+	deleted := deletedAt.Valid
+	if c.Deleted != nil {
+		deleted = *c.Deleted
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE player_game_stats SET
+			points = $2, rebounds = $3, assists = $4, steals = $5, blocks = $6, turnovers = $7,
+			field_goals_made = $8, field_goals_attempted = $9,
+			three_pointers_made = $10, three_pointers_attempted = $11,
+			free_throws_made = $12, free_throws_attempted = $13,
+			offensive_rebounds = $14, defensive_rebounds = $15, personal_fouls = $16,
+			minutes_played = $17, plus_minus = $18, starter = $19,
+			true_shooting_pct = $20, effective_fg_pct = $21,
+			deleted_at = CASE WHEN $22::boolean THEN COALESCE(deleted_at, NOW()) END,
+			seconds_played = $23,
+			corrected = true,
+			updated_at = NOW()
+		WHERE stat_id = $1
+		RETURNING updated_at
+	`, statID, stats.Points, stats.Rebounds, stats.Assists, stats.Steals, stats.Blocks, stats.Turnovers,
+		stats.FieldGoalsMade, stats.FieldGoalsAttempted,
+		stats.ThreePointersMade, stats.ThreePointersAttempted,
+		stats.FreeThrowsMade, stats.FreeThrowsAttempted,
+		stats.OffensiveRebounds, stats.DefensiveRebounds, stats.PersonalFouls,
+		stats.MinutesPlayed, stats.PlusMinus, stats.Starter,
+		stats.TrueShootingPct, stats.EffectiveFGPct, deleted, stats.SecondsPlayed,
+	).Scan(&stats.UpdatedAt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("updating stat %d: %w", statID, store.ConflictError(err))
+	}
+
+	diff, err := json.Marshal(changes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding correction diff: %w", err)
+	}
+
+	entry := &store.StatCorrectionLog{
+		StatID:      statID,
+		Action:      action,
+		Changes:     diff,
+		Reason:      c.Reason,
+		CorrectedBy: c.CorrectedBy,
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO stat_corrections (stat_id, action, changes, reason, corrected_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING correction_id, created_at
+	`, statID, action, string(diff), c.Reason, c.CorrectedBy).Scan(&entry.CorrectionID, &entry.CreatedAt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("recording correction for stat %d: %w", statID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("committing correction for stat %d: %w", statID, err)
+	}
+	notifyEntityWrite(store.EntityPlayerStats, stats.PlayerID)
+
+	return stats, entry, nil
+}
+
+// List returns audit entries, newest first. A zero statID lists corrections across all stat lines.
+func (r *StatCorrectionRepository) List(ctx context.Context, statID, limit int) ([]*store.StatCorrectionLog, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT correction_id, stat_id, action, changes, reason, corrected_by, created_at
+		FROM stat_corrections
+		WHERE ($1 = 0 OR stat_id = $1)
+		ORDER BY created_at DESC, correction_id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, statID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying stat corrections: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*store.StatCorrectionLog{}
+	for rows.Next() {
+		e := &store.StatCorrectionLog{}
+		var changes []byte
+		err := rows.Scan(&e.CorrectionID, &e.StatID, &e.Action, &changes, &e.Reason, &e.CorrectedBy, &e.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scanning stat correction: %w", err)
+		}
+		e.Changes = changes
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// checkStatLine rejects corrected values no real box score could contain
+func checkStatLine(s *store.PlayerGameStats) error {
+	counts := map[string]int{
+		"points": s.Points, "rebounds": s.Rebounds, "assists": s.Assists,
+		"steals": s.Steals, "blocks": s.Blocks, "turnovers": s.Turnovers,
+		"field_goals_made": s.FieldGoalsMade, "three_pointers_made": s.ThreePointersMade,
+		"free_throws_made": s.FreeThrowsMade, "offensive_rebounds": s.OffensiveRebounds,
+		"defensive_rebounds": s.DefensiveRebounds, "personal_fouls": s.PersonalFouls,
+	}
+	for name, v := range counts {
+		if v < 0 {
+			return fmt.Errorf("%w: %s cannot be negative", ErrInvalidCorrection, name)
+		}
+	}
+
+	switch {
+	case s.FieldGoalsMade > s.FieldGoalsAttempted:
+		return fmt.Errorf("%w: field_goals_made exceeds field_goals_attempted", ErrInvalidCorrection)
+	case s.ThreePointersMade > s.ThreePointersAttempted:
+		return fmt.Errorf("%w: three_pointers_made exceeds three_pointers_attempted", ErrInvalidCorrection)
+	case s.FreeThrowsMade > s.FreeThrowsAttempted:
+		return fmt.Errorf("%w: free_throws_made exceeds free_throws_attempted", ErrInvalidCorrection)
+	case s.ThreePointersMade > s.FieldGoalsMade:
+		return fmt.Errorf("%w: three_pointers_made exceeds field_goals_made", ErrInvalidCorrection)
+	case s.MinutesPlayed.Valid && s.MinutesPlayed.Float64 < 0:
+		return fmt.Errorf("%w: minutes_played cannot be negative", ErrInvalidCorrection)
+	case s.SecondsPlayed.Valid && s.SecondsPlayed.Int32 < 0:
+		return fmt.Errorf("%w: seconds_played cannot be negative", ErrInvalidCorrection)
+	}
+	return nil
+}
+
+// nullValue unwraps a sql.Null* for the audit diff, keeping null as JSON null
+func nullValue(v interface{ Value() (driver.Value, error) }) interface{} {
+	value, _ := v.Value()
+	return value
+}
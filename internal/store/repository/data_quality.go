@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// DataQualityRepository stores validation rule violations
+type DataQualityRepository struct {
+	db *store.Database
+}
+
+// NewDataQualityRepository creates a new data quality repository
+func NewDataQualityRepository(db *store.Database) *DataQualityRepository {
+	return &DataQualityRepository{db: db}
+}
+
+// ReplaceForGame swaps a game's stored issues for the latest validation result
+func (r *DataQualityRepository) ReplaceForGame(ctx context.Context, gameID int, issues []store.DataQualityIssue) error {
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning data quality transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM data_quality_issues WHERE game_id = $1`, gameID); err != nil {
+		return fmt.Errorf("clearing data quality issues: %w", err)
+	}
+
+	for _, issue := range issues {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO data_quality_issues (game_id, team_id, player_id, rule, severity, message, expected, actual)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, gameID, issue.TeamID, issue.PlayerID, issue.Rule, issue.Severity, issue.Message, issue.Expected, issue.Actual)
+		if err != nil {
+			return fmt.Errorf("inserting data quality issue: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing data quality issues: %w", err)
+	}
+	return nil
+}
+
+// List returns issues for games on the given date (all dates when zero), newest first.
+// An empty rule matches every rule.
+func (r *DataQualityRepository) List(ctx context.Context, sport string, date time.Time, rule string, limit int) ([]store.DataQualityIssue, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT q.issue_id, q.game_id, g.external_id, g.game_date, q.team_id, q.player_id,
+			q.rule, q.severity, q.message, q.expected, q.actual, q.detected_at
+		FROM data_quality_issues q
+		JOIN games g ON g.game_id = q.game_id
+		WHERE g.sport = $1
+			AND ($2::date IS NULL OR g.game_date = $2::date)
+			AND ($3 = '' OR q.rule = $3)
+		ORDER BY g.game_date DESC, q.game_id, q.severity, q.rule
+		LIMIT $4
+	`
+
+	var dateArg interface{}
+	if !date.IsZero() {
+		dateArg = date.Format("2006-01-02")
+	}
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, sport, dateArg, rule, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying data quality issues: %w", err)
+	}
+	defer rows.Close()
+
+	issues := []store.DataQualityIssue{}
+	for rows.Next() {
+		var issue store.DataQualityIssue
+		if err := rows.Scan(
+			&issue.IssueID, &issue.GameID, &issue.ExternalID, &issue.GameDate, &issue.TeamID, &issue.PlayerID,
+			&issue.Rule, &issue.Severity, &issue.Message, &issue.Expected, &issue.Actual, &issue.DetectedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning data quality issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, rows.Err()
+}
+
+// Summarize counts the issues recorded for a sport's games on one date
+func (r *DataQualityRepository) Summarize(ctx context.Context, sport string, date time.Time) (*store.DataQualitySummary, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT q.rule, q.severity, COUNT(*)
+		FROM data_quality_issues q
+		JOIN games g ON g.game_id = q.game_id
+		WHERE g.sport = $1 AND g.game_date = $2::date
+		GROUP BY q.rule, q.severity
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, sport, date.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("summarizing data quality issues: %w", err)
+	}
+	defer rows.Close()
+
+	summary := &store.DataQualitySummary{ByRule: map[string]int{}}
+	for rows.Next() {
+		var rule, severity string
+		var n int
+		if err := rows.Scan(&rule, &severity, &n); err != nil {
+			return nil, fmt.Errorf("scanning data quality summary: %w", err)
+		}
+		summary.Issues += n
+		summary.ByRule[rule] += n
+		switch severity {
+		case "error":
+			summary.Errors += n
+		case "warning":
+			summary.Warnings += n
+		}
+	}
+	return summary, rows.Err()
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/lib/pq"
+)
+
+// bioMissing matches players missing a field the enrichment job tracks
+const bioMissing = `(p.birth_date IS NULL OR p.birth_city IS NULL OR p.height_inches IS NULL
+	OR p.college IS NULL OR p.nationality IS NULL)`
+
+// EnrichmentCandidate is a player the enrichment job should look up
+type EnrichmentCandidate struct {
+	PlayerID int
+	ESPNID   string
+	FullName string
+}
+
+// EnrichmentRepository tracks player_enrichment progress
+type EnrichmentRepository struct {
+	db *store.Database
+}
+
+// NewEnrichmentRepository creates a new enrichment repository
+func NewEnrichmentRepository(db *store.Database) *EnrichmentRepository {
+	return &EnrichmentRepository{db: db}
+}
+
+// Candidates returns up to limit players with an ESPN ID that are missing a
+// tracked field and due for an attempt. Players never tried come first, newest
+// rows first, then the longest-waiting retries.
+func (r *EnrichmentRepository) Candidates(ctx context.Context, sport string, limit int) ([]*EnrichmentCandidate, error) {
+	query := `
+		SELECT p.player_id, p.external_id, p.full_name
+		FROM players p
+		LEFT JOIN player_enrichment e ON e.player_id = p.player_id
+		WHERE p.sport = $1 AND p.external_id IS NOT NULL
+			AND ` + bioMissing + `
+			AND (e.player_id IS NULL OR e.next_attempt_at <= NOW())
+		ORDER BY e.last_attempt_at NULLS FIRST, p.player_id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.DB().QueryContext(ctx, query, sport, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying enrichment candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []*EnrichmentCandidate
+	for rows.Next() {
+		c := &EnrichmentCandidate{}
+		if err := rows.Scan(&c.PlayerID, &c.ESPNID, &c.FullName); err != nil {
+			return nil, fmt.Errorf("scanning enrichment candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+// RecordAttempt stores the outcome of one lookup and when the player may be tried again
+func (r *EnrichmentRepository) RecordAttempt(ctx context.Context, playerID int, status string, filled []string, lastError string, nextAttempt time.Time) error {
+	if filled == nil {
+		filled = []string{}
+	}
+
+	_, err := r.db.DB().ExecContext(ctx, `
+		INSERT INTO player_enrichment (player_id, attempts, last_status, fields_filled, last_error, last_attempt_at, next_attempt_at)
+		VALUES ($1, 1, $2, $3, NULLIF($4, ''), NOW(), $5)
+		ON CONFLICT (player_id) DO UPDATE SET
+			attempts = player_enrichment.attempts + 1,
+			last_status = EXCLUDED.last_status,
+			fields_filled = EXCLUDED.fields_filled,
+			last_error = EXCLUDED.last_error,
+			last_attempt_at = EXCLUDED.last_attempt_at,
+			next_attempt_at = EXCLUDED.next_attempt_at
+	`, playerID, status, pq.Array(filled), lastError, nextAttempt)
+	if err != nil {
+		return fmt.Errorf("recording enrichment attempt for player %d: %w", playerID, err)
+	}
+	return nil
+}
+
+// Status summarizes biographical coverage and enrichment progress for a sport
+func (r *EnrichmentRepository) Status(ctx context.Context, sport string) (*store.EnrichmentStatus, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE ` + bioMissing + `),
+			COUNT(*) FILTER (WHERE p.birth_date IS NULL),
+			COUNT(*) FILTER (WHERE p.birth_city IS NULL),
+			COUNT(*) FILTER (WHERE p.height_inches IS NULL),
+			COUNT(*) FILTER (WHERE p.college IS NULL),
+			COUNT(*) FILTER (WHERE p.nationality IS NULL),
+			COUNT(e.player_id),
+			COUNT(*) FILTER (WHERE e.last_status = 'enriched'),
+			COUNT(*) FILTER (WHERE e.last_status = 'no_new_data'),
+			COUNT(*) FILTER (WHERE e.last_status = 'not_found'),
+			COUNT(*) FILTER (WHERE e.last_status = 'failed'),
+			COUNT(*) FILTER (WHERE ` + bioMissing + ` AND p.external_id IS NOT NULL
+				AND (e.player_id IS NULL OR e.next_attempt_at <= NOW())),
+			MAX(e.last_attempt_at)
+		FROM players p
+		LEFT JOIN player_enrichment e ON e.player_id = p.player_id
+		WHERE p.sport = $1
+	`
+
+	var birthDate, birthCity, height, college, nationality int
+	var enriched, noNewData, notFound, failed int
+	var lastAttempt sql.NullTime
+	status := &store.EnrichmentStatus{}
+	err := r.db.ReadDB(ctx).QueryRowContext(ctx, query, sport).Scan(
+		&status.Players, &status.MissingAny,
+		&birthDate, &birthCity, &height, &college, &nationality,
+		&status.Attempted, &enriched, &noNewData, &notFound, &failed,
+		&status.Pending, &lastAttempt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying enrichment status: %w", err)
+	}
+
+	status.MissingByField = map[string]int{
+		"birth_date":    birthDate,
+		"birth_city":    birthCity,
+		"height_inches": height,
+		"college":       college,
+		"nationality":   nationality,
+	}
+	status.ByStatus = map[string]int{
+		store.EnrichmentEnriched:  enriched,
+		store.EnrichmentNoNewData: noNewData,
+		store.EnrichmentNotFound:  notFound,
+		store.EnrichmentFailed:    failed,
+	}
+	if lastAttempt.Valid {
+		status.LastAttemptAt = &lastAttempt.Time
+	}
+	return status, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// ExternalIDRepository maps source-specific IDs to Minerva player, team, and game IDs
+type ExternalIDRepository struct {
+	db *store.Database
+}
+
+// NewExternalIDRepository creates a new external ID repository
+func NewExternalIDRepository(db *store.Database) *ExternalIDRepository {
+	return &ExternalIDRepository{db: db}
+}
+
+// Link records that source knows the entity internalID as sourceID. Relinking a
+// source ID to a different entity moves it; an entity may hold several IDs per source.
+func (r *ExternalIDRepository) Link(ctx context.Context, entityType, source, sourceID string, internalID int) error {
+	query := `
+		INSERT INTO external_ids (entity_type, source, source_id, internal_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (entity_type, source, source_id) DO UPDATE SET
+			internal_id = EXCLUDED.internal_id,
+			updated_at = NOW()
+		WHERE external_ids.internal_id <> EXCLUDED.internal_id
+	`
+
+	if _, err := r.db.DB().ExecContext(ctx, query, entityType, source, sourceID, internalID); err != nil {
+		return fmt.Errorf("linking %s %s id %s: %w", source, entityType, sourceID, err)
+	}
+	return nil
+}
+
+// Resolve returns the Minerva ID for a source ID; ok is false when it is not mapped
+func (r *ExternalIDRepository) Resolve(ctx context.Context, entityType, source, sourceID string) (id int, ok bool, err error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT internal_id FROM external_ids
+		WHERE entity_type = $1 AND source = $2 AND source_id = $3
+	`
+
+	err = r.db.ReadDB(ctx).QueryRowContext(ctx, query, entityType, source, sourceID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("resolving %s %s id %s: %w", source, entityType, sourceID, err)
+	}
+	return id, true, nil
+}
+
+// ListForEntity returns every source ID recorded for one player, team, or game
+func (r *ExternalIDRepository) ListForEntity(ctx context.Context, entityType string, internalID int) ([]store.ExternalIDMapping, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT entity_type, source, source_id, internal_id, created_at, updated_at
+		FROM external_ids
+		WHERE entity_type = $1 AND internal_id = $2
+		ORDER BY source, source_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, entityType, internalID)
+	if err != nil {
+		return nil, fmt.Errorf("querying external ids: %w", err)
+	}
+	defer rows.Close()
+
+	mappings := []store.ExternalIDMapping{}
+	for rows.Next() {
+		var m store.ExternalIDMapping
+		if err := rows.Scan(&m.EntityType, &m.Source, &m.SourceID, &m.InternalID, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning external id: %w", err)
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings, rows.Err()
+}
+
+// ListBySource returns a source's IDs for one entity type, keyed by source ID
+func (r *ExternalIDRepository) ListBySource(ctx context.Context, entityType, source string) (map[string]int, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT source_id, internal_id FROM external_ids
+		WHERE entity_type = $1 AND source = $2
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, entityType, source)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s %s ids: %w", source, entityType, err)
+	}
+	defer rows.Close()
+
+	ids := make(map[string]int)
+	for rows.Next() {
+		var sourceID string
+		var internalID int
+		if err := rows.Scan(&sourceID, &internalID); err != nil {
+			return nil, fmt.Errorf("scanning external id: %w", err)
+		}
+		ids[sourceID] = internalID
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// GameUpdateRepository reads and appends to the game update log
+type GameUpdateRepository struct {
+	db *store.Database
+}
+
+// NewGameUpdateRepository creates a new game update repository
+func NewGameUpdateRepository(db *store.Database) *GameUpdateRepository {
+	return &GameUpdateRepository{db: db}
+}
+
+// Record appends one update to the log
+func (r *GameUpdateRepository) Record(ctx context.Context, update *store.GameUpdate) error {
+	var err error
+	fields, stats := []byte("{}"), []byte("[]")
+	if len(update.Fields) > 0 {
+		if fields, err = json.Marshal(update.Fields); err != nil {
+			return fmt.Errorf("encoding game update fields: %w", err)
+		}
+	}
+	if len(update.Stats) > 0 {
+		if stats, err = json.Marshal(update.Stats); err != nil {
+			return fmt.Errorf("encoding game update stats: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO game_update_log (game_id, fields, stats)
+		VALUES ($1, $2::jsonb, $3::jsonb)
+		RETURNING update_id, changed_at
+	`
+
+	err = r.db.DB().QueryRowContext(ctx, query, update.GameID, string(fields), string(stats)).Scan(&update.UpdateID, &update.ChangedAt)
+	if err != nil {
+		return fmt.Errorf("recording game update: %w", err)
+	}
+	return nil
+}
+
+// ListSince returns a game's updates recorded after since, oldest first
+func (r *GameUpdateRepository) ListSince(ctx context.Context, gameID int, since time.Time) ([]*store.GameUpdate, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT update_id, game_id, fields, stats, changed_at
+		FROM game_update_log
+		WHERE game_id = $1 AND changed_at > $2
+		ORDER BY changed_at, update_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, gameID, since)
+	if err != nil {
+		return nil, fmt.Errorf("querying game updates: %w", err)
+	}
+	defer rows.Close()
+
+	var updates []*store.GameUpdate
+	for rows.Next() {
+		update := &store.GameUpdate{}
+		var fields, stats []byte
+		if err := rows.Scan(&update.UpdateID, &update.GameID, &fields, &stats, &update.ChangedAt); err != nil {
+			return nil, fmt.Errorf("scanning game update: %w", err)
+		}
+		if err := json.Unmarshal(fields, &update.Fields); err != nil {
+			return nil, fmt.Errorf("decoding game update %d fields: %w", update.UpdateID, err)
+		}
+		if err := json.Unmarshal(stats, &update.Stats); err != nil {
+			return nil, fmt.Errorf("decoding game update %d stats: %w", update.UpdateID, err)
+		}
+		updates = append(updates, update)
+	}
+
+	return updates, rows.Err()
+}
@@ -3,10 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/fortuna/minerva/internal/store"
+	"github.com/lib/pq"
 )
 
 // GameRepository handles game data access
@@ -19,27 +21,55 @@ func NewGameRepository(db *store.Database) *GameRepository {
 	return &GameRepository{db: db}
 }
 
+// gameColumns is the standard games column list
+var gameColumns = &columnSet[store.Game]{
+	table: "games",
+	columns: []column[store.Game]{
+		{"game_id", func(g *store.Game) interface{} { return &g.GameID }},
+		{"sport", func(g *store.Game) interface{} { return &g.Sport }},
+		{"season_id", func(g *store.Game) interface{} { return &g.SeasonID }},
+		{"external_id", func(g *store.Game) interface{} { return &g.ExternalID }},
+		{"game_date", func(g *store.Game) interface{} { return &g.GameDate }},
+		{"game_time", func(g *store.Game) interface{} { return &g.GameTime }},
+		{"home_team_id", func(g *store.Game) interface{} { return &g.HomeTeamID }},
+		{"away_team_id", func(g *store.Game) interface{} { return &g.AwayTeamID }},
+		{"home_score", func(g *store.Game) interface{} { return &g.HomeScore }},
+		{"away_score", func(g *store.Game) interface{} { return &g.AwayScore }},
+		{"status", func(g *store.Game) interface{} { return &g.Status }},
+		{"period", func(g *store.Game) interface{} { return &g.Period }},
+		{"clock", func(g *store.Game) interface{} { return &g.Clock }},
+		{"venue", func(g *store.Game) interface{} { return &g.Venue }},
+		{"attendance", func(g *store.Game) interface{} { return &g.Attendance }},
+		{"broadcasts", func(g *store.Game) interface{} { return pq.Array(&g.Broadcasts) }},
+		{"metadata", func(g *store.Game) interface{} { return &g.Metadata }},
+		{"game_type", func(g *store.Game) interface{} { return &g.GameType }},
+		{"league", func(g *store.Game) interface{} { return &g.League }},
+		{"finalized_at", func(g *store.Game) interface{} { return &g.FinalizedAt }},
+		{"stats_complete", func(g *store.Game) interface{} { return &g.StatsComplete }},
+		{"home_largest_lead", func(g *store.Game) interface{} { return &g.HomeLargestLead }},
+		{"away_largest_lead", func(g *store.Game) interface{} { return &g.AwayLargestLead }},
+		{"lead_changes", func(g *store.Game) interface{} { return &g.LeadChanges }},
+		{"times_tied", func(g *store.Game) interface{} { return &g.TimesTied }},
+		{"created_at", func(g *store.Game) interface{} { return &g.CreatedAt }},
+		{"updated_at", func(g *store.Game) interface{} { return &g.UpdatedAt }},
+	},
+}
+
 // GetByID finds a game by ID
 // GetByID finds a game by its database ID (integer)
 func (r *GameRepository) GetByID(ctx context.Context, gameID int) (*store.Game, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT game_id, sport, season_id, external_id, game_date, game_time,
-			home_team_id, away_team_id, home_score, away_score, status,
-			period, clock, venue, attendance, metadata, created_at, updated_at
+		SELECT ` + gameColumns.list("") + `
 		FROM games
 		WHERE game_id = $1
 	`
 
-	game := &store.Game{}
-	err := r.db.DB().QueryRowContext(ctx, query, gameID).Scan(
-		&game.GameID, &game.Sport, &game.SeasonID, &game.ExternalID, &game.GameDate, &game.GameTime,
-		&game.HomeTeamID, &game.AwayTeamID, &game.HomeScore, &game.AwayScore, &game.Status,
-		&game.Period, &game.Clock, &game.Venue, &game.Attendance, &game.Metadata,
-		&game.CreatedAt, &game.UpdatedAt,
-	)
-
+	game, err := gameColumns.scan(r.db.ReadDB(ctx).QueryRowContext(ctx, query, gameID))
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("game not found: %d", gameID)
+		return nil, store.NotFound("game %d", gameID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("querying game: %w", err)
@@ -50,24 +80,18 @@ func (r *GameRepository) GetByID(ctx context.Context, gameID int) (*store.Game,
 
 // GetByExternalID finds a game by its external ID (ESPN ID)
 func (r *GameRepository) GetByExternalID(ctx context.Context, externalID string) (*store.Game, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT game_id, sport, season_id, external_id, game_date, game_time,
-			home_team_id, away_team_id, home_score, away_score, status,
-			period, clock, venue, attendance, metadata, created_at, updated_at
+		SELECT ` + gameColumns.list("") + `
 		FROM games
 		WHERE external_id = $1
 	`
 
-	game := &store.Game{}
-	err := r.db.DB().QueryRowContext(ctx, query, externalID).Scan(
-		&game.GameID, &game.Sport, &game.SeasonID, &game.ExternalID, &game.GameDate, &game.GameTime,
-		&game.HomeTeamID, &game.AwayTeamID, &game.HomeScore, &game.AwayScore, &game.Status,
-		&game.Period, &game.Clock, &game.Venue, &game.Attendance, &game.Metadata,
-		&game.CreatedAt, &game.UpdatedAt,
-	)
-
+	game, err := gameColumns.scan(r.db.ReadDB(ctx).QueryRowContext(ctx, query, externalID))
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("game not found: %s", externalID)
+		return nil, store.NotFound("game %s", externalID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("querying game: %w", err)
@@ -76,22 +100,25 @@ func (r *GameRepository) GetByExternalID(ctx context.Context, externalID string)
 	return game, nil
 }
 
-// GetByDate returns all games on a specific date
-func (r *GameRepository) GetByDate(ctx context.Context, date time.Time) ([]*store.Game, error) {
+// GetByDate returns a sport's games on a specific date in leagues, optionally limited to gameTypes
+func (r *GameRepository) GetByDate(ctx context.Context, sport string, date time.Time, leagues, gameTypes []string) ([]*store.Game, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	// Truncate to start of day and get the next day
 	startOfDay := date.Truncate(24 * time.Hour)
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	query := `
-		SELECT game_id, sport, season_id, external_id, game_date, game_time,
-			home_team_id, away_team_id, home_score, away_score, status,
-			period, clock, venue, attendance, metadata, created_at, updated_at
+		SELECT ` + gameColumns.list("") + `
 		FROM games
-		WHERE game_date >= $1 AND game_date < $2
+		WHERE sport = $4 AND game_date >= $1 AND game_date < $2
+			AND (cardinality($3::text[]) = 0 OR game_type = ANY($3))
+			AND league = ANY($5)
 		ORDER BY game_time
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, startOfDay, endOfDay)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, startOfDay, endOfDay, gameTypeArray(gameTypes), sport, leagueArray(leagues))
 	if err != nil {
 		return nil, fmt.Errorf("querying games: %w", err)
 	}
@@ -100,9 +127,12 @@ func (r *GameRepository) GetByDate(ctx context.Context, date time.Time) ([]*stor
 	return r.scanGames(rows)
 }
 
-// GetLiveGames returns all currently live games
+// GetLiveGames returns a sport's currently live games
 // Only returns games from today (EST) to avoid stale data
-func (r *GameRepository) GetLiveGames(ctx context.Context) ([]*store.Game, error) {
+func (r *GameRepository) GetLiveGames(ctx context.Context, sport string, leagues, gameTypes []string) ([]*store.Game, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	// Get today's date range in EST
 	loc, err := time.LoadLocation("America/New_York")
 	if err != nil {
@@ -113,16 +143,16 @@ func (r *GameRepository) GetLiveGames(ctx context.Context) ([]*store.Game, error
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	query := `
-		SELECT game_id, sport, season_id, external_id, game_date, game_time,
-			home_team_id, away_team_id, home_score, away_score, status,
-			period, clock, venue, attendance, metadata, created_at, updated_at
+		SELECT ` + gameColumns.list("") + `
 		FROM games
-		WHERE status = 'in_progress' 
+		WHERE sport = $4 AND status = 'in_progress' 
 			AND game_date >= $1 AND game_date < $2
+			AND (cardinality($3::text[]) = 0 OR game_type = ANY($3))
+			AND league = ANY($5)
 		ORDER BY updated_at DESC
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, startOfDay, endOfDay)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, startOfDay, endOfDay, gameTypeArray(gameTypes), sport, leagueArray(leagues))
 	if err != nil {
 		return nil, fmt.Errorf("querying live games: %w", err)
 	}
@@ -131,9 +161,12 @@ func (r *GameRepository) GetLiveGames(ctx context.Context) ([]*store.Game, error
 	return r.scanGames(rows)
 }
 
-// GetTodaysGames returns all games scheduled for today (any status)
+// GetTodaysGames returns a sport's games scheduled for today (any status)
 // Uses Eastern Time since NBA games are scheduled in EST
-func (r *GameRepository) GetTodaysGames(ctx context.Context) ([]*store.Game, error) {
+func (r *GameRepository) GetTodaysGames(ctx context.Context, sport string, leagues, gameTypes []string) ([]*store.Game, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	loc, err := time.LoadLocation("America/New_York")
 	if err != nil {
 		loc = time.UTC
@@ -143,11 +176,11 @@ func (r *GameRepository) GetTodaysGames(ctx context.Context) ([]*store.Game, err
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	query := `
-		SELECT game_id, sport, season_id, external_id, game_date, game_time,
-			home_team_id, away_team_id, home_score, away_score, status,
-			period, clock, venue, attendance, metadata, created_at, updated_at
+		SELECT ` + gameColumns.list("") + `
 		FROM games
-		WHERE game_date >= $1 AND game_date < $2
+		WHERE sport = $4 AND game_date >= $1 AND game_date < $2
+			AND (cardinality($3::text[]) = 0 OR game_type = ANY($3))
+			AND league = ANY($5)
 		ORDER BY 
 			CASE status 
 				WHEN 'in_progress' THEN 1 
@@ -158,7 +191,7 @@ func (r *GameRepository) GetTodaysGames(ctx context.Context) ([]*store.Game, err
 			game_time
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, startOfDay, endOfDay)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, startOfDay, endOfDay, gameTypeArray(gameTypes), sport, leagueArray(leagues))
 	if err != nil {
 		return nil, fmt.Errorf("querying today's games: %w", err)
 	}
@@ -167,9 +200,12 @@ func (r *GameRepository) GetTodaysGames(ctx context.Context) ([]*store.Game, err
 	return r.scanGames(rows)
 }
 
-// GetUpcomingGames returns upcoming scheduled games
+// GetUpcomingGames returns a sport's upcoming scheduled games
 // Uses Eastern Time (America/New_York) since NBA games are scheduled in EST
-func (r *GameRepository) GetUpcomingGames(ctx context.Context, limit int) ([]*store.Game, error) {
+func (r *GameRepository) GetUpcomingGames(ctx context.Context, sport string, limit int, leagues, gameTypes []string) ([]*store.Game, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	// Get current date in EST for proper comparison
 	loc, err := time.LoadLocation("America/New_York")
 	if err != nil {
@@ -179,16 +215,16 @@ func (r *GameRepository) GetUpcomingGames(ctx context.Context, limit int) ([]*st
 	todayEST := nowEST.Truncate(24 * time.Hour)
 
 	query := `
-		SELECT game_id, sport, season_id, external_id, game_date, game_time,
-			home_team_id, away_team_id, home_score, away_score, status,
-			period, clock, venue, attendance, metadata, created_at, updated_at
+		SELECT ` + gameColumns.list("") + `
 		FROM games
-		WHERE status = 'scheduled' AND game_date >= $1
+		WHERE sport = $4 AND status = 'scheduled' AND game_date >= $1
+			AND (cardinality($3::text[]) = 0 OR game_type = ANY($3))
+			AND league = ANY($5)
 		ORDER BY game_date, game_time
 		LIMIT $2
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, todayEST, limit)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, todayEST, limit, gameTypeArray(gameTypes), sport, leagueArray(leagues))
 	if err != nil {
 		return nil, fmt.Errorf("querying upcoming games: %w", err)
 	}
@@ -197,20 +233,22 @@ func (r *GameRepository) GetUpcomingGames(ctx context.Context, limit int) ([]*st
 	return r.scanGames(rows)
 }
 
-// GetByTeam returns games for a specific team
-func (r *GameRepository) GetByTeam(ctx context.Context, teamID int, seasonID int, limit int) ([]*store.Game, error) {
+// GetByTeam returns games for a specific team, optionally limited to gameTypes
+func (r *GameRepository) GetByTeam(ctx context.Context, teamID int, seasonID int, limit int, gameTypes []string) ([]*store.Game, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT game_id, sport, season_id, external_id, game_date, game_time,
-			home_team_id, away_team_id, home_score, away_score, status,
-			period, clock, venue, attendance, metadata, created_at, updated_at
+		SELECT ` + gameColumns.list("") + `
 		FROM games
 		WHERE (home_team_id = $1 OR away_team_id = $1)
 			AND season_id = $2
+			AND (cardinality($4::text[]) = 0 OR game_type = ANY($4))
 		ORDER BY game_date DESC
 		LIMIT $3
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, teamID, seasonID, limit)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, teamID, seasonID, limit, gameTypeArray(gameTypes))
 	if err != nil {
 		return nil, fmt.Errorf("querying team games: %w", err)
 	}
@@ -221,31 +259,107 @@ func (r *GameRepository) GetByTeam(ctx context.Context, teamID int, seasonID int
 
 // GetBySeason returns all games in a season
 func (r *GameRepository) GetBySeason(ctx context.Context, seasonID int) ([]*store.Game, error) {
+	var games []*store.Game
+	err := r.StreamBySeason(ctx, seasonID, func(game *store.Game) error {
+		games = append(games, game)
+		return nil
+	})
+	return games, err
+}
+
+// StreamBySeason calls fn for each game in a season, in date order, without buffering
+// the result set. Rows are only read as fast as fn returns, so a slow consumer throttles
+// the query instead of growing memory. Iteration stops at the first error from fn or
+// when ctx is done.
+func (r *GameRepository) StreamBySeason(ctx context.Context, seasonID int, fn func(*store.Game) error) error {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT game_id, sport, season_id, external_id, game_date, game_time,
-			home_team_id, away_team_id, home_score, away_score, status,
-			period, clock, venue, attendance, metadata, created_at, updated_at
+		SELECT ` + gameColumns.list("") + `
 		FROM games
 		WHERE season_id = $1
 		ORDER BY game_date, game_time
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, seasonID)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, seasonID)
 	if err != nil {
-		return nil, fmt.Errorf("querying season games: %w", err)
+		return fmt.Errorf("querying season games: %w", err)
 	}
 	defer rows.Close()
 
-	return r.scanGames(rows)
+	for rows.Next() {
+		game, err := scanGameRow(rows)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(game); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
 }
 
-// Upsert inserts or updates a game
-func (r *GameRepository) Upsert(ctx context.Context, game *store.Game) error {
+// ExistingExternalIDs returns the subset of externalIDs already stored for the sport
+func (r *GameRepository) ExistingExternalIDs(ctx context.Context, sport string, externalIDs []string) (map[string]bool, error) {
+	existing := make(map[string]bool, len(externalIDs))
+	if len(externalIDs) == 0 {
+		return existing, nil
+	}
+
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `SELECT external_id FROM games WHERE sport = $1 AND external_id = ANY($2)`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, sport, pq.Array(externalIDs))
+	if err != nil {
+		return nil, fmt.Errorf("querying existing games: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning existing game: %w", err)
+		}
+		existing[id] = true
+	}
+
+	return existing, rows.Err()
+}
+
+// Upsert inserts or updates a game.
+// The update is skipped when the stored content hash matches, so re-ingesting
+// an unchanged game does not touch the row or bump updated_at. scheduleChanged
+// reports an update that moved the date, tip-off, teams, or status; score and
+// clock updates alone leave it false. A game listing no broadcasts keeps the
+// ones already stored. exec is the caller's transaction, or nil for the
+// primary pool.
+func (r *GameRepository) Upsert(ctx context.Context, exec Querier, game *store.Game) (result store.UpsertResult, scheduleChanged bool, err error) {
+	if exec == nil {
+		exec = r.db.DB()
+	}
+	if game.GameType == "" {
+		game.GameType = store.GameTypeRegular
+	}
+	if game.League == "" {
+		game.League = store.LeagueNBA
+	}
+
 	query := `
+		WITH prev AS (
+			SELECT game_date, game_time, home_team_id, away_team_id, status
+			FROM games WHERE sport = $1 AND external_id = $3
+		)
 		INSERT INTO games (sport, season_id, external_id, game_date, game_time,
 			home_team_id, away_team_id, home_score, away_score, status,
-			period, clock, venue, attendance, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			period, clock, venue, attendance, metadata, game_type, content_hash, finalized_at, league, broadcasts)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, COALESCE($20::text[], '{}'))
 		ON CONFLICT (sport, external_id) DO UPDATE SET
 			game_date = EXCLUDED.game_date,
 			game_time = EXCLUDED.game_time,
@@ -258,24 +372,98 @@ func (r *GameRepository) Upsert(ctx context.Context, game *store.Game) error {
 			clock = EXCLUDED.clock,
 			venue = EXCLUDED.venue,
 			attendance = EXCLUDED.attendance,
+			broadcasts = CASE WHEN cardinality(EXCLUDED.broadcasts) > 0 THEN EXCLUDED.broadcasts ELSE games.broadcasts END,
 			metadata = EXCLUDED.metadata,
+			game_type = EXCLUDED.game_type,
+			league = EXCLUDED.league,
+			content_hash = EXCLUDED.content_hash,
+			finalized_at = CASE WHEN EXCLUDED.status = 'final' THEN COALESCE(games.finalized_at, EXCLUDED.finalized_at) END,
+			stats_complete = games.stats_complete AND EXCLUDED.status = 'final',
 			updated_at = NOW()
-		RETURNING game_id
+		WHERE games.content_hash IS DISTINCT FROM EXCLUDED.content_hash
+		RETURNING game_id, (xmax = 0) AS inserted,
+			NOT EXISTS (
+				SELECT 1 FROM prev p
+				WHERE p.game_date = games.game_date
+					AND p.game_time IS NOT DISTINCT FROM games.game_time
+					AND p.home_team_id = games.home_team_id
+					AND p.away_team_id = games.away_team_id
+					AND p.status = games.status
+			) AS schedule_changed
 	`
 
-	err := r.db.DB().QueryRowContext(ctx, query,
+	var inserted bool
+	err = exec.QueryRowContext(ctx, query,
 		game.Sport, game.SeasonID, game.ExternalID, game.GameDate, game.GameTime,
 		game.HomeTeamID, game.AwayTeamID, game.HomeScore, game.AwayScore, game.Status,
-		game.Period, game.Clock, game.Venue, game.Attendance, game.Metadata,
-	).Scan(&game.GameID)
+		game.Period, game.Clock, game.Venue, game.Attendance, game.Metadata, game.GameType, game.ContentHash(),
+		store.NullTime{Time: time.Now(), Valid: game.Status == "final"}, game.League, pq.Array(game.Broadcasts),
+	).Scan(&game.GameID, &inserted, &scheduleChanged)
 
+	if err == sql.ErrNoRows {
+		// Conflict with an identical hash: nothing was written, fetch the existing ID
+		err = exec.QueryRowContext(ctx,
+			`SELECT game_id FROM games WHERE sport = $1 AND external_id = $2`,
+			game.Sport, game.ExternalID,
+		).Scan(&game.GameID)
+		if err != nil {
+			return store.UpsertUnchanged, false, fmt.Errorf("looking up unchanged game: %w", err)
+		}
+		return store.UpsertUnchanged, false, nil
+	}
 	if err != nil {
-		return fmt.Errorf("upserting game: %w", err)
+		return store.UpsertUnchanged, false, fmt.Errorf("upserting game: %w", err)
 	}
 
+	if inserted {
+		return store.UpsertInserted, false, nil
+	}
+	return store.UpsertUpdated, scheduleChanged, nil
+}
+
+// SetQuarterScores stores per-period points in games.game_data
+func (r *GameRepository) SetQuarterScores(ctx context.Context, gameID int, scores *store.QuarterScores) error {
+	payload, err := json.Marshal(scores)
+	if err != nil {
+		return fmt.Errorf("encoding quarter scores: %w", err)
+	}
+
+	query := `
+		UPDATE games
+		SET game_data = jsonb_set(COALESCE(game_data, '{}'::jsonb), '{quarter_scores}', $2::jsonb)
+		WHERE game_id = $1
+			AND game_data->'quarter_scores' IS DISTINCT FROM $2::jsonb
+	`
+
+	if _, err := r.db.DB().ExecContext(ctx, query, gameID, string(payload)); err != nil {
+		return fmt.Errorf("updating quarter scores: %w", err)
+	}
 	return nil
 }
 
+// GetQuarterScores returns the stored per-period points, or nil when none were recorded
+func (r *GameRepository) GetQuarterScores(ctx context.Context, gameID int) (*store.QuarterScores, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	var raw sql.NullString
+	err := r.db.ReadDB(ctx).QueryRowContext(ctx,
+		`SELECT game_data->'quarter_scores' FROM games WHERE game_id = $1`, gameID,
+	).Scan(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("querying quarter scores: %w", err)
+	}
+	if !raw.Valid {
+		return nil, nil
+	}
+
+	scores := &store.QuarterScores{}
+	if err := json.Unmarshal([]byte(raw.String), scores); err != nil {
+		return nil, fmt.Errorf("decoding quarter scores: %w", err)
+	}
+	return scores, nil
+}
+
 // CleanupStaleGames marks games older than 6 hours with "in_progress" status as "final"
 // This fixes stuck games that never had their status updated
 func (r *GameRepository) CleanupStaleGames(ctx context.Context) (int64, error) {
@@ -285,7 +473,7 @@ func (r *GameRepository) CleanupStaleGames(ctx context.Context) (int64, error) {
 
 	query := `
 		UPDATE games 
-		SET status = 'final', updated_at = NOW()
+		SET status = 'final', finalized_at = NOW(), updated_at = NOW()
 		WHERE status = 'in_progress' 
 			AND game_time < $1
 	`
@@ -298,22 +486,181 @@ func (r *GameRepository) CleanupStaleGames(ctx context.Context) (int64, error) {
 	return result.RowsAffected()
 }
 
+// ListStatsUnsettled returns a sport's final games whose box score has not been
+// verified and that went final at least minAge ago, oldest first
+func (r *GameRepository) ListStatsUnsettled(ctx context.Context, sport string, minAge time.Duration) ([]*store.Game, error) {
+	query := `
+		SELECT ` + gameColumns.list("") + `
+		FROM games
+		WHERE sport = $1 AND status = 'final' AND NOT stats_complete AND finalized_at <= $2
+		ORDER BY finalized_at
+	`
+
+	rows, err := r.db.DB().QueryContext(ctx, query, sport, time.Now().Add(-minAge))
+	if err != nil {
+		return nil, fmt.Errorf("querying unsettled games: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanGames(rows)
+}
+
+// ListStartingSoon returns a sport's scheduled games with a tip-off time
+// within the next window, soonest first. Games past tip-off that still show
+// scheduled are included until they go live.
+func (r *GameRepository) ListStartingSoon(ctx context.Context, sport string, window time.Duration) ([]*store.Game, error) {
+	query := `
+		SELECT ` + gameColumns.list("") + `
+		FROM games
+		WHERE sport = $1 AND status = 'scheduled' AND game_time IS NOT NULL
+			AND game_time <= $2 AND game_time > $3
+		ORDER BY game_time
+	`
+
+	now := time.Now()
+	rows, err := r.db.DB().QueryContext(ctx, query, sport, now.Add(window), now.Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("querying games starting soon: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanGames(rows)
+}
+
+// TeamRecord is a team's win-loss record through a date and as it stood the
+// day before
+type TeamRecord struct {
+	TeamID       int
+	Wins         int
+	Losses       int
+	WinsBefore   int
+	LossesBefore int
+}
+
+// GetRecordsThrough returns every team's record in a season's final standings
+// games (regular season and NBA Cup games, which count toward standings) dated
+// on or before date, along with its record before date
+func (r *GameRepository) GetRecordsThrough(ctx context.Context, seasonID int, date time.Time) ([]*TeamRecord, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		WITH results AS (
+			SELECT home_team_id AS team_id, home_score > away_score AS won, game_date
+			FROM games
+			WHERE season_id = $1 AND status = 'final' AND game_date <= $2::date AND game_type = ANY($3)
+			UNION ALL
+			SELECT away_team_id, away_score > home_score, game_date
+			FROM games
+			WHERE season_id = $1 AND status = 'final' AND game_date <= $2::date AND game_type = ANY($3)
+		)
+		SELECT team_id,
+			COUNT(*) FILTER (WHERE won),
+			COUNT(*) FILTER (WHERE NOT won),
+			COUNT(*) FILTER (WHERE won AND game_date < $2::date),
+			COUNT(*) FILTER (WHERE NOT won AND game_date < $2::date)
+		FROM results
+		GROUP BY team_id
+		ORDER BY team_id
+	`
+
+	standingsTypes := pq.Array([]string{store.GameTypeRegular, store.GameTypeTournament})
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, seasonID, date.Format("2006-01-02"), standingsTypes)
+	if err != nil {
+		return nil, fmt.Errorf("querying team records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*TeamRecord
+	for rows.Next() {
+		rec := &TeamRecord{}
+		if err := rows.Scan(&rec.TeamID, &rec.Wins, &rec.Losses, &rec.WinsBefore, &rec.LossesBefore); err != nil {
+			return nil, fmt.Errorf("scanning team record: %w", err)
+		}
+		records = append(records, rec)
+	}
+
+	return records, rows.Err()
+}
+
+// MarkStatsComplete flags a final game's box score as settled
+func (r *GameRepository) MarkStatsComplete(ctx context.Context, gameID int) error {
+	_, err := r.db.DB().ExecContext(ctx, `UPDATE games SET stats_complete = TRUE WHERE game_id = $1 AND status = 'final'`, gameID)
+	if err != nil {
+		return fmt.Errorf("marking game %d stats complete: %w", gameID, err)
+	}
+	return nil
+}
+
+// SetLeadTracking stores a game's largest leads, lead changes, and ties.
+// Largest leads ESPN reported in the team statistics win over the ones in
+// leads; a nil leads keeps the stored lead changes and ties.
+func (r *GameRepository) SetLeadTracking(ctx context.Context, gameID int, leads *store.LeadTracking) error {
+	var homeLead, awayLead, changes, tied interface{}
+	if leads != nil {
+		homeLead, awayLead, changes, tied = leads.HomeLargestLead, leads.AwayLargestLead, leads.LeadChanges, leads.TimesTied
+	}
+
+	query := `
+		WITH tracked AS (
+			SELECT
+				COALESCE((SELECT largest_lead FROM team_game_stats WHERE game_id = $1 AND is_home), $2::smallint) AS home_largest_lead,
+				COALESCE((SELECT largest_lead FROM team_game_stats WHERE game_id = $1 AND NOT is_home), $3::smallint) AS away_largest_lead,
+				$4::smallint AS lead_changes,
+				$5::smallint AS times_tied
+		)
+		UPDATE games g
+		SET home_largest_lead = t.home_largest_lead,
+			away_largest_lead = t.away_largest_lead,
+			lead_changes = COALESCE(t.lead_changes, g.lead_changes),
+			times_tied = COALESCE(t.times_tied, g.times_tied)
+		FROM tracked t
+		WHERE g.game_id = $1
+			AND (g.home_largest_lead, g.away_largest_lead, g.lead_changes, g.times_tied)
+				IS DISTINCT FROM (t.home_largest_lead, t.away_largest_lead, COALESCE(t.lead_changes, g.lead_changes), COALESCE(t.times_tied, g.times_tied))
+	`
+
+	if _, err := r.db.DB().ExecContext(ctx, query, gameID, homeLead, awayLead, changes, tied); err != nil {
+		return fmt.Errorf("updating lead tracking for game %d: %w", gameID, err)
+	}
+	return nil
+}
+
+// leagueArray binds a league filter; empty means NBA games only
+func leagueArray(leagues []string) interface{} {
+	if len(leagues) == 0 {
+		leagues = []string{store.LeagueNBA}
+	}
+	return pq.Array(leagues)
+}
+
+// gameTypeArray binds an optional game type filter; empty matches every type
+func gameTypeArray(gameTypes []string) interface{} {
+	if gameTypes == nil {
+		gameTypes = []string{}
+	}
+	return pq.Array(gameTypes)
+}
+
 // scanGames scans multiple game rows
 func (r *GameRepository) scanGames(rows *sql.Rows) ([]*store.Game, error) {
 	var games []*store.Game
 	for rows.Next() {
-		game := &store.Game{}
-		err := rows.Scan(
-			&game.GameID, &game.Sport, &game.SeasonID, &game.ExternalID, &game.GameDate, &game.GameTime,
-			&game.HomeTeamID, &game.AwayTeamID, &game.HomeScore, &game.AwayScore, &game.Status,
-			&game.Period, &game.Clock, &game.Venue, &game.Attendance, &game.Metadata,
-			&game.CreatedAt, &game.UpdatedAt,
-		)
+		game, err := scanGameRow(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scanning game: %w", err)
+			return nil, err
 		}
 		games = append(games, game)
 	}
 
 	return games, rows.Err()
 }
+
+// scanGameRow scans the current row of a query selecting gameColumns
+func scanGameRow(rows *sql.Rows) (*store.Game, error) {
+	game, err := gameColumns.scan(rows)
+	if err != nil {
+		return nil, fmt.Errorf("scanning game: %w", err)
+	}
+	return game, nil
+}
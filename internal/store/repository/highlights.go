@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// HighlightRepository handles game video highlight data access
+type HighlightRepository struct {
+	db *store.Database
+}
+
+// NewHighlightRepository creates a new highlight repository
+func NewHighlightRepository(db *store.Database) *HighlightRepository {
+	return &HighlightRepository{db: db}
+}
+
+// UpsertForGame writes a game's highlights in one transaction, keyed by ESPN
+// video ID. Unchanged rows are left alone; it returns the number of rows written.
+func (r *HighlightRepository) UpsertForGame(ctx context.Context, gameID int, highlights []*store.GameHighlight) (int, error) {
+	if len(highlights) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning highlight upsert: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO game_highlights (game_id, external_id, headline, description, duration_seconds,
+			thumbnail_url, web_url, video_url, play_external_id, published_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (game_id, external_id) DO UPDATE SET
+			headline = EXCLUDED.headline,
+			description = EXCLUDED.description,
+			duration_seconds = EXCLUDED.duration_seconds,
+			thumbnail_url = EXCLUDED.thumbnail_url,
+			web_url = EXCLUDED.web_url,
+			video_url = EXCLUDED.video_url,
+			play_external_id = COALESCE(EXCLUDED.play_external_id, game_highlights.play_external_id),
+			published_at = EXCLUDED.published_at,
+			updated_at = NOW()
+		WHERE (game_highlights.headline, game_highlights.description, game_highlights.web_url,
+				game_highlights.video_url, game_highlights.play_external_id)
+			IS DISTINCT FROM (EXCLUDED.headline, EXCLUDED.description, EXCLUDED.web_url,
+				EXCLUDED.video_url, COALESCE(EXCLUDED.play_external_id, game_highlights.play_external_id))
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("preparing highlight upsert: %w", err)
+	}
+	defer stmt.Close()
+
+	written := 0
+	for _, h := range highlights {
+		res, err := stmt.ExecContext(ctx,
+			gameID, h.ExternalID, h.Headline, h.Description, h.DurationSeconds,
+			h.ThumbnailURL, h.WebURL, h.VideoURL, h.PlayExternalID, h.PublishedAt,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("upserting highlight %s for game %d: %w", h.ExternalID, gameID, err)
+		}
+		n, _ := res.RowsAffected()
+		written += int(n)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing highlights for game %d: %w", gameID, err)
+	}
+
+	return written, nil
+}
+
+// GetByGame returns a game's highlights in game order: clips linked to a play
+// by play sequence, then unlinked clips by publish time
+func (r *HighlightRepository) GetByGame(ctx context.Context, gameID int) ([]*store.GameHighlight, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT h.highlight_id, h.game_id, h.external_id, h.headline, h.description, h.duration_seconds,
+			h.thumbnail_url, h.web_url, h.video_url, h.play_external_id, h.published_at,
+			p.sequence, p.period, p.clock, p.team_id, p.player_id
+		FROM game_highlights h
+		LEFT JOIN game_plays p ON p.game_id = h.game_id AND p.external_id = h.play_external_id
+		WHERE h.game_id = $1
+		ORDER BY p.sequence NULLS LAST, h.published_at, h.highlight_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("querying highlights: %w", err)
+	}
+	defer rows.Close()
+
+	var highlights []*store.GameHighlight
+	for rows.Next() {
+		h := &store.GameHighlight{}
+		err := rows.Scan(
+			&h.HighlightID, &h.GameID, &h.ExternalID, &h.Headline, &h.Description, &h.DurationSeconds,
+			&h.ThumbnailURL, &h.WebURL, &h.VideoURL, &h.PlayExternalID, &h.PublishedAt,
+			&h.PlaySequence, &h.Period, &h.Clock, &h.TeamID, &h.PlayerID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning highlight: %w", err)
+		}
+		highlights = append(highlights, h)
+	}
+
+	return highlights, rows.Err()
+}
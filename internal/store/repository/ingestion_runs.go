@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// IngestionRunRepository records scheduler and backfill run history
+type IngestionRunRepository struct {
+	db *store.Database
+}
+
+// NewIngestionRunRepository creates a new ingestion run repository
+func NewIngestionRunRepository(db *store.Database) *IngestionRunRepository {
+	return &IngestionRunRepository{db: db}
+}
+
+// Start inserts a running row and fills in RunID and StartedAt
+func (r *IngestionRunRepository) Start(ctx context.Context, run *store.IngestionRun) error {
+	if run.Sport == "" {
+		run.Sport = store.DefaultSport
+	}
+	run.Status = "running"
+
+	query := `
+		INSERT INTO ingestion_runs (sport, source, start_date, end_date, status, metadata)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6::jsonb, '{}'::jsonb))
+		RETURNING run_id, started_at
+	`
+
+	err := r.db.DB().QueryRowContext(ctx, query,
+		run.Sport, run.Source, run.StartDate, run.EndDate, run.Status, run.Metadata,
+	).Scan(&run.RunID, &run.StartedAt)
+	if err != nil {
+		return fmt.Errorf("starting ingestion run: %w", err)
+	}
+
+	return nil
+}
+
+// Finish marks the run succeeded, or failed when runErr is non-nil, and records its counters
+func (r *IngestionRunRepository) Finish(ctx context.Context, run *store.IngestionRun, runErr error) error {
+	run.Status = "succeeded"
+	if runErr != nil {
+		run.Status = "failed"
+		run.ErrorCount++
+		run.LastError = store.NullString{String: runErr.Error(), Valid: true}
+	}
+
+	completed := time.Now()
+	run.CompletedAt = store.NullTime{Time: completed, Valid: true}
+	run.DurationMs = store.NullInt64{Int64: completed.Sub(run.StartedAt).Milliseconds(), Valid: true}
+
+	query := `
+		UPDATE ingestion_runs SET
+			status = $2,
+			games_processed = $3,
+			rows_changed = $4,
+			rows_unchanged = $5,
+			error_count = $6,
+			last_error = $7,
+			completed_at = $8,
+			duration_ms = $9
+		WHERE run_id = $1
+	`
+
+	_, err := r.db.DB().ExecContext(ctx, query,
+		run.RunID, run.Status, run.GamesProcessed, run.RowsChanged, run.RowsUnchanged,
+		run.ErrorCount, run.LastError, run.CompletedAt, run.DurationMs,
+	)
+	if err != nil {
+		return fmt.Errorf("finishing ingestion run %d: %w", run.RunID, err)
+	}
+
+	return nil
+}
+
+// List returns the most recent runs, optionally filtered by source
+func (r *IngestionRunRepository) List(ctx context.Context, source string, limit int) ([]*store.IngestionRun, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT run_id, sport, source, start_date, end_date, status, games_processed,
+			rows_changed, rows_unchanged, error_count, last_error,
+			started_at, completed_at, duration_ms, metadata
+		FROM ingestion_runs
+		WHERE ($1 = '' OR source = $1)
+		ORDER BY started_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, source, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying ingestion runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*store.IngestionRun
+	for rows.Next() {
+		run := &store.IngestionRun{}
+		err := rows.Scan(
+			&run.RunID, &run.Sport, &run.Source, &run.StartDate, &run.EndDate, &run.Status,
+			&run.GamesProcessed, &run.RowsChanged, &run.RowsUnchanged, &run.ErrorCount, &run.LastError,
+			&run.StartedAt, &run.CompletedAt, &run.DurationMs, &run.Metadata,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning ingestion run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+// Freshness reports the latest run per source and the newest stored game data
+func (r *IngestionRunRepository) Freshness(ctx context.Context) (*store.DataFreshness, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	freshness := &store.DataFreshness{Sources: []store.SourceFreshness{}}
+
+	err := r.db.ReadDB(ctx).QueryRowContext(ctx, `
+		SELECT MAX(updated_at), MAX(game_date) FILTER (WHERE status = 'final')
+		FROM games
+	`).Scan(&freshness.LastGameUpdate, &freshness.LastFinalGame)
+	if err != nil {
+		return nil, fmt.Errorf("querying game freshness: %w", err)
+	}
+
+	query := `
+		SELECT DISTINCT ON (source) source, started_at, status,
+			(SELECT MAX(s.completed_at) FROM ingestion_runs s
+				WHERE s.source = r.source AND s.status = 'succeeded') AS last_success
+		FROM ingestion_runs r
+		ORDER BY source, started_at DESC
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying run freshness: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	for rows.Next() {
+		var sf store.SourceFreshness
+		if err := rows.Scan(&sf.Source, &sf.LastRunAt, &sf.LastRunStatus, &sf.LastSuccessAt); err != nil {
+			return nil, fmt.Errorf("scanning run freshness: %w", err)
+		}
+		if sf.LastSuccessAt.Valid {
+			age := int64(now.Sub(sf.LastSuccessAt.Time).Seconds())
+			sf.SecondsSinceSuccess = &age
+		}
+		freshness.Sources = append(freshness.Sources, sf)
+	}
+
+	return freshness, rows.Err()
+}
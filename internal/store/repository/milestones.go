@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// MilestoneRepository handles career milestone threshold config
+type MilestoneRepository struct {
+	db *store.Database
+}
+
+// NewMilestoneRepository creates a new milestone repository
+func NewMilestoneRepository(db *store.Database) *MilestoneRepository {
+	return &MilestoneRepository{db: db}
+}
+
+// GetThresholds returns the active thresholds for a sport, ordered by stat then threshold
+func (r *MilestoneRepository) GetThresholds(ctx context.Context, sport string) ([]*store.MilestoneThreshold, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT threshold_id, sport, stat, threshold, proximity, label
+		FROM milestone_thresholds
+		WHERE sport = $1 AND is_active
+		ORDER BY stat, threshold
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, sport)
+	if err != nil {
+		return nil, fmt.Errorf("querying milestone thresholds: %w", err)
+	}
+	defer rows.Close()
+
+	var thresholds []*store.MilestoneThreshold
+	for rows.Next() {
+		t := &store.MilestoneThreshold{}
+		if err := rows.Scan(&t.ThresholdID, &t.Sport, &t.Stat, &t.Threshold, &t.Proximity, &t.Label); err != nil {
+			return nil, fmt.Errorf("scanning milestone threshold: %w", err)
+		}
+		thresholds = append(thresholds, t)
+	}
+
+	return thresholds, rows.Err()
+}
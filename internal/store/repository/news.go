@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/lib/pq"
+)
+
+// NewsRepository stores ESPN news article metadata
+type NewsRepository struct {
+	db *store.Database
+}
+
+// NewNewsRepository creates a new news repository
+func NewNewsRepository(db *store.Database) *NewsRepository {
+	return &NewsRepository{db: db}
+}
+
+// Upsert stores an article, or refreshes its text and merges its team and
+// player IDs when another feed already stored it. It sets ArticleID, CreatedAt,
+// and the merged IDs, and reports whether the article is new.
+func (r *NewsRepository) Upsert(ctx context.Context, a *store.NewsArticle) (bool, error) {
+	query := `
+		INSERT INTO news_articles (sport, external_id, article_type, headline, description, link,
+			published_at, team_ids, player_ids)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (sport, external_id) DO UPDATE SET
+			article_type = EXCLUDED.article_type,
+			headline = EXCLUDED.headline,
+			description = EXCLUDED.description,
+			link = EXCLUDED.link,
+			published_at = EXCLUDED.published_at,
+			team_ids = ARRAY(SELECT DISTINCT unnest(news_articles.team_ids || EXCLUDED.team_ids) ORDER BY 1),
+			player_ids = ARRAY(SELECT DISTINCT unnest(news_articles.player_ids || EXCLUDED.player_ids) ORDER BY 1),
+			updated_at = NOW()
+		RETURNING article_id, created_at, team_ids, player_ids, (xmax = 0) AS inserted
+	`
+
+	// A nil slice would be sent as NULL rather than an empty array
+	teamIDs, playerIDs := pq.Int64Array{}, pq.Int64Array{}
+	for _, id := range a.TeamIDs {
+		teamIDs = append(teamIDs, int64(id))
+	}
+	for _, id := range a.PlayerIDs {
+		playerIDs = append(playerIDs, int64(id))
+	}
+
+	var inserted bool
+	err := r.db.DB().QueryRowContext(ctx, query,
+		a.Sport, a.ExternalID, a.ArticleType, a.Headline, a.Description, a.Link,
+		a.PublishedAt, teamIDs, playerIDs,
+	).Scan(&a.ArticleID, &a.CreatedAt, &teamIDs, &playerIDs, &inserted)
+	if err != nil {
+		return false, fmt.Errorf("upserting news article %s: %w", a.ExternalID, err)
+	}
+	a.TeamIDs = intSlice(teamIDs)
+	a.PlayerIDs = intSlice(playerIDs)
+	return inserted, nil
+}
+
+// ListByTeam returns the newest articles tagged with a team
+func (r *NewsRepository) ListByTeam(ctx context.Context, sport string, teamID, limit int) ([]*store.NewsArticle, error) {
+	return r.list(ctx, `team_ids @> ARRAY[$2]::integer[]`, sport, teamID, limit)
+}
+
+// ListByPlayer returns the newest articles tagged with a player
+func (r *NewsRepository) ListByPlayer(ctx context.Context, sport string, playerID, limit int) ([]*store.NewsArticle, error) {
+	return r.list(ctx, `player_ids @> ARRAY[$2]::integer[]`, sport, playerID, limit)
+}
+
+func (r *NewsRepository) list(ctx context.Context, filter, sport string, id, limit int) ([]*store.NewsArticle, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT article_id, sport, external_id, article_type, headline, description, link,
+			published_at, team_ids, player_ids, created_at
+		FROM news_articles
+		WHERE sport = $1 AND ` + filter + `
+		ORDER BY published_at DESC, article_id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, sport, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying news articles: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []*store.NewsArticle
+	for rows.Next() {
+		a := &store.NewsArticle{}
+		var teamIDs, playerIDs pq.Int64Array
+		err := rows.Scan(
+			&a.ArticleID, &a.Sport, &a.ExternalID, &a.ArticleType, &a.Headline, &a.Description, &a.Link,
+			&a.PublishedAt, &teamIDs, &playerIDs, &a.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning news article: %w", err)
+		}
+		a.TeamIDs = intSlice(teamIDs)
+		a.PlayerIDs = intSlice(playerIDs)
+		articles = append(articles, a)
+	}
+
+	return articles, rows.Err()
+}
+
+func intSlice(values pq.Int64Array) []int {
+	ints := make([]int, len(values))
+	for i, v := range values {
+		ints[i] = int(v)
+	}
+	return ints
+}
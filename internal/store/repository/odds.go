@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// OddsRepository joins stored results against mapped Alexandria odds
+type OddsRepository struct {
+	db *store.Database
+}
+
+// NewOddsRepository creates a new odds repository
+func NewOddsRepository(db *store.Database) *OddsRepository {
+	return &OddsRepository{db: db}
+}
+
+// closingLinesQuery picks one closing line per final game. When a game has several
+// game mappings, the verified, most confident one wins.
+const closingLinesQuery = `
+	SELECT game_id, game_date, home_team_id, away_team_id, home_score, away_score,
+		alexandria_event_id, home_spread, total, bookmaker
+	FROM (
+		SELECT DISTINCT ON (g.game_id)
+			g.game_id, g.game_date, g.home_team_id, g.away_team_id, g.home_score, g.away_score,
+			om.alexandria_event_id, cl.home_spread, cl.total, cl.bookmaker
+		FROM games g
+		JOIN seasons s ON g.season_id = s.season_id
+		JOIN odds_mappings om ON om.minerva_game_id = g.game_id AND om.mapping_type = 'game'
+		JOIN closing_lines cl ON cl.mapping_id = om.mapping_id
+		WHERE g.sport = $1 AND g.league = 'nba'
+			AND g.status = 'final'
+			AND g.home_score IS NOT NULL AND g.away_score IS NOT NULL
+			AND ($2 = 0 OR g.home_team_id = $2 OR g.away_team_id = $2)
+			AND ($3 = '' OR s.season_year = $3)
+		ORDER BY g.game_id, om.verified DESC, om.confidence DESC, om.mapping_id DESC
+	) lines
+	ORDER BY game_date DESC, game_id DESC
+`
+
+// GetClosingLines returns final games with a mapped closing line, newest first.
+// A zero teamID covers every team; an empty seasonYear covers every season.
+// Reading stops when ctx is done.
+func (r *OddsRepository) GetClosingLines(ctx context.Context, sport string, teamID int, seasonYear string) ([]*store.GameClosingLine, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, closingLinesQuery, sport, teamID, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("querying closing lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []*store.GameClosingLine{}
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		l := &store.GameClosingLine{}
+		err := rows.Scan(
+			&l.GameID, &l.GameDate, &l.HomeTeamID, &l.AwayTeamID, &l.HomeScore, &l.AwayScore,
+			&l.AlexandriaEventID, &l.HomeSpread, &l.Total, &l.Bookmaker,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning closing line: %w", err)
+		}
+		lines = append(lines, l)
+	}
+
+	return lines, rows.Err()
+}
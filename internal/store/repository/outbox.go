@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// Execer runs a statement on a connection pool or inside a transaction, so
+// writers can enqueue outbox entries in the same transaction as their data
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Querier is an Execer that can also read back a row, for upserts that run
+// either on the primary pool or inside an ingestion transaction
+type Querier interface {
+	Execer
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// OutboxRepository stores stream publishes until the outbox relay delivers them
+type OutboxRepository struct {
+	db *store.Database
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *store.Database) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Enqueue adds a JSON payload for stream using exec, or the primary pool when exec is nil
+func (r *OutboxRepository) Enqueue(ctx context.Context, exec Execer, stream string, payload []byte) error {
+	if exec == nil {
+		exec = r.db.DB()
+	}
+	_, err := exec.ExecContext(ctx, `INSERT INTO event_outbox (stream, payload) VALUES ($1, $2::jsonb)`, stream, string(payload))
+	if err != nil {
+		return fmt.Errorf("enqueuing outbox entry for %s: %w", stream, err)
+	}
+	return nil
+}
+
+// Drain locks up to limit pending entries, oldest first, and hands each to
+// deliver. Delivered entries are stamped published. Delivery stops at the first
+// failure so streams stay in order; that entry records the error and is retried
+// on a later drain. Entries locked by another relay are skipped. It returns how
+// many entries were delivered.
+func (r *OutboxRepository) Drain(ctx context.Context, limit int, deliver func(*store.OutboxEntry) error) (int, error) {
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning outbox transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT outbox_id, stream, payload, created_at, attempts
+		FROM event_outbox
+		WHERE published_at IS NULL
+		ORDER BY outbox_id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("querying pending outbox entries: %w", err)
+	}
+
+	var entries []*store.OutboxEntry
+	for rows.Next() {
+		entry := &store.OutboxEntry{}
+		var payload []byte
+		if err := rows.Scan(&entry.OutboxID, &entry.Stream, &payload, &entry.CreatedAt, &entry.Attempts); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scanning outbox entry: %w", err)
+		}
+		entry.Payload = payload
+		entries = append(entries, entry)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterating outbox entries: %w", err)
+	}
+
+	delivered := 0
+	for _, entry := range entries {
+		if deliverErr := deliver(entry); deliverErr != nil {
+			_, err := tx.ExecContext(ctx, `
+				UPDATE event_outbox SET attempts = attempts + 1, last_error = $2 WHERE outbox_id = $1
+			`, entry.OutboxID, deliverErr.Error())
+			if err != nil {
+				return 0, fmt.Errorf("recording outbox failure for %d: %w", entry.OutboxID, err)
+			}
+			break
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			UPDATE event_outbox SET attempts = attempts + 1, last_error = NULL, published_at = NOW() WHERE outbox_id = $1
+		`, entry.OutboxID)
+		if err != nil {
+			return 0, fmt.Errorf("marking outbox entry %d published: %w", entry.OutboxID, err)
+		}
+		delivered++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing outbox drain: %w", err)
+	}
+	return delivered, nil
+}
+
+// Pending returns the number of entries not yet published
+func (r *OutboxRepository) Pending(ctx context.Context) (int, error) {
+	var n int
+	if err := r.db.DB().QueryRowContext(ctx, `SELECT COUNT(*) FROM event_outbox WHERE published_at IS NULL`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("counting pending outbox entries: %w", err)
+	}
+	return n, nil
+}
+
+// PurgePublished deletes entries published before the cut-off
+func (r *OutboxRepository) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.DB().ExecContext(ctx, `DELETE FROM event_outbox WHERE published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("purging published outbox entries: %w", err)
+	}
+	return res.RowsAffected()
+}
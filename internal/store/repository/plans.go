@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/lib/pq"
+)
+
+// HotQueryPlans returns the request-path queries whose plans must stay on indexes.
+// Argument values only need to be well typed; plans are checked with seqscan disabled.
+func HotQueryPlans() []store.PlanCheck {
+	return []store.PlanCheck{
+		{
+			Name:  "player recent stats",
+			Query: playerRecentStatsEnrichedQuery,
+			Args:  []interface{}{0, 10},
+		},
+		{
+			Name:  "player season averages",
+			Query: playerSeasonAveragesQuery,
+			Args:  []interface{}{0, "2025-26", pq.Array(store.SpecialGameTypes), nil},
+		},
+		{
+			Name:  "player date range totals",
+			Query: playerRangeTotalsQuery,
+			Args:  []interface{}{0, "2025-01-01", "2025-02-01", pq.Array(store.SpecialGameTypes)},
+		},
+		{
+			Name:  "team date range totals",
+			Query: teamRangeTotalsQuery,
+			Args:  []interface{}{0, "2025-01-01", "2025-02-01", pq.Array(store.SpecialGameTypes)},
+		},
+		{
+			Name:  "team game logs",
+			Query: teamGameLogsQuery,
+			Args:  []interface{}{0, "2025-26"},
+		},
+		{
+			Name:  "season player stats export",
+			Query: seasonPlayerStatsQuery,
+			Args:  []interface{}{0},
+		},
+	}
+}
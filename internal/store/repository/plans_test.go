@@ -0,0 +1,29 @@
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fortuna/minerva/internal/store/repository"
+	"github.com/fortuna/minerva/internal/testsupport"
+)
+
+// TestHotQueryPlans fails when a hot query falls back to a sequential scan,
+// i.e. when a migration dropped or never created the index it relies on. The
+// test database must be migrated (`minerva migrate up`).
+func TestHotQueryPlans(t *testing.T) {
+	db := testsupport.Database(t)
+
+	for _, check := range repository.HotQueryPlans() {
+		t.Run(check.Name, func(t *testing.T) {
+			scans, err := db.SeqScans(context.Background(), check)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(scans) > 0 {
+				t.Errorf("sequential scan on %s; add or restore its index", strings.Join(scans, ", "))
+			}
+		})
+	}
+}
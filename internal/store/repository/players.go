@@ -3,9 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/fortuna/minerva/internal/store"
+	"github.com/lib/pq"
 )
 
 // PlayerRepository handles player data access
@@ -18,31 +22,55 @@ func NewPlayerRepository(db *store.Database) *PlayerRepository {
 	return &PlayerRepository{db: db}
 }
 
+// playerColumns is the standard players column list
+var playerColumns = &columnSet[store.Player]{
+	table: "players",
+	columns: []column[store.Player]{
+		{"player_id", func(p *store.Player) interface{} { return &p.PlayerID }},
+		{"sport", func(p *store.Player) interface{} { return &p.Sport }},
+		{"external_id", func(p *store.Player) interface{} { return &p.ExternalID }},
+		{"first_name", func(p *store.Player) interface{} { return &p.FirstName }},
+		{"last_name", func(p *store.Player) interface{} { return &p.LastName }},
+		{"full_name", func(p *store.Player) interface{} { return &p.FullName }},
+		{"display_name", func(p *store.Player) interface{} { return &p.DisplayName }},
+		{"birth_date", func(p *store.Player) interface{} { return &p.BirthDate }},
+		{"birth_city", func(p *store.Player) interface{} { return &p.BirthCity }},
+		{"birth_country", func(p *store.Player) interface{} { return &p.BirthCountry }},
+		{"nationality", func(p *store.Player) interface{} { return &p.Nationality }},
+		{"height", func(p *store.Player) interface{} { return &p.Height }},
+		{"height_inches", func(p *store.Player) interface{} { return &p.HeightInches }},
+		{"weight", func(p *store.Player) interface{} { return &p.Weight }},
+		{"position", func(p *store.Player) interface{} { return &p.Position }},
+		{"college", func(p *store.Player) interface{} { return &p.College }},
+		{"high_school", func(p *store.Player) interface{} { return &p.HighSchool }},
+		{"draft_year", func(p *store.Player) interface{} { return &p.DraftYear }},
+		{"draft_round", func(p *store.Player) interface{} { return &p.DraftRound }},
+		{"draft_pick", func(p *store.Player) interface{} { return &p.DraftPick }},
+		{"draft_team_id", func(p *store.Player) interface{} { return &p.DraftTeamID }},
+		{"headshot_url", func(p *store.Player) interface{} { return &p.HeadshotURL }},
+		{"jersey_number", func(p *store.Player) interface{} { return &p.JerseyNumber }},
+		{"status", func(p *store.Player) interface{} { return &p.Status }},
+		{"metadata", func(p *store.Player) interface{} { return &p.Metadata }},
+		{"created_at", func(p *store.Player) interface{} { return &p.CreatedAt }},
+		{"updated_at", func(p *store.Player) interface{} { return &p.UpdatedAt }},
+	},
+}
+
 // GetByID finds a player by ID
 func (r *PlayerRepository) GetByID(ctx context.Context, playerID int) (*store.Player, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT player_id, sport, external_id, first_name, last_name, full_name, display_name,
-			birth_date, birth_city, birth_country, nationality,
-			height, height_inches, weight, position, college, high_school,
-			draft_year, draft_round, draft_pick, draft_team_id,
-			headshot_url, jersey_number, status, metadata,
-			created_at, updated_at
+		SELECT ` + playerColumns.list("") + `
 		FROM players
 		WHERE player_id = $1
 	`
 
-	player := &store.Player{}
-	err := r.db.DB().QueryRowContext(ctx, query, playerID).Scan(
-		&player.PlayerID, &player.Sport, &player.ExternalID, &player.FirstName, &player.LastName,
-		&player.FullName, &player.DisplayName, &player.BirthDate, &player.BirthCity, &player.BirthCountry,
-		&player.Nationality, &player.Height, &player.HeightInches, &player.Weight, &player.Position,
-		&player.College, &player.HighSchool, &player.DraftYear, &player.DraftRound, &player.DraftPick,
-		&player.DraftTeamID, &player.HeadshotURL, &player.JerseyNumber, &player.Status, &player.Metadata,
-		&player.CreatedAt, &player.UpdatedAt,
-	)
+	player, err := playerColumns.scan(r.db.ReadDB(ctx).QueryRowContext(ctx, query, playerID))
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("player not found: %d", playerID)
+		return nil, store.NotFound("player %d", playerID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("querying player: %w", err)
@@ -53,29 +81,19 @@ func (r *PlayerRepository) GetByID(ctx context.Context, playerID int) (*store.Pl
 
 // GetByExternalID finds a player by external ID (e.g., ESPN ID)
 func (r *PlayerRepository) GetByExternalID(ctx context.Context, externalID string) (*store.Player, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT player_id, sport, external_id, first_name, last_name, full_name, display_name,
-			birth_date, birth_city, birth_country, nationality,
-			height, height_inches, weight, position, college, high_school,
-			draft_year, draft_round, draft_pick, draft_team_id,
-			headshot_url, jersey_number, status, metadata,
-			created_at, updated_at
+		SELECT ` + playerColumns.list("") + `
 		FROM players
 		WHERE external_id = $1
 	`
 
-	player := &store.Player{}
-	err := r.db.DB().QueryRowContext(ctx, query, externalID).Scan(
-		&player.PlayerID, &player.Sport, &player.ExternalID, &player.FirstName, &player.LastName,
-		&player.FullName, &player.DisplayName, &player.BirthDate, &player.BirthCity, &player.BirthCountry,
-		&player.Nationality, &player.Height, &player.HeightInches, &player.Weight, &player.Position,
-		&player.College, &player.HighSchool, &player.DraftYear, &player.DraftRound, &player.DraftPick,
-		&player.DraftTeamID, &player.HeadshotURL, &player.JerseyNumber, &player.Status, &player.Metadata,
-		&player.CreatedAt, &player.UpdatedAt,
-	)
+	player, err := playerColumns.scan(r.db.ReadDB(ctx).QueryRowContext(ctx, query, externalID))
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("player not found: %s", externalID)
+		return nil, store.NotFound("player %s", externalID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("querying player: %w", err)
@@ -84,22 +102,126 @@ func (r *PlayerRepository) GetByExternalID(ctx context.Context, externalID strin
 	return player, nil
 }
 
-// GetByName searches for players by name (case-insensitive partial match)
-func (r *PlayerRepository) GetByName(ctx context.Context, name string) ([]*store.Player, error) {
+// GetByIDs loads many players in one query, keyed by player ID. IDs that do not
+// exist are absent from the map; callers keep their own order by iterating
+// their ID list.
+func (r *PlayerRepository) GetByIDs(ctx context.Context, playerIDs []int) (map[int]*store.Player, error) {
+	byID := make(map[int]*store.Player, len(playerIDs))
+	if len(playerIDs) == 0 {
+		return byID, nil
+	}
+
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT player_id, sport, external_id, first_name, last_name, full_name, display_name,
-			birth_date, birth_city, birth_country, nationality,
-			height, height_inches, weight, position, college, high_school,
-			draft_year, draft_round, draft_pick, draft_team_id,
-			headshot_url, jersey_number, status, metadata,
-			created_at, updated_at
+		SELECT ` + playerColumns.list("") + `
 		FROM players
-		WHERE full_name ILIKE $1 OR display_name ILIKE $1
-		ORDER BY full_name
-		LIMIT 50
+		WHERE player_id = ANY($1)
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, "%"+name+"%")
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, pq.Array(playerIDs))
+	if err != nil {
+		return nil, fmt.Errorf("querying players by ID: %w", err)
+	}
+	defer rows.Close()
+
+	players, err := r.scanPlayers(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, player := range players {
+		byID[player.PlayerID] = player
+	}
+	return byID, nil
+}
+
+// GetByExternalIDs loads many players in one query, keyed by external ID.
+// Unknown IDs are absent from the map.
+func (r *PlayerRepository) GetByExternalIDs(ctx context.Context, externalIDs []string) (map[string]*store.Player, error) {
+	byExternalID := make(map[string]*store.Player, len(externalIDs))
+	if len(externalIDs) == 0 {
+		return byExternalID, nil
+	}
+
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + playerColumns.list("") + `
+		FROM players
+		WHERE external_id = ANY($1)
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, pq.Array(externalIDs))
+	if err != nil {
+		return nil, fmt.Errorf("querying players by external ID: %w", err)
+	}
+	defer rows.Close()
+
+	players, err := r.scanPlayers(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, player := range players {
+		byExternalID[player.ExternalID.String] = player
+	}
+	return byExternalID, nil
+}
+
+// GetByName searches for players by name (case-insensitive partial match)
+func (r *PlayerRepository) GetByName(ctx context.Context, name string, limit int) ([]*store.Player, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	// Names are compared through player_search_key (lowercased, unaccented) so
+	// "jokic" finds "Nikola Jokić". Exact names rank first, then names or last
+	// names starting with the term, then substrings, then misspellings close
+	// enough for pg_trgm's word similarity, each ordered by similarity. Aliases
+	// ("Greek Freak") rank like names.
+	query := `
+		WITH q AS (SELECT player_search_key($1) AS term, ` + searchPatternExpr + ` AS pattern),
+		alias_hits AS (
+			SELECT a.player_id,
+				bool_or(player_search_key(a.alias) = q.term) AS exact,
+				bool_or(player_search_key(a.alias) LIKE q.pattern || '%') AS prefix,
+				bool_or(player_search_key(a.alias) LIKE '%' || q.pattern || '%') AS partial,
+				MAX(word_similarity(q.term, player_search_key(a.alias))) AS similarity
+			FROM player_aliases a, q
+			WHERE player_search_key(a.alias) LIKE '%' || q.pattern || '%'
+				OR q.term <% player_search_key(a.alias)
+			GROUP BY a.player_id
+		)
+		SELECT ` + playerColumns.list("p") + `
+		FROM players p
+		CROSS JOIN q
+		LEFT JOIN alias_hits ah ON ah.player_id = p.player_id
+		WHERE player_search_key(p.full_name) LIKE '%' || q.pattern || '%'
+			OR player_search_key(p.display_name) LIKE '%' || q.pattern || '%'
+			OR q.term <% player_search_key(p.full_name)
+			OR q.term <% player_search_key(p.display_name)
+			OR ah.player_id IS NOT NULL
+		ORDER BY
+			CASE
+				WHEN player_search_key(p.full_name) = q.term OR player_search_key(p.display_name) = q.term
+					OR ah.exact THEN 0
+				WHEN player_search_key(p.full_name) LIKE q.pattern || '%'
+					OR player_search_key(p.last_name) LIKE q.pattern || '%'
+					OR player_search_key(p.display_name) LIKE q.pattern || '%'
+					OR ah.prefix THEN 1
+				WHEN player_search_key(p.full_name) LIKE '%' || q.pattern || '%'
+					OR player_search_key(p.display_name) LIKE '%' || q.pattern || '%'
+					OR ah.partial THEN 2
+				ELSE 3
+			END,
+			GREATEST(word_similarity(q.term, player_search_key(p.full_name)),
+				word_similarity(q.term, player_search_key(p.display_name)), ah.similarity) DESC,
+			p.status = 'active' DESC,
+			p.full_name
+		LIMIT $2
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, strings.TrimSpace(name), limit)
 	if err != nil {
 		return nil, fmt.Errorf("querying players: %w", err)
 	}
@@ -108,20 +230,74 @@ func (r *PlayerRepository) GetByName(ctx context.Context, name string) ([]*store
 	return r.scanPlayers(rows)
 }
 
+// Autocomplete returns players whose name, or any word of it, or an alias
+// starts with prefix, matched without case or accents. Whole-name prefixes
+// rank first, then active players.
+func (r *PlayerRepository) Autocomplete(ctx context.Context, prefix string, limit int) ([]*store.PlayerSuggestion, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		WITH q AS (SELECT ` + searchPatternExpr + ` AS pattern)
+		SELECT p.player_id, COALESCE(p.display_name, p.full_name), p.headshot_url,
+			h.team_id, t.abbreviation
+		FROM q, players p
+		LEFT JOIN LATERAL (
+			SELECT team_id
+			FROM player_team_history
+			WHERE player_id = p.player_id AND (end_date IS NULL OR end_date > NOW())
+			ORDER BY start_date DESC
+			LIMIT 1
+		) h ON true
+		LEFT JOIN teams t ON t.team_id = h.team_id
+		WHERE player_search_key(p.full_name) LIKE q.pattern || '%'
+			OR player_search_key(p.last_name) LIKE q.pattern || '%'
+			OR player_search_key(p.display_name) LIKE q.pattern || '%'
+			OR player_search_key(p.full_name) LIKE '% ' || q.pattern || '%'
+			OR EXISTS (
+				SELECT 1 FROM player_aliases a
+				WHERE a.player_id = p.player_id
+					AND player_search_key(a.alias) LIKE q.pattern || '%'
+			)
+		ORDER BY player_search_key(p.full_name) LIKE q.pattern || '%' DESC,
+			p.status = 'active' DESC,
+			p.full_name
+		LIMIT $2
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, strings.TrimSpace(prefix), limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying player suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	var suggestions []*store.PlayerSuggestion
+	for rows.Next() {
+		s := &store.PlayerSuggestion{}
+		if err := rows.Scan(&s.PlayerID, &s.Name, &s.HeadshotURL, &s.TeamID, &s.Team); err != nil {
+			return nil, fmt.Errorf("scanning player suggestion: %w", err)
+		}
+		suggestions = append(suggestions, s)
+	}
+	return suggestions, rows.Err()
+}
+
+// searchPatternExpr folds search term $1 like player_search_key and escapes the
+// LIKE wildcards a user might type
+const searchPatternExpr = `replace(replace(replace(player_search_key($1), '\', '\\'), '%', '\%'), '_', '\_')`
+
 // GetAll returns all players
 func (r *PlayerRepository) GetAll(ctx context.Context) ([]*store.Player, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT player_id, sport, external_id, first_name, last_name, full_name, display_name,
-			birth_date, birth_city, birth_country, nationality,
-			height, height_inches, weight, position, college, high_school,
-			draft_year, draft_round, draft_pick, draft_team_id,
-			headshot_url, jersey_number, status, metadata,
-			created_at, updated_at
+		SELECT ` + playerColumns.list("") + `
 		FROM players
 		ORDER BY full_name
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("querying players: %w", err)
 	}
@@ -130,7 +306,9 @@ func (r *PlayerRepository) GetAll(ctx context.Context) ([]*store.Player, error)
 	return r.scanPlayers(rows)
 }
 
-// Upsert inserts or updates a player
+// Upsert inserts or updates a player. Draft and enriched biographical fields
+// left null keep their stored values, since box score and roster feeds do not
+// carry them.
 func (r *PlayerRepository) Upsert(ctx context.Context, player *store.Player) error {
 	query := `
 		INSERT INTO players (sport, external_id, first_name, last_name, full_name, display_name,
@@ -144,20 +322,20 @@ func (r *PlayerRepository) Upsert(ctx context.Context, player *store.Player) err
 			last_name = EXCLUDED.last_name,
 			full_name = EXCLUDED.full_name,
 			display_name = EXCLUDED.display_name,
-			birth_date = EXCLUDED.birth_date,
-			birth_city = EXCLUDED.birth_city,
-			birth_country = EXCLUDED.birth_country,
-			nationality = EXCLUDED.nationality,
+			birth_date = COALESCE(EXCLUDED.birth_date, players.birth_date),
+			birth_city = COALESCE(EXCLUDED.birth_city, players.birth_city),
+			birth_country = COALESCE(EXCLUDED.birth_country, players.birth_country),
+			nationality = COALESCE(EXCLUDED.nationality, players.nationality),
 			height = EXCLUDED.height,
-			height_inches = EXCLUDED.height_inches,
+			height_inches = COALESCE(EXCLUDED.height_inches, players.height_inches),
 			weight = EXCLUDED.weight,
 			position = EXCLUDED.position,
-			college = EXCLUDED.college,
+			college = COALESCE(EXCLUDED.college, players.college),
 			high_school = EXCLUDED.high_school,
-			draft_year = EXCLUDED.draft_year,
-			draft_round = EXCLUDED.draft_round,
-			draft_pick = EXCLUDED.draft_pick,
-			draft_team_id = EXCLUDED.draft_team_id,
+			draft_year = COALESCE(EXCLUDED.draft_year, players.draft_year),
+			draft_round = COALESCE(EXCLUDED.draft_round, players.draft_round),
+			draft_pick = COALESCE(EXCLUDED.draft_pick, players.draft_pick),
+			draft_team_id = COALESCE(EXCLUDED.draft_team_id, players.draft_team_id),
 			headshot_url = EXCLUDED.headshot_url,
 			jersey_number = EXCLUDED.jersey_number,
 			status = EXCLUDED.status,
@@ -178,11 +356,201 @@ func (r *PlayerRepository) Upsert(ctx context.Context, player *store.Player) err
 		return fmt.Errorf("upserting player: %w", err)
 	}
 
+	notifyEntityWrite(store.EntityPlayer, player.PlayerID)
+	return nil
+}
+
+// GetByExactName returns the players whose full name, display name, or an
+// alias equals name, ignoring case and accents
+func (r *PlayerRepository) GetByExactName(ctx context.Context, name string) ([]*store.Player, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + playerColumns.list("p") + `
+		FROM players p
+		WHERE player_search_key(p.full_name) = player_search_key($1)
+			OR player_search_key(p.display_name) = player_search_key($1)
+			OR EXISTS (
+				SELECT 1 FROM player_aliases a
+				WHERE a.player_id = p.player_id AND player_search_key(a.alias) = player_search_key($1)
+			)
+		ORDER BY p.player_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("querying players by name: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanPlayers(rows)
+}
+
+// GetAliases returns a player's aliases, oldest first
+func (r *PlayerRepository) GetAliases(ctx context.Context, playerID int) ([]*store.PlayerAlias, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, `
+		SELECT alias_id, player_id, alias, alias_type, source, created_at
+		FROM player_aliases
+		WHERE player_id = $1
+		ORDER BY created_at, alias_id
+	`, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("querying player aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []*store.PlayerAlias{}
+	for rows.Next() {
+		a := &store.PlayerAlias{}
+		if err := rows.Scan(&a.AliasID, &a.PlayerID, &a.Alias, &a.AliasType, &a.Source, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning player alias: %w", err)
+		}
+		aliases = append(aliases, a)
+	}
+	return aliases, rows.Err()
+}
+
+// AddAlias records an alternate name for a player, filling in its ID and
+// creation time. An alias the player already has (ignoring case and accents)
+// is a store.ErrConflict; an unknown player is a store.ErrNotFound.
+func (r *PlayerRepository) AddAlias(ctx context.Context, alias *store.PlayerAlias) error {
+	alias.Alias = strings.TrimSpace(alias.Alias)
+	if alias.AliasType == "" {
+		alias.AliasType = store.PlayerAliasNickname
+	}
+	if alias.Source == "" {
+		alias.Source = store.PlayerAliasSourceManual
+	}
+
+	query := `
+		INSERT INTO player_aliases (player_id, alias, alias_type, source)
+		VALUES ($1, $2, $3, $4)
+		RETURNING alias_id, created_at
+	`
+
+	err := r.db.DB().QueryRowContext(ctx, query, alias.PlayerID, alias.Alias, alias.AliasType, alias.Source).
+		Scan(&alias.AliasID, &alias.CreatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+		return store.NotFound("player %d", alias.PlayerID)
+	}
+	if err != nil {
+		return fmt.Errorf("adding player alias: %w", store.ConflictError(err))
+	}
+
+	notifyEntityWrite(store.EntityPlayer, alias.PlayerID)
 	return nil
 }
 
+// DeleteAlias removes one of a player's aliases
+func (r *PlayerRepository) DeleteAlias(ctx context.Context, playerID, aliasID int) error {
+	res, err := r.db.DB().ExecContext(ctx,
+		`DELETE FROM player_aliases WHERE alias_id = $1 AND player_id = $2`, aliasID, playerID)
+	if err != nil {
+		return fmt.Errorf("deleting player alias: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return store.NotFound("alias %d of player %d", aliasID, playerID)
+	}
+
+	notifyEntityWrite(store.EntityPlayer, playerID)
+	return nil
+}
+
+// SetDraft records where a player was drafted. pick is the overall pick number.
+// It reports whether any draft field changed.
+func (r *PlayerRepository) SetDraft(ctx context.Context, playerID, year, round, pick int, draftTeamID store.NullInt32) (bool, error) {
+	res, err := r.db.DB().ExecContext(ctx, `
+		UPDATE players
+		SET draft_year = $2, draft_round = $3, draft_pick = $4, draft_team_id = $5, updated_at = NOW()
+		WHERE player_id = $1
+			AND (draft_year, draft_round, draft_pick, draft_team_id) IS DISTINCT FROM ($2::int, $3::int, $4::int, $5::int)
+	`, playerID, year, round, pick, draftTeamID)
+	if err != nil {
+		return false, fmt.Errorf("setting draft for player %d: %w", playerID, err)
+	}
+	n, _ := res.RowsAffected()
+	if n > 0 {
+		notifyEntityWrite(store.EntityPlayer, playerID)
+	}
+	return n > 0, nil
+}
+
+// FillBio sets the biographical fields a player is missing from bio, leaving
+// stored values alone. It returns the columns it filled.
+func (r *PlayerRepository) FillBio(ctx context.Context, playerID int, bio *store.PlayerBio) ([]string, error) {
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning bio update: %w", err)
+	}
+	defer tx.Rollback()
+
+	cur := &store.Player{}
+	err = tx.QueryRowContext(ctx, `
+		SELECT birth_date, birth_city, birth_country, nationality, height, height_inches, weight, college, headshot_url
+		FROM players
+		WHERE player_id = $1
+		FOR UPDATE
+	`, playerID).Scan(
+		&cur.BirthDate, &cur.BirthCity, &cur.BirthCountry, &cur.Nationality, &cur.Height,
+		&cur.HeightInches, &cur.Weight, &cur.College, &cur.HeadshotURL,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("loading player %d bio: %w", playerID, err)
+	}
+
+	fields := []struct {
+		column  string
+		missing bool
+		known   bool
+		value   interface{}
+	}{
+		{"birth_date", !cur.BirthDate.Valid, bio.BirthDate.Valid, bio.BirthDate},
+		{"birth_city", !cur.BirthCity.Valid, bio.BirthCity.Valid, bio.BirthCity},
+		{"birth_country", !cur.BirthCountry.Valid, bio.BirthCountry.Valid, bio.BirthCountry},
+		{"nationality", !cur.Nationality.Valid, bio.Nationality.Valid, bio.Nationality},
+		{"height", !cur.Height.Valid, bio.Height.Valid, bio.Height},
+		{"height_inches", !cur.HeightInches.Valid, bio.HeightInches.Valid, bio.HeightInches},
+		{"weight", !cur.Weight.Valid, bio.Weight.Valid, bio.Weight},
+		{"college", !cur.College.Valid, bio.College.Valid, bio.College},
+		{"headshot_url", !cur.HeadshotURL.Valid, bio.HeadshotURL.Valid, bio.HeadshotURL},
+	}
+
+	var filled, sets []string
+	args := []interface{}{playerID}
+	for _, f := range fields {
+		if !f.missing || !f.known {
+			continue
+		}
+		args = append(args, f.value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", f.column, len(args)))
+		filled = append(filled, f.column)
+	}
+	if len(filled) == 0 {
+		return nil, nil
+	}
+
+	query := `UPDATE players SET ` + strings.Join(sets, ", ") + `, updated_at = NOW() WHERE player_id = $1`
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("filling player %d bio: %w", playerID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing bio update: %w", err)
+	}
+
+	notifyEntityWrite(store.EntityPlayer, playerID)
+	return filled, nil
+}
+
 // GetCurrentTeamID returns the current team ID for a player from player_team_history
 func (r *PlayerRepository) GetCurrentTeamID(ctx context.Context, playerID int) (int, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
 		SELECT team_id
 		FROM player_team_history
@@ -193,9 +561,9 @@ func (r *PlayerRepository) GetCurrentTeamID(ctx context.Context, playerID int) (
 	`
 
 	var teamID int
-	err := r.db.DB().QueryRowContext(ctx, query, playerID).Scan(&teamID)
+	err := r.db.ReadDB(ctx).QueryRowContext(ctx, query, playerID).Scan(&teamID)
 	if err == sql.ErrNoRows {
-		return 0, fmt.Errorf("no current team found for player %d", playerID)
+		return 0, store.NotFound("current team for player %d", playerID)
 	}
 	if err != nil {
 		return 0, fmt.Errorf("querying current team: %w", err)
@@ -206,13 +574,11 @@ func (r *PlayerRepository) GetCurrentTeamID(ctx context.Context, playerID int) (
 
 // GetByCurrentTeam returns all players currently on a team
 func (r *PlayerRepository) GetByCurrentTeam(ctx context.Context, teamID int) ([]*store.Player, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT DISTINCT p.player_id, p.sport, p.external_id, p.first_name, p.last_name, p.full_name, p.display_name,
-			p.birth_date, p.birth_city, p.birth_country, p.nationality,
-			p.height, p.height_inches, p.weight, p.position, p.college, p.high_school,
-			p.draft_year, p.draft_round, p.draft_pick, p.draft_team_id,
-			p.headshot_url, p.jersey_number, p.status, p.metadata,
-			p.created_at, p.updated_at
+		SELECT DISTINCT ` + playerColumns.list("p") + `
 		FROM players p
 		INNER JOIN player_team_history pth ON p.player_id = pth.player_id
 		WHERE pth.team_id = $1
@@ -220,7 +586,7 @@ func (r *PlayerRepository) GetByCurrentTeam(ctx context.Context, teamID int) ([]
 		ORDER BY p.full_name
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, teamID)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("querying players by team: %w", err)
 	}
@@ -229,19 +595,208 @@ func (r *PlayerRepository) GetByCurrentTeam(ctx context.Context, teamID int) ([]
 	return r.scanPlayers(rows)
 }
 
+// GetSeasonPositions returns the listed position of everyone with a stat line
+// in a season, keyed by player ID. Players without a position are absent.
+// Reading stops when ctx is done.
+func (r *PlayerRepository) GetSeasonPositions(ctx context.Context, seasonID int) (map[int]string, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT p.player_id, p.position
+		FROM players p
+		WHERE p.position IS NOT NULL
+		  AND EXISTS (
+			SELECT 1 FROM player_game_stats pgs
+			JOIN games g ON g.game_id = pgs.game_id
+			WHERE pgs.player_id = p.player_id AND g.season_id = $1
+				AND pgs.deleted_at IS NULL
+		  )
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("querying season positions: %w", err)
+	}
+	defer rows.Close()
+
+	positions := make(map[int]string)
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var playerID int
+		var position string
+		if err := rows.Scan(&playerID, &position); err != nil {
+			return nil, fmt.Errorf("scanning season position: %w", err)
+		}
+		positions[playerID] = position
+	}
+	return positions, rows.Err()
+}
+
+// SetCurrentTeam records that a player is on a team as of a date. An open stint
+// on the same team is left alone; any other open stint is closed the day before.
+// It reports whether the player's current team changed.
+func (r *PlayerRepository) SetCurrentTeam(ctx context.Context, playerID, teamID, seasonID int, asOf time.Time, jersey, position sql.NullString) (bool, error) {
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("beginning team history update: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM player_team_history
+			WHERE player_id = $1 AND team_id = $2 AND end_date IS NULL
+		)
+	`, playerID, teamID).Scan(&current)
+	if err != nil {
+		return false, fmt.Errorf("checking current team: %w", err)
+	}
+	if current {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE player_team_history
+		SET end_date = GREATEST(start_date, $2::date - 1), updated_at = NOW()
+		WHERE player_id = $1 AND end_date IS NULL
+	`, playerID, asOf)
+	if err != nil {
+		return false, fmt.Errorf("closing previous team stint: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO player_team_history (player_id, team_id, season_id, start_date, jersey_number, position)
+		VALUES ($1, $2, $3, $4::date, $5, $6)
+		ON CONFLICT (player_id, team_id, start_date) DO UPDATE SET
+			end_date = NULL,
+			jersey_number = EXCLUDED.jersey_number,
+			position = EXCLUDED.position,
+			updated_at = NOW()
+	`, playerID, teamID, seasonID, asOf, jersey, position)
+	if err != nil {
+		return false, fmt.Errorf("inserting team stint: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("committing team history update: %w", err)
+	}
+	return true, nil
+}
+
+// EndTeamStint closes a player's open stint on a team as of a date, for waivers
+// and releases. It reports whether an open stint was closed.
+func (r *PlayerRepository) EndTeamStint(ctx context.Context, playerID, teamID int, asOf time.Time) (bool, error) {
+	res, err := r.db.DB().ExecContext(ctx, `
+		UPDATE player_team_history
+		SET end_date = GREATEST(start_date, $3::date), updated_at = NOW()
+		WHERE player_id = $1 AND team_id = $2 AND end_date IS NULL
+	`, playerID, teamID, asOf)
+	if err != nil {
+		return false, fmt.Errorf("closing team stint: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// PlayerMergeResult reports the rows moved by Merge
+type PlayerMergeResult struct {
+	GameStatsMoved    int64 `json:"game_stats_moved"`
+	GameStatsDropped  int64 `json:"game_stats_dropped"`
+	TeamHistoryMoved  int64 `json:"team_history_moved"`
+	OddsMappingsMoved int64 `json:"odds_mappings_moved"`
+	AliasesMoved      int64 `json:"aliases_moved"`
+	AliasesAdded      int64 `json:"aliases_added"` // the duplicate's names, kept as aliases
+}
+
+// Merge folds a duplicate player into another in a single transaction.
+// Stat lines for games the target already has are dropped in favour of the
+// target's row, every other reference is repointed, and the duplicate is deleted.
+// The duplicate's names become aliases of the target, so search and ingestion
+// name matching still find it.
+func (r *PlayerRepository) Merge(ctx context.Context, fromID, intoID int) (*PlayerMergeResult, error) {
+	if fromID == intoID {
+		return nil, fmt.Errorf("cannot merge player %d into itself", fromID)
+	}
+
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning merge transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var found int
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM players WHERE player_id IN ($1, $2)`, fromID, intoID,
+	).Scan(&found); err != nil {
+		return nil, fmt.Errorf("checking players: %w", err)
+	}
+	if found != 2 {
+		return nil, store.NotFound("player %d or %d", fromID, intoID)
+	}
+
+	result := &PlayerMergeResult{}
+	steps := []struct {
+		query  string
+		target *int64
+	}{
+		{`DELETE FROM player_game_stats f
+			USING player_game_stats t
+			WHERE f.player_id = $1 AND t.player_id = $2 AND t.game_id = f.game_id`, &result.GameStatsDropped},
+		{`UPDATE player_game_stats SET player_id = $2 WHERE player_id = $1`, &result.GameStatsMoved},
+		{`DELETE FROM player_team_history f
+			USING player_team_history t
+			WHERE f.player_id = $1 AND t.player_id = $2
+				AND t.team_id = f.team_id AND t.start_date = f.start_date`, nil},
+		{`UPDATE player_team_history SET player_id = $2, updated_at = NOW() WHERE player_id = $1`, &result.TeamHistoryMoved},
+		{`UPDATE odds_mappings SET minerva_player_id = $2, updated_at = NOW() WHERE minerva_player_id = $1`, &result.OddsMappingsMoved},
+		{`UPDATE player_transactions SET player_id = $2 WHERE player_id = $1`, nil},
+		{`DELETE FROM player_aliases f
+			USING player_aliases t
+			WHERE f.player_id = $1 AND t.player_id = $2
+				AND player_search_key(t.alias) = player_search_key(f.alias)`, nil},
+		{`UPDATE player_aliases SET player_id = $2 WHERE player_id = $1`, &result.AliasesMoved},
+		{`INSERT INTO player_aliases (player_id, alias, alias_type, source)
+			SELECT DISTINCT ON (player_search_key(n.name)) $2::int, n.name, 'spelling', 'merge'
+			FROM players f
+			JOIN players t ON t.player_id = $2
+			CROSS JOIN LATERAL (VALUES (f.full_name), (f.display_name)) n(name)
+			WHERE f.player_id = $1 AND COALESCE(n.name, '') <> ''
+				AND player_search_key(n.name) <> player_search_key(t.full_name)
+				AND player_search_key(n.name) <> player_search_key(t.display_name)
+			ON CONFLICT DO NOTHING`, &result.AliasesAdded},
+		{`DELETE FROM players WHERE player_id = $1`, nil},
+	}
+
+	for _, step := range steps {
+		res, err := tx.ExecContext(ctx, step.query, fromID, intoID)
+		if err != nil {
+			return nil, fmt.Errorf("merging player %d into %d: %w", fromID, intoID, store.ConflictError(err))
+		}
+		if step.target != nil {
+			*step.target, _ = res.RowsAffected()
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing merge: %w", err)
+	}
+	notifyEntityWrite(store.EntityPlayer, fromID)
+	notifyEntityWrite(store.EntityPlayer, intoID)
+	notifyEntityWrite(store.EntityPlayerStats, fromID)
+	notifyEntityWrite(store.EntityPlayerStats, intoID)
+
+	return result, nil
+}
+
 // scanPlayers is a helper to scan multiple player rows
 func (r *PlayerRepository) scanPlayers(rows *sql.Rows) ([]*store.Player, error) {
 	var players []*store.Player
 	for rows.Next() {
-		player := &store.Player{}
-		err := rows.Scan(
-			&player.PlayerID, &player.Sport, &player.ExternalID, &player.FirstName, &player.LastName,
-			&player.FullName, &player.DisplayName, &player.BirthDate, &player.BirthCity, &player.BirthCountry,
-			&player.Nationality, &player.Height, &player.HeightInches, &player.Weight, &player.Position,
-			&player.College, &player.HighSchool, &player.DraftYear, &player.DraftRound, &player.DraftPick,
-			&player.DraftTeamID, &player.HeadshotURL, &player.JerseyNumber, &player.Status, &player.Metadata,
-			&player.CreatedAt, &player.UpdatedAt,
-		)
+		player, err := playerColumns.scan(rows)
 		if err != nil {
 			return nil, fmt.Errorf("scanning player: %w", err)
 		}
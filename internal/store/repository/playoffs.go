@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/lib/pq"
+)
+
+// PlayoffSeriesRepository handles postseason series data access
+type PlayoffSeriesRepository struct {
+	db *store.Database
+}
+
+// NewPlayoffSeriesRepository creates a new playoff series repository
+func NewPlayoffSeriesRepository(db *store.Database) *PlayoffSeriesRepository {
+	return &PlayoffSeriesRepository{db: db}
+}
+
+// RecordGame links a persisted playoff game to its series, creating the series on
+// first sight, then recomputes game numbers and the series score from final games.
+// Round, name, conference, and best-of are only overwritten when series carries them.
+func (r *PlayoffSeriesRepository) RecordGame(ctx context.Context, game *store.Game, series *store.PlayoffSeries) (int, error) {
+	teamA, teamB := game.HomeTeamID, game.AwayTeamID
+	if teamB < teamA {
+		teamA, teamB = teamB, teamA
+	}
+
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning series update: %w", err)
+	}
+	defer tx.Rollback()
+
+	var seriesID int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO playoff_series (sport, season_id, round, round_name, conference,
+			team_a_id, team_b_id, best_of)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, 0), 7))
+		ON CONFLICT (season_id, team_a_id, team_b_id) DO UPDATE SET
+			round = COALESCE(EXCLUDED.round, playoff_series.round),
+			round_name = COALESCE(EXCLUDED.round_name, playoff_series.round_name),
+			conference = COALESCE(EXCLUDED.conference, playoff_series.conference),
+			best_of = CASE WHEN $8 > 0 THEN EXCLUDED.best_of ELSE playoff_series.best_of END,
+			updated_at = NOW()
+		RETURNING series_id
+	`, game.Sport, game.SeasonID, series.Round, series.RoundName, series.Conference,
+		teamA, teamB, series.BestOf,
+	).Scan(&seriesID)
+	if err != nil {
+		return 0, fmt.Errorf("upserting series: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO playoff_series_games (game_id, series_id, game_number)
+		VALUES ($1, $2, 0)
+		ON CONFLICT (game_id) DO UPDATE SET series_id = EXCLUDED.series_id
+	`, game.GameID, seriesID)
+	if err != nil {
+		return 0, fmt.Errorf("linking game %d to series %d: %w", game.GameID, seriesID, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE playoff_series_games psg
+		SET game_number = n.game_number
+		FROM (
+			SELECT psg.game_id, ROW_NUMBER() OVER (ORDER BY g.game_date, g.game_id) AS game_number
+			FROM playoff_series_games psg
+			JOIN games g ON psg.game_id = g.game_id
+			WHERE psg.series_id = $1
+		) n
+		WHERE psg.game_id = n.game_id AND psg.game_number IS DISTINCT FROM n.game_number
+	`, seriesID)
+	if err != nil {
+		return 0, fmt.Errorf("numbering series %d games: %w", seriesID, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE playoff_series ps
+		SET team_a_wins = w.a_wins,
+			team_b_wins = w.b_wins,
+			winner_team_id = CASE
+				WHEN w.a_wins > ps.best_of / 2 THEN ps.team_a_id
+				WHEN w.b_wins > ps.best_of / 2 THEN ps.team_b_id
+			END,
+			status = CASE
+				WHEN w.a_wins > ps.best_of / 2 OR w.b_wins > ps.best_of / 2 THEN 'completed'
+				WHEN w.played > 0 THEN 'in_progress'
+				ELSE 'scheduled'
+			END,
+			updated_at = NOW()
+		FROM (
+			SELECT
+				COUNT(*) FILTER (WHERE g.status = 'final' AND g.home_score <> g.away_score) AS played,
+				COUNT(*) FILTER (WHERE g.status = 'final' AND
+					CASE WHEN g.home_score > g.away_score THEN g.home_team_id ELSE g.away_team_id END = $2) AS a_wins,
+				COUNT(*) FILTER (WHERE g.status = 'final' AND
+					CASE WHEN g.home_score > g.away_score THEN g.home_team_id ELSE g.away_team_id END = $3) AS b_wins
+			FROM playoff_series_games psg
+			JOIN games g ON psg.game_id = g.game_id
+			WHERE psg.series_id = $1
+		) w
+		WHERE ps.series_id = $1
+	`, seriesID, teamA, teamB)
+	if err != nil {
+		return 0, fmt.Errorf("scoring series %d: %w", seriesID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing series %d: %w", seriesID, err)
+	}
+
+	return seriesID, nil
+}
+
+// GetBySeasonYear returns every series for a season year (e.g. "2024-25") regardless
+// of whether its games were stored under the playoffs or regular season row.
+func (r *PlayoffSeriesRepository) GetBySeasonYear(ctx context.Context, sport, seasonYear string) ([]*store.PlayoffSeries, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ps.series_id, ps.sport, ps.season_id, ps.round, ps.round_name, ps.conference,
+			ps.team_a_id, ps.team_b_id, ps.team_a_wins, ps.team_b_wins, ps.best_of,
+			ps.winner_team_id, ps.status,
+			(SELECT MIN(g.game_date) FROM playoff_series_games psg
+				JOIN games g ON psg.game_id = g.game_id
+				WHERE psg.series_id = ps.series_id) AS start_date
+		FROM playoff_series ps
+		JOIN seasons s ON ps.season_id = s.season_id
+		WHERE ps.sport = $1 AND s.season_year = $2
+		ORDER BY start_date NULLS LAST, ps.series_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, sport, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("querying playoff series: %w", err)
+	}
+	defer rows.Close()
+
+	var series []*store.PlayoffSeries
+	for rows.Next() {
+		s := &store.PlayoffSeries{}
+		err := rows.Scan(
+			&s.SeriesID, &s.Sport, &s.SeasonID, &s.Round, &s.RoundName, &s.Conference,
+			&s.TeamAID, &s.TeamBID, &s.TeamAWins, &s.TeamBWins, &s.BestOf,
+			&s.WinnerTeamID, &s.Status, &s.StartDate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning playoff series: %w", err)
+		}
+		series = append(series, s)
+	}
+
+	return series, rows.Err()
+}
+
+// LatestSeasonYear returns the most recent season year with playoff series on record
+func (r *PlayoffSeriesRepository) LatestSeasonYear(ctx context.Context, sport string) (string, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT s.season_year
+		FROM playoff_series ps
+		JOIN seasons s ON ps.season_id = s.season_id
+		WHERE ps.sport = $1
+		ORDER BY s.start_date DESC
+		LIMIT 1
+	`
+
+	var seasonYear string
+	if err := r.db.ReadDB(ctx).QueryRowContext(ctx, query, sport).Scan(&seasonYear); err != nil {
+		return "", fmt.Errorf("querying latest playoff season: %w", err)
+	}
+	return seasonYear, nil
+}
+
+// GetGamesForSeries returns the games of the given series in game number order
+func (r *PlayoffSeriesRepository) GetGamesForSeries(ctx context.Context, seriesIDs []int) ([]*store.PlayoffSeriesGame, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT psg.series_id, psg.game_number, COALESCE(g.external_id, ''), g.game_date,
+			g.home_team_id, g.away_team_id, g.home_score, g.away_score, g.status
+		FROM playoff_series_games psg
+		JOIN games g ON psg.game_id = g.game_id
+		WHERE psg.series_id = ANY($1)
+		ORDER BY psg.series_id, psg.game_number
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, pq.Array(seriesIDs))
+	if err != nil {
+		return nil, fmt.Errorf("querying series games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []*store.PlayoffSeriesGame
+	for rows.Next() {
+		g := &store.PlayoffSeriesGame{}
+		err := rows.Scan(
+			&g.SeriesID, &g.GameNumber, &g.GameID, &g.GameDate,
+			&g.HomeTeamID, &g.AwayTeamID, &g.HomeScore, &g.AwayScore, &g.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning series game: %w", err)
+		}
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
+}
+
+// GetGameInfo returns playoff context keyed by database game ID. Regular season
+// games are simply absent from the map.
+func (r *PlayoffSeriesRepository) GetGameInfo(ctx context.Context, gameIDs []int) (map[int]*store.PlayoffGameInfo, error) {
+	info := make(map[int]*store.PlayoffGameInfo)
+	if len(gameIDs) == 0 {
+		return info, nil
+	}
+
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT psg.game_id, ps.series_id, ps.round, ps.round_name, psg.game_number,
+			ps.team_a_id, ps.team_a_wins, ps.team_b_id, ps.team_b_wins
+		FROM playoff_series_games psg
+		JOIN playoff_series ps ON psg.series_id = ps.series_id
+		WHERE psg.game_id = ANY($1)
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, pq.Array(gameIDs))
+	if err != nil {
+		return nil, fmt.Errorf("querying playoff game info: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var gameID int
+		p := &store.PlayoffGameInfo{}
+		err := rows.Scan(
+			&gameID, &p.SeriesID, &p.Round, &p.RoundName, &p.GameNumber,
+			&p.TeamAID, &p.TeamAWins, &p.TeamBID, &p.TeamBWins,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning playoff game info: %w", err)
+		}
+		info[gameID] = p
+	}
+
+	return info, rows.Err()
+}
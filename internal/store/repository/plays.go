@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// PlayRepository handles play-by-play data access
+type PlayRepository struct {
+	db *store.Database
+}
+
+// NewPlayRepository creates a new play repository
+func NewPlayRepository(db *store.Database) *PlayRepository {
+	return &PlayRepository{db: db}
+}
+
+// UpsertForGame writes a game's plays in one transaction, keyed by sequence.
+// Rows whose content is unchanged are left alone; it returns the number of rows written.
+func (r *PlayRepository) UpsertForGame(ctx context.Context, gameID int, plays []*store.GamePlay) (int, error) {
+	if len(plays) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning play upsert: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO game_plays (game_id, sequence, external_id, period, clock, clock_seconds,
+			team_id, player_id, play_type, description, scoring_play, score_value,
+			home_score, away_score, wallclock, shooting_play)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (game_id, sequence) DO UPDATE SET
+			external_id = EXCLUDED.external_id,
+			period = EXCLUDED.period,
+			clock = EXCLUDED.clock,
+			clock_seconds = EXCLUDED.clock_seconds,
+			team_id = EXCLUDED.team_id,
+			player_id = EXCLUDED.player_id,
+			play_type = EXCLUDED.play_type,
+			description = EXCLUDED.description,
+			scoring_play = EXCLUDED.scoring_play,
+			shooting_play = EXCLUDED.shooting_play,
+			score_value = EXCLUDED.score_value,
+			home_score = EXCLUDED.home_score,
+			away_score = EXCLUDED.away_score,
+			wallclock = EXCLUDED.wallclock,
+			updated_at = NOW()
+		WHERE (game_plays.description, game_plays.home_score, game_plays.away_score,
+				game_plays.team_id, game_plays.player_id, game_plays.clock, game_plays.shooting_play)
+			IS DISTINCT FROM (EXCLUDED.description, EXCLUDED.home_score, EXCLUDED.away_score,
+				EXCLUDED.team_id, EXCLUDED.player_id, EXCLUDED.clock, EXCLUDED.shooting_play)
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("preparing play upsert: %w", err)
+	}
+	defer stmt.Close()
+
+	written := 0
+	for _, p := range plays {
+		res, err := stmt.ExecContext(ctx,
+			gameID, p.Sequence, p.ExternalID, p.Period, p.Clock, p.ClockSeconds,
+			p.TeamID, p.PlayerID, p.PlayType, p.Description, p.ScoringPlay, p.ScoreValue,
+			p.HomeScore, p.AwayScore, p.Wallclock, p.ShootingPlay,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("upserting play %d for game %d: %w", p.Sequence, gameID, err)
+		}
+		n, _ := res.RowsAffected()
+		written += int(n)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing plays for game %d: %w", gameID, err)
+	}
+
+	return written, nil
+}
+
+// GetByGame returns a game's plays in sequence order. With scoringOnly, only
+// plays that changed the score are returned.
+func (r *PlayRepository) GetByGame(ctx context.Context, gameID int, scoringOnly bool) ([]*store.GamePlay, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT play_id, game_id, sequence, external_id, period, clock, clock_seconds,
+			team_id, player_id, play_type, description, scoring_play, score_value,
+			home_score, away_score, wallclock, shooting_play
+		FROM game_plays
+		WHERE game_id = $1 AND (NOT $2 OR scoring_play)
+		ORDER BY sequence
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, gameID, scoringOnly)
+	if err != nil {
+		return nil, fmt.Errorf("querying plays: %w", err)
+	}
+	defer rows.Close()
+
+	var plays []*store.GamePlay
+	for rows.Next() {
+		p := &store.GamePlay{}
+		err := rows.Scan(
+			&p.PlayID, &p.GameID, &p.Sequence, &p.ExternalID, &p.Period, &p.Clock, &p.ClockSeconds,
+			&p.TeamID, &p.PlayerID, &p.PlayType, &p.Description, &p.ScoringPlay, &p.ScoreValue,
+			&p.HomeScore, &p.AwayScore, &p.Wallclock, &p.ShootingPlay,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning play: %w", err)
+		}
+		plays = append(plays, p)
+	}
+
+	return plays, rows.Err()
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/lib/pq"
+)
+
+// PositionalDefenseRepository aggregates and stores production allowed by position
+type PositionalDefenseRepository struct {
+	db *store.Database
+}
+
+// NewPositionalDefenseRepository creates a new positional defense repository
+func NewPositionalDefenseRepository(db *store.Database) *PositionalDefenseRepository {
+	return &PositionalDefenseRepository{db: db}
+}
+
+// positionalDefenseQuery aggregates an NBA season's final games ($1, e.g. "2024-25"), skipping game
+// types in $2 and, when $3 is set, games on or after that date. Each defending
+// team's per-game figures sum every player at the position who logged minutes
+// against it. The league average behind points_vs_league covers every team,
+// before $4 (0 for all) narrows the result to one.
+const positionalDefenseQuery = `
+	WITH lines AS (
+		SELECT CASE WHEN pgs.team_id = g.home_team_id THEN g.away_team_id ELSE g.home_team_id END AS team_id,
+			g.season_id, pgs.game_id,
+			CASE UPPER(TRIM(p.position))
+				WHEN 'PG' THEN 'PG'
+				WHEN 'SG' THEN 'SG' WHEN 'G' THEN 'SG'
+				WHEN 'SF' THEN 'SF' WHEN 'F' THEN 'SF' WHEN 'G-F' THEN 'SF'
+				WHEN 'PF' THEN 'PF' WHEN 'F-C' THEN 'PF'
+				WHEN 'C' THEN 'C' WHEN 'C-F' THEN 'C'
+			END AS position,
+			pgs.points, pgs.rebounds, pgs.assists, pgs.three_pointers_made,
+			pgs.field_goals_made, pgs.field_goals_attempted
+		FROM player_game_stats pgs
+		JOIN games g ON g.game_id = pgs.game_id
+		JOIN seasons s ON s.season_id = g.season_id
+		JOIN players p ON p.player_id = pgs.player_id
+		WHERE s.season_year = $1 AND g.league = 'nba' AND g.status = 'final' AND pgs.deleted_at IS NULL
+			AND g.game_type <> ALL($2)
+			AND ($3::date IS NULL OR g.game_date < $3::date)
+			AND COALESCE(pgs.minutes_played, 0) > 0
+	), per_game AS (
+		SELECT team_id, season_id, position, game_id,
+			SUM(points) AS points, SUM(rebounds) AS rebounds, SUM(assists) AS assists,
+			SUM(three_pointers_made) AS threes,
+			SUM(field_goals_made) AS fgm, SUM(field_goals_attempted) AS fga
+		FROM lines
+		WHERE position IS NOT NULL
+		GROUP BY team_id, season_id, position, game_id
+	), teams AS (
+		SELECT team_id, season_id, position, COUNT(*) AS games,
+			AVG(points) AS points_per_game, AVG(rebounds) AS rebounds_per_game,
+			AVG(assists) AS assists_per_game, AVG(threes) AS threes_per_game,
+			SUM(fgm)::float / NULLIF(SUM(fga), 0) AS fg_pct
+		FROM per_game
+		GROUP BY team_id, season_id, position
+	), ranked AS (
+		SELECT *, points_per_game - AVG(points_per_game) OVER (PARTITION BY position) AS points_vs_league
+		FROM teams
+	)
+	SELECT team_id, season_id, position, games,
+		ROUND(points_per_game::numeric, 1)::float, ROUND(rebounds_per_game::numeric, 1)::float,
+		ROUND(assists_per_game::numeric, 1)::float, ROUND(threes_per_game::numeric, 1)::float,
+		ROUND(fg_pct::numeric, 3)::float, ROUND(points_vs_league::numeric, 1)::float
+	FROM ranked
+	WHERE $4 = 0 OR team_id = $4
+`
+
+// Compute aggregates a season's positional defense on the fly from games dated
+// strictly before before (zero for all), for one team or every team (teamID 0)
+func (r *PositionalDefenseRepository) Compute(ctx context.Context, seasonYear string, before time.Time, teamID int) ([]*store.TeamPositionalDefense, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, positionalDefenseQuery+" ORDER BY team_id, position",
+		seasonYear, pq.Array(store.SpecialGameTypes), dateBound(before), teamID)
+	if err != nil {
+		return nil, fmt.Errorf("querying positional defense: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPositionalDefense(ctx, rows)
+}
+
+// Refresh recomputes and replaces a season's stored positional defense,
+// returning how many team-position rows were written
+func (r *PositionalDefenseRepository) Refresh(ctx context.Context, seasonYear string) (int64, error) {
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning positional defense refresh: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		DELETE FROM team_positional_defense
+		WHERE season_id IN (SELECT season_id FROM seasons WHERE season_year = $1 AND league = 'nba')
+	`
+	if _, err := tx.ExecContext(ctx, query, seasonYear); err != nil {
+		return 0, fmt.Errorf("clearing positional defense: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO team_positional_defense (
+			team_id, season_id, position, games, points_per_game, rebounds_per_game,
+			assists_per_game, threes_per_game, fg_pct, points_vs_league
+		)`+positionalDefenseQuery,
+		seasonYear, pq.Array(store.SpecialGameTypes), nil, 0)
+	if err != nil {
+		return 0, fmt.Errorf("storing positional defense: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing positional defense refresh: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetBySeason returns a season's stored positional defense, for one team or
+// every team (teamID 0) and one position or all (""), softest defense first
+func (r *PositionalDefenseRepository) GetBySeason(ctx context.Context, seasonYear string, teamID int, position string) ([]*store.TeamPositionalDefense, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT d.team_id, d.season_id, d.position, d.games,
+			d.points_per_game, d.rebounds_per_game, d.assists_per_game, d.threes_per_game,
+			d.fg_pct, d.points_vs_league
+		FROM team_positional_defense d
+		JOIN seasons s ON s.season_id = d.season_id
+		WHERE s.season_year = $1 AND s.league = 'nba'
+			AND ($2 = 0 OR d.team_id = $2)
+			AND ($3 = '' OR d.position = $3)
+		ORDER BY d.position, d.points_vs_league DESC
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, seasonYear, teamID, position)
+	if err != nil {
+		return nil, fmt.Errorf("querying positional defense: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPositionalDefense(ctx, rows)
+}
+
+// scanPositionalDefense scans positional defense rows, stopping when ctx is done
+func scanPositionalDefense(ctx context.Context, rows *sql.Rows) ([]*store.TeamPositionalDefense, error) {
+	var defense []*store.TeamPositionalDefense
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		d := &store.TeamPositionalDefense{}
+		if err := rows.Scan(
+			&d.TeamID, &d.SeasonID, &d.Position, &d.Games,
+			&d.PointsPerGame, &d.ReboundsPerGame, &d.AssistsPerGame, &d.ThreesPerGame,
+			&d.FGPct, &d.PointsVsLeague,
+		); err != nil {
+			return nil, fmt.Errorf("scanning positional defense: %w", err)
+		}
+		defense = append(defense, d)
+	}
+
+	return defense, rows.Err()
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/lib/pq"
+)
+
+// TeamRatingRepository handles the Elo ratings written after final games
+type TeamRatingRepository struct {
+	db *store.Database
+}
+
+// NewTeamRatingRepository creates a new team rating repository
+func NewTeamRatingRepository(db *store.Database) *TeamRatingRepository {
+	return &TeamRatingRepository{db: db}
+}
+
+// teamRatingColumns is the standard team_ratings column list
+var teamRatingColumns = &columnSet[store.TeamRating]{
+	table: "team_ratings",
+	columns: []column[store.TeamRating]{
+		{"team_id", func(t *store.TeamRating) interface{} { return &t.TeamID }},
+		{"game_id", func(t *store.TeamRating) interface{} { return &t.GameID }},
+		{"season_id", func(t *store.TeamRating) interface{} { return &t.SeasonID }},
+		{"rating_date", func(t *store.TeamRating) interface{} { return &t.RatingDate }},
+		{"opponent_team_id", func(t *store.TeamRating) interface{} { return &t.OpponentTeamID }},
+		{"is_home", func(t *store.TeamRating) interface{} { return &t.IsHome }},
+		{"elo_before", func(t *store.TeamRating) interface{} { return &t.EloBefore }},
+		{"elo", func(t *store.TeamRating) interface{} { return &t.Elo }},
+		{"win_probability", func(t *store.TeamRating) interface{} { return &t.WinProbability }},
+	},
+}
+
+// ratedGamesFilter selects the final NBA games that move ratings, aliased g
+const ratedGamesFilter = `
+	g.league = 'nba' AND g.status = 'final' AND g.game_type = ANY($1)
+	AND g.home_score IS NOT NULL AND g.away_score IS NOT NULL
+`
+
+// FirstUnratedDate returns the date of the earliest final game without
+// ratings, and false when every game is rated
+func (r *TeamRatingRepository) FirstUnratedDate(ctx context.Context) (time.Time, bool, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT MIN(g.game_date)
+		FROM games g
+		WHERE ` + ratedGamesFilter + `
+			AND NOT EXISTS (SELECT 1 FROM team_ratings tr WHERE tr.game_id = g.game_id)
+	`
+
+	var first store.NullTime
+	if err := r.db.ReadDB(ctx).QueryRowContext(ctx, query, pq.Array(store.EloGameTypes)).Scan(&first); err != nil {
+		return time.Time{}, false, fmt.Errorf("querying first unrated game: %w", err)
+	}
+	return first.Time, first.Valid, nil
+}
+
+// ListGamesFrom returns the final games that move ratings dated on or after
+// from, in the order they are rated
+func (r *TeamRatingRepository) ListGamesFrom(ctx context.Context, from time.Time) ([]*store.Game, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + gameColumns.list("g") + `
+		FROM games g
+		WHERE ` + ratedGamesFilter + `
+			AND g.game_date >= $2::date
+		ORDER BY g.game_date, g.game_time NULLS LAST, g.game_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, pq.Array(store.EloGameTypes), from.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("querying games to rate: %w", err)
+	}
+	defer rows.Close()
+
+	var games []*store.Game
+	for rows.Next() {
+		game, err := scanGameRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+	return games, rows.Err()
+}
+
+// GetLatest returns each team's most recent rating dated before before, keyed by
+// team ID. A zero before returns current ratings.
+func (r *TeamRatingRepository) GetLatest(ctx context.Context, before time.Time) (map[int]*store.TeamRating, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT DISTINCT ON (team_id) ` + teamRatingColumns.list("") + `
+		FROM team_ratings
+		WHERE ($1::date IS NULL OR rating_date < $1::date)
+		ORDER BY team_id, rating_date DESC, game_id DESC
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, dateBound(before))
+	if err != nil {
+		return nil, fmt.Errorf("querying latest team ratings: %w", err)
+	}
+	defer rows.Close()
+
+	ratings, err := scanTeamRatings(rows)
+	if err != nil {
+		return nil, err
+	}
+	byTeam := make(map[int]*store.TeamRating, len(ratings))
+	for _, rating := range ratings {
+		byTeam[rating.TeamID] = rating
+	}
+	return byTeam, nil
+}
+
+// Replace deletes every rating dated on or after from and saves ratings in
+// their place, in one transaction, so a late game re-rates everything after it
+func (r *TeamRatingRepository) Replace(ctx context.Context, from time.Time, ratings []*store.TeamRating) error {
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning team rating save: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM team_ratings WHERE rating_date >= $1::date`, from.Format("2006-01-02")); err != nil {
+		return fmt.Errorf("clearing team ratings: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO team_ratings (`+teamRatingColumns.list("")+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`)
+	if err != nil {
+		return fmt.Errorf("preparing team rating insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, t := range ratings {
+		_, err := stmt.ExecContext(ctx,
+			t.TeamID, t.GameID, t.SeasonID, t.RatingDate, t.OpponentTeamID, t.IsHome,
+			t.EloBefore, t.Elo, t.WinProbability,
+		)
+		if err != nil {
+			return fmt.Errorf("saving rating for team %d game %d: %w", t.TeamID, t.GameID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing team ratings: %w", err)
+	}
+	return nil
+}
+
+// GetHistory returns a team's ratings over a season, oldest first
+func (r *TeamRatingRepository) GetHistory(ctx context.Context, teamID, seasonID int) ([]*store.TeamRating, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + teamRatingColumns.list("") + `
+		FROM team_ratings
+		WHERE team_id = $1 AND season_id = $2
+		ORDER BY rating_date, game_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, teamID, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("querying team rating history: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTeamRatings(rows)
+}
+
+// GetByGame returns both teams' ratings from a rated game, keyed by team ID
+func (r *TeamRatingRepository) GetByGame(ctx context.Context, gameID int) (map[int]*store.TeamRating, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + teamRatingColumns.list("") + `
+		FROM team_ratings
+		WHERE game_id = $1
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("querying game ratings: %w", err)
+	}
+	defer rows.Close()
+
+	ratings, err := scanTeamRatings(rows)
+	if err != nil {
+		return nil, err
+	}
+	byTeam := make(map[int]*store.TeamRating, len(ratings))
+	for _, rating := range ratings {
+		byTeam[rating.TeamID] = rating
+	}
+	return byTeam, nil
+}
+
+func scanTeamRatings(rows *sql.Rows) ([]*store.TeamRating, error) {
+	ratings := []*store.TeamRating{}
+	for rows.Next() {
+		rating, err := teamRatingColumns.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning team rating: %w", err)
+		}
+		ratings = append(ratings, rating)
+	}
+	return ratings, rows.Err()
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// StarterRepository handles pre-game starting lineup data access
+type StarterRepository struct {
+	db *store.Database
+}
+
+// NewStarterRepository creates a new starting lineup repository
+func NewStarterRepository(db *store.Database) *StarterRepository {
+	return &StarterRepository{db: db}
+}
+
+// ReplaceTeamLineup stores a team's starting lineup for a game in one
+// transaction, replacing the one on record. A lineup matching the stored one
+// slot for slot is left alone; it reports whether anything was written.
+func (r *StarterRepository) ReplaceTeamLineup(ctx context.Context, gameID, teamID int, starters []*store.GameStarter) (bool, error) {
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("beginning lineup replace: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT player_id, lineup_slot, position
+		FROM game_starters
+		WHERE game_id = $1 AND team_id = $2
+		ORDER BY lineup_slot
+		FOR UPDATE
+	`, gameID, teamID)
+	if err != nil {
+		return false, fmt.Errorf("querying lineup: %w", err)
+	}
+	var existing []*store.GameStarter
+	for rows.Next() {
+		s := &store.GameStarter{}
+		if err := rows.Scan(&s.PlayerID, &s.LineupSlot, &s.Position); err != nil {
+			rows.Close()
+			return false, fmt.Errorf("scanning lineup: %w", err)
+		}
+		existing = append(existing, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("querying lineup: %w", err)
+	}
+	if sameLineup(existing, starters) {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM game_starters WHERE game_id = $1 AND team_id = $2`, gameID, teamID); err != nil {
+		return false, fmt.Errorf("clearing lineup for game %d team %d: %w", gameID, teamID, err)
+	}
+	for _, s := range starters {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO game_starters (game_id, team_id, player_id, lineup_slot, position)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (game_id, team_id, player_id) DO NOTHING
+		`, gameID, teamID, s.PlayerID, s.LineupSlot, s.Position)
+		if err != nil {
+			return false, fmt.Errorf("inserting starter %d for game %d: %w", s.PlayerID, gameID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("committing lineup for game %d team %d: %w", gameID, teamID, err)
+	}
+	return true, nil
+}
+
+// sameLineup reports whether two lineups, each in slot order, list the same
+// players in the same slots and positions
+func sameLineup(a, b []*store.GameStarter) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].PlayerID != b[i].PlayerID || a[i].LineupSlot != b[i].LineupSlot || a[i].Position != b[i].Position {
+			return false
+		}
+	}
+	return true
+}
+
+// GetByGame returns a game's stored starters with player names, by team and
+// lineup slot
+func (r *StarterRepository) GetByGame(ctx context.Context, gameID int) ([]*store.GameStarter, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT s.game_id, s.team_id, s.player_id, s.lineup_slot, s.position, s.captured_at,
+			p.full_name, p.external_id
+		FROM game_starters s
+		JOIN players p ON p.player_id = s.player_id
+		WHERE s.game_id = $1
+		ORDER BY s.team_id, s.lineup_slot
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("querying starters: %w", err)
+	}
+	defer rows.Close()
+
+	var starters []*store.GameStarter
+	for rows.Next() {
+		s := &store.GameStarter{}
+		err := rows.Scan(
+			&s.GameID, &s.TeamID, &s.PlayerID, &s.LineupSlot, &s.Position, &s.CapturedAt,
+			&s.FullName, &s.ExternalID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning starter: %w", err)
+		}
+		starters = append(starters, s)
+	}
+
+	return starters, rows.Err()
+}
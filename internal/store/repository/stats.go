@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/fortuna/minerva/internal/store"
+	"github.com/lib/pq"
 )
 
 // StatsRepository handles player and team stats data access
@@ -18,59 +20,219 @@ func NewStatsRepository(db *store.Database) *StatsRepository {
 	return &StatsRepository{db: db}
 }
 
+// playerStatsColumns is the standard player_game_stats column list. Minutes
+// are derived from seconds after scanning (PlayerGameStats.FillMinutes).
+var playerStatsColumns = &columnSet[store.PlayerGameStats]{
+	table: "player_game_stats",
+	columns: []column[store.PlayerGameStats]{
+		{"stat_id", func(s *store.PlayerGameStats) interface{} { return &s.ID }},
+		{"game_id", func(s *store.PlayerGameStats) interface{} { return &s.GameID }},
+		{"player_id", func(s *store.PlayerGameStats) interface{} { return &s.PlayerID }},
+		{"team_id", func(s *store.PlayerGameStats) interface{} { return &s.TeamID }},
+		{"points", func(s *store.PlayerGameStats) interface{} { return &s.Points }},
+		{"rebounds", func(s *store.PlayerGameStats) interface{} { return &s.Rebounds }},
+		{"assists", func(s *store.PlayerGameStats) interface{} { return &s.Assists }},
+		{"steals", func(s *store.PlayerGameStats) interface{} { return &s.Steals }},
+		{"blocks", func(s *store.PlayerGameStats) interface{} { return &s.Blocks }},
+		{"turnovers", func(s *store.PlayerGameStats) interface{} { return &s.Turnovers }},
+		{"field_goals_made", func(s *store.PlayerGameStats) interface{} { return &s.FieldGoalsMade }},
+		{"field_goals_attempted", func(s *store.PlayerGameStats) interface{} { return &s.FieldGoalsAttempted }},
+		{"three_pointers_made", func(s *store.PlayerGameStats) interface{} { return &s.ThreePointersMade }},
+		{"three_pointers_attempted", func(s *store.PlayerGameStats) interface{} { return &s.ThreePointersAttempted }},
+		{"free_throws_made", func(s *store.PlayerGameStats) interface{} { return &s.FreeThrowsMade }},
+		{"free_throws_attempted", func(s *store.PlayerGameStats) interface{} { return &s.FreeThrowsAttempted }},
+		{"offensive_rebounds", func(s *store.PlayerGameStats) interface{} { return &s.OffensiveRebounds }},
+		{"defensive_rebounds", func(s *store.PlayerGameStats) interface{} { return &s.DefensiveRebounds }},
+		{"personal_fouls", func(s *store.PlayerGameStats) interface{} { return &s.PersonalFouls }},
+		{"technical_fouls", func(s *store.PlayerGameStats) interface{} { return &s.TechnicalFouls }},
+		{"flagrant_fouls", func(s *store.PlayerGameStats) interface{} { return &s.FlagrantFouls }},
+		{"minutes_played", func(s *store.PlayerGameStats) interface{} { return &s.MinutesPlayed }},
+		{"seconds_played", func(s *store.PlayerGameStats) interface{} { return &s.SecondsPlayed }},
+		{"plus_minus", func(s *store.PlayerGameStats) interface{} { return &s.PlusMinus }},
+		{"starter", func(s *store.PlayerGameStats) interface{} { return &s.Starter }},
+		{"true_shooting_pct", func(s *store.PlayerGameStats) interface{} { return &s.TrueShootingPct }},
+		{"effective_fg_pct", func(s *store.PlayerGameStats) interface{} { return &s.EffectiveFGPct }},
+		{"usage_rate", func(s *store.PlayerGameStats) interface{} { return &s.UsageRate }},
+		{"created_at", func(s *store.PlayerGameStats) interface{} { return &s.CreatedAt }},
+		{"updated_at", func(s *store.PlayerGameStats) interface{} { return &s.UpdatedAt }},
+		{"corrected", func(s *store.PlayerGameStats) interface{} { return &s.Corrected }},
+	},
+}
+
 // GetPlayerGameStats returns stats for a player in a specific game
 func (r *StatsRepository) GetPlayerGameStats(ctx context.Context, gameID string, playerID int) (*store.PlayerGameStats, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT id, game_id, player_id, team_id, points, rebounds, assists, steals, blocks, turnovers,
-			field_goals_made, field_goals_attempted, three_pointers_made, three_pointers_attempted,
-			free_throws_made, free_throws_attempted, offensive_rebounds, defensive_rebounds,
-			personal_fouls, minutes_played, plus_minus, starter, true_shooting_pct, effective_fg_pct,
-			usage_pct, created_at, updated_at
-		FROM player_game_stats
-		WHERE game_id = $1 AND player_id = $2
+		SELECT ` + playerStatsColumns.list("pgs") + `
+		FROM player_game_stats pgs
+		JOIN games g ON g.game_id = pgs.game_id
+		WHERE g.external_id = $1 AND pgs.player_id = $2 AND pgs.deleted_at IS NULL
 	`
 
-	stats := &store.PlayerGameStats{}
-	err := r.db.DB().QueryRowContext(ctx, query, gameID, playerID).Scan(
-		&stats.ID, &stats.GameID, &stats.PlayerID, &stats.TeamID, &stats.Points, &stats.Rebounds,
-		&stats.Assists, &stats.Steals, &stats.Blocks, &stats.Turnovers, &stats.FieldGoalsMade,
-		&stats.FieldGoalsAttempted, &stats.ThreePointersMade, &stats.ThreePointersAttempted,
-		&stats.FreeThrowsMade, &stats.FreeThrowsAttempted, &stats.OffensiveRebounds,
-		&stats.DefensiveRebounds, &stats.PersonalFouls, &stats.MinutesPlayed, &stats.PlusMinus,
-		&stats.Starter, &stats.TrueShootingPct, &stats.EffectiveFGPct, &stats.UsageRate,
-		&stats.CreatedAt, &stats.UpdatedAt,
-	)
-
+	stats, err := playerStatsColumns.scan(r.db.ReadDB(ctx).QueryRowContext(ctx, query, gameID, playerID))
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("stats not found for game %s, player %d", gameID, playerID)
+		return nil, store.NotFound("stats for game %s, player %d", gameID, playerID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("querying player stats: %w", err)
 	}
+	stats.FillMinutes()
 
 	return stats, nil
 }
 
 // GetGameBoxScore returns all player stats for a game
 func (r *StatsRepository) GetGameBoxScore(ctx context.Context, gameID string) ([]*store.PlayerGameStats, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT id, game_id, player_id, team_id, points, rebounds, assists, steals, blocks, turnovers,
-			field_goals_made, field_goals_attempted, three_pointers_made, three_pointers_attempted,
-			free_throws_made, free_throws_attempted, offensive_rebounds, defensive_rebounds,
-			personal_fouls, minutes_played, plus_minus, starter, true_shooting_pct, effective_fg_pct,
-			usage_pct, created_at, updated_at
+		SELECT ` + playerStatsColumns.list("pgs") + `
+		FROM player_game_stats pgs
+		JOIN games g ON g.game_id = pgs.game_id
+		WHERE g.external_id = $1 AND pgs.deleted_at IS NULL
+		ORDER BY pgs.starter DESC, pgs.minutes_played DESC
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("querying box score: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanPlayerStats(ctx, rows)
+}
+
+// GetByGameID returns all player stat lines for a game by its database ID, top scorers first
+func (r *StatsRepository) GetByGameID(ctx context.Context, gameID int) ([]*store.PlayerGameStats, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + playerStatsColumns.list("") + `
 		FROM player_game_stats
+		WHERE game_id = $1 AND deleted_at IS NULL
+		ORDER BY points DESC, minutes_played DESC NULLS LAST
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("querying game stats: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanPlayerStats(ctx, rows)
+}
+
+// GetTeamStatsByGameID returns both teams' box score totals for a game
+func (r *StatsRepository) GetTeamStatsByGameID(ctx context.Context, gameID int) ([]*store.TeamGameStats, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT stat_id, game_id, team_id, is_home, points,
+			field_goals_made, field_goals_attempted, three_pointers_made, three_pointers_attempted,
+			free_throws_made, free_throws_attempted, offensive_rebounds, defensive_rebounds, rebounds,
+			assists, steals, blocks, turnovers, personal_fouls,
+			technical_fouls, flagrant_fouls, fast_break_points, points_in_paint,
+			points_off_turnovers, second_chance_points, bench_points, largest_lead, created_at, updated_at
+		FROM team_game_stats
 		WHERE game_id = $1
-		ORDER BY starter DESC, minutes_played DESC
+		ORDER BY is_home DESC
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, gameID)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, gameID)
 	if err != nil {
-		return nil, fmt.Errorf("querying box score: %w", err)
+		return nil, fmt.Errorf("querying team game stats: %w", err)
 	}
 	defer rows.Close()
 
-	return r.scanPlayerStats(rows)
+	var teams []*store.TeamGameStats
+	for rows.Next() {
+		t := &store.TeamGameStats{}
+		if err := rows.Scan(
+			&t.ID, &t.GameID, &t.TeamID, &t.IsHome, &t.Points,
+			&t.FieldGoalsMade, &t.FieldGoalsAttempted, &t.ThreePointersMade, &t.ThreePointersAttempted,
+			&t.FreeThrowsMade, &t.FreeThrowsAttempted, &t.OffensiveRebounds, &t.DefensiveRebounds, &t.Rebounds,
+			&t.Assists, &t.Steals, &t.Blocks, &t.Turnovers, &t.PersonalFouls,
+			&t.TechnicalFouls, &t.FlagrantFouls, &t.FastBreakPoints, &t.PointsInPaint,
+			&t.PointsOffTurnovers, &t.SecondChancePoints, &t.BenchPoints, &t.LargestLead, &t.CreatedAt, &t.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning team game stats: %w", err)
+		}
+		teams = append(teams, t)
+	}
+
+	return teams, rows.Err()
+}
+
+// SumPlayerStatsByTeam totals each team's player stat lines for a game, as a
+// stand-in for team box score rows ESPN no longer serves. Team rebounds and
+// team turnovers are not credited to players, so those totals can run low.
+func (r *StatsRepository) SumPlayerStatsByTeam(ctx context.Context, gameID int) ([]*store.TeamGameStats, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT p.game_id, p.team_id, p.team_id = g.home_team_id AS is_home,
+			COALESCE(SUM(p.points), 0),
+			COALESCE(SUM(p.field_goals_made), 0), COALESCE(SUM(p.field_goals_attempted), 0),
+			COALESCE(SUM(p.three_pointers_made), 0), COALESCE(SUM(p.three_pointers_attempted), 0),
+			COALESCE(SUM(p.free_throws_made), 0), COALESCE(SUM(p.free_throws_attempted), 0),
+			COALESCE(SUM(p.offensive_rebounds), 0), COALESCE(SUM(p.defensive_rebounds), 0), COALESCE(SUM(p.rebounds), 0),
+			COALESCE(SUM(p.assists), 0), COALESCE(SUM(p.steals), 0), COALESCE(SUM(p.blocks), 0),
+			COALESCE(SUM(p.turnovers), 0), COALESCE(SUM(p.personal_fouls), 0)
+		FROM player_game_stats p
+		JOIN games g ON g.game_id = p.game_id
+		WHERE p.game_id = $1 AND p.deleted_at IS NULL
+			AND p.team_id IN (g.home_team_id, g.away_team_id)
+		GROUP BY p.game_id, p.team_id, g.home_team_id
+		ORDER BY is_home DESC
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("summing player stats by team: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []*store.TeamGameStats
+	for rows.Next() {
+		t := &store.TeamGameStats{}
+		if err := rows.Scan(
+			&t.GameID, &t.TeamID, &t.IsHome, &t.Points,
+			&t.FieldGoalsMade, &t.FieldGoalsAttempted, &t.ThreePointersMade, &t.ThreePointersAttempted,
+			&t.FreeThrowsMade, &t.FreeThrowsAttempted, &t.OffensiveRebounds, &t.DefensiveRebounds, &t.Rebounds,
+			&t.Assists, &t.Steals, &t.Blocks, &t.Turnovers, &t.PersonalFouls,
+		); err != nil {
+			return nil, fmt.Errorf("scanning summed team stats: %w", err)
+		}
+		teams = append(teams, t)
+	}
+
+	return teams, rows.Err()
+}
+
+// GetPointsHighBefore returns the player's highest-scoring game played before the given date.
+// The result is invalid when the player has no earlier games on record.
+func (r *StatsRepository) GetPointsHighBefore(ctx context.Context, playerID int, before time.Time) (sql.NullInt32, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT MAX(pgs.points)
+		FROM player_game_stats pgs
+		JOIN games g ON pgs.game_id = g.game_id
+		WHERE pgs.player_id = $1 AND pgs.deleted_at IS NULL AND g.status = 'final' AND g.game_date < $2
+	`
+
+	var high sql.NullInt32
+	if err := r.db.ReadDB(ctx).QueryRowContext(ctx, query, playerID, before).Scan(&high); err != nil {
+		return high, fmt.Errorf("querying points high: %w", err)
+	}
+	return high, nil
 }
 
 // EnrichedPlayerStats includes player game stats with game context (date, opponent)
@@ -84,60 +246,73 @@ type EnrichedPlayerStats struct {
 	HomeScore      int    `json:"home_score"`
 	AwayScore      int    `json:"away_score"`
 	Result         string `json:"result"` // "W" or "L"
+
+	OvertimePeriods int               `json:"overtime_periods"`
+	GameMinutes     int               `json:"game_minutes"`          // regulation plus 5 per overtime
+	Possessions     store.NullFloat64 `json:"possessions,omitempty"` // on-court estimate; null without team totals
+	Rates           *store.StatRates  `json:"rates,omitempty"`       // set when the request asks for ?per=per36 or per100
 }
 
 // GetPlayerRecentStats returns a player's stats for their last N games
 func (r *StatsRepository) GetPlayerRecentStats(ctx context.Context, playerID int, limit int) ([]*store.PlayerGameStats, error) {
+	return r.GetPlayerRecentStatsBefore(ctx, playerID, limit, time.Time{})
+}
+
+// GetPlayerRecentStatsBefore is GetPlayerRecentStats over games dated strictly
+// before before, for features that must not see the game being predicted. A
+// zero before covers every game.
+func (r *StatsRepository) GetPlayerRecentStatsBefore(ctx context.Context, playerID int, limit int, before time.Time) ([]*store.PlayerGameStats, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT pgs.stat_id, pgs.game_id, pgs.player_id, pgs.team_id, pgs.points, pgs.rebounds, pgs.assists,
-			pgs.steals, pgs.blocks, pgs.turnovers, pgs.field_goals_made, pgs.field_goals_attempted,
-			pgs.three_pointers_made, pgs.three_pointers_attempted, pgs.free_throws_made,
-			pgs.free_throws_attempted, pgs.offensive_rebounds, pgs.defensive_rebounds,
-			pgs.personal_fouls, pgs.minutes_played, pgs.plus_minus, pgs.starter,
-			pgs.true_shooting_pct, pgs.effective_fg_pct, pgs.usage_rate, pgs.created_at, pgs.updated_at
+		SELECT ` + playerStatsColumns.list("pgs") + `
 		FROM player_game_stats pgs
 		JOIN games g ON pgs.game_id = g.game_id
-		WHERE pgs.player_id = $1 AND g.status = 'final'
+		WHERE pgs.player_id = $1 AND pgs.deleted_at IS NULL AND g.status = 'final'
+			AND ($3::date IS NULL OR g.game_date < $3::date)
 		ORDER BY g.game_date DESC
 		LIMIT $2
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, playerID, limit)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, playerID, limit, dateBound(before))
 	if err != nil {
 		return nil, fmt.Errorf("querying recent stats: %w", err)
 	}
 	defer rows.Close()
 
-	return r.scanPlayerStats(rows)
+	return r.scanPlayerStats(ctx, rows)
 }
 
+// playerRecentStatsEnrichedQuery is shared with HotQueryPlans
+var playerRecentStatsEnrichedQuery = `
+	SELECT ` + playerStatsColumns.list("pgs") + `,
+		g.game_date,
+		g.home_team_id, g.away_team_id,
+		COALESCE(g.home_score, 0) as home_score,
+		COALESCE(g.away_score, 0) as away_score,
+		CASE WHEN pgs.team_id = g.home_team_id THEN g.away_team_id ELSE g.home_team_id END as opponent_team_id,
+		CASE WHEN pgs.team_id = g.home_team_id THEN true ELSE false END as is_home,
+		opp.abbreviation as opponent_abbr,
+		opp.full_name as opponent_name,
+		g.overtime_periods, g.game_minutes,
+		` + onCourtPossessionsExpr + ` as possessions
+	FROM player_game_stats pgs
+	JOIN games g ON pgs.game_id = g.game_id
+	LEFT JOIN teams opp ON opp.team_id = CASE WHEN pgs.team_id = g.home_team_id THEN g.away_team_id ELSE g.home_team_id END
+	LEFT JOIN team_game_stats t ON t.game_id = pgs.game_id AND t.team_id = pgs.team_id
+	LEFT JOIN team_game_stats o ON o.game_id = pgs.game_id AND o.team_id <> pgs.team_id
+	WHERE pgs.player_id = $1 AND pgs.deleted_at IS NULL AND g.status = 'final'
+	ORDER BY g.game_date DESC
+	LIMIT $2
+`
+
 // GetPlayerRecentStatsEnriched returns a player's stats with full game context
 func (r *StatsRepository) GetPlayerRecentStatsEnriched(ctx context.Context, playerID int, limit int) ([]*EnrichedPlayerStats, error) {
-	query := `
-		SELECT 
-			pgs.stat_id, pgs.game_id, pgs.player_id, pgs.team_id, pgs.points, pgs.rebounds, pgs.assists,
-			pgs.steals, pgs.blocks, pgs.turnovers, pgs.field_goals_made, pgs.field_goals_attempted,
-			pgs.three_pointers_made, pgs.three_pointers_attempted, pgs.free_throws_made,
-			pgs.free_throws_attempted, pgs.offensive_rebounds, pgs.defensive_rebounds,
-			pgs.personal_fouls, pgs.minutes_played, pgs.plus_minus, pgs.starter,
-			pgs.true_shooting_pct, pgs.effective_fg_pct, pgs.usage_rate, pgs.created_at, pgs.updated_at,
-			g.game_date,
-			g.home_team_id, g.away_team_id,
-			COALESCE(g.home_score, 0) as home_score,
-			COALESCE(g.away_score, 0) as away_score,
-			CASE WHEN pgs.team_id = g.home_team_id THEN g.away_team_id ELSE g.home_team_id END as opponent_team_id,
-			CASE WHEN pgs.team_id = g.home_team_id THEN true ELSE false END as is_home,
-			opp.abbreviation as opponent_abbr,
-			opp.full_name as opponent_name
-		FROM player_game_stats pgs
-		JOIN games g ON pgs.game_id = g.game_id
-		LEFT JOIN teams opp ON opp.team_id = CASE WHEN pgs.team_id = g.home_team_id THEN g.away_team_id ELSE g.home_team_id END
-		WHERE pgs.player_id = $1 AND g.status = 'final'
-		ORDER BY g.game_date DESC
-		LIMIT $2
-	`
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
 
-	rows, err := r.db.DB().QueryContext(ctx, query, playerID, limit)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, playerRecentStatsEnrichedQuery, playerID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("querying enriched stats: %w", err)
 	}
@@ -151,23 +326,18 @@ func (r *StatsRepository) GetPlayerRecentStatsEnriched(ctx context.Context, play
 		var homeTeamID, awayTeamID int
 		var oppAbbr, oppName sql.NullString
 
-		err := rows.Scan(
-			&stats.ID, &stats.GameID, &stats.PlayerID, &stats.TeamID, &stats.Points, &stats.Rebounds,
-			&stats.Assists, &stats.Steals, &stats.Blocks, &stats.Turnovers, &stats.FieldGoalsMade,
-			&stats.FieldGoalsAttempted, &stats.ThreePointersMade, &stats.ThreePointersAttempted,
-			&stats.FreeThrowsMade, &stats.FreeThrowsAttempted, &stats.OffensiveRebounds,
-			&stats.DefensiveRebounds, &stats.PersonalFouls, &stats.MinutesPlayed, &stats.PlusMinus,
-			&stats.Starter, &stats.TrueShootingPct, &stats.EffectiveFGPct, &stats.UsageRate,
-			&stats.CreatedAt, &stats.UpdatedAt,
+		err := rows.Scan(playerStatsColumns.dest(stats,
 			&gameDate,
 			&homeTeamID, &awayTeamID,
 			&enriched.HomeScore, &enriched.AwayScore,
 			&enriched.OpponentTeamID, &enriched.IsHome,
 			&oppAbbr, &oppName,
-		)
+			&enriched.OvertimePeriods, &enriched.GameMinutes, &enriched.Possessions,
+		)...)
 		if err != nil {
 			return nil, fmt.Errorf("scanning enriched stats: %w", err)
 		}
+		stats.FillMinutes()
 
 		if gameDate.Valid {
 			enriched.GameDate = gameDate.Time.Format("2006-01-02")
@@ -198,34 +368,53 @@ func (r *StatsRepository) GetPlayerRecentStatsEnriched(ctx context.Context, play
 	return allStats, rows.Err()
 }
 
+// playerSeasonAveragesQuery is shared with HotQueryPlans
+const playerSeasonAveragesQuery = `
+	SELECT
+		COUNT(*) as games_played,
+		AVG(points) as ppg,
+		AVG(rebounds) as rpg,
+		AVG(assists) as apg,
+		AVG(steals) as spg,
+		AVG(blocks) as bpg,
+		AVG(turnovers) as tpg,
+		AVG(COALESCE(seconds_played / 60.0, minutes_played)) as mpg,
+		SUM(field_goals_made)::float / NULLIF(SUM(field_goals_attempted), 0) as fg_pct,
+		SUM(three_pointers_made)::float / NULLIF(SUM(three_pointers_attempted), 0) as three_pct,
+		SUM(free_throws_made)::float / NULLIF(SUM(free_throws_attempted), 0) as ft_pct,
+		COUNT(*) FILTER (WHERE g.overtime_periods > 0) as overtime_games
+	FROM player_game_stats pgs
+	JOIN games g ON pgs.game_id = g.game_id
+	JOIN seasons s ON g.season_id = s.season_id
+	WHERE pgs.player_id = $1 AND pgs.deleted_at IS NULL AND s.season_year = $2 AND g.status = 'final'
+		AND g.league = 'nba' AND g.game_type <> ALL($3)
+		AND ($4::date IS NULL OR g.game_date < $4::date)
+`
+
 // GetPlayerSeasonAverages calculates a player's season averages
-// seasonYear is a string like "2024-25" which maps to a season_id in the seasons table
-func (r *StatsRepository) GetPlayerSeasonAverages(ctx context.Context, playerID int, seasonYear string) (map[string]float64, error) {
-	query := `
-		SELECT
-			COUNT(*) as games_played,
-			AVG(points) as ppg,
-			AVG(rebounds) as rpg,
-			AVG(assists) as apg,
-			AVG(steals) as spg,
-			AVG(blocks) as bpg,
-			AVG(turnovers) as tpg,
-			AVG(minutes_played) as mpg,
-			SUM(field_goals_made)::float / NULLIF(SUM(field_goals_attempted), 0) as fg_pct,
-			SUM(three_pointers_made)::float / NULLIF(SUM(three_pointers_attempted), 0) as three_pct,
-			SUM(free_throws_made)::float / NULLIF(SUM(free_throws_attempted), 0) as ft_pct
-		FROM player_game_stats pgs
-		JOIN games g ON pgs.game_id = g.game_id
-		JOIN seasons s ON g.season_id = s.season_id
-		WHERE pgs.player_id = $1 AND s.season_year = $2 AND g.status = 'final'
-	`
+// seasonYear is a string like "2024-25" which maps to a season_id in the seasons table.
+// Games whose game_type is in excludedTypes (usually store.SpecialGameTypes) are skipped.
+func (r *StatsRepository) GetPlayerSeasonAverages(ctx context.Context, playerID int, seasonYear string, excludedTypes []string) (map[string]float64, error) {
+	return r.GetPlayerSeasonAveragesBefore(ctx, playerID, seasonYear, excludedTypes, time.Time{})
+}
+
+// GetPlayerSeasonAveragesBefore is GetPlayerSeasonAverages over games dated
+// strictly before before; a zero before covers the whole season
+func (r *StatsRepository) GetPlayerSeasonAveragesBefore(ctx context.Context, playerID int, seasonYear string, excludedTypes []string, before time.Time) (map[string]float64, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	if excludedTypes == nil {
+		excludedTypes = []string{}
+	}
 
 	var gamesPlayed int
 	var ppg, rpg, apg, spg, bpg, tpg, mpg sql.NullFloat64
 	var fgPct, threePct, ftPct sql.NullFloat64
+	var overtimeGames int
 
-	err := r.db.DB().QueryRowContext(ctx, query, playerID, seasonYear).Scan(
-		&gamesPlayed, &ppg, &rpg, &apg, &spg, &bpg, &tpg, &mpg, &fgPct, &threePct, &ftPct,
+	err := r.db.ReadDB(ctx).QueryRowContext(ctx, playerSeasonAveragesQuery, playerID, seasonYear, pq.Array(excludedTypes), dateBound(before)).Scan(
+		&gamesPlayed, &ppg, &rpg, &apg, &spg, &bpg, &tpg, &mpg, &fgPct, &threePct, &ftPct, &overtimeGames,
 	)
 
 	if err != nil {
@@ -233,7 +422,8 @@ func (r *StatsRepository) GetPlayerSeasonAverages(ctx context.Context, playerID
 	}
 
 	averages := map[string]float64{
-		"games_played": float64(gamesPlayed),
+		"games_played":   float64(gamesPlayed),
+		"overtime_games": float64(overtimeGames),
 	}
 
 	if ppg.Valid {
@@ -270,15 +460,624 @@ func (r *StatsRepository) GetPlayerSeasonAverages(ctx context.Context, playerID
 	return averages, nil
 }
 
-// UpsertPlayerStats inserts or updates player game stats
-func (r *StatsRepository) UpsertPlayerStats(ctx context.Context, stats *store.PlayerGameStats) error {
+// PlayedSeason is a player with the season of a game they played in
+type PlayedSeason struct {
+	PlayerID   int
+	SeasonYear string
+}
+
+// GetPlayerSeasonsOnDate returns each player with a stat line in a final game
+// on date, with that game's season
+func (r *StatsRepository) GetPlayerSeasonsOnDate(ctx context.Context, sport string, date time.Time) ([]PlayedSeason, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT DISTINCT pgs.player_id, s.season_year
+		FROM player_game_stats pgs
+		JOIN games g ON pgs.game_id = g.game_id
+		JOIN seasons s ON g.season_id = s.season_id
+		WHERE g.sport = $1 AND g.game_date::date = $2::date AND g.status = 'final'
+			AND pgs.deleted_at IS NULL
+		ORDER BY pgs.player_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, sport, date.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("querying players on date: %w", err)
+	}
+	defer rows.Close()
+
+	var players []PlayedSeason
+	for rows.Next() {
+		var p PlayedSeason
+		if err := rows.Scan(&p.PlayerID, &p.SeasonYear); err != nil {
+			return nil, fmt.Errorf("scanning player season: %w", err)
+		}
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
+
+// onCourtPossessionsExpr estimates the possessions a player was on the floor for:
+// the game's average team possessions scaled by the player's share of game time.
+// Expects pgs, g, and the player's (t) and opponent's (o) team_game_stats.
+const onCourtPossessionsExpr = `(t.possessions + o.possessions) / 2.0 * pgs.seconds_played / (g.game_minutes * 60.0)`
+
+// playerTotalsColumns sums a player's stat lines into store.StatTotals order
+// (see scanStatTotals), joined as pgs with their game g and the team totals t
+// and o of the player's team and its opponent
+const playerTotalsColumns = `
+			COUNT(*),
+			COUNT(*) FILTER (WHERE g.overtime_periods > 0),
+			COALESCE(SUM(pgs.seconds_played), 0),
+			COALESCE(SUM(` + onCourtPossessionsExpr + `), 0)::float,
+			COALESCE(SUM(pgs.points), 0),
+			COALESCE(SUM(pgs.rebounds), 0),
+			COALESCE(SUM(pgs.offensive_rebounds), 0),
+			COALESCE(SUM(pgs.defensive_rebounds), 0),
+			COALESCE(SUM(pgs.assists), 0),
+			COALESCE(SUM(pgs.steals), 0),
+			COALESCE(SUM(pgs.blocks), 0),
+			COALESCE(SUM(pgs.turnovers), 0),
+			COALESCE(SUM(pgs.three_pointers_made), 0),
+			COALESCE(SUM(pgs.three_pointers_attempted), 0),
+			COALESCE(SUM(pgs.field_goals_made), 0),
+			COALESCE(SUM(pgs.field_goals_attempted), 0),
+			COALESCE(SUM(pgs.free_throws_made), 0),
+			COALESCE(SUM(pgs.free_throws_attempted), 0)`
+
+// scanStatTotals reads a row of playerTotalsColumns or teamTotalsColumns
+func scanStatTotals(row *sql.Row) (*store.StatTotals, error) {
+	totals := &store.StatTotals{}
+	err := row.Scan(
+		&totals.Games, &totals.OvertimeGames, &totals.Seconds, &totals.Possessions,
+		&totals.Points, &totals.Rebounds, &totals.OffensiveRebounds, &totals.DefensiveRebounds,
+		&totals.Assists, &totals.Steals, &totals.Blocks, &totals.Turnovers,
+		&totals.ThreePointersMade, &totals.ThreePointersAtt,
+		&totals.FieldGoalsMade, &totals.FieldGoalsAtt, &totals.FreeThrowsMade, &totals.FreeThrowsAtt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// GetPlayerSeasonTotals sums a player's counting stats, seconds played, and
+// on-court possessions over a season, for per-36 and per-100 rates. Filters
+// match GetPlayerSeasonAverages.
+func (r *StatsRepository) GetPlayerSeasonTotals(ctx context.Context, playerID int, seasonYear string, excludedTypes []string) (*store.StatTotals, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	if excludedTypes == nil {
+		excludedTypes = []string{}
+	}
+
+	query := `
+		SELECT` + playerTotalsColumns + `
+		FROM player_game_stats pgs
+		JOIN games g ON pgs.game_id = g.game_id
+		JOIN seasons s ON g.season_id = s.season_id
+		LEFT JOIN team_game_stats t ON t.game_id = pgs.game_id AND t.team_id = pgs.team_id
+		LEFT JOIN team_game_stats o ON o.game_id = pgs.game_id AND o.team_id <> pgs.team_id
+		WHERE pgs.player_id = $1 AND pgs.deleted_at IS NULL AND s.season_year = $2 AND g.status = 'final'
+			AND g.league = 'nba' AND g.game_type <> ALL($3)
+	`
+
+	totals, err := scanStatTotals(r.db.ReadDB(ctx).QueryRowContext(ctx, query, playerID, seasonYear, pq.Array(excludedTypes)))
+	if err != nil {
+		return nil, fmt.Errorf("querying season totals: %w", err)
+	}
+	return totals, nil
+}
+
+// playerRangeTotalsQuery sums a player's final NBA games dated from $2 through
+// $3, skipping game types in $4. Served by the player covering index; the date
+// bound is checked on each game by primary key.
+const playerRangeTotalsQuery = `
+	SELECT` + playerTotalsColumns + `
+	FROM player_game_stats pgs
+	JOIN games g ON pgs.game_id = g.game_id
+	LEFT JOIN team_game_stats t ON t.game_id = pgs.game_id AND t.team_id = pgs.team_id
+	LEFT JOIN team_game_stats o ON o.game_id = pgs.game_id AND o.team_id <> pgs.team_id
+	WHERE pgs.player_id = $1 AND pgs.deleted_at IS NULL AND g.status = 'final'
+		AND g.game_date BETWEEN $2::date AND $3::date
+		AND g.league = 'nba' AND g.game_type <> ALL($4)
+`
+
+// GetPlayerTotalsBetween sums a player's counting stats, seconds played, and
+// on-court possessions over final games dated from through to, inclusive.
+// Games whose game_type is in excludedTypes are skipped.
+func (r *StatsRepository) GetPlayerTotalsBetween(ctx context.Context, playerID int, from, to time.Time, excludedTypes []string) (*store.StatTotals, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	if excludedTypes == nil {
+		excludedTypes = []string{}
+	}
+
+	row := r.db.ReadDB(ctx).QueryRowContext(ctx, playerRangeTotalsQuery,
+		playerID, dateBound(from), dateBound(to), pq.Array(excludedTypes))
+	totals, err := scanStatTotals(row)
+	if err != nil {
+		return nil, fmt.Errorf("querying player totals: %w", err)
+	}
+	return totals, nil
+}
+
+// teamRangeTotalsQuery sums a team's final NBA games dated from $2 through $3,
+// skipping game types in $4. Playing time is the game's length and possessions
+// the average of both teams' estimates.
+const teamRangeTotalsQuery = `
+	SELECT
+		COUNT(*),
+		COUNT(*) FILTER (WHERE g.overtime_periods > 0),
+		COALESCE(SUM(g.game_minutes * 60), 0),
+		COALESCE(SUM((t.possessions + o.possessions) / 2.0), 0)::float,
+		COALESCE(SUM(t.points), 0),
+		COALESCE(SUM(t.rebounds), 0),
+		COALESCE(SUM(t.offensive_rebounds), 0),
+		COALESCE(SUM(t.defensive_rebounds), 0),
+		COALESCE(SUM(t.assists), 0),
+		COALESCE(SUM(t.steals), 0),
+		COALESCE(SUM(t.blocks), 0),
+		COALESCE(SUM(t.turnovers), 0),
+		COALESCE(SUM(t.three_pointers_made), 0),
+		COALESCE(SUM(t.three_pointers_attempted), 0),
+		COALESCE(SUM(t.field_goals_made), 0),
+		COALESCE(SUM(t.field_goals_attempted), 0),
+		COALESCE(SUM(t.free_throws_made), 0),
+		COALESCE(SUM(t.free_throws_attempted), 0)
+	FROM team_game_stats t
+	JOIN games g ON t.game_id = g.game_id
+	LEFT JOIN team_game_stats o ON o.game_id = t.game_id AND o.team_id <> t.team_id
+	WHERE t.team_id = $1 AND g.status = 'final'
+		AND g.game_date BETWEEN $2::date AND $3::date
+		AND g.league = 'nba' AND g.game_type <> ALL($4)
+`
+
+// GetTeamTotalsBetween sums a team's counting stats, game time, and possessions
+// over final games dated from through to, inclusive. Games whose game_type is
+// in excludedTypes are skipped.
+func (r *StatsRepository) GetTeamTotalsBetween(ctx context.Context, teamID int, from, to time.Time, excludedTypes []string) (*store.StatTotals, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	if excludedTypes == nil {
+		excludedTypes = []string{}
+	}
+
+	row := r.db.ReadDB(ctx).QueryRowContext(ctx, teamRangeTotalsQuery,
+		teamID, dateBound(from), dateBound(to), pq.Array(excludedTypes))
+	totals, err := scanStatTotals(row)
+	if err != nil {
+		return nil, fmt.Errorf("querying team totals: %w", err)
+	}
+	return totals, nil
+}
+
+// GetCareerSeasons returns a player's per-season totals across every stored season, oldest first.
+// seasonType filters to 'regular' or 'playoffs'; empty includes all season types.
+// Only final games in which the player logged minutes count; special event games never do.
+func (r *StatsRepository) GetCareerSeasons(ctx context.Context, playerID int, seasonType string) ([]*store.CareerTotals, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			s.season_year,
+			COALESCE(s.season_type, 'regular'),
+			COUNT(*),
+			COUNT(*) FILTER (WHERE pgs.starter),
+			COALESCE(SUM(COALESCE(pgs.seconds_played / 60.0, pgs.minutes_played)), 0)::float,
+			COALESCE(SUM(pgs.points), 0),
+			COALESCE(SUM(pgs.rebounds), 0),
+			COALESCE(SUM(pgs.offensive_rebounds), 0),
+			COALESCE(SUM(pgs.defensive_rebounds), 0),
+			COALESCE(SUM(pgs.assists), 0),
+			COALESCE(SUM(pgs.steals), 0),
+			COALESCE(SUM(pgs.blocks), 0),
+			COALESCE(SUM(pgs.turnovers), 0),
+			COALESCE(SUM(pgs.personal_fouls), 0),
+			COALESCE(SUM(pgs.field_goals_made), 0),
+			COALESCE(SUM(pgs.field_goals_attempted), 0),
+			COALESCE(SUM(pgs.three_pointers_made), 0),
+			COALESCE(SUM(pgs.three_pointers_attempted), 0),
+			COALESCE(SUM(pgs.free_throws_made), 0),
+			COALESCE(SUM(pgs.free_throws_attempted), 0)
+		FROM player_game_stats pgs
+		JOIN games g ON pgs.game_id = g.game_id
+		JOIN seasons s ON g.season_id = s.season_id
+		WHERE pgs.player_id = $1 AND pgs.deleted_at IS NULL
+			AND g.status = 'final'
+			AND COALESCE(pgs.minutes_played, 0) > 0
+			AND ($2 = '' OR COALESCE(s.season_type, 'regular') = $2)
+			AND g.league = 'nba' AND g.game_type <> ALL($3)
+		GROUP BY s.season_year, COALESCE(s.season_type, 'regular'), s.start_date
+		ORDER BY s.start_date, s.season_year
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, playerID, seasonType, pq.Array(store.SpecialGameTypes))
+	if err != nil {
+		return nil, fmt.Errorf("querying career seasons: %w", err)
+	}
+	defer rows.Close()
+
+	var seasons []*store.CareerTotals
+	for rows.Next() {
+		t := &store.CareerTotals{}
+		err := rows.Scan(
+			&t.SeasonYear, &t.SeasonType, &t.GamesPlayed, &t.GamesStarted, &t.Minutes,
+			&t.Points, &t.Rebounds, &t.OffensiveRebounds, &t.DefensiveRebounds,
+			&t.Assists, &t.Steals, &t.Blocks, &t.Turnovers, &t.PersonalFouls,
+			&t.FieldGoalsMade, &t.FieldGoalsAttempted,
+			&t.ThreePointersMade, &t.ThreePointersAttempted,
+			&t.FreeThrowsMade, &t.FreeThrowsAttempted,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning career season: %w", err)
+		}
+		seasons = append(seasons, t)
+	}
+
+	return seasons, rows.Err()
+}
+
+// GetCareerHighs returns the player's best single game for each counting stat.
+// Ties go to the earliest game; special event games are ignored.
+func (r *StatsRepository) GetCareerHighs(ctx context.Context, playerID int, seasonType string) ([]*store.CareerHigh, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT DISTINCT ON (v.stat) v.stat, v.value, COALESCE(g.external_id, ''), g.game_date
+		FROM player_game_stats pgs
+		JOIN games g ON pgs.game_id = g.game_id
+		JOIN seasons s ON g.season_id = s.season_id
+		CROSS JOIN LATERAL (VALUES
+			('points', pgs.points),
+			('rebounds', pgs.rebounds),
+			('assists', pgs.assists),
+			('steals', pgs.steals),
+			('blocks', pgs.blocks),
+			('three_pointers_made', pgs.three_pointers_made)
+		) AS v(stat, value)
+		WHERE pgs.player_id = $1 AND pgs.deleted_at IS NULL
+			AND g.status = 'final'
+			AND v.value IS NOT NULL
+			AND ($2 = '' OR COALESCE(s.season_type, 'regular') = $2)
+			AND g.league = 'nba' AND g.game_type <> ALL($3)
+		ORDER BY v.stat, v.value DESC, g.game_date
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, playerID, seasonType, pq.Array(store.SpecialGameTypes))
+	if err != nil {
+		return nil, fmt.Errorf("querying career highs: %w", err)
+	}
+	defer rows.Close()
+
+	var highs []*store.CareerHigh
+	for rows.Next() {
+		h := &store.CareerHigh{}
+		if err := rows.Scan(&h.Stat, &h.Value, &h.GameID, &h.GameDate); err != nil {
+			return nil, fmt.Errorf("scanning career high: %w", err)
+		}
+		highs = append(highs, h)
+	}
+
+	return highs, rows.Err()
+}
+
+// GetDraftClass returns a draft year's picks in overall order, each with the
+// player's NBA regular-season career totals. Picks without an overall number
+// sort last.
+func (r *StatsRepository) GetDraftClass(ctx context.Context, sport string, year int) ([]*store.DraftPick, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + playerColumns.list("p") + `,
+			COALESCE(dt.abbreviation, ''),
+			c.games, c.starts, c.minutes, c.points, c.rebounds, c.offensive_rebounds, c.defensive_rebounds,
+			c.assists, c.steals, c.blocks, c.turnovers, c.personal_fouls,
+			c.fgm, c.fga, c.tpm, c.tpa, c.ftm, c.fta
+		FROM players p
+		LEFT JOIN teams dt ON dt.team_id = p.draft_team_id
+		CROSS JOIN LATERAL (
+			SELECT
+				COUNT(*) AS games,
+				COUNT(*) FILTER (WHERE pgs.starter) AS starts,
+				COALESCE(SUM(COALESCE(pgs.seconds_played / 60.0, pgs.minutes_played)), 0)::float AS minutes,
+				COALESCE(SUM(pgs.points), 0) AS points,
+				COALESCE(SUM(pgs.rebounds), 0) AS rebounds,
+				COALESCE(SUM(pgs.offensive_rebounds), 0) AS offensive_rebounds,
+				COALESCE(SUM(pgs.defensive_rebounds), 0) AS defensive_rebounds,
+				COALESCE(SUM(pgs.assists), 0) AS assists,
+				COALESCE(SUM(pgs.steals), 0) AS steals,
+				COALESCE(SUM(pgs.blocks), 0) AS blocks,
+				COALESCE(SUM(pgs.turnovers), 0) AS turnovers,
+				COALESCE(SUM(pgs.personal_fouls), 0) AS personal_fouls,
+				COALESCE(SUM(pgs.field_goals_made), 0) AS fgm,
+				COALESCE(SUM(pgs.field_goals_attempted), 0) AS fga,
+				COALESCE(SUM(pgs.three_pointers_made), 0) AS tpm,
+				COALESCE(SUM(pgs.three_pointers_attempted), 0) AS tpa,
+				COALESCE(SUM(pgs.free_throws_made), 0) AS ftm,
+				COALESCE(SUM(pgs.free_throws_attempted), 0) AS fta
+			FROM player_game_stats pgs
+			JOIN games g ON pgs.game_id = g.game_id
+			JOIN seasons s ON g.season_id = s.season_id
+			WHERE pgs.player_id = p.player_id AND pgs.deleted_at IS NULL
+				AND g.status = 'final'
+				AND COALESCE(pgs.minutes_played, 0) > 0
+				AND COALESCE(s.season_type, 'regular') = 'regular'
+				AND g.league = 'nba' AND g.game_type <> ALL($3)
+		) c
+		WHERE p.sport = $1 AND p.draft_year = $2
+		ORDER BY p.draft_pick NULLS LAST, p.draft_round NULLS LAST, p.full_name
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, sport, year, pq.Array(store.SpecialGameTypes))
+	if err != nil {
+		return nil, fmt.Errorf("querying draft class: %w", err)
+	}
+	defer rows.Close()
+
+	var picks []*store.DraftPick
+	for rows.Next() {
+		player := &store.Player{}
+		t := &store.CareerTotals{}
+		pick := &store.DraftPick{DraftYear: year, Player: player, Career: t}
+		err := rows.Scan(playerColumns.dest(player,
+			&pick.DraftTeamAbbr,
+			&t.GamesPlayed, &t.GamesStarted, &t.Minutes, &t.Points, &t.Rebounds, &t.OffensiveRebounds, &t.DefensiveRebounds,
+			&t.Assists, &t.Steals, &t.Blocks, &t.Turnovers, &t.PersonalFouls,
+			&t.FieldGoalsMade, &t.FieldGoalsAttempted,
+			&t.ThreePointersMade, &t.ThreePointersAttempted,
+			&t.FreeThrowsMade, &t.FreeThrowsAttempted,
+		)...)
+		if err != nil {
+			return nil, fmt.Errorf("scanning draft pick: %w", err)
+		}
+		pick.Round = int(player.DraftRound.Int32)
+		pick.Pick = int(player.DraftPick.Int32)
+		pick.DraftTeamID = player.DraftTeamID
+		picks = append(picks, pick)
+	}
+
+	return picks, rows.Err()
+}
+
+// TeamGameLog is one team's box score line joined with its opponent's in the same game
+type TeamGameLog struct {
+	GameID         string `json:"game_id"` // external (ESPN) ID
+	GameDate       string `json:"game_date"`
+	GameType       string `json:"game_type"`
+	IsHome         bool   `json:"is_home"`
+	OpponentTeamID int    `json:"opponent_team_id"`
+	OpponentAbbr   string `json:"opponent_abbr"`
+	Result         string `json:"result"` // "W" or "L"
+
+	Points         int `json:"points"`
+	PointsAllowed  int `json:"points_allowed"`
+	Margin         int `json:"margin"`
+	Rebounds       int `json:"rebounds"`
+	OppRebounds    int `json:"opponent_rebounds"`
+	ReboundMargin  int `json:"rebound_margin"`
+	Assists        int `json:"assists"`
+	Turnovers      int `json:"turnovers"`
+	OppTurnovers   int `json:"opponent_turnovers"`
+	Steals         int `json:"steals"`
+	Blocks         int `json:"blocks"`
+	FieldGoalsMade int `json:"field_goals_made"`
+	FieldGoalsAtt  int `json:"field_goals_attempted"`
+	ThreesMade     int `json:"three_pointers_made"`
+	ThreesAtt      int `json:"three_pointers_attempted"`
+	FreeThrowsMade int `json:"free_throws_made"`
+	FreeThrowsAtt  int `json:"free_throws_attempted"`
+
+	EffectiveFGPct    *float64          `json:"effective_fg_pct,omitempty"`
+	OppEffectiveFGPct *float64          `json:"opponent_effective_fg_pct,omitempty"`
+	TrueShootingPct   *float64          `json:"true_shooting_pct,omitempty"`
+	Pace              store.NullFloat64 `json:"pace,omitempty"`
+	OffensiveRating   store.NullFloat64 `json:"offensive_rating,omitempty"`
+	DefensiveRating   store.NullFloat64 `json:"defensive_rating,omitempty"`
+
+	// Scoring breakdowns, null for games ESPN did not report them for
+	PointsInPaint      store.NullInt32 `json:"points_in_paint,omitempty"`
+	FastBreakPoints    store.NullInt32 `json:"fast_break_points,omitempty"`
+	PointsOffTurnovers store.NullInt32 `json:"points_off_turnovers,omitempty"`
+	SecondChancePoints store.NullInt32 `json:"second_chance_points,omitempty"`
+	BenchPoints        store.NullInt32 `json:"bench_points,omitempty"`
+	LargestLead        store.NullInt32 `json:"largest_lead,omitempty"`
+}
+
+// teamGameLogsQuery is shared with HotQueryPlans
+const teamGameLogsQuery = `
+	SELECT
+		COALESCE(g.external_id, ''), g.game_date, g.game_type, t.is_home,
+		o.team_id, COALESCE(opp.abbreviation, ''),
+		COALESCE(t.points, 0), COALESCE(o.points, 0),
+		COALESCE(t.rebounds, 0), COALESCE(o.rebounds, 0),
+		COALESCE(t.assists, 0), COALESCE(t.turnovers, 0), COALESCE(o.turnovers, 0),
+		COALESCE(t.steals, 0), COALESCE(t.blocks, 0),
+		COALESCE(t.field_goals_made, 0), COALESCE(t.field_goals_attempted, 0),
+		COALESCE(t.three_pointers_made, 0), COALESCE(t.three_pointers_attempted, 0),
+		COALESCE(t.free_throws_made, 0), COALESCE(t.free_throws_attempted, 0),
+		COALESCE(o.field_goals_made, 0), COALESCE(o.field_goals_attempted, 0),
+		COALESCE(o.three_pointers_made, 0),
+		t.pace, t.offensive_rating, t.defensive_rating,
+		t.points_in_paint, t.fast_break_points, t.points_off_turnovers,
+		t.second_chance_points, t.bench_points, t.largest_lead
+	FROM team_game_stats t
+	JOIN team_game_stats o ON o.game_id = t.game_id AND o.team_id <> t.team_id
+	JOIN games g ON t.game_id = g.game_id
+	JOIN seasons s ON g.season_id = s.season_id
+	LEFT JOIN teams opp ON opp.team_id = o.team_id
+	WHERE t.team_id = $1 AND s.season_year = $2 AND g.status = 'final' AND g.league = 'nba'
+	ORDER BY g.game_date DESC
+`
+
+// GetTeamGameLogs returns a team's final games for a season year (e.g. "2024-25"),
+// most recent first, each joined with the opponent's team_game_stats row
+func (r *StatsRepository) GetTeamGameLogs(ctx context.Context, teamID int, seasonYear string) ([]*TeamGameLog, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, teamGameLogsQuery, teamID, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("querying team game logs: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []*TeamGameLog
+	for rows.Next() {
+		l := &TeamGameLog{}
+		var gameDate time.Time
+		var oppFGM, oppFGA, opp3PM int
+
+		err := rows.Scan(
+			&l.GameID, &gameDate, &l.GameType, &l.IsHome,
+			&l.OpponentTeamID, &l.OpponentAbbr,
+			&l.Points, &l.PointsAllowed,
+			&l.Rebounds, &l.OppRebounds,
+			&l.Assists, &l.Turnovers, &l.OppTurnovers,
+			&l.Steals, &l.Blocks,
+			&l.FieldGoalsMade, &l.FieldGoalsAtt,
+			&l.ThreesMade, &l.ThreesAtt,
+			&l.FreeThrowsMade, &l.FreeThrowsAtt,
+			&oppFGM, &oppFGA, &opp3PM,
+			&l.Pace, &l.OffensiveRating, &l.DefensiveRating,
+			&l.PointsInPaint, &l.FastBreakPoints, &l.PointsOffTurnovers,
+			&l.SecondChancePoints, &l.BenchPoints, &l.LargestLead,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning team game log: %w", err)
+		}
+
+		l.GameDate = gameDate.Format("2006-01-02")
+		l.Margin = l.Points - l.PointsAllowed
+		l.ReboundMargin = l.Rebounds - l.OppRebounds
+		if l.Margin > 0 {
+			l.Result = "W"
+		} else {
+			l.Result = "L"
+		}
+		l.EffectiveFGPct = effectiveFGPct(l.FieldGoalsMade, l.ThreesMade, l.FieldGoalsAtt)
+		l.OppEffectiveFGPct = effectiveFGPct(oppFGM, opp3PM, oppFGA)
+		if tsa := float64(l.FieldGoalsAtt) + 0.44*float64(l.FreeThrowsAtt); tsa > 0 {
+			ts := float64(l.Points) / (2 * tsa)
+			l.TrueShootingPct = &ts
+		}
+
+		logs = append(logs, l)
+	}
+
+	return logs, rows.Err()
+}
+
+// UnitLine is the combined production of a team's starters or bench in one game
+type UnitLine struct {
+	Players   int     `json:"players"` // players who logged minutes
+	Minutes   float64 `json:"minutes"`
+	Points    int     `json:"points"`
+	Rebounds  int     `json:"rebounds"`
+	Assists   int     `json:"assists"`
+	PlusMinus int     `json:"plus_minus"`
+}
+
+// TeamUnitSplit is one game's starter and bench production for a team
+type TeamUnitSplit struct {
+	GameID       string   `json:"game_id"` // external (ESPN) ID
+	GameDate     string   `json:"game_date"`
+	IsHome       bool     `json:"is_home"`
+	OpponentAbbr string   `json:"opponent_abbr"`
+	Starters     UnitLine `json:"starters"`
+	Bench        UnitLine `json:"bench"`
+}
+
+// GetTeamUnitSplits returns starter and bench totals per final game for a season
+// year (e.g. "2024-25"), most recent first, from player_game_stats starter flags
+func (r *StatsRepository) GetTeamUnitSplits(ctx context.Context, teamID int, seasonYear string) ([]*TeamUnitSplit, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			COALESCE(g.external_id, ''), g.game_date, g.home_team_id = $1, COALESCE(opp.abbreviation, ''),
+			COUNT(*) FILTER (WHERE pgs.starter AND pgs.minutes_played > 0),
+			COALESCE(SUM(pgs.minutes_played) FILTER (WHERE pgs.starter), 0),
+			COALESCE(SUM(pgs.points) FILTER (WHERE pgs.starter), 0),
+			COALESCE(SUM(pgs.rebounds) FILTER (WHERE pgs.starter), 0),
+			COALESCE(SUM(pgs.assists) FILTER (WHERE pgs.starter), 0),
+			COALESCE(SUM(pgs.plus_minus) FILTER (WHERE pgs.starter), 0),
+			COUNT(*) FILTER (WHERE NOT pgs.starter AND pgs.minutes_played > 0),
+			COALESCE(SUM(pgs.minutes_played) FILTER (WHERE NOT pgs.starter), 0),
+			COALESCE(SUM(pgs.points) FILTER (WHERE NOT pgs.starter), 0),
+			COALESCE(SUM(pgs.rebounds) FILTER (WHERE NOT pgs.starter), 0),
+			COALESCE(SUM(pgs.assists) FILTER (WHERE NOT pgs.starter), 0),
+			COALESCE(SUM(pgs.plus_minus) FILTER (WHERE NOT pgs.starter), 0)
+		FROM player_game_stats pgs
+		JOIN games g ON pgs.game_id = g.game_id
+		JOIN seasons s ON g.season_id = s.season_id
+		LEFT JOIN teams opp ON opp.team_id = CASE WHEN g.home_team_id = $1 THEN g.away_team_id ELSE g.home_team_id END
+		WHERE pgs.team_id = $1 AND s.season_year = $2 AND g.status = 'final' AND g.league = 'nba' AND pgs.deleted_at IS NULL
+		GROUP BY g.game_id, g.external_id, g.game_date, g.home_team_id, opp.abbreviation
+		ORDER BY g.game_date DESC, g.game_id DESC
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, teamID, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("querying team unit splits: %w", err)
+	}
+	defer rows.Close()
+
+	var splits []*TeamUnitSplit
+	for rows.Next() {
+		sp := &TeamUnitSplit{}
+		var gameDate time.Time
+		err := rows.Scan(
+			&sp.GameID, &gameDate, &sp.IsHome, &sp.OpponentAbbr,
+			&sp.Starters.Players, &sp.Starters.Minutes, &sp.Starters.Points,
+			&sp.Starters.Rebounds, &sp.Starters.Assists, &sp.Starters.PlusMinus,
+			&sp.Bench.Players, &sp.Bench.Minutes, &sp.Bench.Points,
+			&sp.Bench.Rebounds, &sp.Bench.Assists, &sp.Bench.PlusMinus,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning team unit split: %w", err)
+		}
+		sp.GameDate = gameDate.Format("2006-01-02")
+		splits = append(splits, sp)
+	}
+
+	return splits, rows.Err()
+}
+
+// effectiveFGPct is (FGM + 0.5 * 3PM) / FGA, or nil without attempts
+func effectiveFGPct(fgm, threes, fga int) *float64 {
+	if fga == 0 {
+		return nil
+	}
+	v := (float64(fgm) + 0.5*float64(threes)) / float64(fga)
+	return &v
+}
+
+// UpsertPlayerStats inserts or updates player game stats.
+// Rows whose content hash already matches are left untouched, as are rows an operator
+// has corrected or deleted: manual corrections win over later automated ingestion.
+// exec is the caller's transaction, or nil for the primary pool.
+func (r *StatsRepository) UpsertPlayerStats(ctx context.Context, exec Querier, stats *store.PlayerGameStats) (store.UpsertResult, error) {
+	if exec == nil {
+		exec = r.db.DB()
+	}
 	query := `
 		INSERT INTO player_game_stats (game_id, player_id, team_id, points, rebounds, assists,
 			steals, blocks, turnovers, field_goals_made, field_goals_attempted,
 			three_pointers_made, three_pointers_attempted, free_throws_made, free_throws_attempted,
 			offensive_rebounds, defensive_rebounds, personal_fouls, minutes_played, plus_minus,
-			starter, true_shooting_pct, effective_fg_pct, usage_rate)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
+			starter, true_shooting_pct, effective_fg_pct, usage_rate, content_hash, seconds_played,
+			technical_fouls, flagrant_fouls)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28)
 		ON CONFLICT (game_id, player_id) DO UPDATE SET
 			team_id = EXCLUDED.team_id,
 			points = EXCLUDED.points,
@@ -296,47 +1095,65 @@ func (r *StatsRepository) UpsertPlayerStats(ctx context.Context, stats *store.Pl
 			offensive_rebounds = EXCLUDED.offensive_rebounds,
 			defensive_rebounds = EXCLUDED.defensive_rebounds,
 			personal_fouls = EXCLUDED.personal_fouls,
+			technical_fouls = EXCLUDED.technical_fouls,
+			flagrant_fouls = EXCLUDED.flagrant_fouls,
 			minutes_played = EXCLUDED.minutes_played,
+			seconds_played = EXCLUDED.seconds_played,
 			plus_minus = EXCLUDED.plus_minus,
 			starter = EXCLUDED.starter,
 			true_shooting_pct = EXCLUDED.true_shooting_pct,
 			effective_fg_pct = EXCLUDED.effective_fg_pct,
 			usage_rate = EXCLUDED.usage_rate,
+			content_hash = EXCLUDED.content_hash,
 			updated_at = NOW()
-		RETURNING stat_id
+		WHERE player_game_stats.content_hash IS DISTINCT FROM EXCLUDED.content_hash
+			AND NOT player_game_stats.corrected
+		RETURNING stat_id, (xmax = 0) AS inserted
 	`
 
-	err := r.db.DB().QueryRowContext(ctx, query,
+	var inserted bool
+	err := exec.QueryRowContext(ctx, query,
 		stats.GameID, stats.PlayerID, stats.TeamID, stats.Points, stats.Rebounds, stats.Assists,
 		stats.Steals, stats.Blocks, stats.Turnovers, stats.FieldGoalsMade, stats.FieldGoalsAttempted,
 		stats.ThreePointersMade, stats.ThreePointersAttempted, stats.FreeThrowsMade, stats.FreeThrowsAttempted,
 		stats.OffensiveRebounds, stats.DefensiveRebounds, stats.PersonalFouls, stats.MinutesPlayed, stats.PlusMinus,
-		stats.Starter, stats.TrueShootingPct, stats.EffectiveFGPct, stats.UsageRate,
-	).Scan(&stats.ID)
+		stats.Starter, stats.TrueShootingPct, stats.EffectiveFGPct, stats.UsageRate, stats.ContentHash(),
+		stats.SecondsPlayed, stats.TechnicalFouls, stats.FlagrantFouls,
+	).Scan(&stats.ID, &inserted)
 
+	if err == sql.ErrNoRows {
+		// Conflict with an identical hash or a corrected row: nothing was
+		// written, fetch the existing ID
+		err = exec.QueryRowContext(ctx,
+			`SELECT stat_id FROM player_game_stats WHERE game_id = $1 AND player_id = $2`,
+			stats.GameID, stats.PlayerID,
+		).Scan(&stats.ID)
+		if err != nil {
+			return store.UpsertUnchanged, fmt.Errorf("looking up unchanged player stats: %w", err)
+		}
+		return store.UpsertUnchanged, nil
+	}
 	if err != nil {
-		return fmt.Errorf("upserting player stats: %w", err)
+		return store.UpsertUnchanged, fmt.Errorf("upserting player stats: %w", err)
 	}
+	notifyEntityWrite(store.EntityPlayerStats, stats.PlayerID)
 
-	return nil
+	if inserted {
+		return store.UpsertInserted, nil
+	}
+	return store.UpsertUpdated, nil
 }
 
-// scanPlayerStats scans multiple player stats rows
-func (r *StatsRepository) scanPlayerStats(rows *sql.Rows) ([]*store.PlayerGameStats, error) {
+// scanPlayerStats scans multiple player stats rows, stopping when ctx is done
+func (r *StatsRepository) scanPlayerStats(ctx context.Context, rows *sql.Rows) ([]*store.PlayerGameStats, error) {
 	var allStats []*store.PlayerGameStats
 	for rows.Next() {
-		stats := &store.PlayerGameStats{}
-		err := rows.Scan(
-			&stats.ID, &stats.GameID, &stats.PlayerID, &stats.TeamID, &stats.Points, &stats.Rebounds,
-			&stats.Assists, &stats.Steals, &stats.Blocks, &stats.Turnovers, &stats.FieldGoalsMade,
-			&stats.FieldGoalsAttempted, &stats.ThreePointersMade, &stats.ThreePointersAttempted,
-			&stats.FreeThrowsMade, &stats.FreeThrowsAttempted, &stats.OffensiveRebounds,
-			&stats.DefensiveRebounds, &stats.PersonalFouls, &stats.MinutesPlayed, &stats.PlusMinus,
-			&stats.Starter, &stats.TrueShootingPct, &stats.EffectiveFGPct, &stats.UsageRate,
-			&stats.CreatedAt, &stats.UpdatedAt,
-		)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		stats, err := scanPlayerStatsRow(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scanning player stats: %w", err)
+			return nil, err
 		}
 		allStats = append(allStats, stats)
 	}
@@ -344,8 +1161,124 @@ func (r *StatsRepository) scanPlayerStats(rows *sql.Rows) ([]*store.PlayerGameSt
 	return allStats, rows.Err()
 }
 
-// UpsertTeamStats inserts or updates team game stats
-func (r *StatsRepository) UpsertTeamStats(ctx context.Context, stats *store.TeamGameStats) error {
+// scanPlayerStatsRow scans the current row of a query selecting playerStatsColumns
+func scanPlayerStatsRow(rows *sql.Rows) (*store.PlayerGameStats, error) {
+	stats, err := playerStatsColumns.scan(rows)
+	if err != nil {
+		return nil, fmt.Errorf("scanning player stats: %w", err)
+	}
+	stats.FillMinutes()
+	return stats, nil
+}
+
+// seasonPlayerStatsQuery is shared with HotQueryPlans
+var seasonPlayerStatsQuery = `
+	SELECT ` + playerStatsColumns.list("pgs") + `
+	FROM player_game_stats pgs
+	JOIN games g ON pgs.game_id = g.game_id
+	WHERE g.season_id = $1 AND g.status = 'final' AND pgs.deleted_at IS NULL
+	ORDER BY g.game_date, pgs.game_id, pgs.player_id
+`
+
+// StreamSeasonPlayerStats calls fn for every player stat line in a season's final games,
+// ordered by game then player. Like GameRepository.StreamBySeason, rows are pulled only
+// as fast as fn consumes them, and iteration stops when ctx is done.
+func (r *StatsRepository) StreamSeasonPlayerStats(ctx context.Context, seasonID int, fn func(*store.PlayerGameStats) error) error {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, seasonPlayerStatsQuery, seasonID)
+	if err != nil {
+		return fmt.Errorf("querying season player stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		stats, err := scanPlayerStatsRow(rows)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(stats); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// dateBound binds an optional date cutoff, NULL when unset
+func dateBound(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format("2006-01-02")
+}
+
+// TeamGameTotals is one team's scoring and estimated possessions in a final game,
+// next to its opponent's
+type TeamGameTotals struct {
+	GameID         int
+	TeamID         int
+	OpponentTeamID int
+	GameMinutes    int
+	Points         int
+	PointsAllowed  int
+	Possessions    float64
+	OppPossessions float64
+}
+
+// GetSeasonTeamTotals returns every team_game_stats row of a season's final
+// games that has its opponent's row too, with possessions estimated as
+// FGA + 0.44 * FTA - ORB + TOV. Reading stops when ctx is done.
+func (r *StatsRepository) GetSeasonTeamTotals(ctx context.Context, seasonID int) ([]*TeamGameTotals, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT t.game_id, t.team_id, o.team_id, g.game_minutes, t.points, o.points,
+			t.field_goals_attempted + 0.44 * t.free_throws_attempted - t.offensive_rebounds + t.turnovers,
+			o.field_goals_attempted + 0.44 * o.free_throws_attempted - o.offensive_rebounds + o.turnovers
+		FROM team_game_stats t
+		JOIN team_game_stats o ON o.game_id = t.game_id AND o.team_id <> t.team_id
+		JOIN games g ON g.game_id = t.game_id
+		WHERE g.season_id = $1 AND g.status = 'final'
+		ORDER BY g.game_date, t.game_id, t.team_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("querying season team totals: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []*TeamGameTotals
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		t := &TeamGameTotals{}
+		if err := rows.Scan(
+			&t.GameID, &t.TeamID, &t.OpponentTeamID, &t.GameMinutes, &t.Points, &t.PointsAllowed,
+			&t.Possessions, &t.OppPossessions,
+		); err != nil {
+			return nil, fmt.Errorf("scanning season team totals: %w", err)
+		}
+		totals = append(totals, t)
+	}
+
+	return totals, rows.Err()
+}
+
+// UpsertTeamStats inserts or updates team game stats.
+// Rows whose content hash already matches are left untouched. exec is the
+// caller's transaction, or nil for the primary pool.
+func (r *StatsRepository) UpsertTeamStats(ctx context.Context, exec Querier, stats *store.TeamGameStats) (store.UpsertResult, error) {
+	if exec == nil {
+		exec = r.db.DB()
+	}
 	query := `
 		INSERT INTO team_game_stats (
 			game_id, team_id, is_home, points,
@@ -353,9 +1286,12 @@ func (r *StatsRepository) UpsertTeamStats(ctx context.Context, stats *store.Team
 			three_pointers_made, three_pointers_attempted,
 			free_throws_made, free_throws_attempted,
 			offensive_rebounds, defensive_rebounds, rebounds,
-			assists, steals, blocks, turnovers, personal_fouls
+			assists, steals, blocks, turnovers, personal_fouls, content_hash,
+			technical_fouls, flagrant_fouls, fast_break_points, points_in_paint,
+			points_off_turnovers, second_chance_points, bench_points, largest_lead
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23,
+			$24, $25, $26, $27)
 		ON CONFLICT (game_id, team_id) DO UPDATE SET
 			is_home = EXCLUDED.is_home,
 			points = EXCLUDED.points,
@@ -373,22 +1309,81 @@ func (r *StatsRepository) UpsertTeamStats(ctx context.Context, stats *store.Team
 			blocks = EXCLUDED.blocks,
 			turnovers = EXCLUDED.turnovers,
 			personal_fouls = EXCLUDED.personal_fouls,
+			technical_fouls = EXCLUDED.technical_fouls,
+			flagrant_fouls = EXCLUDED.flagrant_fouls,
+			fast_break_points = EXCLUDED.fast_break_points,
+			points_in_paint = EXCLUDED.points_in_paint,
+			points_off_turnovers = EXCLUDED.points_off_turnovers,
+			second_chance_points = EXCLUDED.second_chance_points,
+			bench_points = EXCLUDED.bench_points,
+			largest_lead = EXCLUDED.largest_lead,
+			content_hash = EXCLUDED.content_hash,
 			updated_at = NOW()
-		RETURNING stat_id
+		WHERE team_game_stats.content_hash IS DISTINCT FROM EXCLUDED.content_hash
+		RETURNING stat_id, (xmax = 0) AS inserted
 	`
 
-	err := r.db.DB().QueryRowContext(ctx, query,
+	var inserted bool
+	err := exec.QueryRowContext(ctx, query,
 		stats.GameID, stats.TeamID, stats.IsHome, stats.Points,
 		stats.FieldGoalsMade, stats.FieldGoalsAttempted,
 		stats.ThreePointersMade, stats.ThreePointersAttempted,
 		stats.FreeThrowsMade, stats.FreeThrowsAttempted,
 		stats.OffensiveRebounds, stats.DefensiveRebounds, stats.Rebounds,
-		stats.Assists, stats.Steals, stats.Blocks, stats.Turnovers, stats.PersonalFouls,
-	).Scan(&stats.ID)
+		stats.Assists, stats.Steals, stats.Blocks, stats.Turnovers, stats.PersonalFouls, stats.ContentHash(),
+		stats.TechnicalFouls, stats.FlagrantFouls, stats.FastBreakPoints, stats.PointsInPaint,
+		stats.PointsOffTurnovers, stats.SecondChancePoints, stats.BenchPoints, stats.LargestLead,
+	).Scan(&stats.ID, &inserted)
 
+	if err == sql.ErrNoRows {
+		// Conflict with an identical hash: nothing was written, fetch the existing ID
+		err = exec.QueryRowContext(ctx,
+			`SELECT stat_id FROM team_game_stats WHERE game_id = $1 AND team_id = $2`,
+			stats.GameID, stats.TeamID,
+		).Scan(&stats.ID)
+		if err != nil {
+			return store.UpsertUnchanged, fmt.Errorf("looking up unchanged team stats: %w", err)
+		}
+		return store.UpsertUnchanged, nil
+	}
 	if err != nil {
-		return fmt.Errorf("upserting team stats: %w", err)
+		return store.UpsertUnchanged, fmt.Errorf("upserting team stats: %w", err)
 	}
 
+	if inserted {
+		return store.UpsertInserted, nil
+	}
+	return store.UpsertUpdated, nil
+}
+
+// UpdateGamePace estimates each team's possessions (FGA + 0.44 * FTA - ORB + TOV)
+// for a game and, once it is final with both teams' totals stored, the pace:
+// average possessions per 48 minutes of game time, so overtime doesn't inflate it.
+// Rows that already hold these values are left untouched.
+func (r *StatsRepository) UpdateGamePace(ctx context.Context, gameID int) error {
+	query := `
+		WITH est AS (
+			SELECT team_id,
+				ROUND(field_goals_attempted + 0.44 * free_throws_attempted - offensive_rebounds + turnovers)::integer AS possessions
+			FROM team_game_stats
+			WHERE game_id = $1
+		), paced AS (
+			SELECT e.team_id, e.possessions,
+				CASE WHEN g.status = 'final' AND COUNT(e.possessions) OVER () = 2
+					THEN ROUND(48.0 * AVG(e.possessions) OVER () / g.game_minutes, 2)
+				END AS pace
+			FROM est e
+			JOIN games g ON g.game_id = $1
+		)
+		UPDATE team_game_stats t
+		SET possessions = p.possessions, pace = p.pace
+		FROM paced p
+		WHERE t.game_id = $1 AND t.team_id = p.team_id
+			AND (t.possessions, t.pace) IS DISTINCT FROM (p.possessions, p.pace)
+	`
+
+	if _, err := r.db.DB().ExecContext(ctx, query, gameID); err != nil {
+		return fmt.Errorf("updating pace for game %d: %w", gameID, err)
+	}
 	return nil
 }
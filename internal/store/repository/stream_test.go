@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// rowLimit bounds a stub result set, so a loop that ignores cancellation ends
+// (and fails its test) instead of reading forever
+const rowLimit = 1000
+
+// stubConnector serves every query the same row, up to rowLimit times, and
+// calls onRow after each row it hands out
+type stubConnector struct {
+	row    []driver.Value
+	onRow  func(served int64)
+	served atomic.Int64
+}
+
+func (c *stubConnector) Connect(context.Context) (driver.Conn, error) { return &stubConn{c}, nil }
+func (c *stubConnector) Driver() driver.Driver                        { return stubDriver{} }
+
+type stubDriver struct{}
+
+func (stubDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("open through the connector")
+}
+
+type stubConn struct{ c *stubConnector }
+
+func (c *stubConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *stubConn) Close() error                        { return nil }
+func (c *stubConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *stubConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &stubRows{c.c}, nil
+}
+
+type stubRows struct{ c *stubConnector }
+
+func (r *stubRows) Columns() []string {
+	names := make([]string, len(r.c.row))
+	for i := range names {
+		names[i] = "c"
+	}
+	return names
+}
+
+func (r *stubRows) Close() error { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	served := r.c.served.Load()
+	if served >= rowLimit {
+		return io.EOF
+	}
+	copy(dest, r.c.row)
+	served = r.c.served.Add(1)
+	if r.c.onRow != nil {
+		r.c.onRow(served)
+	}
+	return nil
+}
+
+// stubRow returns driver values that scan into dest: NULL for Scanners and
+// pointers, a fixed value of the destination's kind otherwise
+func stubRow(dest []interface{}) []driver.Value {
+	row := make([]driver.Value, len(dest))
+	for i, d := range dest {
+		if _, ok := d.(sql.Scanner); ok {
+			continue
+		}
+		switch v := reflect.ValueOf(d).Elem(); v.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			row[i] = int64(1)
+		case reflect.Float64:
+			row[i] = 1.5
+		case reflect.String:
+			row[i] = "x"
+		case reflect.Bool:
+			row[i] = true
+		case reflect.Struct:
+			if _, ok := v.Interface().(time.Time); ok {
+				row[i] = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+			}
+		}
+	}
+	return row
+}
+
+// cancelAfter returns a database serving row and a context that is cancelled,
+// as a disconnecting client would, once n rows have been read
+func cancelAfter(t *testing.T, row []driver.Value, n int64) (*store.Database, *stubConnector, context.Context) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	connector := &stubConnector{row: row}
+	connector.onRow = func(served int64) {
+		if served == n {
+			cancel()
+		}
+	}
+	db := store.NewDatabaseFromDB(sql.OpenDB(connector))
+	t.Cleanup(func() { db.Close() })
+	return db, connector, ctx
+}
+
+// checkStopped fails t unless err is the cancellation and at most one row was
+// read past the cancelling one
+func checkStopped(t *testing.T, err error, connector *stubConnector, n int64) {
+	t.Helper()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if served := connector.served.Load(); served > n+1 {
+		t.Fatalf("read %d rows after cancelling at %d", served, n)
+	}
+}
+
+func TestStreamBySeasonStopsOnCancel(t *testing.T) {
+	db, connector, ctx := cancelAfter(t, stubRow(gameColumns.dest(&store.Game{})), 3)
+
+	calls := 0
+	err := NewGameRepository(db).StreamBySeason(ctx, 1, func(*store.Game) error {
+		calls++
+		return nil
+	})
+	checkStopped(t, err, connector, 3)
+	if calls > 2 {
+		t.Fatalf("fn called %d times, want at most 2 before the cancelling row", calls)
+	}
+}
+
+func TestStreamSeasonPlayerStatsStopsOnCancel(t *testing.T) {
+	db, connector, ctx := cancelAfter(t, stubRow(playerStatsColumns.dest(&store.PlayerGameStats{})), 3)
+
+	calls := 0
+	err := NewStatsRepository(db).StreamSeasonPlayerStats(ctx, 1, func(*store.PlayerGameStats) error {
+		calls++
+		return nil
+	})
+	checkStopped(t, err, connector, 3)
+	if calls > 2 {
+		t.Fatalf("fn called %d times, want at most 2 before the cancelling row", calls)
+	}
+}
+
+func TestGetPlayerRecentStatsStopsOnCancel(t *testing.T) {
+	db, connector, ctx := cancelAfter(t, stubRow(playerStatsColumns.dest(&store.PlayerGameStats{})), 3)
+
+	stats, err := NewStatsRepository(db).GetPlayerRecentStats(ctx, 1, rowLimit)
+	checkStopped(t, err, connector, 3)
+	if stats != nil {
+		t.Fatalf("returned %d lines from a cancelled read", len(stats))
+	}
+}
+
+func TestGetSeasonTeamTotalsStopsOnCancel(t *testing.T) {
+	var tt TeamGameTotals
+	row := stubRow([]interface{}{
+		&tt.GameID, &tt.TeamID, &tt.OpponentTeamID, &tt.GameMinutes, &tt.Points, &tt.PointsAllowed,
+		&tt.Possessions, &tt.OppPossessions,
+	})
+	db, connector, ctx := cancelAfter(t, row, 3)
+
+	_, err := NewStatsRepository(db).GetSeasonTeamTotals(ctx, 1)
+	checkStopped(t, err, connector, 3)
+}
+
+func TestGetSeasonPositionsStopsOnCancel(t *testing.T) {
+	db, connector, ctx := cancelAfter(t, []driver.Value{int64(1), "G"}, 3)
+
+	_, err := NewPlayerRepository(db).GetSeasonPositions(ctx, 1)
+	checkStopped(t, err, connector, 3)
+}
+
+func TestGetClosingLinesStopsOnCancel(t *testing.T) {
+	var l store.GameClosingLine
+	row := stubRow([]interface{}{
+		&l.GameID, &l.GameDate, &l.HomeTeamID, &l.AwayTeamID, &l.HomeScore, &l.AwayScore,
+		&l.AlexandriaEventID, &l.HomeSpread, &l.Total, &l.Bookmaker,
+	})
+	db, connector, ctx := cancelAfter(t, row, 3)
+
+	_, err := NewOddsRepository(db).GetClosingLines(ctx, "basketball_nba", 0, "")
+	checkStopped(t, err, connector, 3)
+}
+
+func TestGetPositionalDefenseStopsOnCancel(t *testing.T) {
+	var d store.TeamPositionalDefense
+	row := stubRow([]interface{}{
+		&d.TeamID, &d.SeasonID, &d.Position, &d.Games,
+		&d.PointsPerGame, &d.ReboundsPerGame, &d.AssistsPerGame, &d.ThreesPerGame,
+		&d.FGPct, &d.PointsVsLeague,
+	})
+	db, connector, ctx := cancelAfter(t, row, 3)
+
+	_, err := NewPositionalDefenseRepository(db).GetBySeason(ctx, "2024-25", 0, "")
+	checkStopped(t, err, connector, 3)
+}
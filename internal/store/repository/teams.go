@@ -20,17 +20,50 @@ func NewTeamRepository(db *store.Database) *TeamRepository {
 
 // GetAll returns all NBA teams
 func (r *TeamRepository) GetAll(ctx context.Context) ([]*store.Team, error) {
+	return r.GetByLeague(ctx, store.LeagueNBA)
+}
+
+// teamColumns is the standard teams column list
+var teamColumns = &columnSet[store.Team]{
+	table: "teams",
+	columns: []column[store.Team]{
+		{"team_id", func(t *store.Team) interface{} { return &t.TeamID }},
+		{"sport", func(t *store.Team) interface{} { return &t.Sport }},
+		{"league", func(t *store.Team) interface{} { return &t.League }},
+		{"external_id", func(t *store.Team) interface{} { return &t.ExternalID }},
+		{"abbreviation", func(t *store.Team) interface{} { return &t.Abbreviation }},
+		{"full_name", func(t *store.Team) interface{} { return &t.FullName }},
+		{"short_name", func(t *store.Team) interface{} { return &t.ShortName }},
+		{"city", func(t *store.Team) interface{} { return &t.City }},
+		{"state", func(t *store.Team) interface{} { return &t.State }},
+		{"conference", func(t *store.Team) interface{} { return &t.Conference }},
+		{"division", func(t *store.Team) interface{} { return &t.Division }},
+		{"venue_name", func(t *store.Team) interface{} { return &t.VenueName }},
+		{"venue_capacity", func(t *store.Team) interface{} { return &t.VenueCapacity }},
+		{"founded_year", func(t *store.Team) interface{} { return &t.FoundedYear }},
+		{"logo_url", func(t *store.Team) interface{} { return &t.LogoURL }},
+		{"colors", func(t *store.Team) interface{} { return &t.Colors }},
+		{"social_media", func(t *store.Team) interface{} { return &t.SocialMedia }},
+		{"metadata", func(t *store.Team) interface{} { return &t.Metadata }},
+		{"is_active", func(t *store.Team) interface{} { return &t.IsActive }},
+		{"created_at", func(t *store.Team) interface{} { return &t.CreatedAt }},
+		{"updated_at", func(t *store.Team) interface{} { return &t.UpdatedAt }},
+	},
+}
+
+// GetByLeague returns a league's active teams
+func (r *TeamRepository) GetByLeague(ctx context.Context, league string) ([]*store.Team, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT team_id, sport, external_id, abbreviation, full_name, short_name, 
-			city, state, conference, division, venue_name, venue_capacity, 
-			founded_year, logo_url, colors, social_media, metadata, is_active,
-			created_at, updated_at
+		SELECT ` + teamColumns.list("") + `
 		FROM teams
-		WHERE is_active = true
+		WHERE is_active = true AND league = $1
 		ORDER BY abbreviation
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, league)
 	if err != nil {
 		return nil, fmt.Errorf("querying teams: %w", err)
 	}
@@ -38,14 +71,7 @@ func (r *TeamRepository) GetAll(ctx context.Context) ([]*store.Team, error) {
 
 	var teams []*store.Team
 	for rows.Next() {
-		team := &store.Team{}
-		err := rows.Scan(
-			&team.TeamID, &team.Sport, &team.ExternalID, &team.Abbreviation, 
-			&team.FullName, &team.ShortName, &team.City, &team.State,
-			&team.Conference, &team.Division, &team.VenueName, &team.VenueCapacity,
-			&team.FoundedYear, &team.LogoURL, &team.Colors, &team.SocialMedia, 
-			&team.Metadata, &team.IsActive, &team.CreatedAt, &team.UpdatedAt,
-		)
+		team, err := teamColumns.scan(rows)
 		if err != nil {
 			return nil, fmt.Errorf("scanning team: %w", err)
 		}
@@ -55,28 +81,61 @@ func (r *TeamRepository) GetAll(ctx context.Context) ([]*store.Team, error) {
 	return teams, rows.Err()
 }
 
+// GetAliases returns the team_aliases rows for a league
+func (r *TeamRepository) GetAliases(ctx context.Context, league string) ([]store.TeamAlias, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, `
+		SELECT league, abbreviation, alias
+		FROM team_aliases
+		WHERE league = $1
+		ORDER BY abbreviation, alias
+	`, league)
+	if err != nil {
+		return nil, fmt.Errorf("querying team aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []store.TeamAlias
+	for rows.Next() {
+		var a store.TeamAlias
+		if err := rows.Scan(&a.League, &a.Abbreviation, &a.Alias); err != nil {
+			return nil, fmt.Errorf("scanning team alias: %w", err)
+		}
+		aliases = append(aliases, a)
+	}
+	return aliases, rows.Err()
+}
+
+// GetResolver builds a team name resolver from a league's active teams and aliases
+func (r *TeamRepository) GetResolver(ctx context.Context, league string) (*store.TeamResolver, error) {
+	teams, err := r.GetByLeague(ctx, league)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := r.GetAliases(ctx, league)
+	if err != nil {
+		return nil, err
+	}
+	return store.NewTeamResolver(teams, aliases), nil
+}
+
 // GetByID finds a team by ID
 func (r *TeamRepository) GetByID(ctx context.Context, teamID int) (*store.Team, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT team_id, sport, external_id, abbreviation, full_name, short_name, 
-			city, state, conference, division, venue_name, venue_capacity, 
-			founded_year, logo_url, colors, social_media, metadata, is_active,
-			created_at, updated_at
+		SELECT ` + teamColumns.list("") + `
 		FROM teams
 		WHERE team_id = $1
 	`
 
-	team := &store.Team{}
-	err := r.db.DB().QueryRowContext(ctx, query, teamID).Scan(
-		&team.TeamID, &team.Sport, &team.ExternalID, &team.Abbreviation, 
-		&team.FullName, &team.ShortName, &team.City, &team.State,
-		&team.Conference, &team.Division, &team.VenueName, &team.VenueCapacity,
-		&team.FoundedYear, &team.LogoURL, &team.Colors, &team.SocialMedia, 
-		&team.Metadata, &team.IsActive, &team.CreatedAt, &team.UpdatedAt,
-	)
+	team, err := teamColumns.scan(r.db.ReadDB(ctx).QueryRowContext(ctx, query, teamID))
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("team not found: %d", teamID)
+		return nil, store.NotFound("team %d", teamID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("querying team: %w", err)
@@ -85,28 +144,21 @@ func (r *TeamRepository) GetByID(ctx context.Context, teamID int) (*store.Team,
 	return team, nil
 }
 
-// GetByAbbreviation finds a team by abbreviation (e.g., "LAL", "BOS")
+// GetByAbbreviation finds an NBA team by abbreviation (e.g., "LAL", "BOS")
 func (r *TeamRepository) GetByAbbreviation(ctx context.Context, abbr string) (*store.Team, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT team_id, sport, external_id, abbreviation, full_name, short_name, 
-			city, state, conference, division, venue_name, venue_capacity, 
-			founded_year, logo_url, colors, social_media, metadata, is_active,
-			created_at, updated_at
+		SELECT ` + teamColumns.list("") + `
 		FROM teams
-		WHERE abbreviation = $1
+		WHERE abbreviation = $1 AND league = 'nba'
 	`
 
-	team := &store.Team{}
-	err := r.db.DB().QueryRowContext(ctx, query, abbr).Scan(
-		&team.TeamID, &team.Sport, &team.ExternalID, &team.Abbreviation, 
-		&team.FullName, &team.ShortName, &team.City, &team.State,
-		&team.Conference, &team.Division, &team.VenueName, &team.VenueCapacity,
-		&team.FoundedYear, &team.LogoURL, &team.Colors, &team.SocialMedia, 
-		&team.Metadata, &team.IsActive, &team.CreatedAt, &team.UpdatedAt,
-	)
+	team, err := teamColumns.scan(r.db.ReadDB(ctx).QueryRowContext(ctx, query, abbr))
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("team not found: %s", abbr)
+		return nil, store.NotFound("team %s", abbr)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("querying team: %w", err)
@@ -115,28 +167,21 @@ func (r *TeamRepository) GetByAbbreviation(ctx context.Context, abbr string) (*s
 	return team, nil
 }
 
-// GetByESPNID finds a team by ESPN team ID (external_id)
+// GetByESPNID finds an NBA team by ESPN team ID (external_id)
 func (r *TeamRepository) GetByESPNID(ctx context.Context, espnID string) (*store.Team, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT team_id, sport, external_id, abbreviation, full_name, short_name, 
-			city, state, conference, division, venue_name, venue_capacity, 
-			founded_year, logo_url, colors, social_media, metadata, is_active,
-			created_at, updated_at
+		SELECT ` + teamColumns.list("") + `
 		FROM teams
-		WHERE external_id = $1
+		WHERE external_id = $1 AND league = 'nba'
 	`
 
-	team := &store.Team{}
-	err := r.db.DB().QueryRowContext(ctx, query, espnID).Scan(
-		&team.TeamID, &team.Sport, &team.ExternalID, &team.Abbreviation, 
-		&team.FullName, &team.ShortName, &team.City, &team.State,
-		&team.Conference, &team.Division, &team.VenueName, &team.VenueCapacity,
-		&team.FoundedYear, &team.LogoURL, &team.Colors, &team.SocialMedia, 
-		&team.Metadata, &team.IsActive, &team.CreatedAt, &team.UpdatedAt,
-	)
+	team, err := teamColumns.scan(r.db.ReadDB(ctx).QueryRowContext(ctx, query, espnID))
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("team not found with ESPN ID: %s", espnID)
+		return nil, store.NotFound("team with ESPN ID %s", espnID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("querying team: %w", err)
@@ -145,19 +190,19 @@ func (r *TeamRepository) GetByESPNID(ctx context.Context, espnID string) (*store
 	return team, nil
 }
 
-// GetByConference returns all teams in a conference
+// GetByConference returns all NBA teams in a conference
 func (r *TeamRepository) GetByConference(ctx context.Context, conference string) ([]*store.Team, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT team_id, sport, external_id, abbreviation, full_name, short_name, 
-			city, state, conference, division, venue_name, venue_capacity, 
-			founded_year, logo_url, colors, social_media, metadata, is_active,
-			created_at, updated_at
+		SELECT ` + teamColumns.list("") + `
 		FROM teams
-		WHERE conference = $1 AND is_active = true
+		WHERE conference = $1 AND is_active = true AND league = 'nba'
 		ORDER BY division, abbreviation
 	`
 
-	rows, err := r.db.DB().QueryContext(ctx, query, conference)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, conference)
 	if err != nil {
 		return nil, fmt.Errorf("querying teams: %w", err)
 	}
@@ -165,14 +210,7 @@ func (r *TeamRepository) GetByConference(ctx context.Context, conference string)
 
 	var teams []*store.Team
 	for rows.Next() {
-		team := &store.Team{}
-		err := rows.Scan(
-			&team.TeamID, &team.Sport, &team.ExternalID, &team.Abbreviation, 
-			&team.FullName, &team.ShortName, &team.City, &team.State,
-			&team.Conference, &team.Division, &team.VenueName, &team.VenueCapacity,
-			&team.FoundedYear, &team.LogoURL, &team.Colors, &team.SocialMedia, 
-			&team.Metadata, &team.IsActive, &team.CreatedAt, &team.UpdatedAt,
-		)
+		team, err := teamColumns.scan(rows)
 		if err != nil {
 			return nil, fmt.Errorf("scanning team: %w", err)
 		}
@@ -182,3 +220,63 @@ func (r *TeamRepository) GetByConference(ctx context.Context, conference string)
 	return teams, rows.Err()
 }
 
+
+// UpsertESPNTeam stores a team first seen on a development league scoreboard,
+// keyed by league and ESPN ID, and sets team.TeamID. Existing rows only take
+// ESPN's current abbreviation and name.
+func (r *TeamRepository) UpsertESPNTeam(ctx context.Context, team *store.Team) error {
+	query := `
+		INSERT INTO teams (sport, league, external_id, abbreviation, full_name, short_name)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (sport, league, external_id) DO UPDATE SET
+			abbreviation = EXCLUDED.abbreviation,
+			full_name = EXCLUDED.full_name,
+			updated_at = NOW()
+		RETURNING team_id
+	`
+
+	err := r.db.DB().QueryRowContext(ctx, query,
+		team.Sport, team.League, team.ExternalID, team.Abbreviation, team.FullName, team.ShortName,
+	).Scan(&team.TeamID)
+	if err != nil {
+		return fmt.Errorf("upserting %s team %s: %w", team.League, team.ExternalID, err)
+	}
+	return nil
+}
+
+// GetSeasonNetRatings returns each team's net rating over the season's final
+// games: points scored minus allowed per 100 possessions, with possessions
+// averaged across both box scores. Teams without box scores are absent.
+func (r *TeamRepository) GetSeasonNetRatings(ctx context.Context, seasonID int) (map[int]float64, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT t.team_id,
+			100.0 * (SUM(t.points) - SUM(o.points)) / SUM((t.possessions + o.possessions) / 2.0)
+		FROM team_game_stats t
+		JOIN team_game_stats o ON o.game_id = t.game_id AND o.team_id <> t.team_id
+		JOIN games g ON g.game_id = t.game_id
+		WHERE g.season_id = $1 AND g.status = 'final'
+			AND t.possessions > 0 AND o.possessions > 0
+			AND t.points IS NOT NULL AND o.points IS NOT NULL
+		GROUP BY t.team_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("querying season net ratings: %w", err)
+	}
+	defer rows.Close()
+
+	ratings := make(map[int]float64)
+	for rows.Next() {
+		var teamID int
+		var rating float64
+		if err := rows.Scan(&teamID, &rating); err != nil {
+			return nil, fmt.Errorf("scanning season net rating: %w", err)
+		}
+		ratings[teamID] = rating
+	}
+	return ratings, rows.Err()
+}
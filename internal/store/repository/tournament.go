@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// TournamentRepository handles NBA Cup game data access
+type TournamentRepository struct {
+	db *store.Database
+}
+
+// NewTournamentRepository creates a new tournament repository
+func NewTournamentRepository(db *store.Database) *TournamentRepository {
+	return &TournamentRepository{db: db}
+}
+
+// GetGames returns a season's tournament games (group stage and knockout) in date order
+func (r *TournamentRepository) GetGames(ctx context.Context, sport, seasonYear string) ([]*store.TournamentGame, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT COALESCE(g.external_id, ''), g.game_date,
+			COALESCE(g.metadata->>'tournament_group', ''), g.metadata->>'tournament_round',
+			g.home_team_id, g.away_team_id, g.home_score, g.away_score, g.status
+		FROM games g
+		JOIN seasons s ON g.season_id = s.season_id
+		WHERE g.sport = $1 AND s.season_year = $2
+			AND g.game_type IN ('tournament', 'tournament_final')
+			AND g.metadata->>'tournament_round' IS NOT NULL
+		ORDER BY g.game_date, g.game_id
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, sport, seasonYear)
+	if err != nil {
+		return nil, fmt.Errorf("querying tournament games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []*store.TournamentGame
+	for rows.Next() {
+		g := &store.TournamentGame{}
+		err := rows.Scan(
+			&g.GameID, &g.GameDate, &g.Group, &g.Round,
+			&g.HomeTeamID, &g.AwayTeamID, &g.HomeScore, &g.AwayScore, &g.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning tournament game: %w", err)
+		}
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
+}
+
+// LatestSeasonYear returns the most recent season year with tournament games
+func (r *TournamentRepository) LatestSeasonYear(ctx context.Context, sport string) (string, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT s.season_year
+		FROM games g
+		JOIN seasons s ON g.season_id = s.season_id
+		WHERE g.sport = $1
+			AND g.game_type IN ('tournament', 'tournament_final')
+			AND g.metadata->>'tournament_round' IS NOT NULL
+		ORDER BY g.game_date DESC
+		LIMIT 1
+	`
+
+	var seasonYear string
+	if err := r.db.ReadDB(ctx).QueryRowContext(ctx, query, sport).Scan(&seasonYear); err != nil {
+		return "", fmt.Errorf("querying latest tournament season: %w", err)
+	}
+	return seasonYear, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// TransactionRepository stores roster transactions
+type TransactionRepository struct {
+	db *store.Database
+}
+
+// NewTransactionRepository creates a new transaction repository
+func NewTransactionRepository(db *store.Database) *TransactionRepository {
+	return &TransactionRepository{db: db}
+}
+
+// Insert stores a transaction unless the same team, date, and description is
+// already recorded. It sets TransactionID and reports whether the row is new.
+func (r *TransactionRepository) Insert(ctx context.Context, t *store.PlayerTransaction) (bool, error) {
+	query := `
+		INSERT INTO player_transactions (sport, transaction_date, transaction_type, team_id, player_id, description)
+		VALUES ($1, $2::date, $3, $4, $5, $6)
+		ON CONFLICT (sport, team_id, transaction_date, description) DO NOTHING
+		RETURNING transaction_id, created_at
+	`
+
+	err := r.db.DB().QueryRowContext(ctx, query,
+		t.Sport, t.TransactionDate, t.TransactionType, t.TeamID, t.PlayerID, t.Description,
+	).Scan(&t.TransactionID, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("inserting transaction: %w", err)
+	}
+	return true, nil
+}
+
+// List returns a sport's transactions on or after since, newest first. A zero
+// teamID matches every team.
+func (r *TransactionRepository) List(ctx context.Context, sport string, teamID int, since time.Time, limit int) ([]*store.PlayerTransaction, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT pt.transaction_id, pt.sport, pt.transaction_date, pt.transaction_type,
+			pt.team_id, t.abbreviation, pt.player_id, COALESCE(p.full_name, ''),
+			pt.description, pt.created_at
+		FROM player_transactions pt
+		JOIN teams t ON t.team_id = pt.team_id
+		LEFT JOIN players p ON p.player_id = pt.player_id
+		WHERE pt.sport = $1
+			AND ($2 = 0 OR pt.team_id = $2)
+			AND pt.transaction_date >= $3::date
+		ORDER BY pt.transaction_date DESC, pt.transaction_id DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, sport, teamID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*store.PlayerTransaction
+	for rows.Next() {
+		t := &store.PlayerTransaction{}
+		err := rows.Scan(
+			&t.TransactionID, &t.Sport, &t.TransactionDate, &t.TransactionType,
+			&t.TeamID, &t.TeamAbbr, &t.PlayerID, &t.PlayerName,
+			&t.Description, &t.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning transaction: %w", err)
+		}
+		transactions = append(transactions, t)
+	}
+
+	return transactions, rows.Err()
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// TranslationRepository handles localized team and player name data access
+type TranslationRepository struct {
+	db *store.Database
+}
+
+// NewTranslationRepository creates a new name translation repository
+func NewTranslationRepository(db *store.Database) *TranslationRepository {
+	return &TranslationRepository{db: db}
+}
+
+const translationColumns = `entity_type, entity_id, locale, full_name, short_name, city, updated_at`
+
+// List returns translations, optionally narrowed to one locale and entity type
+// (empty matches all), ordered by locale, entity type, and entity ID
+func (r *TranslationRepository) List(ctx context.Context, locale, entityType string) ([]*store.NameTranslation, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, `
+		SELECT `+translationColumns+`
+		FROM name_translations
+		WHERE ($1 = '' OR locale = $1)
+		  AND ($2 = '' OR entity_type = $2)
+		ORDER BY locale, entity_type, entity_id
+	`, strings.ToLower(locale), entityType)
+	if err != nil {
+		return nil, fmt.Errorf("querying name translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := []*store.NameTranslation{}
+	for rows.Next() {
+		t := &store.NameTranslation{}
+		if err := rows.Scan(&t.EntityType, &t.EntityID, &t.Locale, &t.FullName, &t.ShortName, &t.City, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning name translation: %w", err)
+		}
+		translations = append(translations, t)
+	}
+	return translations, rows.Err()
+}
+
+// Upsert stores a translation, replacing the entity's name in that locale if
+// it has one, and fills in its update time
+func (r *TranslationRepository) Upsert(ctx context.Context, t *store.NameTranslation) error {
+	t.Locale = strings.ToLower(t.Locale)
+
+	err := r.db.DB().QueryRowContext(ctx, `
+		INSERT INTO name_translations (entity_type, entity_id, locale, full_name, short_name, city)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (entity_type, entity_id, locale) DO UPDATE SET
+			full_name = EXCLUDED.full_name,
+			short_name = EXCLUDED.short_name,
+			city = EXCLUDED.city,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, t.EntityType, t.EntityID, t.Locale, t.FullName, t.ShortName, t.City).Scan(&t.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upserting name translation: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an entity's name in one locale
+func (r *TranslationRepository) Delete(ctx context.Context, entityType string, entityID int, locale string) error {
+	res, err := r.db.DB().ExecContext(ctx,
+		`DELETE FROM name_translations WHERE entity_type = $1 AND entity_id = $2 AND locale = $3`,
+		entityType, entityID, strings.ToLower(locale))
+	if err != nil {
+		return fmt.Errorf("deleting name translation: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return store.NotFound("%s %d translation for %q", entityType, entityID, locale)
+	}
+	return nil
+}
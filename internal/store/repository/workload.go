@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// WorkloadRepository handles rolling minute loads and their nightly snapshots
+type WorkloadRepository struct {
+	db *store.Database
+}
+
+// NewWorkloadRepository creates a new workload repository
+func NewWorkloadRepository(db *store.Database) *WorkloadRepository {
+	return &WorkloadRepository{db: db}
+}
+
+// workloadWindowsQuery sums minutes per player over the 3, 7 and 14 days ending on
+// $1. A back-to-back is a game played the day after another; its load is the minutes
+// across both legs.
+const workloadWindowsQuery = `
+	WITH played AS (
+		SELECT pgs.player_id, g.game_date::date AS day, SUM(COALESCE(pgs.minutes_played, 0)) AS minutes
+		FROM player_game_stats pgs
+		JOIN games g ON pgs.game_id = g.game_id
+		WHERE g.sport = $2
+			AND g.status = 'final'
+			AND pgs.deleted_at IS NULL
+			AND g.game_date::date BETWEEN $1::date - 13 AND $1::date
+			AND ($3 = 0 OR pgs.player_id = $3)
+		GROUP BY pgs.player_id, g.game_date::date
+	)
+	SELECT p.player_id,
+		COALESCE(SUM(p.minutes) FILTER (WHERE p.day > $1::date - 3), 0),
+		COALESCE(SUM(p.minutes) FILTER (WHERE p.day > $1::date - 7), 0),
+		COALESCE(SUM(p.minutes), 0),
+		COUNT(*) FILTER (WHERE p.day > $1::date - 7),
+		COUNT(*),
+		COUNT(prev.day),
+		COALESCE(SUM(p.minutes + prev.minutes) FILTER (WHERE prev.day IS NOT NULL), 0)
+	FROM played p
+	LEFT JOIN played prev ON prev.player_id = p.player_id AND prev.day = p.day - 1
+	GROUP BY p.player_id
+`
+
+// ComputeWindows derives workloads as of a date from stored box scores, without the
+// fatigue index. A zero playerID covers every player who appeared in the trailing 14 days.
+func (r *WorkloadRepository) ComputeWindows(ctx context.Context, sport string, asOf time.Time, playerID int) ([]*store.PlayerWorkload, error) {
+	ctx, cancel := r.db.AggregateContext(ctx)
+	defer cancel()
+
+	day := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, workloadWindowsQuery, day.Format("2006-01-02"), sport, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("querying workload windows: %w", err)
+	}
+	defer rows.Close()
+
+	var workloads []*store.PlayerWorkload
+	for rows.Next() {
+		w := &store.PlayerWorkload{AsOfDate: day}
+		err := rows.Scan(
+			&w.PlayerID, &w.Minutes3d, &w.Minutes7d, &w.Minutes14d, &w.Games7d, &w.Games14d,
+			&w.BackToBacks14d, &w.BackToBackMinutes14d,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning workload window: %w", err)
+		}
+		workloads = append(workloads, w)
+	}
+
+	return workloads, rows.Err()
+}
+
+// Save upserts a night's snapshots in one transaction
+func (r *WorkloadRepository) Save(ctx context.Context, workloads []*store.PlayerWorkload) error {
+	tx, err := r.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning workload save: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO player_workloads (
+			player_id, as_of_date, minutes_3d, minutes_7d, minutes_14d, games_7d, games_14d,
+			back_to_backs_14d, back_to_back_minutes_14d, fatigue_index
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (player_id, as_of_date) DO UPDATE SET
+			minutes_3d = EXCLUDED.minutes_3d,
+			minutes_7d = EXCLUDED.minutes_7d,
+			minutes_14d = EXCLUDED.minutes_14d,
+			games_7d = EXCLUDED.games_7d,
+			games_14d = EXCLUDED.games_14d,
+			back_to_backs_14d = EXCLUDED.back_to_backs_14d,
+			back_to_back_minutes_14d = EXCLUDED.back_to_back_minutes_14d,
+			fatigue_index = EXCLUDED.fatigue_index,
+			computed_at = NOW()
+	`)
+	if err != nil {
+		return fmt.Errorf("preparing workload upsert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, w := range workloads {
+		_, err := stmt.ExecContext(ctx,
+			w.PlayerID, w.AsOfDate, w.Minutes3d, w.Minutes7d, w.Minutes14d, w.Games7d, w.Games14d,
+			w.BackToBacks14d, w.BackToBackMinutes14d, w.FatigueIndex,
+		)
+		if err != nil {
+			return fmt.Errorf("saving workload for player %d: %w", w.PlayerID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing workloads: %w", err)
+	}
+	return nil
+}
+
+// GetHistory returns a player's stored snapshots, newest first
+func (r *WorkloadRepository) GetHistory(ctx context.Context, playerID, limit int) ([]*store.PlayerWorkload, error) {
+	ctx, cancel := r.db.ReadContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT player_id, as_of_date, minutes_3d, minutes_7d, minutes_14d, games_7d, games_14d,
+			back_to_backs_14d, back_to_back_minutes_14d, fatigue_index
+		FROM player_workloads
+		WHERE player_id = $1
+		ORDER BY as_of_date DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.ReadDB(ctx).QueryContext(ctx, query, playerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying player workloads: %w", err)
+	}
+	defer rows.Close()
+
+	workloads := []*store.PlayerWorkload{}
+	for rows.Next() {
+		w := &store.PlayerWorkload{}
+		err := rows.Scan(
+			&w.PlayerID, &w.AsOfDate, &w.Minutes3d, &w.Minutes7d, &w.Minutes14d, &w.Games7d, &w.Games14d,
+			&w.BackToBacks14d, &w.BackToBackMinutes14d, &w.FatigueIndex,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning player workload: %w", err)
+		}
+		workloads = append(workloads, w)
+	}
+
+	return workloads, rows.Err()
+}
+
+// GetLatest returns a player's most recent snapshot, or nil when none is stored
+func (r *WorkloadRepository) GetLatest(ctx context.Context, playerID int) (*store.PlayerWorkload, error) {
+	history, err := r.GetHistory(ctx, playerID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, nil
+	}
+	return history[0], nil
+}
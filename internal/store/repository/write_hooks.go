@@ -0,0 +1,30 @@
+package repository
+
+import "sync"
+
+// WriteHook is called after a repository commits a change to a player or team
+// row, with the entity type (store.EntityPlayer or store.EntityTeam) and its ID,
+// or to a player's box score lines (store.EntityPlayerStats and the player ID)
+type WriteHook func(entityType string, id int)
+
+var (
+	writeHooksMu sync.RWMutex
+	writeHooks   []WriteHook
+)
+
+// OnEntityWrite registers a hook for player, team, and stat line writes made in
+// this process. Caches use it to drop stale records; writes from other replicas
+// are not seen, so in-memory caches still need a TTL.
+func OnEntityWrite(hook WriteHook) {
+	writeHooksMu.Lock()
+	defer writeHooksMu.Unlock()
+	writeHooks = append(writeHooks, hook)
+}
+
+func notifyEntityWrite(entityType string, id int) {
+	writeHooksMu.RLock()
+	defer writeHooksMu.RUnlock()
+	for _, hook := range writeHooks {
+		hook(entityType, id)
+	}
+}
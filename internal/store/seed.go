@@ -0,0 +1,195 @@
+package store
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+//go:embed seed/teams.json seed/seasons.json
+var seedFS embed.FS
+
+// TeamSeed is one franchise in the embedded team dataset
+type TeamSeed struct {
+	Abbreviation  string            `json:"abbreviation"`
+	ShortName     string            `json:"short_name"`
+	FullName      string            `json:"full_name"`
+	City          string            `json:"city"`
+	Conference    string            `json:"conference"`
+	Division      string            `json:"division"`
+	ExternalID    string            `json:"external_id"`
+	VenueName     string            `json:"venue_name"`
+	VenueCapacity int               `json:"venue_capacity"`
+	LogoURL       string            `json:"logo_url"`
+	Colors        map[string]string `json:"colors"`
+}
+
+// SeasonSeed is one season in the embedded season dataset
+type SeasonSeed struct {
+	SeasonYear string `json:"season_year"`
+	SeasonType string `json:"season_type"`
+	StartDate  string `json:"start_date"`
+	EndDate    string `json:"end_date"`
+	IsActive   bool   `json:"is_active"`
+	TotalGames int    `json:"total_games"`
+	League     string `json:"league,omitempty"` // empty for NBA seasons
+}
+
+// SeedDataset is the reference data bundled into the binary
+type SeedDataset struct {
+	Sport   string
+	Teams   []TeamSeed
+	Seasons []SeasonSeed
+}
+
+// LoadSeedDataset decodes the embedded team and season files
+func LoadSeedDataset() (*SeedDataset, error) {
+	var teams struct {
+		Sport string     `json:"sport"`
+		Teams []TeamSeed `json:"teams"`
+	}
+	if err := readSeedFile("seed/teams.json", &teams); err != nil {
+		return nil, err
+	}
+
+	var seasons struct {
+		Sport   string       `json:"sport"`
+		Seasons []SeasonSeed `json:"seasons"`
+	}
+	if err := readSeedFile("seed/seasons.json", &seasons); err != nil {
+		return nil, err
+	}
+
+	if teams.Sport != seasons.Sport {
+		return nil, fmt.Errorf("seed sport mismatch: teams=%s seasons=%s", teams.Sport, seasons.Sport)
+	}
+
+	return &SeedDataset{Sport: teams.Sport, Teams: teams.Teams, Seasons: seasons.Seasons}, nil
+}
+
+func readSeedFile(name string, v interface{}) error {
+	content, err := seedFS.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("failed to read seed file %s: %w", name, err)
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("failed to decode seed file %s: %w", name, err)
+	}
+	return nil
+}
+
+// SeedData inserts reference teams and seasons.
+// Seasons are upserted; existing teams are left untouched so manual edits survive
+// restarts (use UpdateTeams to refresh them from the dataset).
+func (db *Database) SeedData() error {
+	log.Println("Running seed data...")
+
+	dataset, err := LoadSeedDataset()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	seasons, err := db.upsertSeasons(ctx, dataset)
+	if err != nil {
+		return err
+	}
+	log.Printf("  ✓ Seeded %d seasons", seasons)
+
+	inserted, err := db.upsertTeams(ctx, dataset, false)
+	if err != nil {
+		return err
+	}
+	log.Printf("  ✓ Seeded teams (%d new, %d already present)", inserted, int64(len(dataset.Teams))-inserted)
+
+	log.Println("✓ Seed data completed successfully")
+	return nil
+}
+
+// UpdateTeams refreshes names, divisions, venues, logos, and colors for every
+// team in the embedded dataset, inserting any that are missing. It returns the
+// number of rows written.
+func (db *Database) UpdateTeams(ctx context.Context) (int64, error) {
+	dataset, err := LoadSeedDataset()
+	if err != nil {
+		return 0, err
+	}
+	return db.upsertTeams(ctx, dataset, true)
+}
+
+func (db *Database) upsertSeasons(ctx context.Context, dataset *SeedDataset) (int64, error) {
+	query := `
+		INSERT INTO seasons (sport, season_year, season_type, start_date, end_date, is_active, total_games, league)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (sport, league, season_year, season_type) DO UPDATE SET
+			start_date = EXCLUDED.start_date,
+			end_date = EXCLUDED.end_date,
+			is_active = EXCLUDED.is_active,
+			total_games = EXCLUDED.total_games,
+			updated_at = NOW()
+	`
+
+	var written int64
+	for _, s := range dataset.Seasons {
+		league := s.League
+		if league == "" {
+			league = LeagueNBA
+		}
+		res, err := db.conn.ExecContext(ctx, query,
+			dataset.Sport, s.SeasonYear, s.SeasonType, s.StartDate, s.EndDate, s.IsActive, s.TotalGames, league)
+		if err != nil {
+			return written, fmt.Errorf("failed to seed %s season %s: %w", league, s.SeasonYear, err)
+		}
+		n, _ := res.RowsAffected()
+		written += n
+	}
+	return written, nil
+}
+
+// upsertTeams inserts missing teams; with refresh it also overwrites reference
+// columns on existing rows.
+func (db *Database) upsertTeams(ctx context.Context, dataset *SeedDataset, refresh bool) (int64, error) {
+	conflict := `ON CONFLICT (sport, league, external_id) DO NOTHING`
+	if refresh {
+		conflict = `ON CONFLICT (sport, league, external_id) DO UPDATE SET
+			abbreviation = EXCLUDED.abbreviation,
+			short_name = EXCLUDED.short_name,
+			full_name = EXCLUDED.full_name,
+			city = EXCLUDED.city,
+			conference = EXCLUDED.conference,
+			division = EXCLUDED.division,
+			venue_name = EXCLUDED.venue_name,
+			venue_capacity = EXCLUDED.venue_capacity,
+			logo_url = EXCLUDED.logo_url,
+			colors = EXCLUDED.colors,
+			is_active = true,
+			updated_at = NOW()`
+	}
+
+	query := `
+		INSERT INTO teams (sport, abbreviation, short_name, full_name, city, conference, division,
+			external_id, venue_name, venue_capacity, logo_url, colors, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, true)
+		` + conflict
+
+	var written int64
+	for _, t := range dataset.Teams {
+		colors, err := json.Marshal(t.Colors)
+		if err != nil {
+			return written, fmt.Errorf("failed to encode colors for %s: %w", t.Abbreviation, err)
+		}
+
+		res, err := db.conn.ExecContext(ctx, query,
+			dataset.Sport, t.Abbreviation, t.ShortName, t.FullName, t.City, t.Conference, t.Division,
+			t.ExternalID, t.VenueName, t.VenueCapacity, t.LogoURL, string(colors))
+		if err != nil {
+			return written, fmt.Errorf("failed to seed team %s: %w", t.Abbreviation, err)
+		}
+		n, _ := res.RowsAffected()
+		written += n
+	}
+	return written, nil
+}
@@ -0,0 +1,14 @@
+package store
+
+import "slices"
+
+// DefaultSport is assumed by routes and jobs that predate sport namespacing
+const DefaultSport = "basketball_nba"
+
+// SupportedSports are the sport keys the API serves (games.sport, seasons.sport)
+var SupportedSports = []string{DefaultSport}
+
+// ValidSport reports whether sport is served by the API
+func ValidSport(sport string) bool {
+	return slices.Contains(SupportedSports, sport)
+}
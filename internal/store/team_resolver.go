@@ -0,0 +1,247 @@
+package store
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Kinds of team name a TeamResolver matches, strongest first
+const (
+	TeamMatchAbbreviation = "abbreviation"
+	TeamMatchFullName     = "full_name"
+	TeamMatchNickname     = "nickname" // teams.short_name
+	TeamMatchAlias        = "alias"    // team_aliases
+	TeamMatchCity         = "city"
+)
+
+var teamMatchPriority = map[string]int{
+	TeamMatchAbbreviation: 0,
+	TeamMatchFullName:     1,
+	TeamMatchNickname:     2,
+	TeamMatchAlias:        3,
+	TeamMatchCity:         4,
+}
+
+// TeamAlias is an extra name, from team_aliases, for the team with
+// Abbreviation in League
+type TeamAlias struct {
+	League       string
+	Abbreviation string
+	Alias        string
+}
+
+// TeamMatch is a team found by TeamResolver.Search and the name it matched on
+type TeamMatch struct {
+	Team      *Team  `json:"team"`
+	MatchedOn string `json:"matched_on"` // abbreviation, full_name, nickname, alias, city
+	Name      string `json:"matched_name"`
+	Exact     bool   `json:"exact"`
+}
+
+type teamName struct {
+	key  string // normalized
+	name string
+	kind string
+	team *Team
+}
+
+// TeamResolver maps free-text team references ("Warriors", "LA Clippers",
+// "Sixers", "GSW") to teams by abbreviation, nickname, city, full name, or
+// alias. Matching ignores case and punctuation. It is read-only once built
+// and safe for concurrent use.
+type TeamResolver struct {
+	teams []*Team
+	names []teamName
+	exact map[string][]teamName
+}
+
+// NewTeamResolver indexes teams and the aliases that belong to them. Aliases
+// whose league and abbreviation match no team are ignored.
+func NewTeamResolver(teams []*Team, aliases []TeamAlias) *TeamResolver {
+	r := &TeamResolver{teams: teams, exact: make(map[string][]teamName)}
+
+	byAbbr := make(map[string]*Team, len(teams))
+	for _, team := range teams {
+		byAbbr[teamLeague(team.League)+":"+strings.ToUpper(team.Abbreviation)] = team
+
+		r.add(team, team.Abbreviation, TeamMatchAbbreviation)
+		r.add(team, team.FullName, TeamMatchFullName)
+		r.add(team, team.ShortName, TeamMatchNickname)
+		if team.City.Valid {
+			r.add(team, team.City.String, TeamMatchCity)
+		}
+	}
+	for _, alias := range aliases {
+		if team, ok := byAbbr[teamLeague(alias.League)+":"+strings.ToUpper(alias.Abbreviation)]; ok {
+			r.add(team, alias.Alias, TeamMatchAlias)
+		}
+	}
+	return r
+}
+
+func teamLeague(league string) string {
+	if league == "" {
+		return LeagueNBA
+	}
+	return league
+}
+
+func (r *TeamResolver) add(team *Team, name, kind string) {
+	key := NormalizeTeamName(name)
+	if key == "" {
+		return
+	}
+	n := teamName{key: key, name: name, kind: kind, team: team}
+	r.names = append(r.names, n)
+	r.exact[key] = append(r.exact[key], n)
+}
+
+// Teams returns the teams the resolver was built from
+func (r *TeamResolver) Teams() []*Team {
+	return r.teams
+}
+
+// Resolve finds the one team a name refers to. An exact name wins; otherwise
+// the longest nickname, full name, alias, or city contained in name as whole
+// words is used ("Golden State Warriors 112"). Names shared by several teams
+// ("Los Angeles") resolve to nothing.
+func (r *TeamResolver) Resolve(name string) (*Team, bool) {
+	key := NormalizeTeamName(name)
+	if key == "" {
+		return nil, false
+	}
+	if team, ok := singleTeam(r.exact[key]); ok {
+		return team, true
+	}
+
+	var best []teamName
+	padded := " " + key + " "
+	for _, n := range r.names {
+		if n.kind == TeamMatchAbbreviation || !strings.Contains(padded, " "+n.key+" ") {
+			continue
+		}
+		switch {
+		case len(best) == 0 || len(n.key) > len(best[0].key):
+			best = []teamName{n}
+		case len(n.key) == len(best[0].key):
+			best = append(best, n)
+		}
+	}
+	return singleTeam(best)
+}
+
+// Abbreviation returns the abbreviation of the team name refers to, or name
+// unchanged when it does not resolve to exactly one team
+func (r *TeamResolver) Abbreviation(name string) string {
+	if team, ok := r.Resolve(name); ok {
+		return team.Abbreviation
+	}
+	return name
+}
+
+// Search returns every team with a name equal to, starting with, or containing
+// query, best first: exact matches, then prefixes (of the name or any word in
+// it), then substrings, each ordered by the kind of name matched.
+func (r *TeamResolver) Search(query string) []*TeamMatch {
+	key := NormalizeTeamName(query)
+	if key == "" {
+		return []*TeamMatch{}
+	}
+
+	type ranked struct {
+		match *TeamMatch
+		rank  int
+	}
+	best := make(map[*Team]*ranked)
+	for _, n := range r.names {
+		rank := -1
+		switch {
+		case n.key == key:
+			rank = 0
+		case strings.HasPrefix(n.key, key) || strings.Contains(n.key, " "+key):
+			rank = 1
+		case strings.Contains(n.key, key):
+			rank = 2
+		}
+		if rank < 0 {
+			continue
+		}
+
+		current, ok := best[n.team]
+		if ok && (current.rank < rank ||
+			(current.rank == rank && teamMatchPriority[current.match.MatchedOn] <= teamMatchPriority[n.kind])) {
+			continue
+		}
+		best[n.team] = &ranked{
+			match: &TeamMatch{Team: n.team, MatchedOn: n.kind, Name: n.name, Exact: rank == 0},
+			rank:  rank,
+		}
+	}
+
+	results := make([]*ranked, 0, len(best))
+	for _, b := range best {
+		results = append(results, b)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if pa, pb := teamMatchPriority[a.match.MatchedOn], teamMatchPriority[b.match.MatchedOn]; pa != pb {
+			return pa < pb
+		}
+		return a.match.Team.Abbreviation < b.match.Team.Abbreviation
+	})
+
+	matches := make([]*TeamMatch, len(results))
+	for i, result := range results {
+		matches[i] = result.match
+	}
+	return matches
+}
+
+// singleTeam returns the team shared by every name, if there is exactly one
+func singleTeam(names []teamName) (*Team, bool) {
+	if len(names) == 0 {
+		return nil, false
+	}
+	team := names[0].team
+	for _, n := range names[1:] {
+		if n.team != team {
+			return nil, false
+		}
+	}
+	return team, true
+}
+
+// NormalizeTeamName lowercases a team name and reduces punctuation to single
+// spaces, so "T-Wolves", "t wolves", and "T.Wolves" compare equal
+func NormalizeTeamName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// SeedTeamResolver builds a resolver from the embedded NBA team dataset, for
+// callers without database access. It knows no aliases and its teams have no IDs.
+func SeedTeamResolver() (*TeamResolver, error) {
+	dataset, err := LoadSeedDataset()
+	if err != nil {
+		return nil, err
+	}
+	teams := make([]*Team, 0, len(dataset.Teams))
+	for _, t := range dataset.Teams {
+		teams = append(teams, &Team{
+			Sport:        dataset.Sport,
+			League:       LeagueNBA,
+			ExternalID:   t.ExternalID,
+			Abbreviation: t.Abbreviation,
+			FullName:     t.FullName,
+			ShortName:    t.ShortName,
+			City:         NullString{String: t.City, Valid: t.City != ""},
+		})
+	}
+	return NewTeamResolver(teams, nil), nil
+}
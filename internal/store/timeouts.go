@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// QueryTimeouts bounds how long repository queries may run
+type QueryTimeouts struct {
+	Read      time.Duration // point lookups and short listings
+	Aggregate time.Duration // season-wide scans and analytics aggregations
+}
+
+// DefaultQueryTimeouts returns the timeouts used when nothing is configured
+func DefaultQueryTimeouts() QueryTimeouts {
+	return QueryTimeouts{
+		Read:      5 * time.Second,
+		Aggregate: 30 * time.Second,
+	}
+}
+
+// SetQueryTimeouts overrides the per-query timeouts applied by the repositories
+func (db *Database) SetQueryTimeouts(timeouts QueryTimeouts) {
+	db.timeouts = timeouts
+}
+
+// ReadContext derives a context bounded by the read timeout
+func (db *Database) ReadContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withQueryTimeout(ctx, db.timeouts.Read)
+}
+
+// AggregateContext derives a context bounded by the aggregation timeout
+func (db *Database) AggregateContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withQueryTimeout(ctx, db.timeouts.Aggregate)
+}
+
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	// context.WithTimeout keeps the earlier of the parent deadline and ours
+	return context.WithTimeout(ctx, timeout)
+}
+
+// queryCanceled is the SQLSTATE of a statement cancelled by statement_timeout
+// or by a cancel request, which pq sends when a query's context ends
+const queryCanceled = "57014"
+
+// canceledQueryError is a statement cancelled because its context ended. It
+// matches both the driver error and the context's error.
+type canceledQueryError struct {
+	err   error
+	cause error
+}
+
+func (e *canceledQueryError) Error() string   { return e.err.Error() }
+func (e *canceledQueryError) Unwrap() []error { return []error{e.err, e.cause} }
+
+// withContextCause ties a query_canceled error to the context that ended the
+// query, so a deadline can be told apart from a caller that gave up
+func withContextCause(ctx context.Context, err error) error {
+	var pqErr *pq.Error
+	if ctx.Err() == nil || !errors.As(err, &pqErr) || pqErr.Code != queryCanceled {
+		return err
+	}
+	return &canceledQueryError{err: err, cause: ctx.Err()}
+}
+
+// IsTimeout reports whether err was caused by a query exceeding its deadline,
+// either on the client side or as a server-side statement timeout. A query
+// cancelled because its caller went away (e.g. a client disconnecting) is not
+// a timeout.
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == queryCanceled {
+		// Without a context the server cancelled it: statement_timeout, or an
+		// operator's pg_cancel_backend
+		return strings.Contains(pqErr.Message, "statement timeout")
+	}
+	return false
+}
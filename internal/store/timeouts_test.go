@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestIsTimeout(t *testing.T) {
+	// pq reports a query its context ended as a cancel request
+	userCancel := &pq.Error{Code: queryCanceled, Message: "canceling statement due to user request"}
+	statementTimeout := &pq.Error{Code: queryCanceled, Message: "canceling statement due to statement timeout"}
+
+	expired, cancelExpired := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancelExpired()
+	<-expired.Done()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"deadline", fmt.Errorf("querying games: %w", context.DeadlineExceeded), true},
+		{"canceled", fmt.Errorf("querying games: %w", context.Canceled), false},
+		{"statement timeout", fmt.Errorf("querying games: %w", statementTimeout), true},
+		{"cancel request without a context", userCancel, false},
+		{"cancel request after the deadline", fmt.Errorf("querying games: %w", withContextCause(expired, userCancel)), true},
+		{"cancel request after the client left", fmt.Errorf("querying games: %w", withContextCause(canceled, userCancel)), false},
+		{"other pq error", withContextCause(expired, &pq.Error{Code: "23505"}), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsTimeout(tc.err); got != tc.want {
+				t.Errorf("IsTimeout(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithContextCauseKeepsDriverError(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	original := &pq.Error{Code: queryCanceled, Message: "canceling statement due to user request"}
+	err := withContextCause(canceled, original)
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr != original {
+		t.Errorf("errors.As lost the pq error: %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("%v does not match context.Canceled", err)
+	}
+	if err.Error() != original.Error() {
+		t.Errorf("message changed to %q", err.Error())
+	}
+	if got := withContextCause(context.Background(), original); got != original {
+		t.Errorf("live context: got %v, want the driver error unchanged", got)
+	}
+}
@@ -0,0 +1,23 @@
+package store
+
+// Transaction types stored in player_transactions.transaction_type
+const (
+	TransactionSigning    = "signing"
+	TransactionWaiver     = "waiver"
+	TransactionRelease    = "release"
+	TransactionTrade      = "trade"
+	TransactionClaim      = "claim"
+	TransactionAssignment = "assignment" // sent to the team's G League affiliate
+	TransactionRecall     = "recall"     // brought back from the G League
+	TransactionOther      = "other"
+)
+
+// ValidTransactionType reports whether t is a known transaction type
+func ValidTransactionType(t string) bool {
+	switch t {
+	case TransactionSigning, TransactionWaiver, TransactionRelease, TransactionTrade,
+		TransactionClaim, TransactionAssignment, TransactionRecall, TransactionOther:
+		return true
+	}
+	return false
+}
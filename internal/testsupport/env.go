@@ -0,0 +1,42 @@
+package testsupport
+
+import (
+	"os"
+	"testing"
+
+	"github.com/fortuna/minerva/internal/store"
+)
+
+// Integration tests run against the database and Redis named by these
+// variables and skip when they're unset. Point them at scratch instances: the
+// tests write fixture rows.
+const (
+	DatabaseEnv = "TEST_ATLAS_DSN"
+	RedisEnv    = "TEST_REDIS_URL"
+)
+
+// Database connects to the test database, skipping t when DatabaseEnv is
+// unset. The connection is closed when t finishes.
+func Database(t testing.TB) *store.Database {
+	t.Helper()
+	dsn := os.Getenv(DatabaseEnv)
+	if dsn == "" {
+		t.Skipf("%s not set", DatabaseEnv)
+	}
+	db, err := store.NewDatabase(dsn)
+	if err != nil {
+		t.Fatalf("connect test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// RedisURL returns the test Redis URL, skipping t when RedisEnv is unset
+func RedisURL(t testing.TB) string {
+	t.Helper()
+	url := os.Getenv(RedisEnv)
+	if url == "" {
+		t.Skipf("%s not set", RedisEnv)
+	}
+	return url
+}
@@ -0,0 +1,304 @@
+package testsupport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// espnSportsPath is where ESPN serves the site API under its host
+const espnSportsPath = "/apis/site/v2/sports"
+
+// ESPNServer serves the scoreboard and summary endpoints of ESPN's site API
+// from fixture games. Point an ingester at URL with espn.NewIngesterWithBaseURL.
+type ESPNServer struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	games    []FixtureGame
+	raw      map[string][]byte // path (with query) -> captured response
+	requests []string
+}
+
+// NewESPNServer starts a mock ESPN API serving games
+func NewESPNServer(games ...FixtureGame) *ESPNServer {
+	s := &ESPNServer{games: games, raw: make(map[string][]byte)}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// URL is the base URL to use in place of espn.BaseURL
+func (s *ESPNServer) URL() string {
+	return s.server.URL + espnSportsPath
+}
+
+// Close shuts the server down
+func (s *ESPNServer) Close() {
+	s.server.Close()
+}
+
+// SetGame replaces the fixture with the same ID, or adds it, so a test can move
+// a game forward between polls
+func (s *ESPNServer) SetGame(game FixtureGame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.games {
+		if s.games[i].ID == game.ID {
+			s.games[i] = game
+			return
+		}
+	}
+	s.games = append(s.games, game)
+}
+
+// SetRaw serves body verbatim for a request path relative to URL, query
+// included (e.g. "/basketball/nba/summary?event=401584894"), for replaying
+// responses captured from the real API
+func (s *ESPNServer) SetRaw(path string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.raw[path] = body
+}
+
+// Requests returns the paths requested so far, relative to URL
+func (s *ESPNServer) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *ESPNServer) serve(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, espnSportsPath)
+	if r.URL.RawQuery != "" {
+		path += "?" + r.URL.RawQuery
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, path)
+	raw, hasRaw := s.raw[path]
+	games := append([]FixtureGame(nil), s.games...)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if hasRaw {
+		w.Write(raw)
+		return
+	}
+
+	var body interface{}
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/scoreboard"):
+		body = scoreboardJSON(games, r.URL.Query().Get("dates"))
+	case strings.HasSuffix(r.URL.Path, "/summary"):
+		id := r.URL.Query().Get("event")
+		for _, game := range games {
+			if game.ID == id {
+				body = summaryJSON(game)
+			}
+		}
+	}
+	if body == nil {
+		w.WriteHeader(http.StatusNotFound)
+		body = map[string]interface{}{"code": http.StatusNotFound, "message": "no fixture for " + path}
+	}
+	json.NewEncoder(w).Encode(body)
+}
+
+// scoreboardJSON lists the games on a YYYYMMDD date (US Eastern, as ESPN
+// files them), or every game when no date is given
+func scoreboardJSON(games []FixtureGame, date string) map[string]interface{} {
+	events := []interface{}{}
+	for _, game := range games {
+		if date != "" && game.Date.In(eastern()).Format("20060102") != date {
+			continue
+		}
+		comp := competitionJSON(game)
+		events = append(events, map[string]interface{}{
+			"id":           game.ID,
+			"date":         comp["date"],
+			"season":       comp["season"],
+			"status":       comp["status"],
+			"competitions": []interface{}{comp},
+		})
+	}
+	return map[string]interface{}{"events": events}
+}
+
+func summaryJSON(game FixtureGame) map[string]interface{} {
+	players := []interface{}{}
+	teams := []interface{}{}
+	for _, team := range []FixtureTeam{game.Home, game.Away} {
+		athletes := make([]interface{}, 0, len(team.Players))
+		for _, p := range team.Players {
+			athletes = append(athletes, map[string]interface{}{
+				"athlete": map[string]interface{}{
+					"id":          p.ESPNID,
+					"displayName": p.Name,
+					"position":    map[string]interface{}{"abbreviation": p.Position},
+				},
+				"starter":    p.Starter,
+				"didNotPlay": false,
+				"stats":      boxScoreLine(p),
+			})
+		}
+		players = append(players, map[string]interface{}{
+			"team": teamJSON(team),
+			"statistics": []interface{}{map[string]interface{}{
+				"names":    boxScoreLabels,
+				"athletes": athletes,
+			}},
+		})
+
+		totals := team.totals()
+		teams = append(teams, map[string]interface{}{
+			"team": teamJSON(team),
+			"statistics": []interface{}{
+				teamStat("fieldGoalsMade-fieldGoalsAttempted", "FG", made(totals.FGM, totals.FGA)),
+				teamStat("threePointFieldGoalsMade-threePointFieldGoalsAttempted", "3PT", made(totals.TPM, totals.TPA)),
+				teamStat("freeThrowsMade-freeThrowsAttempted", "FT", made(totals.FTM, totals.FTA)),
+				teamStat("totalRebounds", "REB", fmt.Sprint(totals.OffensiveRebounds+totals.DefensiveRebounds)),
+				teamStat("offensiveRebounds", "OR", fmt.Sprint(totals.OffensiveRebounds)),
+				teamStat("defensiveRebounds", "DR", fmt.Sprint(totals.DefensiveRebounds)),
+				teamStat("assists", "AST", fmt.Sprint(totals.Assists)),
+				teamStat("steals", "STL", fmt.Sprint(totals.Steals)),
+				teamStat("blocks", "BLK", fmt.Sprint(totals.Blocks)),
+				teamStat("turnovers", "TO", fmt.Sprint(totals.Turnovers)),
+				teamStat("fouls", "PF", fmt.Sprint(totals.Fouls)),
+			},
+		})
+	}
+
+	plays := make([]interface{}, 0, len(game.Plays))
+	for _, p := range game.Plays {
+		play := map[string]interface{}{
+			"id":             fmt.Sprintf("%s%04d", game.ID, p.Sequence),
+			"sequenceNumber": fmt.Sprint(p.Sequence),
+			"text":           p.Text,
+			"period":         map[string]interface{}{"number": p.Period},
+			"clock":          map[string]interface{}{"displayValue": p.Clock},
+			"team":           map[string]interface{}{"id": p.TeamESPNID},
+			"scoringPlay":    p.ScoreValue > 0,
+			"shootingPlay":   p.ScoreValue > 0,
+			"scoreValue":     p.ScoreValue,
+			"homeScore":      p.HomeScore,
+			"awayScore":      p.AwayScore,
+		}
+		if !p.Wallclock.IsZero() {
+			play["wallclock"] = p.Wallclock.UTC().Format("2006-01-02T15:04:05Z")
+		}
+		plays = append(plays, play)
+	}
+
+	return map[string]interface{}{
+		"header":   map[string]interface{}{"competitions": []interface{}{competitionJSON(game)}},
+		"boxscore": map[string]interface{}{"players": players, "teams": teams},
+		"plays":    plays,
+	}
+}
+
+func competitionJSON(game FixtureGame) map[string]interface{} {
+	state, completed := "pre", false
+	switch game.Status {
+	case "in_progress":
+		state = "in"
+	case "final":
+		state, completed = "post", true
+	}
+
+	competitors := []interface{}{}
+	for _, side := range []struct {
+		homeAway string
+		team     FixtureTeam
+	}{{"home", game.Home}, {"away", game.Away}} {
+		linescores := make([]interface{}, 0, len(side.team.Linescores))
+		for _, points := range side.team.Linescores {
+			linescores = append(linescores, map[string]interface{}{"value": points})
+		}
+		competitors = append(competitors, map[string]interface{}{
+			"homeAway":   side.homeAway,
+			"score":      fmt.Sprint(side.team.Score),
+			"team":       teamJSON(side.team),
+			"linescores": linescores,
+		})
+	}
+
+	return map[string]interface{}{
+		"id":   game.ID,
+		"date": game.Date.UTC().Format("2006-01-02T15:04Z"),
+		"season": map[string]interface{}{
+			"year": seasonEndYear(game),
+			"type": 2,
+		},
+		"status": map[string]interface{}{
+			"period":       game.Period,
+			"displayClock": game.Clock,
+			"type":         map[string]interface{}{"state": state, "completed": completed},
+		},
+		"venue":       map[string]interface{}{"fullName": game.Venue},
+		"attendance":  game.Attendance,
+		"broadcasts":  broadcastsJSON(game.Broadcasts),
+		"competitors": competitors,
+	}
+}
+
+// broadcastsJSON lists national networks as ESPN's scoreboard does
+func broadcastsJSON(networks []string) []interface{} {
+	if len(networks) == 0 {
+		return []interface{}{}
+	}
+	names := make([]interface{}, len(networks))
+	for i, name := range networks {
+		names[i] = name
+	}
+	return []interface{}{map[string]interface{}{"market": "national", "names": names}}
+}
+
+func teamJSON(team FixtureTeam) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           team.ESPNID,
+		"abbreviation": team.Abbreviation,
+		"displayName":  team.Name,
+	}
+}
+
+// boxScoreLabels are the player stat columns, in the order ESPN sends them
+var boxScoreLabels = []string{"MIN", "FG", "3PT", "FT", "OREB", "DREB", "REB", "AST", "STL", "BLK", "TO", "PF", "+/-", "PTS"}
+
+func boxScoreLine(p FixturePlayer) []string {
+	return []string{
+		fmt.Sprint(p.Minutes),
+		made(p.FGM, p.FGA),
+		made(p.TPM, p.TPA),
+		made(p.FTM, p.FTA),
+		fmt.Sprint(p.OffensiveRebounds),
+		fmt.Sprint(p.DefensiveRebounds),
+		fmt.Sprint(p.OffensiveRebounds + p.DefensiveRebounds),
+		fmt.Sprint(p.Assists),
+		fmt.Sprint(p.Steals),
+		fmt.Sprint(p.Blocks),
+		fmt.Sprint(p.Turnovers),
+		fmt.Sprint(p.Fouls),
+		"0",
+		fmt.Sprint(p.Points),
+	}
+}
+
+func teamStat(name, label, value string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "label": label, "displayValue": value}
+}
+
+func made(m, a int) string {
+	return fmt.Sprintf("%d-%d", m, a)
+}
+
+// seasonEndYear is the year ESPN labels a season with (2025 for 2024-25)
+func seasonEndYear(game FixtureGame) int {
+	date := game.Date.In(eastern())
+	if date.Month() >= 8 {
+		return date.Year() + 1
+	}
+	return date.Year()
+}
@@ -0,0 +1,152 @@
+// Package testsupport provides deterministic stand-ins for the ESPN API and
+// Google Sports pages, so ingestion, storage, REST, and WebSocket delivery can
+// be tested without network access or live games. Integration tests use
+// Database and RedisURL to reach scratch instances and skip without them.
+package testsupport
+
+import "time"
+
+// FixtureGame is one game as the mock ESPN API and Google page report it
+type FixtureGame struct {
+	ID         string // ESPN event ID
+	Date       time.Time
+	Status     string // scheduled, in_progress, or final
+	Period     int
+	Clock      string
+	Venue      string
+	Attendance int
+	Broadcasts []string // national networks, e.g. "ESPN"
+	Home       FixtureTeam
+	Away       FixtureTeam
+	Plays      []FixturePlay
+}
+
+// FixtureTeam is one side of a fixture game. ESPNID and Abbreviation must
+// match a seeded team for ingestion to resolve it.
+type FixtureTeam struct {
+	ESPNID       string
+	Abbreviation string
+	Name         string // display name, e.g. "Boston Celtics"
+	ShortName    string // nickname Google shows, e.g. "Celtics"
+	Score        int
+	Linescores   []int
+	Players      []FixturePlayer
+}
+
+// FixturePlayer is one box score line
+type FixturePlayer struct {
+	ESPNID            string
+	Name              string
+	Position          string
+	Starter           bool
+	Minutes           int
+	Points            int
+	OffensiveRebounds int
+	DefensiveRebounds int
+	Assists           int
+	Steals            int
+	Blocks            int
+	Turnovers         int
+	Fouls             int
+	FGM, FGA          int
+	TPM, TPA          int
+	FTM, FTA          int
+}
+
+// FixturePlay is one play-by-play entry
+type FixturePlay struct {
+	Sequence   int
+	Period     int
+	Clock      string
+	TeamESPNID string
+	Text       string
+	ScoreValue int
+	HomeScore  int
+	AwayScore  int
+	Wallclock  time.Time
+}
+
+// Team totals summed from the player lines
+func (t FixtureTeam) totals() FixturePlayer {
+	var sum FixturePlayer
+	for _, p := range t.Players {
+		sum.Minutes += p.Minutes
+		sum.Points += p.Points
+		sum.OffensiveRebounds += p.OffensiveRebounds
+		sum.DefensiveRebounds += p.DefensiveRebounds
+		sum.Assists += p.Assists
+		sum.Steals += p.Steals
+		sum.Blocks += p.Blocks
+		sum.Turnovers += p.Turnovers
+		sum.Fouls += p.Fouls
+		sum.FGM += p.FGM
+		sum.FGA += p.FGA
+		sum.TPM += p.TPM
+		sum.TPA += p.TPA
+		sum.FTM += p.FTM
+		sum.FTA += p.FTA
+	}
+	return sum
+}
+
+// DefaultGame is a Lakers at Celtics game in the third quarter of the 2024-25
+// season. Its IDs are outside ESPN's ranges so it never collides with real data.
+func DefaultGame() FixtureGame {
+	tip := time.Date(2025, 1, 16, 0, 30, 0, 0, time.UTC)
+	return FixtureGame{
+		ID:         "990000001",
+		Date:       tip,
+		Status:     "in_progress",
+		Period:     3,
+		Clock:      "5:12",
+		Venue:      "TD Garden",
+		Attendance: 19156,
+		Broadcasts: []string{"ESPN"},
+		Home: FixtureTeam{
+			ESPNID:       "2",
+			Abbreviation: "BOS",
+			Name:         "Boston Celtics",
+			ShortName:    "Celtics",
+			Score:        78,
+			Linescores:   []int{28, 26, 24},
+			Players: []FixturePlayer{
+				{ESPNID: "990000101", Name: "Fixture Forward", Position: "F", Starter: true, Minutes: 28,
+					Points: 30, OffensiveRebounds: 1, DefensiveRebounds: 6, Assists: 4, Steals: 1, Turnovers: 2, Fouls: 2,
+					FGM: 11, FGA: 20, TPM: 4, TPA: 9, FTM: 4, FTA: 5},
+				{ESPNID: "990000102", Name: "Fixture Guard", Position: "G", Starter: true, Minutes: 26,
+					Points: 26, DefensiveRebounds: 3, Assists: 7, Steals: 2, Blocks: 1, Turnovers: 3, Fouls: 3,
+					FGM: 9, FGA: 17, TPM: 5, TPA: 10, FTM: 3, FTA: 3},
+				{ESPNID: "990000103", Name: "Fixture Center", Position: "C", Minutes: 20,
+					Points: 22, OffensiveRebounds: 4, DefensiveRebounds: 5, Assists: 1, Blocks: 3, Turnovers: 1, Fouls: 4,
+					FGM: 9, FGA: 13, FTM: 4, FTA: 6},
+			},
+		},
+		Away: FixtureTeam{
+			ESPNID:       "13",
+			Abbreviation: "LAL",
+			Name:         "Los Angeles Lakers",
+			ShortName:    "Lakers",
+			Score:        71,
+			Linescores:   []int{25, 27, 19},
+			Players: []FixturePlayer{
+				{ESPNID: "990000201", Name: "Fixture Wing", Position: "F", Starter: true, Minutes: 29,
+					Points: 33, OffensiveRebounds: 2, DefensiveRebounds: 7, Assists: 6, Steals: 1, Turnovers: 4, Fouls: 1,
+					FGM: 12, FGA: 22, TPM: 2, TPA: 6, FTM: 7, FTA: 8},
+				{ESPNID: "990000202", Name: "Fixture Big", Position: "C", Starter: true, Minutes: 27,
+					Points: 24, OffensiveRebounds: 3, DefensiveRebounds: 8, Assists: 3, Blocks: 2, Turnovers: 2, Fouls: 3,
+					FGM: 10, FGA: 16, FTM: 4, FTA: 5},
+				{ESPNID: "990000203", Name: "Fixture Shooter", Position: "G", Minutes: 19,
+					Points: 14, DefensiveRebounds: 2, Assists: 2, Steals: 1, Turnovers: 1, Fouls: 2,
+					FGM: 5, FGA: 12, TPM: 4, TPA: 9},
+			},
+		},
+		Plays: []FixturePlay{
+			{Sequence: 1, Period: 1, Clock: "11:41", TeamESPNID: "2", Text: "Fixture Forward makes 26-foot three point jumper",
+				ScoreValue: 3, HomeScore: 3, AwayScore: 0, Wallclock: tip.Add(40 * time.Second)},
+			{Sequence: 2, Period: 1, Clock: "11:20", TeamESPNID: "13", Text: "Fixture Big makes two point shot",
+				ScoreValue: 2, HomeScore: 3, AwayScore: 2, Wallclock: tip.Add(65 * time.Second)},
+			{Sequence: 3, Period: 3, Clock: "5:30", TeamESPNID: "2", Text: "Fixture Center makes dunk",
+				ScoreValue: 2, HomeScore: 78, AwayScore: 71, Wallclock: tip.Add(98 * time.Minute)},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package testsupport
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GoogleServer serves a Google Sports results page with one score card per
+// fixture game, in the markup the scraper parses. Point a scraper at it with
+// google.Config{Mode: google.FetchHTTP, BaseURL: server.URL()}.
+type GoogleServer struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	games   []FixtureGame
+	queries []string
+}
+
+// NewGoogleServer starts a mock Google search page showing games
+func NewGoogleServer(games ...FixtureGame) *GoogleServer {
+	s := &GoogleServer{games: games}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// URL is the search endpoint to use in place of google.BaseURL
+func (s *GoogleServer) URL() string {
+	return s.server.URL + "/search"
+}
+
+// Close shuts the server down
+func (s *GoogleServer) Close() {
+	s.server.Close()
+}
+
+// SetGame replaces the fixture with the same ID, or adds it
+func (s *GoogleServer) SetGame(game FixtureGame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.games {
+		if s.games[i].ID == game.ID {
+			s.games[i] = game
+			return
+		}
+	}
+	s.games = append(s.games, game)
+}
+
+// Queries returns the search queries received so far
+func (s *GoogleServer) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+func (s *GoogleServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.queries = append(s.queries, r.URL.Query().Get("q"))
+	games := append([]FixtureGame(nil), s.games...)
+	s.mu.Unlock()
+
+	var page strings.Builder
+	page.WriteString("<!DOCTYPE html><html><head><title>nba games today - Google Search</title></head><body>")
+	for _, game := range games {
+		page.WriteString(googleCard(game))
+	}
+	page.WriteString("</body></html>")
+
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	fmt.Fprint(w, page.String())
+}
+
+// googleCard renders a game the way Google's match header does: away team
+// first, scores left (away) and right (home), and a status line such as
+// "Q3 - 5:12", "Final", or a date
+func googleCard(game FixtureGame) string {
+	status := "Final"
+	switch game.Status {
+	case "in_progress":
+		status = fmt.Sprintf("Q%d - %s", game.Period, game.Clock)
+		if game.Period > 4 {
+			status = fmt.Sprintf("OT - %s", game.Clock)
+		}
+	case "scheduled":
+		// A tip time would read as a game clock to the parser, so show the date
+		status = game.Date.In(eastern()).Format("Mon 1/2")
+	}
+
+	team := func(class string, t FixtureTeam) string {
+		return fmt.Sprintf(`<div class="%s"><div class="imso_mh__tm-nm"><span>%s</span></div></div>`,
+			class, html.EscapeString(t.ShortName))
+	}
+	return `<div class="imso_mh__lv-m-stl-cont">` +
+		team("imso_mh__first-tn-ed", game.Away) +
+		team("imso_mh__second-tn-ed", game.Home) +
+		fmt.Sprintf(`<div class="imso_mh__l-tm-sc">%d</div>`, game.Away.Score) +
+		fmt.Sprintf(`<div class="imso_mh__r-tm-sc">%d</div>`, game.Home.Score) +
+		fmt.Sprintf(`<div class="imso_mh__stts-l">%s</div>`, html.EscapeString(status)) +
+		`</div>`
+}
+
+// eastern is the time zone ESPN and Google date NBA games in
+func eastern() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,322 @@
+package testsupport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+
+	"github.com/fortuna/minerva/internal/api/rest"
+	"github.com/fortuna/minerva/internal/api/websocket"
+	"github.com/fortuna/minerva/internal/backfill"
+	"github.com/fortuna/minerva/internal/cache"
+	"github.com/fortuna/minerva/internal/ingest/espn"
+	"github.com/fortuna/minerva/internal/ingest/google"
+	"github.com/fortuna/minerva/internal/publisher"
+	"github.com/fortuna/minerva/internal/reconciliation"
+	"github.com/fortuna/minerva/internal/service"
+	"github.com/fortuna/minerva/internal/store"
+	"github.com/fortuna/minerva/internal/store/repository"
+)
+
+// pipelineConfig is what TestPipeline writes to and publishes through
+type pipelineConfig struct {
+	DB        *store.Database
+	Cache     *cache.RedisCache // Redis the WebSocket feed reads from
+	Publisher *publisher.RedisPublisher
+	Game      FixtureGame
+	Season    string        // season_year the game belongs to
+	Timeout   time.Duration // how long to wait for the WebSocket update
+}
+
+// pipelineRun carries what each stage hands to the next
+type pipelineRun struct {
+	config       pipelineConfig
+	espn         *ESPNServer
+	google       *GoogleServer
+	stored       *store.Game
+	live         []google.LiveGame
+	reconciled   *store.Game
+	reconciledAt time.Time
+}
+
+// TestPipeline ingests a fixture game from mock ESPN and Google servers,
+// reconciles it, reads it back through the REST API, and waits for its live
+// update on a WebSocket, all in-process. The fixture game, its players, and its
+// stats are written to the test database, which needs teams and seasons seeded.
+func TestPipeline(t *testing.T) {
+	db := Database(t)
+	redisURL := RedisURL(t)
+
+	redisCache, err := cache.NewRedisCache(redisURL)
+	if err != nil {
+		t.Fatalf("connect Redis: %v", err)
+	}
+	defer redisCache.Close()
+
+	redisPublisher, err := publisher.NewRedisPublisher(redisURL)
+	if err != nil {
+		t.Fatalf("connect Redis publisher: %v", err)
+	}
+	defer redisPublisher.Close()
+	redisPublisher.SetValidation(true)
+
+	config := pipelineConfig{
+		DB:        db,
+		Cache:     redisCache,
+		Publisher: redisPublisher,
+		Game:      DefaultGame(),
+		Season:    "2024-25",
+		Timeout:   15 * time.Second,
+	}
+	if season := os.Getenv("TEST_SEASON"); season != "" {
+		config.Season = season
+	}
+
+	run := &pipelineRun{
+		config: config,
+		espn:   NewESPNServer(config.Game),
+		google: NewGoogleServer(config.Game),
+	}
+	defer run.espn.Close()
+	defer run.google.Close()
+
+	ctx := context.Background()
+	for _, stage := range []struct {
+		name string
+		fn   func(context.Context) (string, error)
+	}{
+		{"espn ingest", run.ingestESPN},
+		{"stored stats", run.checkStored},
+		{"google scrape", run.scrapeGoogle},
+		{"reconcile", run.reconcile},
+		{"rest api", run.checkREST},
+		{"websocket", run.checkWebSocket},
+	} {
+		start := time.Now()
+		detail, err := stage.fn(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", stage.name, err)
+		}
+		t.Logf("%-14s %s (%v)", stage.name, detail, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+func (r *pipelineRun) ingestESPN(ctx context.Context) (string, error) {
+	var seasonID int
+	query := `SELECT season_id FROM seasons WHERE season_year = $1 AND sport = 'basketball_nba' AND league = 'nba' LIMIT 1`
+	if err := r.config.DB.DB().QueryRowContext(ctx, query, r.config.Season).Scan(&seasonID); err != nil {
+		return "", fmt.Errorf("season '%s' not found in database: %w", r.config.Season, err)
+	}
+
+	fixture := r.config.Game
+	games, _, err := espn.NewIngesterWithBaseURL(r.config.DB, r.espn.URL()).
+		IngestGamesByDate(ctx, seasonID, fixture.Date.In(eastern()))
+	if err != nil {
+		return "", err
+	}
+	for _, game := range games {
+		if game.ExternalID == fixture.ID {
+			r.stored = game
+		}
+	}
+	if r.stored == nil {
+		return "", fmt.Errorf("game %s was not stored (are %s and %s seeded?)",
+			fixture.ID, fixture.Home.Abbreviation, fixture.Away.Abbreviation)
+	}
+	if err := checkScore(r.stored, fixture); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("stored game %d from %d ESPN requests", r.stored.GameID, len(r.espn.Requests())), nil
+}
+
+func (r *pipelineRun) checkStored(ctx context.Context) (string, error) {
+	ctx = store.WithPrimary(ctx)
+	fixture := r.config.Game
+
+	lines, err := repository.NewStatsRepository(r.config.DB).GetGameBoxScore(ctx, fixture.ID)
+	if err != nil {
+		return "", err
+	}
+	if want := len(fixture.Home.Players) + len(fixture.Away.Players); len(lines) != want {
+		return "", fmt.Errorf("stored %d player stat lines, want %d", len(lines), want)
+	}
+
+	plays, err := repository.NewPlayRepository(r.config.DB).GetByGame(ctx, r.stored.GameID, false)
+	if err != nil {
+		return "", err
+	}
+	if len(plays) != len(fixture.Plays) {
+		return "", fmt.Errorf("stored %d plays, want %d", len(plays), len(fixture.Plays))
+	}
+	return fmt.Sprintf("%d player stat lines, %d plays", len(lines), len(plays)), nil
+}
+
+func (r *pipelineRun) scrapeGoogle(ctx context.Context) (string, error) {
+	ingester, err := google.NewIngester(nil, r.config.DB, google.Config{Mode: google.FetchHTTP, BaseURL: r.google.URL()})
+	if err != nil {
+		return "", err
+	}
+	games, err := ingester.IngestLiveGames(google.WithCacheBypass(ctx), r.config.Season)
+	if err != nil {
+		return "", err
+	}
+	if len(games) != 1 {
+		return "", fmt.Errorf("parsed %d games from the Google page, want 1", len(games))
+	}
+	fixture := r.config.Game
+	if games[0].HomeScore != fixture.Home.Score || games[0].AwayScore != fixture.Away.Score {
+		return "", fmt.Errorf("parsed score %d-%d, want %d-%d",
+			games[0].HomeScore, games[0].AwayScore, fixture.Home.Score, fixture.Away.Score)
+	}
+	r.live = games
+	return fmt.Sprintf("%s at %s, %s", games[0].AwayTeam, games[0].HomeTeam, games[0].GameStatus), nil
+}
+
+func (r *pipelineRun) reconcile(ctx context.Context) (string, error) {
+	resolver, err := repository.NewTeamRepository(r.config.DB).GetResolver(ctx, store.LeagueNBA)
+	if err != nil {
+		return "", err
+	}
+	games, err := reconciliation.NewMatcher(resolver).MatchAndReconcileAll(
+		[]*store.Game{r.stored}, r.live, reconciliation.NewEngine(reconciliation.SmartMerge))
+	if err != nil {
+		return "", err
+	}
+	if len(games) != 1 {
+		return "", fmt.Errorf("reconciled %d games, want 1", len(games))
+	}
+	if err := checkScore(games[0], r.config.Game); err != nil {
+		return "", err
+	}
+	r.reconciled = games[0]
+	r.reconciledAt = time.Now()
+	return fmt.Sprintf("status %s", games[0].Status), nil
+}
+
+func (r *pipelineRun) checkREST(ctx context.Context) (string, error) {
+	api := rest.NewServer("0", r.config.DB, backfill.NewService(r.config.DB, r.espn.URL(), nil), nil)
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	fixture := r.config.Game
+	var summary service.GameSummary
+	if err := getJSON(ctx, srv.URL+"/api/v1/games/"+fixture.ID+"?envelope=false", &summary); err != nil {
+		return "", err
+	}
+	if summary.Game == nil {
+		return "", fmt.Errorf("game response has no game")
+	}
+	if err := checkScore(summary.Game, fixture); err != nil {
+		return "", err
+	}
+
+	var box service.BoxScore
+	if err := getJSON(ctx, srv.URL+"/api/v1/games/"+fixture.ID+"/boxscore?envelope=false", &box); err != nil {
+		return "", err
+	}
+	if len(box.HomeTeamStats) != len(fixture.Home.Players) || len(box.AwayTeamStats) != len(fixture.Away.Players) {
+		return "", fmt.Errorf("box score has %d home and %d away lines, want %d and %d",
+			len(box.HomeTeamStats), len(box.AwayTeamStats), len(fixture.Home.Players), len(fixture.Away.Players))
+	}
+	return "game and box score served", nil
+}
+
+func (r *pipelineRun) checkWebSocket(ctx context.Context) (string, error) {
+	ws := websocket.NewServer(r.config.DB, r.config.Cache, r.config.Publisher)
+	ws.Start()
+	srv := httptest.NewServer(ws.Handler())
+	defer srv.Close()
+	defer ws.Shutdown(context.Background())
+
+	ctx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	defer cancel()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws/games/live"
+	conn, _, err := gorillaws.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("dial %s: %w", url, err)
+	}
+	defer conn.Close()
+
+	marker := []byte(fmt.Sprintf(`"external_id":%q`, r.reconciled.ExternalID))
+	received := make(chan error, 1)
+	go func() {
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				received <- err
+				return
+			}
+			if bytes.Contains(msg, marker) {
+				received <- nil
+				return
+			}
+		}
+	}()
+
+	// The feed only reads entries added after it starts, so publish until one arrives
+	update := publisher.NewLiveGameUpdate(r.reconciled, publisher.NewClockTracker().Observe(r.reconciled, time.Now()))
+	update.StampLatency(publisher.LatencyStamps{
+		Source:       "google",
+		FetchedAt:    r.live[0].FetchedAt,
+		ReconciledAt: r.reconciledAt,
+	}, time.Now())
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	published := 0
+	for {
+		if err := r.config.Publisher.PublishLiveGameUpdate(ctx, update); err != nil {
+			return "", fmt.Errorf("publish update: %w", err)
+		}
+		published++
+
+		select {
+		case err := <-received:
+			if err != nil {
+				return "", fmt.Errorf("read update: %w", err)
+			}
+			return fmt.Sprintf("update delivered after %d publishes, %v after the Google scrape",
+				published, time.Since(r.live[0].FetchedAt).Round(time.Millisecond)), nil
+		case <-ctx.Done():
+			return "", fmt.Errorf("no update for game %s within %v", r.reconciled.ExternalID, r.config.Timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkScore compares a game's score with the fixture's
+func checkScore(game *store.Game, fixture FixtureGame) error {
+	if int(game.HomeScore.Int32) != fixture.Home.Score || int(game.AwayScore.Int32) != fixture.Away.Score {
+		return fmt.Errorf("game %s score is %d-%d, want %d-%d", game.ExternalID,
+			game.HomeScore.Int32, game.AwayScore.Int32, fixture.Home.Score, fixture.Away.Score)
+	}
+	return nil
+}
+
+func getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode %s: %w", url, err)
+	}
+	return nil
+}
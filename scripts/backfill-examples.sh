@@ -11,24 +11,24 @@ echo ""
 
 # Example 1: Backfill entire 2024-25 season
 echo "Example 1: Backfill entire 2024-25 season"
-echo "go run ./cmd/backfill --season 2024-25"
+echo "go run ./cmd/minerva backfill --season 2024-25"
 echo ""
 
 # Example 2: Backfill date range (last 7 days)
 echo "Example 2: Backfill last 7 days"
 YESTERDAY=$(date -v-1d +%Y-%m-%d)
 WEEK_AGO=$(date -v-7d +%Y-%m-%d)
-echo "go run ./cmd/backfill --start $WEEK_AGO --end $YESTERDAY"
+echo "go run ./cmd/minerva backfill --start $WEEK_AGO --end $YESTERDAY"
 echo ""
 
 # Example 3: Backfill specific game
 echo "Example 3: Backfill specific game (use actual ESPN game ID)"
-echo "go run ./cmd/backfill --game 401584894"
+echo "go run ./cmd/minerva backfill --game 401584894"
 echo ""
 
 # Example 4: Dry run for 2023-24 season
 echo "Example 4: Dry run for 2023-24 season (preview only)"
-echo "go run ./cmd/backfill --season 2023-24 --dry-run"
+echo "go run ./cmd/minerva backfill --season 2023-24 --dry-run"
 echo ""
 
 # Example 5: Backfill via API
@@ -50,7 +50,7 @@ echo "2022-23: October 2022 - June 2023"
 echo ""
 
 echo "=== Usage ==="
-echo "CLI Tool: go run ./cmd/backfill [options]"
+echo "CLI Tool: go run ./cmd/minerva backfill [options]"
 echo "  --season    Season to backfill (e.g., 2024-25)"
 echo "  --start     Start date (YYYY-MM-DD)"
 echo "  --end       End date (YYYY-MM-DD)"